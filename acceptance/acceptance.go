@@ -0,0 +1,187 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package acceptance
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/digest"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/setup"
+)
+
+// DefaultCatalogue is the name of the acceptance catalogue file in the
+// emulator's configuration directory.
+const DefaultCatalogue = "acceptance.json"
+
+// the random seed used for every run of a test ROM. acceptance tests should
+// not depend on the initial state of the hardware but a fixed seed means that
+// the result is repeatable if they do.
+const acceptanceSeed = 1
+
+// Test is a single entry in the acceptance catalogue.
+type Test struct {
+	// the name of the test is unique in the catalogue
+	Name string `json:"name"`
+
+	// tests are grouped by category in the scoreboard. for example, "CPU",
+	// "TIA" or "RIOT"
+	Category string `json:"category"`
+
+	// the hash of the test ROM as generated by the cartridgeloader package
+	Hash string `json:"hash"`
+
+	Mapping string `json:"mapping"`
+	TVtype  string `json:"tv"`
+	Frames  int    `json:"frames"`
+
+	// the video digest after the number of frames has been run
+	Digest string `json:"digest"`
+
+	Notes string `json:"notes,omitempty"`
+}
+
+// Catalogue is the list of acceptance tests.
+type Catalogue struct {
+	Tests []Test `json:"tests"`
+}
+
+// DefaultPath returns the path to the DefaultCatalogue file.
+func DefaultPath() (string, error) {
+	pth, err := paths.ResourcePath("", DefaultCatalogue)
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+	return pth, nil
+}
+
+// Load a catalogue from the named file.
+func Load(filename string) (*Catalogue, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, curated.Errorf("acceptance: %v", err)
+	}
+
+	cat := &Catalogue{}
+	err = json.Unmarshal(b, cat)
+	if err != nil {
+		return nil, curated.Errorf("acceptance: %v", err)
+	}
+
+	return cat, nil
+}
+
+// Save the catalogue as JSON to the named file.
+func (cat *Catalogue) Save(filename string) error {
+	b, err := json.MarshalIndent(cat, "", "  ")
+	if err != nil {
+		return curated.Errorf("acceptance: %v", err)
+	}
+
+	err = ioutil.WriteFile(filename, b, 0644)
+	if err != nil {
+		return curated.Errorf("acceptance: %v", err)
+	}
+
+	return nil
+}
+
+// Add a test to the catalogue. A test with the same name is replaced.
+func (cat *Catalogue) Add(test Test) {
+	for i := range cat.Tests {
+		if cat.Tests[i].Name == test.Name {
+			cat.Tests[i] = test
+			return
+		}
+	}
+	cat.Tests = append(cat.Tests, test)
+}
+
+// Record runs the test ROM and returns a Test with the expectation filled in.
+// The screen produced by the emulation should be compared with the output of
+// real hardware before the test is added to the catalogue.
+func Record(name string, category string, cartload cartridgeloader.Loader, tvType string, frames int) (Test, error) {
+	if frames <= 0 {
+		return Test{}, curated.Errorf("acceptance: number of frames must be greater than zero")
+	}
+
+	err := cartload.Load()
+	if err != nil {
+		return Test{}, curated.Errorf("acceptance: %v", err)
+	}
+
+	dig, err := run(cartload, tvType, frames)
+	if err != nil {
+		return Test{}, err
+	}
+
+	return Test{
+		Name:     name,
+		Category: category,
+		Hash:     fmt.Sprintf("%x", sha1.Sum(cartload.Data)),
+		Mapping:  cartload.Mapping,
+		TVtype:   strings.ToUpper(tvType),
+		Frames:   frames,
+		Digest:   dig,
+	}, nil
+}
+
+// run the cartridge for the number of frames and return the video digest.
+func run(cartload cartridgeloader.Loader, tvType string, frames int) (string, error) {
+	tv, err := television.NewTelevision(tvType)
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+	defer tv.End()
+
+	dig, err := digest.NewVideo(tv)
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+
+	// we want the machine in a known state. the easiest way to do this is to
+	// reset the hardware preferences
+	err = vcs.Prefs.Reset()
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+	vcs.Prefs.Reseed(acceptanceSeed)
+
+	err = setup.AttachCartridge(vcs, cartload)
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+
+	err = vcs.RunForFrameCount(frames, nil)
+	if err != nil {
+		return "", curated.Errorf("acceptance: %v", err)
+	}
+
+	return dig.Hash(), nil
+}
@@ -0,0 +1,130 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package acceptance_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/acceptance"
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+)
+
+// writeROM creates a 4k test ROM that runs a loop of NOP instructions.
+func writeROM(t *testing.T, dir string) string {
+	t.Helper()
+
+	data, err := assembler.Cartridge("sei : cld : nop : nop : nop : nop : jmp $f002")
+	if err != nil {
+		t.Fatalf("unexpected error assembling test ROM: %v", err)
+	}
+
+	filename := filepath.Join(dir, "nop.bin")
+	err = ioutil.WriteFile(filename, data, 0644)
+	if err != nil {
+		t.Fatalf("unexpected error writing test ROM: %v", err)
+	}
+
+	return filename
+}
+
+func TestAcceptance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acceptance_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	rom := writeROM(t, dir)
+
+	pass, err := acceptance.Record("nop", "CPU", cartridgeloader.NewLoader(rom, "AUTO"), "NTSC", 5)
+	if err != nil {
+		t.Fatalf("unexpected error recording test: %v", err)
+	}
+	if pass.Digest == "" || pass.Hash == "" {
+		t.Fatalf("recorded test is incomplete")
+	}
+
+	_, err = acceptance.Record("nop", "CPU", cartridgeloader.NewLoader(rom, "AUTO"), "NTSC", 0)
+	if err == nil {
+		t.Errorf("expected error when recording test with no frames")
+	}
+
+	fail := pass
+	fail.Name = "nop fail"
+	fail.Category = "TIA"
+	fail.Digest = "0000"
+
+	missing := pass
+	missing.Name = "missing"
+	missing.Hash = "0000"
+
+	cat := &acceptance.Catalogue{}
+	cat.Add(pass)
+	cat.Add(fail)
+	cat.Add(missing)
+
+	// adding a test with the same name replaces the existing test
+	cat.Add(pass)
+	if len(cat.Tests) != 3 {
+		t.Fatalf("unexpected number of tests in catalogue: %d (expected 3)", len(cat.Tests))
+	}
+
+	// catalogue survives a save and load
+	catFile := filepath.Join(dir, acceptance.DefaultCatalogue)
+	err = cat.Save(catFile)
+	if err != nil {
+		t.Fatalf("unexpected error saving catalogue: %v", err)
+	}
+	cat, err = acceptance.Load(catFile)
+	if err != nil {
+		t.Fatalf("unexpected error loading catalogue: %v", err)
+	}
+
+	var output bytes.Buffer
+	sb, err := cat.Run(&output, dir)
+	if err != nil {
+		t.Fatalf("unexpected error running catalogue: %v", err)
+	}
+
+	if sb.Count(acceptance.ResultPass) != 1 {
+		t.Errorf("unexpected number of passes: %d (expected 1)", sb.Count(acceptance.ResultPass))
+	}
+	if sb.Count(acceptance.ResultFail) != 1 {
+		t.Errorf("unexpected number of fails: %d (expected 1)", sb.Count(acceptance.ResultFail))
+	}
+	if sb.Count(acceptance.ResultMissing) != 1 {
+		t.Errorf("unexpected number of missing tests: %d (expected 1)", sb.Count(acceptance.ResultMissing))
+	}
+	if sb.Scores[1].Detail != pass.Digest {
+		t.Errorf("failed test should report the digest produced by the emulation")
+	}
+
+	output.Reset()
+	sb.Report(&output)
+	report := output.String()
+	if !strings.Contains(report, "CPU          1/1") || !strings.Contains(report, "TIA          0/1") {
+		t.Errorf("unexpected category scores in report:\n%s", report)
+	}
+	if !strings.Contains(report, "passed 1 of 2 tests (1 test ROMs missing)") {
+		t.Errorf("unexpected summary in report:\n%s", report)
+	}
+}
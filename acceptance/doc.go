@@ -0,0 +1,37 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package acceptance runs hardware test ROMs and compares the output of the
+// emulation with known-good expectations. The result is a scoreboard showing
+// which accuracy tests pass and which fail.
+//
+// Test ROMs are not distributed with the emulator. Instead, the catalogue
+// records the hash of each test ROM and the ROMs are found by scanning a
+// directory of the user's choosing. Test ROMs in the catalogue that can not
+// be found are reported as missing and do not count towards the score.
+//
+// Expectations are video digests taken after a set number of frames. An
+// expectation is recorded with the Record() function and should only be added
+// to the catalogue once the screen has been compared with the output of real
+// hardware.
+//
+// The catalogue is saved as JSON. By default it is saved to the
+// DefaultCatalogue file in the emulator's configuration directory.
+//
+// Unlike the regression package, which checks that the output of the
+// emulation has not changed, the acceptance package checks that the output of
+// the emulation is correct. A test that fails is an accuracy problem with the
+// emulation and not a regression.
+package acceptance
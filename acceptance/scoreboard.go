@@ -0,0 +1,189 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package acceptance
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/catalogue"
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Result is the outcome of a single acceptance test.
+type Result int
+
+// List of valid Result values.
+const (
+	ResultPass Result = iota
+	ResultFail
+	ResultMissing
+	ResultError
+)
+
+func (r Result) String() string {
+	switch r {
+	case ResultPass:
+		return "pass"
+	case ResultFail:
+		return "FAIL"
+	case ResultMissing:
+		return "missing"
+	case ResultError:
+		return "ERROR"
+	}
+	return "unknown result"
+}
+
+// Score is the result of running a single Test.
+type Score struct {
+	Test   Test
+	Result Result
+
+	// the test ROM used for the test. empty if the ROM is missing
+	Filename string
+
+	// the digest produced by the emulation in the case of ResultFail or the
+	// error message in the case of ResultError
+	Detail string
+}
+
+func (sc Score) String() string {
+	s := fmt.Sprintf("%-7s [%s] %s", sc.Result, sc.Test.Category, sc.Test.Name)
+	switch sc.Result {
+	case ResultFail:
+		s = fmt.Sprintf("%s (digest %s)", s, sc.Detail)
+	case ResultError:
+		s = fmt.Sprintf("%s (%s)", s, sc.Detail)
+	}
+	return s
+}
+
+// Scoreboard is the result of running every Test in a Catalogue.
+type Scoreboard struct {
+	Scores []Score
+}
+
+// Run every test in the catalogue using the test ROMs found in romPath.
+// Subdirectories of romPath are also searched. Each score is written to
+// output as soon as the test has completed.
+//
+// A test that fails or that can not be run is recorded in the scoreboard and
+// does not cause an error to be returned.
+func (cat *Catalogue) Run(output io.Writer, romPath string) (*Scoreboard, error) {
+	roms, err := catalogue.Scan(romPath, true)
+	if err != nil {
+		return nil, curated.Errorf("acceptance: %v", err)
+	}
+
+	// test ROMs indexed by hash. if there are duplicate ROMs then the first
+	// one is used
+	hashes := make(map[string]string)
+	for _, e := range roms.Entries {
+		if _, ok := hashes[e.Hash]; !ok {
+			hashes[e.Hash] = e.Filename
+		}
+	}
+
+	sb := &Scoreboard{}
+
+	for _, t := range cat.Tests {
+		sc := Score{
+			Test:     t,
+			Filename: hashes[t.Hash],
+		}
+
+		if sc.Filename == "" {
+			sc.Result = ResultMissing
+		} else {
+			cartload := cartridgeloader.NewLoader(sc.Filename, t.Mapping)
+			dig, err := run(cartload, t.TVtype, t.Frames)
+			if err != nil {
+				sc.Result = ResultError
+				sc.Detail = err.Error()
+			} else if dig != t.Digest {
+				sc.Result = ResultFail
+				sc.Detail = dig
+			}
+		}
+
+		sb.Scores = append(sb.Scores, sc)
+		io.WriteString(output, fmt.Sprintf("%s\n", sc))
+	}
+
+	return sb, nil
+}
+
+// Count returns the number of scores with the specified result.
+func (sb *Scoreboard) Count(result Result) int {
+	n := 0
+	for _, sc := range sb.Scores {
+		if sc.Result == result {
+			n++
+		}
+	}
+	return n
+}
+
+// Report writes a summary of the scoreboard to output, with the number of
+// passes for each category of test. Missing tests do not count towards the
+// score.
+func (sb *Scoreboard) Report(output io.Writer) {
+	type tally struct {
+		pass  int
+		total int
+	}
+
+	categories := make(map[string]*tally)
+	for _, sc := range sb.Scores {
+		if sc.Result == ResultMissing {
+			continue
+		}
+		t, ok := categories[sc.Test.Category]
+		if !ok {
+			t = &tally{}
+			categories[sc.Test.Category] = t
+		}
+		t.total++
+		if sc.Result == ResultPass {
+			t.pass++
+		}
+	}
+
+	names := make([]string, 0, len(categories))
+	for c := range categories {
+		names = append(names, c)
+	}
+	sort.Strings(names)
+
+	s := strings.Builder{}
+	for _, c := range names {
+		t := categories[c]
+		s.WriteString(fmt.Sprintf("%-12s %d/%d\n", c, t.pass, t.total))
+	}
+
+	total := len(sb.Scores) - sb.Count(ResultMissing)
+	s.WriteString(fmt.Sprintf("passed %d of %d tests", sb.Count(ResultPass), total))
+	if n := sb.Count(ResultMissing); n > 0 {
+		s.WriteString(fmt.Sprintf(" (%d test ROMs missing)", n))
+	}
+	s.WriteString("\n")
+
+	io.WriteString(output, s.String())
+}
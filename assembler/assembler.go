@@ -0,0 +1,291 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package assembler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+)
+
+// Lookup resolves a symbol to an address or value. The boolean return value
+// should be false if the symbol is not recognised.
+type Lookup func(symbol string) (uint16, bool)
+
+// Line is a single assembled statement.
+type Line struct {
+	Address uint16
+	Bytes   []uint8
+	Source  string
+}
+
+func (l Line) String() string {
+	b := make([]string, len(l.Bytes))
+	for i := range l.Bytes {
+		b[i] = fmt.Sprintf("%02x", l.Bytes[i])
+	}
+	return fmt.Sprintf("%#04x  %-8s  %s", l.Address, strings.Join(b, " "), l.Source)
+}
+
+// key for the opcodes map.
+type opcodeKey struct {
+	mnemonic string
+	mode     instructions.AddressingMode
+}
+
+// opcodes indexed by mnemonic and addressing mode. where more than one opcode
+// has the same mnemonic and addressing mode (this only happens with
+// undocumented instructions) the first opcode in the definitions table is
+// used.
+var opcodes map[opcodeKey]uint8
+
+// every recognised mnemonic.
+var mnemonics map[string]bool
+
+func init() {
+	opcodes = make(map[opcodeKey]uint8)
+	mnemonics = make(map[string]bool)
+	for _, defn := range instructions.GetDefinitions() {
+		if defn == nil || defn.Mnemonic == "" {
+			continue
+		}
+		mnemonics[defn.Mnemonic] = true
+		k := opcodeKey{mnemonic: defn.Mnemonic, mode: defn.AddressingMode}
+		if _, ok := opcodes[k]; !ok {
+			opcodes[k] = defn.OpCode
+		}
+	}
+}
+
+// Assemble source code, which may consist of more than one statement, for the
+// specified origin address.
+func Assemble(origin uint16, source string, lookup Lookup) ([]Line, error) {
+	var lines []Line
+
+	addr := origin
+	for _, s := range strings.FieldsFunc(source, func(r rune) bool { return r == ':' || r == '\n' }) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		b, err := assembleStatement(addr, s, lookup)
+		if err != nil {
+			return nil, curated.Errorf("assembler: %v (%s)", err, s)
+		}
+
+		lines = append(lines, Line{Address: addr, Bytes: b, Source: s})
+		addr += uint16(len(b))
+	}
+
+	if len(lines) == 0 {
+		return nil, curated.Errorf("assembler: nothing to assemble")
+	}
+
+	return lines, nil
+}
+
+// assembleStatement assembles a single statement for the specified address.
+func assembleStatement(addr uint16, statement string, lookup Lookup) ([]uint8, error) {
+	fields := strings.SplitN(statement, " ", 2)
+
+	// documented mnemonics are upper case and undocumented mnemonics are
+	// lower case. if the mnemonic is written in mixed case then assume the
+	// documented instruction is wanted
+	mnemonic := fields[0]
+	if mnemonic != strings.ToLower(mnemonic) {
+		mnemonic = strings.ToUpper(mnemonic)
+	}
+
+	if !mnemonics[mnemonic] && !mnemonics[strings.ToUpper(mnemonic)] {
+		return nil, fmt.Errorf("unrecognised mnemonic")
+	}
+
+	operand := ""
+	if len(fields) > 1 {
+		operand = strings.ReplaceAll(fields[1], " ", "")
+	}
+
+	opcode := func(mode instructions.AddressingMode) (uint8, bool) {
+		op, ok := opcodes[opcodeKey{mnemonic: mnemonic, mode: mode}]
+		if !ok && mnemonic == strings.ToLower(mnemonic) {
+			op, ok = opcodes[opcodeKey{mnemonic: strings.ToUpper(mnemonic), mode: mode}]
+		}
+		return op, ok
+	}
+
+	// implied (and accumulator) addressing
+	if operand == "" || strings.ToUpper(operand) == "A" {
+		if op, ok := opcode(instructions.Implied); ok {
+			return []uint8{op}, nil
+		}
+		return nil, fmt.Errorf("operand required")
+	}
+
+	// immediate addressing
+	if operand[0] == '#' {
+		v, _, err := value(operand[1:], lookup)
+		if err != nil {
+			return nil, err
+		}
+		if v > 0xff {
+			return nil, fmt.Errorf("immediate value too large")
+		}
+		if op, ok := opcode(instructions.Immediate); ok {
+			return []uint8{op, uint8(v)}, nil
+		}
+		return nil, fmt.Errorf("immediate addressing not supported")
+	}
+
+	// relative addressing is used only by branch instructions and takes
+	// precedence over every other addressing mode
+	if op, ok := opcode(instructions.Relative); ok {
+		v, _, err := value(operand, lookup)
+		if err != nil {
+			return nil, err
+		}
+		offset := int(v) - int(addr+2)
+		if offset < -128 || offset > 127 {
+			return nil, fmt.Errorf("branch target out of range")
+		}
+		return []uint8{op, uint8(offset)}, nil
+	}
+
+	// indirect addressing modes
+	upper := strings.ToUpper(operand)
+	if operand[0] == '(' {
+		var mode instructions.AddressingMode
+		var inner string
+
+		switch {
+		case strings.HasSuffix(upper, ",X)"):
+			mode = instructions.IndexedIndirect
+			inner = operand[1 : len(operand)-3]
+		case strings.HasSuffix(upper, "),Y"):
+			mode = instructions.IndirectIndexed
+			inner = operand[1 : len(operand)-3]
+		case strings.HasSuffix(upper, ")"):
+			mode = instructions.Indirect
+			inner = operand[1 : len(operand)-1]
+		default:
+			return nil, fmt.Errorf("malformed indirect operand")
+		}
+
+		v, _, err := value(inner, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		op, ok := opcode(mode)
+		if !ok {
+			return nil, fmt.Errorf("indirect addressing not supported")
+		}
+
+		if mode == instructions.Indirect {
+			return []uint8{op, uint8(v), uint8(v >> 8)}, nil
+		}
+
+		if v > 0xff {
+			return nil, fmt.Errorf("indirect address must be in zero page")
+		}
+		return []uint8{op, uint8(v)}, nil
+	}
+
+	// absolute and zero page addressing, with or without indexing
+	zp := instructions.ZeroPage
+	abs := instructions.Absolute
+	switch {
+	case strings.HasSuffix(upper, ",X"):
+		zp = instructions.ZeroPageIndexedX
+		abs = instructions.AbsoluteIndexedX
+		operand = operand[:len(operand)-2]
+	case strings.HasSuffix(upper, ",Y"):
+		zp = instructions.ZeroPageIndexedY
+		abs = instructions.AbsoluteIndexedY
+		operand = operand[:len(operand)-2]
+	}
+
+	v, wide, err := value(operand, lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	if v <= 0xff && !wide {
+		if op, ok := opcode(zp); ok {
+			return []uint8{op, uint8(v)}, nil
+		}
+	}
+
+	if op, ok := opcode(abs); ok {
+		return []uint8{op, uint8(v), uint8(v >> 8)}, nil
+	}
+
+	return nil, fmt.Errorf("addressing mode not supported")
+}
+
+// value converts a number or symbol to a 16 bit value. the wide return value
+// is true if the value is a hexadecimal number written with more than two
+// digits.
+func value(s string, lookup Lookup) (uint16, bool, error) {
+	if s == "" {
+		return 0, false, fmt.Errorf("missing value")
+	}
+
+	// low/high byte selection
+	switch s[0] {
+	case '<':
+		v, _, err := value(s[1:], lookup)
+		return v & 0xff, false, err
+	case '>':
+		v, _, err := value(s[1:], lookup)
+		return v >> 8, false, err
+	}
+
+	var n string
+	var base int
+
+	switch {
+	case s[0] == '$':
+		n = s[1:]
+		base = 16
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		n = s[2:]
+		base = 16
+	case s[0] == '%':
+		n = s[1:]
+		base = 2
+	case s[0] >= '0' && s[0] <= '9':
+		n = s
+		base = 10
+	default:
+		if lookup != nil {
+			if v, ok := lookup(s); ok {
+				return v, false, nil
+			}
+		}
+		return 0, false, fmt.Errorf("unrecognised symbol")
+	}
+
+	v, err := strconv.ParseUint(n, base, 16)
+	if err != nil {
+		return 0, false, fmt.Errorf("value must be a 16 bit number")
+	}
+
+	return uint16(v), base == 16 && len(n) > 2, nil
+}
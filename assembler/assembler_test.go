@@ -0,0 +1,141 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package assembler_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+)
+
+func lookup(symbol string) (uint16, bool) {
+	switch symbol {
+	case "GRP0":
+		return 0x1b, true
+	case "START":
+		return 0xf000, true
+	}
+	return 0, false
+}
+
+func assemble(t *testing.T, origin uint16, source string, expected ...uint8) {
+	t.Helper()
+
+	lines, err := assembler.Assemble(origin, source, lookup)
+	if err != nil {
+		t.Fatalf("%s: %v", source, err)
+	}
+
+	var b []uint8
+	for _, l := range lines {
+		b = append(b, l.Bytes...)
+	}
+
+	if !bytes.Equal(b, expected) {
+		t.Errorf("%s: assembled to % 02x, expected % 02x", source, b, expected)
+	}
+}
+
+func fail(t *testing.T, origin uint16, source string) {
+	t.Helper()
+
+	_, err := assembler.Assemble(origin, source, lookup)
+	if err == nil {
+		t.Errorf("%s: expected error", source)
+	}
+}
+
+func TestAddressingModes(t *testing.T) {
+	assemble(t, 0xf000, "NOP", 0xea)
+	assemble(t, 0xf000, "ASL A", 0x0a)
+	assemble(t, 0xf000, "LDA #$05", 0xa9, 0x05)
+	assemble(t, 0xf000, "LDA #%101", 0xa9, 0x05)
+	assemble(t, 0xf000, "LDA #<START", 0xa9, 0x00)
+	assemble(t, 0xf000, "LDA #>START", 0xa9, 0xf0)
+	assemble(t, 0xf000, "LDA $80", 0xa5, 0x80)
+	assemble(t, 0xf000, "LDA $0080", 0xad, 0x80, 0x00)
+	assemble(t, 0xf000, "LDA $1234", 0xad, 0x34, 0x12)
+	assemble(t, 0xf000, "LDA $80,X", 0xb5, 0x80)
+	assemble(t, 0xf000, "LDX $80,Y", 0xb6, 0x80)
+	assemble(t, 0xf000, "LDA $80,Y", 0xb9, 0x80, 0x00)
+	assemble(t, 0xf000, "LDA $1234,X", 0xbd, 0x34, 0x12)
+	assemble(t, 0xf000, "LDA ($80,X)", 0xa1, 0x80)
+	assemble(t, 0xf000, "LDA ($80),Y", 0xb1, 0x80)
+	assemble(t, 0xf000, "JMP ($1234)", 0x6c, 0x34, 0x12)
+	assemble(t, 0xf000, "JMP START", 0x4c, 0x00, 0xf0)
+	assemble(t, 0xf000, "sta GRP0", 0x85, 0x1b)
+	assemble(t, 0xf000, "lax $80", 0xa7, 0x80)
+
+	fail(t, 0xf000, "LAX $80")
+	fail(t, 0xf000, "LDA")
+	fail(t, 0xf000, "LDA #$100")
+	fail(t, 0xf000, "STA #$05")
+	fail(t, 0xf000, "LDA FOO")
+	fail(t, 0xf000, "LDA ($1234),Y")
+	fail(t, 0xf000, "FOO $80")
+}
+
+func TestBranches(t *testing.T) {
+	assemble(t, 0xf000, "BNE $f000", 0xd0, 0xfe)
+	assemble(t, 0xf000, "BNE $f081", 0xd0, 0x7f)
+	assemble(t, 0xf000, "NOP : BEQ START", 0xea, 0xf0, 0xfd)
+
+	fail(t, 0xf000, "BNE $f082")
+}
+
+func TestMultipleStatements(t *testing.T) {
+	lines, err := assembler.Assemble(0xf100, "LDA #$05 : STA GRP0", lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	if lines[1].Address != 0xf102 {
+		t.Errorf("second statement at %#04x, expected 0xf102", lines[1].Address)
+	}
+
+	if lines[1].Source != "STA GRP0" {
+		t.Errorf("unexpected source for second statement (%s)", lines[1].Source)
+	}
+}
+
+func TestCartridge(t *testing.T) {
+	data, err := assembler.Cartridge("sei : jmp $f000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(data) != 4096 {
+		t.Fatalf("cartridge is %d bytes, expected 4096", len(data))
+	}
+
+	if !bytes.Equal(data[:4], []uint8{0x78, 0x4c, 0x00, 0xf0}) {
+		t.Errorf("cartridge starts with % 02x", data[:4])
+	}
+
+	if data[0xffc] != 0x00 || data[0xffd] != 0xf0 {
+		t.Errorf("reset vector is %02x%02x, expected f000", data[0xffd], data[0xffc])
+	}
+
+	_, err = assembler.Cartridge("foo")
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
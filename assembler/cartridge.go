@@ -0,0 +1,42 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package assembler
+
+// CartridgeOrigin is the address at which Cartridge() assembles source code.
+const CartridgeOrigin = 0xf000
+
+// Cartridge assembles the source code at CartridgeOrigin and returns a 4k
+// cartridge image with the reset vector pointing to the first statement.
+// Unused bytes in the image are zero.
+//
+// Useful for creating small ROMs for testing purposes.
+func Cartridge(source string) ([]byte, error) {
+	lines, err := Assemble(CartridgeOrigin, source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 4096)
+	for _, l := range lines {
+		copy(data[l.Address&0x0fff:], l.Bytes)
+	}
+
+	// reset vector
+	data[0xffc] = uint8(CartridgeOrigin & 0xff)
+	data[0xffd] = uint8(CartridgeOrigin >> 8)
+
+	return data, nil
+}
@@ -0,0 +1,45 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package assembler is a minimal 6502 assembler. It is intended for quick
+// experiments in the debugger, patching small amounts of code directly into
+// memory, and is not a replacement for a full assembler toolchain.
+//
+// Statements are separated by a colon or a newline and consist of a mnemonic
+// and an optional operand. For example:
+//
+//	LDA #$05 : STA GRP0
+//
+// Operands are written in the conventional way:
+//
+//	#value       immediate
+//	value        zero page or absolute (or relative for branch instructions)
+//	value,X      indexed zero page or indexed absolute
+//	value,Y      indexed zero page or indexed absolute
+//	(value)      indirect
+//	(value,X)    indexed indirect
+//	(value),Y    indirect indexed
+//
+// Values can be decimal, hexadecimal (with a $ or 0x prefix), binary (with a %
+// prefix) or a symbol. Symbols are resolved by the Lookup function supplied
+// to Assemble(). The low or high byte of an immediate value can be selected
+// with a < or > prefix.
+//
+// Zero page addressing is preferred for values less than 256, unless the
+// value is written as a hexadecimal number of more than two digits.
+//
+// Undocumented instructions are assembled if the mnemonic is written in lower
+// case, following the convention of the instructions package.
+package assembler
@@ -0,0 +1,254 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package busactivity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// HistoryLength is the maximum number of cycles kept by the Monitor.
+const HistoryLength = 256
+
+// Cycle is the state of the buses for a single CPU cycle.
+type Cycle struct {
+	// whether the CPU accessed memory during the cycle. if Access is false
+	// then the Address, Data, Write and Area fields are undefined
+	Access bool
+
+	// the value on the 13 lines of the address bus and the 8 lines of the
+	// data bus
+	Address uint16
+	Data    uint8
+
+	// the state of the R/W line. true if the cycle was a write
+	Write bool
+
+	// the memory area that the address is mapped to
+	Area memorymap.Area
+
+	// the number of CPU cycles the CPU was halted for. if Halted is greater
+	// than zero then the entry represents the halted period and not a single
+	// cycle
+	Halted int
+
+	// the instruction being executed and the cycle number of the instruction
+	PC       uint16
+	Mnemonic string
+	Cycle    int
+
+	// television coordinates at the end of the cycle
+	Frame    int
+	Scanline int
+	Clock    int
+}
+
+// Chip returns the name of the chip that responded to the address. Note that
+// the RAM of the VCS is part of the RIOT chip.
+func (c Cycle) Chip() string {
+	switch c.Area {
+	case memorymap.TIA:
+		return "TIA"
+	case memorymap.RAM:
+		return "RIOT (RAM)"
+	case memorymap.RIOT:
+		return "RIOT"
+	case memorymap.Cartridge:
+		return "Cartridge"
+	}
+	return "-"
+}
+
+// RW returns the state of the R/W line as a single character.
+func (c Cycle) RW() string {
+	if c.Write {
+		return "W"
+	}
+	return "R"
+}
+
+func (c Cycle) String() string {
+	s := strings.Builder{}
+
+	if c.Halted > 0 {
+		s.WriteString(fmt.Sprintf("halted for %d cycles (RDY low)", c.Halted))
+	} else if c.Access {
+		s.WriteString(fmt.Sprintf("A=%04x D=%02x %s %s", c.Address, c.Data, c.RW(), c.Chip()))
+	} else {
+		s.WriteString("internal cycle")
+	}
+
+	if c.Mnemonic != "" && c.Halted == 0 {
+		s.WriteString(fmt.Sprintf(" (%s cycle %d at %#04x)", c.Mnemonic, c.Cycle, c.PC))
+	}
+
+	s.WriteString(fmt.Sprintf(" [frame %d, scanline %d, clock %d]", c.Frame, c.Scanline, c.Clock))
+
+	return s.String()
+}
+
+// Monitor records the state of the buses for the most recent CPU cycles.
+type Monitor struct {
+	vcs *hardware.VCS
+
+	enabled bool
+
+	// circular buffer of cycles. next is the index of the next entry to be
+	// written and ct is the number of valid entries
+	history [HistoryLength]Cycle
+	next    int
+	ct      int
+
+	// the access ID of the most recent memory access that was recorded.
+	// memory accesses with the same ID are not recorded twice
+	lastAccessID int
+
+	// the instruction address and cycle count seen by the most recent call
+	// to Check(). used to decide whether a new CPU cycle has begun
+	lastPC     uint16
+	lastCycles int
+
+	// number of video cycles the CPU has been halted for
+	halted int
+}
+
+// NewMonitor is the preferred method of initialisation for the Monitor type.
+// The monitor is enabled by default.
+func NewMonitor(vcs *hardware.VCS) *Monitor {
+	m := &Monitor{
+		vcs:     vcs,
+		enabled: true,
+	}
+	m.Clear()
+	return m
+}
+
+// Enable or disable the monitor. Disabling the monitor clears the history.
+func (m *Monitor) Enable(enable bool) {
+	m.enabled = enable
+	if !enable {
+		m.Clear()
+	}
+}
+
+// IsEnabled returns true if the monitor is recording bus activity.
+func (m *Monitor) IsEnabled() bool {
+	return m.enabled
+}
+
+// Clear the history.
+func (m *Monitor) Clear() {
+	m.next = 0
+	m.ct = 0
+	m.lastAccessID = -1
+	m.lastPC = 0
+	m.lastCycles = -1
+	m.halted = 0
+}
+
+func (m *Monitor) push(c Cycle) {
+	m.history[m.next] = c
+	m.next++
+	if m.next >= len(m.history) {
+		m.next = 0
+	}
+	if m.ct < len(m.history) {
+		m.ct++
+	}
+}
+
+// Check the state of the CPU and record a new entry if a CPU cycle has
+// completed since the previous call.
+func (m *Monitor) Check() {
+	if !m.enabled {
+		return
+	}
+
+	cpu := m.vcs.CPU
+
+	// the CPU has been halted by the TIA. count the number of video cycles
+	// and collate them into a single entry once the CPU is running again
+	if !cpu.RdyFlg {
+		m.halted++
+		return
+	}
+
+	if m.halted > 0 {
+		m.push(Cycle{
+			Halted:   (m.halted + 2) / 3,
+			Frame:    m.vcs.TV.GetState(signal.ReqFramenum),
+			Scanline: m.vcs.TV.GetState(signal.ReqScanline),
+			Clock:    m.vcs.TV.GetState(signal.ReqHorizPos),
+		})
+		m.halted = 0
+	}
+
+	// the cycle count of the current instruction increases by one for every
+	// CPU cycle. this function is called three times for every CPU cycle so
+	// we only want to record an entry when the cycle count changes
+	res := cpu.LastResult
+	if res.Cycles == m.lastCycles && res.Address == m.lastPC {
+		return
+	}
+	m.lastCycles = res.Cycles
+	m.lastPC = res.Address
+
+	c := Cycle{
+		PC:       res.Address,
+		Cycle:    res.Cycles,
+		Frame:    m.vcs.TV.GetState(signal.ReqFramenum),
+		Scanline: m.vcs.TV.GetState(signal.ReqScanline),
+		Clock:    m.vcs.TV.GetState(signal.ReqHorizPos),
+	}
+
+	if res.Defn != nil {
+		c.Mnemonic = res.Defn.Mnemonic
+	}
+
+	mem := m.vcs.Mem
+	if mem.LastAccessID != m.lastAccessID {
+		m.lastAccessID = mem.LastAccessID
+		c.Access = true
+		c.Address = mem.LastAccessAddress & memorymap.Memtop
+		c.Data = mem.LastAccessValue
+		c.Write = mem.LastAccessWrite
+		_, c.Area = memorymap.MapAddress(mem.LastAccessAddress, !mem.LastAccessWrite)
+	}
+
+	m.push(c)
+}
+
+// History returns a copy of the recorded cycles. The oldest cycle is first in
+// the list.
+func (m *Monitor) History() []Cycle {
+	h := make([]Cycle, 0, m.ct)
+	i := m.next - m.ct
+	if i < 0 {
+		i += len(m.history)
+	}
+	for n := 0; n < m.ct; n++ {
+		h = append(h, m.history[i])
+		i++
+		if i >= len(m.history) {
+			i = 0
+		}
+	}
+	return h
+}
@@ -0,0 +1,98 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package busactivity_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/busactivity"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestMonitor(t *testing.T) {
+	const kernel = `lda $80
+sta $02
+nop
+sta $0281`
+
+	data, err := assembler.Cartridge(kernel)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{Filename: "kernel", Mapping: "4k", Data: data, Hash: "kernel"})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	m := busactivity.NewMonitor(vcs)
+
+	for i := 0; i < 4; i++ {
+		err = vcs.Step(func() error {
+			m.Check()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error running VCS: %v", err)
+		}
+	}
+
+	h := m.History()
+	test.Equate(t, len(h), 13)
+
+	// opcode fetch
+	test.Equate(t, h[0].Access, true)
+	test.Equate(t, h[0].Address, 0x1000)
+	test.Equate(t, int(h[0].Data), 0xa5)
+	test.Equate(t, h[0].Write, false)
+	test.Equate(t, h[0].Chip(), "Cartridge")
+
+	// read of RAM
+	test.Equate(t, h[2].Address, 0x0080)
+	test.Equate(t, h[2].Area == memorymap.RAM, true)
+	test.Equate(t, h[2].Mnemonic, "LDA")
+	test.Equate(t, h[2].Cycle, 3)
+
+	// write to WSYNC and the halted period that follows it
+	test.Equate(t, h[5].Address, 0x0002)
+	test.Equate(t, h[5].Write, true)
+	test.Equate(t, h[5].Chip(), "TIA")
+	test.Equate(t, h[6].Halted, 70)
+
+	// write to RIOT register
+	test.Equate(t, h[12].Address, 0x0281)
+	test.Equate(t, h[12].Write, true)
+	test.Equate(t, h[12].Chip(), "RIOT")
+
+	// disabling the monitor clears the history
+	m.Enable(false)
+	test.Equate(t, len(m.History()), 0)
+}
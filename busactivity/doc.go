@@ -0,0 +1,33 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package busactivity records the state of the address and data buses for
+// every CPU cycle. For each cycle the literal value on the address bus, the
+// value on the data bus, the state of the R/W line and the chip that responded
+// to the address is recorded.
+//
+// The 6507 only has 13 address lines so the address recorded is the address
+// as seen by the chips in the VCS and not the 16bit address used by the
+// program.
+//
+// Cycles in which the emulated CPU did not access memory are recorded as
+// internal cycles. Cycles in which the CPU was halted by the TIA (the RDY line
+// being held low, usually as a result of a write to WSYNC) are collated into a
+// single entry.
+//
+// The Monitor type keeps a limited history of cycles. The Check() function
+// should be called after every video cycle. Calling the function less often
+// than that will result in missed cycles.
+package busactivity
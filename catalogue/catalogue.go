@@ -0,0 +1,271 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package catalogue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+	"github.com/jetsetilly/gopher2600/paths"
+)
+
+// DefaultCatalogue is the name of the catalogue file in the emulator's
+// configuration directory.
+const DefaultCatalogue = "catalogue.json"
+
+// Entry is the catalogue information for a single ROM file.
+type Entry struct {
+	// absolute path to the ROM file
+	Filename string `json:"filename"`
+
+	Size int    `json:"size"`
+	Hash string `json:"hash"`
+
+	// the mapping identified for the ROM. empty if the mapping could not be
+	// identified
+	Mapping string `json:"mapping"`
+
+	// the other files in the catalogue with the same hash
+	Duplicates []string `json:"duplicates,omitempty"`
+
+	// description of any problems found with the ROM. see the package
+	// documentation for details
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Catalogue is the result of scanning a ROM collection.
+type Catalogue struct {
+	// the directory that was scanned
+	Path    string    `json:"path"`
+	Created time.Time `json:"created"`
+
+	// entries are sorted by filename
+	Entries []Entry `json:"entries"`
+
+	// entries indexed by filename. built by Load() and Scan()
+	index map[string]int
+}
+
+// Scan the ROM files in the directory. Subdirectories are scanned if recurse
+// is true. Files that can not be read are skipped.
+func Scan(path string, recurse bool) (*Catalogue, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, curated.Errorf("catalogue: %v", err)
+	}
+
+	cat := &Catalogue{
+		Path:    path,
+		Created: time.Now(),
+	}
+
+	err = filepath.Walk(path, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info.IsDir() {
+			if pth != path && (!recurse || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !isROM(pth) {
+			return nil
+		}
+
+		e, err := scanFile(pth)
+		if err != nil {
+			return nil
+		}
+		cat.Entries = append(cat.Entries, e)
+
+		return nil
+	})
+	if err != nil {
+		return nil, curated.Errorf("catalogue: %v", err)
+	}
+
+	sort.Slice(cat.Entries, func(i, j int) bool {
+		return cat.Entries[i].Filename < cat.Entries[j].Filename
+	})
+
+	cat.findDuplicates()
+	cat.buildIndex()
+
+	return cat, nil
+}
+
+// isROM returns true if the file has one of the extensions recognised by the
+// cartridgeloader package. supercharger audio tapes are not ROM dumps and are
+// not included.
+func isROM(pth string) bool {
+	ext := strings.ToUpper(filepath.Ext(pth))
+	if ext == ".WAV" || ext == ".MP3" {
+		return false
+	}
+	for _, e := range cartridgeloader.FileExtensions {
+		if strings.ToUpper(e) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// scanFile creates a new Entry for the named file.
+func scanFile(pth string) (Entry, error) {
+	cartload := cartridgeloader.NewLoader(pth, "AUTO")
+	err := cartload.Load()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{
+		Filename: pth,
+		Size:     len(cartload.Data),
+		Hash:     cartload.Hash,
+	}
+
+	// attaching the cartridge is the simplest way of identifying the mapper
+	cart := cartridge.NewCartridge(nil)
+	err = cart.Attach(cartload)
+	if err != nil {
+		e.Problems = append(e.Problems, fmt.Sprintf("unrecognised cartridge: %v", err))
+	} else {
+		e.Mapping = cart.ID()
+	}
+
+	e.Problems = append(e.Problems, checkData(cartload.Data, e.Mapping)...)
+
+	return e, nil
+}
+
+// findDuplicates fills in the Duplicates field of every entry.
+func (cat *Catalogue) findDuplicates() {
+	hashes := make(map[string][]string)
+	for _, e := range cat.Entries {
+		hashes[e.Hash] = append(hashes[e.Hash], e.Filename)
+	}
+
+	for i := range cat.Entries {
+		e := &cat.Entries[i]
+		e.Duplicates = nil
+		for _, f := range hashes[e.Hash] {
+			if f != e.Filename {
+				e.Duplicates = append(e.Duplicates, f)
+			}
+		}
+	}
+}
+
+func (cat *Catalogue) buildIndex() {
+	cat.index = make(map[string]int)
+	for i, e := range cat.Entries {
+		cat.index[e.Filename] = i
+	}
+}
+
+// Lookup returns the entry for the named file. The filename will be converted
+// to an absolute path if necessary.
+func (cat *Catalogue) Lookup(filename string) (Entry, bool) {
+	f, err := filepath.Abs(filename)
+	if err != nil {
+		return Entry{}, false
+	}
+	i, ok := cat.index[f]
+	if !ok {
+		return Entry{}, false
+	}
+	return cat.Entries[i], true
+}
+
+// DuplicateGroups returns the filenames of every ROM that has one or more
+// duplicates, grouped by hash.
+func (cat *Catalogue) DuplicateGroups() [][]string {
+	var groups [][]string
+	seen := make(map[string]bool)
+	for _, e := range cat.Entries {
+		if len(e.Duplicates) == 0 || seen[e.Hash] {
+			continue
+		}
+		seen[e.Hash] = true
+		groups = append(groups, append([]string{e.Filename}, e.Duplicates...))
+	}
+	return groups
+}
+
+// Problems returns every entry that has one or more problems.
+func (cat *Catalogue) Problems() []Entry {
+	var p []Entry
+	for _, e := range cat.Entries {
+		if len(e.Problems) > 0 {
+			p = append(p, e)
+		}
+	}
+	return p
+}
+
+// DefaultPath returns the path to the DefaultCatalogue file.
+func DefaultPath() (string, error) {
+	pth, err := paths.ResourcePath("", DefaultCatalogue)
+	if err != nil {
+		return "", curated.Errorf("catalogue: %v", err)
+	}
+	return pth, nil
+}
+
+// Save the catalogue as JSON to the named file.
+func (cat *Catalogue) Save(filename string) error {
+	b, err := json.MarshalIndent(cat, "", "  ")
+	if err != nil {
+		return curated.Errorf("catalogue: %v", err)
+	}
+
+	err = ioutil.WriteFile(filename, b, 0644)
+	if err != nil {
+		return curated.Errorf("catalogue: %v", err)
+	}
+
+	return nil
+}
+
+// Load a catalogue from the named file.
+func Load(filename string) (*Catalogue, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, curated.Errorf("catalogue: %v", err)
+	}
+
+	cat := &Catalogue{}
+	err = json.Unmarshal(b, cat)
+	if err != nil {
+		return nil, curated.Errorf("catalogue: %v", err)
+	}
+
+	cat.buildIndex()
+
+	return cat, nil
+}
@@ -0,0 +1,132 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package catalogue_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/catalogue"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// makeROM returns size bytes of non-repeating data with a reset vector
+// pointing to the start of the 4k cartridge address space.
+func makeROM(size int) []byte {
+	d := make([]byte, size)
+	for i := range d {
+		d[i] = byte(i*7 + i>>8)
+	}
+	d[len(d)-4] = 0x00
+	d[len(d)-3] = 0xf0
+	return d
+}
+
+func writeFile(t *testing.T, dir string, name string, data []byte) {
+	t.Helper()
+	err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func hasProblem(e catalogue.Entry, prefix string) bool {
+	for _, p := range e.Problems {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestScan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catalogue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rom := makeROM(4096)
+	writeFile(t, dir, "good.bin", rom)
+	writeFile(t, dir, "copy.bin", rom)
+	writeFile(t, dir, "overdump.bin", append(append([]byte{}, rom...), rom...))
+	writeFile(t, dir, "truncated.bin", rom[:4000])
+	writeFile(t, dir, "notes.txt", []byte("not a ROM"))
+
+	cat, err := catalogue.Scan(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Equate(t, len(cat.Entries), 4)
+
+	good, ok := cat.Lookup(filepath.Join(dir, "good.bin"))
+	test.ExpectedSuccess(t, ok)
+	test.Equate(t, good.Mapping, "4k")
+	test.Equate(t, len(good.Problems), 0)
+	test.Equate(t, len(good.Duplicates), 1)
+	test.Equate(t, good.Duplicates[0], filepath.Join(dir, "copy.bin"))
+
+	groups := cat.DuplicateGroups()
+	test.Equate(t, len(groups), 1)
+	test.Equate(t, len(groups[0]), 2)
+
+	over, ok := cat.Lookup(filepath.Join(dir, "overdump.bin"))
+	test.ExpectedSuccess(t, ok)
+	test.ExpectedSuccess(t, hasProblem(over, "possible overdump"))
+
+	trunc, ok := cat.Lookup(filepath.Join(dir, "truncated.bin"))
+	test.ExpectedSuccess(t, ok)
+	test.ExpectedSuccess(t, hasProblem(trunc, "possibly truncated"))
+
+	_, ok = cat.Lookup(filepath.Join(dir, "notes.txt"))
+	test.ExpectedFailure(t, ok)
+
+	test.Equate(t, len(cat.Problems()), 2)
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "catalogue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "good.bin", makeROM(4096))
+
+	cat, err := catalogue.Scan(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := filepath.Join(dir, catalogue.DefaultCatalogue)
+	err = cat.Save(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ld, err := catalogue.Load(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Equate(t, len(ld.Entries), 1)
+
+	e, ok := ld.Lookup(filepath.Join(dir, "good.bin"))
+	test.ExpectedSuccess(t, ok)
+	test.Equate(t, e.Hash, cat.Entries[0].Hash)
+}
@@ -0,0 +1,31 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package catalogue scans a collection of ROM files and produces a catalogue
+// of what was found. Each ROM is hashed and the cartridge mapper is
+// identified. ROMs with the same hash are grouped as duplicates and ROMs that
+// look like bad dumps are flagged.
+//
+// Bad dumps are identified with simple heuristics. A ROM may be flagged if
+// the size of the file is not a size expected by any cartridge mapper, if the
+// second half of the file is a copy of the first half (a common result of
+// dumping a cartridge with the wrong size), if there is no valid reset vector
+// or if the data is blank. These are heuristics and there will be false
+// positives.
+//
+// The catalogue is saved as JSON. By default it is saved to the
+// DefaultCatalogue file in the emulator's configuration directory, where it
+// is used by the ROM selector of the GUI.
+package catalogue
@@ -0,0 +1,143 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package catalogue
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/supercharger"
+)
+
+// the sizes of ROM file expected by the cartridge mappers. sizes that are not
+// in the list are not necessarily wrong because some mappers accept ROMs of
+// any size that is a multiple of the bank size.
+var expectedSizes = []int{
+	2048, 4096, 8192, 8448, 10240, 10495, 12288, 16384,
+	25344, 29696, 32768, 33792, 65536, 131072,
+}
+
+// the smallest bank size of any mapper.
+const minBankSize = 1024
+
+// the position of the reset vector in a 2k and 4k bank.
+const (
+	resetVector2k = 0x07fc
+	resetVector4k = 0x0ffc
+)
+
+// the mappings for which the data is not simply a sequence of banks. the reset
+// vector check is not performed for these mappings.
+var noResetVectorCheck = map[string]bool{
+	supercharger.MappingID: true,
+	"DPC+":                 true,
+}
+
+// checkData returns a description of any problems found with the ROM data.
+// the mapping argument is the mapping identified by the cartridge package or
+// the empty string if the data was not recognised.
+func checkData(data []byte, mapping string) []string {
+	var p []string
+
+	if msg, ok := checkSize(len(data), mapping != ""); !ok {
+		p = append(p, msg)
+	}
+
+	if isBlank(data) {
+		p = append(p, "blank data")
+		return p
+	}
+
+	if isOverdump(data) {
+		p = append(p, "possible overdump: second half is a copy of the first half")
+	}
+
+	if !noResetVectorCheck[mapping] && !hasResetVector(data) {
+		p = append(p, "no valid reset vector")
+	}
+
+	return p
+}
+
+// checkSize returns false if the size is unexpected, along with a
+// description of why.
+func checkSize(size int, recognised bool) (string, bool) {
+	for _, s := range expectedSizes {
+		if size == s {
+			return "", true
+		}
+	}
+
+	// a mapper has accepted the data so sizes that are a multiple of the
+	// smallest bank size are not unusual
+	if recognised && size%minBankSize == 0 {
+		return "", true
+	}
+
+	// find the nearest expected size that is larger than the file
+	for _, s := range expectedSizes {
+		if size < s {
+			return fmt.Sprintf("possibly truncated: %d bytes (expected %d)", size, s), false
+		}
+	}
+
+	return fmt.Sprintf("possibly overdumped: %d bytes", size), false
+}
+
+// isBlank returns true if every byte has the same value.
+func isBlank(data []byte) bool {
+	if len(data) == 0 {
+		return true
+	}
+	for _, b := range data {
+		if b != data[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isOverdump returns true if the second half of data is a copy of the first
+// half. only checked for ROMs of 4k or more because the smallest cartridges
+// are commonly mirrored by the hardware.
+func isOverdump(data []byte) bool {
+	l := len(data)
+	if l < 4096 || l%2 != 0 {
+		return false
+	}
+	return bytes.Equal(data[:l/2], data[l/2:])
+}
+
+// hasResetVector returns true if at least one bank of the data has a reset
+// vector that points into the cartridge address space. the size of the bank
+// is 2k for 2k ROMs and 4k otherwise.
+func hasResetVector(data []byte) bool {
+	bank := 4096
+	vector := resetVector4k
+	if len(data) <= 2048 {
+		bank = 2048
+		vector = resetVector2k
+	}
+
+	for b := 0; b+bank <= len(data); b += bank {
+		hi := data[b+vector+1]
+		if hi&0x10 == 0x10 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package cputrace writes and reads execution traces. A trace is a record of
+// every CPU instruction executed by the emulation: the address of the
+// instruction, the contents of the registers after execution and the position
+// of the television at that moment.
+//
+// Comparing a trace with a second run of the emulation is a precise way of
+// isolating nondeterminism in a ROM or regressions in the emulator. The first
+// difference between the trace and the run is the point at which the two
+// executions diverged.
+//
+// Traces are written with the Writer type and can optionally be compressed.
+// The Reader type detects compression automatically.
+package cputrace
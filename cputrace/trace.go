@@ -0,0 +1,227 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cputrace
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/version"
+)
+
+// the string at the start of every trace. it is followed by a single
+// character, which is the major version number of the format.
+const header = "gopher2600cputrace"
+
+// the number of bytes used by each entry in the trace.
+const entrySize = 15
+
+// the first two bytes of a gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Entry is the record of a single executed instruction.
+type Entry struct {
+	// position of the television
+	Frame    int32
+	Scanline int16
+	HorizPos int16
+
+	// address of the instruction
+	PC uint16
+
+	// registers after execution
+	A      uint8
+	X      uint8
+	Y      uint8
+	SP     uint8
+	Status uint8
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("FR=%04d SL=%03d HP=%03d PC=%04x A=%02x X=%02x Y=%02x SP=%02x P=%02x",
+		e.Frame, e.Scanline, e.HorizPos, e.PC, e.A, e.X, e.Y, e.SP, e.Status)
+}
+
+// Diff returns a description of the fields that differ between two entries.
+// Returns the empty string if the entries are the same.
+func (e Entry) Diff(o Entry) string {
+	s := &bytes.Buffer{}
+	diff := func(name string, a, b interface{}, format string) {
+		if a != b {
+			if s.Len() > 0 {
+				s.WriteString(", ")
+			}
+			s.WriteString(fmt.Sprintf("%s "+format+" != "+format, name, a, b))
+		}
+	}
+	diff("frame", e.Frame, o.Frame, "%d")
+	diff("scanline", e.Scanline, o.Scanline, "%d")
+	diff("horizpos", e.HorizPos, o.HorizPos, "%d")
+	diff("PC", e.PC, o.PC, "%04x")
+	diff("A", e.A, o.A, "%02x")
+	diff("X", e.X, o.X, "%02x")
+	diff("Y", e.Y, o.Y, "%02x")
+	diff("SP", e.SP, o.SP, "%02x")
+	diff("P", e.Status, o.Status, "%02x")
+	return s.String()
+}
+
+func (e Entry) encode(b []byte) {
+	binary.LittleEndian.PutUint32(b[0:], uint32(e.Frame))
+	binary.LittleEndian.PutUint16(b[4:], uint16(e.Scanline))
+	binary.LittleEndian.PutUint16(b[6:], uint16(e.HorizPos))
+	binary.LittleEndian.PutUint16(b[8:], e.PC)
+	b[10] = e.A
+	b[11] = e.X
+	b[12] = e.Y
+	b[13] = e.SP
+	b[14] = e.Status
+}
+
+func (e *Entry) decode(b []byte) {
+	e.Frame = int32(binary.LittleEndian.Uint32(b[0:]))
+	e.Scanline = int16(binary.LittleEndian.Uint16(b[4:]))
+	e.HorizPos = int16(binary.LittleEndian.Uint16(b[6:]))
+	e.PC = binary.LittleEndian.Uint16(b[8:])
+	e.A = b[10]
+	e.X = b[11]
+	e.Y = b[12]
+	e.SP = b[13]
+	e.Status = b[14]
+}
+
+// Writer writes entries to a trace.
+type Writer struct {
+	w   *bufio.Writer
+	gz  *gzip.Writer
+	buf [entrySize]byte
+
+	// the number of entries written
+	Count int
+}
+
+// NewWriter is the preferred method of initialisation for the Writer type.
+// The trace will be compressed if compress is true. Close() must be called
+// when the trace is complete but note that Close() does not close the
+// underlying io.Writer.
+func NewWriter(w io.Writer, compress bool) (*Writer, error) {
+	tw := &Writer{}
+
+	if compress {
+		tw.gz = gzip.NewWriter(w)
+		w = tw.gz
+	}
+	tw.w = bufio.NewWriter(w)
+
+	_, err := tw.w.WriteString(fmt.Sprintf("%s%d", header, version.CPUTrace.Major))
+	if err != nil {
+		return nil, curated.Errorf("cputrace: %v", err)
+	}
+
+	return tw, nil
+}
+
+// Write an entry to the trace.
+func (tw *Writer) Write(e Entry) error {
+	e.encode(tw.buf[:])
+	_, err := tw.w.Write(tw.buf[:])
+	if err != nil {
+		return curated.Errorf("cputrace: %v", err)
+	}
+	tw.Count++
+	return nil
+}
+
+// Close completes the trace.
+func (tw *Writer) Close() error {
+	err := tw.w.Flush()
+	if err != nil {
+		return curated.Errorf("cputrace: %v", err)
+	}
+	if tw.gz != nil {
+		err = tw.gz.Close()
+		if err != nil {
+			return curated.Errorf("cputrace: %v", err)
+		}
+	}
+	return nil
+}
+
+// Reader reads entries from a trace.
+type Reader struct {
+	r   io.Reader
+	buf [entrySize]byte
+
+	// the number of entries read
+	Count int
+}
+
+// NewReader is the preferred method of initialisation for the Reader type.
+// Compressed traces are detected automatically.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+
+	tr := &Reader{r: br}
+
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil {
+		return nil, curated.Errorf("cputrace: %v", "not a trace file")
+	}
+	if bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, curated.Errorf("cputrace: %v", err)
+		}
+		tr.r = gz
+	}
+
+	h := make([]byte, len(header)+1)
+	_, err = io.ReadFull(tr.r, h)
+	if err != nil || string(h[:len(header)]) != header {
+		return nil, curated.Errorf("cputrace: %v", "not a trace file")
+	}
+
+	err = version.CPUTrace.Check(string(h[len(header):]))
+	if err != nil {
+		return nil, curated.Errorf("cputrace: %v", err)
+	}
+
+	return tr, nil
+}
+
+// Next returns the next entry in the trace. Returns io.EOF when there are no
+// more entries.
+func (tr *Reader) Next() (Entry, error) {
+	var e Entry
+
+	_, err := io.ReadFull(tr.r, tr.buf[:])
+	if err != nil {
+		if err == io.EOF {
+			return e, io.EOF
+		}
+		return e, curated.Errorf("cputrace: %v", err)
+	}
+
+	e.decode(tr.buf[:])
+	tr.Count++
+
+	return e, nil
+}
@@ -0,0 +1,106 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cputrace_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cputrace"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func entries() []cputrace.Entry {
+	var l []cputrace.Entry
+	for i := 0; i < 1000; i++ {
+		l = append(l, cputrace.Entry{
+			Frame:    int32(i / 100),
+			Scanline: int16(i % 262),
+			HorizPos: int16(i%228 - 68),
+			PC:       uint16(0xf000 + i),
+			A:        uint8(i),
+			X:        uint8(i >> 1),
+			Y:        uint8(i >> 2),
+			SP:       0xff,
+			Status:   0x20,
+		})
+	}
+	return l
+}
+
+func roundTrip(t *testing.T, compress bool) {
+	t.Helper()
+
+	b := &bytes.Buffer{}
+	w, err := cputrace.NewWriter(b, compress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries() {
+		err = w.Write(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Equate(t, w.Count, 1000)
+
+	r, err := cputrace.NewReader(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries() {
+		n, err := r.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.Equate(t, n.Diff(e), "")
+	}
+	_, err = r.Next()
+	test.ExpectedSuccess(t, err == io.EOF)
+}
+
+func TestRoundTrip(t *testing.T) {
+	roundTrip(t, false)
+}
+
+func TestCompressed(t *testing.T) {
+	roundTrip(t, true)
+}
+
+func TestNotATrace(t *testing.T) {
+	_, err := cputrace.NewReader(bytes.NewBufferString("not a trace file at all"))
+	test.ExpectedFailure(t, err)
+}
+
+func TestNewerVersion(t *testing.T) {
+	// a trace written by a newer version of the emulator
+	_, err := cputrace.NewReader(bytes.NewBufferString("gopher2600cputrace9"))
+	test.ExpectedFailure(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	a := cputrace.Entry{PC: 0xf000, A: 0x10}
+	b := a
+	test.Equate(t, a.Diff(b), "")
+	b.A = 0x11
+	b.Scanline = 2
+	test.Equate(t, a.Diff(b), "scanline 0 != 2, A 10 != 11")
+}
@@ -21,6 +21,7 @@ import (
 	"sort"
 
 	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/version"
 )
 
 // arbitrary maximum number of entries.
@@ -35,6 +36,14 @@ const (
 	numLeaderFields
 )
 
+// the first line of a database file records the version of the database
+// format. files without a version line are version 1.0.
+const versionLeader = "#version"
+
+func versionHeader() string {
+	return fmt.Sprintf("%s%s%s%s", versionLeader, fieldSep, version.Database, entrySep)
+}
+
 func recordHeader(key int, id string) string {
 	return fmt.Sprintf("%03d%s%s", key, fieldSep, id)
 }
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/version"
 )
 
 // Sentinal error returned when requested database is not available.
@@ -119,6 +120,11 @@ func (db *Session) EndSession(commitChanges bool) error {
 			return err
 		}
 
+		_, err = db.dbfile.WriteString(versionHeader())
+		if err != nil {
+			return err
+		}
+
 		for k, v := range db.entries {
 			s := strings.Builder{}
 			ser, err := v.Serialise()
@@ -180,6 +186,20 @@ func (db *Session) readDBFile() error {
 			continue
 		}
 
+		// files from newer versions of the emulator may contain entries that
+		// can not be read correctly
+		if strings.HasPrefix(lines[i], versionLeader) {
+			fields := strings.SplitN(lines[i], fieldSep, 2)
+			if len(fields) < 2 {
+				return curated.Errorf("database: missing version [line %d]", i+1)
+			}
+			err := version.Database.Check(fields[1])
+			if err != nil {
+				return curated.Errorf("database: %v", err)
+			}
+			continue
+		}
+
 		// loop through file until EOF is reached
 		fields := strings.SplitN(lines[i], fieldSep, numLeaderFields+1)
 
@@ -0,0 +1,33 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testAsm() {
+	// assemble into RAM. the output is the last line assembled
+	trm.sndInput("ASM 0x80 LDA #$05 : STA GRP0 : BNE 0x80")
+	trm.cmpOutput("0x0084  d0 fa     BNE 0x80")
+
+	trm.sndInput("PEEK 0x82")
+	trm.cmpOutput("0x0082 (RAM) -> 0x85")
+
+	trm.sndInput("ASM 0x80 FOO")
+	trm.cmpOutput("assembler: unrecognised mnemonic (FOO)")
+
+	// changes made by ASM can be undone
+	trm.sndInput("UNDO ALL")
+	trm.sndInput("PEEK 0x82")
+	trm.cmpOutput("0x0082 (RAM) -> 0x00")
+}
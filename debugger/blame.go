@@ -0,0 +1,132 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// Blame records the instruction that most recently wrote to a RAM address.
+type Blame struct {
+	// false if the RAM address has not been written to since the cartridge
+	// was attached
+	Valid bool
+
+	// the address and bank of the instruction
+	PC       uint16
+	Bank     mapper.BankInfo
+	Mnemonic string
+
+	// the value that was written
+	Value uint8
+
+	// television coordinates at the time of the write
+	Coords television.Coordinates
+}
+
+func (b Blame) String() string {
+	if !b.Valid {
+		return "not written"
+	}
+	return fmt.Sprintf("%#02x by %s at %#04x (bank %s) [%s]",
+		b.Value, b.Mnemonic, b.PC, b.Bank, b.Coords)
+}
+
+// ramBlame keeps track of which instruction last wrote to each RAM address.
+type ramBlame struct {
+	dbg     *Debugger
+	entries []Blame
+
+	// the access ID of the most recent write. prevents the same write being
+	// recorded more than once
+	lastAccessID int
+}
+
+func newRAMBlame(dbg *Debugger) *ramBlame {
+	return &ramBlame{
+		dbg:          dbg,
+		entries:      make([]Blame, memorymap.MemtopRAM-memorymap.OriginRAM+1),
+		lastAccessID: -1,
+	}
+}
+
+// clear all blame information.
+func (rb *ramBlame) clear() {
+	for i := range rb.entries {
+		rb.entries[i] = Blame{}
+	}
+}
+
+// check the most recent memory access and record blame if it was a write to
+// RAM. should be called after every CPU cycle.
+func (rb *ramBlame) check() {
+	mem := rb.dbg.VCS.Mem
+	if !mem.LastAccessWrite || mem.LastAccessID == rb.lastAccessID {
+		return
+	}
+	rb.lastAccessID = mem.LastAccessID
+
+	_, area := memorymap.MapAddress(mem.LastAccessAddressMapped, false)
+	if area != memorymap.RAM {
+		return
+	}
+
+	mnemonic := "??"
+	if rb.dbg.VCS.CPU.LastResult.Defn != nil {
+		mnemonic = rb.dbg.VCS.CPU.LastResult.Defn.Mnemonic
+	}
+
+	rb.entries[mem.LastAccessAddressMapped-memorymap.OriginRAM] = Blame{
+		Valid:    true,
+		PC:       rb.dbg.VCS.CPU.LastResult.Address,
+		Bank:     rb.dbg.lastBank,
+		Mnemonic: mnemonic,
+		Value:    mem.LastAccessValue,
+		Coords:   rb.dbg.tv.GetCoords(),
+	}
+}
+
+// GetRAMBlame returns a copy of the blame information for every RAM address.
+// The first entry in the returned slice is for address 0x80.
+func (dbg *Debugger) GetRAMBlame() []Blame {
+	b := make([]Blame, len(dbg.ramBlame.entries))
+	copy(b, dbg.ramBlame.entries)
+	return b
+}
+
+// printBlame prints the blame information for the address, which can be
+// numeric or symbolic.
+func (dbg *Debugger) printBlame(address string) {
+	ai := dbg.dbgmem.mapAddress(address, false)
+	if ai == nil || ai.area != memorymap.RAM {
+		dbg.printLine(terminal.StyleError, "%s is not a RAM address", address)
+		return
+	}
+
+	b := dbg.ramBlame.entries[ai.mappedAddress-memorymap.OriginRAM]
+
+	label := ""
+	if ai.addressLabel != "" {
+		label = fmt.Sprintf(" (%s)", ai.addressLabel)
+	}
+
+	dbg.printLine(terminal.StyleFeedback, "%#04x%s: %s", ai.mappedAddress, label, b)
+}
@@ -67,6 +67,25 @@ func (bk breaker) String() string {
 	return s.String()
 }
 
+// conditions returns the breaker in a form suitable for use as the arguments
+// to the BREAK command.
+func (bk breaker) conditions() string {
+	s := strings.Builder{}
+	n := &bk
+	for n != nil {
+		if n != &bk {
+			s.WriteString(" & ")
+		}
+		v := n.target.FormatValue(n.value)
+		if strings.Contains(v, " ") {
+			v = fmt.Sprintf("\"%s\"", v)
+		}
+		s.WriteString(fmt.Sprintf("%s %s", n.target.keyword, v))
+		n = n.next
+	}
+	return s.String()
+}
+
 // compares two breakers for equality. returns true if the two breakers are
 // logically the same.
 func (bk breaker) cmp(ck breaker) bool {
@@ -226,6 +245,16 @@ func (bp breakpoints) list() {
 	}
 }
 
+// commands returns the list of BREAK commands that will recreate the currently
+// defined breakpoints.
+func (bp breakpoints) commands() []string {
+	cmds := make([]string, 0, len(bp.breaks))
+	for i := range bp.breaks {
+		cmds = append(cmds, fmt.Sprintf("%s %s", cmdBreak, bp.breaks[i].conditions()))
+	}
+	return cmds
+}
+
 // parse token and add new breakpoint. for example:
 //
 //	PC 0xf000
@@ -245,6 +274,26 @@ func (bp breakpoints) list() {
 //
 // !!TODO: simplify breakpoints parser to match help description.
 func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
+	newBreaks, err := bp.parseBreakers(tokens)
+	if err != nil {
+		return err
+	}
+
+	for _, nb := range newBreaks {
+		if i := bp.checkBreaker(nb); i != noBreakEqualivalent {
+			return curated.Errorf("already exists (%s)", bp.breaks[i])
+		}
+		bp.breaks = append(bp.breaks, nb)
+	}
+
+	return nil
+}
+
+// parseBreakers interprets the remaining tokens as a list of break conditions.
+// the returned breakers are not added to the list of breakpoints. the
+// LOGPOINT command shares the syntax of the BREAK command and so uses this
+// function to parse its conditions.
+func (bp *breakpoints) parseBreakers(tokens *commandline.Tokens) ([]breaker, error) {
 	andBreaks := false
 
 	// default target of CPU PC. meaning that "BREAK n" will cause a breakpoint
@@ -253,7 +302,7 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 	// something appropriate
 	tgt, err := parseTarget(bp.dbg, commandline.TokeniseInput("PC"))
 	if err != nil {
-		return curated.Errorf("breakpoint: this should not have failed: %v", err)
+		return nil, curated.Errorf("breakpoint: this should not have failed: %v", err)
 	}
 
 	// resolvedTarget keeps track of whether we have specified a target but not
@@ -301,7 +350,7 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 				err = curated.Errorf("invalid value (%s) for target (%s)", tok, tgt.Label)
 			}
 		default:
-			return curated.Errorf("unsupported value type (%T) for target (%s)", tgt.TargetValue(), tgt.Label())
+			return nil, curated.Errorf("unsupported value type (%T) for target (%s)", tgt.TargetValue(), tgt.Label())
 		}
 
 		if err == nil {
@@ -325,7 +374,7 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 		} else {
 			// make sure we've not left a previous target dangling without a value
 			if !resolvedTarget {
-				return curated.Errorf("%v", err)
+				return nil, curated.Errorf("%v", err)
 			}
 
 			// possibly switch composition mode
@@ -343,7 +392,7 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 				tokens.Unget()
 				tgt, err = parseTarget(bp.dbg, tokens)
 				if err != nil {
-					return curated.Errorf("%v", err)
+					return nil, curated.Errorf("%v", err)
 				}
 				resolvedTarget = false
 			}
@@ -353,10 +402,12 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 	}
 
 	if !resolvedTarget {
-		return curated.Errorf("need a value (%T) to break on (%s)", tgt.TargetValue(), tgt.Label)
+		return nil, curated.Errorf("need a value (%T) to break on (%s)", tgt.TargetValue(), tgt.Label)
 	}
 
-	for _, nb := range newBreaks {
+	for i := range newBreaks {
+		nb := &newBreaks[i]
+
 		// if the break is a singular, undecorated PC target then add a BANK
 		// condition for the current BANK. this is arguably what the user
 		// intends to happen.
@@ -369,14 +420,9 @@ func (bp *breakpoints) parseCommand(tokens *commandline.Tokens) error {
 				nb.next.ignoreValue = nb.next.value
 			}
 		}
-
-		if i := bp.checkBreaker(nb); i != noBreakEqualivalent {
-			return curated.Errorf("already exists (%s)", bp.breaks[i])
-		}
-		bp.breaks = append(bp.breaks, nb)
 	}
 
-	return nil
+	return newBreaks, nil
 }
 
 const noBreakEqualivalent = -1
@@ -0,0 +1,134 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+)
+
+// the build command for a cartridge is stored in a file alongside the
+// cartridge file. the name of the file is the same as the cartridge file but
+// with this extension.
+const buildFileExt = ".build"
+
+// buildFilename returns the name of the file containing the build command for
+// the attached cartridge.
+func (dbg *Debugger) buildFilename() (string, error) {
+	if dbg.VCS.Mem.Cart.IsEjected() {
+		return "", curated.Errorf("build: no cartridge attached")
+	}
+	fn := dbg.VCS.Mem.Cart.Filename
+	return fmt.Sprintf("%s%s", strings.TrimSuffix(fn, filepath.Ext(fn)), buildFileExt), nil
+}
+
+// setBuildCommand saves the program and arguments used to build the attached
+// cartridge. the build file contains one argument per line so that arguments
+// containing spaces are preserved.
+func (dbg *Debugger) setBuildCommand(program string, args []string) error {
+	fn, err := dbg.buildFilename()
+	if err != nil {
+		return err
+	}
+
+	s := strings.Builder{}
+	s.WriteString(program)
+	s.WriteString("\n")
+	for _, a := range args {
+		s.WriteString(a)
+		s.WriteString("\n")
+	}
+
+	err = ioutil.WriteFile(fn, []byte(s.String()), 0644)
+	if err != nil {
+		return curated.Errorf("build: %v", err)
+	}
+
+	return nil
+}
+
+// buildCommand returns the program and arguments used to build the attached
+// cartridge.
+func (dbg *Debugger) buildCommand() ([]string, error) {
+	fn, err := dbg.buildFilename()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return nil, curated.Errorf("build: no build command for cartridge (%s)", fn)
+	}
+
+	var cmd []string
+	for _, a := range strings.Split(string(d), "\n") {
+		a = strings.TrimRight(a, "\r")
+		if a != "" {
+			cmd = append(cmd, a)
+		}
+	}
+
+	if len(cmd) == 0 {
+		return nil, curated.Errorf("build: build file is empty (%s)", fn)
+	}
+
+	return cmd, nil
+}
+
+// build runs the build command for the attached cartridge and reloads the
+// cartridge if the command was successful. the command is run in the directory
+// containing the cartridge file. the output of the command is printed to the
+// terminal with any lines mentioning an error highlighted.
+func (dbg *Debugger) build() error {
+	c, err := dbg.buildCommand()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(c[0], c[1:]...)
+	cmd.Dir = filepath.Dir(dbg.VCS.Mem.Cart.Filename)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	dbg.printLine(terminal.StyleFeedback, "building: %s", strings.Join(c, " "))
+
+	err = cmd.Run()
+
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		if strings.Contains(strings.ToLower(scanner.Text()), "error") {
+			dbg.printLine(terminal.StyleError, "%s", scanner.Text())
+		} else {
+			dbg.printLine(terminal.StyleFeedback, "%s", scanner.Text())
+		}
+	}
+
+	if err != nil {
+		return curated.Errorf("build: %v", err)
+	}
+
+	return dbg.reloadCartridge()
+}
@@ -19,11 +19,13 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/jetsetilly/gopher2600/assembler"
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/debugger/script"
@@ -36,11 +38,16 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports/controllers"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports/savekey"
+	"github.com/jetsetilly/gopher2600/hardware/television"
 	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	"github.com/jetsetilly/gopher2600/linter"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/patch"
+	"github.com/jetsetilly/gopher2600/playmode"
+	"github.com/jetsetilly/gopher2600/score"
 	"github.com/jetsetilly/gopher2600/symbols"
+	"github.com/jetsetilly/gopher2600/typist"
 )
 
 var debuggerCommands *commandline.Commands
@@ -200,6 +207,13 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		case "VIDEO":
 			// changes quantum
 			dbg.quantum = QuantumVideo
+		case "UNTIL":
+			// does not change quantum
+			err := dbg.stepWatches.parseUntil(tokens)
+			if err != nil {
+				return err
+			}
+			dbg.runUntilHalt = true
 		default:
 			// does not change quantum
 			tokens.Unget()
@@ -307,6 +321,24 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			})
 		}
 
+	case cmdSession:
+		option, _ := tokens.Get()
+		name, _ := tokens.Get()
+		switch strings.ToUpper(option) {
+		case "SAVE":
+			err := dbg.saveSession(name)
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "session saved (%s)", name)
+		case "LOAD":
+			err := dbg.loadSession(name)
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "session resumed (%s)", name)
+		}
+
 	case cmdInsert:
 		cart, _ := tokens.Get()
 		err := dbg.attachCartridge(cartridgeloader.NewLoader(cart, "AUTO"))
@@ -315,6 +347,73 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		}
 		dbg.printLine(terminal.StyleFeedback, "machine reset with new cartridge (%s)", cart)
 
+	case cmdReload:
+		option, ok := tokens.Get()
+		if !ok {
+			return dbg.reloadCartridge()
+		}
+
+		switch strings.ToUpper(option) {
+		case "WATCH":
+			arg, _ := tokens.Get()
+			if strings.ToUpper(arg) == "ON" {
+				err := dbg.hotReload.startWatching(dbg.pushReload)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, "%v", err)
+					return nil
+				}
+			} else {
+				dbg.hotReload.stopWatching()
+			}
+		case "BREAKS":
+			arg, _ := tokens.Get()
+			dbg.hotReload.clearBreaks = strings.ToUpper(arg) == "CLEAR"
+		case "SCRIPT":
+			arg, _ := tokens.Get()
+			if strings.ToUpper(arg) == "OFF" {
+				dbg.hotReload.script = ""
+			} else {
+				dbg.hotReload.script = arg
+			}
+		case "STATUS":
+		default:
+			// already caught by command line ValidateTokens()
+		}
+
+		dbg.printLine(terminal.StyleFeedback, "reload: %s", dbg.hotReload.String())
+
+	case cmdBuild:
+		option, _ := tokens.Get()
+		switch strings.ToUpper(option) {
+		case "SET":
+			program, _ := tokens.Get()
+			args := []string{}
+			arg, ok := tokens.Get()
+			for ok {
+				args = append(args, arg)
+				arg, ok = tokens.Get()
+			}
+			err := dbg.setBuildCommand(program, args)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			fallthrough
+		case "SHOW":
+			c, err := dbg.buildCommand()
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "build command: %s", strings.Join(c, " "))
+		default:
+			err := dbg.build()
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+		}
+
 	case cmdCartridge:
 		arg, ok := tokens.Get()
 		if ok {
@@ -383,6 +482,19 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 
 	case cmdPatch:
 		f, _ := tokens.Get()
+
+		if strings.ToUpper(f) == "EXPORT" {
+			format, _ := tokens.Get()
+			filename, _ := tokens.Get()
+			n, err := dbg.exportPatch(strings.ToUpper(format), filename)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "%d changed bytes exported to %s", n, filename)
+			return nil
+		}
+
 		patched, err := patch.CartridgeMemory(dbg.VCS.Mem.Cart, f)
 		if err != nil {
 			dbg.printLine(terminal.StyleError, "%v", err)
@@ -395,6 +507,27 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.printLine(terminal.StyleFeedback, "cartridge patched")
 		}
 
+	case cmdStellaState:
+		action, _ := tokens.Get()
+		filename, _ := tokens.Get()
+
+		switch strings.ToUpper(action) {
+		case "EXPORT":
+			err := dbg.exportStellaState(filename)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "state exported to %s", filename)
+		case "IMPORT":
+			err := dbg.importStellaState(filename)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "state imported from %s", filename)
+		}
+
 	case cmdDisassembly:
 		bytecode := false
 		bank := -1
@@ -443,6 +576,8 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			scope = disassembly.GrepMnemonic
 		case "OPERAND":
 			scope = disassembly.GrepOperand
+		case "LABEL":
+			scope = disassembly.GrepLabel
 		default:
 			tokens.Unget()
 		}
@@ -847,6 +982,8 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 						targetVal = &dbg.VCS.CPU.Status.Carry
 					}
 
+					prev := *targetVal
+
 					switch action {
 					case "SET":
 						*targetVal = true
@@ -855,6 +992,13 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 					case "TOGGLE":
 						*targetVal = !*targetVal
 					}
+
+					if prev != *targetVal {
+						dbg.edits.record(fmt.Sprintf("CPU STATUS %s %v (was %v)", target, *targetVal, prev), func() error {
+							*targetVal = prev
+							return nil
+						})
+					}
 				} else {
 					dbg.printLine(terminal.StyleInstrument, dbg.VCS.CPU.Status.String())
 				}
@@ -871,7 +1015,12 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 						dbg.printLine(terminal.StyleError, "value must be a positive 16 bit number")
 					}
 
+					prev := dbg.VCS.CPU.PC.Address()
 					dbg.VCS.CPU.PC.Load(uint16(v))
+					dbg.edits.record(fmt.Sprintf("CPU SET PC %#04x (was %#04x)", v, prev), func() error {
+						dbg.VCS.CPU.PC.Load(prev)
+						return nil
+					})
 				} else {
 					// 6507 registers are 8 bit
 					v, err := strconv.ParseUint(value, 16, 8)
@@ -891,7 +1040,12 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 						reg = &dbg.VCS.CPU.SP
 					}
 
+					prev := reg.Value()
 					reg.Load(uint8(v))
+					dbg.edits.record(fmt.Sprintf("CPU SET %s %#02x (was %#02x)", reg.Label(), v, prev), func() error {
+						reg.Load(prev)
+						return nil
+					})
 				}
 
 			default:
@@ -918,6 +1072,133 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			a, ok = tokens.Get()
 		}
 
+	case cmdWho:
+		address, _ := tokens.Get()
+		dbg.printBlame(address)
+
+	case cmdScore:
+		option, ok := tokens.Get()
+		if !ok {
+			rs, err := dbg.Score.Read()
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			if len(rs) == 0 {
+				dbg.printLine(terminal.StyleFeedback, "no score fields")
+				return nil
+			}
+			for _, r := range rs {
+				dbg.printLine(terminal.StyleFeedback, "%s: %s", r.Label, r.Text)
+			}
+			if best, ok := dbg.Score.Best(); ok {
+				dbg.printLine(terminal.StyleFeedback, "best: %d", best)
+			}
+			return nil
+		}
+
+		switch strings.ToUpper(option) {
+		case "ADD":
+			label, _ := tokens.Get()
+			f, _ := tokens.Get()
+			format, err := score.ParseFormat(f)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+
+			field := score.Field{Label: label, Format: format}
+
+			a, ok := tokens.Get()
+			for ok {
+				ai := dbg.dbgmem.mapAddress(a, true)
+				if ai == nil {
+					dbg.printLine(terminal.StyleError, "invalid address (%s)", a)
+					return nil
+				}
+				field.Addresses = append(field.Addresses, ai.mappedAddress)
+				a, ok = tokens.Get()
+			}
+
+			err = dbg.Score.Add(field)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "%s", field)
+
+		case "CLEAR":
+			dbg.Score.Clear()
+		}
+
+	case cmdPolls:
+		option, ok := tokens.Get()
+		if !ok {
+			if !dbg.InputPolls.IsEnabled() {
+				dbg.printLine(terminal.StyleFeedback, "input poll monitor is off")
+				return nil
+			}
+			polls := dbg.InputPolls.LastFrame()
+			if len(polls) == 0 {
+				dbg.printLine(terminal.StyleFeedback, "no input polls in last frame")
+				return nil
+			}
+			for _, p := range polls {
+				dbg.printLine(terminal.StyleFeedback, "%s", p)
+			}
+			return nil
+		}
+
+		switch strings.ToUpper(option) {
+		case "ON":
+			dbg.InputPolls.Enable(true)
+			dbg.printLine(terminal.StyleFeedback, "input poll monitor is on")
+		case "OFF":
+			dbg.InputPolls.Enable(false)
+			dbg.printLine(terminal.StyleFeedback, "input poll monitor is off")
+		case "CLEAR":
+			dbg.InputPolls.Clear()
+		}
+
+	case cmdBus:
+		n := 16
+
+		option, ok := tokens.Get()
+		if ok {
+			switch strings.ToUpper(option) {
+			case "ON":
+				dbg.BusActivity.Enable(true)
+				dbg.printLine(terminal.StyleFeedback, "bus activity monitor is on")
+				return nil
+			case "OFF":
+				dbg.BusActivity.Enable(false)
+				dbg.printLine(terminal.StyleFeedback, "bus activity monitor is off")
+				return nil
+			case "CLEAR":
+				dbg.BusActivity.Clear()
+				return nil
+			default:
+				n, _ = strconv.Atoi(option)
+			}
+		}
+
+		if !dbg.BusActivity.IsEnabled() {
+			dbg.printLine(terminal.StyleFeedback, "bus activity monitor is off")
+			return nil
+		}
+
+		history := dbg.BusActivity.History()
+		if len(history) == 0 {
+			dbg.printLine(terminal.StyleFeedback, "no bus activity recorded")
+			return nil
+		}
+		if n < len(history) {
+			history = history[len(history)-n:]
+		}
+		for _, c := range history {
+			dbg.printLine(terminal.StyleFeedback, "%s", c)
+		}
+
 	case cmdPoke:
 		// get address token
 		a, _ := tokens.Get()
@@ -946,7 +1227,7 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				continue // for loop (without advancing address)
 			}
 
-			ai, err := dbg.dbgmem.poke(addr, uint8(val))
+			ai, err := dbg.pokeWithUndo(addr, uint8(val))
 			if err != nil {
 				dbg.printLine(terminal.StyleError, "%s", err)
 			} else {
@@ -958,11 +1239,86 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			addr++
 		}
 
+	case cmdAsm:
+		a, _ := tokens.Get()
+
+		ai := dbg.dbgmem.mapAddress(a, false)
+		if ai == nil {
+			dbg.printLine(terminal.StyleError, fmt.Sprintf(pokeError, a))
+			return nil
+		}
+
+		source := tokens.Remainder()
+		tokens.End()
+
+		lookup := func(symbol string) (uint16, bool) {
+			ok, _, _, addr := dbg.Disasm.Symbols.Search(symbol, symbols.UnspecifiedSymTable)
+			return addr, ok
+		}
+
+		lines, err := assembler.Assemble(ai.address, source, lookup)
+		if err != nil {
+			dbg.printLine(terminal.StyleError, "%s", err)
+			return nil
+		}
+
+		for _, l := range lines {
+			for i, b := range l.Bytes {
+				_, err := dbg.pokeWithUndo(l.Address+uint16(i), b)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, "%s", err)
+					return nil
+				}
+			}
+			dbg.printLine(terminal.StyleInstrument, l.String())
+		}
+
+	case cmdUndo:
+		arg, ok := tokens.Get()
+		if ok {
+			switch strings.ToUpper(arg) {
+			case "ALL":
+				err := dbg.edits.undoAll()
+				if err != nil {
+					return err
+				}
+				dbg.printLine(terminal.StyleFeedback, "all edits undone")
+			case "LIST":
+				dbg.edits.list()
+			default:
+				num, err := strconv.Atoi(arg)
+				if err != nil {
+					return curated.Errorf("edit number must be a number (%s)", arg)
+				}
+				err = dbg.edits.undo(num)
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			err := dbg.edits.undoLast()
+			if err != nil {
+				return err
+			}
+		}
+
 	case cmdRAM:
 		dbg.printLine(terminal.StyleInstrument, dbg.VCS.Mem.RAM.String())
 
 	case cmdTIA:
-		dbg.printLine(terminal.StyleInstrument, dbg.VCS.TIA.String())
+		arg, _ := tokens.Get()
+		switch arg {
+		case "FUTURES":
+			futures := dbg.VCS.TIA.Futures()
+			if len(futures) == 0 {
+				dbg.printLine(terminal.StyleFeedback, "no pending TIA events")
+			}
+			for _, f := range futures {
+				dbg.printLine(terminal.StyleInstrument, f.String())
+			}
+		default:
+			dbg.printLine(terminal.StyleInstrument, dbg.VCS.TIA.String())
+		}
 
 	case cmdRIOT:
 		arg, _ := tokens.Get()
@@ -997,6 +1353,44 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				s := strings.Builder{}
 				s.WriteString(spec.ID)
 				dbg.printLine(terminal.StyleInstrument, s.String())
+			case "FIXTURE":
+				filename, _ := tokens.Get()
+				err := dbg.exportTVFixture(filename)
+				if err != nil {
+					dbg.printLine(terminal.StyleError, "%v", err)
+					return nil
+				}
+				dbg.printLine(terminal.StyleFeedback, "television fixture exported to %s", filename)
+			case "AREA":
+				arg, ok := tokens.Get()
+				if ok {
+					if strings.ToUpper(arg) == "AUTO" {
+						dbg.tv.AutoVisibleArea()
+						dbg.printLine(terminal.StyleFeedback, "visible area will be detected automatically")
+					} else {
+						// numeric arguments already checked by ValidateTokens()
+						top, _ := strconv.Atoi(arg)
+						arg, _ = tokens.Get()
+						bottom, _ := strconv.Atoi(arg)
+						err := dbg.tv.SetVisibleArea(top, bottom)
+						if err != nil {
+							dbg.printLine(terminal.StyleError, "%v", err)
+							return nil
+						}
+						dbg.printLine(terminal.StyleFeedback, "visible area set to %d to %d", top, bottom)
+					}
+				} else {
+					top, bottom := dbg.tv.GetVisibleArea()
+					dbg.printLine(terminal.StyleInstrument, "visible area: %d to %d", top, bottom)
+				}
+			case "BADSYNC":
+				arg, ok := tokens.Get()
+				if ok {
+					// unknown modes already handled by ValidateTokens()
+					mode, _ := television.ParseBadSync(arg)
+					dbg.tv.SetBadSync(mode)
+				}
+				dbg.printLine(terminal.StyleInstrument, "bad sync: %s", dbg.tv.GetBadSync())
 			default:
 				// already caught by command line ValidateTokens()
 			}
@@ -1180,11 +1574,26 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 	case cmdController:
 		player, _ := tokens.Get()
 
+		if strings.ToUpper(player) == "SWAP" {
+			swap, err := playmode.SwapPorts(dbg.VCS)
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			if swap {
+				dbg.printLine(terminal.StyleFeedback, "ports swapped")
+			} else {
+				dbg.printLine(terminal.StyleFeedback, "ports not swapped")
+			}
+			return nil
+		}
+
+		// the left port is the player 0 port and the right port is the player
+		// 1 port
 		var id ports.PortID
-		switch player {
-		case "0":
+		switch strings.ToUpper(player) {
+		case "0", "LEFT":
 			id = ports.Player0ID
-		case "1":
+		case "1", "RIGHT":
 			id = ports.Player1ID
 		}
 
@@ -1201,6 +1610,8 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				err = dbg.VCS.RIOT.Ports.AttachPlayer(id, controllers.NewPaddle)
 			case "keyboard":
 				err = dbg.VCS.RIOT.Ports.AttachPlayer(id, controllers.NewKeyboard)
+			case "savekey":
+				err = dbg.VCS.RIOT.Ports.AttachPlayer(id, savekey.NewSaveKey)
 			}
 		}
 
@@ -1209,10 +1620,10 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		}
 
 		var p ports.Peripheral
-		switch player {
-		case "0":
+		switch id {
+		case ports.Player0ID:
 			p = dbg.VCS.RIOT.Ports.Player0
-		case "1":
+		case ports.Player1ID:
 			p = dbg.VCS.RIOT.Ports.Player1
 		}
 
@@ -1364,6 +1775,67 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			return err
 		}
 
+	case cmdType:
+		option, ok := tokens.Get()
+		if !ok {
+			dbg.printLine(terminal.StyleFeedback, "%d key presses pending", dbg.typist.Pending())
+			return nil
+		}
+
+		switch strings.ToUpper(option) {
+		case "KEYMAP":
+			filename, _ := tokens.Get()
+			if strings.ToUpper(filename) == "DEFAULT" {
+				dbg.typist.SetKeyMap(typist.DefaultKeyMap)
+				dbg.printLine(terminal.StyleFeedback, "using default keymap")
+				return nil
+			}
+
+			f, err := os.Open(filename)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "typist: %v", err)
+				return nil
+			}
+			defer f.Close()
+
+			km, err := typist.ReadKeyMap(f)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.typist.SetKeyMap(km)
+			dbg.printLine(terminal.StyleFeedback, "keymap loaded (%d characters)", len(km))
+
+		case "CLEAR":
+			err := dbg.typist.Clear()
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+
+		case "NEWLINE":
+			err := dbg.typist.Type("\n")
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+
+		default:
+			tokens.Unget()
+			text := []string{}
+			arg, ok := tokens.Get()
+			for ok {
+				text = append(text, arg)
+				arg, ok = tokens.Get()
+			}
+
+			err := dbg.typist.Type(strings.Join(text, " "))
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+		}
+
 	case cmdBreak:
 		err := dbg.breakpoints.parseCommand(tokens)
 		if err != nil {
@@ -1376,6 +1848,17 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			return curated.Errorf("%v", err)
 		}
 
+	case cmdCrashTrap:
+		condition, ok := tokens.Get()
+		if ok {
+			onOff, _ := tokens.Get()
+			err := dbg.crashTraps.set(condition, strings.ToUpper(onOff) != "OFF")
+			if err != nil {
+				return err
+			}
+		}
+		dbg.printLine(terminal.StyleFeedback, dbg.crashTraps.String())
+
 	case cmdWatch:
 		err := dbg.watches.parseCommand(tokens)
 		if err != nil {
@@ -1388,6 +1871,12 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			return curated.Errorf("%v", err)
 		}
 
+	case cmdLogpoint:
+		err := dbg.logpoints.parseCommand(tokens)
+		if err != nil {
+			return curated.Errorf("%v", err)
+		}
+
 	case cmdList:
 		list, _ := tokens.Get()
 		list = strings.ToUpper(list)
@@ -1400,11 +1889,14 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 			dbg.watches.list()
 		case "TRACES":
 			dbg.traces.list()
+		case "LOGPOINTS":
+			dbg.logpoints.list()
 		case "ALL":
 			dbg.breakpoints.list()
 			dbg.traps.list()
 			dbg.watches.list()
 			dbg.traces.list()
+			dbg.logpoints.list()
 		default:
 			// already caught by command line ValidateTokens()
 		}
@@ -1444,6 +1936,12 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				return err
 			}
 			dbg.printLine(terminal.StyleFeedback, "trace #%d dropped", num)
+		case "LOGPOINT":
+			err := dbg.logpoints.drop(num)
+			if err != nil {
+				return err
+			}
+			dbg.printLine(terminal.StyleFeedback, "logpoint #%d dropped", num)
 		default:
 			// already caught by command line ValidateTokens()
 		}
@@ -1464,12 +1962,16 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		case "TRACES":
 			dbg.traces.clear()
 			dbg.printLine(terminal.StyleFeedback, "traces cleared")
+		case "LOGPOINTS":
+			dbg.logpoints.clear()
+			dbg.printLine(terminal.StyleFeedback, "logpoints cleared")
 		case "ALL":
 			dbg.breakpoints.clear()
 			dbg.traps.clear()
 			dbg.watches.clear()
 			dbg.traces.clear()
-			dbg.printLine(terminal.StyleFeedback, "breakpoints, traps, watches and traces cleared")
+			dbg.logpoints.clear()
+			dbg.printLine(terminal.StyleFeedback, "breakpoints, traps, watches, traces and logpoints cleared")
 		default:
 			// already caught by command line ValidateTokens()
 		}
@@ -1529,6 +2031,43 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				return dbg.Rewind.Prefs.Freq.Set(freq)
 			}
 			return nil
+
+		case "STEREO":
+			var pan0, pan1 float64
+
+			option, _ := tokens.Get()
+			option = strings.ToUpper(option)
+			switch option {
+			case "MONO":
+				pan0 = 0.0
+				pan1 = 0.0
+			case "MOD":
+				pan0 = -1.0
+				pan1 = 1.0
+			case "PAN":
+				arg, _ := tokens.Get()
+				pan0, _ = strconv.ParseFloat(arg, 64)
+				arg, _ = tokens.Get()
+				pan1, _ = strconv.ParseFloat(arg, 64)
+			default:
+				dbg.printLine(terminal.StyleFeedback, fmt.Sprintf("channel 0: %s  channel 1: %s",
+					dbg.VCS.Prefs.AudioPan0.String(), dbg.VCS.Prefs.AudioPan1.String()))
+				return nil
+			}
+
+			if pan0 < -1.0 || pan0 > 1.0 || pan1 < -1.0 || pan1 > 1.0 {
+				return curated.Errorf("pan values must be between -1.0 and 1.0")
+			}
+
+			err := dbg.VCS.Prefs.AudioPan0.Set(pan0)
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			err = dbg.VCS.Prefs.AudioPan1.Set(pan1)
+			if err != nil {
+				return curated.Errorf("%v", err)
+			}
+			return nil
 		}
 
 		var err error
@@ -1556,6 +2095,16 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 				v := dbg.VCS.Prefs.RandomPins.Get().(bool)
 				err = dbg.VCS.Prefs.RandomPins.Set(!v)
 			}
+		case "LENIENTTIMER":
+			switch action {
+			case "SET":
+				err = dbg.VCS.Prefs.LenientTimer.Set(true)
+			case "UNSET":
+				err = dbg.VCS.Prefs.LenientTimer.Set(false)
+			case "TOGGLE":
+				v := dbg.VCS.Prefs.LenientTimer.Get().(bool)
+				err = dbg.VCS.Prefs.LenientTimer.Set(!v)
+			}
 		case "FXXXMIRROR":
 			switch action {
 			case "SET":
@@ -1619,6 +2168,140 @@ func (dbg *Debugger) processTokens(tokens *commandline.Tokens) error {
 		s.WriteString(fmt.Sprintf("  NumGC = %v", m.NumGC))
 
 		dbg.printLine(terminal.StyleLog, s.String())
+
+	case cmdMix:
+		option, _ := tokens.Get()
+		switch strings.ToUpper(option) {
+		case "ON":
+			dbg.InstructionMix.SetEnabled(true)
+		case "OFF":
+			dbg.InstructionMix.SetEnabled(false)
+		case "CLEAR":
+			dbg.InstructionMix.Clear()
+		case "WINDOW":
+			arg, _ := tokens.Get()
+			frames, _ := strconv.Atoi(arg)
+			err := dbg.InstructionMix.SetWindow(frames)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+		case "EXPORT":
+			filename, _ := tokens.Get()
+			err := dbg.exportInstructionMix(filename)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "instruction mix exported to %s", filename)
+		case "MODES":
+			dbg.printInstructionMix(dbg.InstructionMix.ByAddressingMode())
+		default:
+			dbg.printInstructionMix(dbg.InstructionMix.ByOpcode())
+		}
+
+	case cmdCompare:
+		option, _ := tokens.Get()
+		switch strings.ToUpper(option) {
+		case "RECORD":
+			filename, _ := tokens.Get()
+			arg, _ := tokens.Get()
+			frames, _ := strconv.Atoi(arg)
+			compress, _ := tokens.Get()
+			err := dbg.startTraceRecording(filename, frames, strings.ToUpper(compress) == "COMPRESS")
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "recording trace for %d frames to %s", frames, filename)
+		case "AGAINST":
+			filename, _ := tokens.Get()
+			err := dbg.startTraceComparison(filename)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+			dbg.printLine(terminal.StyleFeedback, "comparing emulation with trace %s", filename)
+		case "OFF":
+			dbg.stopCompare()
+		default:
+			dbg.printCompareStatus()
+		}
+
+	case cmdExec:
+		capture := false
+		program, _ := tokens.Get()
+		if strings.ToUpper(program) == "CAPTURE" {
+			capture = true
+			program, _ = tokens.Get()
+		}
+
+		args := []string{}
+		arg, ok := tokens.Get()
+		for ok {
+			args = append(args, arg)
+			arg, ok = tokens.Get()
+		}
+
+		err := dbg.execProgram(capture, program, args)
+		if err != nil {
+			dbg.printLine(terminal.StyleError, "%v", err)
+			return nil
+		}
+
+	case cmdEvent:
+		option, ok := tokens.Get()
+		if !ok {
+			option = "LIST"
+		}
+		switch strings.ToUpper(option) {
+		case "MEMORY":
+			label, _ := tokens.Get()
+			address, _ := tokens.Get()
+			err := dbg.addMemoryEvent(label, address)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+		case "COLLISION":
+			label, _ := tokens.Get()
+			register, _ := tokens.Get()
+			m, _ := tokens.Get()
+			mask, err := strconv.ParseUint(m, 0, 8)
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "invalid collision mask (%s)", m)
+				return nil
+			}
+			err = dbg.addCollisionEvent(label, register, uint8(mask))
+			if err != nil {
+				dbg.printLine(terminal.StyleError, "%v", err)
+				return nil
+			}
+		case "LIST":
+			s := dbg.eventMonitor.String()
+			if s == "" {
+				dbg.printLine(terminal.StyleFeedback, "no events being monitored (other than reset)")
+			} else {
+				for _, l := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+					dbg.printLine(terminal.StyleFeedback, "%s", l)
+				}
+			}
+			if dbg.haltOnEvent {
+				dbg.printLine(terminal.StyleFeedback, "halting on event")
+			}
+		case "CLEAR":
+			dbg.eventMonitor.Clear()
+		case "HALT":
+			arg, _ := tokens.Get()
+			switch strings.ToUpper(arg) {
+			case "ON":
+				dbg.haltOnEvent = true
+			case "OFF":
+				dbg.haltOnEvent = false
+			default:
+				dbg.haltOnEvent = !dbg.haltOnEvent
+			}
+		}
 	}
 
 	return nil
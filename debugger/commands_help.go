@@ -41,7 +41,15 @@ command.
 
 In the above example, the emulation will run until the next frame is reached.
 Think of target stepping as a single use trap. Note that breakpoints, watches
-and traps still trigger a halt during a target step.`,
+and traps still trigger a halt during a target step.
+
+The UNTIL argument steps until the CPU next writes to the named TIA or RIOT
+register. Writes to mirrors of the register also count.
+
+	STEP UNTIL GRP0
+	STEP UNTIL WSYNC
+
+Think of this as a single use WRITE watch.`,
 
 	cmdQuantum: `Change or view stepping quantum. The stepping quantum defines the frequency
 at which the emulation is checked and reported upon by the debugger.
@@ -76,15 +84,62 @@ comments.  Comments are line oriented and are indicated by the # character.`,
 be 'current' execution state. If numbered frame is not in rewind history,
 emulation will move to the nearest frame that is.`,
 
+	cmdSession: `Save or resume a named debugging session. A session consists of the cartridge,
+the current position in the emulation, the breakpoints, traps, watches, traces and
+logpoints, and the list of open windows if the GUI supports it.
+
+Loading a session reinserts the cartridge and runs the emulation from power-on to
+the saved position. The original state is only reproduced if the emulation is
+deterministic. ie. the RANDSTART and RANDPINS preferences should be unset and
+the session should not have relied on user input.`,
+
 	cmdInsert: `Insert cartridge into emulation. Cartridge names (with paths) beginning with
 http:// will loaded via the http protocol. If no such protocol is present, the
 cartridge will be loaded from disk.`,
 
+	cmdReload: `Reload the current cartridge from disk and reset the machine. Breakpoints and
+other halt conditions are preserved.
+
+The WATCH argument turns automatic reloading on or off. When on, the cartridge file is watched
+and the cartridge is reloaded whenever the file is modified. Useful when developing a ROM because
+the emulation is updated as soon as the ROM has been assembled.
+
+BREAKS CLEAR will cause breakpoints to be cleared when the cartridge is reloaded. SCRIPT specifies
+a script to run after every reload. STATUS shows the current reload settings.`,
+
+	cmdBuild: `Run the build command for the current cartridge and reload the cartridge if the
+build succeeds. The build command is run in the directory containing the cartridge. Output from
+the command is printed to the terminal.
+
+The build command is set with the SET argument. For example, BUILD SET dasm game.asm -f3 -ogame.bin
+The command is stored in a file alongside the cartridge file, with the extension .build, so it
+only needs to be set once for each project. SHOW displays the current build command.`,
+
 	cmdCartridge: `Display information about the current cartridge. Without arguments the command
 will show where the game was loaded from, the cartridge type and bank number. The BANK
 argument meanwhile can be used to switch banks (if possible).`,
 
-	cmdPatch: "Apply a patch file to the loaded cartridge",
+	cmdPatch: `Apply a patch file to the loaded cartridge. Patch files should be placed in the
+patches sub-directory of the resource path.
+
+Changes made to cartridge ROM (with the POKE and ASM commands for example) can be
+exported as an IPS patch file or as a complete copy of the modified ROM:
+
+	PATCH EXPORT IPS mygame.ips
+	PATCH EXPORT ROM mygame_modified.bin
+
+Existing files will not be overwritten. Cartridge formats that have data
+outside of the normal cartridge banks (eg. DPC) can not be exported.`,
+
+	cmdStellaState: `Export or import the emulation state as a Stella savestate file. Support for the
+Stella format is best-effort: only the CPU, RAM, RIOT timer and cartridge bank are included and only
+the 2k, 4k, F8, F6 and F4 cartridge formats are supported. The TIA state is not included.
+
+	STELLASTATE EXPORT mygame.sta
+	STELLASTATE IMPORT mygame.sta
+
+Existing files will not be overwritten. A savestate can only be imported for the cartridge for
+which it was created.`,
 
 	cmdDisassembly: `Display cartridge disassembly. By default, all banks will be displayed. Single
 banks can be displayed by specifying the bank number. Use BYTECODE to display raw bytes alongside
@@ -93,7 +148,7 @@ the disassembly.`,
 	cmdGrep: `Simple string search (case insensitive) of the disassembly. Prints all matching lines
 in the disassembly to the termain.
 
-The scope of the GREP can be restricted to the MNEMONIC and OPERAND columns. By
+The scope of the GREP can be restricted to the MNEMONIC, OPERAND and LABEL columns. By
 default GREP will consider the entire line.`,
 
 	cmdSymbol: `The SYMBOL command has two modes of operation. The first mode returns the address of
@@ -153,6 +208,63 @@ or numerically.`,
 	cmdPoke: `Modify an individual memory address. Addresses can be specified symbolically
 or numerically. Mulptiple data values will be poked into consecutive addresses.`,
 
+	cmdWho: `Show which instruction most recently wrote to a RAM address. Addresses can be specified
+symbolically or numerically. Writes are only recorded while the emulation is being run by the debugger.`,
+
+	cmdScore: `Interpret memory as the score or timer of the game. Without arguments the current value of
+each field is shown, along with the best score seen since the cartridge was attached.
+
+ADD takes a label, a format and the addresses of the bytes, most significant byte first. Each byte
+is two BCD digits. TIMER formats the bytes as minutes and seconds (two bytes) or as hours, minutes
+and seconds (three bytes). For example:
+
+	SCORE ADD P1 BCD 0x80 0x81 0x82
+
+Adding a field with an existing label replaces that field. CLEAR removes all fields. Fields
+are also read from the setup database when a cartridge is attached.`,
+
+	cmdPolls: `Show the reads of the input registers (INPT0 to INPT5, SWCHA and SWCHB) made during the
+most recently completed frame. Each read is shown with the instruction that made it and the
+television coordinates at the time of the read.
+
+The monitor is on by default. OFF stops recording and discards the recorded reads. CLEAR discards
+the recorded reads without stopping the monitor. Reads are only recorded while the emulation is
+being run by the debugger.`,
+
+	cmdBus: `Show the state of the address and data buses for the most recent CPU cycles. Each
+cycle is shown with the address on the 13 bit address bus, the value on the data bus, the
+state of the R/W line and the chip that responded to the address. Cycles in which the CPU
+did not access memory are shown as internal cycles and periods during which the CPU was
+halted by the TIA (a write to WSYNC) are shown as a single entry.
+
+By default the most recent 16 cycles are shown. A number argument changes how many cycles
+are shown, up to a maximum of 256.
+
+The monitor is on by default. OFF stops recording and discards the recorded cycles. CLEAR
+discards the recorded cycles without stopping the monitor. Cycles are only recorded while the
+emulation is being run by the debugger.`,
+
+	cmdAsm: `Assemble 6502 instructions into memory, starting at the specified address.
+Instructions are separated by a colon. For example:
+
+	ASM 0xf100 LDA #$05 : STA GRP0
+
+Operands can refer to symbols. Addresses in cartridge space will patch the
+ROM. Changes made with ASM can be undone with the UNDO command.`,
+
+	cmdUndo: `Undo changes made to the emulation with the POKE, ASM and CPU commands, or through
+the GUI. Without an argument the most recent change is undone. Changes can
+be undone individually by specifying the number of the edit reported by:
+
+	UNDO LIST
+
+All changes can be undone (in reverse order) with:
+
+	UNDO ALL
+
+The list of changes is forgotten when the machine is reset or when a new
+cartridge is inserted.`,
+
 	cmdRAM: `Display the current contents of RAM. The optional CART argument will display any
 additional RAM in the cartridge.`,
 
@@ -170,7 +282,11 @@ additional RAM in the cartridge.`,
                                      |
                cpu cycles -----------+
 
-Video and CPU cycles are counted from the beginning of the current scanline.`,
+Video and CPU cycles are counted from the beginning of the current scanline.
+
+The FUTURES argument lists the pending (delayed) events in the TIA. Each
+event shows what will happen, the value that will be applied and the number of
+color clocks remaining before it happens.`,
 
 	cmdRIOT: `Display current state of the RIOT. Without an argument the command will display
 information about the RIOT ports (SWCHA, etc.)`,
@@ -189,7 +305,22 @@ information about the RIOT ports (SWCHA, etc.)`,
 	cmdTV: `Display the current TV state. Optional argument SPEC will display the currently
 selected TV specification. Supplying an argument to the TV SPEC command will set the TV to that
 specification. AUTO indicates that the specification will change if the condition of the TV signal
-suggest that it should.`,
+suggest that it should. PAL60 is the PAL palette with NTSC timing.
+
+The FIXTURE argument writes the television signals for the most recently completed frame to a file.
+The file can be replayed into a television instance without the need for a ROM, which is useful for
+reproducing problems with the television in unit tests. Existing files will not be overwritten.
+
+The AREA argument displays the scanlines of the visible area of the screen. The visible area is
+usually detected automatically but it can be set by supplying the top and bottom scanline. The bottom
+scanline is not included in the visible area. Automatic detection is restored with AREA AUTO.
+
+The BADSYNC argument sets how the TV reacts to a frame that ends without a valid VSYNC, once the TV
+has become stable. FLYBACK silently returns to the top of the screen and synchronises with the next
+VSYNC whenever it occurs. This is the default. ROLL only synchronises with a VSYNC that occurs near
+the end of the frame, as a real CRT would, so the image will roll vertically if the ROM has lost sync.
+HALT behaves like FLYBACK but also halts the emulation with an error. Without an argument the current
+mode is displayed.`,
 
 	cmdPlayer: `Display the current state of the player sprites. The player information to
 display can be selected with 0 or 1 arguments. Omitting this argument will show
@@ -365,9 +496,17 @@ The NICK and ID can be changed for the session but also be saved to disk and be
 all PlusROM cartridges.`,
 
 	// user input
-	cmdController: `Change the current controller type for the specified player. The AUTO
-controller handles changes of controller according to user input and where possible what
-can be inferred from the ROM.`,
+	cmdController: `Change the current controller type for the specified player. The player can
+be specified with 0 or 1, or with LEFT or RIGHT. The AUTO controller handles changes of controller
+according to user input and where possible what can be inferred from the ROM.
+
+Controllers can be changed at any time. The state of the controllers is included in rewind
+snapshots, so rewinding to a point before the controller was changed will restore the previous
+controller.
+
+SWAP toggles whether user input for the left player is sent to the right port and vice versa. This
+is useful for games that expect the controller to be in the "wrong" port. The swap is not affected
+by rewinding.`,
 
 	cmdPanel: "Inspect and set front panel settings. Switches can be set or toggled.",
 
@@ -390,6 +529,19 @@ an error will be returned.
 
 Specify the player with the 0 or 1 arguments.`,
 
+	cmdType: `Type text using the keypad controllers. Each character is converted to a sequence of
+key presses according to the current keymap. Key presses are held and released over several frames
+so the ROM has time to see them, so the text will only be entered while the emulation is running.
+
+The default keymap contains only the characters printed on the keypad (0 to 9, * and #), which are
+typed on the left keypad. A keymap for a specific ROM, for example BASIC Programming, can be loaded
+with the KEYMAP argument. KEYMAP DEFAULT restores the default keymap. See the typist package
+documentation for the format of keymap files.
+
+NEWLINE types the newline character, which can not otherwise be entered from the command line.
+CLEAR discards any key presses that have not yet been sent. Without arguments the command shows the
+number of key presses waiting to be sent.`,
+
 	// halt conditions
 	cmdBreak: `Halt execution of the emulation when a specific value is "loaded" into a named
 target. A target is a part of the emulation hardware that can be interegated
@@ -439,6 +591,18 @@ for details).
 Existing traps can be reviewed with the LIST command and deleted with the
 DROP or CLEAR commands`,
 
+	cmdCrashTrap: `Cause emulator to halt on conditions that are common symptoms of a crashed ROM.
+The BRK argument halts on execution of a BRK instruction. The STACK argument halts when the stack
+pointer wraps around. The UNINIT argument halts on the first read of a RAM address that has not
+been written to since the cartridge was attached. ALL sets all three conditions.
+
+Each condition is turned on unless followed by OFF. Without arguments the current conditions are
+printed. When a crash trap is triggered the television coordinates and the most recently executed
+instructions are printed.
+
+Some ROMs use the BRK instruction deliberately and some do not initialise all of RAM, so not every
+trap is a sign of a crash.`,
+
 	cmdWatch: `Watch a memory address for activity. Emulation will halt when the watch
 is triggered. An individual watch can wait for either read access or write
 access of specific address address. Addresses can be specified numerically or
@@ -500,12 +664,78 @@ There is currently no ONTRACE command.
 Generally, WATCH is a more flexible instrument but TRACE can be useful to quickly gather information
 about an address.`,
 
-	cmdList:  "List currently defined BREAKS, TRAPS, WATCHES and TRACES.",
-	cmdDrop:  "Drop a specific BREAK, TRAP, WATCH or TRACE condition, using the number of the condition reported by LIST.",
-	cmdClear: "Clear all BREAKS, TRAPS, WATCHES and TRACES.",
+	cmdLogpoint: `Print a message to the terminal whenever the specified conditions are met.
+Unlike BREAK, the emulation is not halted. The conditions are specified in
+exactly the same way as for BREAK. For example:
+
+	LOGPOINT "starting frame" SL 0
+
+The message can contain the current value of any target or memory address by
+placing the target or address in curly braces. For example:
+
+	LOGPOINT "scanline {SL} A={A} lives={0x80}" 0xf010
+
+As with BREAK, a message will be printed only once until the conditions change
+and then match again.
+
+Existing logpoints can be reviewed with the LIST command and deleted with the
+DROP or CLEAR commands`,
+
+	cmdList:  "List currently defined BREAKS, TRAPS, WATCHES, TRACES and LOGPOINTS.",
+	cmdDrop:  "Drop a specific BREAK, TRAP, WATCH, TRACE or LOGPOINT condition, using the number of the condition reported by LIST.",
+	cmdClear: "Clear all BREAKS, TRAPS, WATCHES, TRACES and LOGPOINTS.",
+
+	cmdEvent: `Monitor the emulation for events. Events are printed to the terminal and forwarded to the GUI, which
+may flash the screen in response. A game reset is always detected. Other events must be requested.
+
+The MEMORY argument creates an event whenever the value at the address changes. Memory is checked once
+per frame. The COLLISION argument creates an event whenever any of the bits in the mask become set in
+the collision register. For example, 'EVENT COLLISION hit CXM0P 0x80'.
+
+Monitored events can be listed with LIST and removed with CLEAR. HALT ON will halt the emulation when an
+event occurs. HALT without an argument toggles this behaviour. Use the ONHALT command to run commands
+when this happens.`,
+
+	cmdMix: `Count executed CPU instructions, grouped by opcode, over a window of frames. Counting is OFF by default.
+The window is 60 frames by default and can be changed with the WINDOW argument. Without arguments the opcodes
+executed in the window are listed, most frequent first. The MODES argument groups the counts by addressing mode
+instead. CLEAR discards all counts.
+
+The instruction mix can be written to a CSV file with the EXPORT argument. Existing files will not be overwritten.`,
+
+	cmdCompare: `Record an execution trace or compare the emulation with a previously recorded trace. The trace
+records the address of every executed instruction, the CPU registers after execution and the position
+of the television.
+
+The RECORD argument begins recording to a new file for the specified number of frames. The COMPRESS
+argument compresses the trace. Existing files will not be overwritten.
+
+The AGAINST argument compares every instruction with the trace in the named file. The emulation halts
+at the first difference, which is the point at which the two runs diverged. Comparison is most useful
+when both runs begin from the same state, for example immediately after a RESET. Rewinding during a
+comparison will cause a difference to be reported.
+
+OFF ends the recording or comparison. Without arguments the current state is printed.`,
+
+	cmdExec: `Run an external program. The debugger waits for the program to finish. With the CAPTURE argument the
+output of the program is printed to the terminal, otherwise it is discarded.
+
+Arguments to the program can refer to targets and memory addresses in the same way as LOGPOINT messages.
+The references are replaced with their current value before the program is run. For example:
+
+	EXEC CAPTURE echo "frame {FR} PC {PC} lives {0x80}"`,
 
 	// meta
-	cmdPrefs: "Set preferences for debugger.",
+	cmdPrefs: `Set preferences for debugger.
+
+The STEREO option controls the stereo position of the two TIA audio channels. MONO centres both channels, as on an
+unmodified console. MOD pans channel 0 hard left and channel 1 hard right, as with the popular "stereo mod". PAN sets
+each channel individually: -1.0 is hard left, 0.0 is centre and 1.0 is hard right. STEREO on its own prints the current
+pan values.
+
+The LENIENTTIMER option changes how the TIMINT flag of the RIOT timer is cleared. By default, reading or writing the
+timer in the cycle immediately after the timer underflows does not clear the flag, as on real hardware. With
+LENIENTTIMER set the flag is always cleared.`,
 	cmdLog: `Print log to terminal. The LAST argument will cause the most recent log entry to be printed.
 
 Note that while "ONSTEP LOG LAST" is a valid construct it may not print what you expect - it will always print the last
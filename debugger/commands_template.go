@@ -26,10 +26,14 @@ const (
 	cmdQuantum = "QUANTUM"
 	cmdScript  = "SCRIPT"
 	cmdRewind  = "REWIND"
+	cmdSession = "SESSION"
 
 	cmdInsert      = "INSERT"
+	cmdReload      = "RELOAD"
+	cmdBuild       = "BUILD"
 	cmdCartridge   = "CARTRIDGE"
 	cmdPatch       = "PATCH"
+	cmdStellaState = "STELLASTATE"
 	cmdDisassembly = "DISASSEMBLY"
 	cmdLint        = "LINT"
 	cmdGrep        = "GREP"
@@ -42,6 +46,12 @@ const (
 	cmdCPU         = "CPU"
 	cmdPeek        = "PEEK"
 	cmdPoke        = "POKE"
+	cmdWho         = "WHO"
+	cmdScore       = "SCORE"
+	cmdPolls       = "POLLS"
+	cmdBus         = "BUS"
+	cmdAsm         = "ASM"
+	cmdUndo        = "UNDO"
 	cmdRAM         = "RAM"
 	cmdTIA         = "TIA"
 	cmdRIOT        = "RIOT"
@@ -60,20 +70,27 @@ const (
 	cmdPanel      = "PANEL"
 	cmdStick      = "STICK"
 	cmdKeyboard   = "KEYBOARD"
+	cmdType       = "TYPE"
 
 	// halt conditions.
-	cmdBreak = "BREAK"
-	cmdTrap  = "TRAP"
-	cmdWatch = "WATCH"
-	cmdTrace = "TRACE"
-	cmdList  = "LIST"
-	cmdDrop  = "DROP"
-	cmdClear = "CLEAR"
+	cmdBreak     = "BREAK"
+	cmdTrap      = "TRAP"
+	cmdCrashTrap = "CRASHTRAP"
+	cmdWatch     = "WATCH"
+	cmdTrace     = "TRACE"
+	cmdLogpoint  = "LOGPOINT"
+	cmdList      = "LIST"
+	cmdDrop      = "DROP"
+	cmdClear     = "CLEAR"
+	cmdEvent     = "EVENT"
 
 	// meta.
 	cmdPrefs    = "PREFS"
 	cmdLog      = "LOG"
 	cmdMemUsage = "MEMUSAGE"
+	cmdMix      = "MIX"
+	cmdCompare  = "COMPARE"
+	cmdExec     = "EXEC"
 )
 
 const cmdHelp = "HELP"
@@ -83,18 +100,22 @@ var commandTemplate = []string{
 	cmdQuit,
 
 	cmdRun,
-	cmdStep + " (CPU|VIDEO|%<target>S)",
+	cmdStep + " (CPU|VIDEO|UNTIL [%<register>S]|%<target>S)",
 	cmdHalt,
 	cmdQuantum + " (CPU|VIDEO)",
 	cmdScript + " [RECORD %<new file>F|END|%<file>F]",
 	cmdRewind + " [%<frame>N|LAST|SUMMARY]",
+	cmdSession + " [SAVE|LOAD] %<name>S",
 
 	cmdInsert + " %<cartridge>F",
+	cmdReload + " (WATCH [ON|OFF]|BREAKS [KEEP|CLEAR]|SCRIPT [OFF|%<file>F]|STATUS)",
+	cmdBuild + " (SET %<program>S {%<arguments>S}|SHOW)",
 	cmdCartridge + " (BANK|STATIC|REGISTERS|RAM)",
-	cmdPatch + " %<patch file>S",
+	cmdPatch + " [EXPORT [IPS|ROM] %<file>S|%<patch file>S]",
+	cmdStellaState + " [EXPORT|IMPORT] %<file>S",
 	cmdDisassembly + " (BYTECODE) (%<bank num>N)",
 	cmdLint,
-	cmdGrep + " (MNEMONIC|OPERAND) %<search>S",
+	cmdGrep + " (MNEMONIC|OPERAND|LABEL) %<search>S",
 	cmdSymbol + " [LIST (LABELS|READ|WRITE)|%<symbol>S (ALL|MIRRORS)]",
 	cmdOnHalt + " (OFF|ON|%<command>S {%<commands>S})",
 	cmdOnStep + " (OFF|ON|%<command>S {%<commands>S})",
@@ -104,11 +125,17 @@ var commandTemplate = []string{
 	cmdCPU + " (STATUS ([SET|UNSET|TOGGLE] [S|O|B|D|I|Z|C])|(SET [PC|A|X|Y|SP] [%<register value>S]))",
 	cmdPeek + " [%<address>S] {%<addresses>S}",
 	cmdPoke + " %<address>S [%<value>N] {%<values>N}",
+	cmdWho + " %<address>S",
+	cmdScore + " (ADD %<label>S [BCD|TIMER] %<address>S {%<address>S}|CLEAR)",
+	cmdPolls + " (ON|OFF|CLEAR)",
+	cmdBus + " (ON|OFF|CLEAR|%<cycles>N)",
+	cmdAsm + " %<address>S [%<statement>S] {%<statement>S}",
+	cmdUndo + " (ALL|LIST|%<edit number>N)",
 	cmdRAM,
-	cmdTIA,
+	cmdTIA + " (FUTURES)",
 	cmdRIOT + " (PORTS|TIMER)",
 	cmdAudio,
-	cmdTV + " (SPEC (PAL|NTSC|AUTO))",
+	cmdTV + " (SPEC (PAL|PAL60|NTSC|AUTO)|FIXTURE %<file>S|AREA (AUTO|%<top>N %<bottom>N)|BADSYNC (FLYBACK|ROLL|HALT))",
 	cmdPlayer + " (0|1)",
 	cmdMissile + " (0|1)",
 	cmdBall,
@@ -119,25 +146,32 @@ var commandTemplate = []string{
 	cmdPlusROM + " (NICK [%<name>S]|ID [%<id>S]|HOST [%<host>S]|PATH [%<path>S])",
 
 	// user input
-	cmdController + " [0|1] (AUTO|STICK|PADDLE|KEYBOARD)",
+	cmdController + " [SWAP|[0|1|LEFT|RIGHT] (AUTO|STICK|PADDLE|KEYBOARD|SAVEKEY)]",
 	cmdPanel + " (SET [P0PRO|P1PRO|P0AM|P1AM|COL|BW]|TOGGLE [P0|P1|COL]|[HOLD|RELEASE] [SELECT|RESET])",
 	cmdStick + " [0|1] [LEFT|RIGHT|UP|DOWN|FIRE|NOLEFT|NORIGHT|NOUP|NODOWN|NOFIRE]",
 	cmdKeyboard + " [0|1] [none|0|1|2|3|4|5|6|7|8|9|*|#]",
+	cmdType + " (KEYMAP [DEFAULT|%<file>F]|CLEAR|NEWLINE|%<text>S {%<text>S})",
 
 	// halt conditions
 	cmdBreak + " [%<pc value>S|%<target>S %<value>N] {& %<value>S|%<target>S %<value>S}",
 
 	cmdTrap + " [%<target>S] {%<targets>S}",
+	cmdCrashTrap + " ([BRK|STACK|UNINIT|ALL] (ON|OFF))",
 	cmdWatch + " (READ|WRITE) (MIRRORS|ANY) [%<address>S] (%<value>S)",
 	cmdTrace + " (%<address>S)",
-	cmdList + " [BREAKS|TRAPS|WATCHES|TRACES|ALL]",
-	cmdDrop + " [BREAK|TRAP|WATCH|TRACE] %<number in list>N",
-	cmdClear + " [BREAKS|TRAPS|WATCHES|TRACES|ALL]",
+	cmdLogpoint + " %<message>S [%<pc value>S|%<target>S %<value>N] {& %<value>S|%<target>S %<value>S}",
+	cmdList + " [BREAKS|TRAPS|WATCHES|TRACES|LOGPOINTS|ALL]",
+	cmdDrop + " [BREAK|TRAP|WATCH|TRACE|LOGPOINT] %<number in list>N",
+	cmdClear + " [BREAKS|TRAPS|WATCHES|TRACES|LOGPOINTS|ALL]",
+	cmdEvent + " (MEMORY %<label>S %<address>S|COLLISION %<label>S %<register>S %<mask>S|LIST|CLEAR|HALT (ON|OFF))",
 
 	// emulation
-	cmdPrefs + " ([LOAD|SAVE]|[SET|UNSET|TOGGLE] [RANDSTART|RANDPINS|FXXXMIRROR|SYMBOLS]|REWIND [MAX %<entries>N|FREQ %<frames>N])",
+	cmdPrefs + " ([LOAD|SAVE]|[SET|UNSET|TOGGLE] [RANDSTART|RANDPINS|LENIENTTIMER|FXXXMIRROR|SYMBOLS]|REWIND [MAX %<entries>N|FREQ %<frames>N]|STEREO [MONO|MOD|PAN %<channel 0>P %<channel 1>P])",
 	cmdLog + " (LAST|RECENT|CLEAR)",
 	cmdMemUsage,
+	cmdMix + " (ON|OFF|CLEAR|MODES|WINDOW %<frames>N|EXPORT %<file>S)",
+	cmdCompare + " (RECORD %<file>S %<frames>N (COMPRESS)|AGAINST %<file>S|OFF)",
+	cmdExec + " (CAPTURE) %<program>S {%<arguments>S}",
 }
 
 // list of commands that should not be executed when recording/playing scripts.
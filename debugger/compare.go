@@ -0,0 +1,226 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"io"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/cputrace"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// compareRuns records an execution trace or compares the emulation with a
+// previously recorded trace. COMPARE command.
+type compareRuns struct {
+	// the trace file. nil if no recording or comparison is taking place
+	file     *os.File
+	filename string
+
+	// only one of writer and reader will be non-nil at any one time
+	writer *cputrace.Writer
+	reader *cputrace.Reader
+
+	// recording ends at the start of this frame
+	endFrame int
+}
+
+func (cmp *compareRuns) isActive() bool {
+	return cmp.file != nil
+}
+
+// end the recording or comparison. the trace file is closed.
+func (cmp *compareRuns) end() error {
+	if cmp.file == nil {
+		return nil
+	}
+
+	var err error
+	if cmp.writer != nil {
+		err = cmp.writer.Close()
+	}
+	if cerr := cmp.file.Close(); err == nil {
+		err = cerr
+	}
+
+	cmp.file = nil
+	cmp.writer = nil
+	cmp.reader = nil
+
+	return err
+}
+
+// startTraceRecording begins recording an execution trace to a new file. the
+// recording continues for the specified number of frames. existing files will
+// not be overwritten.
+func (dbg *Debugger) startTraceRecording(filename string, frames int, compress bool) error {
+	if filename == "" {
+		return curated.Errorf("no filename specified")
+	}
+	if frames <= 0 {
+		return curated.Errorf("number of frames must be greater than zero")
+	}
+
+	err := dbg.compare.end()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return curated.Errorf("file already exists (%s)", filename)
+		}
+		return err
+	}
+
+	w, err := cputrace.NewWriter(f, compress)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	dbg.compare.file = f
+	dbg.compare.filename = filename
+	dbg.compare.writer = w
+	dbg.compare.endFrame = dbg.tv.GetState(signal.ReqFramenum) + frames
+
+	return nil
+}
+
+// startTraceComparison begins comparing the emulation with the execution trace
+// in the named file.
+func (dbg *Debugger) startTraceComparison(filename string) error {
+	if filename == "" {
+		return curated.Errorf("no filename specified")
+	}
+
+	err := dbg.compare.end()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+
+	r, err := cputrace.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	dbg.compare.file = f
+	dbg.compare.filename = filename
+	dbg.compare.reader = r
+
+	return nil
+}
+
+// stopCompare ends any recording or comparison and prints a summary.
+func (dbg *Debugger) stopCompare() {
+	if !dbg.compare.isActive() {
+		dbg.printLine(terminal.StyleFeedback, "no trace is being recorded or compared")
+		return
+	}
+
+	if dbg.compare.writer != nil {
+		dbg.printLine(terminal.StyleFeedback, "trace recording stopped: %d instructions written to %s",
+			dbg.compare.writer.Count, dbg.compare.filename)
+	} else {
+		dbg.printLine(terminal.StyleFeedback, "trace comparison stopped: %d instructions matched",
+			dbg.compare.reader.Count)
+	}
+
+	err := dbg.compare.end()
+	if err != nil {
+		dbg.printLine(terminal.StyleError, "%v", err)
+	}
+}
+
+// printCompareStatus prints the state of the recording or comparison.
+func (dbg *Debugger) printCompareStatus() {
+	if !dbg.compare.isActive() {
+		dbg.printLine(terminal.StyleFeedback, "no trace is being recorded or compared")
+		return
+	}
+
+	if dbg.compare.writer != nil {
+		dbg.printLine(terminal.StyleFeedback, "recording trace to %s: %d instructions, ending at frame %d",
+			dbg.compare.filename, dbg.compare.writer.Count, dbg.compare.endFrame)
+	} else {
+		dbg.printLine(terminal.StyleFeedback, "comparing with trace %s: %d instructions matched",
+			dbg.compare.filename, dbg.compare.reader.Count)
+	}
+}
+
+// checkCompare should be called after every completed CPU instruction. the
+// emulation is halted when the emulation diverges from the trace being
+// compared.
+func (dbg *Debugger) checkCompare() {
+	if !dbg.compare.isActive() {
+		return
+	}
+
+	e := cputrace.Entry{
+		Frame:    int32(dbg.tv.GetState(signal.ReqFramenum)),
+		Scanline: int16(dbg.tv.GetState(signal.ReqScanline)),
+		HorizPos: int16(dbg.tv.GetState(signal.ReqHorizPos)),
+		PC:       dbg.VCS.CPU.LastResult.Address,
+		A:        dbg.VCS.CPU.A.Value(),
+		X:        dbg.VCS.CPU.X.Value(),
+		Y:        dbg.VCS.CPU.Y.Value(),
+		SP:       dbg.VCS.CPU.SP.Value(),
+		Status:   dbg.VCS.CPU.Status.Value(),
+	}
+
+	if dbg.compare.writer != nil {
+		if int(e.Frame) >= dbg.compare.endFrame {
+			dbg.stopCompare()
+			return
+		}
+
+		err := dbg.compare.writer.Write(e)
+		if err != nil {
+			dbg.printLine(terminal.StyleError, "%v", err)
+			_ = dbg.compare.end()
+		}
+		return
+	}
+
+	expected, err := dbg.compare.reader.Next()
+	if err != nil {
+		if err == io.EOF {
+			dbg.printLine(terminal.StyleFeedback, "end of trace: all %d instructions matched", dbg.compare.reader.Count)
+		} else {
+			dbg.printLine(terminal.StyleError, "%v", err)
+		}
+		_ = dbg.compare.end()
+		dbg.haltImmediately = true
+		return
+	}
+
+	if diff := expected.Diff(e); diff != "" {
+		dbg.printLine(terminal.StyleError, "divergence at instruction %d: %s", dbg.compare.reader.Count, diff)
+		dbg.printLine(terminal.StyleError, "  expected: %s", expected)
+		dbg.printLine(terminal.StyleError, "  actual:   %s", e)
+		_ = dbg.compare.end()
+		dbg.haltImmediately = true
+	}
+}
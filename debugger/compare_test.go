@@ -0,0 +1,33 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testCompare() {
+	trm.sndInput("COMPARE")
+	trm.cmpOutput("no trace is being recorded or compared")
+
+	trm.sndInput("COMPARE OFF")
+	trm.cmpOutput("no trace is being recorded or compared")
+
+	trm.sndInput("COMPARE RECORD trace 0")
+	trm.cmpOutput("number of frames must be greater than zero")
+
+	trm.sndInput("COMPARE AGAINST non_existent_trace")
+	trm.cmpOutput("open non_existent_trace: no such file or directory")
+
+	trm.sndInput("COMPARE")
+	trm.cmpOutput("no trace is being recorded or compared")
+}
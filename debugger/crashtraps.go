@@ -0,0 +1,207 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/cputrace"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// the number of instructions printed when a crash trap is triggered.
+const crashTraceLength = 8
+
+// crashTraps halt the emulation on conditions that are common symptoms of a
+// crashed ROM. CRASHTRAP command.
+//
+// unlike traps and breakpoints, crash traps are checked at the end of every
+// instruction, regardless of the quantum.
+type crashTraps struct {
+	// the conditions being trapped
+	brk    bool
+	stack  bool
+	uninit bool
+
+	// the most recently executed instructions. a circular buffer
+	recent    [crashTraceLength]string
+	recentIdx int
+
+	// the value of the stack pointer before the most recent instruction was
+	// executed
+	lastSP uint8
+
+	// RAM addresses for which an uninitialised read has already been reported.
+	// an address is only reported once
+	reported [memorymap.MemtopRAM - memorymap.OriginRAM + 1]bool
+}
+
+func (ct *crashTraps) isActive() bool {
+	return ct.brk || ct.stack || ct.uninit
+}
+
+// clear recent instruction history and the list of reported addresses. the
+// conditions being trapped are unchanged.
+func (ct *crashTraps) clear() {
+	for i := range ct.recent {
+		ct.recent[i] = ""
+	}
+	for i := range ct.reported {
+		ct.reported[i] = false
+	}
+}
+
+func (ct *crashTraps) String() string {
+	if !ct.isActive() {
+		return "no crash traps"
+	}
+
+	s := strings.Builder{}
+	s.WriteString("crash traps:")
+	if ct.brk {
+		s.WriteString(" BRK")
+	}
+	if ct.stack {
+		s.WriteString(" STACK")
+	}
+	if ct.uninit {
+		s.WriteString(" UNINIT")
+	}
+	return s.String()
+}
+
+// set crash trap condition. condition can be BRK, STACK, UNINIT or ALL.
+func (ct *crashTraps) set(condition string, on bool) error {
+	switch strings.ToUpper(condition) {
+	case "BRK":
+		ct.brk = on
+	case "STACK":
+		ct.stack = on
+	case "UNINIT":
+		ct.uninit = on
+	case "ALL":
+		ct.brk = on
+		ct.stack = on
+		ct.uninit = on
+	default:
+		return curated.Errorf("unrecognised crash trap (%s)", condition)
+	}
+	return nil
+}
+
+// crashTrapEntry returns a cputrace entry for the current state of the emulation.
+func (dbg *Debugger) crashTrapEntry() cputrace.Entry {
+	return cputrace.Entry{
+		Frame:    int32(dbg.tv.GetState(signal.ReqFramenum)),
+		Scanline: int16(dbg.tv.GetState(signal.ReqScanline)),
+		HorizPos: int16(dbg.tv.GetState(signal.ReqHorizPos)),
+		PC:       dbg.VCS.CPU.LastResult.Address,
+		A:        dbg.VCS.CPU.A.Value(),
+		X:        dbg.VCS.CPU.X.Value(),
+		Y:        dbg.VCS.CPU.Y.Value(),
+		SP:       dbg.VCS.CPU.SP.Value(),
+		Status:   dbg.VCS.CPU.Status.Value(),
+	}
+}
+
+// triggerCrashTrap prints the reason for the trap and the recent instruction
+// history, and halts the emulation.
+func (dbg *Debugger) triggerCrashTrap(reason string) {
+	dbg.printLine(terminal.StyleError, "crash trap: %s", reason)
+	dbg.printLine(terminal.StyleError, "  at %s", dbg.tv.GetCoords())
+
+	dbg.printLine(terminal.StyleFeedback, "recent instructions:")
+	ct := &dbg.crashTraps
+	for i := 0; i < crashTraceLength; i++ {
+		s := ct.recent[(ct.recentIdx+i)%crashTraceLength]
+		if s != "" {
+			dbg.printLine(terminal.StyleFeedback, "  %s", s)
+		}
+	}
+
+	dbg.haltImmediately = true
+}
+
+// checkCrashTraps should be called after every completed CPU instruction and
+// after lastResult has been updated.
+func (dbg *Debugger) checkCrashTraps() {
+	ct := &dbg.crashTraps
+	if !ct.isActive() {
+		return
+	}
+
+	res := dbg.VCS.CPU.LastResult
+	sp := dbg.VCS.CPU.SP.Value()
+	lastSP := ct.lastSP
+
+	if res.Defn == nil {
+		return
+	}
+
+	ct.recent[ct.recentIdx] = fmt.Sprintf("%s  %s %s", dbg.crashTrapEntry(), dbg.lastResult.Mnemonic, dbg.lastResult.Operand)
+	ct.recentIdx = (ct.recentIdx + 1) % crashTraceLength
+
+	if ct.brk && res.Defn.Mnemonic == "BRK" {
+		dbg.triggerCrashTrap(fmt.Sprintf("BRK instruction at %#04x", res.Address))
+		return
+	}
+
+	// no instruction moves the stack pointer by more than three, except for
+	// TXS which can set it to any value. a larger movement means that the
+	// stack pointer has wrapped around. the stack grows downwards so an
+	// increase in the stack pointer is an overflow
+	if ct.stack && res.Defn.Mnemonic != "TXS" {
+		d := int(sp) - int(lastSP)
+		if d > 3 {
+			dbg.triggerCrashTrap(fmt.Sprintf("stack overflow (SP %#02x->%#02x) at %#04x", lastSP, sp, res.Address))
+			return
+		} else if d < -3 {
+			dbg.triggerCrashTrap(fmt.Sprintf("stack underflow (SP %#02x->%#02x) at %#04x", lastSP, sp, res.Address))
+			return
+		}
+	}
+
+	// the last memory access of an instruction that reads a value is the
+	// read of that value. checking only the last access excludes the phantom
+	// reads that happen during the execution of many instructions
+	if ct.uninit && res.Defn.Effect == instructions.Read {
+		mem := dbg.VCS.Mem
+		if mem.LastAccessWrite {
+			return
+		}
+
+		_, area := memorymap.MapAddress(mem.LastAccessAddressMapped, true)
+		if area != memorymap.RAM {
+			return
+		}
+
+		// RAM blame records every write to RAM since the cartridge was
+		// attached
+		idx := mem.LastAccessAddressMapped - memorymap.OriginRAM
+		if ct.reported[idx] || dbg.ramBlame.entries[idx].Valid {
+			return
+		}
+		ct.reported[idx] = true
+
+		dbg.triggerCrashTrap(fmt.Sprintf("read of uninitialised RAM (%#04x) by %s at %#04x",
+			mem.LastAccessAddressMapped, res.Defn.Mnemonic, res.Address))
+	}
+}
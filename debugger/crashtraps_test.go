@@ -0,0 +1,36 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testCrashTraps() {
+	trm.sndInput("CRASHTRAP")
+	trm.cmpOutput("no crash traps")
+
+	trm.sndInput("CRASHTRAP BRK")
+	trm.cmpOutput("crash traps: BRK")
+
+	trm.sndInput("CRASHTRAP UNINIT ON")
+	trm.cmpOutput("crash traps: BRK UNINIT")
+
+	trm.sndInput("CRASHTRAP BRK OFF")
+	trm.cmpOutput("crash traps: UNINIT")
+
+	trm.sndInput("CRASHTRAP ALL")
+	trm.cmpOutput("crash traps: BRK STACK UNINIT")
+
+	trm.sndInput("CRASHTRAP ALL OFF")
+	trm.cmpOutput("no crash traps")
+}
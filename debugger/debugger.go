@@ -20,12 +20,15 @@ import (
 	"os/signal"
 	"strings"
 
+	"github.com/jetsetilly/gopher2600/busactivity"
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/debugger/script"
 	"github.com/jetsetilly/gopher2600/debugger/terminal"
 	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
 	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/eventbus"
+	"github.com/jetsetilly/gopher2600/framehealth"
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
@@ -36,11 +39,15 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports/savekey"
 	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/inputpolls"
+	"github.com/jetsetilly/gopher2600/instructionmix"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/reflection"
 	"github.com/jetsetilly/gopher2600/rewind"
+	"github.com/jetsetilly/gopher2600/score"
 	"github.com/jetsetilly/gopher2600/setup"
 	"github.com/jetsetilly/gopher2600/symbols"
+	"github.com/jetsetilly/gopher2600/typist"
 )
 
 // Debugger is the basic debugging frontend for the emulation. In order to be
@@ -71,16 +78,27 @@ type Debugger struct {
 	// required
 	reflect *reflection.Monitor
 
+	// checks the television signal for non-standard sync. warnings are sent
+	// to the log
+	frameHealth *framehealth.Checker
+
 	// halt conditions
 	breakpoints *breakpoints
 	traps       *traps
 	watches     *watches
 	traces      *traces
+	logpoints   *logpoints
+
+	// user modifications to the emulation that can be undone
+	edits *edits
 
 	// single-fire step traps. these are used for the STEP command, allowing
 	// things like "STEP FRAME".
 	stepTraps *traps
 
+	// single use watches. used by STEP UNTIL
+	stepWatches *watches
+
 	// commandOnHalt is the sequence of commands that runs when emulation
 	// halts
 	commandOnHalt       []*commandline.Tokens
@@ -108,6 +126,42 @@ type Debugger struct {
 	Rewind    *rewind.Rewind
 	rewinding chan bool
 
+	// counts of executed instructions. counting is off by default
+	InstructionMix *instructionmix.Mix
+
+	// emulation events are published to the Events bus. the eventMonitor
+	// watches the emulation for the events that have been requested by the
+	// EVENT command
+	Events       *eventbus.Bus
+	eventMonitor *eventbus.Monitor
+
+	// halt the emulation when an event is published
+	haltOnEvent bool
+
+	// the instruction that most recently wrote to each RAM address
+	ramBlame *ramBlame
+
+	// reloads the cartridge when the cartridge file is modified
+	hotReload hotReload
+
+	// converts text to keypad presses. TYPE command
+	typist *typist.Typist
+
+	// reads the score from memory. SCORE command
+	Score *score.Tracker
+
+	// records reads of the input registers. POLLS command
+	InputPolls *inputpolls.Monitor
+
+	// records the state of the address and data buses for recent CPU cycles
+	BusActivity *busactivity.Monitor
+
+	// records or compares execution traces. COMPARE command
+	compare compareRuns
+
+	// halts the emulation on common symptoms of a crashed ROM. CRASHTRAP command
+	crashTraps crashTraps
+
 	// \/\/\/ inputLoop \/\/\/
 
 	// is current inputloop inside a video cycle
@@ -145,6 +199,12 @@ type Debugger struct {
 func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal, useSavekey bool) (*Debugger, error) {
 	var err error
 
+	// the debugger relies on signal history and reflection, neither of which
+	// are available when the television is not rendering
+	if tv.IsNoRender() {
+		return nil, curated.Errorf("debugger: not available when television is in no-render mode")
+	}
+
 	dbg := &Debugger{
 		tv:   tv,
 		scr:  scr,
@@ -187,6 +247,11 @@ func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal,
 		dbg.tv.AddReflector(dbg.reflect)
 	}
 
+	// check frame health of ROMs being debugged
+	dbg.frameHealth = framehealth.NewChecker(dbg.tv, func(w framehealth.Warning) {
+		logger.Log("frame health", w.String())
+	})
+
 	// plug in rewind system
 	dbg.Rewind, err = rewind.NewRewind(dbg.VCS, dbg)
 	if err != nil {
@@ -194,6 +259,34 @@ func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal,
 	}
 	dbg.rewinding = make(chan bool, 1)
 
+	// instruction mix profiler
+	dbg.InstructionMix = instructionmix.NewMix()
+	dbg.tv.AddFrameTrigger(dbg.InstructionMix)
+
+	// text input for keypad controllers
+	dbg.typist = typist.NewTypist(dbg.VCS)
+	dbg.tv.AddFrameTrigger(dbg.typist)
+
+	// score readout
+	dbg.Score = score.NewTracker(dbg.VCS.Mem)
+	dbg.tv.AddFrameTrigger(dbg.Score)
+
+	// input register reads
+	dbg.InputPolls = inputpolls.NewMonitor(dbg.VCS)
+	dbg.tv.AddFrameTrigger(dbg.InputPolls)
+
+	// bus activity
+	dbg.BusActivity = busactivity.NewMonitor(dbg.VCS)
+
+	// record television signals so that the most recent frame can be
+	// exported as a fixture with the TV FIXTURE command
+	dbg.tv.SetFrameRecording(true)
+
+	// emulation events
+	dbg.Events = eventbus.NewBus()
+	dbg.eventMonitor = eventbus.NewMonitor(dbg.VCS, dbg.Events)
+	dbg.Events.Subscribe(dbg.eventHandler)
+
 	// set up breakpoints/traps
 	dbg.breakpoints, err = newBreakpoints(dbg)
 	if err != nil {
@@ -202,7 +295,11 @@ func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal,
 	dbg.traps = newTraps(dbg)
 	dbg.watches = newWatches(dbg)
 	dbg.traces = newTraces(dbg)
+	dbg.logpoints = newLogpoints(dbg)
 	dbg.stepTraps = newTraps(dbg)
+	dbg.stepWatches = newWatches(dbg)
+	dbg.edits = newEdits(dbg)
+	dbg.ramBlame = newRAMBlame(dbg)
 
 	// make synchronisation channels
 	//
@@ -219,8 +316,7 @@ func NewDebugger(tv *television.Television, scr gui.GUI, term terminal.Terminal,
 		GuiEvents:       make(chan gui.Event, 10),
 		GuiEventHandler: dbg.guiEventHandler,
 		IntEvents:       make(chan os.Signal, 1),
-		RawEvents:       make(chan func(), 1024),
-		RawEventsReturn: make(chan func(), 1024),
+		RawEvents:       make(chan terminal.RawEvent, rawEventsQueueLen),
 	}
 
 	// connect Interrupt signal to dbg.events.intChan
@@ -289,6 +385,14 @@ func (dbg *Debugger) Start(initScript string, cartload cartridgeloader.Loader) e
 		}
 	}()
 
+	// make sure trace files are complete
+	defer func() {
+		err := dbg.compare.end()
+		if err != nil {
+			logger.Log("debugger", err.Error())
+		}
+	}()
+
 	// inputloop will continue until debugger is to be terminated
 	done := false
 	for !done {
@@ -325,6 +429,7 @@ func (dbg *Debugger) reset() error {
 		return err
 	}
 	dbg.Rewind.Reset()
+	dbg.edits.clear()
 	dbg.lastResult = &disassembly.Entry{Result: execution.Result{Final: true}}
 	dbg.printLine(terminal.StyleFeedback, "machine reset")
 	return nil
@@ -392,6 +497,35 @@ func (dbg *Debugger) attachCartridge(cartload cartridgeloader.Loader) error {
 	// attaching a new cartridge always causes the rewind system to reset
 	dbg.Rewind.Reset()
 
+	// edits made to the previous cartridge cannot be undone
+	dbg.edits.clear()
+
+	// RAM blame information refers to the previous cartridge
+	dbg.ramBlame.clear()
+
+	// as does the crash trap history. the crash trap conditions are kept
+	dbg.crashTraps.clear()
+
+	// as do input polls
+	dbg.InputPolls.Clear()
+	dbg.BusActivity.Clear()
+
+	// score fields also refer to the previous cartridge. replace them with
+	// the fields in the setupDB for the new cartridge, if there are any
+	dbg.Score.Clear()
+	scoreFields, err := setup.ScoreFields(dbg.VCS.Mem.Cart.Hash)
+	if err != nil {
+		logger.Log("score", err.Error())
+	}
+	for _, f := range scoreFields {
+		if err := dbg.Score.Add(f); err != nil {
+			logger.Log("score", err.Error())
+		}
+	}
+
+	// note cartridge for reloading
+	dbg.hotReload.attached(cartload)
+
 	symbols, err := symbols.ReadSymbolsFile(dbg.VCS.Mem.Cart)
 	if err != nil {
 		logger.Log("symbols", err.Error())
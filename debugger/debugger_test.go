@@ -70,10 +70,19 @@ func (trm *mockTerm) RegisterTabCompletion(_ terminal.TabCompletion) {
 func (trm *mockTerm) Silence(silenced bool) {
 }
 
-func (trm *mockTerm) TermRead(buffer []byte, _ terminal.Prompt, _ *terminal.ReadEvents) (int, error) {
-	s := <-trm.inp
-	copy(buffer, s)
-	return len(s) + 1, nil
+func (trm *mockTerm) TermRead(buffer []byte, _ terminal.Prompt, events *terminal.ReadEvents) (int, error) {
+	for {
+		select {
+		case s := <-trm.inp:
+			copy(buffer, s)
+			return len(s) + 1, nil
+		case ev := <-events.RawEvents:
+			ev.Fn()
+			if ev.Return {
+				return 0, nil
+			}
+		}
+	}
 }
 
 func (trm *mockTerm) TermReadCheck() bool {
@@ -140,6 +149,22 @@ func (trm *mockTerm) testSequence() {
 	trm.testBreakpoints()
 	trm.testTraps()
 	trm.testWatches()
+	trm.testStepUntil()
+	trm.testLogpoints()
+	trm.testExec()
+	trm.testWho()
+	trm.testSession()
+	trm.testAsm()
+	trm.testReload()
+	trm.testBuild()
+	trm.testType()
+	trm.testScore()
+	trm.testController()
+	trm.testPolls()
+	trm.testBusActivity()
+	trm.testCompare()
+	trm.testCrashTraps()
+	trm.testTV()
 }
 
 func TestDebugger_withNonExistantInitScript(t *testing.T) {
@@ -60,4 +60,29 @@
 // debugger, is through the Terminal interface (see terminal package). Where
 // this is not possible, functions have been provided. For interaction from
 // other goroutines, the PushRawEvent() function should be used.
+//
+// Goroutines
+//
+// The emulation and the debugger run in the same goroutine. No other goroutine
+// should read or write the state of the emulation directly. Instead, a
+// function should be pushed onto the raw event queue with one of:
+//
+//	PushRawEvent()
+//	PushRawEventReturn()
+//	PushRawEventLowPriority()
+//
+// All three functions use the same bounded queue. Functions are run in the
+// order they were pushed, either by the input loop or by the terminal while it
+// waits for user input.
+//
+// If the queue is full PushRawEvent() and PushRawEventReturn() will wait for
+// a short time before dropping the event. PushRawEventLowPriority() never
+// waits and will drop the event if the queue is nearly full, leaving space for
+// the higher priority events. Low priority events are intended for events
+// that are pushed every GUI frame, such as those used by the lazyvalues
+// package in sdlimgui.
+//
+// Values that are sent from the emulation to the GUI should be sent with the
+// GUI's SetFeature() function, or through a type designed to be shared, such
+// as the atomic values used by the lazyvalues package.
 package debugger
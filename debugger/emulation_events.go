@@ -0,0 +1,59 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/eventbus"
+	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+)
+
+// eventHandler is subscribed to the debugger's event bus. events are printed
+// to the terminal and forwarded to the GUI.
+func (dbg *Debugger) eventHandler(n eventbus.Notification) {
+	dbg.printLine(terminal.StyleFeedback, "event: %s", n)
+
+	// not all GUIs will support emulation events so we ignore any error
+	_ = dbg.scr.SetFeature(gui.ReqEmulationEvent, n)
+
+	if dbg.haltOnEvent {
+		dbg.haltImmediately = true
+	}
+}
+
+// addMemoryEvent adds a memory condition to the event monitor. the address
+// can be numeric or symbolic.
+func (dbg *Debugger) addMemoryEvent(label string, address string) error {
+	ai := dbg.dbgmem.mapAddress(address, true)
+	if ai == nil {
+		return curated.Errorf("invalid memory address (%s)", address)
+	}
+	return dbg.eventMonitor.AddMemoryCondition(label, ai.address)
+}
+
+// addCollisionEvent adds a collision register to the event monitor. the
+// register must be one of the TIA collision registers.
+func (dbg *Debugger) addCollisionEvent(label string, register string, mask uint8) error {
+	address, ok := addresses.ReadAddress[strings.ToUpper(register)]
+	if !ok {
+		return curated.Errorf("unrecognised collision register (%s)", register)
+	}
+	return dbg.eventMonitor.AddCollision(label, address, mask)
+}
@@ -126,11 +126,10 @@ func (dbg *Debugger) checkEvents() error {
 			}
 
 		case ev := <-dbg.events.RawEvents:
-			ev()
-
-		case ev := <-dbg.events.RawEventsReturn:
-			ev()
-			return nil
+			ev.Fn()
+			if ev.Return {
+				return nil
+			}
 
 		default:
 			return nil
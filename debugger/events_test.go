@@ -0,0 +1,104 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/debugger"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// pushes raw events from several goroutines while the emulation is running.
+// best run with the race detector:
+//
+//	go test -race -run Debugger_rawEvents ./debugger
+func TestDebugger_rawEvents(t *testing.T) {
+	prefs.DisableSaving = true
+
+	trm := newMockTerm(t)
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbg, err := debugger.NewDebugger(tv, &mockGUI{}, trm, false)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	const numPushers = 4
+	const numEvents = 200
+
+	var pushed int32
+	var serviced int32
+
+	go func() {
+		defer func() { trm.sndInput("QUIT") }()
+
+		trm.sndInput("BREAK FRAME 10")
+		trm.sndInput("RUN")
+
+		var wg sync.WaitGroup
+		for i := 0; i < numPushers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < numEvents; j++ {
+					ok := dbg.PushRawEvent(func() {
+						// accessing emulation state is safe inside a raw event
+						_ = dbg.VCS.CPU.PC.Address()
+						atomic.AddInt32(&serviced, 1)
+					})
+					if ok {
+						atomic.AddInt32(&pushed, 1)
+					}
+
+					// low priority events can be dropped and are not counted
+					_ = dbg.PushRawEventLowPriority(func() {
+						_ = dbg.VCS.TV.GetState(0)
+					})
+				}
+			}()
+		}
+		wg.Wait()
+
+		// wait for all pushed events to be serviced
+		timeout := time.After(5 * time.Second)
+		for atomic.LoadInt32(&serviced) < atomic.LoadInt32(&pushed) {
+			select {
+			case <-timeout:
+				t.Errorf("raw events not serviced (%d of %d)", atomic.LoadInt32(&serviced), atomic.LoadInt32(&pushed))
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+
+		if atomic.LoadInt32(&pushed) != numPushers*numEvents {
+			t.Errorf("normal priority raw events have been dropped (%d of %d)", atomic.LoadInt32(&pushed), numPushers*numEvents)
+		}
+	}()
+
+	err = dbg.Start("", cartridgeloader.Loader{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+}
@@ -0,0 +1,60 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"bufio"
+	"bytes"
+	"os/exec"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+)
+
+// execProgram runs an external program and waits for it to finish. values in
+// the arguments are interpolated in the same way as logpoint messages. the
+// output of the program is printed to the terminal if capture is true.
+func (dbg *Debugger) execProgram(capture bool, program string, args []string) error {
+	var ip interpolation
+	for i := range args {
+		err := ip.parse(dbg, args[i], "exec argument")
+		if err != nil {
+			return err
+		}
+		args[i] = ip.format(dbg)
+	}
+
+	cmd := exec.Command(program, args...)
+
+	var out bytes.Buffer
+	if capture {
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+	}
+
+	err := cmd.Run()
+
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		dbg.printLine(terminal.StyleFeedback, "%s", scanner.Text())
+	}
+
+	if err != nil {
+		return curated.Errorf("exec: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import "runtime"
+
+func (trm *mockTerm) testExec() {
+	// the echo program is not available on all platforms
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	trm.sndInput("EXEC CAPTURE echo hello")
+	trm.cmpOutput("hello")
+
+	// output is discarded without the CAPTURE argument
+	trm.sndInput("EXEC echo hello")
+	trm.cmpOutput("")
+
+	// values are interpolated in the same way as logpoints
+	trm.sndInput("EXEC CAPTURE echo \"frame {FR}\"")
+	trm.cmpOutput("frame 0")
+
+	trm.sndInput("EXEC CAPTURE echo \"{FOO}\"")
+	trm.cmpOutput("unrecognised value in exec argument (FOO)")
+}
@@ -0,0 +1,46 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"os"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// exportTVFixture writes the television signals of the most recently
+// completed frame to a file. existing files will not be overwritten.
+func (dbg *Debugger) exportTVFixture(filename string) error {
+	if filename == "" {
+		return curated.Errorf("no filename specified")
+	}
+
+	fx, err := dbg.tv.Fixture()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return curated.Errorf("file already exists (%s)", filename)
+		}
+		return err
+	}
+	defer f.Close()
+
+	return fx.Write(f)
+}
@@ -0,0 +1,195 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// how often the cartridge file is checked for modification.
+const hotReloadPoll = 250 * time.Millisecond
+
+// hotReload watches the file of the attached cartridge and reloads the
+// cartridge when the file is modified. useful when developing a ROM because
+// the debugger does not need to be restarted after every assembly.
+type hotReload struct {
+	crit sync.Mutex
+
+	// the cartridge that is currently attached. the filename is watched and
+	// the cartridge is reloaded with the same mapping
+	filename string
+	mapping  string
+
+	// breakpoints are cleared on reload if clearBreaks is true. the script
+	// is run after every reload if it is not empty
+	clearBreaks bool
+	script      string
+
+	// closing the quit channel stops the watcher goroutine. the channel is nil
+	// if the watcher is not running
+	quit chan bool
+}
+
+// attached is called whenever a new cartridge is attached.
+func (hr *hotReload) attached(cartload cartridgeloader.Loader) {
+	hr.crit.Lock()
+	defer hr.crit.Unlock()
+	hr.filename = cartload.Filename
+	hr.mapping = cartload.Mapping
+}
+
+// loader returns a new cartridgeloader.Loader for the attached cartridge.
+func (hr *hotReload) loader() cartridgeloader.Loader {
+	hr.crit.Lock()
+	defer hr.crit.Unlock()
+	return cartridgeloader.NewLoader(hr.filename, hr.mapping)
+}
+
+func (hr *hotReload) String() string {
+	hr.crit.Lock()
+	defer hr.crit.Unlock()
+
+	s := "not watching"
+	if hr.quit != nil {
+		s = fmt.Sprintf("watching %s", hr.filename)
+	}
+	if hr.clearBreaks {
+		s = fmt.Sprintf("%s, clearing breakpoints on reload", s)
+	}
+	if hr.script != "" {
+		s = fmt.Sprintf("%s, running %s on reload", s, hr.script)
+	}
+	return s
+}
+
+// modTime returns the modification time of the attached cartridge file.
+func (hr *hotReload) modTime() (time.Time, error) {
+	hr.crit.Lock()
+	fn := hr.filename
+	hr.crit.Unlock()
+
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fi.ModTime(), nil
+}
+
+// startWatching the cartridge file. the reload function is called (in the
+// watcher goroutine) when the file has changed.
+func (hr *hotReload) startWatching(reload func()) error {
+	if hr.quit != nil {
+		return nil
+	}
+
+	last, err := hr.modTime()
+	if err != nil {
+		return curated.Errorf("reload: %v", err)
+	}
+
+	hr.quit = make(chan bool)
+
+	go func(quit chan bool) {
+		// the file is reloaded only when the modification time has been stable
+		// for a complete poll period. this prevents reloading of partially
+		// written files
+		var pending bool
+
+		for {
+			select {
+			case <-quit:
+				return
+			case <-time.After(hotReloadPoll):
+			}
+
+			t, err := hr.modTime()
+			if err != nil {
+				// file may be in the process of being replaced
+				continue // for loop
+			}
+
+			if !t.Equal(last) {
+				last = t
+				pending = true
+			} else if pending {
+				pending = false
+				reload()
+			}
+		}
+	}(hr.quit)
+
+	return nil
+}
+
+func (hr *hotReload) stopWatching() {
+	if hr.quit == nil {
+		return
+	}
+	close(hr.quit)
+	hr.quit = nil
+}
+
+// reloadCartridge attaches the current cartridge again. the file will be read
+// from disk so any changes to the file will be reflected in the emulation.
+func (dbg *Debugger) reloadCartridge() error {
+	cartload := dbg.hotReload.loader()
+
+	err := dbg.attachCartridge(cartload)
+	if err != nil {
+		return err
+	}
+
+	if dbg.hotReload.clearBreaks {
+		dbg.breakpoints.clear()
+	}
+
+	dbg.printLine(terminal.StyleFeedback, "machine reset with reloaded cartridge (%s)", cartload.Filename)
+
+	if dbg.hotReload.script != "" {
+		err = dbg.parseInput(fmt.Sprintf("%s %s", cmdScript, dbg.hotReload.script), false, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pushReload is the reload function used by the hotReload watcher. the reload
+// happens in the debugger goroutine and in the case of the video cycle input
+// loop, once the loop has been unwound.
+func (dbg *Debugger) pushReload() {
+	dbg.PushRawEventReturn(func() {
+		if dbg.isVideoCycleInputLoop {
+			dbg.restartInputLoop(dbg.reloadCartridge)
+			return
+		}
+
+		err := dbg.reloadCartridge()
+		if err != nil {
+			dbg.printLine(terminal.StyleError, "%v", err)
+			logger.Log("reload", err.Error())
+		}
+	})
+}
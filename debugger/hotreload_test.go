@@ -0,0 +1,45 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+import "runtime"
+
+func (trm *mockTerm) testReload() {
+	trm.sndInput("RELOAD STATUS")
+	trm.cmpOutput("reload: not watching")
+
+	trm.sndInput("RELOAD BREAKS CLEAR")
+	trm.cmpOutput("reload: not watching, clearing breakpoints on reload")
+
+	trm.sndInput("RELOAD SCRIPT reload_script")
+	trm.cmpOutput("reload: not watching, clearing breakpoints on reload, running reload_script on reload")
+
+	trm.sndInput("RELOAD BREAKS KEEP")
+	trm.cmpOutput("reload: not watching, running reload_script on reload")
+
+	trm.sndInput("RELOAD SCRIPT OFF")
+	trm.cmpOutput("reload: not watching")
+
+	// there is no cartridge file to watch. the error message is not the same
+	// on all platforms
+	if runtime.GOOS != "windows" {
+		trm.sndInput("RELOAD WATCH ON")
+		trm.cmpOutput("reload: stat : no such file or directory")
+	}
+
+	trm.sndInput("RELOAD WATCH OFF")
+	trm.cmpOutput("reload: not watching")
+}
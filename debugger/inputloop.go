@@ -94,6 +94,7 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 		}
 
 		var stepTrapMessage string
+		var stepWatchMessage string
 		var breakMessage string
 		var trapMessage string
 		var watchMessage string
@@ -108,10 +109,14 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 			trapMessage = dbg.traps.check(trapMessage)
 			watchMessage = dbg.watches.check(watchMessage)
 			stepTrapMessage = dbg.stepTraps.check("")
+			stepWatchMessage = dbg.stepWatches.check("")
+
+			// logpoints print their messages immediately and never halt
+			dbg.logpoints.check()
 		}
 
 		// check for halt conditions
-		haltEmulation := stepTrapMessage != "" || breakMessage != "" ||
+		haltEmulation := stepTrapMessage != "" || stepWatchMessage != "" || breakMessage != "" ||
 			trapMessage != "" || watchMessage != "" ||
 			dbg.lastStepError || dbg.haltImmediately
 
@@ -123,9 +128,10 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 
 		// if emulation is to be halted or if we need to check the terminal
 		if haltEmulation {
-			// always clear steptraps. if the emulation has halted for any
-			// reason then any existing step trap is stale.
+			// always clear steptraps and stepwatches. if the emulation has
+			// halted for any reason then any existing step trap is stale.
 			dbg.stepTraps.clear()
+			dbg.stepWatches.clear()
 
 			// print and reset accumulated break/trap/watch messages
 			dbg.printLine(terminal.StyleFeedback, breakMessage)
@@ -190,7 +196,7 @@ func (dbg *Debugger) inputLoop(inputter terminal.Input, videoCycle bool) error {
 			if dbg.runUntilHalt {
 				// unpause TV/GUI if there are no step traps. unpausing a TV/GUI when
 				// stepping by scanline, for example, looks ugly
-				if dbg.stepTraps.isEmpty() {
+				if dbg.stepTraps.isEmpty() && dbg.stepWatches.isEmpty() {
 					err = dbg.tv.Pause(false)
 					if err != nil {
 						return err
@@ -310,6 +316,10 @@ func (dbg *Debugger) readTerminal(inputter terminal.Input) error {
 
 func (dbg *Debugger) contEmulation(inputter terminal.Input) error {
 	quantumCPU := func() error {
+		dbg.ramBlame.check()
+		dbg.InputPolls.Check()
+		dbg.BusActivity.Check()
+
 		if dbg.reflect == nil {
 			return nil
 		}
@@ -354,6 +364,10 @@ func (dbg *Debugger) contEmulation(inputter terminal.Input) error {
 	// to happen before we call the VCS.Step() function
 	dbg.lastBank = dbg.VCS.Mem.Cart.GetBank(dbg.VCS.CPU.PC.Address())
 
+	// the stack pointer is also noted before the instruction is executed. the
+	// crash traps use it to detect stack pointer wraparound
+	dbg.crashTraps.lastSP = dbg.VCS.CPU.SP.Value()
+
 	// not using the err variable because we'll clobber it before we
 	// get to check the result of VCS.Step()
 	var stepErr error
@@ -436,12 +450,18 @@ func (dbg *Debugger) contEmulation(inputter terminal.Input) error {
 	} else if dbg.VCS.CPU.LastResult.Final {
 		var err error
 
+		dbg.InstructionMix.Record(dbg.VCS.CPU.LastResult)
+		dbg.eventMonitor.Check()
+		dbg.checkCompare()
+
 		// update entry and store result as last result
 		dbg.lastResult, err = dbg.Disasm.ExecutedEntry(dbg.lastBank, dbg.VCS.CPU.LastResult, dbg.VCS.CPU.PC.Value())
 		if err != nil {
 			return err
 		}
 
+		dbg.checkCrashTraps()
+
 		// check validity of instruction result
 		err = dbg.VCS.CPU.LastResult.IsValid()
 		if err != nil {
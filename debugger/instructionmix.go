@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/instructionmix"
+)
+
+// the maximum number of entries printed by the MIX command.
+const maxMixEntries = 20
+
+// printInstructionMix prints the most frequent entries of the instruction mix
+// to the terminal.
+func (dbg *Debugger) printInstructionMix(entries []instructionmix.Entry) {
+	if !dbg.InstructionMix.IsEnabled() {
+		dbg.printLine(terminal.StyleFeedback, "instruction mix is off")
+	}
+
+	if len(entries) == 0 {
+		dbg.printLine(terminal.StyleFeedback, "no instructions in window (%d frames)", dbg.InstructionMix.Window())
+		return
+	}
+
+	s := strings.Builder{}
+	for i, e := range entries {
+		if i >= maxMixEntries {
+			s.WriteString(fmt.Sprintf("... and %d more", len(entries)-maxMixEntries))
+			break
+		}
+		if e.Defn != nil {
+			s.WriteString(fmt.Sprintf("$%02x %s %-8s", e.Defn.OpCode, e.Defn.Mnemonic, e.AddressingMode))
+		} else {
+			s.WriteString(fmt.Sprintf("%-17s", e.AddressingMode))
+		}
+		s.WriteString(fmt.Sprintf(" %10d %6.2f%%  %10d cycles %6.2f%%\n", e.Count, e.CountPercent, e.Cycles, e.CyclesPercent))
+	}
+
+	dbg.printLine(terminal.StyleFeedback, strings.TrimSuffix(s.String(), "\n"))
+}
+
+// exportInstructionMix writes the instruction mix to a CSV file. existing
+// files will not be overwritten.
+func (dbg *Debugger) exportInstructionMix(filename string) error {
+	if filename == "" {
+		return curated.Errorf("no filename specified")
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return curated.Errorf("file already exists (%s)", filename)
+		}
+		return err
+	}
+	defer f.Close()
+
+	return dbg.InstructionMix.WriteCSV(f)
+}
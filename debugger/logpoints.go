@@ -0,0 +1,243 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// logpoints are similar to breakpoints except that they do not halt the
+// emulation. instead, a message is printed to the terminal every time the
+// condition matches. the message can contain references to targets and
+// memory addresses, which are replaced by their current value when the
+// message is printed.
+
+package debugger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+)
+
+// logValue is a single value to be interpolated into a logpoint message.
+// either target or address will be defined but not both.
+type logValue struct {
+	target  *target
+	address string
+}
+
+func (v logValue) String(dbg *Debugger) string {
+	if v.target != nil {
+		return v.target.FormatValue(v.target.TargetValue())
+	}
+
+	ai, err := dbg.dbgmem.peek(v.address)
+	if err != nil {
+		return "??"
+	}
+	return fmt.Sprintf("%#02x", ai.data)
+}
+
+// interpolation is a string divided into literal parts and values. values
+// are replaced by their current value when the string is formatted.
+type interpolation struct {
+	// there is always one more literal than there are values
+	literals []string
+	values   []logValue
+}
+
+// format the string with the current value of each referenced value.
+func (ip interpolation) format(dbg *Debugger) string {
+	s := strings.Builder{}
+	for i := range ip.values {
+		s.WriteString(ip.literals[i])
+		s.WriteString(ip.values[i].String(dbg))
+	}
+	s.WriteString(ip.literals[len(ip.literals)-1])
+	return s.String()
+}
+
+// parse divides the string into literals and values. values are delimited by
+// curly braces and can be either a target (as used by BREAK) or a memory
+// address. for example:
+//
+//	"scanline {SL} A={A} lives={0x80}"
+//
+// the context argument describes the string in error messages.
+func (ip *interpolation) parse(dbg *Debugger, m string, context string) error {
+	ip.literals = ip.literals[:0]
+	ip.values = ip.values[:0]
+
+	for {
+		i := strings.Index(m, "{")
+		if i == -1 {
+			ip.literals = append(ip.literals, m)
+			return nil
+		}
+
+		j := strings.Index(m[i:], "}")
+		if j == -1 {
+			return curated.Errorf("unterminated value in %s", context)
+		}
+		j += i
+
+		ref := strings.TrimSpace(m[i+1 : j])
+		if ref == "" {
+			return curated.Errorf("empty value in %s", context)
+		}
+
+		var v logValue
+
+		tgt, err := parseTarget(dbg, commandline.TokeniseInput(ref))
+		if err == nil {
+			v.target = tgt
+		} else {
+			if dbg.dbgmem.mapAddress(ref, true) == nil {
+				return curated.Errorf("unrecognised value in %s (%s)", context, ref)
+			}
+			v.address = ref
+		}
+
+		ip.literals = append(ip.literals, m[:i])
+		ip.values = append(ip.values, v)
+		m = m[j+1:]
+	}
+}
+
+type logpoint struct {
+	// the message as entered by the user
+	message string
+
+	// the message after parsing
+	interpolation
+
+	cond breaker
+}
+
+func (lp logpoint) String() string {
+	return fmt.Sprintf("\"%s\" on %s", lp.message, lp.cond)
+}
+
+// parseMessage divides the message into literals and values.
+func (lp *logpoint) parseMessage(dbg *Debugger) error {
+	return lp.parse(dbg, lp.message, "logpoint message")
+}
+
+// the list of currently defined logpoints in the system.
+type logpoints struct {
+	dbg    *Debugger
+	points []logpoint
+}
+
+// newLogpoints is the preferred method of initialisation for the logpoints
+// type.
+func newLogpoints(dbg *Debugger) *logpoints {
+	lps := &logpoints{
+		dbg: dbg,
+	}
+	lps.clear()
+	return lps
+}
+
+// clear all logpoints.
+func (lps *logpoints) clear() {
+	lps.points = make([]logpoint, 0, 10)
+}
+
+// drop a specific logpoint by position in the list.
+func (lps *logpoints) drop(num int) error {
+	if len(lps.points)-1 < num {
+		return curated.Errorf("logpoint #%d is not defined", num)
+	}
+
+	h := lps.points[:num]
+	t := lps.points[num+1:]
+	lps.points = make([]logpoint, len(h)+len(t), cap(lps.points))
+	copy(lps.points, h)
+	copy(lps.points[len(h):], t)
+
+	return nil
+}
+
+// check compares the current state of the emulation with every logpoint
+// condition and prints the message of every logpoint that matches.
+func (lps *logpoints) check() {
+	for i := range lps.points {
+		if lps.points[i].cond.check() == checkMatch {
+			lps.dbg.printLine(terminal.StyleFeedback, " <log> %s", lps.points[i].format(lps.dbg))
+		}
+	}
+}
+
+// list currently defined logpoints.
+func (lps *logpoints) list() {
+	if len(lps.points) == 0 {
+		lps.dbg.printLine(terminal.StyleFeedback, "no logpoints")
+	} else {
+		lps.dbg.printLine(terminal.StyleFeedback, "logpoints:")
+		for i := range lps.points {
+			lps.dbg.printLine(terminal.StyleFeedback, "% 2d: %s", i, lps.points[i])
+		}
+	}
+}
+
+// commands returns the list of LOGPOINT commands that will recreate the
+// currently defined logpoints.
+func (lps logpoints) commands() []string {
+	cmds := make([]string, 0, len(lps.points))
+	for _, p := range lps.points {
+		cmds = append(cmds, fmt.Sprintf("%s \"%s\" %s", cmdLogpoint, p.message, p.cond.conditions()))
+	}
+	return cmds
+}
+
+// parse tokens and add new logpoint. the first token is the message and the
+// remaining tokens are the conditions, in the same form as for the BREAK
+// command. more than one logpoint will be added if the conditions are ORed.
+func (lps *logpoints) parseCommand(tokens *commandline.Tokens) error {
+	msg, ok := tokens.Get()
+	if !ok {
+		return curated.Errorf("logpoint requires a message")
+	}
+
+	conds, err := lps.dbg.breakpoints.parseBreakers(tokens)
+	if err != nil {
+		return err
+	}
+
+	if len(conds) == 0 {
+		return curated.Errorf("logpoint requires a condition")
+	}
+
+	newPoints := make([]logpoint, 0, len(conds))
+	for _, c := range conds {
+		lp := logpoint{message: msg, cond: c}
+		err := lp.parseMessage(lps.dbg)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range lps.points {
+			if p.message == lp.message && p.cond.cmp(lp.cond) {
+				return curated.Errorf("already exists (%s)", p)
+			}
+		}
+
+		newPoints = append(newPoints, lp)
+	}
+
+	lps.points = append(lps.points, newPoints...)
+
+	return nil
+}
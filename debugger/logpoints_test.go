@@ -0,0 +1,44 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testLogpoints() {
+	// debugger starts off with no logpoints
+	trm.sndInput("LIST LOGPOINTS")
+	trm.cmpOutput("no logpoints")
+
+	// add a logpoint. there should be no output
+	trm.sndInput("LOGPOINT \"scanline {SL} x {X}\" SL 100")
+	trm.cmpOutput("")
+
+	// list logpoints and check last line of output
+	trm.sndInput("LIST LOGPOINTS")
+	trm.cmpOutput(" 0: \"scanline {SL} x {X}\" on Scanline->100")
+
+	// try to add same logpoint. check error feedback
+	trm.sndInput("LOGPOINT \"scanline {SL} x {X}\" SL 100")
+	trm.cmpOutput("already exists (\"scanline {SL} x {X}\" on Scanline->100)")
+
+	// unrecognised values in the message are rejected
+	trm.sndInput("LOGPOINT \"{FOO}\" SL 100")
+	trm.cmpOutput("unrecognised value in logpoint message (FOO)")
+
+	trm.sndInput("LOGPOINT \"{SL\" SL 100")
+	trm.cmpOutput("unterminated value in logpoint message")
+
+	trm.sndInput("DROP LOGPOINT 0")
+	trm.cmpOutput("logpoint #0 dropped")
+}
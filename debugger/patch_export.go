@@ -0,0 +1,81 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"os"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/patch"
+)
+
+// exportPatch writes the changes made to cartridge ROM (with POKE or ASM for
+// example) to a file. the format can be "IPS" for an IPS patch file or "ROM"
+// for a complete copy of the modified cartridge file. returns the number of
+// modified bytes.
+//
+// existing files will not be overwritten.
+func (dbg *Debugger) exportPatch(format string, filename string) (int, error) {
+	cart := dbg.VCS.Mem.Cart
+	if cart.IsEjected() {
+		return 0, curated.Errorf("no cartridge to export changes from")
+	}
+
+	// reload the original cartridge data so that we can compare it with the
+	// current state of the cartridge
+	cartload := cartridgeloader.NewLoader(cart.Filename, "AUTO")
+	if cartload.IsSoundData {
+		return 0, curated.Errorf("cannot export changes for cartridges loaded from sound data")
+	}
+	err := cartload.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	modified, n, err := patch.Modified(cart, cartload.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	if n == 0 {
+		return 0, curated.Errorf("no changes to export")
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return 0, curated.Errorf("file already exists (%s)", filename)
+		}
+		return 0, err
+	}
+	defer f.Close()
+
+	switch format {
+	case "IPS":
+		err = patch.WriteIPS(f, cartload.Data, modified)
+	case "ROM":
+		_, err = f.Write(modified)
+	default:
+		err = curated.Errorf("unknown patch format (%s)", format)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
@@ -0,0 +1,38 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testScore() {
+	trm.sndInput("SCORE")
+	trm.cmpOutput("no score fields")
+
+	trm.sndInput("SCORE ADD P1 BCD 0x80 0x81")
+	trm.cmpOutput("P1: BCD 0x0080 0x0081")
+
+	trm.sndInput("POKE 0x80 0x12 0x34")
+	trm.sndInput("SCORE")
+	trm.cmpOutput("P1: 1234")
+
+	trm.sndInput("SCORE ADD T TIMER 0x80")
+	trm.cmpOutput("score: TIMER field must have 2 or 3 addresses")
+
+	trm.sndInput("SCORE ADD T FOO 0x80")
+	trm.cmpOutput("unrecognised argument (FOO)")
+
+	trm.sndInput("SCORE CLEAR")
+	trm.sndInput("SCORE")
+	trm.cmpOutput("no score fields")
+}
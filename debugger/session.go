@@ -0,0 +1,202 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/version"
+)
+
+// sessions are stored in this sub-directory of the resource path.
+const sessionPath = "sessions"
+
+// session is the information required to resume a debugging session. it is
+// stored on disk as JSON.
+//
+// there is no savestate format for the emulation and so the position is
+// restored by running the emulation from power-on to the recorded frame,
+// scanline and horizontal position. this will only reproduce the original
+// state if the emulation is deterministic; if the random startup preferences
+// are off and the original session did not rely on user input.
+type session struct {
+	// the version of the session format. sessions saved before the version
+	// field was introduced are version 1.0
+	Version string `json:"version"`
+
+	Cartridge string `json:"cartridge"`
+	Hash      string `json:"hash"`
+
+	Frame    int `json:"frame"`
+	Scanline int `json:"scanline"`
+	HorizPos int `json:"horizpos"`
+
+	// debugger commands that will recreate breakpoints, traps, watches, etc.
+	Commands []string `json:"commands"`
+
+	// the list of open windows. empty if the GUI does not support the
+	// ReqWindowLayout request
+	Windows []string `json:"windows,omitempty"`
+}
+
+func sessionFilename(name string) (string, error) {
+	return paths.ResourcePath(sessionPath, name+".json")
+}
+
+// saveSession writes the current state of the debugger to the named session
+// file.
+func (dbg *Debugger) saveSession(name string) error {
+	fn, err := sessionFilename(name)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	sess := session{
+		Version:   version.Session.String(),
+		Cartridge: dbg.VCS.Mem.Cart.Filename,
+		Hash:      dbg.VCS.Mem.Cart.Hash,
+	}
+
+	coords := dbg.VCS.TV.GetCoords()
+	sess.Frame = coords.Frame
+	sess.Scanline = coords.Scanline
+	sess.HorizPos = coords.Clock
+
+	// an empty cartridge field indicates that no cartridge is attached
+	if dbg.VCS.Mem.Cart.IsEjected() {
+		sess.Cartridge = ""
+		sess.Hash = ""
+	}
+
+	sess.Commands = append(sess.Commands, dbg.breakpoints.commands()...)
+	sess.Commands = append(sess.Commands, dbg.traps.commands()...)
+	sess.Commands = append(sess.Commands, dbg.watches.commands()...)
+	sess.Commands = append(sess.Commands, dbg.traces.commands()...)
+	sess.Commands = append(sess.Commands, dbg.logpoints.commands()...)
+
+	// window layout is optional. not all GUIs will support it
+	if l, err := dbg.scr.GetFeature(gui.ReqWindowLayout); err == nil {
+		if w, ok := l.([]string); ok {
+			sess.Windows = w
+		}
+	}
+
+	// not using json.MarshalIndent() because we don't want the & symbol in
+	// BREAK commands to be escaped
+	d := &bytes.Buffer{}
+	enc := json.NewEncoder(d)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(sess)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	err = ioutil.WriteFile(fn, d.Bytes(), 0600)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	return nil
+}
+
+// loadSession restores the debugger to the state recorded in the named
+// session file. the cartridge is reinserted, so any existing breakpoints,
+// traps, etc. are cleared before the recorded ones are added.
+func (dbg *Debugger) loadSession(name string) error {
+	fn, err := sessionFilename(name)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	d, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	var sess session
+	err = json.Unmarshal(d, &sess)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	if sess.Version == "" {
+		sess.Version = "1.0"
+	}
+	err = version.Session.Check(sess.Version)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	var cartload cartridgeloader.Loader
+	if sess.Cartridge != "" {
+		cartload = cartridgeloader.NewLoader(sess.Cartridge, "AUTO")
+
+		// loading will fail if the cartridge has changed since the session
+		// was saved
+		cartload.Hash = sess.Hash
+	}
+	err = dbg.attachCartridge(cartload)
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	dbg.breakpoints.clear()
+	dbg.traps.clear()
+	dbg.watches.clear()
+	dbg.traces.clear()
+	dbg.logpoints.clear()
+
+	for _, c := range sess.Commands {
+		err = dbg.parseCommand(c, false, false)
+		if err != nil {
+			return curated.Errorf("session: %s: %v", c, err)
+		}
+	}
+
+	if len(sess.Windows) > 0 {
+		err = dbg.scr.SetFeature(gui.ReqWindowLayout, sess.Windows)
+		if err != nil && !curated.Is(err, gui.UnsupportedGuiFeature) {
+			return curated.Errorf("session: %v", err)
+		}
+	}
+
+	// run emulation to the recorded position
+	cap := dbg.VCS.TV.SetFPSCap(false)
+	defer dbg.VCS.TV.SetFPSCap(cap)
+
+	coords := television.Coordinates{Frame: sess.Frame, Scanline: sess.Scanline, Clock: sess.HorizPos}
+	err = dbg.CatchUpLoop(func() bool {
+		return dbg.VCS.TV.GetCoords().Before(coords)
+	})
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	err = dbg.VCS.TV.ForceDraw()
+	if err != nil {
+		return curated.Errorf("session: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testSession() {
+	trm.sndInput("CLEAR ALL")
+	trm.rcvOutput()
+
+	trm.sndInput("BREAK SL 100 & HP 10")
+	trm.cmpOutput("")
+	trm.sndInput("TRAP RESULT MNEMONIC")
+	trm.cmpOutput("")
+	trm.sndInput("WATCH WRITE 0x80 0x01")
+	trm.cmpOutput("")
+	trm.sndInput("LOGPOINT \"x {X}\" FR 2")
+	trm.cmpOutput("")
+
+	trm.sndInput("SESSION SAVE test")
+	trm.cmpOutput("session saved (test)")
+
+	trm.sndInput("CLEAR ALL")
+	trm.rcvOutput()
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput("no breakpoints")
+
+	// loading the session recreates everything that was defined
+	trm.sndInput("SESSION LOAD test")
+	trm.cmpOutput("session resumed (test)")
+
+	trm.sndInput("LIST BREAKS")
+	trm.cmpOutput(" 0: Scanline->100 & Horiz Pos->10")
+	trm.sndInput("LIST TRAPS")
+	trm.cmpOutput(" 0: Mnemonic")
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 0: 0x0080 (RAM) write (value=0x01)")
+	trm.sndInput("LIST LOGPOINTS")
+	trm.cmpOutput(" 0: \"x {X}\" on Frame->2")
+
+	trm.sndInput("SESSION LOAD nonexistent")
+	trm.rcvOutput()
+
+	trm.sndInput("CLEAR ALL")
+	trm.rcvOutput()
+}
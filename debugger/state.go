@@ -16,6 +16,9 @@
 package debugger
 
 import (
+	"time"
+
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
 	"github.com/jetsetilly/gopher2600/disassembly"
 	"github.com/jetsetilly/gopher2600/logger"
 )
@@ -55,21 +58,68 @@ func (dbg *Debugger) TogglePCBreak(e *disassembly.Entry) {
 	dbg.breakpoints.togglePCBreak(e)
 }
 
+// the length of the raw events queue.
+const rawEventsQueueLen = 1024
+
+// low priority raw events will not be queued if there are fewer than this
+// number of free places in the queue. the remaining places are reserved for
+// normal priority events.
+const rawEventsReserve = rawEventsQueueLen / 4
+
+// the amount of time a normal priority raw event will wait for a place in the
+// queue before being dropped.
+const rawEventsTimeout = 100 * time.Millisecond
+
 // PushRawEvent onto the event queue. This can be used to get information out
 // of the debygger into another goroutine. Useful for when there is no
 // equivalent terminal command.
-func (dbg *Debugger) PushRawEvent(f func()) {
+//
+// If the queue is full the function will wait for a short time for a place to
+// become available. If the timeout expires the event is dropped and the
+// function returns false.
+func (dbg *Debugger) PushRawEvent(f func()) bool {
+	return dbg.pushRawEvent(terminal.RawEvent{Fn: f})
+}
+
+// PushRawEventReturn is the same as PushRawEvent except that control will be
+// returned to the input loop as soon as the function has been run.
+func (dbg *Debugger) PushRawEventReturn(f func()) bool {
+	return dbg.pushRawEvent(terminal.RawEvent{Fn: f, Return: true})
+}
+
+// PushRawEventLowPriority is the same as PushRawEvent except that the event is
+// dropped immediately if the queue is busy. Suitable for events that are
+// pushed repeatedly and where a missed event can be tolerated. For example,
+// the refreshing of values displayed by a GUI.
+//
+// Returns false if the event was dropped.
+func (dbg *Debugger) PushRawEventLowPriority(f func()) bool {
+	if cap(dbg.events.RawEvents)-len(dbg.events.RawEvents) <= rawEventsReserve {
+		return false
+	}
+
 	select {
-	case dbg.events.RawEvents <- f:
+	case dbg.events.RawEvents <- terminal.RawEvent{Fn: f}:
 	default:
-		logger.Log("debugger", "dropped raw event push")
+		return false
 	}
+
+	return true
 }
 
-func (dbg *Debugger) PushRawEventReturn(f func()) {
+func (dbg *Debugger) pushRawEvent(ev terminal.RawEvent) bool {
 	select {
-	case dbg.events.RawEventsReturn <- f:
+	case dbg.events.RawEvents <- ev:
+		return true
 	default:
-		logger.Log("debugger", "dropped raw event (with return) push")
 	}
+
+	select {
+	case dbg.events.RawEvents <- ev:
+	case <-time.After(rawEventsTimeout):
+		logger.Log("debugger", "dropped raw event push")
+		return false
+	}
+
+	return true
 }
@@ -0,0 +1,59 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"os"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/stellastate"
+)
+
+// exportStellaState writes the current emulation state to a Stella savestate
+// file. existing files will not be overwritten.
+func (dbg *Debugger) exportStellaState(filename string) error {
+	hash, err := stellastate.CartridgeMD5(dbg.VCS)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return curated.Errorf("file already exists (%s)", filename)
+		}
+		return err
+	}
+	defer f.Close()
+
+	return stellastate.Export(dbg.VCS, hash, f)
+}
+
+// importStellaState sets the emulation state from a Stella savestate file.
+func (dbg *Debugger) importStellaState(filename string) error {
+	hash, err := stellastate.CartridgeMD5(dbg.VCS)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return stellastate.Import(dbg.VCS, hash, f)
+}
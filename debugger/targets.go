@@ -30,6 +30,10 @@ type targetValue interface{}
 type target struct {
 	label string
 
+	// the keyword(s) by which the target was specified. parseTarget() will
+	// recognise the keyword and return an equivalent target
+	keyword string
+
 	// must be a comparable type
 	currentValue targetValue
 	format       string
@@ -205,6 +209,8 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 				default:
 					return nil, curated.Errorf("invalid target: %s %s", keyword, subkey)
 				}
+
+				keyword = fmt.Sprintf("%s %s", keyword, subkey)
 			} else {
 				return nil, curated.Errorf("invalid target: %s", keyword)
 			}
@@ -212,6 +218,10 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 		default:
 			return nil, curated.Errorf("invalid target: %s", keyword)
 		}
+
+		if trg != nil {
+			trg.keyword = keyword
+		}
 	}
 
 	return trg, nil
@@ -221,7 +231,8 @@ func parseTarget(dbg *Debugger, tokens *commandline.Tokens) (*target, error) {
 // explicitly in parseTarget().
 func bankTarget(dbg *Debugger) *target {
 	return &target{
-		label: "Bank",
+		label:   "Bank",
+		keyword: "BANK",
 		currentValue: func() targetValue {
 			return dbg.VCS.Mem.Cart.GetBank(dbg.VCS.CPU.PC.Address()).Number
 		},
@@ -0,0 +1,295 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package netterm implements the Terminal interface for the gopher2600
+// debugger over a network connection. This allows the emulator to run on one
+// machine while the debugging terminal is used on another.
+//
+// The terminal listens on either a TCP or a Unix socket. Only one client can be
+// connected at once. A new connection replaces any existing connection. While
+// no client is connected, output is written to stdout.
+//
+// Any program that can open a socket and send lines of text can be used as a
+// client. For example, netcat:
+//
+//	nc localhost 6502
+//
+// Note that there is no authentication of any kind. Anyone who can connect to
+// the socket has full control of the debugger, including commands that run
+// other programs on the host. For this reason a TCP address without a host
+// will only listen on the loopback interface. Listening on any other interface
+// must be requested explicitly by specifying the host. For example:
+//
+//	tcp:0.0.0.0:6502
+package netterm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// NetTerminal implements the terminal.Terminal interface over a network
+// connection.
+type NetTerminal struct {
+	network string
+	address string
+
+	listener net.Listener
+
+	// the current connection. nil if there is no client
+	crit sync.Mutex
+	conn net.Conn
+
+	// the last prompt sent to the client. sent again to new connections
+	prompt string
+
+	// lines received from the client and notification of new clients
+	lines     chan string
+	connected chan bool
+
+	silenced bool
+}
+
+// NewNetTerminal is the preferred method of initialisation for the
+// NetTerminal type. The spec argument is of the form NETWORK:ADDRESS, where
+// NETWORK is either "tcp" or "unix". For example:
+//
+//	tcp::6502
+//	tcp:192.168.0.10:6502
+//	unix:/tmp/gopher2600.sock
+//
+// A TCP address with no host, as in the first example, listens on the
+// loopback interface only.
+//
+// The terminal will not start listening until Initialise() is called.
+func NewNetTerminal(spec string) (*NetTerminal, error) {
+	s := strings.SplitN(spec, ":", 2)
+	if len(s) != 2 || s[1] == "" {
+		return nil, curated.Errorf("netterm: %v", fmt.Sprintf("badly formed listen address (%s)", spec))
+	}
+
+	nt := &NetTerminal{
+		network:   strings.ToLower(s[0]),
+		address:   s[1],
+		lines:     make(chan string, 16),
+		connected: make(chan bool, 1),
+	}
+
+	switch nt.network {
+	case "tcp", "tcp4", "tcp6":
+		host, port, err := net.SplitHostPort(nt.address)
+		if err != nil {
+			return nil, curated.Errorf("netterm: %v", err)
+		}
+
+		// there is no authentication so listening on anything other than
+		// the loopback interface must be requested explicitly
+		if host == "" {
+			nt.address = net.JoinHostPort("localhost", port)
+		}
+	case "unix":
+	default:
+		return nil, curated.Errorf("netterm: %v", fmt.Sprintf("unsupported network (%s)", nt.network))
+	}
+
+	return nt, nil
+}
+
+// Initialise perfoms any setting up required for the terminal.
+func (nt *NetTerminal) Initialise() error {
+	var err error
+
+	nt.listener, err = net.Listen(nt.network, nt.address)
+	if err != nil {
+		return curated.Errorf("netterm: %v", err)
+	}
+
+	logger.Log("netterm", fmt.Sprintf("listening on %s:%s", nt.network, nt.listener.Addr()))
+
+	if addr, ok := nt.listener.Addr().(*net.TCPAddr); ok && !addr.IP.IsLoopback() {
+		logger.Log("netterm", "listening on a non-loopback interface. anyone who can connect has full control of the debugger")
+	}
+
+	go nt.accept()
+
+	return nil
+}
+
+// accept connections until the listener is closed.
+func (nt *NetTerminal) accept() {
+	for {
+		conn, err := nt.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		nt.crit.Lock()
+		if nt.conn != nil {
+			nt.conn.Close()
+		}
+		nt.conn = conn
+		nt.crit.Unlock()
+
+		logger.Log("netterm", fmt.Sprintf("connected to %s", conn.RemoteAddr()))
+
+		// notify TermRead() that the prompt should be sent again
+		select {
+		case nt.connected <- true:
+		default:
+		}
+
+		go nt.read(conn)
+	}
+}
+
+// read lines from the connection until it is closed.
+func (nt *NetTerminal) read(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		nt.lines <- scanner.Text()
+	}
+
+	nt.crit.Lock()
+	if nt.conn == conn {
+		nt.conn.Close()
+		nt.conn = nil
+		logger.Log("netterm", "disconnected")
+	}
+	nt.crit.Unlock()
+}
+
+// write to the current connection or to stdout if there is no connection.
+func (nt *NetTerminal) write(s string) {
+	nt.crit.Lock()
+	defer nt.crit.Unlock()
+
+	var w io.Writer = os.Stdout
+	if nt.conn != nil {
+		w = nt.conn
+	}
+
+	_, err := w.Write([]byte(s))
+	if err != nil && nt.conn != nil {
+		nt.conn.Close()
+		nt.conn = nil
+	}
+}
+
+// CleanUp perfoms any cleaning up required for the terminal.
+func (nt *NetTerminal) CleanUp() {
+	if nt.listener != nil {
+		nt.listener.Close()
+	}
+
+	nt.crit.Lock()
+	defer nt.crit.Unlock()
+	if nt.conn != nil {
+		nt.conn.Close()
+		nt.conn = nil
+	}
+}
+
+// RegisterTabCompletion adds an implementation of TabCompletion to the terminal.
+func (nt *NetTerminal) RegisterTabCompletion(terminal.TabCompletion) {
+}
+
+// Silence implements the terminal.Terminal interface.
+func (nt *NetTerminal) Silence(silenced bool) {
+	nt.silenced = silenced
+}
+
+// TermPrintLine implements the terminal.Output interface.
+func (nt *NetTerminal) TermPrintLine(style terminal.Style, s string) {
+	if nt.silenced && style != terminal.StyleError {
+		return
+	}
+
+	// the client will echo user input
+	if style == terminal.StyleEcho {
+		return
+	}
+
+	switch style {
+	case terminal.StyleError:
+		s = fmt.Sprintf("* %s", s)
+	}
+
+	nt.write(s)
+	nt.write("\n")
+}
+
+// TermRead implements the terminal.Input interface.
+func (nt *NetTerminal) TermRead(input []byte, prompt terminal.Prompt, events *terminal.ReadEvents) (int, error) {
+	if nt.silenced {
+		return 0, nil
+	}
+
+	// any pending connection notification is now stale because the prompt is
+	// about to be written to the current connection
+	select {
+	case <-nt.connected:
+	default:
+	}
+
+	nt.prompt = prompt.String()
+	nt.write(nt.prompt)
+
+	// the debugger is waiting for input from the client but we still need to
+	// service events in the meantime.
+	for {
+		select {
+		case s := <-nt.lines:
+			n := copy(input, s+"\n")
+			return n, nil
+
+		case <-nt.connected:
+			nt.write(nt.prompt)
+
+		case <-events.IntEvents:
+			return 0, curated.Errorf(terminal.UserInterrupt)
+
+		case ev := <-events.RawEvents:
+			ev.Fn()
+			if ev.Return {
+				return 0, nil
+			}
+
+		case ev := <-events.GuiEvents:
+			err := events.GuiEventHandler(ev)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+// TermReadCheck implements the terminal.Input interface.
+func (nt *NetTerminal) TermReadCheck() bool {
+	return len(nt.lines) > 0
+}
+
+// IsInteractive implements the terminal.Input interface.
+func (nt *NetTerminal) IsInteractive() bool {
+	return true
+}
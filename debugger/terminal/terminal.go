@@ -53,12 +53,21 @@ type ReadEvents struct {
 	GuiEventHandler func(gui.Event) error
 	IntEvents       chan os.Signal
 
-	// RawEvents allows functions to be pushed into the debugger goroutine
-	RawEvents chan func()
+	// RawEvents allows functions to be pushed into the debugger goroutine.
+	// this is the only channel through which other goroutines should change
+	// or read the state of the emulation.
+	//
+	// the channel is bounded. see debugger.PushRawEvent() for how a full
+	// channel is handled.
+	RawEvents chan RawEvent
+}
+
+// RawEvent is a function to be run in the debugger goroutine.
+type RawEvent struct {
+	Fn func()
 
-	// RawEventsReturn is a variation of RawEvents that returns control to the
-	// input loop as soon as the function is run
-	RawEventsReturn chan func()
+	// return control to the input loop as soon as the function is run
+	Return bool
 }
 
 // Output defines the operations required by an interface that allows output.
@@ -16,6 +16,7 @@
 package debugger
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/curated"
@@ -116,6 +117,16 @@ func (trc *traces) list() {
 	}
 }
 
+// commands returns the list of TRACE commands that will recreate the
+// currently defined traces.
+func (trc traces) commands() []string {
+	cmds := make([]string, 0, len(trc.traces))
+	for i := range trc.traces {
+		cmds = append(cmds, fmt.Sprintf("%s %#04x", cmdTrace, trc.traces[i].ai.address))
+	}
+	return cmds
+}
+
 // parse tokens and add new trace. only one trace at a time can be specified on
 // the command line.
 func (trc *traces) parseCommand(tokens *commandline.Tokens) error {
@@ -106,6 +106,16 @@ func (tr traps) list() {
 	}
 }
 
+// commands returns the list of TRAP commands that will recreate the currently
+// defined traps.
+func (tr traps) commands() []string {
+	cmds := make([]string, 0, len(tr.traps))
+	for i := range tr.traps {
+		cmds = append(cmds, fmt.Sprintf("%s %s", cmdTrap, tr.traps[i].target.keyword))
+	}
+	return cmds
+}
+
 // parse tokens and add new trap.
 func (tr *traps) parseCommand(tokens *commandline.Tokens) error {
 	_, present := tokens.Peek()
@@ -0,0 +1,30 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testTV() {
+	trm.sndInput("TV BADSYNC")
+	trm.cmpOutput("bad sync: FLYBACK")
+
+	trm.sndInput("TV BADSYNC roll")
+	trm.cmpOutput("bad sync: ROLL")
+
+	trm.sndInput("TV BADSYNC HALT")
+	trm.cmpOutput("bad sync: HALT")
+
+	trm.sndInput("TV BADSYNC FLYBACK")
+	trm.cmpOutput("bad sync: FLYBACK")
+}
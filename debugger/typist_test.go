@@ -0,0 +1,38 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger_test
+
+func (trm *mockTerm) testType() {
+	trm.sndInput("TYPE")
+	trm.cmpOutput("0 key presses pending")
+
+	trm.sndInput("TYPE 12#3")
+	trm.sndInput("TYPE")
+	trm.cmpOutput("4 key presses pending")
+
+	trm.sndInput("TYPE ABC")
+	trm.cmpOutput("typist: no keys for character ('A')")
+
+	trm.sndInput("TYPE CLEAR")
+	trm.sndInput("TYPE")
+	trm.cmpOutput("0 key presses pending")
+
+	trm.sndInput("TYPE NEWLINE")
+	trm.cmpOutput("typist: no keys for character ('\\n')")
+
+	trm.sndInput("TYPE KEYMAP DEFAULT")
+	trm.cmpOutput("using default keymap")
+}
@@ -0,0 +1,167 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package debugger
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/debugger/terminal"
+)
+
+// edit records a single modification to the emulation made by the user. for
+// example, a POKE or a change of CPU register.
+type edit struct {
+	description string
+	revert      func() error
+}
+
+func (e edit) String() string {
+	return e.description
+}
+
+// the list of user modifications that can be undone. edits are listed in the
+// order in which they were made.
+type edits struct {
+	dbg   *Debugger
+	edits []edit
+}
+
+// newEdits is the preferred method of initialisation for the edits type.
+func newEdits(dbg *Debugger) *edits {
+	ed := &edits{
+		dbg: dbg,
+	}
+	ed.clear()
+	return ed
+}
+
+// clear all edits. the edits are forgotten and not reverted.
+func (ed *edits) clear() {
+	ed.edits = make([]edit, 0, 10)
+}
+
+// record a new edit. the revert function should return the emulation to the
+// state it was in before the edit.
+func (ed *edits) record(description string, revert func() error) {
+	ed.edits = append(ed.edits, edit{description: description, revert: revert})
+}
+
+// undo a specific edit by its position in the list. the edit is reverted and
+// removed from the list. edits made after the undone edit are left in place.
+func (ed *edits) undo(num int) error {
+	if num < 0 || len(ed.edits)-1 < num {
+		return curated.Errorf("edit #%d is not defined", num)
+	}
+
+	err := ed.edits[num].revert()
+	if err != nil {
+		return err
+	}
+
+	h := ed.edits[:num]
+	t := ed.edits[num+1:]
+	ed.edits = make([]edit, len(h)+len(t), cap(ed.edits))
+	copy(ed.edits, h)
+	copy(ed.edits[len(h):], t)
+
+	return nil
+}
+
+// undo the most recent edit.
+func (ed *edits) undoLast() error {
+	if len(ed.edits) == 0 {
+		return curated.Errorf("nothing to undo")
+	}
+	return ed.undo(len(ed.edits) - 1)
+}
+
+// undo all edits in reverse order.
+func (ed *edits) undoAll() error {
+	for len(ed.edits) > 0 {
+		err := ed.undoLast()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list edits that can be undone.
+func (ed *edits) list() {
+	if len(ed.edits) == 0 {
+		ed.dbg.printLine(terminal.StyleFeedback, "no edits")
+	} else {
+		ed.dbg.printLine(terminal.StyleFeedback, "edits:")
+		for i := range ed.edits {
+			ed.dbg.printLine(terminal.StyleFeedback, "% 2d: %s", i, ed.edits[i])
+		}
+	}
+}
+
+// pokeWithUndo is the same as dbgmem.poke() except that the edit is recorded
+// so that it can be undone later.
+func (dbg *Debugger) pokeWithUndo(address uint16, data uint8) (*addressInfo, error) {
+	// the previous value at the address. we're peeking through the write
+	// mapping of the address because that's the mapping that poke() uses
+	ai := dbg.dbgmem.mapAddress(address, false)
+	if ai == nil {
+		return nil, curated.Errorf(pokeError, address)
+	}
+	prev, err := dbg.VCS.Mem.GetArea(ai.area).Peek(ai.mappedAddress)
+	if err != nil {
+		return nil, curated.Errorf(pokeError, address)
+	}
+
+	ai, err = dbg.dbgmem.poke(address, data)
+	if err != nil {
+		return nil, err
+	}
+
+	dbg.edits.record(fmt.Sprintf("POKE %#04x %#02x (was %#02x)", address, data, prev), func() error {
+		_, err := dbg.dbgmem.poke(address, prev)
+		return err
+	})
+
+	return ai, nil
+}
+
+// PokeWithUndo writes a value to the specified address, without triggering
+// any side effects, and records the change so that it can be reverted with the
+// UNDO command.
+//
+// Unlike the POKE command, the address is mapped as though it were being
+// read. This makes it suitable for changing the value of read-only registers.
+//
+// Should only be called from the emulation goroutine. For example, through
+// the PushRawEvent() function.
+func (dbg *Debugger) PokeWithUndo(address uint16, data uint8) error {
+	prev, err := dbg.VCS.Mem.Peek(address)
+	if err != nil {
+		return err
+	}
+
+	err = dbg.VCS.Mem.Poke(address, data)
+	if err != nil {
+		return err
+	}
+
+	dbg.edits.record(fmt.Sprintf("POKE %#04x %#02x (was %#02x)", address, data, prev), func() error {
+		return dbg.VCS.Mem.Poke(address, prev)
+	})
+
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/debugger/terminal"
 	"github.com/jetsetilly/gopher2600/debugger/terminal/commandline"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 )
 
 type watcher struct {
@@ -71,6 +72,11 @@ func (wtc *watches) clear() {
 	wtc.watches = make([]watcher, 0, 10)
 }
 
+// isEmpty returns true if there are no currently defined watches.
+func (wtc *watches) isEmpty() bool {
+	return len(wtc.watches) == 0
+}
+
 // drop a specific watcher by a position in the list.
 func (wtc *watches) drop(num int) error {
 	if len(wtc.watches)-1 < num {
@@ -143,6 +149,22 @@ func (wtc *watches) check(previousResult string) string {
 	return checkString.String()
 }
 
+// parseUntil adds a watch for the next write to the TIA or RIOT register
+// named by the next token. mirrors of the register will also match. used by
+// the STEP UNTIL command.
+func (wtc *watches) parseUntil(tokens *commandline.Tokens) error {
+	reg, _ := tokens.Get()
+
+	ai := wtc.dbg.dbgmem.mapAddress(reg, false)
+	if ai == nil || (ai.area != memorymap.TIA && ai.area != memorymap.RIOT) {
+		return curated.Errorf("not a TIA or RIOT register: %s", reg)
+	}
+
+	wtc.watches = append(wtc.watches, watcher{ai: *ai, mirrors: true})
+
+	return nil
+}
+
 // list currently defined watches.
 func (wtc *watches) list() {
 	if len(wtc.watches) == 0 {
@@ -155,6 +177,30 @@ func (wtc *watches) list() {
 	}
 }
 
+// commands returns the list of WATCH commands that will recreate the
+// currently defined watches.
+func (wtc watches) commands() []string {
+	cmds := make([]string, 0, len(wtc.watches))
+	for _, w := range wtc.watches {
+		s := strings.Builder{}
+		s.WriteString(cmdWatch)
+		if w.ai.read {
+			s.WriteString(" READ")
+		} else {
+			s.WriteString(" WRITE")
+		}
+		if w.mirrors {
+			s.WriteString(" MIRRORS")
+		}
+		s.WriteString(fmt.Sprintf(" %#04x", w.ai.address))
+		if w.matchValue {
+			s.WriteString(fmt.Sprintf(" %#02x", w.value))
+		}
+		cmds = append(cmds, s.String())
+	}
+	return cmds
+}
+
 // parse tokens and add new watch. unlike breakpoints and traps, only one watch
 // at a time can be specified on the command line.
 func (wtc *watches) parseCommand(tokens *commandline.Tokens) error {
@@ -69,3 +69,16 @@ func (trm *mockTerm) testWatches() {
 	trm.sndInput("LIST WATCHES")
 	trm.cmpOutput(" 1: 0x0000 (VSYNC) (TIA) write (value=0x01)")
 }
+
+func (trm *mockTerm) testStepUntil() {
+	// only TIA and RIOT registers are accepted
+	trm.sndInput("STEP UNTIL FOO")
+	trm.cmpOutput("not a TIA or RIOT register: FOO")
+
+	trm.sndInput("STEP UNTIL 0x80")
+	trm.cmpOutput("not a TIA or RIOT register: 0x80")
+
+	// step until watches are not listed with the regular watches
+	trm.sndInput("LIST WATCHES")
+	trm.cmpOutput(" 1: 0x0000 (VSYNC) (TIA) write (value=0x01)")
+}
@@ -37,6 +37,10 @@ const audioBufferStart = sha1.Size
 // embedded television for convenience. It periodically generates a SHA-1 value
 // of the audio stream.
 //
+// Audio also implements the television.StereoMixer interface so the digest
+// covers the left and right channels. The TIA audio is mixed with integer
+// arithmetic only so the digest is the same on every platform.
+//
 // Note that the use of SHA-1 is fine for this application because this is not a
 // cryptographic task.
 type Audio struct {
@@ -85,8 +89,24 @@ func (dig *Audio) SetAudio(audioData uint8) error {
 	return nil
 }
 
+// SetStereo implements the television.StereoMixer interface.
+func (dig *Audio) SetStereo(left uint8, right uint8) error {
+	// the buffer length is not a multiple of two so the left and right
+	// values are checked separately
+	err := dig.SetAudio(left)
+	if err != nil {
+		return err
+	}
+	return dig.SetAudio(right)
+}
+
 func (dig *Audio) flushAudio() error {
-	dig.digest = sha1.Sum(dig.buffer)
+	// nothing to flush
+	if dig.bufferCt == audioBufferStart {
+		return nil
+	}
+
+	dig.digest = sha1.Sum(dig.buffer[:dig.bufferCt])
 	n := copy(dig.buffer, dig.digest[:])
 	if n != len(dig.digest) {
 		return curated.Errorf("digest: audio: digest error while flushing audio stream")
@@ -95,7 +115,8 @@ func (dig *Audio) flushAudio() error {
 	return nil
 }
 
-// EndMixing implements the television.AudioMixer interface.
+// EndMixing implements the television.AudioMixer interface. Any audio that
+// has not yet been included in the digest is flushed.
 func (dig *Audio) EndMixing() error {
-	return nil
+	return dig.flushAudio()
 }
@@ -22,6 +22,12 @@
 //
 // The hashes produced by these types are used from regression tests and for
 // verification of playback scripts.
+//
+// By default, digest.Video uses the SHA-1 algorithm so that hashes are
+// compatible with previously recorded regression tests and playback scripts.
+// The faster xxHash algorithm can be selected with NewVideoWithAlgorithm()
+// when compatibility isn't required. The hash of every frame can be received
+// as it is produced by registering a FrameListener.
 package digest
 
 // Digest implementations compute a mathematical hash, retreivable with the
@@ -18,6 +18,7 @@ package digest
 import (
 	"crypto/sha1"
 	"fmt"
+	"hash"
 
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/television"
@@ -25,44 +26,87 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
 )
 
+// Algorithm specifies the hashing algorithm used by Video.
+type Algorithm string
+
+// List of valid Algorithm values.
+const (
+	// SHA1 is compatible with digests created by earlier versions of the
+	// emulator. Regression tests and playback recordings use this algorithm.
+	SHA1 Algorithm = "SHA1"
+
+	// XXHash is much faster than SHA1 and is suitable for digests that are
+	// only compared with other digests created during the same session.
+	XXHash Algorithm = "XXHASH"
+)
+
+// FrameListener implementations receive the digest of every frame as it is
+// completed. Useful for detecting when two emulations have diverged.
+type FrameListener interface {
+	// the digest slice should not be retained by the FrameListener after
+	// the function has returned. it should be copied if necessary
+	FrameDigest(frameNum int, digest []byte)
+}
+
 // Video is an implementation of the television.PixelRenderer interface with an
-// embedded television for convenience. It generates a SHA-1 value of the image
-// every frame. it does not display the image anywhere.
+// embedded television for convenience. It generates a hash of the image every
+// frame. it does not display the image anywhere.
 //
 // Note that the use of SHA-1 is fine for this application because this is not
 // a cryptographic task.
 type Video struct {
 	*television.Television
 	spec     specification.Spec
-	digest   [sha1.Size]byte
 	pixels   []byte
 	frameNum int
+
+	// the hash is chained. the digest of the previous frame is written to the
+	// hash before the pixels of the current frame
+	hash   hash.Hash
+	digest []byte
+
+	listeners []FrameListener
 }
 
 const pixelDepth = 3
 
-// NewVideo initialises a new instance of DigestTV. For convenience, the
-// television argument can be nil, in which case an instance of
-// StellaTelevision will be created.
+// NewVideo initialises a new instance of DigestTV using the SHA1 algorithm.
 func NewVideo(tv *television.Television) (*Video, error) {
+	return NewVideoWithAlgorithm(tv, SHA1)
+}
+
+// NewVideoWithAlgorithm initialises a new instance of DigestTV using the
+// specified hashing algorithm.
+func NewVideoWithAlgorithm(tv *television.Television, alg Algorithm) (*Video, error) {
 	// set up digest tv
 	dig := &Video{Television: tv}
 
+	switch alg {
+	case SHA1:
+		dig.hash = sha1.New()
+	case XXHash:
+		dig.hash = newXXHash()
+	default:
+		return nil, curated.Errorf("digest: video: unknown algorithm (%s)", alg)
+	}
+	dig.digest = make([]byte, dig.hash.Size())
+
 	// register ourselves as a television.Renderer
 	dig.AddPixelRenderer(dig)
 
-	// length of pixels array contains enough room for the previous frames
-	// digest value
-	l := len(dig.digest)
-
 	// allocate enough pixels for entire frame
 	dig.spec = dig.GetSpec()
-	l += ((specification.HorizClksScanline + 1) * (dig.spec.ScanlinesTotal + 1) * pixelDepth)
-	dig.pixels = make([]byte, l)
+	dig.pixels = make([]byte, (specification.HorizClksScanline+1)*(dig.spec.ScanlinesTotal+1)*pixelDepth)
 
 	return dig, nil
 }
 
+// AddFrameListener registers an implementation of FrameListener. Multiple
+// implementations can be added.
+func (dig *Video) AddFrameListener(l FrameListener) {
+	dig.listeners = append(dig.listeners, l)
+}
+
 // Hash implements digest.Digest interface.
 func (dig Video) Hash() string {
 	return fmt.Sprintf("%x", dig.digest)
@@ -88,22 +132,24 @@ func (dig *Video) Resize(spec specification.Spec, _, _ int) error {
 
 	// allocate enough pixels for entire frame
 	dig.spec = spec
-	l := len(dig.digest)
-	l += ((specification.HorizClksScanline + 1) * (spec.ScanlinesTotal + 1) * pixelDepth)
-	dig.pixels = make([]byte, l)
+	dig.pixels = make([]byte, (specification.HorizClksScanline+1)*(spec.ScanlinesTotal+1)*pixelDepth)
 
 	return nil
 }
 
 // NewFrame implements television.PixelRenderer interface.
-func (dig *Video) NewFrame(_ bool) error {
-	// chain fingerprints by copying the value of the last fingerprint
-	// to the head of the video data
-	n := copy(dig.pixels, dig.digest[:])
-	if n != len(dig.digest) {
-		return curated.Errorf("digest: video: digest error during new frame")
+func (dig *Video) NewFrame(_ television.FrameInfo) error {
+	// chain fingerprints by writing the value of the last fingerprint before
+	// the video data
+	dig.hash.Reset()
+	_, _ = dig.hash.Write(dig.digest)
+	_, _ = dig.hash.Write(dig.pixels)
+	dig.digest = dig.hash.Sum(dig.digest[:0])
+
+	for _, l := range dig.listeners {
+		l.FrameDigest(dig.frameNum, dig.digest)
 	}
-	dig.digest = sha1.Sum(dig.pixels)
+
 	dig.frameNum++
 	return nil
 }
@@ -119,13 +165,11 @@ func (dig *Video) UpdatingPixels(_ bool) {
 
 // SetPixel implements television.PixelRenderer interface.
 func (dig *Video) SetPixel(sig signal.SignalAttributes, _ bool) error {
-	// preserve the first few bytes for a chained fingerprint
-	i := len(dig.digest)
-	i += specification.HorizClksScanline * sig.Scanline * pixelDepth
-	i += sig.HorizPos * pixelDepth
+	i := specification.HorizClksScanline * sig.Scanline() * pixelDepth
+	i += sig.HorizPos() * pixelDepth
 
 	if i <= len(dig.pixels)-pixelDepth {
-		col := dig.spec.GetColor(sig.Pixel)
+		col := dig.spec.GetColor(sig.Pixel())
 
 		// setting every pixel regardless of vblank value
 		dig.pixels[i] = col.R
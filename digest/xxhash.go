@@ -0,0 +1,169 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package digest
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// xxhash is an implementation of the 64bit variant of the xxHash algorithm.
+// it is much faster than SHA-1 and is suitable when the digest is not
+// required to be compatible with digests produced by earlier versions of the
+// emulator.
+//
+// https://github.com/Cyan4973/xxHash/blob/dev/doc/xxhash_spec.md
+type xxhash struct {
+	v   [4]uint64
+	buf [32]byte
+	n   int
+	len uint64
+}
+
+// the primes are variables rather than constants so that the arithmetic in
+// Reset() is allowed to overflow.
+var (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// the size of an xxhash digest in bytes.
+const xxhashSize = 8
+
+func newXXHash() hash.Hash64 {
+	x := &xxhash{}
+	x.Reset()
+	return x
+}
+
+func xxRound(acc uint64, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMerge(acc uint64, v uint64) uint64 {
+	acc ^= xxRound(0, v)
+	return acc*xxPrime1 + xxPrime4
+}
+
+// Reset implements the hash.Hash interface.
+func (x *xxhash) Reset() {
+	x.v[0] = xxPrime1 + xxPrime2
+	x.v[1] = xxPrime2
+	x.v[2] = 0
+	x.v[3] = -xxPrime1
+	x.n = 0
+	x.len = 0
+}
+
+// Size implements the hash.Hash interface.
+func (x *xxhash) Size() int {
+	return xxhashSize
+}
+
+// BlockSize implements the hash.Hash interface.
+func (x *xxhash) BlockSize() int {
+	return len(x.buf)
+}
+
+// consume a 32 byte stripe.
+func (x *xxhash) stripe(b []byte) {
+	x.v[0] = xxRound(x.v[0], binary.LittleEndian.Uint64(b[0:]))
+	x.v[1] = xxRound(x.v[1], binary.LittleEndian.Uint64(b[8:]))
+	x.v[2] = xxRound(x.v[2], binary.LittleEndian.Uint64(b[16:]))
+	x.v[3] = xxRound(x.v[3], binary.LittleEndian.Uint64(b[24:]))
+}
+
+// Write implements the hash.Hash interface.
+func (x *xxhash) Write(b []byte) (int, error) {
+	l := len(b)
+	x.len += uint64(l)
+
+	// complete any partially filled stripe
+	if x.n > 0 {
+		c := copy(x.buf[x.n:], b)
+		x.n += c
+		b = b[c:]
+		if x.n < len(x.buf) {
+			return l, nil
+		}
+		x.stripe(x.buf[:])
+		x.n = 0
+	}
+
+	for len(b) >= len(x.buf) {
+		x.stripe(b)
+		b = b[len(x.buf):]
+	}
+
+	x.n = copy(x.buf[:], b)
+
+	return l, nil
+}
+
+// Sum64 implements the hash.Hash64 interface.
+func (x *xxhash) Sum64() uint64 {
+	var acc uint64
+
+	if x.len >= uint64(len(x.buf)) {
+		acc = bits.RotateLeft64(x.v[0], 1) + bits.RotateLeft64(x.v[1], 7) +
+			bits.RotateLeft64(x.v[2], 12) + bits.RotateLeft64(x.v[3], 18)
+		acc = xxMerge(acc, x.v[0])
+		acc = xxMerge(acc, x.v[1])
+		acc = xxMerge(acc, x.v[2])
+		acc = xxMerge(acc, x.v[3])
+	} else {
+		acc = x.v[2] + xxPrime5
+	}
+
+	acc += x.len
+
+	b := x.buf[:x.n]
+	for ; len(b) >= 8; b = b[8:] {
+		acc ^= xxRound(0, binary.LittleEndian.Uint64(b))
+		acc = bits.RotateLeft64(acc, 27)*xxPrime1 + xxPrime4
+	}
+	if len(b) >= 4 {
+		acc ^= uint64(binary.LittleEndian.Uint32(b)) * xxPrime1
+		acc = bits.RotateLeft64(acc, 23)*xxPrime2 + xxPrime3
+		b = b[4:]
+	}
+	for ; len(b) > 0; b = b[1:] {
+		acc ^= uint64(b[0]) * xxPrime5
+		acc = bits.RotateLeft64(acc, 11) * xxPrime1
+	}
+
+	acc ^= acc >> 33
+	acc *= xxPrime2
+	acc ^= acc >> 29
+	acc *= xxPrime3
+	acc ^= acc >> 32
+
+	return acc
+}
+
+// Sum implements the hash.Hash interface. The digest is appended to b in big
+// endian order.
+func (x *xxhash) Sum(b []byte) []byte {
+	var s [xxhashSize]byte
+	binary.BigEndian.PutUint64(s[:], x.Sum64())
+	return append(b, s[:]...)
+}
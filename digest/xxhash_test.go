@@ -0,0 +1,50 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package digest
+
+import (
+	"testing"
+)
+
+func TestXXHash(t *testing.T) {
+	vectors := []struct {
+		input  string
+		digest uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+		{"Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+	}
+
+	for _, v := range vectors {
+		x := newXXHash()
+		_, _ = x.Write([]byte(v.input))
+		if x.Sum64() != v.digest {
+			t.Errorf("unexpected digest for %q (%016x instead of %016x)", v.input, x.Sum64(), v.digest)
+		}
+
+		// the same digest should be produced when the input is written one
+		// byte at a time
+		x.Reset()
+		for i := range v.input {
+			_, _ = x.Write([]byte{v.input[i]})
+		}
+		if x.Sum64() != v.digest {
+			t.Errorf("unexpected digest for streamed %q (%016x instead of %016x)", v.input, x.Sum64(), v.digest)
+		}
+	}
+}
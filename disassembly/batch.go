@@ -0,0 +1,126 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+)
+
+// isROMFile returns true if the filename has one of the file extensions
+// recognised by the cartridgeloader package.
+func isROMFile(filename string) bool {
+	ext := strings.ToUpper(filepath.Ext(filename))
+	for _, e := range cartridgeloader.FileExtensions {
+		if ext == strings.ToUpper(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchStats disassembles every ROM file in the directory (and its
+// sub-directories) and writes summary statistics for each ROM to output, in
+// CSV format. The first line of output is a header describing each column.
+//
+// ROMs that fail to disassemble are included in the output. The error is
+// recorded in the final column.
+func BatchStats(output io.Writer, dir string, mapping string) error {
+	defns := instructions.GetDefinitions()
+
+	w := csv.NewWriter(output)
+
+	header := []string{"filename", "mapping", "banks", "size", "code bytes", "utilisation",
+		"instructions", "undocumented", "distinct opcodes"}
+	for _, d := range defns {
+		if d != nil {
+			header = append(header, fmt.Sprintf("$%02x %s", d.OpCode, d.Mnemonic))
+		}
+	}
+	header = append(header, "error")
+
+	err := w.Write(header)
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	err = filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isROMFile(pth) {
+			return nil
+		}
+
+		record := make([]string, len(header))
+		record[0] = pth
+
+		st, err := batchStats(pth, mapping)
+		if err != nil {
+			record[len(record)-1] = err.Error()
+		} else {
+			record[1] = st.Mapping
+			record[2] = fmt.Sprintf("%d", st.NumBanks)
+			record[3] = fmt.Sprintf("%d", st.TotalBytes)
+			record[4] = fmt.Sprintf("%d", st.CodeBytes)
+			record[5] = fmt.Sprintf("%.2f", st.Utilisation())
+			record[6] = fmt.Sprintf("%d", st.Instructions)
+			record[7] = fmt.Sprintf("%d", st.Undocumented)
+
+			distinct := 0
+			i := 9
+			for _, d := range defns {
+				if d != nil {
+					n := st.Opcodes[d.OpCode]
+					if n > 0 {
+						distinct++
+					}
+					record[i] = fmt.Sprintf("%d", n)
+					i++
+				}
+			}
+			record[8] = fmt.Sprintf("%d", distinct)
+		}
+
+		return w.Write(record)
+	})
+	if err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return curated.Errorf("disassembly: %v", err)
+	}
+
+	return nil
+}
+
+func batchStats(filename string, mapping string) (Stats, error) {
+	dsm, err := FromCartridge(cartridgeloader.NewLoader(filename, mapping))
+	if err != nil {
+		return Stats{}, err
+	}
+	return dsm.Stats()
+}
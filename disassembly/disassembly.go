@@ -53,10 +53,17 @@ type Disassembly struct {
 	// whether a sync.Mutex is the best low level synchronisation method is
 	// another question.
 	crit sync.Mutex
+
+	// the current generation of formatted fields. the field cache of an entry
+	// is only valid if it matches this value. should only be accessed through
+	// the sync/atomic package.
+	fieldsGeneration uint32
 }
 
 func NewDisassembly() (*Disassembly, error) {
-	dsm := &Disassembly{}
+	dsm := &Disassembly{
+		fieldsGeneration: 1,
+	}
 
 	var err error
 
@@ -109,6 +116,9 @@ func (dsm *Disassembly) FromMemory(cart *cartridge.Cartridge, symbols *symbols.S
 		dsm.Symbols = symbols
 	}
 
+	// new symbols may change the width of fields
+	dsm.invalidateFields()
+
 	// allocate memory for disassembly. the GUI may find itself trying to
 	// iterate through disassembly at the same time as we're doing this.
 	dsm.crit.Lock()
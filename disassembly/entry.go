@@ -88,6 +88,9 @@ type Entry struct {
 	//
 	// should be empty if EntryLevel != EntryLevelExecuted
 	ExecutionNotes string
+
+	// cache of formatted fields. see GetField()
+	fields fieldCache
 }
 
 // String returns a very basic representation of an Entry. Provided for
@@ -213,6 +216,9 @@ func (e *Entry) updateExecutionEntry(result execution.Result) {
 	// indicate that entry has been executed
 	e.Level = EntryLevelExecuted
 
+	// formatted fields are now out of date
+	e.fields.generation = 0
+
 	// actual cycles
 	e.Cycles = fmt.Sprintf("%d", e.Result.Cycles)
 
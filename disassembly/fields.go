@@ -17,6 +17,7 @@ package disassembly
 
 import (
 	"fmt"
+	"sync/atomic"
 )
 
 // Field identifies which part of the disassmbly entry is of interest.
@@ -32,6 +33,8 @@ const (
 	FldDefnCycles
 	FldActualCycles
 	FldActualNotes
+
+	numFields
 )
 
 // required widths (in characters) of the various disassembly fields.
@@ -56,8 +59,55 @@ const (
 	// the width of the notes field is not recorded.
 )
 
+// fieldCache holds the result of formatField() for every field in an Entry.
+// formatting fields is relatively expensive and the GUI will request the same
+// fields for many entries every frame.
+//
+// the cache is valid only if the generation matches the generation of the
+// parent Disassembly. a generation of zero is never valid.
+type fieldCache struct {
+	generation uint32
+	fields     [numFields]string
+}
+
+// invalidateFields forces the field cache of every entry in the disassembly
+// to be regenerated. should be called whenever a change is made that affects
+// the formatting of all entries. for example, a change to the symbols
+// preference.
+func (dsm *Disassembly) invalidateFields() {
+	atomic.AddUint32(&dsm.fieldsGeneration, 1)
+}
+
+// updateFieldCache formats every field of the entry if the cache is out of
+// date. the entry should be the one stored in the disassembly (ie. not a copy)
+// and the disassembly's critical section should be locked.
+func (e *Entry) updateFieldCache() {
+	if e.dsm == nil {
+		return
+	}
+
+	gen := atomic.LoadUint32(&e.dsm.fieldsGeneration)
+	if e.fields.generation == gen {
+		return
+	}
+
+	for f := Field(0); f < numFields; f++ {
+		e.fields.fields[f] = e.formatField(f)
+	}
+	e.fields.generation = gen
+}
+
 // GetField returns the formatted field from the speficied Entry.
 func (e *Entry) GetField(field Field) string {
+	if e.dsm != nil && e.fields.generation == atomic.LoadUint32(&e.dsm.fieldsGeneration) {
+		return e.fields.fields[field]
+	}
+	return e.formatField(field)
+}
+
+// formatField returns the formatted field without reference to the field
+// cache.
+func (e *Entry) formatField(field Field) string {
 	var s string
 	var w int
 
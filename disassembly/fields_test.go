@@ -0,0 +1,114 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/symbols"
+)
+
+// create an entry for "STA WSYNC" in a new disassembly.
+func testEntry(t testing.TB) (*Disassembly, *Entry) {
+	t.Helper()
+
+	dsm, err := NewDisassembly()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dsm.Symbols = symbols.NewSymbols()
+
+	// use symbols regardless of preferences on disk
+	err = dsm.Prefs.Symbols.Set(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var defn *instructions.Definition
+	for _, d := range instructions.GetDefinitions() {
+		if d != nil && d.OpCode == 0x85 {
+			defn = d
+			break
+		}
+	}
+
+	e, err := dsm.FormatResult(mapper.BankInfo{}, execution.Result{
+		Defn:            defn,
+		ByteCount:       2,
+		Address:         0xf000,
+		InstructionData: 0x02,
+		Final:           true,
+	}, EntryLevelBlessed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dsm, e
+}
+
+func TestFieldCache(t *testing.T) {
+	dsm, e := testEntry(t)
+
+	uncached := e.GetField(FldOperand)
+	e.updateFieldCache()
+	if e.fields.generation == 0 {
+		t.Fatalf("field cache has not been updated")
+	}
+
+	cached := e.GetField(FldOperand)
+	if cached != uncached {
+		t.Errorf("cached field (%q) differs from uncached field (%q)", cached, uncached)
+	}
+
+	// changing the symbols preference must invalidate the cache
+	err := dsm.Prefs.Symbols.Set(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.GetField(FldOperand) == cached {
+		t.Errorf("field cache has not been invalidated by preference change")
+	}
+
+	e.updateFieldCache()
+	if e.GetField(FldOperand) != e.formatField(FldOperand) {
+		t.Errorf("field cache has not been regenerated")
+	}
+}
+
+func BenchmarkGetFieldUncached(b *testing.B) {
+	_, e := testEntry(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for f := Field(0); f < numFields; f++ {
+			_ = e.formatField(f)
+		}
+	}
+}
+
+func BenchmarkGetFieldCached(b *testing.B) {
+	_, e := testEntry(b)
+	e.updateFieldCache()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for f := Field(0); f < numFields; f++ {
+			_ = e.GetField(f)
+		}
+	}
+}
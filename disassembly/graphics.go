@@ -0,0 +1,261 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// the TIA registers that take graphics data. playfield registers are included
+// although the bit order of PF0 and PF2 is reversed when displayed.
+var graphicsRegisters = map[uint16]bool{
+	0x0d: true, // PF0
+	0x0e: true, // PF1
+	0x0f: true, // PF2
+	0x1b: true, // GRP0
+	0x1c: true, // GRP1
+}
+
+// the number of instructions after an indexed load in which to look for a
+// store to a graphics register.
+const graphicsLookahead = 4
+
+// the maximum length of a graphics table.
+const graphicsMaxLen = 256
+
+// GraphicsTable is a table of bytes in cartridge memory that is referenced by
+// the program in a way that suggests it contains sprite or playfield
+// graphics.
+type GraphicsTable struct {
+	Bank int
+
+	// address of the first byte of the table, as referenced by the program
+	Address uint16
+
+	// the TIA registers the table data is written to
+	Registers []string
+
+	// copy of the table data
+	Data []uint8
+}
+
+func (tbl GraphicsTable) String() string {
+	return fmt.Sprintf("bank %d %#04x (%d bytes) -> %s", tbl.Bank, tbl.Address, len(tbl.Data), strings.Join(tbl.Registers, ", "))
+}
+
+// Write the table as text art. Each byte is drawn as a row of eight
+// characters, most significant bit first.
+func (tbl GraphicsTable) Write(output io.Writer) {
+	output.Write([]byte(fmt.Sprintf("--- graphics: %s ---\n", tbl)))
+	for i, d := range tbl.Data {
+		s := strings.Builder{}
+		for b := 7; b >= 0; b-- {
+			if d&(1<<b) != 0 {
+				s.WriteRune('#')
+			} else {
+				s.WriteRune('.')
+			}
+		}
+		output.Write([]byte(fmt.Sprintf("%#04x  %02x  %s\n", tbl.Address+uint16(i), d, s.String())))
+	}
+}
+
+// GraphicsTables returns the list of tables that appear to contain graphics
+// data, in order of bank and address.
+//
+// A table is detected when an indexed load from cartridge memory is followed
+// shortly afterwards by a store of the same register to GRP0, GRP1 or one of
+// the playfield registers. The length of the table is not known so the table
+// is assumed to end at the next instruction, the next table or after 256
+// bytes, whichever comes first.
+func (dsm *Disassembly) GraphicsTables() []GraphicsTable {
+	dsm.crit.Lock()
+	defer dsm.crit.Unlock()
+
+	if dsm.cart == nil {
+		return nil
+	}
+
+	banks, err := dsm.cart.CopyBanks()
+	if err != nil {
+		return nil
+	}
+
+	var tables []GraphicsTable
+
+	for b := range dsm.entries {
+		// tables found in this bank, indexed by masked address
+		found := make(map[uint16]*GraphicsTable)
+
+		for _, e := range dsm.entries[b] {
+			if e == nil || e.Level < EntryLevelBlessed || e.Result.Defn == nil {
+				continue // for loop
+			}
+
+			reg := loadRegister(e.Result.Defn)
+			if reg == "" {
+				continue // for loop
+			}
+
+			_, area := memorymap.MapAddress(e.Result.InstructionData, true)
+			if area != memorymap.Cartridge {
+				continue // for loop
+			}
+
+			target := dsm.graphicsStore(b, e, reg)
+			if target == "" {
+				continue // for loop
+			}
+
+			addr := e.Result.InstructionData & memorymap.CartridgeBits
+			if tbl, ok := found[addr]; ok {
+				tbl.addRegister(target)
+			} else {
+				found[addr] = &GraphicsTable{
+					Bank:      b,
+					Address:   e.Result.InstructionData,
+					Registers: []string{target},
+				}
+			}
+		}
+
+		// order tables by address and copy table data
+		addrs := make([]uint16, 0, len(found))
+		for a := range found {
+			addrs = append(addrs, a)
+		}
+		sort.Slice(addrs, func(i, j int) bool { return addrs[i] < addrs[j] })
+
+		for i, a := range addrs {
+			end := a + graphicsMaxLen
+			if i+1 < len(addrs) && addrs[i+1] < end {
+				end = addrs[i+1]
+			}
+
+			// table ends at the next instruction
+			for n := a; n < end && int(n) < len(dsm.entries[b]); n++ {
+				if e := dsm.entries[b][n]; e != nil && e.Level >= EntryLevelBlessed {
+					end = n
+				}
+			}
+
+			tbl := found[a]
+			for _, bank := range banks {
+				if bank.Number != b {
+					continue // for loop
+				}
+				for _, o := range bank.Origins {
+					o &= memorymap.CartridgeBits
+					if a >= o && int(a-o) < len(bank.Data) {
+						s := int(a - o)
+						e := int(end - o)
+						if e > len(bank.Data) {
+							e = len(bank.Data)
+						}
+						tbl.Data = append([]uint8{}, bank.Data[s:e]...)
+						break // for loop
+					}
+				}
+			}
+
+			if len(tbl.Data) > 0 {
+				tables = append(tables, *tbl)
+			}
+		}
+	}
+
+	return tables
+}
+
+func (tbl *GraphicsTable) addRegister(reg string) {
+	for _, r := range tbl.Registers {
+		if r == reg {
+			return
+		}
+	}
+	tbl.Registers = append(tbl.Registers, reg)
+}
+
+// loadRegister returns the register loaded by an indexed absolute load
+// instruction. returns the empty string if the instruction is not an indexed
+// absolute load.
+func loadRegister(defn *instructions.Definition) string {
+	if defn.AddressingMode != instructions.AbsoluteIndexedX && defn.AddressingMode != instructions.AbsoluteIndexedY {
+		return ""
+	}
+	switch defn.Mnemonic {
+	case "LDA":
+		return "A"
+	case "LDX":
+		return "X"
+	case "LDY":
+		return "Y"
+	case "lax":
+		return "A"
+	}
+	return ""
+}
+
+// graphicsStore looks at the instructions following the load entry for a
+// store of the register to a graphics register. returns the name of the
+// graphics register or the empty string if no such store is found.
+//
+// called with the crit lock held.
+func (dsm *Disassembly) graphicsStore(bank int, load *Entry, reg string) string {
+	e := load
+	for i := 0; i < graphicsLookahead; i++ {
+		next := (e.Result.Address + uint16(e.Result.ByteCount)) & memorymap.CartridgeBits
+		if int(next) >= len(dsm.entries[bank]) {
+			return ""
+		}
+
+		e = dsm.entries[bank][next]
+		if e == nil || e.Level < EntryLevelBlessed || e.Result.Defn == nil {
+			return ""
+		}
+
+		defn := e.Result.Defn
+
+		// a change of flow ends the search
+		if defn.Effect == instructions.Flow || defn.Effect == instructions.Subroutine || defn.Effect == instructions.Interrupt {
+			return ""
+		}
+
+		if defn.Effect == instructions.Write && "ST"+reg == defn.Mnemonic {
+			switch defn.AddressingMode {
+			case instructions.ZeroPage, instructions.Absolute:
+				ma, area := memorymap.MapAddress(e.Result.InstructionData, false)
+				if area == memorymap.TIA && graphicsRegisters[ma] {
+					return addresses.TIAWriteSymbols[ma]
+				}
+			}
+		}
+
+		// the register has been overwritten
+		if loadRegister(defn) == reg || ("LD"+reg == defn.Mnemonic) {
+			return ""
+		}
+	}
+
+	return ""
+}
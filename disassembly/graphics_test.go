@@ -0,0 +1,79 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+)
+
+func TestGraphicsTables(t *testing.T) {
+	data, err := assembler.Cartridge(`ldy #3 : lda $f010,y : sta $02 : sta $1b : dey : bpl $f002 : jmp $f000`)
+	if err != nil {
+		t.Fatalf("unexpected error assembling program: %v", err)
+	}
+
+	// graphics data at $f010, after a single padding byte
+	copy(data[0x10:], []byte{0x18, 0x3c, 0x7e, 0xff})
+
+	// the table runs until the end of the bank if it is not followed by
+	// another instruction. make sure the rest of the bank is not executable
+	// and is easily identifiable
+	for i := 0x14; i < 0xffc; i++ {
+		data[i] = 0x02
+	}
+
+	cartload := cartridgeloader.Loader{
+		Filename: "graphics_test.bin",
+		Mapping:  "4k",
+		Data:     data,
+	}
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables := dsm.GraphicsTables()
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 graphics table, got %d", len(tables))
+	}
+
+	tbl := tables[0]
+	if tbl.Address != 0xf010 {
+		t.Errorf("unexpected table address (%#04x)", tbl.Address)
+	}
+
+	if len(tbl.Registers) != 1 || tbl.Registers[0] != "GRP0" {
+		t.Errorf("unexpected table registers (%v)", tbl.Registers)
+	}
+
+	if !bytes.HasPrefix(tbl.Data, []byte{0x18, 0x3c, 0x7e, 0xff, 0x02}) {
+		t.Errorf("unexpected table data (% 02x)", tbl.Data[:5])
+	}
+
+	s := &strings.Builder{}
+	tbl.Write(s)
+	lines := strings.Split(s.String(), "\n")
+	if lines[1] != "0xf010  18  ...##..." || lines[4] != "0xf013  ff  ########" {
+		t.Errorf("unexpected text art (%s) (%s)", lines[1], lines[4])
+	}
+}
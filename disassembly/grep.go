@@ -32,6 +32,7 @@ const (
 	GrepAll GrepScope = iota
 	GrepMnemonic
 	GrepOperand
+	GrepLabel
 )
 
 // Grep searches the disassembly for the specified search string.
@@ -65,6 +66,8 @@ func (dsm *Disassembly) Grep(output io.Writer, scope GrepScope, search string, c
 				s = e.Mnemonic
 			case GrepOperand:
 				s = e.String()
+			case GrepLabel:
+				s = e.Label.String()
 			case GrepAll:
 				s = l.String()
 			}
@@ -96,3 +99,53 @@ func (dsm *Disassembly) Grep(output io.Writer, scope GrepScope, search string, c
 
 	return nil
 }
+
+// SearchResult identifies an Entry found by the Search() function.
+type SearchResult struct {
+	Bank    int
+	Address uint16
+}
+
+// Search returns the location of every entry that matches the search string.
+// Results are ordered by bank and then by position in the bank. Unlike Grep()
+// the GrepOperand scope matches only the operand of the entry.
+func (dsm *Disassembly) Search(scope GrepScope, search string, caseSensitive bool) ([]SearchResult, error) {
+	if !caseSensitive {
+		search = strings.ToUpper(search)
+	}
+
+	var results []SearchResult
+
+	citr := dsm.NewCartIteration()
+	for b, ok := citr.Start(); ok; b, ok = citr.Next() {
+		bitr, err := dsm.NewBankIteration(EntryLevelBlessed, b)
+		if err != nil {
+			return nil, curated.Errorf("search: %v", err)
+		}
+
+		for _, e := bitr.Start(); e != nil; _, e = bitr.Next() {
+			var s string
+
+			switch scope {
+			case GrepMnemonic:
+				s = e.Mnemonic
+			case GrepOperand:
+				s = e.Operand.String()
+			case GrepLabel:
+				s = e.Label.String()
+			case GrepAll:
+				s = fmt.Sprintf("%s %s %s", e.Label.String(), e.Mnemonic, e.Operand.String())
+			}
+
+			if !caseSensitive {
+				s = strings.ToUpper(s)
+			}
+
+			if strings.Contains(s, search) {
+				results = append(results, SearchResult{Bank: b, Address: e.Result.Address})
+			}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,71 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/disassembly"
+)
+
+const searchProgram = `sei : cld : ldx #$ff : txs
+lda $80 : sta $81 : lda $82
+jmp $f000`
+
+func TestSearch(t *testing.T) {
+	data, err := assembler.Cartridge(searchProgram)
+	if err != nil {
+		t.Fatalf("unexpected error assembling program: %v", err)
+	}
+
+	cartload := cartridgeloader.NewLoader("search_test.bin", "AUTO")
+	cartload.Data = data
+
+	dsm, err := disassembly.FromCartridge(cartload)
+	if err != nil {
+		t.Fatalf("unexpected error disassembling: %v", err)
+	}
+
+	res, err := dsm.Search(disassembly.GrepMnemonic, "lda", false)
+	if err != nil {
+		t.Fatalf("unexpected error searching: %v", err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected 2 results for LDA (got %d)", len(res))
+	}
+	if res[0].Address >= res[1].Address {
+		t.Errorf("search results are not ordered by address")
+	}
+
+	// mnemonics are upper case so a case sensitive search will fail
+	res, err = dsm.Search(disassembly.GrepMnemonic, "lda", true)
+	if err != nil {
+		t.Fatalf("unexpected error searching: %v", err)
+	}
+	if len(res) != 0 {
+		t.Errorf("expected no results for case sensitive search (got %d)", len(res))
+	}
+
+	res, err = dsm.Search(disassembly.GrepOperand, "$81", false)
+	if err != nil {
+		t.Fatalf("unexpected error searching: %v", err)
+	}
+	if len(res) != 1 {
+		t.Errorf("expected 1 result for operand search (got %d)", len(res))
+	}
+}
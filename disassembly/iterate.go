@@ -184,6 +184,10 @@ func (bitr *IterateBank) next() (int, *Entry) {
 
 	bitr.lastEntry = bitr.dsm.entries[bitr.bank][bitr.idx]
 
+	// format fields while we have the critical section. the copy of the entry
+	// will have an up-to-date field cache
+	bitr.lastEntry.updateFieldCache()
+
 	return bitr.idx, makeCopyofEntry(*bitr.lastEntry)
 }
 
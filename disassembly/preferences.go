@@ -77,6 +77,12 @@ func newPreferences(dsm *Disassembly) (*Preferences, error) {
 		return nil
 	})
 
+	p.Symbols.RegisterCallback(func(v prefs.Value) error {
+		// symbols change the content and width of the label and operand fields
+		dsm.invalidateFields()
+		return nil
+	})
+
 	err = p.dsk.Load(true)
 	if err != nil {
 		return nil, err
@@ -101,6 +107,9 @@ func (dsm *Disassembly) setCartMirror() {
 	dsm.crit.Lock()
 	defer dsm.crit.Unlock()
 
+	// addresses of all entries are changing
+	dsm.invalidateFields()
+
 	for b := range dsm.entries {
 		for _, e := range dsm.entries[b] {
 			// mask off bits that indicate the cartridge/segment origin and reset
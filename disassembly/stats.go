@@ -0,0 +1,111 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// Stats summarises the disassembly of a cartridge. Only blessed entries are
+// considered.
+type Stats struct {
+	// the cartridge mapping and the number of banks
+	Mapping  string
+	NumBanks int
+
+	// number of instructions in the disassembly
+	Instructions int
+
+	// number of instructions using each opcode. indexed by opcode value
+	Opcodes [256]int
+
+	// number of instructions that are undocumented (or illegal) opcodes
+	Undocumented int
+
+	// the number of bytes in the cartridge that are occupied by instructions
+	// compared to the total number of bytes in the cartridge
+	CodeBytes  int
+	TotalBytes int
+}
+
+// Stats returns summary statistics for the disassembly.
+func (dsm *Disassembly) Stats() (Stats, error) {
+	st := Stats{
+		Mapping: dsm.cart.ID(),
+	}
+
+	banks, err := dsm.cart.CopyBanks()
+	if err != nil {
+		return st, curated.Errorf("disassembly: %v", err)
+	}
+
+	// the coverage of each bank. a bank might be disassembled at more than one
+	// address (the bank may be mirrored for example) so we record which bytes
+	// have been counted in order to not count them twice
+	coverage := make([][]bool, len(banks))
+	for i, b := range banks {
+		coverage[i] = make([]bool, len(b.Data))
+		st.TotalBytes += len(b.Data)
+	}
+
+	citr := dsm.NewCartIteration()
+	st.NumBanks = citr.BankCount
+
+	for b, ok := citr.Start(); ok; b, ok = citr.Next() {
+		bitr, err := dsm.NewBankIteration(EntryLevelBlessed, b)
+		if err != nil {
+			return st, curated.Errorf("disassembly: %v", err)
+		}
+
+		for _, e := bitr.Start(); e != nil; _, e = bitr.Next() {
+			if e.Result.Defn == nil {
+				continue
+			}
+
+			st.Instructions++
+			st.Opcodes[e.Result.Defn.OpCode]++
+			if e.Result.Defn.IsUndocumented() {
+				st.Undocumented++
+			}
+
+			if b >= len(coverage) || len(coverage[b]) == 0 {
+				continue
+			}
+
+			cov := coverage[b]
+			offset := int(e.Result.Address & memorymap.CartridgeBits)
+			for i := 0; i < e.Result.ByteCount; i++ {
+				idx := (offset + i) % len(cov)
+				if !cov[idx] {
+					cov[idx] = true
+					st.CodeBytes++
+				}
+			}
+		}
+	}
+
+	return st, nil
+}
+
+// Utilisation returns the proportion of the cartridge occupied by
+// instructions, as a percentage.
+func (st Stats) Utilisation() float64 {
+	if st.TotalBytes == 0 {
+		return 0
+	}
+	return float64(st.CodeBytes) / float64(st.TotalBytes) * 100
+}
@@ -0,0 +1,101 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package disassembly_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/disassembly"
+)
+
+// a short program with one undocumented opcode (lax).
+const statsProgram = `sei : cld : ldx #$ff : txs
+lax $80 : inx : stx $81
+jmp $f000`
+
+func TestBatchStats(t *testing.T) {
+	data, err := assembler.Cartridge(statsProgram)
+	if err != nil {
+		t.Fatalf("unexpected error assembling program: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "stats_test")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "test.bin"), data, 0644)
+	if err != nil {
+		t.Fatalf("unexpected error writing ROM: %v", err)
+	}
+
+	// files that are not ROMs should be ignored
+	err = ioutil.WriteFile(filepath.Join(dir, "readme.txt"), []byte("not a rom"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error writing file: %v", err)
+	}
+
+	var b bytes.Buffer
+	err = disassembly.BatchStats(&b, dir, "AUTO")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error reading CSV: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected header and one ROM in CSV output, got %d records", len(records))
+	}
+
+	hdr := records[0]
+	rec := records[1]
+
+	col := func(name string) string {
+		for i, h := range hdr {
+			if h == name {
+				return rec[i]
+			}
+		}
+		t.Fatalf("no column named %s", name)
+		return ""
+	}
+
+	if e := col("error"); e != "" {
+		t.Fatalf("unexpected error in CSV output: %s", e)
+	}
+	if v := col("mapping"); v != "4k" {
+		t.Errorf("unexpected mapping: %s", v)
+	}
+	if v := col("banks"); v != "1" {
+		t.Errorf("unexpected number of banks: %s", v)
+	}
+	if v := col("undocumented"); v != "1" {
+		t.Errorf("unexpected number of undocumented instructions: %s", v)
+	}
+	if v := col("$a7 lax"); v != "1" {
+		t.Errorf("unexpected count for lax opcode: %s", v)
+	}
+}
@@ -0,0 +1,23 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package doctor produces a report of the environment in which the emulator
+// is running. The report is intended to be pasted into bug reports.
+//
+// The Check() function covers everything that does not require the GUI.
+// Diagnostics of the GUI environment (SDL, OpenGL and audio devices) are the
+// responsibility of the GUI package being used. For example, the
+// sdlimgui.Diagnose() function.
+package doctor
@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+	"github.com/jetsetilly/gopher2600/version"
+)
+
+// Check writes a report of the program's environment to output. Problems
+// found are written to the report and do not cause an error to be returned.
+func Check(output io.Writer) {
+	Section(output, "environment")
+	Item(output, "gopher2600 version", version.Version)
+	Item(output, "go version", runtime.Version())
+	Item(output, "os/arch", fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH))
+	Item(output, "cpus", fmt.Sprintf("%d", runtime.NumCPU()))
+
+	Section(output, "preferences")
+	checkPreferences(output)
+}
+
+// Section writes a section heading to output.
+func Section(output io.Writer, title string) {
+	io.WriteString(output, fmt.Sprintf("\n[%s]\n", title))
+}
+
+// Item writes a single named value to output.
+func Item(output io.Writer, label string, value string) {
+	io.WriteString(output, fmt.Sprintf("%-20s %s\n", label+":", value))
+}
+
+// Problem writes a single named value to output, marked as a problem.
+func Problem(output io.Writer, label string, problem string) {
+	Item(output, label, fmt.Sprintf("PROBLEM: %s", problem))
+}
+
+func checkPreferences(output io.Writer) {
+	pth, err := paths.ResourcePath("", "")
+	if err != nil {
+		Problem(output, "directory", err.Error())
+		return
+	}
+	Item(output, "directory", pth)
+
+	if paths.IsPortable() {
+		Item(output, "portable mode", "yes")
+	} else {
+		Item(output, "portable mode", "no")
+	}
+
+	// check that the preferences directory can be written to by creating (and
+	// then removing) a temporary file
+	f, err := ioutil.TempFile(pth, "doctor")
+	if err != nil {
+		Problem(output, "writable", err.Error())
+	} else {
+		Item(output, "writable", "yes")
+		f.Close()
+		os.Remove(f.Name())
+	}
+
+	pth, err = paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		Problem(output, "preferences file", err.Error())
+		return
+	}
+
+	inf, err := os.Stat(pth)
+	if err != nil {
+		if os.IsNotExist(err) {
+			Item(output, "preferences file", "not yet created")
+		} else {
+			Problem(output, "preferences file", err.Error())
+		}
+		return
+	}
+
+	f, err = os.Open(pth)
+	if err != nil {
+		Problem(output, "preferences file", err.Error())
+		return
+	}
+	f.Close()
+
+	Item(output, "preferences file", fmt.Sprintf("%s (%d bytes)", pth, inf.Size()))
+}
@@ -0,0 +1,34 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package doctor_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/doctor"
+)
+
+func TestCheck(t *testing.T) {
+	s := &strings.Builder{}
+	doctor.Check(s)
+
+	for _, section := range []string{"[environment]", "[preferences]"} {
+		if !strings.Contains(s.String(), section) {
+			t.Errorf("report is missing the %s section", section)
+		}
+	}
+}
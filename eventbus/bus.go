@@ -0,0 +1,147 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package eventbus
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Event identifies the type of the Notification.
+type Event int
+
+// List of valid Event values.
+const (
+	// the game has been reset. either by the console's reset switch or by
+	// the game itself
+	Reset Event = iota
+
+	// a memory location of interest has changed value. for example, the
+	// memory location of the player's score
+	MemoryCondition
+
+	// a collision of interest has occurred
+	Collision
+)
+
+func (ev Event) String() string {
+	switch ev {
+	case Reset:
+		return "reset"
+	case MemoryCondition:
+		return "memory condition"
+	case Collision:
+		return "collision"
+	}
+	return "unknown event"
+}
+
+// Notification is sent to subscribers of the Bus.
+type Notification struct {
+	Event Event
+
+	// the label given to the memory condition or collision when it was added
+	// to the Monitor. empty for events that don't have labels
+	Label string
+
+	// the frame number at the time of the event
+	Frame int
+
+	// additional detail about the event. suitable for display to the user
+	Detail string
+}
+
+func (n Notification) String() string {
+	s := n.Event.String()
+	if n.Label != "" {
+		s = fmt.Sprintf("%s (%s)", s, n.Label)
+	}
+	if n.Detail != "" {
+		s = fmt.Sprintf("%s: %s", s, n.Detail)
+	}
+	return fmt.Sprintf("%s [frame %d]", s, n.Frame)
+}
+
+// Subscriber functions are called with each Notification they have subscribed
+// to.
+type Subscriber func(Notification)
+
+type subscription struct {
+	id     int
+	events []Event
+	fn     Subscriber
+}
+
+// Bus distributes notifications to subscribers. It is safe to Subscribe() and
+// Unsubscribe() from any goroutine.
+type Bus struct {
+	crit   sync.Mutex
+	subs   []subscription
+	nextID int
+}
+
+// NewBus is the preferred method of initialisation for the Bus type.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe to the list of events. An empty list means that the subscriber
+// will receive all events. Returns an ID that can be used with Unsubscribe().
+func (b *Bus) Subscribe(fn Subscriber, events ...Event) int {
+	b.crit.Lock()
+	defer b.crit.Unlock()
+
+	b.nextID++
+	b.subs = append(b.subs, subscription{id: b.nextID, events: events, fn: fn})
+
+	return b.nextID
+}
+
+// Unsubscribe the subscriber with the ID returned by Subscribe().
+func (b *Bus) Unsubscribe(id int) {
+	b.crit.Lock()
+	defer b.crit.Unlock()
+
+	for i := range b.subs {
+		if b.subs[i].id == id {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish notification to all interested subscribers.
+func (b *Bus) Publish(n Notification) {
+	b.crit.Lock()
+	subs := make([]subscription, len(b.subs))
+	copy(subs, b.subs)
+	b.crit.Unlock()
+
+	// subscribers are called outside of the critical section so that they
+	// can themselves subscribe and unsubscribe
+	for _, s := range subs {
+		if len(s.events) == 0 {
+			s.fn(n)
+			continue
+		}
+		for _, ev := range s.events {
+			if ev == n.Event {
+				s.fn(n)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package eventbus_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/eventbus"
+)
+
+func TestBus(t *testing.T) {
+	bus := eventbus.NewBus()
+
+	var all, collisions int
+
+	allID := bus.Subscribe(func(n eventbus.Notification) {
+		all++
+	})
+
+	bus.Subscribe(func(n eventbus.Notification) {
+		collisions++
+	}, eventbus.Collision)
+
+	bus.Publish(eventbus.Notification{Event: eventbus.Reset})
+	bus.Publish(eventbus.Notification{Event: eventbus.Collision})
+	bus.Publish(eventbus.Notification{Event: eventbus.MemoryCondition})
+
+	if all != 3 {
+		t.Errorf("expected 3 notifications for unfiltered subscriber (got %d)", all)
+	}
+	if collisions != 1 {
+		t.Errorf("expected 1 notification for collision subscriber (got %d)", collisions)
+	}
+
+	bus.Unsubscribe(allID)
+	bus.Publish(eventbus.Notification{Event: eventbus.Collision})
+
+	if all != 3 {
+		t.Errorf("unsubscribed subscriber received notification")
+	}
+	if collisions != 2 {
+		t.Errorf("expected 2 notifications for collision subscriber (got %d)", collisions)
+	}
+}
@@ -0,0 +1,32 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package eventbus allows interested parties to be notified of significant
+// events in the emulation. For example, the game being reset, a memory
+// location of interest changing or a collision between two particular
+// objects.
+//
+// The Bus type is the means of communication. Subscribers are added with the
+// Subscribe() function and events are sent to the subscribers with the
+// Publish() function.
+//
+// The Monitor type watches the emulation and publishes events to a Bus as and
+// when it detects them. The Check() function of the Monitor should be called
+// after every CPU instruction.
+//
+// Subscribers are called from the emulation goroutine. Subscribers that need
+// to do any significant work, or which need to do work in another goroutine
+// (a GUI for example), should pass the Notification on over a channel.
+package eventbus
@@ -0,0 +1,168 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package eventbus
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// memoryCondition is a memory location that is checked once per frame.
+type memoryCondition struct {
+	label   string
+	address uint16
+	value   uint8
+}
+
+// collision is a collision register that is checked after every instruction.
+type collision struct {
+	label    string
+	register uint16
+	mask     uint8
+	set      bool
+}
+
+// Monitor watches the emulation for events and publishes them to the Bus.
+type Monitor struct {
+	vcs *hardware.VCS
+	bus *Bus
+
+	// the address pointed to by the reset vector. updated once per frame
+	resetAddress uint16
+
+	// the address of the most recent instruction
+	lastAddress uint16
+
+	// frame number at the most recent call to Check()
+	frame int
+
+	memory     []memoryCondition
+	collisions []collision
+}
+
+// NewMonitor is the preferred method of initialisation for the Monitor type.
+func NewMonitor(vcs *hardware.VCS, bus *Bus) *Monitor {
+	m := &Monitor{
+		vcs: vcs,
+		bus: bus,
+	}
+	m.frame = -1
+	return m
+}
+
+// AddMemoryCondition adds a memory location to the list of locations that
+// will cause a MemoryCondition event when they change value.
+func (m *Monitor) AddMemoryCondition(label string, address uint16) error {
+	v, err := m.vcs.Mem.Peek(address)
+	if err != nil {
+		return err
+	}
+	m.memory = append(m.memory, memoryCondition{label: label, address: address, value: v})
+	return nil
+}
+
+// AddCollision adds a collision register to the list of registers that will
+// cause a Collision event when any of the bits in the mask become set.
+func (m *Monitor) AddCollision(label string, register uint16, mask uint8) error {
+	if _, ok := addresses.TIAReadSymbols[register]; !ok {
+		return curated.Errorf("eventbus: %#04x is not a TIA read register", register)
+	}
+	m.collisions = append(m.collisions, collision{label: label, register: register, mask: mask})
+	return nil
+}
+
+// Clear all memory conditions and collisions.
+func (m *Monitor) Clear() {
+	m.memory = m.memory[:0]
+	m.collisions = m.collisions[:0]
+}
+
+// String returns a list of memory conditions and collisions being monitored.
+func (m *Monitor) String() string {
+	s := ""
+	for _, c := range m.memory {
+		s += fmt.Sprintf("memory condition (%s): %#04x\n", c.label, c.address)
+	}
+	for _, c := range m.collisions {
+		s += fmt.Sprintf("collision (%s): %s & %#02x\n", c.label, addresses.ReadSymbols[c.register], c.mask)
+	}
+	return s
+}
+
+// Check the emulation for events. Should be called after every CPU
+// instruction.
+func (m *Monitor) Check() {
+	fn := m.vcs.TV.GetState(signal.ReqFramenum)
+	newFrame := fn != m.frame
+	m.frame = fn
+
+	if newFrame {
+		if lo, err := m.vcs.Mem.Peek(addresses.Reset); err == nil {
+			if hi, err := m.vcs.Mem.Peek(addresses.Reset + 1); err == nil {
+				m.resetAddress = uint16(hi)<<8 | uint16(lo)
+			}
+		}
+
+		for i := range m.memory {
+			c := &m.memory[i]
+			v, err := m.vcs.Mem.Peek(c.address)
+			if err != nil || v == c.value {
+				continue
+			}
+			m.bus.Publish(Notification{
+				Event:  MemoryCondition,
+				Label:  c.label,
+				Frame:  fn,
+				Detail: fmt.Sprintf("%#02x -> %#02x", c.value, v),
+			})
+			c.value = v
+		}
+	}
+
+	// the game has been reset if the instruction just executed is at the
+	// address indicated by the reset vector. we compare only the lower 13
+	// bits of the address because the cartridge may be mirrored
+	address := m.vcs.CPU.LastResult.Address & 0x1fff
+	if address == m.resetAddress&0x1fff && m.lastAddress != address {
+		m.bus.Publish(Notification{
+			Event: Reset,
+			Frame: fn,
+		})
+	}
+	m.lastAddress = address
+
+	for i := range m.collisions {
+		c := &m.collisions[i]
+		v, err := m.vcs.Mem.Peek(c.register)
+		if err != nil {
+			continue
+		}
+		set := v&c.mask != 0
+		if set && !c.set {
+			m.bus.Publish(Notification{
+				Event:  Collision,
+				Label:  c.label,
+				Frame:  fn,
+				Detail: addresses.ReadSymbols[c.register],
+			})
+		}
+		c.set = set
+	}
+}
@@ -0,0 +1,34 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package farm runs many independent emulations concurrently in the same
+// process. It is intended for regression farms and for machine learning
+// applications, where the throughput of many emulations is more important
+// than the speed of any one emulation.
+//
+// Each Instance is run in its own goroutine with its own television and VCS.
+// The Hooks type allows the caller to interact with each emulation: Setup()
+// is called once before the emulation starts, and is the place to add pixel
+// renderers or audio mixers to the television; Frame() is called after every
+// frame, and is the place to inject input and inspect the state of the
+// emulation.
+//
+// Hooks are called from the instance's goroutine. Hooks for different
+// instances may be called at the same time so care should be taken if the
+// hook implementations share any data.
+//
+// For maximum throughput instances should be run in no-render mode. See the
+// NoRender field of the Instance type.
+package farm
@@ -0,0 +1,165 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package farm
+
+import (
+	"sync"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/setup"
+)
+
+// Hooks allow the caller to interact with a running instance. The id argument
+// is the index of the instance in the list given to Run(). Either field can
+// be nil.
+type Hooks struct {
+	// Setup is called once, after the cartridge has been attached and before
+	// the emulation starts
+	Setup func(id int, vcs *hardware.VCS) error
+
+	// Frame is called after every frame. Input should be injected with the
+	// HandleEvent() function of the VCS's ports. Return false to end the
+	// emulation
+	Frame func(id int, vcs *hardware.VCS) (bool, error)
+}
+
+// Instance specifies a single emulation.
+type Instance struct {
+	// the cartridge to run. if the Data field of the loader has been filled
+	// then each instance will receive a copy of the data
+	Cartload cartridgeloader.Loader
+
+	// television specification. an empty string is the same as "AUTO"
+	Spec string
+
+	// put the television into no-render mode. pixel renderers can not be
+	// added in the Setup() hook when this is true
+	NoRender bool
+
+	// seed for the instance's random number generator. a value of zero will
+	// seed the generator with the current time
+	Seed int64
+
+	// the number of frames to run for. a value of zero means that the
+	// emulation will run until the Frame() hook returns false
+	Frames int
+
+	Hooks Hooks
+}
+
+// Result of a single instance.
+type Result struct {
+	// the number of frames that were run
+	Frames int
+
+	// the error that caused the instance to end, if any
+	Err error
+}
+
+// Run all instances concurrently. Returns when every instance has ended. The
+// results are returned in the same order as the instances.
+func Run(instances []Instance) []Result {
+	results := make([]Result, len(instances))
+
+	var wg sync.WaitGroup
+	for i := range instances {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			results[id].Frames, results[id].Err = run(id, instances[id])
+		}(i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// run a single instance. returns the number of frames run.
+func run(id int, inst Instance) (int, error) {
+	spec := inst.Spec
+	if spec == "" {
+		spec = "AUTO"
+	}
+
+	tv, err := television.NewTelevision(spec)
+	if err != nil {
+		return 0, curated.Errorf("farm: %v", err)
+	}
+	defer tv.End()
+
+	tv.SetFPSCap(false)
+
+	if inst.NoRender {
+		err = tv.SetNoRender(true)
+		if err != nil {
+			return 0, curated.Errorf("farm: %v", err)
+		}
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		return 0, curated.Errorf("farm: %v", err)
+	}
+	vcs.Prefs.Reseed(inst.Seed)
+
+	// the cartridge data must not be shared between instances
+	cartload := inst.Cartload
+	if cartload.Data != nil {
+		cartload.Data = make([]byte, len(inst.Cartload.Data))
+		copy(cartload.Data, inst.Cartload.Data)
+	}
+
+	err = setup.AttachCartridge(vcs, cartload)
+	if err != nil {
+		return 0, curated.Errorf("farm: %v", err)
+	}
+
+	if inst.Hooks.Setup != nil {
+		err = inst.Hooks.Setup(id, vcs)
+		if err != nil {
+			return 0, curated.Errorf("farm: %v", err)
+		}
+	}
+
+	startFrame := tv.GetState(signal.ReqFramenum)
+	lastFrame := startFrame
+
+	err = vcs.Run(func() (bool, error) {
+		fn := tv.GetState(signal.ReqFramenum)
+		if fn == lastFrame {
+			return true, nil
+		}
+		lastFrame = fn
+
+		if inst.Hooks.Frame != nil {
+			cont, err := inst.Hooks.Frame(id, vcs)
+			if !cont || err != nil {
+				return false, err
+			}
+		}
+
+		return inst.Frames == 0 || fn-startFrame < inst.Frames, nil
+	})
+	if err != nil {
+		return lastFrame - startFrame, curated.Errorf("farm: %v", err)
+	}
+
+	return lastFrame - startFrame, nil
+}
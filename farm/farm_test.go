@@ -0,0 +1,98 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package farm_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/farm"
+	"github.com/jetsetilly/gopher2600/hardware"
+)
+
+// a simple kernel that increments a RAM location every frame.
+const kernel = `sei : cld : ldx #$ff : txs
+lda #$02 : sta $00 : sta $02 : sta $02 : sta $02 : lda #$00 : sta $00
+inc $80
+ldx #$ff : sta $02 : dex : bne $f015
+jmp $f005`
+
+func TestFarm(t *testing.T) {
+	const numInstances = 8
+	const numFrames = 10
+
+	data, err := assembler.Cartridge(kernel)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	cartload := cartridgeloader.Loader{
+		Filename: "farm_test",
+		Mapping:  "4k",
+		Data:     data,
+	}
+
+	// each instance writes only to its own entry
+	setups := make([]bool, numInstances)
+	frames := make([]int, numInstances)
+
+	instances := make([]farm.Instance, numInstances)
+	for i := range instances {
+		instances[i] = farm.Instance{
+			Cartload: cartload,
+			Spec:     "NTSC",
+			NoRender: true,
+			Seed:     1,
+			Frames:   numFrames,
+			Hooks: farm.Hooks{
+				Setup: func(id int, _ *hardware.VCS) error {
+					setups[id] = true
+					return nil
+				},
+				Frame: func(id int, _ *hardware.VCS) (bool, error) {
+					frames[id]++
+					return true, nil
+				},
+			},
+		}
+	}
+
+	// the last instance stops itself early
+	instances[numInstances-1].Hooks.Frame = func(id int, _ *hardware.VCS) (bool, error) {
+		frames[id]++
+		return frames[id] < numFrames/2, nil
+	}
+
+	results := farm.Run(instances)
+
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("instance %d: unexpected error: %v", i, r.Err)
+		}
+		if !setups[i] {
+			t.Errorf("instance %d: setup hook not called", i)
+		}
+
+		expected := numFrames
+		if i == numInstances-1 {
+			expected = numFrames / 2
+		}
+		if r.Frames != expected || frames[i] != expected {
+			t.Errorf("instance %d: expected %d frames, got %d (%d hook calls)", i, expected, r.Frames, frames[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package framehealth checks the television signal produced by a ROM for
+// non-standard synchronisation. Non-standard sync might display correctly in
+// an emulator but cause problems on real televisions.
+//
+// The checks made are:
+//
+//	o frames that end without a VSYNC
+//	o VSYNC held for a number of scanlines other than three
+//	o frames in which VBLANK is never enabled
+//	o frames with a number of scanlines other than that required by the
+//	  television specification
+//	o PAL frames with an odd number of scanlines, which cause colour loss on
+//	  PAL televisions
+//
+// Each warning is tagged with the frame, scanline and horizontal position at
+// which the problem was detected. To prevent the same problem being reported
+// every frame, a warning is only issued when a check starts failing. It will
+// be issued again if the check passes and then fails again.
+package framehealth
@@ -0,0 +1,215 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package framehealth
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// the number of scanlines VSYNC should be held for.
+const vsyncScanlines = 3
+
+// the number of frames after a reset in which no checks are made. it is
+// normal for the signal to be unstable while a ROM initialises.
+const leadingFrames = 5
+
+// Warning describes a sync problem and where it was detected.
+type Warning struct {
+	Frame    int
+	Scanline int
+	HorizPos int
+	Message  string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("frame %d scanline %d horizpos %d: %s", w.Frame, w.Scanline, w.HorizPos, w.Message)
+}
+
+// the list of checks. used to index the failing array in the Checker type.
+const (
+	checkVSYNC = iota
+	checkVSYNCLength
+	checkVBLANK
+	checkScanlines
+	checkPALPhase
+	numChecks
+)
+
+// Checker is an implementation of the television.PixelRenderer interface. It
+// does not display anything. it examines the signal and produces warnings.
+type Checker struct {
+	tv   *television.Television
+	spec specification.Spec
+
+	// function to call with every new warning
+	onWarning func(Warning)
+
+	// frame number of the frame being examined
+	frameNum int
+
+	// frames examined since reset
+	frameCt int
+
+	// information about the current frame. the length of the frame and of
+	// the VSYNC are measured in color clocks rather than by scanline number
+	// because a frame (and VSYNC) will not usually begin at the start of a
+	// scanline
+	clockCt       int
+	lastScanline  int
+	lastHorizPos  int
+	vblank        bool
+	vsync         bool
+	vsyncScanline int
+	vsyncHorizPos int
+	vsyncClockCt  int
+
+	// whether a check failed in the previous frame
+	failing [numChecks]bool
+}
+
+// NewChecker is the preferred method of initialisation for the Checker type.
+// The Checker is added to the television as a PixelRenderer. The onWarning
+// function is called for every warning.
+func NewChecker(tv *television.Television, onWarning func(Warning)) *Checker {
+	chk := &Checker{
+		tv:        tv,
+		spec:      tv.GetSpec(),
+		onWarning: onWarning,
+	}
+	chk.Reset()
+	tv.AddPixelRenderer(chk)
+	return chk
+}
+
+// Resize implements television.PixelRenderer interface.
+func (chk *Checker) Resize(spec specification.Spec, _, _ int) error {
+	chk.spec = spec
+	return nil
+}
+
+// NewFrame implements television.PixelRenderer interface.
+func (chk *Checker) NewFrame(_ television.FrameInfo) error {
+	if chk.frameCt >= leadingFrames {
+		chk.checkFrame()
+	}
+
+	chk.frameNum++
+	chk.frameCt++
+	chk.clockCt = 0
+	chk.vblank = false
+	chk.vsync = false
+	chk.vsyncClockCt = 0
+
+	return nil
+}
+
+func (chk *Checker) checkFrame() {
+	scanlines := clocksToScanlines(chk.clockCt)
+
+	if !chk.vsync {
+		chk.check(checkVSYNC, false, chk.lastScanline, chk.lastHorizPos,
+			"frame ended without VSYNC")
+	} else {
+		chk.check(checkVSYNC, true, 0, 0, "")
+		n := clocksToScanlines(chk.vsyncClockCt)
+		chk.check(checkVSYNCLength, n == vsyncScanlines, chk.vsyncScanline, chk.vsyncHorizPos,
+			fmt.Sprintf("VSYNC held for %d scanlines (should be %d)", n, vsyncScanlines))
+	}
+
+	chk.check(checkVBLANK, chk.vblank, 0, 0,
+		"VBLANK was never enabled")
+
+	chk.check(checkScanlines, scanlines == chk.spec.ScanlinesTotal, chk.lastScanline, chk.lastHorizPos,
+		fmt.Sprintf("%d scanlines in frame (should be %d for %s)", scanlines, chk.spec.ScanlinesTotal, chk.spec.ID))
+
+	if chk.spec.ID == specification.SpecPAL.ID || chk.spec.ID == specification.SpecPAL60.ID {
+		chk.check(checkPALPhase, scanlines%2 == 0, chk.lastScanline, chk.lastHorizPos,
+			fmt.Sprintf("odd number of scanlines (%d) will cause colour loss on PAL televisions", scanlines))
+	}
+}
+
+// the number of whole scanlines, to the nearest scanline, in the number of
+// color clocks.
+func clocksToScanlines(clocks int) int {
+	return (clocks + specification.HorizClksScanline/2) / specification.HorizClksScanline
+}
+
+// check the result of the check. a warning is produced only if the check
+// has failed and did not fail in the previous frame.
+func (chk *Checker) check(c int, ok bool, scanline int, horizpos int, msg string) {
+	if !ok && !chk.failing[c] && chk.onWarning != nil {
+		chk.onWarning(Warning{
+			Frame:    chk.frameNum,
+			Scanline: scanline,
+			HorizPos: horizpos,
+			Message:  msg,
+		})
+	}
+	chk.failing[c] = !ok
+}
+
+// NewScanline implements television.PixelRenderer interface.
+func (chk *Checker) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements television.PixelRenderer interface.
+func (chk *Checker) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements television.PixelRenderer interface.
+func (chk *Checker) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	chk.clockCt++
+	chk.lastScanline = sig.Scanline()
+	chk.lastHorizPos = sig.HorizPos() - specification.HorizClksHBlank
+
+	if sig.VBlank() {
+		chk.vblank = true
+	}
+
+	if sig.VSync() {
+		if !chk.vsync {
+			chk.vsync = true
+			chk.vsyncScanline = sig.Scanline()
+			chk.vsyncHorizPos = sig.HorizPos() - specification.HorizClksHBlank
+		}
+		chk.vsyncClockCt++
+	}
+
+	return nil
+}
+
+// Reset implements television.PixelRenderer interface.
+func (chk *Checker) Reset() {
+	chk.frameNum = chk.tv.GetState(signal.ReqFramenum)
+	chk.frameCt = 0
+	chk.clockCt = 0
+	chk.vblank = false
+	chk.vsync = false
+	chk.vsyncClockCt = 0
+	for i := range chk.failing {
+		chk.failing[i] = false
+	}
+}
+
+// EndRendering implements television.PixelRenderer interface.
+func (chk *Checker) EndRendering() error {
+	return nil
+}
@@ -0,0 +1,105 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package framehealth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/framehealth"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// send a frame of the specified number of scanlines to the checker. the last
+// vsync scanlines of the frame have the VSYNC signal set. the first 37
+// scanlines of the frame have the VBLANK signal set if vblank is true.
+func frame(t *testing.T, chk *framehealth.Checker, scanlines int, vsync int, vblank bool) {
+	t.Helper()
+	for y := 0; y < scanlines; y++ {
+		for x := 0; x < specification.HorizClksScanline; x++ {
+			var sig signal.SignalAttributes
+			sig.SetHorizPos(x)
+			sig.SetScanline(y)
+			sig.SetVSync(y >= scanlines-vsync)
+			sig.SetVBlank(vblank && y < 37)
+			if err := chk.SetPixel(sig, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := chk.NewFrame(television.FrameInfo{Stable: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChecker(t *testing.T) {
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings []string
+	chk := framehealth.NewChecker(tv, func(w framehealth.Warning) {
+		warnings = append(warnings, w.Message)
+	})
+
+	expect := func(s ...string) {
+		t.Helper()
+		if len(warnings) != len(s) {
+			t.Fatalf("expected %d warnings, got %d: %v", len(s), len(warnings), warnings)
+		}
+		for i := range s {
+			if !strings.HasPrefix(warnings[i], s[i]) {
+				t.Errorf("unexpected warning (%s) should be (%s)", warnings[i], s[i])
+			}
+		}
+		warnings = warnings[:0]
+	}
+
+	// the initial frames are never checked
+	for i := 0; i < 5; i++ {
+		frame(t, chk, 10, 0, false)
+	}
+	expect()
+
+	// a good frame produces no warnings
+	frame(t, chk, 262, 3, true)
+	expect()
+
+	// short VSYNC. the warning is only produced once
+	frame(t, chk, 262, 2, true)
+	frame(t, chk, 262, 2, true)
+	expect("VSYNC held for 2 scanlines")
+
+	// good frame followed by a return to the short VSYNC produces the
+	// warning again
+	frame(t, chk, 262, 3, true)
+	frame(t, chk, 262, 2, true)
+	expect("VSYNC held for 2 scanlines")
+
+	// missing VSYNC and VBLANK with the wrong number of scanlines
+	frame(t, chk, 263, 0, false)
+	expect("frame ended without VSYNC", "VBLANK was never enabled", "263 scanlines in frame")
+
+	// PAL frames with an odd number of scanlines
+	chk.Resize(specification.SpecPAL, 0, 0)
+	frame(t, chk, 312, 3, true)
+	expect()
+	frame(t, chk, 311, 3, true)
+	expect("311 scanlines in frame", "odd number of scanlines")
+}
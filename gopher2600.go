@@ -21,26 +21,40 @@ import (
 	"math/rand"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/jetsetilly/gopher2600/acceptance"
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/catalogue"
+	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/debugger"
 	"github.com/jetsetilly/gopher2600/debugger/terminal"
 	"github.com/jetsetilly/gopher2600/debugger/terminal/colorterm"
+	"github.com/jetsetilly/gopher2600/debugger/terminal/netterm"
 	"github.com/jetsetilly/gopher2600/debugger/terminal/plainterm"
 	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/doctor"
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/gui/sdlimgui"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/supercharger"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
 	"github.com/jetsetilly/gopher2600/hardware/television"
 	"github.com/jetsetilly/gopher2600/hiscore"
+	"github.com/jetsetilly/gopher2600/linter"
 	"github.com/jetsetilly/gopher2600/logger"
 	"github.com/jetsetilly/gopher2600/modalflag"
+	"github.com/jetsetilly/gopher2600/outputs"
+	"github.com/jetsetilly/gopher2600/outputs/ffmpeg"
 	"github.com/jetsetilly/gopher2600/paths"
 	"github.com/jetsetilly/gopher2600/performance"
 	"github.com/jetsetilly/gopher2600/playmode"
+	"github.com/jetsetilly/gopher2600/playstats"
 	"github.com/jetsetilly/gopher2600/recorder"
 	"github.com/jetsetilly/gopher2600/regression"
+	"github.com/jetsetilly/gopher2600/version"
 	"github.com/jetsetilly/gopher2600/wavwriter"
 )
 
@@ -209,7 +223,8 @@ func launch(sync *mainSync) {
 	md := &modalflag.Modes{Output: os.Stdout}
 	md.NewArgs(os.Args[1:])
 	md.NewMode()
-	md.AddSubModes("RUN", "PLAY", "DEBUG", "DISASM", "PERFORMANCE", "REGRESS", "HISCORE")
+	md.AddSubModes("RUN", "PLAY", "DEBUG", "DISASM", "LINT", "TAPE", "PERFORMANCE", "REGRESS", "HISCORE", "DOCTOR", "CATALOGUE", "ACCEPTANCE", "PLAYSTATS", "RENDER", "VERSION")
+	portable := md.AddBool("portable", false, "keep preferences and other files next to the executable. detected automatically if not specified")
 
 	p, err := md.Parse()
 	switch p {
@@ -224,6 +239,24 @@ func launch(sync *mainSync) {
 		return
 	}
 
+	// portable mode must be set before any resource paths are used. if the
+	// flag has not been specified then portable mode is detected
+	// automatically. see paths.SetPortable()
+	md.Visit(func(flg string) {
+		if flg == "portable" {
+			paths.SetPortable(*portable)
+		}
+	})
+
+	// load output plugins. outputs in the plugins will register themselves
+	// with the outputs package as they are loaded
+	if pth, err := paths.ResourcePath(pluginsPath, ""); err == nil {
+		err = outputs.LoadPlugins(pth)
+		if err != nil {
+			logger.Log("outputs", err.Error())
+		}
+	}
+
 	switch md.Mode() {
 	case "RUN":
 		fallthrough
@@ -237,6 +270,12 @@ func launch(sync *mainSync) {
 	case "DISASM":
 		err = disasm(md)
 
+	case "LINT":
+		err = lint(md)
+
+	case "TAPE":
+		err = tape(md)
+
 	case "PERFORMANCE":
 		err = perform(md, sync)
 
@@ -245,6 +284,24 @@ func launch(sync *mainSync) {
 
 	case "HISCORE":
 		err = hiscoreServer(md)
+
+	case "DOCTOR":
+		err = diagnose(md, sync)
+
+	case "CATALOGUE":
+		err = catalogueScan(md)
+
+	case "ACCEPTANCE":
+		err = acceptanceTests(md)
+
+	case "PLAYSTATS":
+		err = exportPlayStats(md)
+
+	case "RENDER":
+		err = render(md)
+
+	case "VERSION":
+		err = showVersion(md)
 	}
 
 	if err != nil {
@@ -260,16 +317,23 @@ func play(md *modalflag.Modes, sync *mainSync) error {
 	md.NewMode()
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60")
 	scaling := md.AddFloat64("scale", 0.0, "television scaling")
+	rotation := md.AddInt("rotate", 0, "rotate screen clockwise: 0, 90, 180, 270")
+	fit := md.AddString("fit", "", "fit screen to window: STRETCH, LETTERBOX, CROP")
+	border := md.AddString("border", "", "border color when letterboxing: #rrggbb")
 	crt := md.AddBool("crt", true, "apply CRT post-processing")
 	fpsCap := md.AddBool("fpscap", true, "cap fps to specification")
 	record := md.AddBool("record", false, "record user input to a file")
 	wav := md.AddString("wav", "", "record audio to wav file")
+	output := md.AddString("output", "", "additional television outputs (comma separated list)")
 	patchFile := md.AddString("patch", "", "patch file to apply (cartridge args only)")
 	hiscore := md.AddBool("hiscore", false, "contact hiscore server [EXPERIMENTAL]")
 	log := md.AddBool("log", false, "echo debugging log to stdout")
 	useSavekey := md.AddBool("savekey", false, "use savekey in player 1 port")
+	runAhead := md.AddInt("runahead", -1, "number of frames to run ahead (-1 to use preferences)")
+	autosave := md.AddString("autosave", "", "save state on exit and offer to resume: ON, OFF (empty to use preferences)")
+	md.AdditionalHelp(outputsHelp())
 
 	p, err := md.Parse()
 	if err != nil || p != modalflag.ParseContinue {
@@ -307,6 +371,12 @@ func play(md *modalflag.Modes, sync *mainSync) error {
 			tv.AddAudioMixer(aw)
 		}
 
+		// attach additional outputs
+		err = outputs.Attach(tv, *output)
+		if err != nil {
+			return err
+		}
+
 		// create gui
 		sync.creator <- func() (GuiCreator, error) {
 			return sdlimgui.NewSdlImgui(tv, true)
@@ -346,7 +416,37 @@ func play(md *modalflag.Modes, sync *mainSync) error {
 			}
 		}
 
-		err = playmode.Play(tv, scr, *record, cartload, *patchFile, *hiscore, *useSavekey)
+		// set rotation only if it has been specified on the command line.
+		// otherwise the rotation value from the preferences file will be used
+		var setRotation bool
+		md.Visit(func(flg string) {
+			if flg == "rotate" {
+				setRotation = true
+			}
+		})
+		if setRotation {
+			err = scr.SetFeature(gui.ReqSetRotation, *rotation)
+			if err != nil {
+				return err
+			}
+		}
+
+		// as above, display fit and border color will be taken from the
+		// preferences file if they have not been specified
+		if *fit != "" {
+			err = scr.SetFeature(gui.ReqSetDisplayFit, *fit)
+			if err != nil {
+				return err
+			}
+		}
+		if *border != "" {
+			err = scr.SetFeature(gui.ReqSetBorderColor, *border)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = playmode.Play(tv, scr, *record, cartload, *patchFile, *hiscore, *useSavekey, *runAhead, *autosave)
 		if err != nil {
 			return err
 		}
@@ -377,11 +477,14 @@ func debug(md *modalflag.Modes, sync *mainSync) error {
 	}
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60")
 	termType := md.AddString("term", "IMGUI", "terminal type to use in debug mode: IMGUI, COLOR, PLAIN")
+	listen := md.AddString("listen", "", "listen for terminal connections on network socket (eg. tcp::6502 or unix:/tmp/gopher2600.sock). tcp without a host listens on loopback only")
 	initScript := md.AddString("initscript", defInitScript, "script to run on debugger start")
 	profile := md.AddBool("profile", false, "run debugger through cpu profiler")
 	useSavekey := md.AddBool("savekey", false, "use savekey in player 1 port")
+	output := md.AddString("output", "", "additional television outputs (comma separated list)")
+	md.AdditionalHelp(outputsHelp())
 
 	p, err := md.Parse()
 	if err != nil || p != modalflag.ParseContinue {
@@ -394,6 +497,12 @@ func debug(md *modalflag.Modes, sync *mainSync) error {
 	}
 	defer tv.End()
 
+	// attach additional outputs
+	err = outputs.Attach(tv, *output)
+	if err != nil {
+		return err
+	}
+
 	var term terminal.Terminal
 	var scr gui.GUI
 
@@ -420,6 +529,14 @@ func debug(md *modalflag.Modes, sync *mainSync) error {
 		scr = gui.Stub{}
 	}
 
+	// a network terminal takes priority over any other terminal
+	if *listen != "" {
+		term, err = netterm.NewNetTerminal(*listen)
+		if err != nil {
+			return err
+		}
+	}
+
 	// if the GUI does not supply a terminal then use a color or plain terminal
 	// as a fallback
 	if term == nil {
@@ -494,12 +611,27 @@ func debug(md *modalflag.Modes, sync *mainSync) error {
 	return nil
 }
 
+// the sub-directory of the resource path in which to look for output plugins.
+const pluginsPath = "plugins"
+
+// outputsHelp returns a list of the available outputs suitable for use with
+// the AdditionalHelp() function of the modalflag package.
+func outputsHelp() string {
+	s := strings.Builder{}
+	s.WriteString("available outputs:")
+	for _, o := range outputs.List() {
+		s.WriteString(fmt.Sprintf("\n  %s\t%s", o.Name, o.Description))
+	}
+	return s.String()
+}
+
 func disasm(md *modalflag.Modes) error {
 	md.NewMode()
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
 	bytecode := md.AddBool("bytecode", false, "include bytecode in disassembly")
 	bank := md.AddInt("bank", -1, "show disassembly for a specific bank")
+	graphics := md.AddBool("graphics", false, "include graphics tables drawn as text")
 
 	p, err := md.Parse()
 	if err != nil || p != modalflag.ParseContinue {
@@ -510,6 +642,11 @@ func disasm(md *modalflag.Modes) error {
 	case 0:
 		return fmt.Errorf("2600 cartridge required for %s mode", md)
 	case 1:
+		// disassemble every ROM in a directory and output summary statistics
+		if info, err := os.Stat(md.GetArg(0)); err == nil && info.IsDir() {
+			return disassembly.BatchStats(md.Output, md.GetArg(0), *mapping)
+		}
+
 		attr := disassembly.WriteAttr{
 			ByteCode: *bytecode,
 		}
@@ -536,6 +673,102 @@ func disasm(md *modalflag.Modes) error {
 		if err != nil {
 			return err
 		}
+
+		if *graphics {
+			for _, tbl := range dsm.GraphicsTables() {
+				if *bank < 0 || tbl.Bank == *bank {
+					tbl.Write(md.Output)
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	return nil
+}
+
+func lint(md *modalflag.Modes) error {
+	md.NewMode()
+
+	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60")
+	frames := md.AddInt("frames", 600, "number of frames to run for")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	switch len(md.RemainingArgs()) {
+	case 0:
+		return fmt.Errorf("2600 cartridge required for %s mode", md)
+	case 1:
+		cartload := cartridgeloader.NewLoader(md.GetArg(0), *mapping)
+
+		num, err := linter.Run(cartload, *spec, *frames, md.Output)
+		if err != nil {
+			return err
+		}
+
+		// a non-zero exit code when problems have been found is useful for
+		// continuous integration scripts
+		if num > 0 {
+			return fmt.Errorf("%d problems found", num)
+		}
+	default:
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	return nil
+}
+
+// tape converts a Supercharger binary file into a WAV file that can be played
+// into a real Supercharger.
+func tape(md *modalflag.Modes) error {
+	md.NewMode()
+
+	output := md.AddString("o", "", "output filename (default is the input filename with .wav extension)")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	switch len(md.RemainingArgs()) {
+	case 0:
+		return fmt.Errorf("supercharger binary required for %s mode", md)
+	case 1:
+		cartload := cartridgeloader.NewLoader(md.GetArg(0), "AR")
+		err := cartload.Load()
+		if err != nil {
+			return err
+		}
+
+		filename := *output
+		if filename == "" {
+			filename = strings.TrimSuffix(md.GetArg(0), filepath.Ext(md.GetArg(0))) + ".wav"
+		}
+
+		// do not overwrite existing files
+		f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+
+		err = supercharger.ExportWAV(f, cartload.Data)
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(filename)
+			return err
+		}
+
+		err = f.Close()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(md.Output, "tape written to %s\n", filename)
 	default:
 		return fmt.Errorf("too many arguments for %s mode", md)
 	}
@@ -547,12 +780,13 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 	md.NewMode()
 
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60")
 	display := md.AddBool("display", false, "display TV output")
 	scaling := md.AddFloat64("scale", 0.0, "display scaling (only valid if -display=true")
 	fpsCap := md.AddBool("fpscap", true, "cap FPS to specification (only valid if -display=true)")
 	duration := md.AddString("duration", "5s", "run duration (note: there is a 2s overhead)")
 	profile := md.AddBool("profile", false, "produce cpu and memory profiling reports")
+	noRender := md.AddBool("norender", false, "skip all rendering for maximum throughput (not valid if -display=true)")
 
 	p, err := md.Parse()
 	if err != nil || p != modalflag.ParseContinue {
@@ -563,6 +797,10 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 	case 0:
 		return fmt.Errorf("2600 cartridge required for %s mode", md)
 	case 1:
+		if *noRender && *display {
+			return fmt.Errorf("-norender and -display can not be used together in %s mode", md)
+		}
+
 		cartload := cartridgeloader.NewLoader(md.GetArg(0), *mapping)
 
 		tv, err := television.NewTelevision(*spec)
@@ -573,6 +811,13 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 
 		tv.SetFPSCap(*fpsCap)
 
+		if *noRender {
+			err = tv.SetNoRender(true)
+			if err != nil {
+				return err
+			}
+		}
+
 		if *display {
 			// create gui
 			sync.creator <- func() (GuiCreator, error) {
@@ -612,6 +857,427 @@ func perform(md *modalflag.Modes, sync *mainSync) error {
 	return nil
 }
 
+func diagnose(md *modalflag.Modes, sync *mainSync) error {
+	md.NewMode()
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	if len(md.RemainingArgs()) > 0 {
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	md.Output.Write([]byte("gopher2600 doctor report (paste this into bug reports)\n"))
+
+	doctor.Check(md.Output)
+
+	// SDL must be initialised on the main thread so we use the gui creator
+	// channel to run the SDL diagnostics. no gui is actually created
+	sync.creator <- func() (GuiCreator, error) {
+		err := sdlimgui.Diagnose(md.Output)
+		if err != nil {
+			doctor.Problem(md.Output, "SDL", err.Error())
+		}
+		return nil, nil
+	}
+
+	// wait for creator result
+	select {
+	case <-sync.creation:
+	case err := <-sync.creationError:
+		return err
+	}
+
+	return nil
+}
+
+// catalogueScan scans a ROM collection and saves the result. The catalogue is
+// used by the ROM selector in the GUI.
+func catalogueScan(md *modalflag.Modes) error {
+	md.NewMode()
+
+	recurse := md.AddBool("recurse", true, "scan subdirectories")
+	output := md.AddString("o", "", "output filename (default is the catalogue file in the configuration directory)")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	switch len(md.RemainingArgs()) {
+	case 0:
+		return fmt.Errorf("ROM directory required for %s mode", md)
+	case 1:
+		cat, err := catalogue.Scan(md.GetArg(0), *recurse)
+		if err != nil {
+			return err
+		}
+
+		filename := *output
+		if filename == "" {
+			filename, err = catalogue.DefaultPath()
+			if err != nil {
+				return err
+			}
+		}
+
+		err = cat.Save(filename)
+		if err != nil {
+			return err
+		}
+
+		groups := cat.DuplicateGroups()
+		problems := cat.Problems()
+
+		fmt.Fprintf(md.Output, "%d ROMs catalogued\n", len(cat.Entries))
+
+		if len(groups) > 0 {
+			fmt.Fprintf(md.Output, "\n%d duplicate groups\n", len(groups))
+			for _, g := range groups {
+				for i, f := range g {
+					if i == 0 {
+						fmt.Fprintf(md.Output, "  %s\n", f)
+					} else {
+						fmt.Fprintf(md.Output, "    = %s\n", f)
+					}
+				}
+			}
+		}
+
+		if len(problems) > 0 {
+			fmt.Fprintf(md.Output, "\n%d ROMs with problems\n", len(problems))
+			for _, e := range problems {
+				fmt.Fprintf(md.Output, "  %s\n", e.Filename)
+				for _, p := range e.Problems {
+					fmt.Fprintf(md.Output, "    %s\n", p)
+				}
+			}
+		}
+
+		fmt.Fprintf(md.Output, "\ncatalogue written to %s\n", filename)
+	default:
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	return nil
+}
+
+func acceptanceTests(md *modalflag.Modes) error {
+	md.NewMode()
+	md.AddSubModes("RUN", "LIST", "ADD")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	defaultCatalogue, err := acceptance.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	switch md.Mode() {
+	case "RUN":
+		md.NewMode()
+
+		catalogueFile := md.AddString("catalogue", defaultCatalogue, "acceptance catalogue file")
+
+		p, err := md.Parse()
+		if err != nil || p != modalflag.ParseContinue {
+			return err
+		}
+
+		switch len(md.RemainingArgs()) {
+		case 0:
+			return fmt.Errorf("test ROM directory required for %s mode", md)
+		case 1:
+			cat, err := acceptance.Load(*catalogueFile)
+			if err != nil {
+				return err
+			}
+
+			sb, err := cat.Run(md.Output, md.GetArg(0))
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(md.Output)
+			sb.Report(md.Output)
+		default:
+			return fmt.Errorf("too many arguments for %s mode", md)
+		}
+
+	case "LIST":
+		md.NewMode()
+
+		catalogueFile := md.AddString("catalogue", defaultCatalogue, "acceptance catalogue file")
+
+		p, err := md.Parse()
+		if err != nil || p != modalflag.ParseContinue {
+			return err
+		}
+
+		switch len(md.RemainingArgs()) {
+		case 0:
+			cat, err := acceptance.Load(*catalogueFile)
+			if err != nil {
+				return err
+			}
+
+			for _, t := range cat.Tests {
+				fmt.Fprintf(md.Output, "[%s] %s [%s] frames=%d\n", t.Category, t.Name, t.TVtype, t.Frames)
+			}
+			fmt.Fprintf(md.Output, "Total: %d\n", len(cat.Tests))
+		default:
+			return fmt.Errorf("no additional arguments required for %s mode", md)
+		}
+
+	case "ADD":
+		md.NewMode()
+
+		catalogueFile := md.AddString("catalogue", defaultCatalogue, "acceptance catalogue file")
+		name := md.AddString("name", "", "name of the test (default is the name of the test ROM)")
+		category := md.AddString("category", "", "category of the test (eg. CPU, TIA, RIOT)")
+		notes := md.AddString("notes", "", "additional annotation for the catalogue")
+		mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping")
+		spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60")
+		numframes := md.AddInt("frames", 10, "number of frames to run")
+
+		md.AdditionalHelp(
+			`The screen produced by the emulation after the number of frames is recorded as the
+expected result of the test. The screen should be compared with the output of real
+hardware before the test is added. A test with the same name as an existing test
+replaces the existing test.`)
+
+		p, err := md.Parse()
+		if err != nil || p != modalflag.ParseContinue {
+			return err
+		}
+
+		switch len(md.RemainingArgs()) {
+		case 0:
+			return fmt.Errorf("test ROM required for %s mode", md)
+		case 1:
+			// a missing catalogue file is not an error. a new catalogue will
+			// be created
+			cat, err := acceptance.Load(*catalogueFile)
+			if err != nil {
+				if _, serr := os.Stat(*catalogueFile); !os.IsNotExist(serr) {
+					return err
+				}
+				cat = &acceptance.Catalogue{}
+			}
+
+			cartload := cartridgeloader.NewLoader(md.GetArg(0), *mapping)
+			if *name == "" {
+				*name = cartload.ShortName()
+			}
+
+			t, err := acceptance.Record(*name, *category, cartload, *spec, *numframes)
+			if err != nil {
+				return err
+			}
+			t.Notes = *notes
+			cat.Add(t)
+
+			err = cat.Save(*catalogueFile)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(md.Output, "test added to %s\n", *catalogueFile)
+		default:
+			return fmt.Errorf("acceptance tests can only be added one at a time")
+		}
+	}
+
+	return nil
+}
+
+// showVersion prints the version of the emulator and the versions of the file
+// formats it can read.
+func showVersion(md *modalflag.Modes) error {
+	md.NewMode()
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	if len(md.RemainingArgs()) > 0 {
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	fmt.Fprintf(md.Output, "gopher2600 %s\n", version.Version)
+	fmt.Fprintf(md.Output, "file formats:\n")
+	for _, f := range version.Capabilities() {
+		fmt.Fprintf(md.Output, "  %-10s %s\n", f.Name, f)
+	}
+
+	return nil
+}
+
+// exportPlayStats writes the play statistics for every ROM as CSV.
+func exportPlayStats(md *modalflag.Modes) error {
+	md.NewMode()
+
+	output := md.AddString("o", "", "output filename (default is stdout)")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	if len(md.RemainingArgs()) > 0 {
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	if *output == "" {
+		return playstats.Export(md.Output)
+	}
+
+	// do not overwrite existing files
+	f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = playstats.Export(f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(*output)
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(md.Output, "play statistics written to %s\n", *output)
+
+	return nil
+}
+
+func render(md *modalflag.Modes) error {
+	md.NewMode()
+
+	scale := md.AddInt("scale", ffmpeg.DefaultOptions.Scale, "integer scaling of the television image")
+	phosphor := md.AddBool("phosphor", false, "simulate phosphor persistence")
+	composite := md.AddBool("composite", false, "simulate NTSC composite video artifacts")
+	input := md.AddBool("input", false, "draw joystick and panel input over the television image")
+
+	p, err := md.Parse()
+	if err != nil || p != modalflag.ParseContinue {
+		return err
+	}
+
+	switch len(md.RemainingArgs()) {
+	case 0:
+		return fmt.Errorf("playback script and output filename required for %s mode", md)
+	case 1:
+		return fmt.Errorf("output filename required for %s mode", md)
+	case 2:
+	default:
+		return fmt.Errorf("too many arguments for %s mode", md)
+	}
+
+	if *scale < 1 {
+		return fmt.Errorf("scale must be a positive number")
+	}
+
+	script := md.GetArg(0)
+	filename := md.GetArg(1)
+
+	plb, err := recorder.NewPlayback(script)
+	if err != nil {
+		return err
+	}
+
+	tv, err := television.NewTelevision(plb.TVSpec)
+	if err != nil {
+		return err
+	}
+
+	enc, err := ffmpeg.NewEncoder(filename, ffmpeg.Options{
+		Scale:     *scale,
+		Phosphor:  *phosphor,
+		Composite: *composite,
+	})
+	if err != nil {
+		return err
+	}
+	tv.AddPixelRenderer(enc)
+	tv.AddAudioMixer(enc)
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		return err
+	}
+
+	if *input {
+		enc.AttachInput(vcs.Mem)
+	}
+
+	err = plb.AttachToVCS(vcs)
+	if err != nil {
+		return err
+	}
+
+	// not using setup.AttachCartridge. setup changes will have been copied
+	// into the playback script (see PlaybackRegression)
+	err = vcs.AttachCartridge(plb.CartLoad)
+	if err != nil {
+		return err
+	}
+
+	// prepare ticker for progress meter
+	tck := time.NewTicker(time.Second)
+	defer tck.Stop()
+
+	msg := fmt.Sprintf("rendering %s", filepath.Base(script))
+	fmt.Fprint(md.Output, msg)
+
+	// the emulation runs as fast as possible. there is no need to keep to
+	// the frame rate of the television because nothing is being displayed
+	err = vcs.Run(func() (bool, error) {
+		hasEnded, err := plb.EndFrame()
+		if err != nil {
+			return false, err
+		}
+		if hasEnded {
+			return false, nil
+		}
+
+		// display progress meter every 1 second
+		select {
+		case <-tck.C:
+			fmt.Fprintf(md.Output, "\r%s [%s]", msg, plb)
+		default:
+		}
+		return true, nil
+	})
+
+	fmt.Fprintln(md.Output)
+
+	// a playback that ends with the VCS being powered off is normal
+	if err != nil && !curated.Has(err, ports.PowerOff) {
+		_ = enc.EndRendering()
+		return err
+	}
+
+	err = enc.EndRendering()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(md.Output, "video written to %s\n", filename)
+
+	return nil
+}
+
 type yesReader struct{}
 
 func (*yesReader) Read(p []byte) (n int, err error) {
@@ -712,7 +1378,7 @@ func regressAdd(md *modalflag.Modes) error {
 	mode := md.AddString("mode", "", "type of regression entry")
 	notes := md.AddString("notes", "", "additional annotation for the database")
 	mapping := md.AddString("mapping", "AUTO", "force use of cartridge mapping [non-playback]")
-	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL [non-playback]")
+	spec := md.AddString("tv", "AUTO", "television specification: NTSC, PAL, PAL60 [non-playback]")
 	numframes := md.AddInt("frames", 10, "number of frames to run [non-playback]")
 	state := md.AddString("state", "", "record emulator state at every CPU step [non-playback]")
 	log := md.AddBool("log", false, "echo debugging log to stdout")
@@ -722,7 +1388,7 @@ func regressAdd(md *modalflag.Modes) error {
 recorded playback file. For playback files, the flags marked [non-playback] do not make
 sense and will be ignored.
 
-Available modes are VIDEO, PLAYBACK and LOG. If not mode is explicitly given then
+Available modes are VIDEO, PLAYBACK, LOG and AUDIO. If not mode is explicitly given then
 VIDEO will be used for ROM files and PLAYBACK will be used for playback recordings.
 
 Value for the -state flag can be one of TV, PORTS, TIMER, CPU and can be used
@@ -795,6 +1461,15 @@ with the LOG mode. Note that asking for log output will suppress regression prog
 				NumFrames: *numframes,
 				Notes:     *notes,
 			}
+		case "AUDIO":
+			cartload := cartridgeloader.NewLoader(md.GetArg(0), *mapping)
+
+			reg = &regression.AudioRegression{
+				CartLoad:  cartload,
+				TVtype:    strings.ToUpper(*spec),
+				NumFrames: *numframes,
+				Notes:     *notes,
+			}
 		}
 
 		err := regression.RegressAdd(md.Output, reg)
@@ -36,6 +36,37 @@ type Preferences struct {
 	MaskScanlineScaling prefs.Int
 
 	Vignette prefs.Bool
+
+	// color deficiency filter. see the ColorDeficiency type for valid values
+	ColorDeficiency prefs.Int
+
+	// correct (daltonise) for the selected color deficiency rather than
+	// simulate it
+	ColorCorrection prefs.Bool
+}
+
+// ColorDeficiency identifies the type of color blindness to simulate or to
+// correct for. Values are used directly by the fragment shader.
+type ColorDeficiency int
+
+// List of valid ColorDeficiency values.
+const (
+	ColorDeficiencyNone ColorDeficiency = iota
+	ColorDeficiencyProtanopia
+	ColorDeficiencyDeuteranopia
+	ColorDeficiencyTritanopia
+)
+
+func (c ColorDeficiency) String() string {
+	switch c {
+	case ColorDeficiencyProtanopia:
+		return "Protanopia"
+	case ColorDeficiencyDeuteranopia:
+		return "Deuteranopia"
+	case ColorDeficiencyTritanopia:
+		return "Tritanopia"
+	}
+	return "None"
 }
 
 func (p *Preferences) String() string {
@@ -54,6 +85,9 @@ const (
 	maskScanlineScaling = 1
 
 	vignette = true
+
+	colorDeficiency = int(ColorDeficiencyNone)
+	colorCorrection = false
 )
 
 // NewPreferences is the preferred method of initialisation for the Preferences type.
@@ -113,6 +147,15 @@ func NewPreferences() (*Preferences, error) {
 		return nil, err
 	}
 
+	err = p.dsk.Add("crt.colorDeficiency", &p.ColorDeficiency)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("crt.colorCorrection", &p.ColorCorrection)
+	if err != nil {
+		return nil, err
+	}
+
 	err = p.dsk.Load(true)
 	if err != nil {
 		return nil, err
@@ -133,6 +176,8 @@ func (p *Preferences) SetDefaults() {
 	p.MaskScanlineScaling.Set(maskScanlineScaling)
 	p.NoiseLevel.Set(noiseLevel)
 	p.Vignette.Set(vignette)
+	p.ColorDeficiency.Set(colorDeficiency)
+	p.ColorCorrection.Set(colorCorrection)
 }
 
 // Load disassembly preferences and apply to the current disassembly.
@@ -45,6 +45,8 @@ uniform float ScanlinesBrightness;
 uniform float NoiseLevel;
 uniform int Vignette;
 uniform int MaskScanlineScaling;
+uniform int ColorDeficiency; // 0 = none; 1 = protanopia; 2 = deuteranopia; 3 = tritanopia
+uniform int ColorCorrection; // 0 = simulate deficiency; 1 = correct for deficiency
 
 uniform sampler2D Texture;
 in vec2 Frag_UV;
@@ -70,6 +72,51 @@ float gold_noise(in vec2 xy){
 	return fract(tan(distance(xy*PHI, xy)*RandSeed)*xy.x);
 }
 
+// color deficiency simulation and correction (daltonisation). the method and
+// the matrix values are taken from the paper "Digital Video Colourmaps for
+// Checking the Legibility of Displays by Dichromats" (Vienot, Brettel,
+// Mollon) with the correction step as described by Fidaner, Lin and Ozguven.
+vec3 colorFilter(vec3 rgb)
+{
+	if (ColorDeficiency == 0) {
+		return rgb;
+	}
+
+	// RGB to LMS colour space
+	float L = (17.8824 * rgb.r) + (43.5161 * rgb.g) + (4.11935 * rgb.b);
+	float M = (3.45565 * rgb.r) + (27.1554 * rgb.g) + (3.86714 * rgb.b);
+	float S = (0.0299566 * rgb.r) + (0.184309 * rgb.g) + (1.46709 * rgb.b);
+
+	// simulate deficiency
+	if (ColorDeficiency == 1) {
+		L = (2.02344 * M) - (2.52581 * S);
+	} else if (ColorDeficiency == 2) {
+		M = (0.494207 * L) + (1.24827 * S);
+	} else if (ColorDeficiency == 3) {
+		S = (-0.395913 * L) + (0.801109 * M);
+	}
+
+	// LMS back to RGB colour space
+	vec3 sim;
+	sim.r = (0.0809444479 * L) + (-0.130504409 * M) + (0.116721066 * S);
+	sim.g = (-0.0102485335 * L) + (0.0540193266 * M) + (-0.113614708 * S);
+	sim.b = (-0.000365296938 * L) + (-0.00412161469 * M) + (0.693511405 * S);
+
+	if (ColorCorrection == 0) {
+		return clamp(sim, 0.0, 1.0);
+	}
+
+	// shift the information lost by the deficiency towards the part of the
+	// spectrum that can be seen
+	vec3 err = rgb - sim;
+	vec3 shift;
+	shift.r = 0.0;
+	shift.g = (0.7 * err.r) + err.g;
+	shift.b = (0.7 * err.r) + err.b;
+
+	return clamp(rgb + shift, 0.0, 1.0);
+}
+
 void main()
 {
 	// imgui texture
@@ -236,6 +283,7 @@ void main()
 
 	// set basic color
 	Out_Color = Frag_Color * texture(Texture, Frag_UV.st);
+	Out_Color.rgb = colorFilter(Out_Color.rgb);
 
 	// if pixel-perfect	rendering is selected then there's nothing much more to do
 	if (CRT == 0 && ImageType != 4) {
@@ -245,7 +293,6 @@ void main()
 	// only apply CRT effects on the "cropped" area of the screen. we can think
 	// of the cropped area as the "play" area
 	if (Cropped < 0 && (coords.x < hblank || coords.y < topScanline || coords.y > botScanline)) {
-		Out_Color = Frag_Color * texture(Texture, Frag_UV.st);
 		return;
 	}
 
@@ -75,3 +75,28 @@ type EventDbgMouseButton struct {
 	HorizPos int
 	Scanline int
 }
+
+// EventPause is sent when the playmode pause menu is opened or closed. The
+// emulation should not advance while paused.
+type EventPause struct {
+	Pause bool
+}
+
+// PlaymodeAction identifies an action requested from the playmode pause menu.
+type PlaymodeAction int
+
+// List of valid playmode actions.
+const (
+	ActionResetConsole PlaymodeAction = iota
+	ActionSwapController
+	ActionSwapPorts
+	ActionSaveState
+	ActionLoadState
+	ActionScreenshot
+	ActionResumeSuspend
+)
+
+// EventPlaymodeAction is the data that accompanies EventPlaymodeAction events.
+type EventPlaymodeAction struct {
+	Action PlaymodeAction
+}
@@ -65,6 +65,23 @@ const (
 	ReqIncScale        FeatureReq = "ReqIncScale"        // none
 	ReqDecScale        FeatureReq = "ReqDecScale"        // none
 
+	// the input display shows the state of the player 0 joystick and the VCS
+	// panel over the playmode screen. useful when recording videos.
+	ReqSetInputDisplay    FeatureReq = "ReqSetInputDisplay"    // bool
+	ReqToggleInputDisplay FeatureReq = "ReqToggleInputDisplay" // none
+
+	// rotation of the playmode screen. value is in degrees and must be one
+	// of 0, 90, 180 or 270. rotation is clockwise.
+	ReqSetRotation FeatureReq = "ReqSetRotation" // int
+
+	// how the playmode screen is fitted to the window when the aspect ratio
+	// of the window does not match the aspect ratio of the screen. one of
+	// STRETCH, LETTERBOX or CROP. the border color is used to fill the unused
+	// area of the window when the fit is LETTERBOX and should be in the
+	// #rrggbb form.
+	ReqSetDisplayFit  FeatureReq = "ReqSetDisplayFit"  // string
+	ReqSetBorderColor FeatureReq = "ReqSetBorderColor" // string
+
 	// the add VCS request is used to associate the gui with an emulated VCS.
 	// a debugger does not need to send this request if it already sends a
 	// ReqAddDebugger request (which it should).
@@ -92,8 +109,24 @@ const (
 	// triggered when cartridge is being change.
 	ReqChangingCartridge FeatureReq = "ReqChangingCartridge" // bool
 
+	// the list of open debugging windows, identified by window title. GUIs
+	// should ignore any titles that they do not recognise.
+	ReqWindowLayout FeatureReq = "ReqWindowLayout" // []string
+
 	// special request for PlusROM cartridges.
 	ReqPlusROMFirstInstallation FeatureReq = "ReqPlusROMFirstInstallation" // PlusROMFirstInstallation
+
+	// an event has been published on the emulation's event bus. GUIs can use
+	// this to provide feedback to the user, for example by flashing the screen.
+	ReqEmulationEvent FeatureReq = "ReqEmulationEvent" // eventbus.Notification
+
+	// save the current playmode screen image to the named file in the PNG
+	// format.
+	ReqScreenshot FeatureReq = "ReqScreenshot" // string
+
+	// a short message to be shown briefly over the playmode screen. used to
+	// confirm actions that have no other visible effect.
+	ReqPlaymodeNotification FeatureReq = "ReqPlaymodeNotification" // string
 )
 
 // PlusROMFirstInstallation is used to pass information to the GUI as part of
@@ -17,13 +17,20 @@ package sdlaudio
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
 	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/prefs"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
 
+// audio is output in stereo. the emulation sends a left and right value for
+// each sample. all lengths below are measured in samples; buffers and queue
+// sizes measured in bytes should be multiplied by numChannels.
+const numChannels = 2
+
 // the buffer length is important to get right. unfortunately, there's no
 // special way (that I know of) that can tells us what the ideal value is
 //
@@ -42,6 +49,15 @@ const critQueueLength = 64
 // if queued audio ever exceeds this value then clip the audio.
 const maxQueueLength = 8192
 
+// the amount of audio history kept for scrubbing. this is also the maximum
+// length of audio that will be played after a step.
+const scrubHistoryLength = audio.SampleFreq
+
+// scrubbed audio shorter than this will not be queued. for the same reason we
+// don't queue short slices in SetAudio(), and because very short sounds are
+// just clicks.
+const minScrubLength = 64
+
 // Audio outputs sound using SDL.
 type Audio struct {
 	id   sdl.AudioDeviceID
@@ -49,18 +65,54 @@ type Audio struct {
 
 	buffer   []uint8
 	bufferCt int
+
+	// audio history is a circular buffer of the most recent audio. it is
+	// always being written to.
+	history    []uint8
+	historyIdx int
+
+	// when paused, audio is not queued as it is generated. instead, it is
+	// counted and queued in one go when the emulation halts again
+	paused  bool
+	scrubCt int
+	scrub   []uint8
+
+	// ScrubWindow is the minimum length of audio (in milliseconds) played
+	// after the emulation has been stepped. a value of zero means that only
+	// the audio generated by the step is played.
+	ScrubWindow prefs.Int
+
+	// Volume is the master volume as a percentage between MinVolume and
+	// MaxVolume. it is independent of the volume of the host system.
+	Volume prefs.Int
+
+	// Mute silences the audio without changing the Volume value.
+	Mute prefs.Bool
+
+	// the gain applied to every sample, as a percentage. derived from Volume
+	// and Mute. the preferences are changed by the GUI goroutine so the value
+	// is accessed atomically
+	gain int32
 }
 
+// the range of values for the Volume preference.
+const (
+	MinVolume = 0
+	MaxVolume = 100
+)
+
 // NewAudio is the preferred method of initialisatoin for the Audio Type.
 func NewAudio() (*Audio, error) {
 	aud := &Audio{
-		buffer: make([]uint8, bufferLength),
+		buffer:  make([]uint8, bufferLength*numChannels),
+		history: make([]uint8, scrubHistoryLength*numChannels),
+		scrub:   make([]uint8, 0, scrubHistoryLength*numChannels),
 	}
 
 	spec := &sdl.AudioSpec{
 		Freq:     audio.SampleFreq,
 		Format:   sdl.AUDIO_U8,
-		Channels: 1,
+		Channels: numChannels,
 		Samples:  uint16(bufferLength),
 	}
 
@@ -74,6 +126,19 @@ func NewAudio() (*Audio, error) {
 
 	aud.spec = actualSpec
 
+	aud.Volume.RegisterCallback(func(_ prefs.Value) error {
+		aud.updateGain()
+		return nil
+	})
+	aud.Mute.RegisterCallback(func(_ prefs.Value) error {
+		aud.updateGain()
+		return nil
+	})
+	err = aud.Volume.Set(MaxVolume)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Log("sdl audio", fmt.Sprintf("frequency: %d samples/sec", aud.spec.Freq))
 	logger.Log("sdl audio", fmt.Sprintf("format: %d", aud.spec.Format))
 	logger.Log("sdl audio", fmt.Sprintf("channels: %d", aud.spec.Channels))
@@ -83,16 +148,78 @@ func NewAudio() (*Audio, error) {
 	for i := range aud.buffer {
 		aud.buffer[i] = aud.spec.Silence
 	}
+	for i := range aud.history {
+		aud.history[i] = aud.spec.Silence
+	}
 
 	sdl.PauseAudioDevice(aud.id, false)
 
 	return aud, nil
 }
 
+// updateGain derives the gain from the Volume and Mute preferences.
+func (aud *Audio) updateGain() {
+	g := aud.Volume.Get().(int)
+	if g < MinVolume {
+		g = MinVolume
+	} else if g > MaxVolume {
+		g = MaxVolume
+	}
+	if aud.Mute.Get().(bool) {
+		g = 0
+	}
+	atomic.StoreInt32(&aud.gain, int32(g))
+}
+
+// AdjustVolume changes the Volume by the specified amount, keeping it inside
+// the range MinVolume to MaxVolume. Returns the new volume.
+func (aud *Audio) AdjustVolume(delta int) (int, error) {
+	v := aud.Volume.Get().(int) + delta
+	if v < MinVolume {
+		v = MinVolume
+	} else if v > MaxVolume {
+		v = MaxVolume
+	}
+	return v, aud.Volume.Set(v)
+}
+
+// ToggleMute flips the Mute preference. Returns the new value.
+func (aud *Audio) ToggleMute() (bool, error) {
+	m := !aud.Mute.Get().(bool)
+	return m, aud.Mute.Set(m)
+}
+
 // SetAudio implements the television.AudioMixer interface.
 func (aud *Audio) SetAudio(audioData uint8) error {
-	aud.buffer[aud.bufferCt] = audioData + aud.spec.Silence
-	aud.bufferCt++
+	return aud.SetStereo(audioData, audioData)
+}
+
+// SetStereo implements the television.StereoMixer interface.
+func (aud *Audio) SetStereo(left uint8, right uint8) error {
+	if g := atomic.LoadInt32(&aud.gain); g != MaxVolume {
+		left = uint8(int32(left) * g / MaxVolume)
+		right = uint8(int32(right) * g / MaxVolume)
+	}
+
+	left += aud.spec.Silence
+	right += aud.spec.Silence
+
+	aud.history[aud.historyIdx] = left
+	aud.history[aud.historyIdx+1] = right
+	aud.historyIdx += numChannels
+	if aud.historyIdx >= len(aud.history) {
+		aud.historyIdx = 0
+	}
+
+	// audio generated while paused will be queued by Pause()
+	if aud.paused {
+		aud.scrubCt++
+		return nil
+	}
+
+	aud.buffer[aud.bufferCt] = left
+	aud.buffer[aud.bufferCt+1] = right
+	aud.bufferCt += numChannels
 
 	if aud.bufferCt >= len(aud.buffer) {
 		// if buffer is full then queue audio unconditionally
@@ -104,7 +231,7 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 	} else {
 		remaining := int(sdl.GetQueuedAudioSize(aud.id))
 
-		if remaining < critQueueLength {
+		if remaining < critQueueLength*numChannels {
 			// if we're running short of bits in the queue the queue what we have
 			// in the buffer and NOT clearing the buffer
 			//
@@ -113,7 +240,7 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 			if err != nil {
 				return err
 			}
-		} else if remaining < minQueueLength && aud.bufferCt > 10 {
+		} else if remaining < minQueueLength*numChannels && aud.bufferCt > 10*numChannels {
 			// if we're running short of bits in the queue the queue what we have
 			// in the buffer.
 			//
@@ -122,12 +249,12 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 			// the additional condition makes sure we're not queueing a slice
 			// that is too short. SDL has been known to hang with short audio
 			// queues
-			err := sdl.QueueAudio(aud.id, aud.buffer[:aud.bufferCt-1])
+			err := sdl.QueueAudio(aud.id, aud.buffer[:aud.bufferCt-numChannels])
 			if err != nil {
 				return err
 			}
 			aud.bufferCt = 0
-		} else if remaining > maxQueueLength {
+		} else if remaining > maxQueueLength*numChannels {
 			// if length of SDL audio queue is getting too long then clear it
 			//
 			// condition valid when the frame rate is SIGNIFICANTLY MORE than 50/60fps
@@ -143,6 +270,78 @@ func (aud *Audio) SetAudio(audioData uint8) error {
 	return nil
 }
 
+// Pause implements the television.PausingMixer interface.
+//
+// The first call to Pause(true) stops audio being queued as it is generated.
+// Subsequent calls to Pause(true), before a call to Pause(false), mean that the
+// emulation has been stepped and halted again. The audio generated during that
+// step is then played in its entirety.
+func (aud *Audio) Pause(pause bool) error {
+	if !pause {
+		aud.paused = false
+		return nil
+	}
+
+	if !aud.paused {
+		aud.paused = true
+		aud.scrubCt = 0
+		return nil
+	}
+
+	n := aud.scrubCt
+	aud.scrubCt = 0
+
+	// nothing was generated by the step. this will happen if the step was
+	// shorter than a half-scanline
+	if n == 0 {
+		return nil
+	}
+
+	if w := aud.ScrubWindow.Get().(int) * audio.SampleFreq / 1000; n < w {
+		n = w
+	}
+	if n > scrubHistoryLength {
+		n = scrubHistoryLength
+	}
+	if n < minScrubLength {
+		return nil
+	}
+
+	// convert number of samples to number of bytes
+	n *= numChannels
+
+	// copy the most recent n samples from the history
+	aud.scrub = aud.scrub[:0]
+	start := aud.historyIdx - n
+	if start < 0 {
+		aud.scrub = append(aud.scrub, aud.history[len(aud.history)+start:]...)
+		start = 0
+	}
+	aud.scrub = append(aud.scrub, aud.history[start:aud.historyIdx]...)
+
+	// audio from a previous step is no longer interesting
+	sdl.ClearQueuedAudio(aud.id)
+
+	return sdl.QueueAudio(aud.id, aud.scrub)
+}
+
+// Suspend stops audio output and discards any queued audio. Used when the
+// emulation has been stopped for reasons outside the emulation, for example
+// when the window has lost focus.
+func (aud *Audio) Suspend(suspend bool) {
+	if suspend {
+		sdl.ClearQueuedAudio(aud.id)
+	}
+	sdl.PauseAudioDevice(aud.id, suspend)
+}
+
+// QueueFill returns the amount of audio waiting to be played, as a fraction of
+// the maximum length of the queue. A value that is regularly close to zero
+// means the emulation is not keeping up with the audio device.
+func (aud *Audio) QueueFill() float32 {
+	return float32(sdl.GetQueuedAudioSize(aud.id)) / float32(maxQueueLength*numChannels)
+}
+
 // EndMixing implements the television.AudioMixer interface.
 func (aud *Audio) EndMixing() error {
 	sdl.CloseAudioDevice(aud.id)
@@ -0,0 +1,76 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlaudio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jetsetilly/gopher2600/doctor"
+	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Diagnose writes a report of the audio environment to output. SDL must have
+// been initialised before calling this function.
+func Diagnose(output io.Writer) {
+	doctor.Section(output, "audio")
+
+	drivers := ""
+	for i := 0; i < sdl.GetNumAudioDrivers(); i++ {
+		if i > 0 {
+			drivers += ", "
+		}
+		drivers += sdl.GetAudioDriver(i)
+	}
+	doctor.Item(output, "drivers", drivers)
+
+	drv := sdl.GetCurrentAudioDriver()
+	if drv == "" {
+		doctor.Problem(output, "current driver", "audio subsystem not initialised")
+		return
+	}
+	doctor.Item(output, "current driver", drv)
+
+	n := sdl.GetNumAudioDevices(false)
+	if n <= 0 {
+		doctor.Problem(output, "devices", "no output devices found")
+	}
+	for i := 0; i < n; i++ {
+		doctor.Item(output, fmt.Sprintf("device %d", i), sdl.GetAudioDeviceName(i, false))
+	}
+
+	// try opening the default device in the same way as NewAudio()
+	spec := &sdl.AudioSpec{
+		Freq:     audio.SampleFreq,
+		Format:   sdl.AUDIO_U8,
+		Channels: numChannels,
+		Samples:  uint16(bufferLength),
+	}
+
+	var actualSpec sdl.AudioSpec
+
+	id, err := sdl.OpenAudioDevice("", false, spec, &actualSpec, 0)
+	if err != nil {
+		doctor.Problem(output, "default device", err.Error())
+		return
+	}
+	sdl.CloseAudioDevice(id)
+
+	doctor.Item(output, "default device", fmt.Sprintf("%d samples/sec, %d channels, %d sample buffer",
+		actualSpec.Freq, actualSpec.Channels, actualSpec.Samples))
+}
@@ -16,6 +16,7 @@
 package sdlimgui
 
 import (
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
 	"github.com/jetsetilly/gopher2600/reflection"
 
@@ -40,13 +41,15 @@ type imguiColors struct {
 	Transparent imgui.Vec4
 
 	// playscreen color
-	PlayWindowBg     imgui.Vec4
 	PlayWindowBorder imgui.Vec4
 
 	// ROM selector
 	ROMSelectDir  imgui.Vec4
 	ROMSelectFile imgui.Vec4
 
+	// ROM files with problems listed in the catalogue
+	ROMSelectProblem imgui.Vec4
+
 	// the color to draw the TV Screen window border when mouse is captured
 	CapturedScreenTitle  imgui.Vec4
 	CapturedScreenBorder imgui.Vec4
@@ -58,6 +61,26 @@ type imguiColors struct {
 	// RAM window
 	RAMDiff imgui.Vec4
 
+	// TV screen rulers
+	RulerTick  imgui.Vec4
+	RulerText  imgui.Vec4
+	RulerBlank imgui.Vec4
+	RulerBeam  imgui.Vec4
+
+	// safe area guides
+	SafeAreaAction imgui.Vec4
+	SafeAreaTitle  imgui.Vec4
+
+	// tutorial frame parts
+	TutorialVSYNC    imgui.Vec4
+	TutorialVBLANK   imgui.Vec4
+	TutorialVisible  imgui.Vec4
+	TutorialOverscan imgui.Vec4
+
+	// magnifier window
+	MagnifyGrid   imgui.Vec4
+	MagnifyCursor imgui.Vec4
+
 	// control window buttons
 	ControlRun         imgui.Vec4
 	ControlRunHovered  imgui.Vec4
@@ -80,6 +103,7 @@ type imguiColors struct {
 	DisasmVideoStep    imgui.Vec4
 	DisasmBreakAddress imgui.Vec4
 	DisasmBreakOther   imgui.Vec4
+	DisasmSearchMatch  imgui.Vec4
 
 	// audio oscilloscope
 	AudioOscBg   imgui.Vec4
@@ -94,6 +118,34 @@ type imguiColors struct {
 	// chip registers window
 	RegisterBit imgui.Vec4
 
+	// graphics tables window
+	GraphicsBit   imgui.Vec4
+	GraphicsNoBit imgui.Vec4
+
+	// bank trace window
+	BankTraceActive   imgui.Vec4
+	BankTraceInactive imgui.Vec4
+
+	// playmode input display
+	InputDisplayBg  imgui.Vec4
+	InputDisplayOn  imgui.Vec4
+	InputDisplayOff imgui.Vec4
+
+	// playmode event flash
+	EventFlash imgui.Vec4
+
+	// input polls window
+	InputPollsBg     imgui.Vec4
+	InputPollsHBlank imgui.Vec4
+	InputPollStick   imgui.Vec4
+	InputPollPanel   imgui.Vec4
+	InputPollFire    imgui.Vec4
+	InputPollPaddle  imgui.Vec4
+
+	// frame statistics HUD
+	StatsHUDBg      imgui.Vec4
+	StatsHUDWarning imgui.Vec4
+
 	// savekey i2c/eeprom window
 	SaveKeyBit        imgui.Vec4
 	SaveKeyOscBG      imgui.Vec4
@@ -129,7 +181,6 @@ func newColors() *imguiColors {
 		TitleBgActive: imgui.Vec4{0.16, 0.29, 0.48, 1.0},
 		Border:        imgui.Vec4{0.14, 0.14, 0.29, 1.0},
 
-		PlayWindowBg:     imgui.Vec4{0.0, 0.0, 0.0, 1.0},
 		PlayWindowBorder: imgui.Vec4{0.0, 0.0, 0.0, 1.0},
 
 		// additional general colors
@@ -141,6 +192,9 @@ func newColors() *imguiColors {
 		ROMSelectDir:  imgui.Vec4{1.0, 0.5, 0.5, 1.0},
 		ROMSelectFile: imgui.Vec4{1.0, 1.0, 1.0, 1.0},
 
+		// catalogue
+		ROMSelectProblem: imgui.Vec4{1.0, 0.6, 0.2, 1.0},
+
 		// deferring CapturedScreenTitle & CapturedScreenBorder
 
 		// CPU status register buttons
@@ -150,6 +204,26 @@ func newColors() *imguiColors {
 		// RAM window
 		RAMDiff: imgui.Vec4{0.3, 0.2, 0.5, 1.0},
 
+		// TV screen rulers
+		RulerTick:  imgui.Vec4{0.8, 0.8, 0.8, 0.8},
+		RulerText:  imgui.Vec4{0.9, 0.9, 0.9, 1.0},
+		RulerBlank: imgui.Vec4{0.2, 0.2, 0.6, 0.3},
+		RulerBeam:  imgui.Vec4{1.0, 0.3, 0.3, 0.8},
+
+		// safe area guides
+		SafeAreaAction: imgui.Vec4{0.3, 1.0, 0.3, 0.8},
+		SafeAreaTitle:  imgui.Vec4{1.0, 1.0, 0.3, 0.8},
+
+		// tutorial frame parts
+		TutorialVSYNC:    imgui.Vec4{0.9, 0.3, 0.9, 0.9},
+		TutorialVBLANK:   imgui.Vec4{0.3, 0.5, 1.0, 0.9},
+		TutorialVisible:  imgui.Vec4{0.3, 0.9, 0.3, 0.9},
+		TutorialOverscan: imgui.Vec4{1.0, 0.6, 0.2, 0.9},
+
+		// magnifier window
+		MagnifyGrid:   imgui.Vec4{0.5, 0.5, 0.5, 0.5},
+		MagnifyCursor: imgui.Vec4{1.0, 1.0, 1.0, 0.8},
+
 		// control window buttons
 		ControlRun:         imgui.Vec4{0.3, 0.6, 0.3, 1.0},
 		ControlRunHovered:  imgui.Vec4{0.3, 0.65, 0.3, 1.0},
@@ -168,8 +242,9 @@ func newColors() *imguiColors {
 		DisasmNotes:    imgui.Vec4{0.8, 0.8, 0.8, 1.0},
 
 		// disassembly other
-		DisasmCPUstep:   imgui.Vec4{1.0, 1.0, 1.0, 0.1},
-		DisasmVideoStep: imgui.Vec4{0.5, 0.5, 0.5, 0.07},
+		DisasmCPUstep:     imgui.Vec4{1.0, 1.0, 1.0, 0.1},
+		DisasmVideoStep:   imgui.Vec4{0.5, 0.5, 0.5, 0.07},
+		DisasmSearchMatch: imgui.Vec4{0.8, 0.8, 0.3, 0.2},
 		// deferring DisasmBreakAddress & DisasmBreakOther
 
 		// audio oscilloscope
@@ -183,6 +258,34 @@ func newColors() *imguiColors {
 
 		// deferring chip registers window RegisterBit
 
+		// graphics tables window
+		GraphicsBit:   imgui.Vec4{0.9, 0.9, 0.9, 1.0},
+		GraphicsNoBit: imgui.Vec4{0.15, 0.15, 0.15, 1.0},
+
+		// bank trace window
+		BankTraceActive:   imgui.Vec4{0.8, 0.6, 0.2, 1.0},
+		BankTraceInactive: imgui.Vec4{0.15, 0.15, 0.15, 1.0},
+
+		// playmode input display
+		InputDisplayBg:  imgui.Vec4{0.0, 0.0, 0.0, 0.6},
+		InputDisplayOn:  imgui.Vec4{0.9, 0.2, 0.2, 1.0},
+		InputDisplayOff: imgui.Vec4{0.4, 0.4, 0.4, 0.8},
+
+		// playmode event flash
+		EventFlash: imgui.Vec4{1.0, 0.9, 0.3, 1.0},
+
+		// input polls window
+		InputPollsBg:     imgui.Vec4{0.15, 0.15, 0.15, 1.0},
+		InputPollsHBlank: imgui.Vec4{0.08, 0.08, 0.08, 1.0},
+		InputPollStick:   imgui.Vec4{0.2, 0.8, 0.2, 1.0},
+		InputPollPanel:   imgui.Vec4{0.8, 0.8, 0.8, 1.0},
+		InputPollFire:    imgui.Vec4{0.9, 0.2, 0.2, 1.0},
+		InputPollPaddle:  imgui.Vec4{0.3, 0.5, 0.9, 1.0},
+
+		// frame statistics HUD
+		StatsHUDBg:      imgui.Vec4{0.0, 0.0, 0.0, 0.6},
+		StatsHUDWarning: imgui.Vec4{1.0, 0.4, 0.2, 1.0},
+
 		// deferring savekey i2c/eeprom window RegisterBit
 
 		SaveKeyOscBG:      imgui.Vec4{0.21, 0.29, 0.23, 1.0},
@@ -225,8 +328,8 @@ func newColors() *imguiColors {
 	// convert 2600 colours to format usable by imgui
 
 	// convert to imgiu.Vec4 first...
-	vec4PaletteNTSC := make([]imgui.Vec4, 0, len(specification.PaletteNTSC))
-	for _, c := range specification.PaletteNTSC {
+	vec4PaletteNTSC := make([]imgui.Vec4, 0, signal.NumColors)
+	for _, c := range specification.SpecNTSC.Palette() {
 		v := imgui.Vec4{
 			float32(c.R) / 255,
 			float32(c.G) / 255,
@@ -236,8 +339,8 @@ func newColors() *imguiColors {
 		vec4PaletteNTSC = append(vec4PaletteNTSC, v)
 	}
 
-	vec4PalettePAL := make([]imgui.Vec4, 0, len(specification.PalettePAL))
-	for _, c := range specification.PalettePAL {
+	vec4PalettePAL := make([]imgui.Vec4, 0, signal.NumColors)
+	for _, c := range specification.SpecPAL.Palette() {
 		v := imgui.Vec4{
 			float32(c.R) / 255,
 			float32(c.G) / 255,
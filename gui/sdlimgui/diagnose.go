@@ -0,0 +1,125 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/go-gl/gl/v3.2-core/gl"
+	"github.com/jetsetilly/gopher2600/doctor"
+	"github.com/jetsetilly/gopher2600/gui/sdlaudio"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Diagnose writes a report of the SDL, OpenGL and audio environment to
+// output. The function initialises SDL and creates a hidden window in the same
+// way as NewSdlImgui() and so must be called from the main thread. SDL is
+// shutdown before the function returns.
+//
+// Problems found are written to the report. Errors are returned only if SDL
+// can not be initialised at all.
+func Diagnose(output io.Writer) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	doctor.Section(output, "SDL")
+
+	var v sdl.Version
+	sdl.VERSION(&v)
+	doctor.Item(output, "compiled version", fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch))
+	sdl.GetVersion(&v)
+	doctor.Item(output, "linked version", fmt.Sprintf("%d.%d.%d (%s)", v.Major, v.Minor, v.Patch, sdl.GetRevision()))
+	doctor.Item(output, "platform", sdl.GetPlatform())
+
+	err := sdl.Init(sdl.INIT_VIDEO | sdl.INIT_AUDIO)
+	if err != nil {
+		return fmt.Errorf("SDL: %v", err)
+	}
+	defer sdl.Quit()
+
+	drv, err := sdl.GetCurrentVideoDriver()
+	if err != nil {
+		doctor.Problem(output, "video driver", err.Error())
+	} else {
+		doctor.Item(output, "video driver", drv)
+	}
+
+	n, err := sdl.GetNumVideoDisplays()
+	if err != nil {
+		doctor.Problem(output, "displays", err.Error())
+	}
+	for i := 0; i < n; i++ {
+		label := fmt.Sprintf("display %d", i)
+		name, err := sdl.GetDisplayName(i)
+		if err != nil {
+			doctor.Problem(output, label, err.Error())
+			continue
+		}
+		mode, err := sdl.GetCurrentDisplayMode(i)
+		if err != nil {
+			doctor.Problem(output, label, err.Error())
+			continue
+		}
+		doctor.Item(output, label, fmt.Sprintf("%s (%dx%d @ %dHz)", name, mode.W, mode.H, mode.RefreshRate))
+	}
+
+	diagnoseGL(output)
+
+	sdlaudio.Diagnose(output)
+
+	return nil
+}
+
+// diagnoseGL creates a hidden window with the same OpenGL attributes as used
+// by the platform type and reports on the resulting OpenGL context.
+func diagnoseGL(output io.Writer) {
+	doctor.Section(output, "OpenGL")
+
+	_ = sdl.GLSetAttribute(sdl.GL_CONTEXT_MAJOR_VERSION, 3)
+	_ = sdl.GLSetAttribute(sdl.GL_CONTEXT_MINOR_VERSION, 2)
+	_ = sdl.GLSetAttribute(sdl.GL_CONTEXT_FLAGS, sdl.GL_CONTEXT_FORWARD_COMPATIBLE_FLAG)
+	_ = sdl.GLSetAttribute(sdl.GL_CONTEXT_PROFILE_MASK, sdl.GL_CONTEXT_PROFILE_CORE)
+
+	window, err := sdl.CreateWindow(windowTitle,
+		sdl.WINDOWPOS_UNDEFINED, sdl.WINDOWPOS_UNDEFINED, 64, 64,
+		sdl.WINDOW_OPENGL|sdl.WINDOW_HIDDEN)
+	if err != nil {
+		doctor.Problem(output, "window", err.Error())
+		return
+	}
+	defer window.Destroy()
+
+	ctx, err := window.GLCreateContext()
+	if err != nil {
+		doctor.Problem(output, "context", err.Error())
+		return
+	}
+	defer sdl.GLDeleteContext(ctx)
+
+	err = gl.Init()
+	if err != nil {
+		doctor.Problem(output, "initialisation", err.Error())
+		return
+	}
+
+	doctor.Item(output, "vendor", gl.GoStr(gl.GetString(gl.VENDOR)))
+	doctor.Item(output, "renderer", gl.GoStr(gl.GetString(gl.RENDERER)))
+	doctor.Item(output, "version", gl.GoStr(gl.GetString(gl.VERSION)))
+	doctor.Item(output, "glsl version", gl.GoStr(gl.GetString(gl.SHADING_LANGUAGE_VERSION)))
+}
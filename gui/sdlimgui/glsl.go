@@ -84,6 +84,8 @@ type glsl struct {
 	attribNoiseLevel          int32 // uniform
 	attribVignette            int32 // uniform
 	attribMaskScanlineScaling int32 // uniform
+	attribColorDeficiency     int32 // uniform
+	attribColorCorrection     int32 // uniform
 }
 
 func newGlsl(io imgui.IO, img *SdlImgui) (*glsl, error) {
@@ -232,6 +234,8 @@ func (rnd *glsl) render(displaySize [2]float32, framebufferSize [2]float32, draw
 				gl.Uniform1f(rnd.attribNoiseLevel, float32(rnd.img.crtPrefs.NoiseLevel.Get().(float64)))
 				gl.Uniform1i(rnd.attribVignette, boolToInt32(rnd.img.crtPrefs.Vignette.Get().(bool)))
 				gl.Uniform1i(rnd.attribMaskScanlineScaling, int32(rnd.img.crtPrefs.MaskScanlineScaling.Get().(int)))
+				gl.Uniform1i(rnd.attribColorDeficiency, int32(rnd.img.crtPrefs.ColorDeficiency.Get().(int)))
+				gl.Uniform1i(rnd.attribColorCorrection, boolToInt32(rnd.img.crtPrefs.ColorCorrection.Get().(bool)))
 
 				// critical section
 				rnd.img.screen.crit.section.Lock()
@@ -309,6 +313,10 @@ func (rnd *glsl) render(displaySize [2]float32, framebufferSize [2]float32, draw
 					gl.Uniform1i(rnd.attribImageType, 1)
 				case rnd.img.wm.dbgScr.overlayTexture:
 					gl.Uniform1i(rnd.attribImageType, 2)
+				case rnd.img.wm.magnify.magnifyTexture:
+					gl.Uniform1i(rnd.attribImageType, 2)
+				case rnd.img.wm.dbgScr.thumbnailTexture:
+					gl.Uniform1i(rnd.attribImageType, 2)
 				case rnd.img.wm.playScr.screenTexture:
 					gl.Uniform1i(rnd.attribImageType, 3)
 				case rnd.img.wm.crtPrefs.crtTexture:
@@ -405,6 +413,8 @@ func (rnd *glsl) setup() {
 	rnd.attribNoiseLevel = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("NoiseLevel"+"\x00"))
 	rnd.attribVignette = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("Vignette"+"\x00"))
 	rnd.attribMaskScanlineScaling = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("MaskScanlineScaling"+"\x00"))
+	rnd.attribColorDeficiency = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("ColorDeficiency"+"\x00"))
+	rnd.attribColorCorrection = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("ColorCorrection"+"\x00"))
 
 	rnd.attribTexture = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("Texture"+"\x00"))
 	rnd.attribProjMtx = gl.GetUniformLocation(rnd.shaderHandle, gl.Str("ProjMtx"+"\x00"))
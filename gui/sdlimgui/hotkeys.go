@@ -0,0 +1,92 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// the amount by which the volume hotkeys change the master volume.
+const volumeStep = 10
+
+// hotkey handles the keys that are acted upon by the GUI rather than by the
+// emulation. returns true if the key has been handled and should not be
+// forwarded to the emulation.
+func (img *SdlImgui) hotkey(key string, mod gui.KeyMod, down bool) bool {
+	if mod != gui.KeyModNone {
+		return false
+	}
+
+	switch key {
+	case "-", "=", "M", "F8":
+	default:
+		return false
+	}
+
+	// key up events for the hotkeys are swallowed
+	if !down {
+		return true
+	}
+
+	var msg string
+
+	switch key {
+	case "-", "=":
+		delta := volumeStep
+		if key == "-" {
+			delta = -volumeStep
+		}
+		v, err := img.audio.AdjustVolume(delta)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+			return true
+		}
+		msg = fmt.Sprintf("Volume %d%%", v)
+		if img.audio.Mute.Get().(bool) {
+			msg = fmt.Sprintf("%s (muted)", msg)
+		}
+
+	case "M":
+		m, err := img.audio.ToggleMute()
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+			return true
+		}
+		msg = "Sound on"
+		if m {
+			msg = "Sound muted"
+		}
+
+	case "F8":
+		s := !img.screen.statsHUD.Get().(bool)
+		err := img.screen.statsHUD.Set(s)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+			return true
+		}
+		msg = "Frame statistics hidden"
+		if s {
+			msg = "Frame statistics shown"
+		}
+	}
+
+	img.wm.playScr.notify(msg)
+
+	return true
+}
@@ -199,6 +199,8 @@ func (img *SdlImgui) imguiTVPalette() (string, packedPalette) {
 	switch img.lz.TV.Spec.ID {
 	case "PAL":
 		return "PAL", img.cols.packedPalettePAL
+	case "PAL60":
+		return "PAL60", img.cols.packedPalettePAL
 	case "NTSC":
 		return "NTSC", img.cols.packedPaletteNTSC
 	}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 )
 
 type popupState int
@@ -92,7 +93,7 @@ func (pal *popupPalette) draw() {
 	// information bar
 	imgui.Text(pal.paletteName)
 	imgui.SameLine()
-	imgui.Text(fmt.Sprintf("%02x", *pal.target))
+	imgui.Text(signal.ColorSignal(*pal.target).String())
 	imgui.SameLine()
 
 	// remove alpha component
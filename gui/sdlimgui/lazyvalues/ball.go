@@ -23,6 +23,7 @@ import (
 
 // LazyBall lazily accesses ball information from the emulator.
 type LazyBall struct {
+	demand
 	val *LazyValues
 
 	bs            atomic.Value // *video.BallSprite
@@ -65,7 +66,7 @@ type LazyBall struct {
 }
 
 func newLazyBall(val *LazyValues) *LazyBall {
-	return &LazyBall{val: val}
+	return &LazyBall{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyBall) push() {
@@ -46,7 +46,7 @@ func newLazyBreakpoints(val *LazyValues) *LazyBreakpoints {
 func (lz *LazyBreakpoints) HasBreak(e *disassembly.Entry) debugger.BreakGroup {
 	i := e.Result.Address & memorymap.CartridgeBits
 
-	lz.val.Dbg.PushRawEvent(func() {
+	lz.val.Dbg.PushRawEventLowPriority(func() {
 		lz.breakpoints[i].Store(lz.val.Dbg.HasBreak(e))
 	})
 
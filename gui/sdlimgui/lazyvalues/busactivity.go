@@ -0,0 +1,47 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package lazyvalues
+
+import (
+	"sync/atomic"
+
+	"github.com/jetsetilly/gopher2600/busactivity"
+)
+
+// LazyBusActivity lazily accesses the bus activity recorded by the debugger.
+type LazyBusActivity struct {
+	demand
+	val *LazyValues
+
+	enabled atomic.Value // bool
+	history atomic.Value // []busactivity.Cycle
+	Enabled bool
+	History []busactivity.Cycle
+}
+
+func newLazyBusActivity(val *LazyValues) *LazyBusActivity {
+	return &LazyBusActivity{demand: newDemand(val), val: val}
+}
+
+func (lz *LazyBusActivity) push() {
+	lz.enabled.Store(lz.val.Dbg.BusActivity.IsEnabled())
+	lz.history.Store(lz.val.Dbg.BusActivity.History())
+}
+
+func (lz *LazyBusActivity) update() {
+	lz.Enabled, _ = lz.enabled.Load().(bool)
+	lz.History, _ = lz.history.Load().([]busactivity.Cycle)
+}
@@ -23,6 +23,7 @@ import (
 
 // LazyChipRegisters lazily accesses chip registere information from the emulator.
 type LazyChipRegisters struct {
+	demand
 	val *LazyValues
 
 	swcha  atomic.Value // uint8
@@ -47,7 +48,7 @@ type LazyChipRegisters struct {
 }
 
 func newLazyChipRegisters(val *LazyValues) *LazyChipRegisters {
-	return &LazyChipRegisters{val: val}
+	return &LazyChipRegisters{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyChipRegisters) push() {
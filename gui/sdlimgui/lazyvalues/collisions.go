@@ -21,6 +21,7 @@ import (
 
 // LazyTimer lazily accesses RIOT timer information from the emulator.
 type LazyCollisions struct {
+	demand
 	val *LazyValues
 
 	cxm0p  atomic.Value // uint8
@@ -43,7 +44,7 @@ type LazyCollisions struct {
 }
 
 func newLazyCollisions(val *LazyValues) *LazyCollisions {
-	return &LazyCollisions{val: val}
+	return &LazyCollisions{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyCollisions) push() {
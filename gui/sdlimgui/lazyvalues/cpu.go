@@ -23,6 +23,7 @@ import (
 
 // LazyCPU lazily accesses CPU information from the emulator.
 type LazyCPU struct {
+	demand
 	val *LazyValues
 
 	hasReset  atomic.Value // bool
@@ -45,7 +46,7 @@ type LazyCPU struct {
 }
 
 func newLazyCPU(val *LazyValues) *LazyCPU {
-	return &LazyCPU{val: val}
+	return &LazyCPU{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyCPU) push() {
@@ -0,0 +1,50 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package lazyvalues
+
+import "sync/atomic"
+
+// demand is embedded in those lazy types that are only refreshed when they
+// have been demanded by the GUI. this reduces the amount of information that
+// needs to be copied from the emulation on every refresh.
+//
+// a lazy type with demand must be demanded every GUI frame (by calling the
+// Demand() function) if the values are to be kept up-to-date. the values of
+// a lazy type that has not been demanded recently will be stale.
+type demand struct {
+	val *LazyValues
+
+	// the refresh generation in which the type was most recently demanded.
+	// should only be accessed through the sync/atomic package.
+	generation uint32
+}
+
+func newDemand(val *LazyValues) demand {
+	return demand{val: val}
+}
+
+// Demand indicates that the values of the lazy type are required. The values
+// will be refreshed on the next call to Refresh() and will be available on the
+// call after that.
+func (d *demand) Demand() {
+	atomic.StoreUint32(&d.generation, atomic.LoadUint32(&d.val.generation))
+}
+
+// isDemanded returns true if Demand() has been called since the previous
+// refresh generation.
+func (d *demand) isDemanded() bool {
+	return atomic.LoadUint32(&d.val.generation)-atomic.LoadUint32(&d.generation) <= 1
+}
@@ -117,6 +117,26 @@
 // additional context provided by the disassembly.Entry is required.
 //
 //
+// Demand
+// ------
+//
+// Copying every value from the emulation on every refresh is wasteful when
+// most of those values are not being displayed. For this reason, the larger
+// lazy types are only refreshed if they have been "demanded" recently. For
+// example, the CPU window demands the LazyCPU type every frame that it is
+// open:
+//
+//	lazyval.CPU.Demand()
+//
+// The demand mechanism uses the number of calls to Refresh() as a generation
+// counter. A lazy type is refreshed if it has been demanded in the current or
+// the previous generation. Otherwise the values in the type are left alone
+// and will be stale.
+//
+// Lazy types without a Demand() function are refreshed on every call to
+// Refresh().
+//
+//
 // Ensuring Up-To-Date Information
 // -------------------------------
 //
@@ -0,0 +1,44 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package lazyvalues
+
+import (
+	"sync/atomic"
+
+	"github.com/jetsetilly/gopher2600/hardware/tia/delay"
+)
+
+// LazyFutures lazily accesses the pending delay events in the TIA.
+type LazyFutures struct {
+	demand
+	val *LazyValues
+
+	futures atomic.Value // []delay.Future
+
+	Futures []delay.Future
+}
+
+func newLazyFutures(val *LazyValues) *LazyFutures {
+	return &LazyFutures{demand: newDemand(val), val: val}
+}
+
+func (lz *LazyFutures) push() {
+	lz.futures.Store(lz.val.Dbg.VCS.TIA.Futures())
+}
+
+func (lz *LazyFutures) update() {
+	lz.Futures, _ = lz.futures.Load().([]delay.Future)
+}
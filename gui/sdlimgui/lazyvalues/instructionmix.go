@@ -0,0 +1,63 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package lazyvalues
+
+import (
+	"sync/atomic"
+
+	"github.com/jetsetilly/gopher2600/instructionmix"
+)
+
+// LazyInstructionMix lazily accesses the instruction mix from the emulator.
+type LazyInstructionMix struct {
+	demand
+	val *LazyValues
+
+	enabled       atomic.Value // bool
+	window        atomic.Value // int
+	byOpcode      atomic.Value // []instructionmix.Entry
+	byAddressMode atomic.Value // []instructionmix.Entry
+	Enabled       bool
+	Window        int
+	ByOpcode      []instructionmix.Entry
+	ByAddressMode []instructionmix.Entry
+}
+
+func newLazyInstructionMix(val *LazyValues) *LazyInstructionMix {
+	return &LazyInstructionMix{demand: newDemand(val), val: val}
+}
+
+func (lz *LazyInstructionMix) push() {
+	lz.enabled.Store(lz.val.Dbg.InstructionMix.IsEnabled())
+	lz.window.Store(lz.val.Dbg.InstructionMix.Window())
+	lz.byOpcode.Store(lz.val.Dbg.InstructionMix.ByOpcode())
+	lz.byAddressMode.Store(lz.val.Dbg.InstructionMix.ByAddressingMode())
+}
+
+func (lz *LazyInstructionMix) update() {
+	if v, ok := lz.enabled.Load().(bool); ok {
+		lz.Enabled = v
+	}
+	if v, ok := lz.window.Load().(int); ok {
+		lz.Window = v
+	}
+	if v, ok := lz.byOpcode.Load().([]instructionmix.Entry); ok {
+		lz.ByOpcode = v
+	}
+	if v, ok := lz.byAddressMode.Load().([]instructionmix.Entry); ok {
+		lz.ByAddressMode = v
+	}
+}
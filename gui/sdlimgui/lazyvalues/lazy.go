@@ -16,6 +16,8 @@
 package lazyvalues
 
 import (
+	"sync/atomic"
+
 	"github.com/jetsetilly/gopher2600/debugger"
 )
 
@@ -25,6 +27,11 @@ import (
 type LazyValues struct {
 	active bool
 
+	// the number of times Refresh() has been called. used by the demand
+	// mechanism to decide which lazy types need refreshing. should only be
+	// accessed through the sync/atomic package.
+	generation uint32
+
 	// the debugger is racy. it should not be accessed directly except through
 	// the lazy system or directly with Debugger.PushRawEvent()
 	Dbg *debugger.Debugger
@@ -35,6 +42,7 @@ type LazyValues struct {
 	CPU           *LazyCPU
 	RAM           *LazyRAM
 	Timer         *LazyTimer
+	Futures       *LazyFutures
 	Playfield     *LazyPlayfield
 	Player0       *LazyPlayer
 	Player1       *LazyPlayer
@@ -50,6 +58,17 @@ type LazyValues struct {
 	Log           *LazyLog
 	SaveKey       *LazySaveKey
 	Rewind        *LazyRewind
+	Mix           *LazyInstructionMix
+	Score         *LazyScore
+	InputPolls    *LazyInputPolls
+	BusActivity   *LazyBusActivity
+
+	// the following types are only refreshed when they have been demanded.
+	// see the Demand() function
+	//
+	// CPU, RAM, Timer, Futures, Playfield, Player0, Player1, Missile0,
+	// Missile1, Ball, Collisions, ChipRegisters, Log, Mix, Score, InputPolls,
+	// BusActivity
 
 	// note that LazyBreakpoints works slightly different to the the other Lazy* types.
 	Breakpoints *LazyBreakpoints
@@ -63,6 +82,7 @@ func NewLazyValues() *LazyValues {
 	val.CPU = newLazyCPU(val)
 	val.RAM = newLazyRAM(val)
 	val.Timer = newLazyTimer(val)
+	val.Futures = newLazyFutures(val)
 	val.Playfield = newLazyPlayfield(val)
 	val.Player0 = newLazyPlayer(val, 0)
 	val.Player1 = newLazyPlayer(val, 1)
@@ -79,6 +99,10 @@ func NewLazyValues() *LazyValues {
 	val.SaveKey = newLazySaveKey(val)
 	val.Breakpoints = newLazyBreakpoints(val)
 	val.Rewind = newLazyRewind(val)
+	val.Mix = newLazyInstructionMix(val)
+	val.Score = newLazyScore(val)
+	val.InputPolls = newLazyInputPolls(val)
+	val.BusActivity = newLazyBusActivity(val)
 
 	return val
 }
@@ -96,49 +120,129 @@ func (val *LazyValues) Refresh() {
 		return
 	}
 
-	val.Dbg.PushRawEvent(func() {
+	atomic.AddUint32(&val.generation, 1)
+
+	val.Dbg.PushRawEventLowPriority(func() {
 		val.Debugger.push()
-		val.CPU.push()
-		val.RAM.push()
-		val.Timer.push()
-		val.Playfield.push()
-		val.Player0.push()
-		val.Player1.push()
-		val.Missile0.push()
-		val.Missile1.push()
-		val.Ball.push()
+		if val.CPU.isDemanded() {
+			val.CPU.push()
+		}
+		if val.RAM.isDemanded() {
+			val.RAM.push()
+		}
+		if val.Timer.isDemanded() {
+			val.Timer.push()
+		}
+		if val.Futures.isDemanded() {
+			val.Futures.push()
+		}
+		if val.Playfield.isDemanded() {
+			val.Playfield.push()
+		}
+		if val.Player0.isDemanded() {
+			val.Player0.push()
+		}
+		if val.Player1.isDemanded() {
+			val.Player1.push()
+		}
+		if val.Missile0.isDemanded() {
+			val.Missile0.push()
+		}
+		if val.Missile1.isDemanded() {
+			val.Missile1.push()
+		}
+		if val.Ball.isDemanded() {
+			val.Ball.push()
+		}
 		val.TV.push()
 		val.Cart.push()
 		val.Controllers.push()
 		val.Prefs.push()
-		val.Collisions.push()
-		val.ChipRegisters.push()
-		val.Log.push()
+		if val.Collisions.isDemanded() {
+			val.Collisions.push()
+		}
+		if val.ChipRegisters.isDemanded() {
+			val.ChipRegisters.push()
+		}
+		if val.Log.isDemanded() {
+			val.Log.push()
+		}
 		val.SaveKey.push()
 		val.Rewind.push()
+		if val.Mix.isDemanded() {
+			val.Mix.push()
+		}
+		if val.Score.isDemanded() {
+			val.Score.push()
+		}
+		if val.InputPolls.isDemanded() {
+			val.InputPolls.push()
+		}
+		if val.BusActivity.isDemanded() {
+			val.BusActivity.push()
+		}
 
 		// no push() function for breakpoints type
 	})
 
 	val.Debugger.update()
-	val.CPU.update()
-	val.RAM.update()
-	val.Timer.update()
-	val.Playfield.update()
-	val.Player0.update()
-	val.Player1.update()
-	val.Missile0.update()
-	val.Missile1.update()
-	val.Ball.update()
+	if val.CPU.isDemanded() {
+		val.CPU.update()
+	}
+	if val.RAM.isDemanded() {
+		val.RAM.update()
+	}
+	if val.Timer.isDemanded() {
+		val.Timer.update()
+	}
+	if val.Futures.isDemanded() {
+		val.Futures.update()
+	}
+	if val.Playfield.isDemanded() {
+		val.Playfield.update()
+	}
+	if val.Player0.isDemanded() {
+		val.Player0.update()
+	}
+	if val.Player1.isDemanded() {
+		val.Player1.update()
+	}
+	if val.Missile0.isDemanded() {
+		val.Missile0.update()
+	}
+	if val.Missile1.isDemanded() {
+		val.Missile1.update()
+	}
+	if val.Ball.isDemanded() {
+		val.Ball.update()
+	}
 	val.TV.update()
 	val.Cart.update()
 	val.Controllers.update()
 	val.Prefs.update()
-	val.Collisions.update()
-	val.ChipRegisters.update()
-	val.Log.update()
+	if val.Collisions.isDemanded() {
+		val.Collisions.update()
+	}
+	if val.ChipRegisters.isDemanded() {
+		val.ChipRegisters.update()
+	}
+	if val.Log.isDemanded() {
+		val.Log.update()
+	}
 	val.SaveKey.update()
 	val.Rewind.update()
+	if val.Mix.isDemanded() {
+		val.Mix.update()
+	}
+	if val.Score.isDemanded() {
+		val.Score.update()
+	}
+	if val.InputPolls.isDemanded() {
+		val.InputPolls.update()
+	}
+	if val.BusActivity.isDemanded() {
+		val.BusActivity.update()
+	}
 
 	// no update() function for breakpoints type
 }
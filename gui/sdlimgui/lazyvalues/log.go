@@ -23,6 +23,7 @@ import (
 
 // LazyLog lazily accesses chip registere information from the emulator.
 type LazyLog struct {
+	demand
 	val *LazyValues
 
 	log   atomic.Value // []logger.Entry
@@ -32,7 +33,7 @@ type LazyLog struct {
 }
 
 func newLazyLog(val *LazyValues) *LazyLog {
-	return &LazyLog{val: val}
+	return &LazyLog{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyLog) push() {
@@ -23,6 +23,7 @@ import (
 
 // LazyMissile lazily accesses missile information from the emulator.
 type LazyMissile struct {
+	demand
 	val *LazyValues
 	id  int
 
@@ -62,7 +63,7 @@ type LazyMissile struct {
 }
 
 func newLazyMissile(val *LazyValues, id int) *LazyMissile {
-	return &LazyMissile{val: val, id: id}
+	return &LazyMissile{demand: newDemand(val), val: val, id: id}
 }
 
 func (lz *LazyMissile) push() {
@@ -23,6 +23,7 @@ import (
 
 // LazyPlayer lazily accesses player information from the emulator.
 type LazyPlayer struct {
+	demand
 	val *LazyValues
 	id  int
 
@@ -70,7 +71,7 @@ type LazyPlayer struct {
 }
 
 func newLazyPlayer(val *LazyValues, id int) *LazyPlayer {
-	return &LazyPlayer{val: val, id: id}
+	return &LazyPlayer{demand: newDemand(val), val: val, id: id}
 }
 
 func (lz *LazyPlayer) push() {
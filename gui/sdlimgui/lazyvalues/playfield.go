@@ -23,6 +23,7 @@ import (
 
 // LazyPlayfield lazily accesses playfield information from the emulator.
 type LazyPlayfield struct {
+	demand
 	val *LazyValues
 
 	pf              atomic.Value // *video.Playfield
@@ -61,7 +62,7 @@ type LazyPlayfield struct {
 }
 
 func newLazyPlayfield(val *LazyValues) *LazyPlayfield {
-	return &LazyPlayfield{val: val}
+	return &LazyPlayfield{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyPlayfield) push() {
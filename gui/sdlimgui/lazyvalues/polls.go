@@ -0,0 +1,48 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package lazyvalues
+
+import (
+	"sync/atomic"
+
+	"github.com/jetsetilly/gopher2600/inputpolls"
+)
+
+// LazyInputPolls lazily accesses the input register reads recorded by the
+// debugger.
+type LazyInputPolls struct {
+	demand
+	val *LazyValues
+
+	enabled atomic.Value // bool
+	polls   atomic.Value // []inputpolls.Poll
+	Enabled bool
+	Polls   []inputpolls.Poll
+}
+
+func newLazyInputPolls(val *LazyValues) *LazyInputPolls {
+	return &LazyInputPolls{demand: newDemand(val), val: val}
+}
+
+func (lz *LazyInputPolls) push() {
+	lz.enabled.Store(lz.val.Dbg.InputPolls.IsEnabled())
+	lz.polls.Store(lz.val.Dbg.InputPolls.LastFrame())
+}
+
+func (lz *LazyInputPolls) update() {
+	lz.Enabled, _ = lz.enabled.Load().(bool)
+	lz.Polls, _ = lz.polls.Load().([]inputpolls.Poll)
+}
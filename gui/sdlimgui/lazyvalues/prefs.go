@@ -25,17 +25,25 @@ type LazyPrefs struct {
 
 	randomState      atomic.Value // bool (from prefs.Bool.Get())
 	randomPins       atomic.Value // bool (from prefs.Bool.Get())
+	lenientTimer     atomic.Value // bool (from prefs.Bool.Get())
 	fxxxMirror       atomic.Value // bool (from prefs.Bool.Get())
 	symbols          atomic.Value // bool (from prefs.Bool.Get())
 	rewindMaxEntries atomic.Value // int (from prefs.Int.Get())
 	rewindFreq       atomic.Value // int (from prefs.Int.Get())
+	audioPan0        atomic.Value // float64 (from prefs.Float.Get())
+	audioPan1        atomic.Value // float64 (from prefs.Float.Get())
+	swapPorts        atomic.Value // bool (from prefs.Bool.Get())
 
 	RandomState      bool
 	RandomPins       bool
+	LenientTimer     bool
 	FxxxMirror       bool
 	Symbols          bool
 	RewindMaxEntries int
 	RewindFreq       int
+	AudioPan0        float64
+	AudioPan1        float64
+	SwapPorts        bool
 }
 
 func newLazyPrefs(val *LazyValues) *LazyPrefs {
@@ -46,16 +54,24 @@ func newLazyPrefs(val *LazyValues) *LazyPrefs {
 func (lz *LazyPrefs) push() {
 	lz.randomState.Store(lz.val.Dbg.VCS.Prefs.RandomState.Get())
 	lz.randomPins.Store(lz.val.Dbg.VCS.Prefs.RandomPins.Get())
+	lz.lenientTimer.Store(lz.val.Dbg.VCS.Prefs.LenientTimer.Get())
 	lz.fxxxMirror.Store(lz.val.Dbg.Disasm.Prefs.FxxxMirror.Get())
 	lz.symbols.Store(lz.val.Dbg.Disasm.Prefs.Symbols.Get())
 	lz.rewindMaxEntries.Store(lz.val.Dbg.Rewind.Prefs.MaxEntries.Get())
 	lz.rewindFreq.Store(lz.val.Dbg.Rewind.Prefs.Freq.Get())
+	lz.audioPan0.Store(lz.val.Dbg.VCS.Prefs.AudioPan0.Get())
+	lz.audioPan1.Store(lz.val.Dbg.VCS.Prefs.AudioPan1.Get())
+	lz.swapPorts.Store(lz.val.Dbg.VCS.Prefs.SwapPorts.Get())
 }
 func (lz *LazyPrefs) update() {
 	lz.RandomState, _ = lz.randomState.Load().(bool)
 	lz.RandomPins, _ = lz.randomPins.Load().(bool)
+	lz.LenientTimer, _ = lz.lenientTimer.Load().(bool)
 	lz.FxxxMirror, _ = lz.fxxxMirror.Load().(bool)
 	lz.Symbols, _ = lz.symbols.Load().(bool)
 	lz.RewindMaxEntries, _ = lz.rewindMaxEntries.Load().(int)
 	lz.RewindFreq, _ = lz.rewindFreq.Load().(int)
+	lz.AudioPan0, _ = lz.audioPan0.Load().(float64)
+	lz.AudioPan1, _ = lz.audioPan1.Load().(float64)
+	lz.SwapPorts, _ = lz.swapPorts.Load().(bool)
 }
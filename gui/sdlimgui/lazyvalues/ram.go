@@ -18,21 +18,28 @@ package lazyvalues
 import (
 	"sync/atomic"
 
+	"github.com/jetsetilly/gopher2600/debugger"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
 )
 
 // LazyRAM lazily accesses the RAM area of VCS memory.
 type LazyRAM struct {
+	demand
 	val *LazyValues
 
 	ram atomic.Value // []atomic.Value -> uint8
 	RAM []uint8
+
+	// the instruction that most recently wrote to each RAM address
+	blame atomic.Value // []debugger.Blame
+	Blame []debugger.Blame
 }
 
 func newLazyRAM(val *LazyValues) *LazyRAM {
 	lz := &LazyRAM{
-		val: val,
-		RAM: make([]uint8, memorymap.MemtopRAM-memorymap.OriginRAM+1),
+		demand: newDemand(val),
+		val:    val,
+		RAM:    make([]uint8, memorymap.MemtopRAM-memorymap.OriginRAM+1),
 	}
 	lz.ram.Store(make([]atomic.Value, memorymap.MemtopRAM-memorymap.OriginRAM+1))
 	return lz
@@ -44,6 +51,7 @@ func (lz *LazyRAM) push() {
 		ram[i].Store(lz.val.Dbg.VCS.Mem.RAM.RAM[i])
 	}
 	lz.ram.Store(ram)
+	lz.blame.Store(lz.val.Dbg.GetRAMBlame())
 }
 
 func (lz *LazyRAM) update() {
@@ -54,4 +62,5 @@ func (lz *LazyRAM) update() {
 			}
 		}
 	}
+	lz.Blame, _ = lz.blame.Load().([]debugger.Blame)
 }
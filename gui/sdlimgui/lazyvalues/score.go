@@ -0,0 +1,56 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package lazyvalues
+
+import (
+	"sync/atomic"
+
+	"github.com/jetsetilly/gopher2600/score"
+)
+
+// LazyScore lazily accesses the score readout from the debugger.
+type LazyScore struct {
+	demand
+	val *LazyValues
+
+	readings  atomic.Value // []score.Reading
+	best      atomic.Value // int
+	bestValid atomic.Value // bool
+	Readings  []score.Reading
+	Best      int
+	BestValid bool
+}
+
+func newLazyScore(val *LazyValues) *LazyScore {
+	return &LazyScore{demand: newDemand(val), val: val}
+}
+
+func (lz *LazyScore) push() {
+	// errors are unlikely and there is nothing useful the GUI can do with
+	// them. a nil list of readings is pushed instead
+	rs, _ := lz.val.Dbg.Score.Read()
+	lz.readings.Store(rs)
+
+	best, ok := lz.val.Dbg.Score.Best()
+	lz.best.Store(best)
+	lz.bestValid.Store(ok)
+}
+
+func (lz *LazyScore) update() {
+	lz.Readings, _ = lz.readings.Load().([]score.Reading)
+	lz.Best, _ = lz.best.Load().(int)
+	lz.BestValid, _ = lz.bestValid.Load().(bool)
+}
@@ -19,6 +19,7 @@ import "sync/atomic"
 
 // LazyTimer lazily accesses RIOT timer information from the emulator.
 type LazyTimer struct {
+	demand
 	val *LazyValues
 
 	divider        atomic.Value // string
@@ -31,7 +32,7 @@ type LazyTimer struct {
 }
 
 func newLazyTimer(val *LazyValues) *LazyTimer {
-	return &LazyTimer{val: val}
+	return &LazyTimer{demand: newDemand(val), val: val}
 }
 
 func (lz *LazyTimer) push() {
@@ -36,6 +36,11 @@ type LazyTV struct {
 	actualFPS  atomic.Value // float32
 	reqFPS     atomic.Value // float32
 
+	lastFrameScanlines atomic.Value // int
+	visibleTop         atomic.Value // int
+	visibleBottom      atomic.Value // int
+	syncedFrame        atomic.Value // int
+
 	Spec       specification.Spec
 	TVstr      string
 	LastSignal signal.SignalAttributes
@@ -45,6 +50,12 @@ type LazyTV struct {
 	IsStable   bool
 	AcutalFPS  float32
 	ReqFPS     float32
+
+	// frame geometry
+	LastFrameScanlines int
+	VisibleTop         int
+	VisibleBottom      int
+	SyncedFrame        int
 }
 
 func newLazyTV(val *LazyValues) *LazyTV {
@@ -69,6 +80,11 @@ func (lz *LazyTV) push() {
 	lz.actualFPS.Store(lz.val.Dbg.VCS.TV.GetActualFPS())
 
 	lz.reqFPS.Store(lz.val.Dbg.VCS.TV.GetReqFPS())
+
+	lz.lastFrameScanlines.Store(lz.val.Dbg.VCS.TV.GetState(signal.ReqLastFrameScanlines))
+	lz.visibleTop.Store(lz.val.Dbg.VCS.TV.GetState(signal.ReqVisibleTop))
+	lz.visibleBottom.Store(lz.val.Dbg.VCS.TV.GetState(signal.ReqVisibleBottom))
+	lz.syncedFrame.Store(lz.val.Dbg.VCS.TV.GetState(signal.ReqSyncedFramenum))
 }
 
 func (lz *LazyTV) update() {
@@ -81,4 +97,8 @@ func (lz *LazyTV) update() {
 	lz.IsStable, _ = lz.isStable.Load().(bool)
 	lz.AcutalFPS, _ = lz.actualFPS.Load().(float32)
 	lz.ReqFPS, _ = lz.reqFPS.Load().(float32)
+	lz.LastFrameScanlines, _ = lz.lastFrameScanlines.Load().(int)
+	lz.VisibleTop, _ = lz.visibleTop.Load().(int)
+	lz.VisibleBottom, _ = lz.visibleBottom.Load().(int)
+	lz.SyncedFrame, _ = lz.syncedFrame.Load().(int)
 }
@@ -0,0 +1,205 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/debugger"
+	"github.com/jetsetilly/gopher2600/gui"
+)
+
+// the menus that issue debugger commands. the commands are pushed to the
+// terminal in exactly the same way as if they had been typed by the user. this
+// means that the debugger can be operated entirely with the mouse.
+const (
+	commandMenuEmulation = "Emulation"
+	commandMenuHalt      = "Halt Conditions"
+	commandMenuSession   = "Session"
+)
+
+// the titles of the popups used to name a saved or loaded session.
+const (
+	sessionSavePopup = "Save Session"
+	sessionLoadPopup = "Load Session"
+)
+
+// menuCommand draws a menu entry that pushes the command to the terminal.
+// the label is indented to align with window entries in the other menus.
+func (wm *windowManager) menuCommand(label string, command string) {
+	if imgui.Selectable(fmt.Sprintf("  %s", label)) {
+		wm.img.term.pushCommand(command)
+	}
+}
+
+// menuCommandToggle is like menuCommand() except that the entry is decorated
+// with the same indicator used for open windows if selected is true.
+func (wm *windowManager) menuCommandToggle(label string, command string, selected bool) {
+	if selected {
+		label = fmt.Sprintf("· %s", label)
+	} else {
+		label = fmt.Sprintf("  %s", label)
+	}
+	if imgui.Selectable(label) {
+		wm.img.term.pushCommand(command)
+	}
+}
+
+func (wm *windowManager) menuSeparator() {
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+}
+
+func (wm *windowManager) drawEmulationMenu() {
+	if !imgui.BeginMenu(commandMenuEmulation) {
+		return
+	}
+
+	if wm.img.state == gui.StateRunning {
+		wm.menuCommand("Halt", "HALT")
+	} else {
+		wm.menuCommand("Run", "RUN")
+	}
+
+	wm.menuSeparator()
+
+	wm.menuCommand("Step", "STEP")
+	wm.menuCommand("Step CPU Instruction", "STEP CPU")
+	wm.menuCommand("Step Video Cycle", "STEP VIDEO")
+	wm.menuCommand("Step Scanline", "STEP SCANLINE")
+	wm.menuCommand("Step Frame", "STEP FRAME")
+
+	wm.menuSeparator()
+
+	if imgui.BeginMenu("  Quantum") {
+		quantum := wm.img.lz.Debugger.Quantum
+		wm.menuCommandToggle("CPU", "QUANTUM CPU", quantum == debugger.QuantumCPU)
+		wm.menuCommandToggle("Video", "QUANTUM VIDEO", quantum == debugger.QuantumVideo)
+		imgui.EndMenu()
+	}
+
+	wm.menuSeparator()
+
+	wm.menuCommand("Reset", "RESET")
+	wm.menuCommand("Rewind to Latest", "REWIND LAST")
+
+	imgui.EndMenu()
+}
+
+func (wm *windowManager) drawHaltMenu() {
+	if !imgui.BeginMenu(commandMenuHalt) {
+		return
+	}
+
+	wm.menuCommand(fmt.Sprintf("Break at PC (%#04x)", wm.img.lz.CPU.PC.Address()),
+		fmt.Sprintf("BREAK PC %#04x", wm.img.lz.CPU.PC.Address()))
+	wm.menuCommand("Trap New Frame", "TRAP FRAME")
+	wm.menuCommand("Trap New Scanline", "TRAP SCANLINE")
+	wm.menuCommand("Toggle Halt on Event", "EVENT HALT")
+
+	wm.menuSeparator()
+
+	// the output of the LIST command goes to the terminal so make sure it is
+	// open
+	if imgui.Selectable("  List All") {
+		wm.term.setOpen(true)
+		wm.img.term.pushCommand("LIST ALL")
+	}
+
+	if imgui.BeginMenu("  Clear") {
+		for _, c := range []string{"BREAKS", "TRAPS", "WATCHES", "TRACES", "LOGPOINTS", "ALL"} {
+			wm.menuCommand(fmt.Sprintf("%s%s", c[:1], strings.ToLower(c[1:])), fmt.Sprintf("CLEAR %s", c))
+		}
+		imgui.EndMenu()
+	}
+
+	imgui.EndMenu()
+}
+
+func (wm *windowManager) drawSessionMenu() {
+	if !imgui.BeginMenu(commandMenuSession) {
+		return
+	}
+
+	wm.drawMenuWindowEntry(wm.windows[winSelectROMTitle], "Insert Cartridge...")
+
+	// the output of the build command goes to the terminal so make sure it
+	// is open
+	if imgui.Selectable("  Build Cartridge") {
+		wm.term.setOpen(true)
+		wm.img.term.pushCommand("BUILD")
+	}
+	wm.menuCommand("Reload Cartridge", "RELOAD")
+
+	wm.menuSeparator()
+
+	if imgui.Selectable("  Save Session...") {
+		wm.sessionPopup = sessionSavePopup
+	}
+	if imgui.Selectable("  Load Session...") {
+		wm.sessionPopup = sessionLoadPopup
+	}
+
+	imgui.EndMenu()
+}
+
+// drawSessionPopup draws the modal popup that asks for the name of a session
+// to save or load.
+func (wm *windowManager) drawSessionPopup() {
+	if wm.sessionPopup == "" {
+		return
+	}
+
+	wm.img.hasModal = true
+
+	closePopup := func() {
+		imgui.CloseCurrentPopup()
+		wm.sessionPopup = ""
+		wm.img.hasModal = false
+	}
+
+	imgui.OpenPopup(wm.sessionPopup)
+	if imgui.BeginPopupModalV(wm.sessionPopup, nil, imgui.WindowFlagsAlwaysAutoResize) {
+		imgui.AlignTextToFramePadding()
+		imgui.Text("Name")
+		imgui.SameLine()
+
+		enter := imguiTextInput("##sessionName", true, 32, &wm.sessionName, true)
+
+		imgui.Spacing()
+
+		if len(wm.sessionName) > 0 {
+			op := "SAVE"
+			if wm.sessionPopup == sessionLoadPopup {
+				op = "LOAD"
+			}
+			if imgui.Button("OK") || enter {
+				wm.img.term.pushCommand(fmt.Sprintf("SESSION %s \"%s\"", op, wm.sessionName))
+				closePopup()
+			}
+			imgui.SameLine()
+		}
+
+		if imgui.Button("Cancel") {
+			closePopup()
+		}
+
+		imgui.EndPopup()
+	}
+}
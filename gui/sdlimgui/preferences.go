@@ -96,6 +96,85 @@ func newPreferences(img *SdlImgui, group prefGroup) (*Preferences, error) {
 		return nil, err
 	}
 
+	// the master volume is shared by the debugger and playmode
+	err = p.dsk.Add("sdlaudio.volume", &img.audio.Volume)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("sdlaudio.mute", &img.audio.Mute)
+	if err != nil {
+		return nil, err
+	}
+
+	// pausing on focus loss only happens in playmode but the preference can
+	// be changed in the debugger too
+	err = p.dsk.Add("sdlimgui.pauseOnFocusLoss", &img.wm.playScr.pauseOnFocusLoss)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Add(fmt.Sprintf("%s.statsHUD", group), &img.screen.statsHUD)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Add(fmt.Sprintf("%s.phosphor", group), &img.screen.phosphor)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add(fmt.Sprintf("%s.phosphorFrames", group), &img.screen.phosphorFrames)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add(fmt.Sprintf("%s.phosphorDecay", group), &img.screen.phosphorDecay)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add(fmt.Sprintf("%s.composite", group), &img.screen.composite)
+	if err != nil {
+		return nil, err
+	}
+
+	// the high contrast debug palette is only meaningful in the debugger
+	if group == prefsGrpDebugger {
+		err = p.dsk.Add(fmt.Sprintf("%s.highContrast", group), &img.screen.highContrast)
+		if err != nil {
+			return nil, err
+		}
+		err = p.dsk.Add(fmt.Sprintf("%s.actionSafe", group), &img.wm.dbgScr.actionSafe)
+		if err != nil {
+			return nil, err
+		}
+		err = p.dsk.Add(fmt.Sprintf("%s.titleSafe", group), &img.wm.dbgScr.titleSafe)
+		if err != nil {
+			return nil, err
+		}
+		err = p.dsk.Add(fmt.Sprintf("%s.audioScrubWindow", group), &img.audio.ScrubWindow)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// playmode screen rotation is only meaningful in playmode
+	if group == prefsGrpPlaymode {
+		err = p.dsk.Add(fmt.Sprintf("%s.rotation", group), &img.wm.playScr.rotation)
+		if err != nil {
+			return nil, err
+		}
+		err = p.dsk.Add(fmt.Sprintf("%s.inputDisplay", group), &img.wm.playScr.inputDisplay)
+		if err != nil {
+			return nil, err
+		}
+		err = p.dsk.Add(fmt.Sprintf("%s.displayFit", group), &img.wm.playScr.fit.method)
+		if err != nil {
+			return nil, err
+		}
+		err = p.dsk.Add(fmt.Sprintf("%s.borderColor", group), &img.wm.playScr.fit.border)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// load preferences from disk
 	err = p.dsk.Load(true)
 	if err != nil {
@@ -18,6 +18,7 @@ package sdlimgui
 import (
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/debugger"
+	"github.com/jetsetilly/gopher2600/eventbus"
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware"
 )
@@ -40,6 +41,9 @@ func (img *SdlImgui) serviceGetFeature(request featureRequest) {
 	case gui.ReqState:
 		img.featureGetData <- img.state
 		img.featureGetErr <- nil
+	case gui.ReqWindowLayout:
+		img.featureGetData <- img.wm.openWindows()
+		img.featureGetErr <- nil
 	default:
 		img.featureGetData <- nil
 		img.featureGetErr <- curated.Errorf(gui.UnsupportedGuiFeature, request.request)
@@ -108,6 +112,21 @@ func (img *SdlImgui) serviceSetFeature(request featureRequest) {
 	case gui.ReqSetScale:
 		img.setScale(request.args[0].(float32), false)
 
+	case gui.ReqSetInputDisplay:
+		err = img.wm.playScr.inputDisplay.Set(request.args[0].(bool))
+
+	case gui.ReqToggleInputDisplay:
+		err = img.wm.playScr.inputDisplay.Set(!img.wm.playScr.inputDisplay.Get().(bool))
+
+	case gui.ReqSetRotation:
+		err = img.wm.playScr.setRotation(request.args[0].(int))
+
+	case gui.ReqSetDisplayFit:
+		err = img.wm.playScr.setDisplayFit(request.args[0].(string))
+
+	case gui.ReqSetBorderColor:
+		err = img.wm.playScr.setBorderColor(request.args[0].(string))
+
 	case gui.ReqAddVCS:
 		img.vcs = request.args[0].(*hardware.VCS)
 
@@ -129,9 +148,21 @@ func (img *SdlImgui) serviceSetFeature(request featureRequest) {
 		// lazyvalues.Reset() function commentary for why)
 		img.lz.Reset(request.args[0].(bool))
 
+	case gui.ReqWindowLayout:
+		img.wm.setOpenWindows(request.args[0].([]string))
+
 	case gui.ReqPlusROMFirstInstallation:
 		img.plusROMFirstInstallation = request.args[0].(*gui.PlusROMFirstInstallation)
 
+	case gui.ReqEmulationEvent:
+		img.wm.playScr.flash(request.args[0].(eventbus.Notification))
+
+	case gui.ReqScreenshot:
+		err = img.wm.playScr.screenshot(request.args[0].(string))
+
+	case gui.ReqPlaymodeNotification:
+		img.wm.playScr.notify(request.args[0].(string))
+
 	default:
 		err = curated.Errorf(gui.UnsupportedGuiFeature, request.request)
 	}
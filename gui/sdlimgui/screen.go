@@ -16,13 +16,18 @@
 package sdlimgui
 
 import (
+	"bytes"
 	"image"
 	"image/color"
 	"sync"
 
 	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/phosphor"
 	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/prefs"
 	"github.com/jetsetilly/gopher2600/reflection"
 )
 
@@ -30,6 +35,11 @@ import (
 // "pixels" of two pixels across.
 const pixelWidth = 2
 
+// the number of pixels at the right edge of the screen used to mark scanlines
+// on which an audio register was written to. the right edge is visible in both
+// the cropped and uncropped views of the debug screen.
+const audioMarkerWidth = 8
+
 // textureRenderers can share the underlying pixels of the screen type instance.
 type textureRenderers interface {
 	render()
@@ -52,6 +62,24 @@ type screen struct {
 	// read/write by the GUI thread so doesn't need to be in critical section.
 	gotoCoordsX int
 	gotoCoordsY int
+
+	// use the high contrast palettes for debug colors and overlays. changes
+	// are reflected in the critical section by the preference callback.
+	highContrast prefs.Bool
+
+	// show the frame statistics HUD over the play and debug screens. changes
+	// are reflected in the critical section by the preference callback.
+	statsHUD prefs.Bool
+
+	// phosphor persistence. changes are reflected in the critical section by
+	// the preference callbacks.
+	phosphor       prefs.Bool
+	phosphorFrames prefs.Int
+	phosphorDecay  prefs.Float
+
+	// emulate the artifacts of an NTSC composite video connection. changes
+	// are reflected in the critical section by the preference callback.
+	composite prefs.Bool
 }
 
 // for clarity, variables accessed in the critical section are encapsulated in
@@ -67,12 +95,19 @@ type screenCrit struct {
 	// whether the current frame was generated from a stable television state
 	isStable bool
 
+	// the interlace field of the frame being drawn and of the most recently
+	// completed frame. both are television.FieldNone unless the television
+	// has detected an interlaced image
+	field     television.Field
+	lastField television.Field
+
 	// current values for *playable* area of the screen
 	topScanline int
 	scanlines   int
 
 	// the pixels array is used in the presentation texture of the play and
-	// debug screen.
+	// debug screen. the height of the image is television.MaxScanlinesAbsolute
+	// so that scanlines beyond the specification's maximum are not lost.
 	pixels *image.RGBA
 
 	// backingPixels are what we plot pixels to while we wait for a frame to
@@ -80,16 +115,32 @@ type screenCrit struct {
 	backingPixels       *image.RGBA
 	backingPixelsUpdate bool
 
+	// a copy of the backing pixels for the previous frame. used by the "Frame
+	// Diff" overlay. only updated when that overlay is selected
+	prevPixels *image.RGBA
+
 	// element colors and overlay colors are only used in the debugger so we
 	// don't need to replicate the "backing pixels" idea.
 	elementPixels *image.RGBA
 	overlayPixels *image.RGBA
 
+	// the rows of pixels, elementPixels and overlayPixels that have changed.
+	// used to limit the amount of data uploaded to textures
+	pixelsDirty   dirtyRows
+	elementsDirty dirtyRows
+	overlayDirty  dirtyRows
+
 	// the selected overlay
 	overlay string
 
-	// 2d array of disasm entries. resized at the same time as overlayPixels resize
-	reflection [][]reflection.Reflection
+	// the palettes used for debug colors and for the overlay. set by the
+	// highContrast preference
+	paletteElements []color.RGBA
+	paletteEvents   map[string]color.RGBA
+
+	// reflection information for every pixel. cleared whenever the screen is
+	// resized
+	reflection reflection.Buffer
 
 	// the cropped view of the screen pixels. note that these instances are
 	// created through the SubImage() command and should not be written to
@@ -98,14 +149,80 @@ type screenCrit struct {
 	cropElementPixels *image.RGBA
 	cropOverlayPixels *image.RGBA
 
+	// the uncropped view of the screen pixels. the height of the image is the
+	// number of scanlines in the specification. as with the cropped view, the
+	// instances are created through SubImage()
+	uncropPixels        *image.RGBA
+	uncropElementPixels *image.RGBA
+	uncropOverlayPixels *image.RGBA
+
+	// the off-screen view of the screen pixels. this is the uncropped view
+	// extended to include any scanlines beyond the specification's maximum
+	// that have been seen since the last resize.
+	offscreenPixels        *image.RGBA
+	offscreenElementPixels *image.RGBA
+	offscreenOverlayPixels *image.RGBA
+
+	// the number of scanlines in the off-screen view and the highest scanline
+	// seen by SetPixel() since the last resize
+	offscreenScanlines int
+	maxScanline        int
+
+	// thumbnails of the most recent frames. see thumbnails type for details
+	thumbnails thumbnails
+
+	// banks active on each scanline and frame. see bankTrace type for details
+	bankTrace bankTrace
+
+	// frame pacing statistics. see frameStats type for details
+	stats frameStats
+
+	// mixes pixels with the previous frames. nil if phosphor persistence is
+	// disabled
+	phosphor *phosphor.Phosphor
+
+	// decodes each scanline as though it had been sent over a composite
+	// video connection. nil if composite emulation is disabled or if the
+	// current specification does not support it
+	composite *specification.Composite
+
+	// the color signals for the scanline currently being drawn. the scanline
+	// is decoded by the composite decoder once it has been completed
+	compositeLine     []signal.ColorSignal
+	compositeOut      []color.RGBA
+	compositeScanline int
+
+	// input state of the VCS sampled at the end of each frame. only sampled
+	// when inputDisplay is true
+	inputDisplay bool
+	input        inputState
+
 	// the coordinates of the last SetPixel(). used to help set the alpha
 	// channel when emulation is paused
 	lastX int
 	lastY int
+
+	// whether an audio register has been written to in the scanline currently
+	// being plotted by plotOverlay(). pixels are always plotted from left to
+	// right so the value is reset at the start of every scanline
+	audioScanline bool
 }
 
 func newScreen(img *SdlImgui) *screen {
 	scr := &screen{img: img}
+	scr.crit.thumbnails = newThumbnails()
+	scr.crit.bankTrace.clear()
+	scr.crit.pixelsDirty = newDirtyRows()
+	scr.crit.elementsDirty = newDirtyRows()
+	scr.crit.overlayDirty = newDirtyRows()
+
+	// the images are large enough for any specification and are allocated
+	// once. resize() creates sub-images of the appropriate size
+	scr.crit.pixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, television.MaxScanlinesAbsolute))
+	scr.crit.backingPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, television.MaxScanlinesAbsolute))
+	scr.crit.prevPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, television.MaxScanlinesAbsolute))
+	scr.crit.elementPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, television.MaxScanlinesAbsolute))
+	scr.crit.overlayPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, television.MaxScanlinesAbsolute))
 
 	// start off by showing entirity of NTSC screen
 	scr.resize(specification.SpecNTSC, specification.SpecNTSC.ScanlineTop, specification.SpecNTSC.ScanlinesVisible)
@@ -113,6 +230,67 @@ func newScreen(img *SdlImgui) *screen {
 	scr.crit.lastX = 0
 	scr.crit.lastY = 0
 	scr.crit.overlay = reflection.OverlayList[0]
+	scr.crit.paletteElements = reflection.PaletteElements
+	scr.crit.paletteEvents = reflection.PaletteEvents
+
+	scr.highContrast.RegisterCallback(func(v prefs.Value) error {
+		scr.crit.section.Lock()
+		defer scr.crit.section.Unlock()
+
+		if v.(bool) {
+			scr.crit.paletteElements = reflection.PaletteElementsHighContrast
+			scr.crit.paletteEvents = reflection.PaletteEventsHighContrast
+		} else {
+			scr.crit.paletteElements = reflection.PaletteElements
+			scr.crit.paletteEvents = reflection.PaletteEvents
+		}
+
+		scr.replotElements()
+		scr.replotOverlay()
+
+		return nil
+	})
+
+	scr.statsHUD.RegisterCallback(func(v prefs.Value) error {
+		scr.crit.section.Lock()
+		defer scr.crit.section.Unlock()
+
+		scr.crit.stats.enabled = v.(bool)
+		scr.crit.stats.clear()
+
+		return nil
+	})
+
+	// the phosphor is recreated whenever one of the preferences change
+	updatePhosphor := func(_ prefs.Value) error {
+		scr.crit.section.Lock()
+		defer scr.crit.section.Unlock()
+
+		scr.crit.phosphor = nil
+		if !scr.phosphor.Get().(bool) {
+			return nil
+		}
+
+		var err error
+		scr.crit.phosphor, err = phosphor.NewPhosphor(scr.phosphorFrames.Get().(int), scr.phosphorDecay.Get().(float64))
+		return err
+	}
+	scr.phosphor.RegisterCallback(updatePhosphor)
+	scr.phosphorFrames.RegisterCallback(updatePhosphor)
+	scr.phosphorDecay.RegisterCallback(updatePhosphor)
+
+	scr.crit.compositeLine = make([]signal.ColorSignal, specification.HorizClksScanline)
+	scr.crit.compositeOut = make([]color.RGBA, specification.HorizClksScanline)
+	scr.composite.RegisterCallback(func(_ prefs.Value) error {
+		scr.crit.section.Lock()
+		defer scr.crit.section.Unlock()
+		scr.updateComposite()
+		return nil
+	})
+
+	// default phosphor values are suitable for games that flicker at 30Hz
+	_ = scr.phosphorFrames.Set(phosphor.MinFrames)
+	_ = scr.phosphorDecay.Set(1.0)
 
 	return scr
 }
@@ -137,19 +315,24 @@ func (scr *screen) resize(spec specification.Spec, topScanline int, visibleScanl
 	scr.crit.topScanline = topScanline
 	scr.crit.scanlines = visibleScanlines
 
-	scr.crit.pixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, spec.ScanlinesTotal))
-	scr.crit.backingPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, spec.ScanlinesTotal))
-	scr.crit.elementPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, spec.ScanlinesTotal))
-	scr.crit.overlayPixels = image.NewRGBA(image.Rect(0, 0, specification.HorizClksScanline, spec.ScanlinesTotal))
+	// composite decoder depends on the specification
+	scr.updateComposite()
 
-	// allocate reflection info
-	scr.crit.reflection = make([][]reflection.Reflection, specification.HorizClksScanline)
-	for x := 0; x < specification.HorizClksScanline; x++ {
-		scr.crit.reflection[x] = make([]reflection.Reflection, spec.ScanlinesTotal)
-	}
+	// clear reflection info
+	scr.crit.reflection.Reset()
+
+	// create an uncropped image from the main
+	r := image.Rect(0, 0, specification.HorizClksScanline, spec.ScanlinesTotal)
+	scr.crit.uncropPixels = scr.crit.pixels.SubImage(r).(*image.RGBA)
+	scr.crit.uncropElementPixels = scr.crit.elementPixels.SubImage(r).(*image.RGBA)
+	scr.crit.uncropOverlayPixels = scr.crit.overlayPixels.SubImage(r).(*image.RGBA)
+
+	// the off-screen image starts off the same size as the uncropped image
+	scr.crit.maxScanline = 0
+	scr.setOffscreenScanlines(spec.ScanlinesTotal)
 
 	// create a cropped image from the main
-	r := image.Rect(
+	r = image.Rect(
 		specification.HorizClksHBlank, scr.crit.topScanline,
 		specification.HorizClksHBlank+specification.HorizClksVisible, scr.crit.topScanline+scr.crit.scanlines,
 	)
@@ -164,8 +347,12 @@ func (scr *screen) resize(spec specification.Spec, topScanline int, visibleScanl
 			scr.crit.elementPixels.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
 			scr.crit.overlayPixels.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
 			scr.crit.backingPixels.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
+			scr.crit.prevPixels.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
 		}
 	}
+	scr.crit.pixelsDirty.markAll()
+	scr.crit.elementsDirty.markAll()
+	scr.crit.overlayDirty.markAll()
 
 	// end critical section
 	scr.crit.section.Unlock()
@@ -193,13 +380,55 @@ func (scr *screen) Resize(spec specification.Spec, topScanline int, visibleScanl
 // NewFrame implements the television.PixelRenderer interface
 //
 // MUST NOT be called from the #mainthread.
-func (scr *screen) NewFrame(isStable bool) error {
+func (scr *screen) NewFrame(info television.FrameInfo) error {
 	scr.crit.section.Lock()
 	defer scr.crit.section.Unlock()
 
-	scr.crit.isStable = isStable
+	scr.crit.isStable = info.Stable
 	scr.crit.backingPixelsUpdate = true
 
+	// the final scanline of the frame will not have been decoded yet
+	if scr.crit.composite != nil {
+		scr.decodeComposite()
+	}
+
+	// NewField() will be called immediately after NewFrame() with the field of
+	// the next frame
+	scr.crit.lastField = scr.crit.field
+
+	// keep a copy of the completed frame for the "Frame Diff" overlay
+	if scr.crit.overlay == "Frame Diff" {
+		copy(scr.crit.prevPixels.Pix, scr.crit.backingPixels.Pix)
+	}
+
+	if scr.crit.thumbnails.enabled {
+		scr.crit.thumbnails.capture(scr.crit.backingPixels, scr.crit.cropPixels.Bounds(), info.FrameNum-1)
+	}
+
+	if scr.crit.stats.enabled {
+		scr.crit.stats.newFrame(scr.crit.spec.ScanlinesTotal)
+	}
+
+	if scr.crit.phosphor != nil {
+		scr.crit.phosphor.NewFrame()
+	}
+
+	if scr.crit.bankTrace.enabled {
+		scr.crit.bankTrace.newFrame(info.FrameNum - 1)
+	}
+
+	if scr.crit.inputDisplay && scr.img.vcs != nil {
+		scr.crit.input.sample(scr.img.vcs.Mem)
+	}
+
+	return nil
+}
+
+// NewField implements the television.FieldRenderer interface.
+func (scr *screen) NewField(field television.Field) error {
+	scr.crit.section.Lock()
+	defer scr.crit.section.Unlock()
+	scr.crit.field = field
 	return nil
 }
 
@@ -225,20 +454,85 @@ func (scr *screen) SetPixel(sig signal.SignalAttributes, current bool) error {
 	col := color.RGBA{R: 0, G: 0, B: 0, A: 255}
 
 	// handle VBLANK by setting pixels to black
-	if !sig.VBlank {
-		col = scr.crit.spec.GetColor(sig.Pixel)
+	if !sig.VBlank() {
+		col = scr.crit.spec.GetColor(sig.Pixel())
 	}
 
 	if current {
-		scr.crit.lastX = sig.HorizPos
-		scr.crit.lastY = sig.Scanline
+		scr.crit.lastX = sig.HorizPos()
+		scr.crit.lastY = sig.Scanline()
 	}
 
-	scr.crit.backingPixels.SetRGBA(sig.HorizPos, sig.Scanline, col)
+	if sig.Scanline() > scr.crit.maxScanline {
+		scr.crit.maxScanline = sig.Scanline()
+	}
+
+	if scr.crit.stats.enabled {
+		scr.crit.stats.pixel(sig.Scanline())
+	}
+
+	// the pixel is drawn immediately with the palette color. the scanline is
+	// redrawn by the composite decoder once it has been completed
+	if scr.crit.composite != nil {
+		if sig.Scanline() != scr.crit.compositeScanline {
+			scr.decodeComposite()
+			scr.crit.compositeScanline = sig.Scanline()
+		}
+		if sig.VBlank() {
+			scr.crit.compositeLine[sig.HorizPos()] = signal.VideoBlack
+		} else {
+			scr.crit.compositeLine[sig.HorizPos()] = sig.Pixel()
+		}
+	}
+
+	if scr.crit.phosphor != nil {
+		col = scr.crit.phosphor.SetPixel(sig.HorizPos(), sig.Scanline(), col)
+	}
+
+	scr.crit.backingPixels.SetRGBA(sig.HorizPos(), sig.Scanline(), col)
 
 	return nil
 }
 
+// updateComposite creates or removes the composite decoder according to the
+// composite preference and the current specification.
+//
+// Must be called from inside the critical section.
+func (scr *screen) updateComposite() {
+	scr.crit.composite = nil
+	if !scr.composite.Get().(bool) {
+		return
+	}
+
+	var err error
+	scr.crit.composite, err = scr.crit.spec.NewComposite()
+	if err != nil {
+		logger.Log("sdlimgui", err.Error())
+		return
+	}
+
+	for i := range scr.crit.compositeLine {
+		scr.crit.compositeLine[i] = signal.VideoBlack
+	}
+}
+
+// decodeComposite replaces the pixels of the most recently completed scanline
+// with the output of the composite decoder.
+//
+// Must be called from inside the critical section.
+func (scr *screen) decodeComposite() {
+	y := scr.crit.compositeScanline
+	scr.crit.composite.Scanline(scr.crit.compositeLine, scr.crit.compositeOut)
+
+	for x, col := range scr.crit.compositeOut {
+		if scr.crit.phosphor != nil {
+			col = scr.crit.phosphor.SetPixel(x, y, col)
+		}
+		scr.crit.backingPixels.SetRGBA(x, y, col)
+		scr.crit.compositeLine[x] = signal.VideoBlack
+	}
+}
+
 // Reset implements the television.PixelRenderer interface.
 func (scr *screen) Reset() {
 	scr.crit.section.Lock()
@@ -252,6 +546,14 @@ func (scr *screen) Reset() {
 		scr.crit.backingPixels.Pix[i+3] = 255
 	}
 	scr.crit.backingPixelsUpdate = true
+
+	scr.crit.thumbnails.clear()
+	scr.crit.bankTrace.clear()
+	scr.crit.stats.clear()
+
+	if scr.crit.phosphor != nil {
+		scr.crit.phosphor.Reset()
+	}
 }
 
 // EndRendering implements the television.PixelRenderer interface.
@@ -263,60 +565,119 @@ func (scr *screen) EndRendering() error {
 //
 // Must only be called between calls to UpdatingPixels(true) and UpdatingPixels(false).
 func (scr *screen) Reflect(ref reflection.Reflection) error {
-	x := ref.TV.HorizPos
-	y := ref.TV.Scanline
+	x := ref.TV.HorizPos()
+	y := ref.TV.Scanline()
 
 	// store Reflection instance
-	if x < len(scr.crit.reflection) && y < len(scr.crit.reflection[x]) {
-		scr.crit.reflection[x][y] = ref
-	}
+	scr.crit.reflection.Set(x, y, ref)
 
 	// set element pixel
-	rgb := reflection.PaletteElements[ref.VideoElement]
-	scr.crit.elementPixels.SetRGBA(x, y, rgb)
+	rgb := scr.crit.paletteElements[ref.VideoElement]
+	if scr.crit.elementPixels.RGBAAt(x, y) != rgb {
+		scr.crit.elementPixels.SetRGBA(x, y, rgb)
+		scr.crit.elementsDirty.mark(y)
+	}
 
 	// write to overlay
 	scr.plotOverlay(x, y, ref)
 
+	if scr.crit.bankTrace.enabled {
+		scr.crit.bankTrace.record(ref)
+	}
+
 	return nil
 }
 
+// replotElements should be called from within a scr.crit.section Lock().
+func (scr *screen) replotElements() {
+	for y := 0; y < scr.crit.elementPixels.Bounds().Size().Y; y++ {
+		for x := 0; x < scr.crit.elementPixels.Bounds().Size().X; x++ {
+			ref := scr.crit.reflection.Get(x, y)
+			scr.crit.elementPixels.SetRGBA(x, y, scr.crit.paletteElements[ref.VideoElement])
+		}
+	}
+}
+
 // replotOverlay should be called from within a scr.crit.section Lock().
 func (scr *screen) replotOverlay() {
 	for y := 0; y < scr.crit.overlayPixels.Bounds().Size().Y; y++ {
 		for x := 0; x < scr.crit.overlayPixels.Bounds().Size().X; x++ {
-			scr.plotOverlay(x, y, scr.crit.reflection[x][y])
+			scr.plotOverlay(x, y, scr.crit.reflection.Get(x, y))
 		}
 	}
 }
 
 // plotOverlay should be called from within a scr.crit.section Lock().
 func (scr *screen) plotOverlay(x, y int, ref reflection.Reflection) {
-	scr.crit.overlayPixels.SetRGBA(x, y, color.RGBA{0, 0, 0, 0})
+	col := color.RGBA{0, 0, 0, 0}
+
 	switch scr.crit.overlay {
 	case "WSYNC":
 		if ref.WSYNC {
-			scr.crit.overlayPixels.SetRGBA(x, y, reflection.PaletteEvents["WSYNC"])
+			col = scr.crit.paletteEvents["WSYNC"]
 		}
 	case "Collisions":
 		if ref.Collision != "" {
-			scr.crit.overlayPixels.SetRGBA(x, y, reflection.PaletteEvents["Collisions"])
+			col = scr.crit.paletteEvents["Collisions"]
 		}
 	case "HMOVE":
 		// HmoveCt counts to -1 (or 255 for a uint8)
 		if ref.Hmove.Delay {
-			scr.crit.overlayPixels.SetRGBA(x, y, reflection.PaletteEvents["HMOVE delay"])
+			col = scr.crit.paletteEvents["HMOVE delay"]
 		} else if ref.Hmove.Latch {
 			if ref.Hmove.RippleCt != 255 {
-				scr.crit.overlayPixels.SetRGBA(x, y, reflection.PaletteEvents["HMOVE"])
+				col = scr.crit.paletteEvents["HMOVE"]
 			} else {
-				scr.crit.overlayPixels.SetRGBA(x, y, reflection.PaletteEvents["HMOVE latched"])
+				col = scr.crit.paletteEvents["HMOVE latched"]
 			}
 		}
 	case "Unchanged":
 		if ref.Unchanged {
-			scr.crit.overlayPixels.SetRGBA(x, y, reflection.PaletteEvents["Unchanged"])
+			col = scr.crit.paletteEvents["Unchanged"]
 		}
+	case "Frame Diff":
+		if scr.crit.backingPixels.RGBAAt(x, y) != scr.crit.prevPixels.RGBAAt(x, y) {
+			col = scr.crit.paletteEvents["Frame Diff"]
+		}
+	case "Cycles":
+		// pixels with no CPU information have not been drawn this frame
+		if ref.CPU.Defn != nil || ref.WSYNC {
+			switch ref.Activity() {
+			case reflection.ActivityWork:
+				col = scr.crit.paletteEvents["Cycles work"]
+			case reflection.ActivityBusy:
+				col = scr.crit.paletteEvents["Cycles busy"]
+			case reflection.ActivityWSYNC:
+				col = scr.crit.paletteEvents["Cycles WSYNC"]
+			}
+		}
+	case "Audio":
+		if x == 0 {
+			scr.crit.audioScanline = false
+		}
+
+		w := scr.crit.overlayPixels.Bounds().Size().X
+		if ref.AudioWrite != "" {
+			col = scr.crit.paletteEvents["Audio"]
+
+			// if the write is inside the marker area then the marker pixels
+			// to the left of the write have already been plotted. plot them
+			// again now
+			if !scr.crit.audioScanline {
+				scr.crit.audioScanline = true
+				for mx := w - audioMarkerWidth; mx < x; mx++ {
+					scr.crit.overlayPixels.SetRGBA(mx, y, scr.crit.paletteEvents["Audio scanline"])
+				}
+				scr.crit.overlayDirty.mark(y)
+			}
+		} else if scr.crit.audioScanline && x >= w-audioMarkerWidth {
+			col = scr.crit.paletteEvents["Audio scanline"]
+		}
+	}
+
+	if scr.crit.overlayPixels.RGBAAt(x, y) != col {
+		scr.crit.overlayPixels.SetRGBA(x, y, col)
+		scr.crit.overlayDirty.mark(y)
 	}
 }
 
@@ -337,13 +698,140 @@ func (scr *screen) render() {
 	// critical section
 	scr.crit.section.Lock()
 	if scr.crit.backingPixelsUpdate {
-		copy(scr.crit.pixels.Pix, scr.crit.backingPixels.Pix)
+		// copy backing pixels one row at a time, noting which rows have
+		// changed
+		stride := scr.crit.pixels.Stride
+		for y := 0; y < scr.crit.pixels.Bounds().Size().Y; y++ {
+			dst := scr.crit.pixels.Pix[y*stride : (y+1)*stride]
+			src := scr.crit.backingPixels.Pix[y*stride : (y+1)*stride]
+			if !bytes.Equal(dst, src) {
+				copy(dst, src)
+				scr.crit.pixelsDirty.mark(y)
+			}
+		}
 		scr.crit.backingPixelsUpdate = false
 	}
+
+	if scr.crit.stats.enabled {
+		scr.crit.stats.render()
+	}
+
+	// grow the off-screen view if scanlines have been seen beyond the current
+	// extent of the view
+	resize := scr.crit.maxScanline >= scr.crit.offscreenScanlines
+	if resize {
+		scr.setOffscreenScanlines(scr.crit.maxScanline + 1)
+	}
+
 	scr.crit.section.Unlock()
 	// end of critical section
 
 	for _, r := range scr.renderers {
+		if resize {
+			r.resize()
+		}
 		r.render()
 	}
 }
+
+// setOffscreenScanlines should be called from within a scr.crit.section Lock().
+func (scr *screen) setOffscreenScanlines(scanlines int) {
+	if scanlines > television.MaxScanlinesAbsolute {
+		scanlines = television.MaxScanlinesAbsolute
+	}
+	scr.crit.offscreenScanlines = scanlines
+
+	r := image.Rect(0, 0, specification.HorizClksScanline, scanlines)
+	scr.crit.offscreenPixels = scr.crit.pixels.SubImage(r).(*image.RGBA)
+	scr.crit.offscreenElementPixels = scr.crit.elementPixels.SubImage(r).(*image.RGBA)
+	scr.crit.offscreenOverlayPixels = scr.crit.overlayPixels.SubImage(r).(*image.RGBA)
+}
+
+// the number of frames kept by the thumbnails type and the size of each
+// thumbnail.
+const (
+	numThumbnails   = 10
+	thumbnailWidth  = 40
+	thumbnailHeight = 50
+)
+
+// thumbnails keeps downscaled copies of the most recently completed frames.
+// all thumbnails are kept in a single image, side by side, so that they can
+// be uploaded to a single texture.
+//
+// thumbnails is only accessed from within a scr.crit.section Lock().
+type thumbnails struct {
+	// thumbnails are only captured when enabled is true
+	enabled bool
+
+	// the thumbnail images side by side. the position in the image does not
+	// relate to the order in which they were captured
+	pixels *image.RGBA
+
+	// frame number of each thumbnail. a value of -1 indicates that there is
+	// no thumbnail for that position yet
+	frames [numThumbnails]int
+
+	// the next position to capture to. this is also the position of the
+	// oldest thumbnail
+	next int
+
+	// pixels have been updated since the last upload to the texture
+	updated bool
+}
+
+func newThumbnails() thumbnails {
+	th := thumbnails{
+		pixels: image.NewRGBA(image.Rect(0, 0, thumbnailWidth*numThumbnails, thumbnailHeight)),
+	}
+	th.clear()
+	return th
+}
+
+// clear all thumbnails.
+func (th *thumbnails) clear() {
+	for i := range th.frames {
+		th.frames[i] = -1
+	}
+	for i := 0; i < len(th.pixels.Pix)-3; i += 4 {
+		th.pixels.Pix[i] = 0
+		th.pixels.Pix[i+1] = 0
+		th.pixels.Pix[i+2] = 0
+		th.pixels.Pix[i+3] = 255
+	}
+	th.next = 0
+	th.updated = true
+}
+
+// capture area of src as a new thumbnail using nearest neighbour sampling.
+func (th *thumbnails) capture(src *image.RGBA, area image.Rectangle, frame int) {
+	sz := area.Size()
+	ox := th.next * thumbnailWidth
+	for y := 0; y < thumbnailHeight; y++ {
+		sy := area.Min.Y + y*sz.Y/thumbnailHeight
+		for x := 0; x < thumbnailWidth; x++ {
+			sx := area.Min.X + x*sz.X/thumbnailWidth
+			th.pixels.SetRGBA(ox+x, y, src.RGBAAt(sx, sy))
+		}
+	}
+
+	th.frames[th.next] = frame
+	th.next++
+	if th.next >= numThumbnails {
+		th.next = 0
+	}
+	th.updated = true
+}
+
+// ordered returns the positions of the thumbnails in the order of capture.
+// oldest first.
+func (th *thumbnails) ordered() []int {
+	o := make([]int, 0, numThumbnails)
+	for i := 0; i < numThumbnails; i++ {
+		p := (th.next + i) % numThumbnails
+		if th.frames[p] != -1 {
+			o = append(o, p)
+		}
+	}
+	return o
+}
@@ -0,0 +1,122 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/reflection"
+)
+
+// the number of frames kept by the bankTrace type.
+const numBankTraceFrames = 300
+
+// the maximum number of banks that can be traced. banks with a higher number
+// are not recorded.
+const maxBankTraceBanks = 64
+
+// bankTrace records which cartridge banks are active on each scanline of the
+// most recent frame and on each of the most recent frames. the active banks
+// are recorded as a bit mask with bit zero representing bank zero.
+//
+// bankTrace is only accessed from within a scr.crit.section Lock().
+type bankTrace struct {
+	// banks are only recorded when enabled is true
+	enabled bool
+
+	// active banks for each scanline of the most recently completed frame
+	scanlines [television.MaxScanlinesAbsolute]uint64
+
+	// active banks for each scanline of the frame currently being drawn
+	current [television.MaxScanlinesAbsolute]uint64
+
+	// number of scanlines in the most recently completed frame
+	numScanlines int
+	maxScanline  int
+
+	// active banks for each frame and the corresponding frame number. a
+	// frame number of -1 indicates that there is no entry for that position
+	// yet
+	frames   [numBankTraceFrames]uint64
+	frameNum [numBankTraceFrames]int
+
+	// the next position in the frames array. this is also the position of the
+	// oldest entry
+	next int
+}
+
+// clear all recorded bank information.
+func (bt *bankTrace) clear() {
+	for i := range bt.scanlines {
+		bt.scanlines[i] = 0
+		bt.current[i] = 0
+	}
+	for i := range bt.frames {
+		bt.frames[i] = 0
+		bt.frameNum[i] = -1
+	}
+	bt.numScanlines = 0
+	bt.maxScanline = 0
+	bt.next = 0
+}
+
+// record the bank active for the reflected video cycle.
+func (bt *bankTrace) record(ref reflection.Reflection) {
+	if ref.Bank.NonCart || ref.Bank.Number < 0 || ref.Bank.Number >= maxBankTraceBanks {
+		return
+	}
+	if ref.TV.Scanline() < 0 || ref.TV.Scanline() >= len(bt.current) {
+		return
+	}
+	bt.current[ref.TV.Scanline()] |= 1 << ref.Bank.Number
+	if ref.TV.Scanline() > bt.maxScanline {
+		bt.maxScanline = ref.TV.Scanline()
+	}
+}
+
+// newFrame completes the recording of the current frame.
+func (bt *bankTrace) newFrame(frame int) {
+	var banks uint64
+	for i := 0; i <= bt.maxScanline; i++ {
+		banks |= bt.current[i]
+	}
+	copy(bt.scanlines[:], bt.current[:])
+	bt.numScanlines = bt.maxScanline + 1
+
+	for i := range bt.current {
+		bt.current[i] = 0
+	}
+	bt.maxScanline = 0
+
+	bt.frames[bt.next] = banks
+	bt.frameNum[bt.next] = frame
+	bt.next++
+	if bt.next >= numBankTraceFrames {
+		bt.next = 0
+	}
+}
+
+// ordered returns the positions of the recorded frames in the order of
+// capture. oldest first.
+func (bt *bankTrace) ordered() []int {
+	o := make([]int, 0, numBankTraceFrames)
+	for i := 0; i < numBankTraceFrames; i++ {
+		p := (bt.next + i) % numBankTraceFrames
+		if bt.frameNum[p] != -1 {
+			o = append(o, p)
+		}
+	}
+	return o
+}
@@ -0,0 +1,116 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"image"
+
+	"github.com/go-gl/gl/v3.2-core/gl"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// dirtyRows records which rows of a screen image have changed. uploading an
+// entire frame to a texture every GUI frame is wasteful when, as is often the
+// case, only a few scanlines have changed (or none at all when the emulation
+// is paused).
+//
+// every row is stamped with the generation in which it was last changed. the
+// generation is advanced every time a texture is uploaded. a texture that was
+// uploaded in an earlier generation needs only those rows with a later stamp.
+//
+// dirtyRows is only accessed from within a scr.crit.section Lock().
+type dirtyRows struct {
+	gen  uint32
+	rows [television.MaxScanlinesAbsolute]uint32
+}
+
+func newDirtyRows() dirtyRows {
+	return dirtyRows{gen: 1}
+}
+
+// mark row as having changed in the current generation.
+func (d *dirtyRows) mark(y int) {
+	if y >= 0 && y < len(d.rows) {
+		d.rows[y] = d.gen
+	}
+}
+
+// markAll rows as having changed in the current generation.
+func (d *dirtyRows) markAll() {
+	for y := range d.rows {
+		d.rows[y] = d.gen
+	}
+}
+
+// textureUpload records what was most recently uploaded to a texture.
+type textureUpload struct {
+	img *image.RGBA
+	gen uint32
+}
+
+// invalidate forces the next call to dirtyRows.upload() to upload the entire
+// image.
+func (u *textureUpload) invalidate() {
+	u.img = nil
+}
+
+// upload the rows of img that have changed since the previous upload to the
+// texture currently bound to gl.TEXTURE_2D. contiguous rows are uploaded
+// together. the entire image is uploaded if it is not the same image that
+// was previously uploaded.
+//
+// the texture must have been created with the same dimensions as img.
+func (d *dirtyRows) upload(img *image.RGBA, u *textureUpload) {
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(img.Stride)/4)
+	defer gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+
+	b := img.Bounds()
+	w := int32(b.Size().X)
+
+	if u.img != img {
+		gl.TexSubImage2D(gl.TEXTURE_2D, 0,
+			0, 0, w, int32(b.Size().Y),
+			gl.RGBA, gl.UNSIGNED_BYTE,
+			gl.Ptr(img.Pix))
+		u.img = img
+		u.gen = d.gen
+		d.gen++
+		return
+	}
+
+	y := b.Min.Y
+	for y < b.Max.Y {
+		if d.rows[y] <= u.gen {
+			y++
+			continue
+		}
+
+		// find end of contiguous run of changed rows
+		top := y
+		for y < b.Max.Y && d.rows[y] > u.gen {
+			y++
+		}
+
+		gl.TexSubImage2D(gl.TEXTURE_2D, 0,
+			0, int32(top-b.Min.Y), w, int32(y-top),
+			gl.RGBA, gl.UNSIGNED_BYTE,
+			gl.Ptr(img.Pix[(top-b.Min.Y)*img.Stride:]))
+	}
+
+	// rows changed after this point will be stamped with a later generation
+	u.gen = d.gen
+	d.gen++
+}
@@ -0,0 +1,53 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+)
+
+// inputState is a copy of the VCS registers that reflect the state of the
+// player 0 joystick and the panel. it is sampled at the end of every frame
+// and is used by the playmode input display.
+//
+// inputState is only accessed from within a scr.crit.section Lock().
+type inputState struct {
+	swcha uint8
+	swchb uint8
+	inpt4 uint8
+}
+
+func (inp *inputState) sample(mem *memory.Memory) {
+	inp.swcha, _ = mem.Peek(addresses.ReadAddress["SWCHA"])
+	inp.swchb, _ = mem.Peek(addresses.ReadAddress["SWCHB"])
+	inp.inpt4, _ = mem.Peek(addresses.ReadAddress["INPT4"])
+}
+
+// the joystick directions and fire button are active low.
+func (inp inputState) up() bool    { return inp.swcha&0x10 == 0x00 }
+func (inp inputState) down() bool  { return inp.swcha&0x20 == 0x00 }
+func (inp inputState) left() bool  { return inp.swcha&0x40 == 0x00 }
+func (inp inputState) right() bool { return inp.swcha&0x80 == 0x00 }
+func (inp inputState) fire() bool  { return inp.inpt4&0x80 == 0x00 }
+
+// the reset and select switches are active low. the color and difficulty
+// switches are high for color and for the "A" (or pro) setting respectively.
+func (inp inputState) reset() bool { return inp.swchb&0x01 == 0x00 }
+func (inp inputState) sel() bool   { return inp.swchb&0x02 == 0x00 }
+func (inp inputState) color() bool { return inp.swchb&0x08 == 0x08 }
+func (inp inputState) p0Pro() bool { return inp.swchb&0x40 == 0x40 }
+func (inp inputState) p1Pro() bool { return inp.swchb&0x80 == 0x80 }
@@ -64,6 +64,16 @@ func (img *SdlImgui) Service() {
 					}
 				}
 
+			case *sdl.WindowEvent:
+				if img.isPlaymode() {
+					switch ev.Event {
+					case sdl.WINDOWEVENT_FOCUS_LOST:
+						img.wm.playScr.focusLost()
+					case sdl.WINDOWEVENT_FOCUS_GAINED:
+						img.wm.playScr.focusGained()
+					}
+				}
+
 			case *sdl.TextInputEvent:
 				if img.hasModal || !img.isCaptured() {
 					img.io.AddInputCharacters(string(ev.Text[:]))
@@ -78,6 +88,8 @@ func (img *SdlImgui) Service() {
 					if ev.Type == sdl.KEYUP && ev.Repeat == 0 {
 						if img.isCaptured() {
 							img.setCapture(false)
+						} else if img.isPlaymode() {
+							img.wm.playScr.setPauseMenu(!img.wm.playScr.pause.open)
 						} else if img.state == gui.StatePaused {
 							img.term.pushCommand("RUN")
 						} else {
@@ -86,7 +98,13 @@ func (img *SdlImgui) Service() {
 					}
 
 				default:
-					if !img.hasModal && (img.isPlaymode() || img.isCaptured()) {
+					if img.isPlaymode() && img.wm.playScr.pause.open {
+						// the pause menu has the keyboard. input is not
+						// forwarded to the emulation
+						if ev.Type == sdl.KEYDOWN && ev.Repeat == 0 {
+							img.wm.playScr.pauseMenuKey(sdl.GetKeyName(ev.Keysym.Sym))
+						}
+					} else if !img.hasModal && (img.isPlaymode() || img.isCaptured()) {
 						mod := gui.KeyModNone
 
 						if sdl.GetModState()&sdl.KMOD_LALT == sdl.KMOD_LALT ||
@@ -100,6 +118,10 @@ func (img *SdlImgui) Service() {
 							mod = gui.KeyModCtrl
 						}
 
+						if img.hotkey(sdl.GetKeyName(ev.Keysym.Sym), mod, ev.Type == sdl.KEYDOWN) {
+							break // switch
+						}
+
 						switch ev.Type {
 						case sdl.KEYDOWN:
 							if ev.Repeat == 0 {
@@ -197,6 +219,11 @@ func (img *SdlImgui) Service() {
 				x := float32(mx) / float32(w)
 				y := float32(my) / float32(h)
 
+				// adjust coordinates for the orientation of the playmode screen
+				if img.isPlaymode() {
+					x, y = img.wm.playScr.rotateMouse(x, y)
+				}
+
 				select {
 				case img.events <- gui.EventMouseMotion{X: x, Y: y}:
 				default:
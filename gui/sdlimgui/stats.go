@@ -0,0 +1,235 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+// the number of frames (and renders) over which frame statistics are
+// gathered.
+const numFrameStats = 60
+
+// if no frame has been completed for this long then the emulation is
+// considered to be stopped and no emulated frame rate is shown.
+const statsStopped = time.Second
+
+// frameStats gathers information about the pacing of frames. it is used to
+// diagnose performance problems on the host machine and to warn of frames
+// that are longer than the television specification allows, usually the
+// result of an overlong VBLANK or overscan.
+//
+// frameStats is only accessed from within a scr.crit.section Lock().
+type frameStats struct {
+	// statistics are only gathered when enabled is true
+	enabled bool
+
+	// the number of scanlines in, and the completion time of, the most recent
+	// frames. numFrames is the number of entries that are valid and
+	// nextFrame is the position of the oldest entry
+	scanlines [numFrameStats]int
+	frameTime [numFrameStats]time.Time
+	numFrames int
+	nextFrame int
+
+	// the highest scanline seen in the current frame
+	scanline int
+
+	// whether the most recent frame was longer than the specification
+	// allows and the number of such frames since the statistics were cleared
+	long       bool
+	longFrames int
+
+	// the time between the most recent renders of the screen
+	renderTime [numFrameStats]time.Duration
+	numRenders int
+	nextRender int
+	lastRender time.Time
+
+	// the number of frames completed since the last render. frames that are
+	// never rendered are counted as dropped
+	unrendered int
+	dropped    int
+}
+
+// clear all statistics.
+func (st *frameStats) clear() {
+	enabled := st.enabled
+	*st = frameStats{enabled: enabled}
+}
+
+// pixel should be called for every pixel sent to the screen.
+func (st *frameStats) pixel(scanline int) {
+	if scanline > st.scanline {
+		st.scanline = scanline
+	}
+}
+
+// newFrame should be called at the end of every frame. the total argument is
+// the number of scanlines allowed by the television specification.
+func (st *frameStats) newFrame(total int) {
+	n := st.scanline + 1
+	st.scanline = 0
+
+	st.long = n > total
+	if st.long {
+		st.longFrames++
+	}
+
+	st.scanlines[st.nextFrame] = n
+	st.frameTime[st.nextFrame] = time.Now()
+	st.nextFrame = (st.nextFrame + 1) % numFrameStats
+	if st.numFrames < numFrameStats {
+		st.numFrames++
+	}
+
+	st.unrendered++
+}
+
+// render should be called every time the screen is rendered by the GUI.
+func (st *frameStats) render() {
+	t := time.Now()
+	if !st.lastRender.IsZero() {
+		st.renderTime[st.nextRender] = t.Sub(st.lastRender)
+		st.nextRender = (st.nextRender + 1) % numFrameStats
+		if st.numRenders < numFrameStats {
+			st.numRenders++
+		}
+	}
+	st.lastRender = t
+
+	if st.unrendered > 1 {
+		st.dropped += st.unrendered - 1
+	}
+	st.unrendered = 0
+}
+
+// scanlineRange returns the number of scanlines in the most recent frame and
+// the smallest and largest number of scanlines over all recorded frames.
+func (st *frameStats) scanlineRange() (last int, min int, max int) {
+	if st.numFrames == 0 {
+		return 0, 0, 0
+	}
+
+	last = st.scanlines[(st.nextFrame+numFrameStats-1)%numFrameStats]
+	min = last
+	max = last
+	for i := 0; i < st.numFrames; i++ {
+		n := st.scanlines[(st.nextFrame+numFrameStats-1-i)%numFrameStats]
+		if n < min {
+			min = n
+		}
+		if n > max {
+			max = n
+		}
+	}
+
+	return last, min, max
+}
+
+// emulatedFPS returns the rate at which frames have been completed by the
+// emulation. returns false if the rate can not be measured.
+func (st *frameStats) emulatedFPS() (float32, bool) {
+	if st.numFrames < 2 {
+		return 0, false
+	}
+
+	newest := st.frameTime[(st.nextFrame+numFrameStats-1)%numFrameStats]
+	oldest := st.frameTime[(st.nextFrame+numFrameStats-st.numFrames)%numFrameStats]
+	if time.Since(newest) > statsStopped {
+		return 0, false
+	}
+
+	d := newest.Sub(oldest).Seconds()
+	if d <= 0 {
+		return 0, false
+	}
+
+	return float32(st.numFrames-1) / float32(d), true
+}
+
+// hostFrameTime returns the mean and the longest time between renders.
+func (st *frameStats) hostFrameTime() (time.Duration, time.Duration) {
+	if st.numRenders == 0 {
+		return 0, 0
+	}
+
+	var sum time.Duration
+	var max time.Duration
+	for i := 0; i < st.numRenders; i++ {
+		d := st.renderTime[i]
+		sum += d
+		if d > max {
+			max = d
+		}
+	}
+
+	return sum / time.Duration(st.numRenders), max
+}
+
+// dimensions of the statistics HUD.
+const statsHUDPadding = 8.0
+
+// drawStatsHUD draws the frame statistics in the top right corner of the area
+// described by min and max. the cursor position is preserved.
+func (img *SdlImgui) drawStatsHUD(st *frameStats, min imgui.Vec2, max imgui.Vec2) {
+	last, lo, hi := st.scanlineRange()
+	mean, longest := st.hostFrameTime()
+
+	fps := "-"
+	if f, ok := st.emulatedFPS(); ok {
+		fps = fmt.Sprintf("%.1f", f)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Scanlines: %d (%d to %d)", last, lo, hi),
+		fmt.Sprintf("Long frames: %d", st.longFrames),
+		fmt.Sprintf("Emulation: %s fps", fps),
+		fmt.Sprintf("Host frame: %.1fms (max %.1fms)", mean.Seconds()*1000, longest.Seconds()*1000),
+		fmt.Sprintf("Audio queue: %.0f%%", img.audio.QueueFill()*100),
+		fmt.Sprintf("Dropped frames: %d", st.dropped),
+	}
+
+	w := imguiGetFrameDim(lines[0], lines[1:]...).X
+	h := imgui.TextLineHeightWithSpacing() * float32(len(lines))
+	dim := imgui.Vec2{X: w + statsHUDPadding*2, Y: h + statsHUDPadding*2}
+
+	origin := imgui.Vec2{X: max.X - statsHUDPadding - dim.X, Y: min.Y + statsHUDPadding}
+
+	dl := imgui.WindowDrawList()
+	dl.AddRectFilledV(origin, origin.Plus(dim), imgui.PackedColorFromVec4(img.cols.StatsHUDBg), 4.0, imgui.DrawCornerFlagsAll)
+
+	cursor := imgui.CursorPos()
+	defer imgui.SetCursorPos(cursor)
+
+	imgui.SetCursorScreenPos(origin.Plus(imgui.Vec2{X: statsHUDPadding, Y: statsHUDPadding}))
+	imgui.BeginGroup()
+	for i, l := range lines {
+		// the scanline count and the number of long frames are shown in the
+		// warning color if the most recent frame was too long
+		if i <= 1 && st.long {
+			imgui.PushStyleColor(imgui.StyleColorText, img.cols.StatsHUDWarning)
+			imgui.Text(l)
+			imgui.PopStyleColor()
+		} else {
+			imgui.Text(l)
+		}
+	}
+	imgui.EndGroup()
+}
@@ -132,11 +132,10 @@ func (trm *term) TermRead(buffer []byte, prompt terminal.Prompt, events *termina
 			return 0, curated.Errorf(terminal.UserAbort)
 
 		case ev := <-events.RawEvents:
-			ev()
-
-		case ev := <-events.RawEventsReturn:
-			ev()
-			return 0, nil
+			ev.Fn()
+			if ev.Return {
+				return 0, nil
+			}
 
 		case ev := <-events.GuiEvents:
 			err := events.GuiEventHandler(ev)
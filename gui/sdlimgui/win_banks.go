@@ -0,0 +1,180 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+const winBanksTitle = "Bank Trace"
+
+type winBanks struct {
+	windowManagement
+
+	img *SdlImgui
+	scr *screen
+
+	active   imgui.PackedColor
+	inactive imgui.PackedColor
+}
+
+func newWinBanks(img *SdlImgui) (managedWindow, error) {
+	win := &winBanks{
+		img: img,
+		scr: img.screen,
+	}
+
+	return win, nil
+}
+
+func (win *winBanks) init() {
+	win.active = imgui.PackedColorFromVec4(win.img.cols.BankTraceActive)
+	win.inactive = imgui.PackedColorFromVec4(win.img.cols.BankTraceInactive)
+}
+
+func (win *winBanks) destroy() {
+}
+
+func (win *winBanks) id() string {
+	return winBanksTitle
+}
+
+// the size of each cell in the bank trace plots.
+const (
+	bankTraceCellWidth  = 2
+	bankTraceCellHeight = 8
+)
+
+func (win *winBanks) draw() {
+	win.scr.crit.section.Lock()
+	defer win.scr.crit.section.Unlock()
+
+	bt := &win.scr.crit.bankTrace
+
+	// banks are only traced while the window is open
+	if !win.open {
+		if bt.enabled {
+			bt.enabled = false
+			bt.clear()
+		}
+		return
+	}
+	bt.enabled = true
+
+	numBanks := win.img.lz.Cart.NumBanks
+	if numBanks > maxBankTraceBanks {
+		numBanks = maxBankTraceBanks
+	}
+
+	imgui.SetNextWindowPosV(imgui.Vec2{633, 358}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.BeginV(winBanksTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
+
+	imgui.Text("Scanlines (most recent frame)")
+	if x, ok := win.drawPlot(bt.scanlines[:bt.numScanlines], numBanks); ok {
+		imgui.BeginTooltip()
+		imgui.Text(fmt.Sprintf("Scanline %d", x))
+		imgui.Text(fmt.Sprintf("Banks: %s", bankList(bt.scanlines[x], numBanks)))
+		imgui.EndTooltip()
+	}
+
+	imgui.Spacing()
+
+	ordered := bt.ordered()
+	frames := make([]uint64, len(ordered))
+	for i, p := range ordered {
+		frames[i] = bt.frames[p]
+	}
+
+	imgui.Text(fmt.Sprintf("Frames (most recent %d)", numBankTraceFrames))
+	if x, ok := win.drawPlot(frames, numBanks); ok {
+		imgui.BeginTooltip()
+		imgui.Text(fmt.Sprintf("Frame %d", bt.frameNum[ordered[x]]))
+		imgui.Text(fmt.Sprintf("Banks: %s", bankList(frames[x], numBanks)))
+		imgui.EndTooltip()
+	}
+
+	imgui.End()
+}
+
+// drawPlot draws a plot of the bank masks. each column is a mask and each row
+// is a bank. the plot is always wide enough for the maximum number of
+// scanlines or frames.
+//
+// returns the index of the column under the mouse and true if the mouse is
+// hovering over the plot.
+func (win *winBanks) drawPlot(masks []uint64, numBanks int) (int, bool) {
+	cols := len(win.scr.crit.bankTrace.scanlines)
+	if numBankTraceFrames > cols {
+		cols = numBankTraceFrames
+	}
+
+	dl := imgui.WindowDrawList()
+	p := imgui.CursorScreenPos()
+
+	// bank numbers down the left hand side. a label is not drawn for every
+	// bank if the text is taller than the row. the labels are drawn with
+	// imgui.Text() so we need to restore the cursor position afterwards
+	labelWidth := imgui.CalcTextSize(fmt.Sprintf("%d", numBanks), false, 0).X + imgui.CurrentStyle().ItemSpacing().X
+	labelEvery := int(imgui.TextLineHeight())/bankTraceCellHeight + 1
+	for b := 0; b < numBanks; b += labelEvery {
+		imgui.SetCursorScreenPos(p.Plus(imgui.Vec2{0, float32(b * bankTraceCellHeight)}))
+		imgui.Text(fmt.Sprintf("%d", b))
+	}
+	imgui.SetCursorScreenPos(p)
+	p = p.Plus(imgui.Vec2{labelWidth, 0})
+
+	sz := imgui.Vec2{float32(cols * bankTraceCellWidth), float32(numBanks * bankTraceCellHeight)}
+	dl.AddRectFilled(p, p.Plus(sz), win.inactive)
+
+	for x, m := range masks {
+		for b := 0; b < numBanks; b++ {
+			if m&(1<<b) != 0 {
+				tl := p.Plus(imgui.Vec2{float32(x * bankTraceCellWidth), float32(b * bankTraceCellHeight)})
+				dl.AddRectFilled(tl, tl.Plus(imgui.Vec2{bankTraceCellWidth, bankTraceCellHeight}), win.active)
+			}
+		}
+	}
+
+	imgui.Dummy(sz.Plus(imgui.Vec2{labelWidth, 0}))
+
+	if !imgui.IsItemHovered() {
+		return 0, false
+	}
+
+	x := int(imgui.MousePos().Minus(p).X) / bankTraceCellWidth
+	if x < 0 || x >= len(masks) {
+		return 0, false
+	}
+
+	return x, true
+}
+
+// bankList returns a list of the banks in the mask as a string.
+func bankList(mask uint64, numBanks int) string {
+	s := make([]string, 0, numBanks)
+	for b := 0; b < numBanks; b++ {
+		if mask&(1<<b) != 0 {
+			s = append(s, fmt.Sprintf("%d", b))
+		}
+	}
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,180 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/busactivity"
+)
+
+const winBusActivityTitle = "Bus Activity"
+
+type winBusActivity struct {
+	windowManagement
+
+	img *SdlImgui
+
+	// the most recent cycle seen by the window. used to decide whether the
+	// list should be scrolled to the end
+	last busactivity.Cycle
+}
+
+func newWinBusActivity(img *SdlImgui) (managedWindow, error) {
+	win := &winBusActivity{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winBusActivity) init() {
+}
+
+func (win *winBusActivity) destroy() {
+}
+
+func (win *winBusActivity) id() string {
+	return winBusActivityTitle
+}
+
+func (win *winBusActivity) draw() {
+	if !win.open {
+		return
+	}
+
+	win.img.lz.BusActivity.Demand()
+
+	imgui.SetNextWindowPosV(imgui.Vec2{632, 390}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.SetNextWindowSizeV(imgui.Vec2{520, 300}, imgui.ConditionFirstUseEver)
+	imgui.BeginV(winBusActivityTitle, &win.open, imgui.WindowFlagsNone)
+
+	enabled := win.img.lz.BusActivity.Enabled
+	if imgui.Checkbox("Record", &enabled) {
+		if enabled {
+			win.img.term.pushCommand("BUS ON")
+		} else {
+			win.img.term.pushCommand("BUS OFF")
+		}
+	}
+	imgui.SameLine()
+	if imgui.Button("Clear") {
+		win.img.term.pushCommand("BUS CLEAR")
+	}
+
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+
+	history := win.img.lz.BusActivity.History
+	if len(history) == 0 {
+		if enabled {
+			imgui.Text("No bus activity recorded")
+		} else {
+			imgui.Text("Bus activity is not being recorded")
+		}
+		imgui.End()
+		return
+	}
+
+	imgui.ColumnsV(5, "##busactivityheader", false)
+	win.header()
+	imgui.Columns()
+	imgui.Separator()
+
+	imgui.BeginChildV("##busactivitylist", imgui.Vec2{0, 0}, false, 0)
+	imgui.ColumnsV(5, "##busactivity", false)
+
+	for i, c := range history {
+		// the most recent cycle is highlighted
+		if i == len(history)-1 {
+			imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.DisasmCPUstep)
+		}
+
+		if c.Halted > 0 {
+			imgui.Text("")
+			imgui.NextColumn()
+			imgui.Text("")
+			imgui.NextColumn()
+			imgui.Text("")
+			imgui.NextColumn()
+			imgui.Text(fmt.Sprintf("halted for %d cycles", c.Halted))
+			imgui.NextColumn()
+			imgui.Text("RDY low")
+			imgui.NextColumn()
+		} else {
+			if c.Access {
+				imgui.Text(fmt.Sprintf("%04x", c.Address))
+				imgui.NextColumn()
+				imgui.Text(fmt.Sprintf("%02x", c.Data))
+				imgui.NextColumn()
+				imgui.Text(c.RW())
+				imgui.NextColumn()
+				imgui.Text(c.Chip())
+				imgui.NextColumn()
+			} else {
+				imgui.Text("-")
+				imgui.NextColumn()
+				imgui.Text("-")
+				imgui.NextColumn()
+				imgui.Text("-")
+				imgui.NextColumn()
+				imgui.Text("internal")
+				imgui.NextColumn()
+			}
+			if c.Mnemonic != "" {
+				imgui.Text(fmt.Sprintf("%#04x %s (%d)", c.PC, c.Mnemonic, c.Cycle))
+			} else {
+				imgui.Text(fmt.Sprintf("%#04x", c.PC))
+			}
+			imgui.NextColumn()
+		}
+
+		if i == len(history)-1 {
+			imgui.PopStyleColor()
+		}
+
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("frame %d, scanline %d, clock %d", c.Frame, c.Scanline, c.Clock))
+		}
+	}
+
+	imgui.Columns()
+
+	// scroll to the end of the list if there has been new activity
+	if history[len(history)-1] != win.last {
+		win.last = history[len(history)-1]
+		imgui.SetScrollHereY(1.0)
+	}
+
+	imgui.EndChild()
+
+	imgui.End()
+}
+
+func (win *winBusActivity) header() {
+	imgui.Text("Address")
+	imgui.NextColumn()
+	imgui.Text("Data")
+	imgui.NextColumn()
+	imgui.Text("R/W")
+	imgui.NextColumn()
+	imgui.Text("Chip")
+	imgui.NextColumn()
+	imgui.Text("Instruction")
+	imgui.NextColumn()
+}
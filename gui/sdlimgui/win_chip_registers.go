@@ -59,6 +59,8 @@ func (win *winChipRegisters) draw() {
 		return
 	}
 
+	win.img.lz.ChipRegisters.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{653, 400}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.BeginV(winChipRegistersTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
@@ -106,7 +108,7 @@ func (win *winChipRegisters) drawChipRegister(label string, val uint8) {
 			panic(err)
 		}
 		win.img.lz.Dbg.PushRawEvent(func() {
-			err := win.img.lz.Dbg.VCS.Mem.Poke(addresses.ReadAddress[label], uint8(v))
+			err := win.img.lz.Dbg.PokeWithUndo(addresses.ReadAddress[label], uint8(v))
 			if err != nil {
 				panic(err)
 			}
@@ -123,7 +125,7 @@ func (win *winChipRegisters) drawChipRegisterBits(read uint8, reg string) {
 		if seq.rectFill(win.regBit) {
 			b := read ^ (0x80 >> i)
 			win.img.lz.Dbg.PushRawEvent(func() {
-				err := win.img.lz.Dbg.VCS.Mem.Poke(addresses.ReadAddress[reg], b)
+				err := win.img.lz.Dbg.PokeWithUndo(addresses.ReadAddress[reg], b)
 				if err != nil {
 					panic(err)
 				}
@@ -54,6 +54,8 @@ func (win *winCollisions) draw() {
 		return
 	}
 
+	win.img.lz.Collisions.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{623, 527}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.BeginV(winCollisionsTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
@@ -0,0 +1,163 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+const winColorPickerTitle = "Color Picker"
+
+type winColorPicker struct {
+	windowManagement
+
+	img *SdlImgui
+	scr *screen
+
+	// the pixel being inspected. the values will be updated whenever the mouse
+	// is hovering over the debugging TV screen
+	horizPos int
+	scanline int
+}
+
+func newWinColorPicker(img *SdlImgui) (managedWindow, error) {
+	win := &winColorPicker{
+		img:      img,
+		scr:      img.screen,
+		horizPos: specification.HorizClksHBlank + specification.HorizClksVisible/2,
+		scanline: specification.SpecNTSC.ScanlineTop + specification.SpecNTSC.ScanlinesVisible/2,
+	}
+
+	return win, nil
+}
+
+func (win *winColorPicker) init() {
+}
+
+func (win *winColorPicker) destroy() {
+}
+
+func (win *winColorPicker) id() string {
+	return winColorPickerTitle
+}
+
+// the size of the swatches in the palette grid.
+const colorPickerSwatch = 14
+
+func (win *winColorPicker) draw() {
+	if !win.open {
+		return
+	}
+
+	// follow the mouse if it is hovering over the debugging screen
+	if win.img.wm.dbgScr.isHovered {
+		win.horizPos = win.img.wm.dbgScr.mouseHorizPos
+		win.scanline = win.img.wm.dbgScr.mouseScanline
+	}
+
+	win.scr.crit.section.Lock()
+	ref := win.scr.crit.reflection.Get(win.horizPos, win.scanline)
+	spec := win.scr.crit.spec
+	win.scr.crit.section.Unlock()
+
+	col := ref.TV.Pixel()
+
+	imgui.SetNextWindowPosV(imgui.Vec2{640, 300}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.BeginV(winColorPickerTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
+
+	imguiText("Scanline:")
+	imguiText(fmt.Sprintf("%-4d", win.scanline))
+	imgui.SameLineV(0, 15)
+	imguiText("Horiz Pos:")
+	imguiText(fmt.Sprintf("%-4d", win.horizPos-specification.HorizClksHBlank))
+
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+
+	// the television shows black during HBLANK and VBLANK regardless of the
+	// color signal
+	if ref.Hblank || ref.TV.VBlank() || col == signal.VideoBlack {
+		imgui.Text("No color signal")
+		if ref.Hblank {
+			imgui.SameLine()
+			imgui.Text("[HBLANK]")
+		} else if ref.TV.VBlank() {
+			imgui.SameLine()
+			imgui.Text("[VBLANK]")
+		}
+	} else {
+		rgb := spec.GetColor(col)
+		imgui.Text(fmt.Sprintf("%s %s", spec.ID, col))
+		imgui.Text(fmt.Sprintf("Hue: %d", col.Hue()))
+		imgui.SameLineV(0, 15)
+		imgui.Text(fmt.Sprintf("Luminance: %d", col.Luminance()))
+		imgui.SameLineV(0, 15)
+		imgui.Text(fmt.Sprintf("RGB: #%02x%02x%02x", rgb.R, rgb.G, rgb.B))
+	}
+
+	imgui.Spacing()
+	win.drawPalette(spec, col)
+
+	imgui.End()
+}
+
+// drawPalette draws every color in the palette, one hue per row and one
+// luminance per column. the selected color is outlined.
+func (win *winColorPicker) drawPalette(spec specification.Spec, selected signal.ColorSignal) {
+	pal := spec.Palette()
+
+	dl := imgui.WindowDrawList()
+	p := imgui.CursorScreenPos()
+
+	const numHues = 16
+	const numLums = 8
+	sz := imgui.Vec2{colorPickerSwatch, colorPickerSwatch}
+
+	for h := uint8(0); h < numHues; h++ {
+		for l := uint8(0); l < numLums; l++ {
+			c := signal.NewColorSignal(h, l)
+			tl := p.Plus(imgui.Vec2{float32(l) * colorPickerSwatch, float32(h) * colorPickerSwatch})
+			dl.AddRectFilled(tl, tl.Plus(sz), imgui.PackedColorFromVec4(imgui.Vec4{
+				X: float32(pal[c].R) / 255,
+				Y: float32(pal[c].G) / 255,
+				Z: float32(pal[c].B) / 255,
+				W: 1.0,
+			}))
+			if selected != signal.VideoBlack && h == selected.Hue() && l == selected.Luminance() {
+				dl.AddRect(tl, tl.Plus(sz), imgui.PackedColorFromVec4(win.img.cols.MagnifyCursor))
+			}
+		}
+	}
+
+	imgui.Dummy(imgui.Vec2{numLums * colorPickerSwatch, numHues * colorPickerSwatch})
+
+	// tooltip for the color under the mouse
+	if imgui.IsItemHovered() {
+		m := imgui.MousePos().Minus(p)
+		h := uint8(m.Y / colorPickerSwatch)
+		l := uint8(m.X / colorPickerSwatch)
+		if h < numHues && l < numLums {
+			imgui.BeginTooltip()
+			imgui.Text(signal.NewColorSignal(h, l).String())
+			imgui.EndTooltip()
+		}
+	}
+}
@@ -80,6 +80,12 @@ func (win *winControllers) draw() {
 	win.drawController(1)
 	imgui.EndGroup()
 
+	imgui.Spacing()
+	swap := win.img.lz.Prefs.SwapPorts
+	if imgui.Checkbox("Swap Ports (F7)", &swap) {
+		win.img.term.pushCommand("CONTROLLER SWAP")
+	}
+
 	imgui.End()
 }
 
@@ -56,6 +56,8 @@ func (win *winCPU) draw() {
 		return
 	}
 
+	win.img.lz.CPU.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{659, 35}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.BeginV(winCPUTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
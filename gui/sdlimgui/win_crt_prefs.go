@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-gl/gl/v3.2-core/gl"
 	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/gui/crt"
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
 	"github.com/jetsetilly/gopher2600/logger"
 )
@@ -98,7 +99,27 @@ func (win *winCRTPrefs) draw() {
 	imgui.BeginV(winCRTPrefsTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
 	imgui.BeginGroup()
+	win.drawSettings()
+	imgui.EndGroup()
+
+	imgui.SameLine()
 
+	imgui.BeginGroup()
+	imgui.Image(imgui.TextureID(win.crtTexture), imgui.Vec2{300, 300})
+	imgui.EndGroup()
+
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+
+	win.drawDiskButtons()
+
+	imgui.End()
+}
+
+// drawSettings draws all the CRT settings. it is also used by the CRT tab of
+// the preferences window.
+func (win *winCRTPrefs) drawSettings() {
 	win.drawGamma()
 
 	imgui.Spacing()
@@ -128,21 +149,10 @@ func (win *winCRTPrefs) draw() {
 
 	win.drawMaskScanlineScaling()
 
-	imgui.EndGroup()
-
-	imgui.SameLine()
-
-	imgui.BeginGroup()
-	imgui.Image(imgui.TextureID(win.crtTexture), imgui.Vec2{300, 300})
-	imgui.EndGroup()
-
 	imgui.Spacing()
-	imgui.Separator()
 	imgui.Spacing()
 
-	win.drawDiskButtons()
-
-	imgui.End()
+	win.drawColorDeficiency()
 }
 
 func (win *winCRTPrefs) drawGamma() {
@@ -186,6 +196,28 @@ func (win *winCRTPrefs) drawMaskScanlineScaling() {
 	}
 }
 
+func (win *winCRTPrefs) drawColorDeficiency() {
+	d := crt.ColorDeficiency(win.img.crtPrefs.ColorDeficiency.Get().(int))
+	if imgui.BeginCombo("Color Filter##colordeficiency", d.String()) {
+		for _, v := range []crt.ColorDeficiency{
+			crt.ColorDeficiencyNone,
+			crt.ColorDeficiencyProtanopia,
+			crt.ColorDeficiencyDeuteranopia,
+			crt.ColorDeficiencyTritanopia,
+		} {
+			if imgui.Selectable(v.String()) {
+				win.img.crtPrefs.ColorDeficiency.Set(int(v))
+			}
+		}
+		imgui.EndCombo()
+	}
+
+	b := win.img.crtPrefs.ColorCorrection.Get().(bool)
+	if imgui.Checkbox("Correct (rather than simulate)##colorcorrection", &b) {
+		win.img.crtPrefs.ColorCorrection.Set(b)
+	}
+}
+
 func (win *winCRTPrefs) drawNoise() {
 	b := win.img.crtPrefs.Noise.Get().(bool)
 	if imgui.Checkbox("Noise##noise", &b) {
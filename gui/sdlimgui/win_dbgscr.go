@@ -23,8 +23,13 @@ import (
 	"github.com/inkyblackness/imgui-go/v2"
 	"github.com/jetsetilly/gopher2600/disassembly"
 	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/hardware/tia/video"
+	"github.com/jetsetilly/gopher2600/prefs"
 	"github.com/jetsetilly/gopher2600/reflection"
+	"github.com/jetsetilly/gopher2600/tutorial"
 )
 
 const winDbgScrTitle = "TV Screen"
@@ -40,14 +45,42 @@ type winDbgScr struct {
 	cropped     bool
 	crt         bool
 	overlay     bool
+	rulers      bool
+
+	// show scanlines beyond the specification's maximum. only has an effect
+	// when the screen is not cropped
+	offscreen bool
+
+	// show thumbnails of the most recent frames
+	thumbnails bool
+
+	// show the action-safe and title-safe areas of the screen. the size of
+	// each area is the percentage of the visible screen
+	safeArea   bool
+	actionSafe prefs.Float
+	titleSafe  prefs.Float
+
+	// label the parts of the frame and annotate the effect of the most
+	// recent instruction. the bands are recalculated every draw() while the
+	// tutorial is enabled
+	tutorial      bool
+	tutorialBands []tutorial.Band
+
+	// the result of the most recent PNG export. shown in the tool bar
+	exportResult string
 
 	// textures
-	screenTexture  uint32
-	overlayTexture uint32
+	screenTexture    uint32
+	overlayTexture   uint32
+	thumbnailTexture uint32
 
 	// (re)create textures on next render()
 	createTextures bool
 
+	// what was most recently uploaded to screenTexture and overlayTexture
+	screenUpload  textureUpload
+	overlayUpload textureUpload
+
 	// is screen currently pointed at
 	isHovered bool
 
@@ -61,6 +94,13 @@ type winDbgScr struct {
 	mouseHorizPos int
 	mouseScanline int
 
+	// the disassembly entry shown in the most recent tooltip. formatting the
+	// entry is relatively expensive so it is only reformatted when the bank or
+	// CPU result changes
+	tooltipEntry *disassembly.Entry
+	tooltipBank  mapper.BankInfo
+	tooltipCPU   execution.Result
+
 	// height of tool bar at bottom of window. valid after first frame.
 	toolBarHeight float32
 
@@ -96,6 +136,9 @@ func newWinDbgScr(img *SdlImgui) (managedWindow, error) {
 		cropped: true,
 	}
 
+	win.actionSafe.Set(defaultActionSafe)
+	win.titleSafe.Set(defaultTitleSafe)
+
 	// set texture, creation of textures will be done after every call to resize()
 	gl.ActiveTexture(gl.TEXTURE0)
 	gl.GenTextures(1, &win.screenTexture)
@@ -109,6 +152,12 @@ func newWinDbgScr(img *SdlImgui) (managedWindow, error) {
 	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
 	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
 
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.GenTextures(1, &win.thumbnailTexture)
+	gl.BindTexture(gl.TEXTURE_2D, win.thumbnailTexture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+
 	return win, nil
 }
 
@@ -233,6 +282,28 @@ func (win *winDbgScr) draw() {
 	// then imgui.IsItemHovered() is false by definition
 	win.isHovered = imgui.IsItemHovered()
 
+	// draw rulers over the screen image
+	if win.rulers {
+		win.drawRulers(mouseOrigin, w, h)
+	}
+
+	// draw safe area guides over the screen image
+	if win.safeArea {
+		win.drawSafeArea(mouseOrigin)
+	}
+
+	// label the parts of the frame
+	if win.tutorial {
+		win.tutorialBands = win.measureTutorialBands()
+		win.drawTutorial(mouseOrigin, h)
+	}
+
+	// draw frame statistics over the screen image. the critical section is
+	// already locked
+	if win.img.screen.statsHUD.Get().(bool) {
+		win.img.drawStatsHUD(&win.scr.crit.stats, mouseOrigin, mouseOrigin.Plus(imgui.Vec2{w, h}))
+	}
+
 	// draw tool tip
 	if win.isHovered {
 		win.drawReflectionTooltip(mouseOrigin)
@@ -269,6 +340,13 @@ func (win *winDbgScr) draw() {
 	imgui.SameLineV(0, 15)
 	imguiText("Frame:")
 	imguiText(fmt.Sprintf("%-4d", win.img.lz.TV.Frame))
+	if imgui.IsItemHovered() {
+		imgui.BeginTooltip()
+		imgui.Text(fmt.Sprintf("Last frame: %d scanlines", win.img.lz.TV.LastFrameScanlines))
+		imgui.Text(fmt.Sprintf("Visible: %d to %d", win.img.lz.TV.VisibleTop, win.img.lz.TV.VisibleBottom))
+		imgui.Text(fmt.Sprintf("Synced frames: %d", win.img.lz.TV.SyncedFrame))
+		imgui.EndTooltip()
+	}
 	imgui.SameLineV(0, 15)
 	imguiText("Scanline:")
 	imguiText(fmt.Sprintf("%-4d", win.img.lz.TV.Scanline))
@@ -301,8 +379,16 @@ func (win *winDbgScr) draw() {
 		win.setCropping(win.cropped)
 	}
 	imgui.SameLine()
+	if imgui.Checkbox("Off-screen", &win.offscreen) {
+		win.setOffscreen(win.offscreen)
+	}
+	imgui.SameLine()
 	imgui.Checkbox("CRT Effects", &win.crt)
 	imgui.SameLine()
+	imgui.Checkbox("Rulers", &win.rulers)
+	imgui.SameLine()
+	imgui.Checkbox("Safe Area", &win.safeArea)
+	imgui.SameLine()
 	imgui.Checkbox("Overlay", &win.overlay)
 	imgui.SameLine()
 	imgui.PushItemWidth(win.overlayComboDim.X)
@@ -317,6 +403,38 @@ func (win *winDbgScr) draw() {
 		imgui.EndCombo()
 	}
 	imgui.PopItemWidth()
+	imgui.SameLine()
+	imgui.Checkbox("Tutorial", &win.tutorial)
+	imgui.SameLine()
+	if imgui.Checkbox("Thumbnails", &win.thumbnails) {
+		win.scr.crit.thumbnails.enabled = win.thumbnails
+		if !win.thumbnails {
+			win.scr.crit.thumbnails.clear()
+		}
+	}
+	imgui.SameLine()
+	if imgui.Button("Export PNG") {
+		win.exportPNG()
+	}
+	if win.exportResult != "" {
+		imgui.SameLine()
+		imgui.Text(win.exportResult)
+	}
+
+	if win.safeArea {
+		imgui.Spacing()
+		win.drawSafeAreaSettings()
+	}
+
+	if win.tutorial {
+		imgui.Spacing()
+		win.drawTutorialAnnotations()
+	}
+
+	if win.thumbnails {
+		imgui.Spacing()
+		win.drawThumbnails()
+	}
 
 	// note height of tool bar
 	win.toolBarHeight = imgui.CursorPosY() - toolBarTop
@@ -324,6 +442,208 @@ func (win *winDbgScr) draw() {
 	imgui.End()
 }
 
+// the amount by which a thumbnail is enlarged when hovered over.
+const thumbnailTooltipScale = 4
+
+// drawThumbnails draws the thumbnails of the most recent frames in a strip.
+// clicking on a thumbnail will rewind to that frame if possible.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) drawThumbnails() {
+	th := &win.scr.crit.thumbnails
+
+	// the size of a thumbnail in the strip. the horizontal size takes into
+	// account the width of a color clock
+	sz := imgui.Vec2{thumbnailWidth * pixelWidth * win.scr.aspectBias, thumbnailHeight}
+
+	imgui.PushStyleVarVec2(imgui.StyleVarFramePadding, imgui.Vec2{0.0, 0.0})
+	defer imgui.PopStyleVar()
+
+	for i, p := range th.ordered() {
+		if i > 0 {
+			imgui.SameLine()
+		}
+
+		uv0 := imgui.Vec2{float32(p) / numThumbnails, 0.0}
+		uv1 := imgui.Vec2{float32(p+1) / numThumbnails, 1.0}
+		frame := th.frames[p]
+
+		imgui.PushID(fmt.Sprintf("thumbnail%d", i))
+		if imgui.ImageButtonV(imgui.TextureID(win.thumbnailTexture), sz, uv0, uv1, 0, win.img.cols.Transparent, imgui.Vec4{1.0, 1.0, 1.0, 1.0}) {
+			if frame >= win.img.lz.Rewind.Summary.Start && frame <= win.img.lz.Rewind.Summary.End {
+				win.img.lz.Dbg.PushRewind(frame, frame == win.img.lz.Rewind.Summary.End)
+			}
+		}
+		imgui.PopID()
+
+		if imgui.IsItemHovered() {
+			imgui.BeginTooltip()
+			imgui.Text(fmt.Sprintf("Frame: %d", frame))
+			imgui.ImageV(imgui.TextureID(win.thumbnailTexture), sz.Times(thumbnailTooltipScale), uv0, uv1,
+				imgui.Vec4{1.0, 1.0, 1.0, 1.0}, imgui.Vec4{0.0, 0.0, 0.0, 0.0})
+			imgui.EndTooltip()
+		}
+	}
+}
+
+// the spacing of ruler ticks and labels. values are in color clocks and
+// scanlines.
+const (
+	rulerTickHoriz  = 8
+	rulerLabelHoriz = 32
+	rulerTickVert   = 10
+	rulerLabelVert  = 50
+	rulerTickLen    = 4
+)
+
+// drawRulers draws the HBLANK/VBLANK regions, the current position of the
+// beam and numeric rulers along the top and left edges of the screen image.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) drawRulers(origin imgui.Vec2, w float32, h float32) {
+	dl := imgui.WindowDrawList()
+
+	// the size of a single color clock and scanline in screen pixels
+	cw := win.getScaling(true)
+	sh := win.getScaling(false)
+
+	// the full-frame coordinates of the top-left corner of the image
+	var ox, oy int
+	if win.cropped {
+		ox = specification.HorizClksHBlank
+		oy = win.scr.crit.topScanline
+	}
+
+	// transform full-frame coordinates to screen coordinates
+	toScreen := func(hp int, sl int) imgui.Vec2 {
+		return origin.Plus(imgui.Vec2{float32(hp-ox) * cw, float32(sl-oy) * sh})
+	}
+
+	// blanking regions are only visible in the uncropped image
+	if !win.cropped {
+		col := imgui.PackedColorFromVec4(win.img.cols.RulerBlank)
+		bot := win.scr.crit.topScanline + win.scr.crit.scanlines
+		dl.AddRectFilled(origin, toScreen(specification.HorizClksHBlank, 0).Plus(imgui.Vec2{0, h}), col)
+		dl.AddRectFilled(toScreen(specification.HorizClksHBlank, 0), toScreen(0, win.scr.crit.topScanline).Plus(imgui.Vec2{w, 0}), col)
+		dl.AddRectFilled(toScreen(specification.HorizClksHBlank, bot), origin.Plus(imgui.Vec2{w, h}), col)
+	}
+
+	// ticks and labels. we use imgui.Text() to draw the labels so we need to
+	// restore the cursor position afterwards
+	cursor := imgui.CursorScreenPos()
+	tick := imgui.PackedColorFromVec4(win.img.cols.RulerTick)
+	imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.RulerText)
+
+	// horizontal ticks are aligned with the visible portion of the scanline
+	// (ie. horizontal position zero is the first pixel after HBLANK)
+	for x := -specification.HorizClksHBlank + specification.HorizClksHBlank%rulerTickHoriz; x < specification.HorizClksVisible; x += rulerTickHoriz {
+		hp := x + specification.HorizClksHBlank
+		if hp < ox {
+			continue
+		}
+		p := toScreen(hp, oy)
+		l := float32(rulerTickLen)
+		if x%rulerLabelHoriz == 0 {
+			l *= 2
+			imgui.SetCursorScreenPos(p.Plus(imgui.Vec2{2, l}))
+			imgui.Text(fmt.Sprintf("%d", x))
+		}
+		dl.AddLine(p, p.Plus(imgui.Vec2{0, l}), tick)
+	}
+
+	for sl := oy - oy%rulerTickVert; sl < oy+int(h/sh); sl += rulerTickVert {
+		if sl < oy {
+			continue
+		}
+		p := toScreen(ox, sl)
+		l := float32(rulerTickLen)
+		if sl%rulerLabelVert == 0 {
+			l *= 2
+			imgui.SetCursorScreenPos(p.Plus(imgui.Vec2{l + 2, 0}))
+			imgui.Text(fmt.Sprintf("%d", sl))
+		}
+		dl.AddLine(p, p.Plus(imgui.Vec2{l, 0}), tick)
+	}
+
+	imgui.PopStyleColor()
+	imgui.SetCursorScreenPos(cursor)
+
+	// beam position is only interesting when the emulation is not running
+	if win.img.state != gui.StateRunning {
+		beam := imgui.PackedColorFromVec4(win.img.cols.RulerBeam)
+		p := toScreen(win.scr.crit.lastX, win.scr.crit.lastY)
+		if p.X >= origin.X && p.X <= origin.X+w {
+			dl.AddLine(imgui.Vec2{p.X, origin.Y}, imgui.Vec2{p.X, origin.Y + h}, beam)
+		}
+		if p.Y >= origin.Y && p.Y <= origin.Y+h {
+			dl.AddLine(imgui.Vec2{origin.X, p.Y}, imgui.Vec2{origin.X + w, p.Y}, beam)
+		}
+	}
+}
+
+// the traditional safe areas of a television picture. the values are the
+// proportion of the visible picture (in both dimensions) that is safe.
+const (
+	defaultActionSafe = 0.9
+	defaultTitleSafe  = 0.8
+)
+
+// drawSafeArea outlines the action-safe and title-safe areas of the visible
+// picture. picture elements outside of the action-safe area may not be
+// visible at all on a real television. text (eg. the title or score) should
+// be inside the title-safe area.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) drawSafeArea(origin imgui.Vec2) {
+	dl := imgui.WindowDrawList()
+
+	cw := win.getScaling(true)
+	sh := win.getScaling(false)
+
+	// the visible picture in screen coordinates
+	x := origin.X
+	y := origin.Y
+	if !win.cropped {
+		x += float32(specification.HorizClksHBlank) * cw
+		y += float32(win.scr.crit.topScanline) * sh
+	}
+	w := float32(specification.HorizClksVisible) * cw
+	h := float32(win.scr.crit.scanlines) * sh
+
+	outline := func(pct float64, col imgui.Vec4) {
+		ix := w * (1.0 - float32(pct)) / 2
+		iy := h * (1.0 - float32(pct)) / 2
+		dl.AddRect(imgui.Vec2{x + ix, y + iy}, imgui.Vec2{x + w - ix, y + h - iy}, imgui.PackedColorFromVec4(col))
+	}
+
+	outline(win.actionSafe.Get().(float64), win.img.cols.SafeAreaAction)
+	outline(win.titleSafe.Get().(float64), win.img.cols.SafeAreaTitle)
+}
+
+// drawSafeAreaSettings draws the sliders that control the size of the safe
+// areas.
+func (win *winDbgScr) drawSafeAreaSettings() {
+	imgui.PushItemWidth(150)
+	defer imgui.PopItemWidth()
+
+	f := float32(win.actionSafe.Get().(float64) * 100)
+	if imgui.SliderFloatV("Action Safe##actionsafe", &f, 50, 100, "%.0f%%", 1.0) {
+		win.actionSafe.Set(f / 100)
+	}
+
+	imgui.SameLine()
+	f = float32(win.titleSafe.Get().(float64) * 100)
+	if imgui.SliderFloatV("Title Safe##titlesafe", &f, 50, 100, "%.0f%%", 1.0) {
+		win.titleSafe.Set(f / 100)
+	}
+
+	imgui.SameLine()
+	if imgui.Button("Defaults##safearea") {
+		win.actionSafe.Set(defaultActionSafe)
+		win.titleSafe.Set(defaultTitleSafe)
+	}
+}
+
 // called from within a win.scr.crit.section Lock().
 func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 	// get mouse position and transform
@@ -335,7 +655,7 @@ func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 		mp.X += float32(specification.HorizClksHBlank)
 		mp.Y += float32(win.scr.crit.topScanline)
 	} else {
-		sz := win.scr.crit.pixels.Bounds().Size()
+		sz := win.uncroppedPixels().Bounds().Size()
 		mp.X = mp.X / win.getScaledWidth(false) * float32(sz.X)
 		mp.Y = mp.Y / win.getScaledHeight(false) * float32(sz.Y)
 	}
@@ -344,11 +664,7 @@ func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 	win.mouseScanline = int(mp.Y)
 
 	// get reflection information
-	var ref reflection.Reflection
-
-	if win.mouseHorizPos < len(win.scr.crit.reflection) && win.mouseScanline < len(win.scr.crit.reflection[win.mouseHorizPos]) {
-		ref = win.scr.crit.reflection[win.mouseHorizPos][win.mouseScanline]
-	}
+	ref := win.scr.crit.reflection.Get(win.mouseHorizPos, win.mouseScanline)
 
 	// present tooltip showing pixel coords and CPU state
 	if win.isCaptured {
@@ -361,6 +677,15 @@ func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 	imgui.Text(fmt.Sprintf("Scanline: %d", win.mouseScanline))
 	imgui.Text(fmt.Sprintf("Horiz Pos: %d", win.mouseHorizPos-specification.HorizClksHBlank))
 
+	if win.tutorial {
+		n := tutorialPart(win.tutorialBands, win.mouseScanline, win.mouseHorizPos).Note()
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+		imgui.Text(n.Title)
+		imgui.Text(n.Summary)
+	}
+
 	if win.overlay {
 		switch win.scr.crit.overlay {
 		case "WSYNC":
@@ -388,11 +713,34 @@ func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 			} else {
 				imgui.Text("no HMOVE")
 			}
+		case "Cycles":
+			imgui.Spacing()
+			imgui.Separator()
+			imgui.Spacing()
+			imgui.Text(fmt.Sprintf("CPU activity: %s", ref.Activity()))
+			if ref.CPU.Defn != nil && !ref.WSYNC {
+				imgui.Text(fmt.Sprintf("%s (%d cycles)", ref.CPU.Defn.Mnemonic, ref.CPU.Cycles))
+			}
+		case "Audio":
+			imgui.Spacing()
+			imgui.Separator()
+			imgui.Spacing()
+			if ref.AudioWrite != "" {
+				imgui.Text(fmt.Sprintf("%s written", ref.AudioWrite))
+			} else {
+				imgui.Text("no audio register written")
+			}
 		}
 		return
 	}
 
-	e, _ := win.img.lz.Dbg.Disasm.FormatResult(ref.Bank, ref.CPU, disassembly.EntryLevelBlessed)
+	if win.tooltipEntry == nil || win.tooltipBank != ref.Bank || win.tooltipCPU != ref.CPU {
+		win.tooltipEntry, _ = win.img.lz.Dbg.Disasm.FormatResult(ref.Bank, ref.CPU, disassembly.EntryLevelBlessed)
+		win.tooltipBank = ref.Bank
+		win.tooltipCPU = ref.CPU
+	}
+
+	e := win.tooltipEntry
 	if e.Address == "" {
 		return
 	}
@@ -402,20 +750,26 @@ func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 	imgui.Spacing()
 
 	// pixel swatch. using black swatch if pixel is HBLANKed or VBLANKed
-	if ref.Hblank || ref.TV.VBlank {
+	if ref.Hblank || ref.TV.VBlank() {
 		win.img.imguiSwatch(0, 0.5)
 	} else {
-		win.img.imguiSwatch(uint8(ref.TV.Pixel), 0.5)
+		win.img.imguiSwatch(uint8(ref.TV.Pixel()), 0.5)
 	}
 
 	// element information regardless of HBLANK/VBLANK state
 	imguiText(ref.VideoElement.String())
 
+	// note priority mode if it is not the default
+	if ref.Priority != video.PriorityNormal {
+		imgui.SameLine()
+		imguiText(fmt.Sprintf("(%s)", ref.Priority))
+	}
+
 	// add HBLANK/VBLANK information
 	if ref.Hblank {
 		imgui.SameLine()
 		imguiText("[HBLANK]")
-	} else if ref.TV.VBlank {
+	} else if ref.TV.VBlank() {
 		imgui.SameLine()
 		imguiText("[VBLANK]")
 	}
@@ -445,9 +799,31 @@ func (win *winDbgScr) drawReflectionTooltip(mouseOrigin imgui.Vec2) {
 
 func (win *winDbgScr) setCropping(set bool) {
 	win.cropped = set
+	if win.cropped {
+		win.offscreen = false
+	}
+	win.createTextures = true
+}
+
+func (win *winDbgScr) setOffscreen(set bool) {
+	win.offscreen = set
+	if win.offscreen {
+		win.cropped = false
+	}
 	win.createTextures = true
 }
 
+// uncroppedPixels returns the uncropped or off-screen view of the screen
+// pixels depending on the offscreen flag.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) uncroppedPixels() *image.RGBA {
+	if win.offscreen {
+		return win.scr.crit.offscreenPixels
+	}
+	return win.scr.crit.uncropPixels
+}
+
 func (win *winDbgScr) resize() {
 	win.createTextures = true
 }
@@ -460,6 +836,11 @@ func (win *winDbgScr) render() {
 	// critical section
 	win.scr.crit.section.Lock()
 
+	pixelsDirty := &win.scr.crit.pixelsDirty
+	if win.debugColors {
+		pixelsDirty = &win.scr.crit.elementsDirty
+	}
+
 	if win.cropped {
 		if win.debugColors {
 			pixels = win.scr.crit.cropElementPixels
@@ -467,13 +848,20 @@ func (win *winDbgScr) render() {
 			pixels = win.scr.crit.cropPixels
 		}
 		overlayPixels = win.scr.crit.cropOverlayPixels
+	} else if win.offscreen {
+		if win.debugColors {
+			pixels = win.scr.crit.offscreenElementPixels
+		} else {
+			pixels = win.scr.crit.offscreenPixels
+		}
+		overlayPixels = win.scr.crit.offscreenOverlayPixels
 	} else {
 		if win.debugColors {
-			pixels = win.scr.crit.elementPixels
+			pixels = win.scr.crit.uncropElementPixels
 		} else {
-			pixels = win.scr.crit.pixels
+			pixels = win.scr.crit.uncropPixels
 		}
-		overlayPixels = win.scr.crit.overlayPixels
+		overlayPixels = win.scr.crit.uncropOverlayPixels
 	}
 
 	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(pixels.Stride)/4)
@@ -495,18 +883,26 @@ func (win *winDbgScr) render() {
 			gl.Ptr(overlayPixels.Pix))
 
 		win.createTextures = false
+		win.screenUpload.invalidate()
+		win.overlayUpload.invalidate()
 	} else {
 		gl.BindTexture(gl.TEXTURE_2D, win.screenTexture)
-		gl.TexSubImage2D(gl.TEXTURE_2D, 0,
-			0, 0, int32(pixels.Bounds().Size().X), int32(pixels.Bounds().Size().Y),
-			gl.RGBA, gl.UNSIGNED_BYTE,
-			gl.Ptr(pixels.Pix))
+		pixelsDirty.upload(pixels, &win.screenUpload)
 
 		gl.BindTexture(gl.TEXTURE_2D, win.overlayTexture)
-		gl.TexSubImage2D(gl.TEXTURE_2D, 0,
-			0, 0, int32(pixels.Bounds().Size().X), int32(pixels.Bounds().Size().Y),
+		win.scr.crit.overlayDirty.upload(overlayPixels, &win.overlayUpload)
+	}
+
+	// thumbnails texture is only updated when it needs to be
+	if win.thumbnails && win.scr.crit.thumbnails.updated {
+		th := win.scr.crit.thumbnails.pixels
+		gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(th.Stride)/4)
+		gl.BindTexture(gl.TEXTURE_2D, win.thumbnailTexture)
+		gl.TexImage2D(gl.TEXTURE_2D, 0,
+			gl.RGBA, int32(th.Bounds().Size().X), int32(th.Bounds().Size().Y), 0,
 			gl.RGBA, gl.UNSIGNED_BYTE,
-			gl.Ptr(overlayPixels.Pix))
+			gl.Ptr(th.Pix))
+		win.scr.crit.thumbnails.updated = false
 	}
 
 	win.scr.crit.section.Unlock()
@@ -520,14 +916,14 @@ func (win *winDbgScr) getScaledWidth(cropped bool) float32 {
 	if cropped {
 		return float32(win.scr.crit.cropPixels.Bounds().Size().X) * win.getScaling(true)
 	}
-	return float32(win.scr.crit.pixels.Bounds().Size().X) * win.getScaling(true)
+	return float32(win.uncroppedPixels().Bounds().Size().X) * win.getScaling(true)
 }
 
 func (win *winDbgScr) getScaledHeight(cropped bool) float32 {
 	if cropped {
 		return float32(win.scr.crit.cropPixels.Bounds().Size().Y) * win.getScaling(false)
 	}
-	return float32(win.scr.crit.pixels.Bounds().Size().Y) * win.getScaling(false)
+	return float32(win.uncroppedPixels().Bounds().Size().Y) * win.getScaling(false)
 }
 
 func (win *winDbgScr) setScaleFromWindow(sz imgui.Vec2) {
@@ -542,8 +938,8 @@ func (win *winDbgScr) setScaleFromWindow(sz imgui.Vec2) {
 		imageW = float32(win.scr.crit.cropPixels.Bounds().Size().X)
 		imageH = float32(win.scr.crit.cropPixels.Bounds().Size().Y)
 	} else {
-		imageW = float32(win.scr.crit.pixels.Bounds().Size().X)
-		imageH = float32(win.scr.crit.pixels.Bounds().Size().Y)
+		imageW = float32(win.uncroppedPixels().Bounds().Size().X)
+		imageH = float32(win.uncroppedPixels().Bounds().Size().Y)
 	}
 	imageW *= pixelWidth * win.scr.aspectBias
 
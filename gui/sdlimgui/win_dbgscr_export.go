@@ -0,0 +1,110 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/reflection"
+)
+
+// exportPNG saves the screen as currently presented in the debug screen
+// window to a uniquely named PNG file in the current directory. the result of
+// the export is noted in exportResult.
+func (win *winDbgScr) exportPNG() {
+	shortName := path.Base(win.img.lz.Cart.Filename)
+	shortName = strings.TrimSuffix(shortName, path.Ext(shortName))
+
+	n := time.Now()
+	filename := fmt.Sprintf("debugscreen_%s_%s.png", shortName,
+		fmt.Sprintf("%04d%02d%02d_%02d%02d%02d",
+			n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second()))
+
+	if err := win.export(filename); err != nil {
+		logger.Log("sdlimgui", err.Error())
+		win.exportResult = "Export failed"
+		return
+	}
+
+	win.exportResult = fmt.Sprintf("Saved to %s", filename)
+}
+
+// export the screen to the named file in the PNG format. the image reflects
+// the debug colours, cropping and off-screen settings of the window. if the
+// overlay is enabled then it is composited onto the screen image and a legend
+// for the overlay is added to the bottom of the image.
+//
+// the CRT effects, rulers, safe area and tutorial layers are drawn by the GUI
+// and are not included.
+func (win *winDbgScr) export(filename string) error {
+	win.scr.crit.section.Lock()
+
+	var pixels *image.RGBA
+	var overlayPixels *image.RGBA
+
+	if win.cropped {
+		pixels = win.scr.crit.cropPixels
+		if win.debugColors {
+			pixels = win.scr.crit.cropElementPixels
+		}
+		overlayPixels = win.scr.crit.cropOverlayPixels
+	} else if win.offscreen {
+		pixels = win.scr.crit.offscreenPixels
+		if win.debugColors {
+			pixels = win.scr.crit.offscreenElementPixels
+		}
+		overlayPixels = win.scr.crit.offscreenOverlayPixels
+	} else {
+		pixels = win.scr.crit.uncropPixels
+		if win.debugColors {
+			pixels = win.scr.crit.uncropElementPixels
+		}
+		overlayPixels = win.scr.crit.uncropOverlayPixels
+	}
+
+	var img *image.RGBA
+	if win.overlay {
+		img = reflection.Composite(pixels, overlayPixels)
+		img = reflection.AddLegend(img, win.scr.crit.overlay, win.scr.crit.paletteEvents)
+	} else {
+		img = image.NewRGBA(image.Rect(0, 0, pixels.Bounds().Size().X, pixels.Bounds().Size().Y))
+		draw.Draw(img, img.Bounds(), pixels, pixels.Bounds().Min, draw.Src)
+	}
+
+	win.scr.crit.section.Unlock()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return curated.Errorf("export: %v", err)
+	}
+	defer f.Close()
+
+	err = png.Encode(f, img)
+	if err != nil {
+		return curated.Errorf("export: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/tutorial"
+)
+
+// the width of the colored strip that marks each part of the frame.
+const tutorialStripWidth = 4
+
+// measureTutorialBands divides the most recent frame into parts using the
+// reflection information.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) measureTutorialBands() []tutorial.Band {
+	// the VSYNC and VBLANK bits are sampled in the middle of the visible part
+	// of each scanline
+	const x = specification.HorizClksHBlank + specification.HorizClksVisible/2
+
+	n := win.uncroppedPixels().Bounds().Size().Y
+	lines := make([]tutorial.Line, n)
+	for sl := range lines {
+		ref := win.scr.crit.reflection.Get(x, sl)
+		lines[sl] = tutorial.Line{VSync: ref.TV.VSync(), VBlank: ref.TV.VBlank()}
+	}
+
+	return tutorial.Bands(lines)
+}
+
+// tutorialPart returns the part of the frame that the coordinates are in.
+func tutorialPart(bands []tutorial.Band, scanline int, clock int) tutorial.FramePart {
+	if clock < specification.HorizClksHBlank {
+		return tutorial.PartHBLANK
+	}
+	for _, b := range bands {
+		if scanline >= b.Top && scanline <= b.Bottom {
+			return b.Part
+		}
+	}
+	return tutorial.PartVisible
+}
+
+func (win *winDbgScr) tutorialColor(p tutorial.FramePart) imgui.PackedColor {
+	switch p {
+	case tutorial.PartVSYNC:
+		return imgui.PackedColorFromVec4(win.img.cols.TutorialVSYNC)
+	case tutorial.PartVBLANK:
+		return imgui.PackedColorFromVec4(win.img.cols.TutorialVBLANK)
+	case tutorial.PartOverscan:
+		return imgui.PackedColorFromVec4(win.img.cols.TutorialOverscan)
+	}
+	return imgui.PackedColorFromVec4(win.img.cols.TutorialVisible)
+}
+
+// drawTutorial labels the parts of the frame with a colored strip along the
+// left edge of the screen image.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) drawTutorial(origin imgui.Vec2, h float32) {
+	dl := imgui.WindowDrawList()
+
+	sh := win.getScaling(false)
+
+	// the first scanline of the screen image
+	var oy int
+	if win.cropped {
+		oy = win.scr.crit.topScanline
+	}
+
+	// labels are drawn with imgui.Text() so we need to restore the cursor
+	// position afterwards
+	cursor := imgui.CursorScreenPos()
+	imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.RulerText)
+
+	for _, b := range win.tutorialBands {
+		top := float32(b.Top-oy) * sh
+		bot := float32(b.Bottom-oy+1) * sh
+
+		// clip band to the screen image
+		if bot <= 0 || top >= h {
+			continue
+		}
+		if top < 0 {
+			top = 0
+		}
+		if bot > h {
+			bot = h
+		}
+
+		p := origin.Plus(imgui.Vec2{0, top})
+		dl.AddRectFilled(p, origin.Plus(imgui.Vec2{tutorialStripWidth, bot}), win.tutorialColor(b.Part))
+
+		// only label the band if there is room
+		if bot-top >= imgui.TextLineHeight() {
+			imgui.SetCursorScreenPos(p.Plus(imgui.Vec2{tutorialStripWidth + 2, 0}))
+			imgui.Text(b.Part.String())
+		}
+	}
+
+	imgui.PopStyleColor()
+	imgui.SetCursorScreenPos(cursor)
+}
+
+// drawTutorialNote draws a single line summary of the note. The detail of the
+// note is shown in a tooltip when the line is hovered over.
+func drawTutorialNote(label string, n tutorial.Note) {
+	imgui.Text(fmt.Sprintf("%s %s: %s", label, n.Title, n.Summary))
+	if n.Detail != "" && imgui.IsItemHovered() {
+		imgui.BeginTooltip()
+		imgui.Text(n.Detail)
+		imgui.EndTooltip()
+	}
+}
+
+// drawTutorialAnnotations draws the tutorial notes for the current position
+// of the beam and for the most recent CPU instruction.
+//
+// called from within a win.scr.crit.section Lock().
+func (win *winDbgScr) drawTutorialAnnotations() {
+	part := tutorialPart(win.tutorialBands, win.img.lz.TV.Scanline, win.img.lz.TV.HP+specification.HorizClksHBlank)
+	drawTutorialNote("Beam is in", part.Note())
+
+	if n, ok := tutorial.Annotate(win.img.lz.Debugger.LastResult.Result); ok {
+		drawTutorialNote(win.img.lz.Debugger.LastResult.Mnemonic, n)
+	} else {
+		imgui.Text("The most recent instruction did not write to the TIA or RIOT")
+	}
+}
@@ -57,10 +57,26 @@ type winDisasm struct {
 	alignOnOtherAddress bool
 	alignAddress        uint16
 
+	// the bank to align on when alignOnOtherAddress is set. a value of -1
+	// means that alignment will happen in whichever bank is being drawn
+	alignBank int
+
+	// the bank tab to select in the next (imgui) frame. a value of -1 means
+	// that the tab selection is not to be changed
+	selectBank int
+
 	// the address of the entry a the top of the list, we use to help
 	// list alignment (see alignAddress above)
 	addressTopList uint16
 
+	// search and goto input
+	searchScope   disassembly.GrepScope
+	searchString  string
+	searchResults []disassembly.SearchResult
+	searchIdx     int
+	gotoString    string
+	gotoErr       string
+
 	// the program counter value in the previous (imgui) frame
 	pcaddrPrevFrame uint16
 
@@ -69,12 +85,15 @@ type winDisasm struct {
 	colVideoStep    imgui.PackedColor
 	colBreakAddress imgui.PackedColor
 	colBreakOther   imgui.PackedColor
+	colSearchMatch  imgui.PackedColor
 }
 
 func newWinDisasm(img *SdlImgui) (managedWindow, error) {
 	win := &winDisasm{
-		img:       img,
-		alignOnPC: false,
+		img:        img,
+		alignOnPC:  false,
+		alignBank:  -1,
+		selectBank: -1,
 	}
 
 	return win, nil
@@ -85,6 +104,7 @@ func (win *winDisasm) init() {
 	win.colVideoStep = imgui.PackedColorFromVec4(win.img.cols.DisasmVideoStep)
 	win.colBreakAddress = imgui.PackedColorFromVec4(win.img.cols.DisasmBreakAddress)
 	win.colBreakOther = imgui.PackedColorFromVec4(win.img.cols.DisasmBreakOther)
+	win.colSearchMatch = imgui.PackedColorFromVec4(win.img.cols.DisasmSearchMatch)
 }
 
 func (win *winDisasm) destroy() {
@@ -99,6 +119,8 @@ func (win *winDisasm) draw() {
 		return
 	}
 
+	win.img.lz.CPU.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{905, 242}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.SetNextWindowSizeV(imgui.Vec2{353, 466}, imgui.ConditionFirstUseEver)
 	imgui.BeginV(winDisasmTitle, &win.open, 0)
@@ -141,7 +163,11 @@ func (win *winDisasm) draw() {
 			// set tab flags. select the tab that represents the
 			// bank currently being referenced by the VCS
 			flgs := imgui.TabItemFlagsNone
-			if !currBank.NonCart && win.alignOnPC && b == currBank.Number {
+			if win.selectBank != -1 {
+				if b == win.selectBank {
+					flgs = imgui.TabItemFlagsSetSelected
+				}
+			} else if !currBank.NonCart && win.alignOnPC && b == currBank.Number {
 				flgs = imgui.TabItemFlagsSetSelected
 			}
 
@@ -153,6 +179,8 @@ func (win *winDisasm) draw() {
 		}
 
 		imgui.EndTabBar()
+
+		win.selectBank = -1
 	}
 
 	// set alignOnPC flag when PC address has not changed since last (imgui) frame
@@ -173,10 +201,15 @@ func (win *winDisasm) draw() {
 	}
 	imgui.Text(s.String())
 
+	// search and goto lines
+	win.drawSearch()
+	win.drawGoto()
+
 	// options line
 	if imgui.Checkbox("Show all", &win.showAllEntries) {
 		win.alignOnOtherAddress = true
 		win.alignAddress = win.addressTopList
+		win.alignBank = -1
 		win.alignOnPC = true
 	}
 
@@ -259,13 +292,18 @@ func (win *winDisasm) drawBank(pcaddr uint16, b int, selected bool, cpuStep bool
 	// note that alignOnPC has an additional condition and will only be
 	// honoured if the selected flag is set. this is to prevent alignment
 	// attempts when the PC is executing in VCS RAM
-	if (win.alignOnPC && selected) || win.alignOnOtherAddress {
+	//
+	// alignOnOtherAddress is only honoured if the bank being drawn is the
+	// bank that has been asked for. it may take a frame for the tab bar to
+	// select the requested bank
+	alignOnOther := win.alignOnOtherAddress && (win.alignBank == -1 || win.alignBank == b)
+	if (win.alignOnPC && selected) || alignOnOther {
 		var addr uint16
 		var scrollMargin float32
 
 		// figure out what kind of alignment to perform. aligning on non-PC
 		// address takes priority
-		if win.alignOnOtherAddress {
+		if alignOnOther {
 			addr = win.alignAddress
 			scrollMargin = 0
 
@@ -329,6 +367,15 @@ func (win *winDisasm) drawEntry(e *disassembly.Entry, pcaddr uint16, selected bo
 		adj = imgui.Vec4{0.0, 0.0, 0.0, -0.4}
 	}
 
+	// highlight the current search result
+	if win.isSearchMatch(e) {
+		p1 := imgui.CursorScreenPos()
+		p2 := p1
+		p2.X += imgui.WindowWidth()
+		p2.Y += imgui.FontSize() * 1.1
+		imgui.WindowDrawList().AddRectFilled(p1, p2, win.colSearchMatch)
+	}
+
 	// if the entry is being drawn by a selected bank then highlight the entry
 	// for the current pc address
 	if selected && pcaddr&memorymap.CartridgeBits == e.Result.Address&memorymap.CartridgeBits {
@@ -0,0 +1,181 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// the names of the search scopes in the order of the disassembly.GrepScope
+// values.
+var searchScopes = []string{"All", "Mnemonic", "Operand", "Label"}
+
+// drawSearch draws the search line of the disassembly window. the search is
+// performed when the enter key is pressed in the input box.
+func (win *winDisasm) drawSearch() {
+	imgui.AlignTextToFramePadding()
+	imgui.Text("Search")
+	imgui.SameLine()
+
+	imgui.PushItemWidth(imguiGetFrameDim("Mnemonic").X + imgui.FrameHeight())
+	if imgui.BeginCombo("##searchScope", searchScopes[win.searchScope]) {
+		for i, s := range searchScopes {
+			if imgui.Selectable(s) {
+				win.searchScope = disassembly.GrepScope(i)
+				win.search()
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.PopItemWidth()
+
+	imgui.SameLine()
+	if imguiTextInput("##search", true, 16, &win.searchString, true) {
+		win.search()
+	}
+
+	if len(win.searchResults) == 0 {
+		if win.searchString != "" {
+			imgui.SameLine()
+			imgui.Text("no matches")
+		}
+		return
+	}
+
+	imgui.SameLine()
+	if imgui.Button("<##search") {
+		win.searchIdx--
+		if win.searchIdx < 0 {
+			win.searchIdx = len(win.searchResults) - 1
+		}
+		win.gotoSearchResult()
+	}
+	imgui.SameLine()
+	if imgui.Button(">##search") {
+		win.searchIdx++
+		if win.searchIdx >= len(win.searchResults) {
+			win.searchIdx = 0
+		}
+		win.gotoSearchResult()
+	}
+
+	imgui.SameLine()
+	imgui.Text(fmt.Sprintf("%d of %d", win.searchIdx+1, len(win.searchResults)))
+}
+
+// search the disassembly with the current search string and scope. aligns
+// the disassembly on the first match.
+func (win *winDisasm) search() {
+	win.searchResults = win.searchResults[:0]
+	win.searchIdx = 0
+
+	if win.searchString == "" {
+		return
+	}
+
+	var err error
+	win.searchResults, err = win.img.lz.Dbg.Disasm.Search(win.searchScope, win.searchString, false)
+	if err != nil {
+		win.searchResults = win.searchResults[:0]
+		return
+	}
+
+	win.gotoSearchResult()
+}
+
+func (win *winDisasm) gotoSearchResult() {
+	if win.searchIdx >= len(win.searchResults) {
+		return
+	}
+	r := win.searchResults[win.searchIdx]
+	win.gotoAddress(r.Bank, r.Address)
+}
+
+// isSearchMatch returns true if the entry is the current search result.
+func (win *winDisasm) isSearchMatch(e *disassembly.Entry) bool {
+	if win.searchIdx >= len(win.searchResults) {
+		return false
+	}
+	r := win.searchResults[win.searchIdx]
+	return e.Bank.Number == r.Bank && e.Result.Address&memorymap.CartridgeBits == r.Address&memorymap.CartridgeBits
+}
+
+// drawGoto draws the goto line of the disassembly window. the address can be
+// prefixed with a bank number and a colon. for example, 3:f000
+func (win *winDisasm) drawGoto() {
+	imgui.AlignTextToFramePadding()
+	imgui.Text("Goto  ")
+	imgui.SameLine()
+
+	if imguiInput("##goto", true, 8, &win.gotoString, "abcdefABCDEF0123456789:$x", true) {
+		bank, address, err := win.parseGoto(win.gotoString)
+		if err != nil {
+			win.gotoErr = err.Error()
+		} else {
+			win.gotoErr = ""
+			win.gotoAddress(bank, address)
+		}
+	}
+
+	if win.gotoErr != "" {
+		imgui.SameLine()
+		imgui.Text(win.gotoErr)
+	}
+}
+
+// parseGoto parses the goto string. if no bank is specified then the bank
+// currently referenced by the VCS is used.
+func (win *winDisasm) parseGoto(s string) (int, uint16, error) {
+	bank := win.img.lz.Cart.CurrBank.Number
+
+	if i := strings.Index(s, ":"); i != -1 {
+		b, err := strconv.Atoi(s[:i])
+		if err != nil || b < 0 || b >= win.img.lz.Cart.NumBanks {
+			return 0, 0, curated.Errorf("invalid bank")
+		}
+		bank = b
+		s = s[i+1:]
+	}
+
+	s = strings.TrimPrefix(s, "$")
+	s = strings.TrimPrefix(strings.ToLower(s), "0x")
+
+	a, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, 0, curated.Errorf("invalid address")
+	}
+
+	return bank, uint16(a), nil
+}
+
+// gotoAddress selects the bank and aligns the disassembly on the address.
+func (win *winDisasm) gotoAddress(bank int, address uint16) {
+	if win.img.lz.Cart.NumBanks > 1 {
+		win.selectBank = bank
+		win.alignBank = bank
+	} else {
+		win.alignBank = -1
+	}
+	win.alignOnOtherAddress = true
+	win.alignAddress = address
+}
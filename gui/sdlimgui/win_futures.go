@@ -0,0 +1,110 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+const winFuturesTitle = "TIA Futures"
+
+// winFutures shows the pending delay events in the TIA. The delays between a
+// register being written to and the effect of the write being seen are the
+// cause of much confusion when programming the TIA.
+type winFutures struct {
+	windowManagement
+
+	img *SdlImgui
+}
+
+func newWinFutures(img *SdlImgui) (managedWindow, error) {
+	win := &winFutures{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winFutures) init() {
+}
+
+func (win *winFutures) destroy() {
+}
+
+func (win *winFutures) id() string {
+	return winFuturesTitle
+}
+
+func (win *winFutures) draw() {
+	if !win.open {
+		return
+	}
+
+	win.img.lz.Futures.Demand()
+
+	imgui.SetNextWindowPosV(imgui.Vec2{632, 590}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.SetNextWindowSizeV(imgui.Vec2{400, 200}, imgui.ConditionFirstUseEver)
+	imgui.BeginV(winFuturesTitle, &win.open, imgui.WindowFlagsNone)
+
+	futures := win.img.lz.Futures.Futures
+
+	if len(futures) == 0 {
+		imgui.Text("No pending events")
+		imgui.End()
+		return
+	}
+
+	imgui.ColumnsV(4, "##futures", false)
+	imgui.Text("Component")
+	imgui.NextColumn()
+	imgui.Text("Event")
+	imgui.NextColumn()
+	imgui.Text("Value")
+	imgui.NextColumn()
+	imgui.Text("Clocks")
+	imgui.NextColumn()
+	imgui.Separator()
+
+	for _, f := range futures {
+		imgui.Text(f.Label)
+		imgui.NextColumn()
+		imgui.Text(f.Description)
+		imgui.NextColumn()
+		imgui.Text(fmt.Sprintf("%02x", f.Value))
+		imgui.NextColumn()
+
+		// progress bar shows how much of the delay has elapsed
+		fraction := float32(1.0)
+		if f.Initial > 0 {
+			fraction = float32(f.Initial-f.Remaining) / float32(f.Initial)
+		}
+		overlay := fmt.Sprintf("%d", f.Remaining)
+		if f.Paused {
+			overlay = fmt.Sprintf("%d (paused)", f.Remaining)
+		}
+		imgui.ProgressBarV(fraction, imgui.Vec2{-1, 0}, overlay)
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("%d of %d color clocks remaining", f.Remaining, f.Initial))
+		}
+		imgui.NextColumn()
+	}
+
+	imgui.Columns()
+
+	imgui.End()
+}
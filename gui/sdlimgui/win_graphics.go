@@ -0,0 +1,147 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/disassembly"
+)
+
+const winGraphicsTitle = "Graphics Tables"
+
+type winGraphics struct {
+	windowManagement
+
+	img *SdlImgui
+
+	// graphics tables are found when the window is opened and when the
+	// refresh button is pressed. finding the tables every frame would be
+	// wasteful
+	tables  []disassembly.GraphicsTable
+	wasOpen bool
+
+	bit   imgui.PackedColor
+	noBit imgui.PackedColor
+}
+
+func newWinGraphics(img *SdlImgui) (managedWindow, error) {
+	win := &winGraphics{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winGraphics) init() {
+	win.bit = imgui.PackedColorFromVec4(win.img.cols.GraphicsBit)
+	win.noBit = imgui.PackedColorFromVec4(win.img.cols.GraphicsNoBit)
+}
+
+func (win *winGraphics) destroy() {
+}
+
+func (win *winGraphics) id() string {
+	return winGraphicsTitle
+}
+
+// the size of a single bit in a graphics table.
+const (
+	graphicsBitWidth  = 6
+	graphicsBitHeight = 3
+)
+
+func (win *winGraphics) draw() {
+	if !win.open {
+		win.wasOpen = false
+		return
+	}
+
+	if !win.wasOpen {
+		win.tables = win.img.lz.Dbg.Disasm.GraphicsTables()
+		win.wasOpen = true
+	}
+
+	imgui.SetNextWindowPosV(imgui.Vec2{633, 358}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.SetNextWindowSizeV(imgui.Vec2{400, 300}, imgui.ConditionFirstUseEver)
+	imgui.BeginV(winGraphicsTitle, &win.open, 0)
+
+	if imgui.Button("Refresh") {
+		win.tables = win.img.lz.Dbg.Disasm.GraphicsTables()
+	}
+	imgui.SameLine()
+	imgui.Text(fmt.Sprintf("%d tables found", len(win.tables)))
+
+	imgui.Separator()
+
+	imgui.BeginChild("tables")
+
+	// tables are laid out left to right, wrapping when there is no more
+	// horizontal space
+	avail := imgui.ContentRegionAvail().X
+	var used float32
+
+	for i, tbl := range win.tables {
+		w := float32(8 * graphicsBitWidth)
+		if i > 0 {
+			if used+w+imgui.CurrentStyle().ItemSpacing().X < avail {
+				imgui.SameLine()
+			} else {
+				used = 0
+			}
+		}
+		used += w + imgui.CurrentStyle().ItemSpacing().X
+
+		imgui.BeginGroup()
+		win.drawTable(tbl)
+		imgui.EndGroup()
+
+		if imgui.IsItemHovered() {
+			imgui.BeginTooltip()
+			imgui.Text(fmt.Sprintf("Bank %d", tbl.Bank))
+			imgui.Text(fmt.Sprintf("Address %#04x", tbl.Address))
+			imgui.Text(fmt.Sprintf("%d bytes", len(tbl.Data)))
+			imgui.Text(strings.Join(tbl.Registers, ", "))
+			imgui.EndTooltip()
+		}
+	}
+
+	imgui.EndChild()
+
+	imgui.End()
+}
+
+func (win *winGraphics) drawTable(tbl disassembly.GraphicsTable) {
+	imgui.Text(fmt.Sprintf("%04x", tbl.Address))
+
+	dl := imgui.WindowDrawList()
+	p := imgui.CursorScreenPos()
+
+	for y, d := range tbl.Data {
+		for x := 0; x < 8; x++ {
+			col := win.noBit
+			if d&(0x80>>x) != 0 {
+				col = win.bit
+			}
+			tl := p.Plus(imgui.Vec2{float32(x * graphicsBitWidth), float32(y * graphicsBitHeight)})
+			dl.AddRectFilled(tl, tl.Plus(imgui.Vec2{graphicsBitWidth, graphicsBitHeight}), col)
+		}
+	}
+
+	imgui.Dummy(imgui.Vec2{8 * graphicsBitWidth, float32(len(tbl.Data) * graphicsBitHeight)})
+}
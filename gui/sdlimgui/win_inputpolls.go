@@ -0,0 +1,192 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/inputpolls"
+)
+
+const winInputPollsTitle = "Input Polls"
+
+type winInputPolls struct {
+	windowManagement
+
+	img *SdlImgui
+
+	bg     imgui.PackedColor
+	hblank imgui.PackedColor
+	stick  imgui.PackedColor
+	panel  imgui.PackedColor
+	fire   imgui.PackedColor
+	paddle imgui.PackedColor
+}
+
+func newWinInputPolls(img *SdlImgui) (managedWindow, error) {
+	win := &winInputPolls{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winInputPolls) init() {
+	win.bg = imgui.PackedColorFromVec4(win.img.cols.InputPollsBg)
+	win.hblank = imgui.PackedColorFromVec4(win.img.cols.InputPollsHBlank)
+	win.stick = imgui.PackedColorFromVec4(win.img.cols.InputPollStick)
+	win.panel = imgui.PackedColorFromVec4(win.img.cols.InputPollPanel)
+	win.fire = imgui.PackedColorFromVec4(win.img.cols.InputPollFire)
+	win.paddle = imgui.PackedColorFromVec4(win.img.cols.InputPollPaddle)
+}
+
+func (win *winInputPolls) destroy() {
+}
+
+func (win *winInputPolls) id() string {
+	return winInputPollsTitle
+}
+
+const (
+	inputPollsClockWidth = 2
+	inputPollsMarkerSize = 4
+)
+
+func (win *winInputPolls) draw() {
+	if !win.open {
+		return
+	}
+
+	win.img.lz.InputPolls.Demand()
+
+	imgui.SetNextWindowPosV(imgui.Vec2{633, 358}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.BeginV(winInputPollsTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
+
+	enabled := win.img.lz.InputPolls.Enabled
+	if imgui.Checkbox("Record", &enabled) {
+		if enabled {
+			win.img.term.pushCommand("POLLS ON")
+		} else {
+			win.img.term.pushCommand("POLLS OFF")
+		}
+	}
+	imgui.SameLine()
+	if imgui.Button("Clear") {
+		win.img.term.pushCommand("POLLS CLEAR")
+	}
+
+	polls := win.img.lz.InputPolls.Polls
+
+	imgui.Spacing()
+	imgui.Text(fmt.Sprintf("%d reads in last frame", len(polls)))
+
+	if i, ok := win.drawPlot(polls); ok {
+		imgui.BeginTooltip()
+		imgui.Text(polls[i].String())
+		imgui.EndTooltip()
+	}
+
+	imgui.Spacing()
+	win.drawKey()
+
+	if len(polls) > 0 {
+		imgui.Spacing()
+		imgui.BeginChildV("##inputpollslist", imgui.Vec2{0, imgui.FrameHeightWithSpacing() * 8}, false, 0)
+		for _, p := range polls {
+			imgui.Text(p.String())
+		}
+		imgui.EndChild()
+	}
+
+	imgui.End()
+}
+
+// color returns the color used to plot a read of the named register.
+func (win *winInputPolls) color(register string) imgui.PackedColor {
+	switch register {
+	case "SWCHA":
+		return win.stick
+	case "SWCHB":
+		return win.panel
+	case "INPT4", "INPT5":
+		return win.fire
+	}
+	return win.paddle
+}
+
+func (win *winInputPolls) drawKey() {
+	key := func(col imgui.PackedColor, label string) {
+		p := imgui.CursorScreenPos()
+		sz := imgui.TextLineHeight()
+		imgui.WindowDrawList().AddRectFilled(p, p.Plus(imgui.Vec2{sz, sz}), col)
+		imgui.Dummy(imgui.Vec2{sz, sz})
+		imgui.SameLine()
+		imgui.Text(label)
+	}
+
+	key(win.stick, "SWCHA")
+	imgui.SameLine()
+	key(win.panel, "SWCHB")
+	imgui.SameLine()
+	key(win.fire, "INPT4/5")
+	imgui.SameLine()
+	key(win.paddle, "INPT0-3")
+}
+
+// drawPlot draws the reads on a grid of scanlines and color clocks. returns
+// the index of the read under the mouse, if there is one.
+func (win *winInputPolls) drawPlot(polls []inputpolls.Poll) (int, bool) {
+	scanlines := win.img.lz.TV.Spec.ScanlinesTotal
+
+	dl := imgui.WindowDrawList()
+	p := imgui.CursorScreenPos()
+
+	sz := imgui.Vec2{float32(specification.HorizClksScanline * inputPollsClockWidth), float32(scanlines)}
+	dl.AddRectFilled(p, p.Plus(sz), win.bg)
+	dl.AddRectFilled(p, p.Plus(imgui.Vec2{float32(specification.HorizClksHBlank * inputPollsClockWidth), sz.Y}), win.hblank)
+
+	// position of read in the plot. clock is adjusted so that the left edge
+	// of the plot is the start of HBLANK
+	pos := func(poll inputpolls.Poll) imgui.Vec2 {
+		x := (poll.Clock + specification.HorizClksHBlank) * inputPollsClockWidth
+		return p.Plus(imgui.Vec2{float32(x), float32(poll.Scanline)})
+	}
+
+	half := imgui.Vec2{inputPollsMarkerSize / 2, inputPollsMarkerSize / 2}
+	for _, poll := range polls {
+		c := pos(poll)
+		dl.AddRectFilled(c.Minus(half), c.Plus(half), win.color(poll.Register))
+	}
+
+	imgui.Dummy(sz)
+
+	if !imgui.IsItemHovered() {
+		return 0, false
+	}
+
+	// the most recent read under the mouse
+	m := imgui.MousePos()
+	for i := len(polls) - 1; i >= 0; i-- {
+		d := m.Minus(pos(polls[i]))
+		if d.X >= -half.X && d.X <= half.X && d.Y >= -half.Y && d.Y <= half.Y {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,158 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/instructionmix"
+)
+
+const winInstructionMixTitle = "Instruction Mix"
+
+type winInstructionMix struct {
+	windowManagement
+
+	img *SdlImgui
+
+	// show entries grouped by addressing mode rather than by opcode
+	byMode bool
+
+	// value of the window slider while it is being edited
+	window     int32
+	windowEdit bool
+}
+
+func newWinInstructionMix(img *SdlImgui) (managedWindow, error) {
+	win := &winInstructionMix{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winInstructionMix) init() {
+}
+
+func (win *winInstructionMix) destroy() {
+}
+
+func (win *winInstructionMix) id() string {
+	return winInstructionMixTitle
+}
+
+func (win *winInstructionMix) draw() {
+	if !win.open {
+		return
+	}
+
+	win.img.lz.Mix.Demand()
+
+	imgui.SetNextWindowPosV(imgui.Vec2{632, 390}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.SetNextWindowSizeV(imgui.Vec2{450, 400}, imgui.ConditionFirstUseEver)
+	imgui.BeginV(winInstructionMixTitle, &win.open, imgui.WindowFlagsNone)
+
+	enabled := win.img.lz.Mix.Enabled
+	if imgui.Checkbox("Enabled", &enabled) {
+		if enabled {
+			win.img.term.pushCommand("MIX ON")
+		} else {
+			win.img.term.pushCommand("MIX OFF")
+		}
+	}
+
+	imgui.SameLine()
+	if imgui.Button("Clear") {
+		win.img.term.pushCommand("MIX CLEAR")
+	}
+
+	imgui.SameLine()
+	if imgui.Button("Export CSV") {
+		n := time.Now()
+		fn := fmt.Sprintf("instructionmix_%04d%02d%02d_%02d%02d%02d.csv",
+			n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second())
+		win.img.term.pushCommand(fmt.Sprintf("MIX EXPORT %s", fn))
+	}
+
+	imgui.SameLine()
+	imgui.Checkbox("By Addressing Mode", &win.byMode)
+
+	// the window is only changed when the slider is released because changing
+	// the window resets all counts
+	if !win.windowEdit {
+		win.window = int32(win.img.lz.Mix.Window)
+	}
+	if imgui.SliderIntV("Window##mixwindow", &win.window, 1, instructionmix.MaxWindow, "%d frames") {
+		win.windowEdit = true
+	}
+	if win.windowEdit && !imgui.IsItemActive() {
+		win.img.term.pushCommand(fmt.Sprintf("MIX WINDOW %d", win.window))
+		win.windowEdit = false
+	}
+
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+
+	entries := win.img.lz.Mix.ByOpcode
+	if win.byMode {
+		entries = win.img.lz.Mix.ByAddressMode
+	}
+
+	if len(entries) == 0 {
+		if win.img.lz.Mix.Enabled {
+			imgui.Text("No instructions in window")
+		} else {
+			imgui.Text("Instruction counting is not enabled")
+		}
+		imgui.End()
+		return
+	}
+
+	imgui.ColumnsV(3, "##instructionmix", false)
+	imgui.Text("Instruction")
+	imgui.NextColumn()
+	imgui.Text("Count")
+	imgui.NextColumn()
+	imgui.Text("Cycles")
+	imgui.NextColumn()
+	imgui.Separator()
+
+	for _, e := range entries {
+		if e.Defn != nil {
+			imgui.Text(fmt.Sprintf("$%02x %s %s", e.Defn.OpCode, e.Defn.Mnemonic, e.AddressingMode))
+		} else {
+			imgui.Text(e.AddressingMode.String())
+		}
+		imgui.NextColumn()
+		imgui.ProgressBarV(float32(e.CountPercent/100), imgui.Vec2{-1, 0}, fmt.Sprintf("%d", e.Count))
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("%.2f%% of instructions", e.CountPercent))
+		}
+		imgui.NextColumn()
+		imgui.ProgressBarV(float32(e.CyclesPercent/100), imgui.Vec2{-1, 0}, fmt.Sprintf("%d", e.Cycles))
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("%.2f%% of cycles", e.CyclesPercent))
+		}
+		imgui.NextColumn()
+	}
+
+	imgui.Columns()
+
+	imgui.End()
+}
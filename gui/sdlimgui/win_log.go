@@ -50,6 +50,8 @@ func (win *winLog) draw() {
 		return
 	}
 
+	win.img.lz.Log.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{500, 480}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.SetNextWindowSizeV(imgui.Vec2{400, 400}, imgui.ConditionFirstUseEver)
 
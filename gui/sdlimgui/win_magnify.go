@@ -0,0 +1,187 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/go-gl/gl/v3.2-core/gl"
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+const winMagnifyTitle = "Magnifier"
+
+// the size of the magnified image in the window.
+const magnifyDim = 256
+
+// the minimum and maximum zoom values. the zoom value is the number of screen
+// pixels used for each scanline.
+const (
+	magnifyMinZoom = 2
+	magnifyMaxZoom = 16
+)
+
+type winMagnify struct {
+	windowManagement
+
+	img *SdlImgui
+	scr *screen
+
+	magnifyTexture uint32
+	pixels         *image.RGBA
+
+	// the zoom level and whether to draw a grid over the magnified image
+	zoom int32
+	grid bool
+
+	// the centre of the magnified area. the values will be updated whenever
+	// the mouse is hovering over the debugging TV screen
+	centreHorizPos int
+	centreScanline int
+}
+
+func newWinMagnify(img *SdlImgui) (managedWindow, error) {
+	win := &winMagnify{
+		img:            img,
+		scr:            img.screen,
+		zoom:           8,
+		grid:           true,
+		centreHorizPos: specification.HorizClksHBlank + specification.HorizClksVisible/2,
+		centreScanline: specification.SpecNTSC.ScanlineTop + specification.SpecNTSC.ScanlinesVisible/2,
+	}
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.GenTextures(1, &win.magnifyTexture)
+	gl.BindTexture(gl.TEXTURE_2D, win.magnifyTexture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.NEAREST)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.NEAREST)
+
+	return win, nil
+}
+
+func (win *winMagnify) init() {
+}
+
+func (win *winMagnify) destroy() {
+}
+
+func (win *winMagnify) id() string {
+	return winMagnifyTitle
+}
+
+// the number of color clocks and scanlines in the magnified area for the
+// current zoom level.
+func (win *winMagnify) area() (int, int) {
+	return magnifyDim / int(win.zoom*pixelWidth), magnifyDim / int(win.zoom)
+}
+
+func (win *winMagnify) draw() {
+	if !win.open {
+		return
+	}
+
+	// follow the mouse if it is hovering over the debugging screen
+	if win.img.wm.dbgScr.isHovered {
+		win.centreHorizPos = win.img.wm.dbgScr.mouseHorizPos
+		win.centreScanline = win.img.wm.dbgScr.mouseScanline
+	}
+
+	w, h := win.area()
+
+	win.scr.crit.section.Lock()
+
+	src := win.scr.crit.pixels
+	if win.img.wm.dbgScr.debugColors {
+		src = win.scr.crit.elementPixels
+	}
+
+	if win.pixels == nil || win.pixels.Bounds().Size() != image.Pt(w, h) {
+		win.pixels = image.NewRGBA(image.Rect(0, 0, w, h))
+	}
+
+	// copy pixels from the area around the centre point. pixels outside of
+	// the screen will be black
+	ox := win.centreHorizPos - w/2
+	oy := win.centreScanline - h/2
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			p := image.Pt(ox+x, oy+y)
+			if p.In(src.Bounds()) {
+				win.pixels.SetRGBA(x, y, src.RGBAAt(p.X, p.Y))
+			} else {
+				win.pixels.SetRGBA(x, y, color.RGBA{A: 255})
+			}
+		}
+	}
+
+	win.scr.crit.section.Unlock()
+
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(win.pixels.Stride)/4)
+	gl.BindTexture(gl.TEXTURE_2D, win.magnifyTexture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0,
+		gl.RGBA, int32(w), int32(h), 0,
+		gl.RGBA, gl.UNSIGNED_BYTE,
+		gl.Ptr(win.pixels.Pix))
+	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
+
+	imgui.SetNextWindowPosV(imgui.Vec2{640, 28}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.BeginV(winMagnifyTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
+
+	// magnified image
+	origin := imgui.CursorScreenPos()
+	imgui.Image(imgui.TextureID(win.magnifyTexture), imgui.Vec2{magnifyDim, magnifyDim})
+
+	dl := imgui.WindowDrawList()
+
+	// clock width and scanline height in screen pixels
+	cw := float32(magnifyDim) / float32(w)
+	sh := float32(magnifyDim) / float32(h)
+
+	if win.grid {
+		col := imgui.PackedColorFromVec4(win.img.cols.MagnifyGrid)
+		for x := 1; x < w; x++ {
+			dx := origin.X + float32(x)*cw
+			dl.AddLine(imgui.Vec2{dx, origin.Y}, imgui.Vec2{dx, origin.Y + magnifyDim}, col)
+		}
+		for y := 1; y < h; y++ {
+			dy := origin.Y + float32(y)*sh
+			dl.AddLine(imgui.Vec2{origin.X, dy}, imgui.Vec2{origin.X + magnifyDim, dy}, col)
+		}
+	}
+
+	// outline the pixel at the centre of the magnified area
+	c := origin.Plus(imgui.Vec2{float32(w/2) * cw, float32(h/2) * sh})
+	dl.AddRect(c, c.Plus(imgui.Vec2{cw, sh}), imgui.PackedColorFromVec4(win.img.cols.MagnifyCursor))
+
+	imgui.Spacing()
+	imguiText("Scanline:")
+	imguiText(fmt.Sprintf("%-4d", win.centreScanline))
+	imgui.SameLineV(0, 15)
+	imguiText("Horiz Pos:")
+	imguiText(fmt.Sprintf("%-4d", win.centreHorizPos-specification.HorizClksHBlank))
+
+	imgui.Spacing()
+	imgui.PushItemWidth(magnifyDim / 2)
+	imgui.SliderIntV("Zoom##magnifyzoom", &win.zoom, magnifyMinZoom, magnifyMaxZoom, "%dx")
+	imgui.PopItemWidth()
+	imgui.SameLine()
+	imgui.Checkbox("Grid##magnifygrid", &win.grid)
+
+	imgui.End()
+}
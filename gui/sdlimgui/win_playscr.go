@@ -16,8 +16,18 @@
 package sdlimgui
 
 import (
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"time"
+
 	"github.com/go-gl/gl/v3.2-core/gl"
 	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/eventbus"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/prefs"
 )
 
 const winPlayScrTitle = "Atari VCS"
@@ -38,6 +48,9 @@ type winPlayScr struct {
 	// (re)create textures on next render()
 	createTextures bool
 
+	// what was most recently uploaded to screenTexture
+	screenUpload textureUpload
+
 	// the tv screen has captured mouse input
 	isCaptured bool
 
@@ -53,15 +66,73 @@ type winPlayScr struct {
 	//
 	// use getScaling() and setScaling to access this value
 	scaling float32
+
+	// rotation of the screen in degrees. one of 0, 90, 180 or 270. rotation is
+	// clockwise.
+	rotation prefs.Int
+
+	// the rotated copy of the cropped pixels. only used when rotation is not
+	// zero
+	rotatedPixels *image.RGBA
+
+	// how the screen image is fitted to the window
+	fit displayFit
+
+	// additional horizontal scaling of the image. only used when the display
+	// fit method is STRETCH, otherwise it is always 1.0
+	stretch float32
+
+	// show the state of the joystick and panel over the screen
+	inputDisplay prefs.Bool
+
+	// the most recent emulation event and the time it was received. the screen
+	// border is flashed for a short time after an event
+	flashEvent eventbus.Notification
+	flashTime  time.Time
+
+	// the pause menu shown over the screen
+	pause pauseMenu
+
+	// pause the emulation when the window loses focus. focusPaused is true
+	// while the emulation is paused for that reason
+	pauseOnFocusLoss prefs.Bool
+	focusPaused      bool
+
+	// short message shown over the screen and the time it was received
+	notification     string
+	notificationTime time.Time
 }
 
+// how long the screen border is flashed after an emulation event.
+const flashDuration = 500 * time.Millisecond
+
+// how long a notification is shown for.
+const notificationDuration = 2 * time.Second
+
 func newWinPlayScr(img *SdlImgui) managedWindow {
 	win := &winPlayScr{
 		img:     img,
 		scr:     img.screen,
 		scaling: 2.0,
+		stretch: 1.0,
 	}
 
+	win.fit.init()
+
+	win.rotation.RegisterCallback(func(v prefs.Value) error {
+		// new texture dimensions are required if the rotation has changed
+		win.createTextures = true
+
+		return nil
+	})
+
+	win.inputDisplay.RegisterCallback(func(v prefs.Value) error {
+		win.scr.crit.section.Lock()
+		win.scr.crit.inputDisplay = v.(bool)
+		win.scr.crit.section.Unlock()
+		return nil
+	})
+
 	// set texture, creation of textures will be done after every call to resize()
 	gl.ActiveTexture(gl.TEXTURE0)
 	gl.GenTextures(1, &win.screenTexture)
@@ -91,7 +162,7 @@ func (win *winPlayScr) draw() {
 	w := win.getScaledWidth()
 	h := win.getScaledHeight()
 
-	imgui.PushStyleColor(imgui.StyleColorWindowBg, win.img.cols.PlayWindowBg)
+	imgui.PushStyleColor(imgui.StyleColorWindowBg, win.fit.borderCol)
 	imgui.PushStyleColor(imgui.StyleColorBorder, win.img.cols.PlayWindowBorder)
 
 	imgui.SetNextWindowPosV(imgui.Vec2{0, 0}, 0, imgui.Vec2{0, 0})
@@ -109,16 +180,119 @@ func (win *winPlayScr) draw() {
 
 	// add horiz/vert padding around screen image
 	imgui.SetCursorPos(imgui.CursorPos().Plus(win.imagePadding))
+
+	// the even field of an interlaced image is drawn half a scanline lower
+	// than the odd field (bob deinterlacing)
+	win.scr.crit.section.Lock()
+	field := win.scr.crit.lastField
+	win.scr.crit.section.Unlock()
+	if field == television.FieldEven && !win.isSideways() {
+		imgui.SetCursorPos(imgui.CursorPos().Plus(imgui.Vec2{Y: win.getScaling(false) / 2}))
+	}
 	imgui.Image(imgui.TextureID(win.screenTexture), imgui.Vec2{w, h})
 
+	if win.inputDisplay.Get().(bool) {
+		win.drawInputDisplay()
+	}
+
+	if win.img.screen.statsHUD.Get().(bool) {
+		win.scr.crit.section.Lock()
+		st := win.scr.crit.stats
+		win.scr.crit.section.Unlock()
+		win.img.drawStatsHUD(&st, imgui.ItemRectMin(), imgui.ItemRectMax())
+	}
+
+	win.drawFlash()
+	win.drawNotification()
+	win.drawFocusPaused()
+
 	// capture mouse on double click
-	if !win.img.hasModal && imgui.IsMouseDoubleClicked(0) {
+	if !win.img.hasModal && !win.pause.open && imgui.IsMouseDoubleClicked(0) {
 		win.img.setCapture(true)
 	}
 
 	imgui.PopStyleColorV(2)
 
 	imgui.End()
+
+	win.drawPauseMenu()
+}
+
+// flash the screen border in response to an emulation event.
+func (win *winPlayScr) flash(n eventbus.Notification) {
+	win.flashEvent = n
+	win.flashTime = time.Now()
+}
+
+// drawFlash draws a border around the window that fades over flashDuration.
+// the label of the event is shown in the top-left corner.
+func (win *winPlayScr) drawFlash() {
+	d := time.Since(win.flashTime)
+	if d >= flashDuration {
+		return
+	}
+
+	col := win.img.cols.EventFlash
+	col.W *= 1.0 - float32(d)/float32(flashDuration)
+
+	const thickness = 8.0
+
+	pos := imgui.WindowPos()
+	dl := imgui.WindowDrawList()
+	dl.AddRectV(pos, pos.Plus(win.winDim), imgui.PackedColorFromVec4(col), 0, imgui.DrawCornerFlagsNone, thickness)
+
+	imgui.SetCursorScreenPos(pos.Plus(imgui.Vec2{X: thickness * 2, Y: thickness * 2}))
+	imgui.PushStyleColor(imgui.StyleColorText, col)
+	imgui.Text(win.flashEvent.String())
+	imgui.PopStyleColor()
+}
+
+// notify shows a short message over the screen.
+func (win *winPlayScr) notify(msg string) {
+	win.notification = msg
+	win.notificationTime = time.Now()
+}
+
+// drawNotification draws the most recent notification at the bottom of the
+// window. the notification fades over notificationDuration.
+func (win *winPlayScr) drawNotification() {
+	d := time.Since(win.notificationTime)
+	if d >= notificationDuration {
+		return
+	}
+
+	col := win.img.cols.EventFlash
+	col.W *= 1.0 - float32(d)/float32(notificationDuration)
+
+	dim := imguiGetFrameDim(win.notification)
+	pos := imgui.WindowPos().Plus(imgui.Vec2{X: (win.winDim.X - dim.X) / 2, Y: win.winDim.Y - dim.Y*3})
+
+	imgui.SetCursorScreenPos(pos)
+	imgui.PushStyleColor(imgui.StyleColorText, col)
+	imgui.Text(win.notification)
+	imgui.PopStyleColor()
+}
+
+// screenshot saves the current screen image to the named file in the PNG
+// format.
+func (win *winPlayScr) screenshot(filename string) error {
+	win.scr.crit.section.Lock()
+	img := image.NewRGBA(win.scr.crit.cropPixels.Bounds())
+	draw.Draw(img, img.Bounds(), win.scr.crit.cropPixels, img.Bounds().Min, draw.Src)
+	win.scr.crit.section.Unlock()
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return curated.Errorf("screenshot: %v", err)
+	}
+	defer f.Close()
+
+	err = png.Encode(f, img)
+	if err != nil {
+		return curated.Errorf("screenshot: %v", err)
+	}
+
+	return nil
 }
 
 func (win *winPlayScr) resize() {
@@ -143,8 +317,16 @@ func (win *winPlayScr) render() {
 	// make a note of fram stability for later on outside of the critical section
 	isStable := win.scr.crit.isStable
 
-	win.scr.crit.section.Unlock()
-	// end of critical section
+	// rotate pixels if required. this must be done inside the critical section
+	// because the rotated image is a copy of the cropped pixels
+	rotated := win.rotation.Get().(int) != 0
+	if rotated {
+		pixels = win.rotate(pixels)
+	}
+
+	// the texture upload happens inside the critical section because the
+	// dirty rows must be consistent with the pixels being uploaded
+	defer win.scr.crit.section.Unlock()
 
 	gl.PixelStorei(gl.UNPACK_ROW_LENGTH, int32(pixels.Stride)/4)
 	defer gl.PixelStorei(gl.UNPACK_ROW_LENGTH, 0)
@@ -161,23 +343,97 @@ func (win *winPlayScr) render() {
 				gl.Ptr(pixels.Pix))
 
 			win.createTextures = false
-		} else {
+			win.screenUpload.invalidate()
+		} else if rotated {
+			// the rotated image does not correspond row-for-row with the
+			// dirty rows so it is always uploaded in its entirety
 			gl.BindTexture(gl.TEXTURE_2D, win.screenTexture)
 			gl.TexSubImage2D(gl.TEXTURE_2D, 0,
 				0, 0, int32(pixels.Bounds().Size().X), int32(pixels.Bounds().Size().Y),
 				gl.RGBA, gl.UNSIGNED_BYTE,
 				gl.Ptr(pixels.Pix))
+			win.screenUpload.invalidate()
+		} else {
+			gl.BindTexture(gl.TEXTURE_2D, win.screenTexture)
+			win.scr.crit.pixelsDirty.upload(pixels, &win.screenUpload)
 		}
 	}
 }
 
+// rotate the pixels in src according to the rotation value. the returned
+// image should not be retained by the caller beyond the next call to rotate().
+//
+// must be called from with a critical section.
+func (win *winPlayScr) rotate(src *image.RGBA) *image.RGBA {
+	sz := src.Bounds().Size()
+	rot := win.rotation.Get().(int)
+
+	// dimensions of rotated image
+	w, h := sz.X, sz.Y
+	if rot == 90 || rot == 270 {
+		w, h = h, w
+	}
+
+	if win.rotatedPixels == nil || win.rotatedPixels.Bounds().Size() != image.Pt(w, h) {
+		win.rotatedPixels = image.NewRGBA(image.Rect(0, 0, w, h))
+	}
+
+	min := src.Bounds().Min
+	for y := 0; y < sz.Y; y++ {
+		for x := 0; x < sz.X; x++ {
+			var rx, ry int
+			switch rot {
+			case 90:
+				rx, ry = sz.Y-1-y, x
+			case 180:
+				rx, ry = sz.X-1-x, sz.Y-1-y
+			case 270:
+				rx, ry = y, sz.X-1-x
+			default:
+				rx, ry = x, y
+			}
+			win.rotatedPixels.SetRGBA(rx, ry, src.RGBAAt(min.X+x, min.Y+y))
+		}
+	}
+
+	return win.rotatedPixels
+}
+
+// isSideways returns true if the screen has been rotated by 90 or 270 degrees.
+func (win *winPlayScr) isSideways() bool {
+	rot := win.rotation.Get().(int)
+	return rot == 90 || rot == 270
+}
+
+// rotateMouse transforms the mouse coordinates (expressed as a fraction of
+// the window dimensions) such that they are in the same orientation as the
+// unrotated screen. used to make sure that paddle movement remains
+// horizontal with respect to the image on screen.
+func (win *winPlayScr) rotateMouse(x, y float32) (float32, float32) {
+	switch win.rotation.Get().(int) {
+	case 90:
+		return y, 1.0 - x
+	case 180:
+		return 1.0 - x, 1.0 - y
+	case 270:
+		return 1.0 - y, x
+	}
+	return x, y
+}
+
 func (win *winPlayScr) getScaledWidth() float32 {
 	// must be called from with a critical section
+	if win.isSideways() {
+		return float32(win.scr.crit.cropPixels.Bounds().Size().Y) * win.getScaling(false)
+	}
 	return float32(win.scr.crit.cropPixels.Bounds().Size().X) * win.getScaling(true)
 }
 
 func (win *winPlayScr) getScaledHeight() float32 {
 	// must be called from with a critical section
+	if win.isSideways() {
+		return float32(win.scr.crit.cropPixels.Bounds().Size().X) * win.getScaling(true)
+	}
 	return float32(win.scr.crit.cropPixels.Bounds().Size().Y) * win.getScaling(false)
 }
 
@@ -189,20 +445,48 @@ func (win *winPlayScr) setScaleFromWindow(sz imgui.Vec2) {
 	imageW := float32(win.scr.crit.cropPixels.Bounds().Size().X)
 	imageH := float32(win.scr.crit.cropPixels.Bounds().Size().Y)
 	imageW *= pixelWidth * win.scr.aspectBias
+
+	// scaling is calculated with respect to the unrotated image so we need to
+	// swap the dimensions of the window if the screen is on its side
+	if win.isSideways() {
+		sz.X, sz.Y = sz.Y, sz.X
+		winAspectRatio = sz.X / sz.Y
+	}
+
 	aspectRatio := imageW / imageH
 
-	if aspectRatio < winAspectRatio {
+	// letterbox scales the image to the smaller of the two dimensions and crop
+	// scales the image to the larger. in the case of crop the padding will be
+	// negative and the image will overflow the window on both sides
+	fitHeight := aspectRatio < winAspectRatio
+	method := win.fit.method.Get().(string)
+	if method == fitCrop {
+		fitHeight = !fitHeight
+	}
+
+	win.stretch = 1.0
+
+	var padding imgui.Vec2
+	if method == fitStretch {
+		win.scaling = sz.Y / imageH
+		win.stretch = sz.X / (imageW * win.scaling)
+	} else if fitHeight {
 		win.scaling = sz.Y / imageH
-		win.imagePadding = imgui.Vec2{X: float32(int((sz.X - (imageW * win.scaling)) / 2))}
+		padding = imgui.Vec2{X: float32(int((sz.X - (imageW * win.scaling)) / 2))}
 	} else {
 		win.scaling = sz.X / imageW
-		win.imagePadding = imgui.Vec2{Y: float32(int((sz.Y - (imageH * win.scaling)) / 2))}
+		padding = imgui.Vec2{Y: float32(int((sz.Y - (imageH * win.scaling)) / 2))}
 	}
+
+	if win.isSideways() {
+		padding.X, padding.Y = padding.Y, padding.X
+	}
+	win.imagePadding = padding
 }
 
 func (win *winPlayScr) getScaling(horiz bool) float32 {
 	if horiz {
-		return pixelWidth * win.scr.aspectBias * win.scaling
+		return pixelWidth * win.scr.aspectBias * win.scaling * win.stretch
 	}
 	return win.scaling
 }
@@ -211,3 +495,14 @@ func (win *winPlayScr) setScaling(scaling float32) {
 	win.winDim = win.winDim.Times(scaling / win.scaling)
 	win.img.plt.window.SetSize(int32(win.winDim.X), int32(win.winDim.Y))
 }
+
+// setRotation changes the rotation of the screen. value should be in degrees
+// and one of 0, 90, 180 or 270.
+func (win *winPlayScr) setRotation(rotation int) error {
+	switch rotation {
+	case 0, 90, 180, 270:
+	default:
+		return curated.Errorf("unsupported screen rotation (%d)", rotation)
+	}
+	return win.rotation.Set(rotation)
+}
@@ -0,0 +1,103 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// the methods by which the playmode screen image is fitted to the window when
+// the aspect ratio of the window does not match the aspect ratio of the image.
+const (
+	// the image is scaled to fill the window in both dimensions. the image
+	// will be distorted.
+	fitStretch = "STRETCH"
+
+	// the image is scaled to fit inside the window with the correct aspect
+	// ratio. the unfilled area is drawn in the border color.
+	fitLetterbox = "LETTERBOX"
+
+	// the image is scaled to fill the window with the correct aspect ratio.
+	// parts of the image will be outside the window.
+	fitCrop = "CROP"
+)
+
+// the default border color for the letterbox fitting method.
+const defaultBorderColor = "#000000"
+
+// displayFit is the method by which the screen image is fitted to the window
+// and the color of any border around the image.
+type displayFit struct {
+	method prefs.String
+	border prefs.String
+
+	// border color as parsed from the border preference
+	borderCol imgui.Vec4
+}
+
+func (fit *displayFit) init() {
+	fit.border.RegisterCallback(func(v prefs.Value) error {
+		col, err := parseBorderColor(v.(string))
+		if err != nil {
+			return err
+		}
+		fit.borderCol = col
+		return nil
+	})
+
+	_ = fit.method.Set(fitLetterbox)
+	_ = fit.border.Set(defaultBorderColor)
+}
+
+// parseBorderColor parses a color in the #rrggbb form.
+func parseBorderColor(s string) (imgui.Vec4, error) {
+	var r, g, b uint8
+	if len(s) != 7 {
+		return imgui.Vec4{}, curated.Errorf("border color should be in the form #rrggbb (%s)", s)
+	}
+	_, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b)
+	if err != nil {
+		return imgui.Vec4{}, curated.Errorf("border color should be in the form #rrggbb (%s)", s)
+	}
+	return imgui.Vec4{X: float32(r) / 255, Y: float32(g) / 255, Z: float32(b) / 255, W: 1.0}, nil
+}
+
+// setDisplayFit changes the method by which the screen image is fitted to the
+// window. one of STRETCH, LETTERBOX or CROP.
+func (win *winPlayScr) setDisplayFit(method string) error {
+	method = strings.ToUpper(method)
+	switch method {
+	case fitStretch, fitLetterbox, fitCrop:
+	default:
+		return curated.Errorf("unsupported display fit (%s)", method)
+	}
+	return win.fit.method.Set(method)
+}
+
+// setBorderColor changes the color of the border around the letterboxed screen
+// image. color should be in the #rrggbb form.
+func (win *winPlayScr) setBorderColor(col string) error {
+	col = strings.ToLower(col)
+	if _, err := parseBorderColor(col); err != nil {
+		return err
+	}
+	return win.fit.border.Set(col)
+}
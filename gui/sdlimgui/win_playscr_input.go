@@ -0,0 +1,109 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+// dimensions of the input display.
+const (
+	inputDisplayPadding = 8.0
+	inputDisplayButton  = 14.0
+)
+
+// drawInputDisplay draws the state of the joystick and panel in the bottom
+// left corner of the most recently drawn imgui item. it should be called
+// immediately after the screen image has been drawn.
+func (win *winPlayScr) drawInputDisplay() {
+	win.scr.crit.section.Lock()
+	inp := win.scr.crit.input
+	win.scr.crit.section.Unlock()
+
+	on := imgui.PackedColorFromVec4(win.img.cols.InputDisplayOn)
+	off := imgui.PackedColorFromVec4(win.img.cols.InputDisplayOff)
+	col := func(b bool) imgui.PackedColor {
+		if b {
+			return on
+		}
+		return off
+	}
+
+	const btn = inputDisplayButton
+	const pad = inputDisplayPadding
+
+	// the labels of the panel switches
+	reset := "RESET"
+	sel := "SELECT"
+	color := "COLOR"
+	if !inp.color() {
+		color = "B/W"
+	}
+	p0 := "P0 B"
+	if inp.p0Pro() {
+		p0 = "P0 A"
+	}
+	p1 := "P1 B"
+	if inp.p1Pro() {
+		p1 = "P1 A"
+	}
+
+	// width of panel switches is the width of the widest label
+	textDim := imguiGetFrameDim(sel)
+	switchWidth := textDim.X
+	switchHeight := textDim.Y
+
+	// size of the entire display
+	dim := imgui.Vec2{
+		X: pad*5 + btn*5 + (switchWidth+2)*5 - 2,
+		Y: pad*2 + btn*3,
+	}
+
+	// position display in the bottom left corner of the screen image
+	origin := imgui.Vec2{X: imgui.ItemRectMin().X + pad, Y: imgui.ItemRectMax().Y - pad - dim.Y}
+
+	dl := imgui.WindowDrawList()
+	dl.AddRectFilledV(origin, origin.Plus(dim), imgui.PackedColorFromVec4(win.img.cols.InputDisplayBg), 4.0, imgui.DrawCornerFlagsAll)
+
+	// joystick directions are arranged in a cross
+	stick := origin.Plus(imgui.Vec2{X: pad, Y: pad})
+	square := func(x, y float32, b bool) {
+		min := stick.Plus(imgui.Vec2{X: x * btn, Y: y * btn})
+		dl.AddRectFilled(min, min.Plus(imgui.Vec2{X: btn - 1, Y: btn - 1}), col(b))
+	}
+	square(1, 0, inp.up())
+	square(0, 1, inp.left())
+	square(2, 1, inp.right())
+	square(1, 2, inp.down())
+
+	// fire button to the right of the joystick
+	fire := stick.Plus(imgui.Vec2{X: btn*3 + pad + btn, Y: btn * 1.5})
+	dl.AddCircleFilled(fire, btn, col(inp.fire()))
+
+	// panel switches in a row to the right of the fire button
+	sw := imgui.Vec2{X: fire.X + btn + pad*2, Y: origin.Y + (dim.Y-switchHeight)/2}
+	panel := func(label string, b bool) {
+		dl.AddRectFilledV(sw, sw.Plus(imgui.Vec2{X: switchWidth, Y: switchHeight}), col(b), 2.0, imgui.DrawCornerFlagsAll)
+		imgui.SetCursorScreenPos(sw.Plus(imgui.Vec2{X: (switchWidth - imguiGetFrameDim(label).X) / 2, Y: imgui.CurrentStyle().FramePadding().Y}))
+		imgui.Text(label)
+		sw.X += switchWidth + 2
+	}
+	panel(reset, inp.reset())
+	panel(sel, inp.sel())
+	panel(color, inp.color())
+	panel(p0, inp.p0Pro())
+	panel(p1, inp.p1Pro())
+}
@@ -0,0 +1,236 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// list of items in the pause menu. the order of the list is the order in
+// which the items are drawn.
+const (
+	pauseResume = iota
+	pauseResetConsole
+	pauseSwapController
+	pauseSwapPorts
+	pauseSaveState
+	pauseLoadState
+	pauseResumeSuspend
+	pauseScreenshot
+	pauseCRT
+	pauseFocusLoss
+	pauseQuit
+	numPauseItems
+)
+
+// pauseMenu is the menu shown over the playmode screen when the emulation
+// has been paused by the user.
+type pauseMenu struct {
+	open bool
+
+	// the currently highlighted item. one of the pause* values
+	selected int
+}
+
+// sendEvent sends an event to the emulation. events are dropped if the event
+// channel is full.
+func (win *winPlayScr) sendEvent(ev gui.Event) {
+	if win.img.events == nil {
+		return
+	}
+
+	select {
+	case win.img.events <- ev:
+	default:
+		logger.Log("sdlimgui", fmt.Sprintf("dropped %T event", ev))
+	}
+}
+
+// setPauseMenu opens or closes the pause menu. the emulation is paused for
+// as long as the menu is open.
+func (win *winPlayScr) setPauseMenu(open bool) {
+	if open == win.pause.open {
+		return
+	}
+
+	win.pause.open = open
+	win.pause.selected = pauseResume
+	win.sendEvent(gui.EventPause{Pause: open})
+
+	// a captured mouse would prevent the menu from being used
+	if open {
+		win.img.setCapture(false)
+	}
+}
+
+// pauseMenuKey handles a key press while the pause menu is open.
+func (win *winPlayScr) pauseMenuKey(key string) {
+	switch key {
+	case "Up":
+		win.pause.selected--
+		if win.pause.selected < 0 {
+			win.pause.selected = numPauseItems - 1
+		}
+	case "Down":
+		win.pause.selected++
+		if win.pause.selected >= numPauseItems {
+			win.pause.selected = 0
+		}
+	case "Return", "Space":
+		win.pauseMenuSelect(win.pause.selected)
+	}
+}
+
+// pauseMenuSelect performs the action for the pause menu item. actions that
+// change the state of the emulation also close the menu.
+func (win *winPlayScr) pauseMenuSelect(item int) {
+	switch item {
+	case pauseResume:
+		win.setPauseMenu(false)
+	case pauseResetConsole:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionResetConsole})
+		win.setPauseMenu(false)
+	case pauseSwapController:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionSwapController})
+	case pauseSwapPorts:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionSwapPorts})
+	case pauseSaveState:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionSaveState})
+	case pauseLoadState:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionLoadState})
+		win.setPauseMenu(false)
+	case pauseResumeSuspend:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionResumeSuspend})
+		win.setPauseMenu(false)
+	case pauseScreenshot:
+		win.sendEvent(gui.EventPlaymodeAction{Action: gui.ActionScreenshot})
+	case pauseCRT:
+		win.img.wm.dbgScr.crt = !win.img.wm.dbgScr.crt
+	case pauseFocusLoss:
+		err := win.pauseOnFocusLoss.Set(!win.pauseOnFocusLoss.Get().(bool))
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	case pauseQuit:
+		win.sendEvent(gui.EventQuit{})
+	}
+}
+
+// the label for each item in the pause menu.
+func (win *winPlayScr) pauseMenuLabel(item int) string {
+	switch item {
+	case pauseResume:
+		return "Resume"
+	case pauseResetConsole:
+		return "Reset Console"
+	case pauseSwapController:
+		return "Swap Controller"
+	case pauseSwapPorts:
+		return "Swap Ports"
+	case pauseSaveState:
+		return "Save State"
+	case pauseLoadState:
+		return "Load State"
+	case pauseResumeSuspend:
+		return "Resume Suspend Point"
+	case pauseScreenshot:
+		return "Screenshot"
+	case pauseCRT:
+		if win.img.wm.dbgScr.crt {
+			return "CRT Effects: On"
+		}
+		return "CRT Effects: Off"
+	case pauseFocusLoss:
+		if win.pauseOnFocusLoss.Get().(bool) {
+			return "Pause on Focus Loss: On"
+		}
+		return "Pause on Focus Loss: Off"
+	case pauseQuit:
+		return "Quit"
+	}
+	return ""
+}
+
+// drawPauseMenu draws the pause menu in the centre of the playmode window.
+// the menu can be navigated with the cursor keys or with the mouse.
+func (win *winPlayScr) drawPauseMenu() {
+	if !win.pause.open {
+		return
+	}
+
+	width := imguiGetFrameDim("Pause on Focus Loss: Off").X * 1.5
+	height := imgui.FrameHeightWithSpacing() * (numPauseItems + 2)
+
+	imgui.SetNextWindowPosV(imgui.Vec2{X: win.winDim.X / 2, Y: win.winDim.Y / 2}, imgui.ConditionAlways, imgui.Vec2{X: 0.5, Y: 0.5})
+	imgui.SetNextWindowSizeV(imgui.Vec2{X: width, Y: height}, imgui.ConditionAlways)
+	imgui.PushStyleColor(imgui.StyleColorWindowBg, win.img.cols.InputDisplayBg)
+	imgui.BeginV("Paused", nil, imgui.WindowFlagsNoResize|imgui.WindowFlagsNoMove|
+		imgui.WindowFlagsNoCollapse|imgui.WindowFlagsNoSavedSettings)
+
+	for i := 0; i < numPauseItems; i++ {
+		if imgui.SelectableV(win.pauseMenuLabel(i), win.pause.selected == i, 0, imgui.Vec2{}) {
+			win.pause.selected = i
+			win.pauseMenuSelect(i)
+		}
+	}
+
+	imgui.End()
+	imgui.PopStyleColor()
+}
+
+// focusLost pauses the emulation if the pauseOnFocusLoss preference is set.
+// the emulation is not paused again if the pause menu is already open.
+func (win *winPlayScr) focusLost() {
+	if !win.pauseOnFocusLoss.Get().(bool) || win.pause.open || win.focusPaused {
+		return
+	}
+
+	win.focusPaused = true
+	win.sendEvent(gui.EventPause{Pause: true})
+	win.img.audio.Suspend(true)
+}
+
+// focusGained resumes the emulation if it was paused by focusLost().
+func (win *winPlayScr) focusGained() {
+	if !win.focusPaused {
+		return
+	}
+
+	win.focusPaused = false
+	win.sendEvent(gui.EventPause{Pause: false})
+	win.img.audio.Suspend(false)
+}
+
+// drawFocusPaused indicates that the emulation has been paused because the
+// window has lost focus.
+func (win *winPlayScr) drawFocusPaused() {
+	if !win.focusPaused {
+		return
+	}
+
+	const msg = "Paused"
+	dim := imguiGetFrameDim(msg)
+	pos := imgui.WindowPos().Plus(imgui.Vec2{X: (win.winDim.X - dim.X) / 2, Y: (win.winDim.Y - dim.Y) / 2})
+
+	imgui.SetCursorScreenPos(pos)
+	imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.EventFlash)
+	imgui.Text(msg)
+	imgui.PopStyleColor()
+}
@@ -19,7 +19,12 @@ import (
 	"fmt"
 
 	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/gui/sdlaudio"
+	"github.com/jetsetilly/gopher2600/hardware/television/phosphor"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
 	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
 )
 
 const winPrefsTile = "Preferences"
@@ -27,6 +32,10 @@ const winPrefsTile = "Preferences"
 type winPrefs struct {
 	windowManagement
 	img *SdlImgui
+
+	// the location of the preferences on disk. shown in the directories tab
+	configDir string
+	prefsFile string
 }
 
 func newWinPrefs(img *SdlImgui) (managedWindow, error) {
@@ -38,6 +47,17 @@ func newWinPrefs(img *SdlImgui) (managedWindow, error) {
 }
 
 func (win *winPrefs) init() {
+	var err error
+
+	win.configDir, err = paths.ResourcePath("", "")
+	if err != nil {
+		logger.Log("sdlimgui", fmt.Sprintf("could not find preferences directory: %v", err))
+	}
+
+	win.prefsFile, err = paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		logger.Log("sdlimgui", fmt.Sprintf("could not find preferences file: %v", err))
+	}
 }
 
 func (win *winPrefs) destroy() {
@@ -55,22 +75,191 @@ func (win *winPrefs) draw() {
 	imgui.SetNextWindowPosV(imgui.Vec2{10, 10}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.BeginV(winPrefsTile, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
-	win.drawGeneral()
+	if imgui.BeginTabBar("prefs") {
+		if imgui.BeginTabItem("Television") {
+			win.drawTelevision()
+			imgui.EndTabItem()
+		}
+
+		if imgui.BeginTabItem("CRT") {
+			win.img.wm.crtPrefs.drawSettings()
+			imgui.EndTabItem()
+		}
+
+		if imgui.BeginTabItem("Audio") {
+			win.drawAudio()
+			imgui.EndTabItem()
+		}
+
+		if imgui.BeginTabItem("Input") {
+			win.drawInput()
+			imgui.EndTabItem()
+		}
+
+		if imgui.BeginTabItem("Debugger") {
+			win.drawDebugger()
+			imgui.Spacing()
+			imgui.Separator()
+			imgui.Spacing()
+			imgui.Text("Rewind")
+			imgui.Spacing()
+			win.drawRewind()
+			imgui.EndTabItem()
+		}
+
+		if imgui.BeginTabItem("Directories") {
+			win.drawDirectories()
+			imgui.EndTabItem()
+		}
+
+		imgui.EndTabBar()
+	}
+
 	imgui.Spacing()
 	imgui.Separator()
 	imgui.Spacing()
 
-	imgui.Text("Rewind")
+	win.drawDiskButtons()
+
+	imgui.End()
+}
+
+func (win *winPrefs) drawTelevision() {
+	if imgui.BeginCombo("Specification##spec", win.img.lz.TV.Spec.ID) {
+		for _, s := range append([]string{"AUTO"}, specification.SpecList...) {
+			if imgui.Selectable(s) {
+				win.img.term.pushCommand(fmt.Sprintf("TV SPEC %s", s))
+			}
+		}
+		imgui.EndCombo()
+	}
+
 	imgui.Spacing()
-	win.drawRewind()
+	imgui.Spacing()
+
+	highContrast := win.img.screen.highContrast.Get().(bool)
+	if imgui.Checkbox("High Contrast Debug Colors", &highContrast) {
+		err := win.img.screen.highContrast.Set(highContrast)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	statsHUD := win.img.screen.statsHUD.Get().(bool)
+	if imgui.Checkbox("Show Frame Statistics (F8)", &statsHUD) {
+		err := win.img.screen.statsHUD.Set(statsHUD)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
 
 	imgui.Spacing()
-	imgui.Separator()
 	imgui.Spacing()
 
-	win.drawDiskButtons()
+	win.drawPhosphor()
 
-	imgui.End()
+	imgui.Spacing()
+	imgui.Spacing()
+
+	win.drawComposite()
+
+	imgui.Spacing()
+	imgui.Spacing()
+
+	win.img.wm.dbgScr.drawSafeAreaSettings()
+}
+
+func (win *winPrefs) drawPhosphor() {
+	p := win.img.screen.phosphor.Get().(bool)
+	if imgui.Checkbox("Phosphor Persistence", &p) {
+		err := win.img.screen.phosphor.Set(p)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	if !p {
+		return
+	}
+
+	f := int32(win.img.screen.phosphorFrames.Get().(int))
+	if imgui.SliderIntV("Frames##phosphorFrames", &f, phosphor.MinFrames, phosphor.MaxFrames, "%d") {
+		err := win.img.screen.phosphorFrames.Set(f)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	d := float32(win.img.screen.phosphorDecay.Get().(float64))
+	if imgui.SliderFloatV("Decay##phosphorDecay", &d, 0.0, 1.0, "%.2f", 1.0) {
+		err := win.img.screen.phosphorDecay.Set(d)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	imgui.Spacing()
+	imguiIndentText("Mixes each frame with the previous frames.")
+	imguiIndentText("Useful for games that flicker sprites.")
+}
+
+func (win *winPrefs) drawComposite() {
+	c := win.img.screen.composite.Get().(bool)
+	if imgui.Checkbox("Composite Artifacts", &c) {
+		err := win.img.screen.composite.Set(c)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	imgui.Spacing()
+	imguiIndentText("Emulates the color fringing of an NTSC composite")
+	imguiIndentText("connection. Has no effect on PAL ROMs.")
+}
+
+func (win *winPrefs) drawInput() {
+	// the controller drawing requires that both controllers are plugged in
+	if win.img.lz.Controllers.Player0 == nil || win.img.lz.Controllers.Player1 == nil {
+		imgui.Text("Controllers are not available")
+	} else {
+		imgui.BeginGroup()
+		imgui.Text("Player 0")
+		imgui.Spacing()
+		win.img.wm.controllers.drawController(0)
+		imgui.EndGroup()
+
+		imgui.SameLine()
+
+		imgui.BeginGroup()
+		imgui.Text("Player 1")
+		imgui.Spacing()
+		win.img.wm.controllers.drawController(1)
+		imgui.EndGroup()
+	}
+
+	imgui.Spacing()
+	imgui.Spacing()
+
+	pause := win.img.wm.playScr.pauseOnFocusLoss.Get().(bool)
+	if imgui.Checkbox("Pause playmode when window loses focus", &pause) {
+		err := win.img.wm.playScr.pauseOnFocusLoss.Set(pause)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+}
+
+func (win *winPrefs) drawDirectories() {
+	imgui.Text("Preferences directory")
+	imguiIndentText(win.configDir)
+	if paths.IsPortable() {
+		imguiIndentText("(portable mode)")
+	}
+
+	imgui.Spacing()
+
+	imgui.Text("Preferences file")
+	imguiIndentText(win.prefsFile)
 }
 
 func (win *winPrefs) drawRewind() {
@@ -96,7 +285,68 @@ func (win *winPrefs) drawRewind() {
 	imguiIndentText("rewind controls to feel sluggish.")
 }
 
-func (win *winPrefs) drawGeneral() {
+func (win *winPrefs) drawAudio() {
+	pan0 := float32(win.img.lz.Prefs.AudioPan0)
+	pan1 := float32(win.img.lz.Prefs.AudioPan1)
+	changed := imgui.SliderFloatV("Channel 0 Pan##pan0", &pan0, -1.0, 1.0, "%.2f", 1.0)
+	changed = imgui.SliderFloatV("Channel 1 Pan##pan1", &pan1, -1.0, 1.0, "%.2f", 1.0) || changed
+	if changed {
+		win.img.term.pushCommand(fmt.Sprintf("PREFS STEREO PAN %.2f %.2f", pan0, pan1))
+	}
+
+	if imgui.Button("Mono") {
+		win.img.term.pushCommand("PREFS STEREO MONO")
+	}
+	imgui.SameLine()
+	if imgui.Button("Stereo Mod") {
+		win.img.term.pushCommand("PREFS STEREO MOD")
+	}
+
+	imgui.Spacing()
+	imgui.Spacing()
+
+	v := int32(win.img.audio.Volume.Get().(int))
+	if imgui.SliderIntV("Volume##volume", &v, sdlaudio.MinVolume, sdlaudio.MaxVolume, "%d%%") {
+		err := win.img.audio.Volume.Set(v)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	m := win.img.audio.Mute.Get().(bool)
+	if imgui.Checkbox("Mute##mute", &m) {
+		err := win.img.audio.Mute.Set(m)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	imgui.Spacing()
+	imguiIndentText("The volume can also be changed with the")
+	imguiIndentText("minus and equals keys. Press M to mute.")
+
+	imgui.Spacing()
+	imgui.Spacing()
+
+	w := int32(win.img.audio.ScrubWindow.Get().(int))
+	label := fmt.Sprintf("%dms", w)
+	if w == 0 {
+		label = "step only"
+	}
+	if imgui.SliderIntV("Scrub Window##scrubwindow", &w, 0, 500, label) {
+		err := win.img.audio.ScrubWindow.Set(w)
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not set preference value: %v", err))
+		}
+	}
+
+	imgui.Spacing()
+	imguiIndentText("Audio generated while stepping the emulation")
+	imguiIndentText("is played when the emulation halts. The scrub")
+	imguiIndentText("window is the minimum length of audio played.")
+}
+
+func (win *winPrefs) drawDebugger() {
 	if imgui.Checkbox("Random State (on startup)", &win.img.lz.Prefs.RandomState) {
 		win.img.term.pushCommand("PREFS TOGGLE RANDSTART")
 	}
@@ -105,6 +355,10 @@ func (win *winPrefs) drawGeneral() {
 		win.img.term.pushCommand("PREFS TOGGLE RANDPINS")
 	}
 
+	if imgui.Checkbox("Lenient RIOT Timer", &win.img.lz.Prefs.LenientTimer) {
+		win.img.term.pushCommand("PREFS TOGGLE LENIENTTIMER")
+	}
+
 	if imgui.Checkbox("Use Fxxx Mirror", &win.img.lz.Prefs.FxxxMirror) {
 		win.img.term.pushCommand("PREFS TOGGLE FXXXMIRROR")
 	}
@@ -128,20 +382,33 @@ func (win *winPrefs) drawGeneral() {
 }
 
 func (win *winPrefs) drawDiskButtons() {
+	imguiIndentText("Changes take effect immediately. Save to keep")
+	imguiIndentText("them or Revert to restore the saved preferences.")
+
+	imgui.Spacing()
+
 	if imgui.Button("Save") {
 		err := win.img.prefs.save()
 		if err != nil {
 			logger.Log("sdlimgui", fmt.Sprintf("could not save preferences: %v", err))
 		}
+		err = win.img.crtPrefs.Save()
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not save crt settings: %v", err))
+		}
 		win.img.term.pushCommand("PREFS SAVE")
 	}
 
 	imgui.SameLine()
-	if imgui.Button("Restore") {
+	if imgui.Button("Revert") {
 		err := win.img.prefs.load()
 		if err != nil {
 			logger.Log("sdlimgui", fmt.Sprintf("could not restore preferences: %v", err))
 		}
+		err = win.img.crtPrefs.Load()
+		if err != nil {
+			logger.Log("sdlimgui", fmt.Sprintf("could not restore crt settings: %v", err))
+		}
 		win.img.term.pushCommand("PREFS LOAD")
 	}
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/inkyblackness/imgui-go/v2"
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/logger"
 )
 
 const winRAMTitle = "RAM"
@@ -52,6 +53,8 @@ func (win *winRAM) draw() {
 		return
 	}
 
+	win.img.lz.RAM.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{890, 29}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.BeginV(winRAMTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
@@ -98,14 +101,17 @@ func (win *winRAM) draw() {
 			if v, err := strconv.ParseUint(b, 16, 8); err == nil {
 				a := addr // we have to make a copy of the address
 				win.img.lz.Dbg.PushRawEvent(func() {
-					win.img.lz.Dbg.VCS.Mem.Write(a, uint8(v))
+					err := win.img.lz.Dbg.PokeWithUndo(a, uint8(v))
+					if err != nil {
+						logger.Log("sdlimgui", err.Error())
+					}
 				})
 			}
 		}
 
 		// undo any color changes
 		if imgui.IsItemHovered() {
-			win.drawSnapshotInfo(d, e)
+			win.drawTooltip(i, d, e)
 		}
 
 		if d != e {
@@ -119,8 +125,25 @@ func (win *winRAM) draw() {
 	imgui.End()
 }
 
-func (win *winRAM) drawSnapshotInfo(current, snapshot uint8) {
+// drawTooltip shows the difference between the current value and the value
+// in the comparison snapshot, and the instruction that last wrote to the
+// address.
+func (win *winRAM) drawTooltip(idx int, current, snapshot uint8) {
 	imgui.BeginTooltip()
 	imgui.Text(fmt.Sprintf("%02x -> %02x", snapshot, current))
+
+	if idx < len(win.img.lz.RAM.Blame) {
+		b := win.img.lz.RAM.Blame[idx]
+		imgui.Spacing()
+		imgui.Separator()
+		imgui.Spacing()
+		if b.Valid {
+			imgui.Text(fmt.Sprintf("written by %s at %#04x (bank %s)", b.Mnemonic, b.PC, b.Bank))
+			imgui.Text(b.Coords.String())
+		} else {
+			imgui.Text("not written")
+		}
+	}
+
 	imgui.EndTooltip()
 }
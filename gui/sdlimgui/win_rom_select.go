@@ -24,7 +24,9 @@ import (
 
 	"github.com/inkyblackness/imgui-go/v2"
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/catalogue"
 	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/playstats"
 )
 
 const winSelectROMTitle = "Select ROM"
@@ -38,6 +40,16 @@ type winSelectROM struct {
 	err      error
 
 	selectedFile string
+
+	// the ROM catalogue is loaded (if it exists) every time the window is
+	// opened. nil if there is no catalogue
+	cat *catalogue.Catalogue
+
+	// play statistics for the selected file. statsFile is the file the
+	// statistics were loaded for
+	stats     *playstats.Stats
+	statsFile string
+
 	showAllFiles bool
 	showHidden   bool
 
@@ -178,9 +190,32 @@ func (win *winSelectROM) draw() {
 				imgui.SetScrollHereY(0.0)
 			}
 
-			if imgui.SelectableV(f.Name(), selected, 0, imgui.Vec2{0, 0}) {
+			var entry catalogue.Entry
+			var catalogued bool
+			if win.cat != nil {
+				entry, catalogued = win.cat.Lookup(filepath.Join(win.currPath, f.Name()))
+			}
+
+			label := f.Name()
+			if catalogued {
+				label = win.catalogueLabel(label, entry)
+				if len(entry.Problems) > 0 {
+					imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.ROMSelectProblem)
+				}
+			}
+
+			if imgui.SelectableV(label, selected, 0, imgui.Vec2{0, 0}) {
 				win.selectedFile = filepath.Join(win.currPath, f.Name())
 			}
+
+			if catalogued {
+				if len(entry.Problems) > 0 {
+					imgui.PopStyleColor()
+				}
+				if imgui.IsItemHovered() {
+					win.catalogueTooltip(entry)
+				}
+			}
 		}
 	}
 	imgui.PopStyleColor()
@@ -190,6 +225,17 @@ func (win *winSelectROM) draw() {
 	// control buttons. start controlHeight measurement
 	controlHeight := imgui.CursorPosY()
 
+	// play statistics for selected file
+	if win.selectedFile != "" {
+		win.updateStats()
+		if win.stats != nil && win.stats.Sessions > 0 {
+			imgui.Text(fmt.Sprintf("Played: %s", win.stats))
+			imgui.SameLine()
+			imgui.Text(fmt.Sprintf("(last %s)", win.stats.LastPlayed.Format("2006-01-02")))
+			imgui.Spacing()
+		}
+	}
+
 	imgui.Checkbox("Show all files", &win.showAllFiles)
 	imgui.SameLine()
 	imgui.Checkbox("Show hidden entries", &win.showHidden)
@@ -229,6 +275,84 @@ func (win *winSelectROM) draw() {
 	imgui.End()
 }
 
+// updateStats loads the play statistics for the selected file if they have not
+// already been loaded.
+func (win *winSelectROM) updateStats() {
+	if win.selectedFile == win.statsFile {
+		return
+	}
+	win.statsFile = win.selectedFile
+	win.stats = nil
+
+	// the hash of the file is required to find the statistics. the catalogue
+	// saves us from loading the file if it has been catalogued
+	var hash string
+	if win.cat != nil {
+		if e, ok := win.cat.Lookup(win.selectedFile); ok {
+			hash = e.Hash
+		}
+	}
+	if hash == "" {
+		cartload := cartridgeloader.NewLoader(win.selectedFile, "AUTO")
+		if err := cartload.Load(); err != nil {
+			return
+		}
+		hash = cartload.Hash
+	}
+
+	st, err := playstats.Load(hash)
+	if err != nil {
+		logger.Log("sdlimgui", err.Error())
+		return
+	}
+	win.stats = st
+}
+
+// catalogueLabel adds the mapping and duplicate/problem markers to the
+// filename.
+func (win *winSelectROM) catalogueLabel(name string, entry catalogue.Entry) string {
+	s := strings.Builder{}
+	s.WriteString(name)
+	if entry.Mapping != "" {
+		s.WriteString(fmt.Sprintf(" [%s]", entry.Mapping))
+	}
+	if len(entry.Duplicates) > 0 {
+		s.WriteString(" [dup]")
+	}
+	if len(entry.Problems) > 0 {
+		s.WriteString(" [!]")
+	}
+	return s.String()
+}
+
+func (win *winSelectROM) catalogueTooltip(entry catalogue.Entry) {
+	imgui.BeginTooltip()
+	defer imgui.EndTooltip()
+
+	if entry.Mapping != "" {
+		imgui.Text(fmt.Sprintf("Mapping: %s", entry.Mapping))
+	}
+	imgui.Text(fmt.Sprintf("Size: %d bytes", entry.Size))
+	imgui.Text(fmt.Sprintf("SHA1: %s", entry.Hash))
+
+	if len(entry.Duplicates) > 0 {
+		imgui.Spacing()
+		imgui.Text("Duplicates:")
+		for _, d := range entry.Duplicates {
+			imgui.Text(fmt.Sprintf("  %s", d))
+		}
+	}
+
+	if len(entry.Problems) > 0 {
+		imgui.Spacing()
+		imgui.PushStyleColor(imgui.StyleColorText, win.img.cols.ROMSelectProblem)
+		for _, p := range entry.Problems {
+			imgui.Text(p)
+		}
+		imgui.PopStyleColor()
+	}
+}
+
 func (win *winSelectROM) setPath(path string) error {
 	var err error
 
@@ -257,6 +381,22 @@ func (win *winSelectROM) setOpen(open bool) {
 		}
 		win.selectedFile = win.img.lz.Cart.Filename
 
+		// play statistics may have changed since the window was last open
+		win.statsFile = ""
+		win.stats = nil
+
+		// load catalogue if it exists. the catalogue is created by the
+		// CATALOGUE mode from the command line
+		win.cat = nil
+		if pth, err := catalogue.DefaultPath(); err == nil {
+			if _, err := os.Stat(pth); err == nil {
+				win.cat, err = catalogue.Load(pth)
+				if err != nil {
+					logger.Log("sdlimgui", err.Error())
+				}
+			}
+		}
+
 		return
 	}
 
@@ -0,0 +1,125 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+	"github.com/jetsetilly/gopher2600/score"
+)
+
+const winScoreTitle = "Score"
+
+type winScore struct {
+	windowManagement
+	img *SdlImgui
+
+	// values for new field
+	label     string
+	format    score.Format
+	addresses string
+}
+
+func newWinScore(img *SdlImgui) (managedWindow, error) {
+	win := &winScore{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winScore) init() {
+}
+
+func (win *winScore) destroy() {
+}
+
+func (win *winScore) id() string {
+	return winScoreTitle
+}
+
+func (win *winScore) draw() {
+	if !win.open {
+		return
+	}
+
+	win.img.lz.Score.Demand()
+
+	imgui.SetNextWindowPosV(imgui.Vec2{632, 220}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.BeginV(winScoreTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
+
+	if len(win.img.lz.Score.Readings) == 0 {
+		imgui.Text("No score fields")
+	}
+
+	for _, r := range win.img.lz.Score.Readings {
+		imguiText(fmt.Sprintf("%s:", r.Label))
+		imgui.SameLine()
+		imgui.Text(r.Text)
+		if !r.Valid {
+			imgui.SameLine()
+			imgui.Text("[not BCD]")
+		}
+	}
+
+	if win.img.lz.Score.BestValid {
+		imgui.Spacing()
+		imguiText(fmt.Sprintf("Best: %d", win.img.lz.Score.Best))
+	}
+
+	imgui.Spacing()
+	imgui.Separator()
+	imgui.Spacing()
+
+	// new field. the field is added with the SCORE command
+	imguiText("Label")
+	imgui.SameLine()
+	imguiTextInput("##scorelabel", false, 10, &win.label, false)
+
+	imgui.SameLine()
+	imgui.PushItemWidth(imguiGetFrameDim("TIMER").X + imgui.FrameHeight())
+	if imgui.BeginComboV("##scoreformat", win.format.String(), imgui.ComboFlagNoArrowButton) {
+		for _, f := range []score.Format{score.FormatBCD, score.FormatTimer} {
+			if imgui.Selectable(f.String()) {
+				win.format = f
+			}
+		}
+		imgui.EndCombo()
+	}
+	imgui.PopItemWidth()
+
+	imguiText("Addresses")
+	imgui.SameLine()
+	imguiTextInput("##scoreaddresses", false, 30, &win.addresses, false)
+
+	if imgui.Button("Add") {
+		// labels are a single token in the SCORE command
+		label := strings.ReplaceAll(win.label, " ", "_")
+		addresses := strings.ReplaceAll(win.addresses, ",", " ")
+		if label != "" && strings.TrimSpace(addresses) != "" {
+			win.img.term.pushCommand(fmt.Sprintf("SCORE ADD %s %s %s", label, win.format, addresses))
+		}
+	}
+
+	imgui.SameLine()
+	if imgui.Button("Clear All") {
+		win.img.term.pushCommand("SCORE CLEAR")
+	}
+
+	imgui.End()
+}
@@ -75,6 +75,14 @@ func (win *winTIA) draw() {
 		return
 	}
 
+	// the tabs of the TIA window between them require all video objects
+	win.img.lz.Playfield.Demand()
+	win.img.lz.Player0.Demand()
+	win.img.lz.Player1.Demand()
+	win.img.lz.Missile0.Demand()
+	win.img.lz.Missile1.Demand()
+	win.img.lz.Ball.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{X: 31, Y: 512}, imgui.ConditionFirstUseEver, imgui.Vec2{X: 0, Y: 0})
 	imgui.SetNextWindowSizeV(imgui.Vec2{X: 558, Y: 201}, imgui.ConditionFirstUseEver)
 	imgui.BeginV(winTIATitle, &win.open, 0)
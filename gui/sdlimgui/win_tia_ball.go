@@ -57,8 +57,23 @@ func (win *winTIA) drawBall() {
 	if imgui.Checkbox("##enableddelay", &enbd) {
 		win.img.lz.Dbg.PushRawEvent(func() { bs.EnabledDelay = enbd })
 	}
+
+	imgui.SameLine()
+	imguiText("Vert. Delay")
+	vd := lz.VerticalDelay
+	if imgui.Checkbox("##vertdelay", &vd) {
+		win.img.lz.Dbg.PushRawEvent(func() { bs.VerticalDelay = vd })
+	}
 	imgui.EndGroup()
 
+	// which of the enable bits is being used for output. see comment in
+	// drawPlayer()
+	if lz.VerticalDelay {
+		imguiText("VDELBL set. Enabled Del. is being used")
+	} else {
+		imguiText("Enabled is being used")
+	}
+
 	imgui.Spacing()
 	imgui.Spacing()
 
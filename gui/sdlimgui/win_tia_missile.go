@@ -28,10 +28,12 @@ import (
 
 func (win *winTIA) drawMissile(missile int) {
 	lz := win.img.lz.Missile0
+	plz := win.img.lz.Player0
 	ms := win.img.lz.Missile0.Ms
 	ps := win.img.lz.Player0.Ps
 	if missile != 0 {
 		lz = win.img.lz.Missile1
+		plz = win.img.lz.Player1
 		ms = win.img.lz.Missile1.Ms
 		ps = win.img.lz.Player1.Ps
 	}
@@ -164,7 +166,12 @@ func (win *winTIA) drawMissile(missile int) {
 
 	// horizontal positioning
 	imgui.BeginGroup()
-	imgui.Text(fmt.Sprintf("Last reset at pixel %03d. First copy draws at pixel %03d", lz.ResetPixel, lz.HmovedPixel))
+	if lz.ResetToPlayer {
+		imgui.Text(fmt.Sprintf("Locked to player at pixel %03d (player +%d). Missile is hidden until released",
+			lz.HmovedPixel, video.ResetToPlayerOffset(plz.SizeAndCopies)))
+	} else {
+		imgui.Text(fmt.Sprintf("Last reset at pixel %03d. First copy draws at pixel %03d", lz.ResetPixel, lz.HmovedPixel))
+	}
 	if lz.MoreHmove {
 		imgui.SameLine()
 		imgui.Text("[currently moving]")
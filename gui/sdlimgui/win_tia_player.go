@@ -166,6 +166,17 @@ func (win *winTIA) drawPlayer(num int) {
 	imgui.Spacing()
 	imgui.Spacing()
 
+	// which of the gfx registers is being used for output. a common kernel
+	// bug is to leave VDEL set (or unset) so that the wrong one is drawn
+	if lz.VerticalDelay {
+		imguiText(fmt.Sprintf("VDELP%d set. Old Gfx is being drawn", num))
+	} else {
+		imguiText("New Gfx is being drawn")
+	}
+
+	imgui.Spacing()
+	imgui.Spacing()
+
 	// nusiz
 	imgui.BeginGroup()
 	imgui.PushItemWidth(win.playerSizeAndCopiesComboDim.X)
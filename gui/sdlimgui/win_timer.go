@@ -60,6 +60,8 @@ func (win *winTimer) draw() {
 		return
 	}
 
+	win.img.lz.Timer.Demand()
+
 	imgui.SetNextWindowPosV(imgui.Vec2{632, 514}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
 	imgui.BeginV(winTimerTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
 
@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package sdlimgui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inkyblackness/imgui-go/v2"
+)
+
+const winTypistTitle = "Keypad Typing"
+const menuTypistTitle = "Typing"
+
+type winTypist struct {
+	windowManagement
+	img *SdlImgui
+
+	// the text to be typed
+	text string
+
+	// whether to type a newline after the text
+	newline bool
+}
+
+func newWinTypist(img *SdlImgui) (managedWindow, error) {
+	win := &winTypist{
+		img: img,
+	}
+
+	return win, nil
+}
+
+func (win *winTypist) init() {
+}
+
+func (win *winTypist) destroy() {
+}
+
+func (win *winTypist) id() string {
+	return winTypistTitle
+}
+
+// keypadAttached returns true if either player has a keypad attached.
+func (win *winTypist) keypadAttached() bool {
+	if win.img.lz.Controllers.Player0 != nil && win.img.lz.Controllers.Player0.Name() == "Keyboard" {
+		return true
+	}
+	if win.img.lz.Controllers.Player1 != nil && win.img.lz.Controllers.Player1.Name() == "Keyboard" {
+		return true
+	}
+	return false
+}
+
+func (win *winTypist) draw() {
+	if !win.open {
+		return
+	}
+
+	if !win.keypadAttached() {
+		return
+	}
+
+	imgui.SetNextWindowPosV(imgui.Vec2{677, 438}, imgui.ConditionFirstUseEver, imgui.Vec2{0, 0})
+	imgui.BeginV(winTypistTitle, &win.open, imgui.WindowFlagsAlwaysAutoResize)
+
+	typeText := imguiTextInput("##typisttext", true, 40, &win.text, false)
+
+	imgui.SameLine()
+	if imgui.Button("Type") {
+		typeText = true
+	}
+
+	imgui.Checkbox("Newline after text", &win.newline)
+
+	if imgui.Button("Clear Pending") {
+		win.img.term.pushCommand("TYPE CLEAR")
+	}
+
+	if typeText {
+		// the terminal has no way of escaping quotation marks so we remove
+		// them from the text before sending the command
+		s := strings.ReplaceAll(win.text, "\"", "")
+		if s != "" {
+			win.img.term.pushCommand(fmt.Sprintf("TYPE \"%s\"", s))
+		}
+		if win.newline {
+			win.img.term.pushCommand("TYPE NEWLINE")
+		}
+		win.text = ""
+	}
+
+	imgui.End()
+}
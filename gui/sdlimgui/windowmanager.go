@@ -68,11 +68,18 @@ type windowManager struct {
 	windowMenu map[string][]string
 
 	// some windows need to be referenced elsewhere
-	term     *winTerm
-	dbgScr   *winDbgScr
-	playScr  *winPlayScr
-	disasm   *winDisasm
-	crtPrefs *winCRTPrefs
+	term        *winTerm
+	dbgScr      *winDbgScr
+	playScr     *winPlayScr
+	disasm      *winDisasm
+	crtPrefs    *winCRTPrefs
+	magnify     *winMagnify
+	controllers *winControllers
+
+	// the title of the session popup to show. empty if no popup is to be
+	// shown. see drawSessionPopup()
+	sessionPopup string
+	sessionName  string
 
 	// the position of the screen on the current display. the SDL function
 	// Window.GetPosition() is unsuitable for use in conjunction with imgui
@@ -150,6 +157,9 @@ func newWindowManager(img *SdlImgui) (*windowManager, error) {
 	if err := addWindow(newWinTimer, true, windowMenuVCS); err != nil {
 		return nil, err
 	}
+	if err := addWindow(newWinFutures, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
 	if err := addWindow(newWinDisasm, true, windowMenuVCS); err != nil {
 		return nil, err
 	}
@@ -168,6 +178,30 @@ func newWindowManager(img *SdlImgui) (*windowManager, error) {
 	if err := addWindow(newWinChipRegisters, false, windowMenuVCS); err != nil {
 		return nil, err
 	}
+	if err := addWindow(newWinBanks, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinGraphics, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinMagnify, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinColorPicker, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinInstructionMix, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinScore, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinInputPolls, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
+	if err := addWindow(newWinBusActivity, false, windowMenuVCS); err != nil {
+		return nil, err
+	}
 
 	// windows that appear in cartridge specific menus
 	if err := addWindow(newWinDPCregisters, false, windowMenuCart); err != nil {
@@ -205,6 +239,11 @@ func newWindowManager(img *SdlImgui) (*windowManager, error) {
 		return nil, err
 	}
 
+	// keypad windows
+	if err := addWindow(newWinTypist, false, windowMenuOther); err != nil {
+		return nil, err
+	}
+
 	// associate cartridge types with cartridge specific menus. using cartridge
 	// ID as the key in the windowMenu map
 	//
@@ -222,6 +261,8 @@ func newWindowManager(img *SdlImgui) (*windowManager, error) {
 	wm.term = wm.windows[winTermTitle].(*winTerm)
 	wm.disasm = wm.windows[winDisasmTitle].(*winDisasm)
 	wm.crtPrefs = wm.windows[winCRTPrefsTitle].(*winCRTPrefs)
+	wm.magnify = wm.windows[winMagnifyTitle].(*winMagnify)
+	wm.controllers = wm.windows[winControllersTitle].(*winControllers)
 
 	// create play window. this is a very special window that never appears
 	// directly in an any menu
@@ -246,6 +287,31 @@ func (wm *windowManager) init() {
 	wm.hasInitialised = true
 }
 
+// openWindows returns the sorted list of titles of the currently open windows.
+func (wm *windowManager) openWindows() []string {
+	open := make([]string, 0, len(wm.windows))
+	for id, w := range wm.windows {
+		if w.isOpen() {
+			open = append(open, id)
+		}
+	}
+	sort.Strings(open)
+	return open
+}
+
+// setOpenWindows opens the windows in the list and closes all others. unknown
+// window titles are ignored.
+func (wm *windowManager) setOpenWindows(open []string) {
+	for _, w := range wm.windows {
+		w.setOpen(false)
+	}
+	for _, id := range open {
+		if w, ok := wm.windows[id]; ok {
+			w.setOpen(true)
+		}
+	}
+}
+
 func (wm *windowManager) destroy() {
 	for w := range wm.windows {
 		wm.windows[w].destroy()
@@ -259,6 +325,7 @@ func (wm *windowManager) draw() {
 		wm.init()
 
 		wm.drawMenu()
+		wm.drawSessionPopup()
 		for w := range wm.windows {
 			wm.windows[w].draw()
 		}
@@ -288,6 +355,11 @@ func (wm *windowManager) drawMenu() {
 		imgui.EndMenu()
 	}
 
+	// menus for debugger commands
+	wm.drawSessionMenu()
+	wm.drawEmulationMenu()
+	wm.drawHaltMenu()
+
 	// window menu
 	if imgui.BeginMenu(windowMenuVCS) {
 		for _, id := range wm.windowMenu[windowMenuVCS] {
@@ -348,6 +420,14 @@ func (wm *windowManager) drawMenu() {
 		}
 	}
 
+	// add keypad specific menu
+	if wm.windows[winTypistTitle].(*winTypist).keypadAttached() {
+		if imgui.BeginMenu("Keypad") {
+			wm.drawMenuWindowEntry(wm.windows[winTypistTitle], menuTypistTitle)
+			imgui.EndMenu()
+		}
+	}
+
 	// filename in titlebar
 	imgui.SameLineV(imgui.WindowWidth()-imguiGetFrameDim(wm.img.lz.Cart.Filename).X-20.0, 0.0)
 	imgui.Text(wm.img.lz.Cart.Filename)
@@ -22,7 +22,7 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
 	"github.com/jetsetilly/gopher2600/hardware/cpu/registers"
-	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory"
 	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
 	"github.com/jetsetilly/gopher2600/hardware/preferences"
 )
@@ -43,8 +43,18 @@ type CPU struct {
 	acc8  registers.Register
 	acc16 registers.ProgramCounter
 
-	mem          bus.CPUBus
-	instructions []*instructions.Definition
+	mem bus.CPUBus
+
+	// the zero page interface of mem. stored separately to avoid a type
+	// assertion on every zero page read. will be nil if mem does not implement
+	// the CPUBusZeroPage interface
+	memZeroPage bus.CPUBusZeroPage
+
+	// optimisation: mem as the concrete VCS memory type. will be nil if mem is
+	// some other implementation of CPUBus (eg. in the disassembly package).
+	// when not nil, memory is accessed with direct function calls rather than
+	// through the CPUBus interface. see the read() function
+	vcsMem *memory.Memory
 
 	// cycleCallback is called by endCycle() for additional emulator
 	// functionality
@@ -79,19 +89,20 @@ type CPU struct {
 // NewCPU is the preferred method of initialisation for the CPU structure. Note
 // that the CPU will be initialised in a random state.
 func NewCPU(prefs *preferences.Preferences, mem bus.CPUBus) *CPU {
-	return &CPU{
-		prefs:        prefs,
-		mem:          mem,
-		PC:           registers.NewProgramCounter(0),
-		A:            registers.NewRegister(0, "A"),
-		X:            registers.NewRegister(0, "X"),
-		Y:            registers.NewRegister(0, "Y"),
-		SP:           registers.NewRegister(0, "SP"),
-		Status:       registers.NewStatusRegister(),
-		acc8:         registers.NewRegister(0, "accumulator"),
-		acc16:        registers.NewProgramCounter(0),
-		instructions: instructions.GetDefinitions(),
-	}
+	mc := &CPU{
+		prefs:  prefs,
+		mem:    mem,
+		PC:     registers.NewProgramCounter(0),
+		A:      registers.NewRegister(0, "A"),
+		X:      registers.NewRegister(0, "X"),
+		Y:      registers.NewRegister(0, "Y"),
+		SP:     registers.NewRegister(0, "SP"),
+		Status: registers.NewStatusRegister(),
+		acc8:   registers.NewRegister(0, "accumulator"),
+		acc16:  registers.NewProgramCounter(0),
+	}
+	mc.Plumb(mem)
+	return mc
 }
 
 // Snapshot creates a copy of the CPU in its current state.
@@ -103,6 +114,8 @@ func (mc *CPU) Snapshot() *CPU {
 // Plumb a new CPUBus into the CPU.
 func (mc *CPU) Plumb(mem bus.CPUBus) {
 	mc.mem = mem
+	mc.memZeroPage, _ = mem.(bus.CPUBusZeroPage)
+	mc.vcsMem, _ = mem.(*memory.Memory)
 }
 
 func (mc *CPU) String() string {
@@ -154,7 +167,7 @@ func (mc *CPU) LoadPCIndirect(indirectAddress uint16) error {
 
 	// read 16 bit address from specified indirect address
 
-	lo, err := mc.mem.Read(indirectAddress)
+	lo, err := mc.read(indirectAddress)
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
 			return err
@@ -162,7 +175,7 @@ func (mc *CPU) LoadPCIndirect(indirectAddress uint16) error {
 		mc.LastResult.Error = err.Error()
 	}
 
-	hi, err := mc.mem.Read(indirectAddress + 1)
+	hi, err := mc.read(indirectAddress + 1)
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
 			return err
@@ -186,12 +199,30 @@ func (mc *CPU) LoadPC(directAddress uint16) error {
 	return nil
 }
 
+// read is the basic memory read function. it reads directly from the VCS
+// memory if possible, otherwise it reads through the CPUBus interface.
+//
+// the function cannot be inlined so the hottest memory access functions
+// (read8Bit(), read8BitPC(), etc.) repeat the logic rather than calling read()
+func (mc *CPU) read(address uint16) (uint8, error) {
+	if mc.vcsMem != nil {
+		return mc.vcsMem.Read(address)
+	}
+	return mc.mem.Read(address)
+}
+
 // read8Bit returns 8bit value from the specified address
 //
 // side-effects:
 //	* calls endCycle after memory read
 func (mc *CPU) read8Bit(address uint16) (uint8, error) {
-	val, err := mc.mem.Read(address)
+	var val uint8
+	var err error
+	if mc.vcsMem != nil {
+		val, err = mc.vcsMem.Read(address)
+	} else {
+		val, err = mc.mem.Read(address)
+	}
 
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
@@ -214,7 +245,15 @@ func (mc *CPU) read8Bit(address uint16) (uint8, error) {
 // side-effects:
 //	* calls endCycle after memory read
 func (mc *CPU) read8BitZeroPage(address uint8) (uint8, error) {
-	val, err := mc.mem.(bus.CPUBusZeroPage).ReadZeroPage(address)
+	var val uint8
+	var err error
+	if mc.vcsMem != nil {
+		val, err = mc.vcsMem.ReadZeroPage(address)
+	} else if mc.memZeroPage != nil {
+		val, err = mc.memZeroPage.ReadZeroPage(address)
+	} else {
+		val, err = mc.mem.Read(uint16(address))
+	}
 
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
@@ -236,7 +275,12 @@ func (mc *CPU) read8BitZeroPage(address uint8) (uint8, error) {
 // on the state of the CPU which means that *endCycle must be called by the
 // calling function as appropriate*.
 func (mc *CPU) write8Bit(address uint16, value uint8) error {
-	err := mc.mem.Write(address, value)
+	var err error
+	if mc.vcsMem != nil {
+		err = mc.vcsMem.Write(address, value)
+	} else {
+		err = mc.mem.Write(address, value)
+	}
 
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
@@ -253,7 +297,13 @@ func (mc *CPU) write8Bit(address uint16, value uint8) error {
 // side-effects:
 //	* calls endCycle after each 8bit read
 func (mc *CPU) read16Bit(address uint16) (uint16, error) {
-	lo, err := mc.mem.Read(address)
+	var lo uint8
+	var err error
+	if mc.vcsMem != nil {
+		lo, err = mc.vcsMem.Read(address)
+	} else {
+		lo, err = mc.mem.Read(address)
+	}
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
 			return 0, err
@@ -267,7 +317,12 @@ func (mc *CPU) read16Bit(address uint16) (uint16, error) {
 		return 0, err
 	}
 
-	hi, err := mc.mem.Read(address + 1)
+	var hi uint8
+	if mc.vcsMem != nil {
+		hi, err = mc.vcsMem.Read(address + 1)
+	} else {
+		hi, err = mc.mem.Read(address + 1)
+	}
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
 			return 0, err
@@ -291,10 +346,16 @@ func (mc *CPU) read16Bit(address uint16) (uint16, error) {
 //	* calls endCycle at end of function
 //	* updates LastResult.ByteCount
 //	* callback function in which LastResult should be updated as appropriate
-//		- probably updating InstructionData field
-//		- but can be used to read opcode too
+//	* probably updating InstructionData field
+//	* but can be used to read opcode too
 func (mc *CPU) read8BitPC(f func(val uint8) error) error {
-	v, err := mc.mem.Read(mc.PC.Address())
+	var v uint8
+	var err error
+	if mc.vcsMem != nil {
+		v, err = mc.vcsMem.Read(mc.PC.Address())
+	} else {
+		v, err = mc.mem.Read(mc.PC.Address())
+	}
 
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
@@ -326,6 +387,43 @@ func (mc *CPU) read8BitPC(f func(val uint8) error) error {
 	return nil
 }
 
+// read8BitPCOperand reads 8 bits from the memory location pointed to by PC.
+// it is equivalent to read8BitPC() with a callback function that stores the
+// value in InstructionData but without the overhead of the callback
+//
+// side-effects:
+//	* updates program counter
+//	* calls endCycle at end of function
+//	* updates LastResult.ByteCount
+//	* updates LastResult.InstructionData
+func (mc *CPU) read8BitPCOperand() error {
+	var v uint8
+	var err error
+	if mc.vcsMem != nil {
+		v, err = mc.vcsMem.Read(mc.PC.Address())
+	} else {
+		v, err = mc.mem.Read(mc.PC.Address())
+	}
+
+	if err != nil {
+		if !curated.Has(err, bus.AddressError) {
+			return err
+		}
+		mc.LastResult.Error = err.Error()
+	}
+
+	// ignoring if program counter cycling
+	mc.PC.Add(1)
+
+	// bump the number of bytes read during instruction decode
+	mc.LastResult.ByteCount++
+
+	mc.LastResult.InstructionData = uint16(v)
+
+	// +1 cycle
+	return mc.endCycle()
+}
+
 // read16BitPC reads 16 bits from the memory location pointed to by PC
 //
 // side-effects:
@@ -333,10 +431,16 @@ func (mc *CPU) read8BitPC(f func(val uint8) error) error {
 //	* calls endCycle after each 8 bit read
 //	* updates LastResult.ByteCount
 //	* updates InstructionData field, once before each call to endCycle
-//		- no callback function because this function is only ever used
-//	 	to read operands
+//	* no callback function because this function is only ever used
+//     to read operands
 func (mc *CPU) read16BitPC() error {
-	lo, err := mc.mem.Read(mc.PC.Address())
+	var lo uint8
+	var err error
+	if mc.vcsMem != nil {
+		lo, err = mc.vcsMem.Read(mc.PC.Address())
+	} else {
+		lo, err = mc.mem.Read(mc.PC.Address())
+	}
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
 			return err
@@ -359,7 +463,12 @@ func (mc *CPU) read16BitPC() error {
 		return err
 	}
 
-	hi, err := mc.mem.Read(mc.PC.Address())
+	var hi uint8
+	if mc.vcsMem != nil {
+		hi, err = mc.vcsMem.Read(mc.PC.Address())
+	} else {
+		hi, err = mc.mem.Read(mc.PC.Address())
+	}
 	if err != nil {
 		if !curated.Has(err, bus.AddressError) {
 			return err
@@ -472,9 +581,9 @@ const (
 // ExecuteInstruction steps CPU forward one instruction. The basic process when
 // executing an instruction is this:
 //
-//	1. read opcode and look up instruction definition
-//	2. read operands (if any) according to the addressing mode of the instruction
-//	3. using the mnemonic as a guide, perform the instruction on the data
+//  1. read opcode and look up instruction definition
+//  2. read operands (if any) according to the addressing mode of the instruction
+//  3. using the mnemonic as a guide, perform the instruction on the data
 //
 // All instructions take at least 2 cycle. After each cycle, the
 // cycleCallback() function is run, thereby allowing the rest of the VCS
@@ -502,1156 +611,97 @@ func (mc *CPU) ExecuteInstruction(cycleCallback func() error) error {
 	mc.LastResult.Reset()
 	mc.LastResult.Address = mc.PC.Address()
 
-	// register end cycle callback
-	defer func() {
-		mc.cycleCallback = nil
-	}()
+	// the cycle callback is forgotten once the instruction has completed. we
+	// don't use defer for this because it is measurably slower in what is the
+	// hottest function in the emulation
+	err := mc.executeInstruction()
+	mc.cycleCallback = nil
 
-	var err error
-	var opcode uint8
-	var defn *instructions.Definition
+	return err
+}
 
-	// read next instruction (end cycle part of read8BitPC)
-	// +1 cycle
-	err = mc.read8BitPC(func(val uint8) error {
-		opcode = val
-		defn = mc.instructions[val]
+// decodeError is called when an error occurs while reading the opcode. even
+// when there is an error we need to update some LastResult field values before
+// returning the error. the calling function might still want to make use of
+// LastResult even when an error has occurred and there's no reason to disagree
+// (see disassembly package for an exmple of this)
+//
+// I don't believe similar treatment is necessary for other error conditions
+// in the rest of the ExecuteInstruction() function.
+func (mc *CPU) decodeError(opcode uint8, err error) error {
+	// firstly, the number of bytes read is by definition one
+	mc.LastResult.ByteCount = 1
 
-		// !!TODO: remove this once all opcodes are defined/implemented
-		if defn == nil {
-			return curated.Errorf(UnimplementedInstruction, val, mc.PC.Address()-1)
-		}
+	// secondly, the definition field. this is only required while we have
+	// undefined opcodes in the CPU definition.
 
-		mc.LastResult.Defn = defn
+	// finally, this is the final byte of the instruction
+	mc.LastResult.Final = true
 
-		return nil
-	})
-	if err != nil {
-		// even when there is an error we need to update some LastResult field
-		// values before returning the error. the calling function might still
-		// want to make use of LastResult even when an error has occurred and
-		// there's no reason to disagree (see disassembly package for an exmple
-		// of this)
-		//
-		// I don't believe similar treatment is necessary for other error
-		// conditions in the rest of the ExecuteInstruction() function
-
-		// firstly, the number of bytes read is by definition one
-		mc.LastResult.ByteCount = 1
-
-		// secondly, the definition field. this is only required while we have
-		// undefined opcodes in the CPU definition.
-
-		// finally, this is the final byte of the instruction
-		mc.LastResult.Final = true
-
-		// if there is no definition create a fake one
-		// !!TODO: remove this once all opcodes are defined/implemented
-		if mc.LastResult.Defn == nil {
-			mc.LastResult.Defn = &instructions.Definition{
-				OpCode:   opcode,
-				Mnemonic: "??",
-				Bytes:    1,
-				Cycles:   0,
-				// remaining fields are undefined
-			}
+	// if there is no definition create a fake one
+	// !!TODO: remove this once all opcodes are defined/implemented
+	if mc.LastResult.Defn == nil {
+		mc.LastResult.Defn = &instructions.Definition{
+			OpCode:   opcode,
+			Mnemonic: "??",
+			Bytes:    1,
+			Cycles:   0,
+			// remaining fields are undefined
 		}
-
-		return err
 	}
 
-	// address is the actual address to use to access memory (after any indexing
-	// has taken place)
-	var address uint16
-
-	// value is nil if addressing mode is implied and is read from the program for
-	// immediate/relative mode, and from non-program memory for all other modes
-	// note that for instructions which are read-modify-write, the value will
-	// change during execution and be used to write back to memory
-	var value uint8
-
-	// whether the data-read should be a zero page read or not
-	var zeroPage bool
-
-	// get address to use when reading/writing from/to memory (note that in the
-	// case of immediate addressing, we are actually getting the value to use
-	// in the instruction, not the address).
-	//
-	// we also take the opportunity to set the InstructionData value for the
-	// StepResult and whether a page fault has occurred. note that we don't do
-	// this in the case of JSR
-	switch defn.AddressingMode {
-	case instructions.Implied:
-		// implied mode does not use any additional bytes. however, the next
-		// instruction is read but the PC is not incremented
-
-		if defn.Mnemonic == "BRK" {
-			// BRK is unusual in that it increases the PC by two bytes despite
-			// being an implied addressing mode.
-			// +1 cycle
-			err = mc.read8BitPC(nil)
-			if err != nil {
-				return err
-			}
-
-			// but we don't LastResult to show this
-			mc.LastResult.ByteCount--
-		} else {
-			// phantom read
-			// +1 cycle
-			_, err = mc.read8Bit(mc.PC.Address())
-			if err != nil {
-				return err
-			}
-		}
-
-	case instructions.Immediate:
-		// for immediate mode, the value is the next byte in the program
-		// therefore, we don't set the address and we read the value through the PC
-
-		// +1 cycle
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		value = uint8(mc.LastResult.InstructionData)
-
-	case instructions.Relative:
-		// relative addressing is only used for branch instructions, the address
-		// is an offset value from the current PC position
-
-		// most of the addressing cycles for this addressing mode are consumed
-		// in the branch() function
-
-		// +1 cycle
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		address = mc.LastResult.InstructionData
-
-	case instructions.Absolute:
-		if defn.Effect != instructions.Subroutine {
-			// +2 cycles
-			err := mc.read16BitPC()
-			if err != nil {
-				return err
-			}
-			address = mc.LastResult.InstructionData
-		}
-
-		// else... for JSR, addresses are read slightly differently so we defer
-		// this part of the operation to the mnemonic switch below
-
-	case instructions.ZeroPage:
-		zeroPage = true
-
-		// +1 cycle
-		err = mc.read8BitPC(func(val uint8) error {
-			// while we must trest the value as an address (ie. as uint16) we
-			// actually only read an 8 bit value so we store the value as uint8
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		address = mc.LastResult.InstructionData
-
-	case instructions.Indirect:
-		// indirect addressing (without indexing) is only used for the JMP command
-
-		// +2 cycles
-		err := mc.read16BitPC()
-		if err != nil {
-			return err
-		}
-		indirectAddress := mc.LastResult.InstructionData
-
-		// handle indirect addressing JMP bug
-		if indirectAddress&0x00ff == 0x00ff {
-			mc.LastResult.CPUBug = "indirect addressing bug (JMP bug)"
-
-			var lo, hi uint8
-
-			lo, err = mc.mem.Read(indirectAddress)
-			if err != nil {
-				if !curated.Has(err, bus.AddressError) {
-					return err
-				}
-				mc.LastResult.Error = err.Error()
-			}
-
-			// +1 cycle
-			err = mc.endCycle()
-			if err != nil {
-				if !curated.Has(err, bus.AddressError) {
-					return err
-				}
-				mc.LastResult.Error = err.Error()
-				return err
-			}
-
-			// in this bug path, the lower byte of the indirect address is on a
-			// page boundary. because of the bug we must read high byte of JMP
-			// address from the zero byte of the same page (rather than the
-			// zero byte of the next page)
-			hi, err = mc.mem.Read(indirectAddress & 0xff00)
-			if err != nil {
-				return err
-			}
-			address = uint16(hi) << 8
-			address |= uint16(lo)
-
-			// +1 cycle
-			err = mc.endCycle()
-			if err != nil {
-				return err
-			}
-		} else {
-			// normal, non-buggy behaviour
-
-			// +2 cycles
-			address, err = mc.read16Bit(indirectAddress)
-			if err != nil {
-				return err
-			}
-		}
-
-	case instructions.IndexedIndirect: // x indexing
-		// +1 cycle
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		indirectAddress := uint8(mc.LastResult.InstructionData)
-
-		// phantom read before adjusting the index
-		// +1 cycle
-		_, err = mc.read8Bit(uint16(indirectAddress))
-		if err != nil {
-			return err
-		}
-
-		// using 8bit addition because of the 6507's indirect addressing bug -
-		// we don't want indexed address t8 extend past the first page
-		mc.acc8.Load(mc.X.Value())
-		mc.acc8.Add(indirectAddress, false)
-
-		// make a note of indirect addressig bug
-		if uint16(indirectAddress+mc.X.Value())&0xff00 != uint16(indirectAddress)&0xff00 {
-			mc.LastResult.CPUBug = "indirect addressing bug"
-		}
-
-		// +2 cycles
-		address, err = mc.read16Bit(mc.acc8.Address())
-		if err != nil {
-			return err
-		}
-
-		// never a page fault wth pre-index indirect addressing
-
-	case instructions.IndirectIndexed: // y indexing
-		// +1 cycle
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		indirectAddress := mc.LastResult.InstructionData
-
-		// +2 cycles
-		var indexedAddress uint16
-		indexedAddress, err = mc.read16Bit(indirectAddress)
-		if err != nil {
-			return err
-		}
-
-		mc.acc16.Load(mc.Y.Address())
-		mc.acc16.Add(indexedAddress & 0x00ff)
-		address = mc.acc16.Address()
-
-		// check for page fault
-		if defn.PageSensitive && (address&0xff00 == 0x0100) {
-			mc.LastResult.CPUBug = "indirect addressing bug"
-			mc.LastResult.PageFault = true
-		}
-
-		if mc.LastResult.PageFault || defn.Effect == instructions.Write || defn.Effect == instructions.RMW {
-			// phantom read (always happens for Write and RMW)
-			// +1 cycle
-			_, err = mc.read8Bit((indexedAddress & 0xff00) | (address & 0x00ff))
-			if err != nil {
-				return err
-			}
-		}
-
-		// fix MSB of address
-		mc.acc16.Add(indexedAddress & 0xff00)
-		address = mc.acc16.Address()
-
-	case instructions.AbsoluteIndexedX:
-		// +2 cycles
-		err = mc.read16BitPC()
-		if err != nil {
-			return err
-		}
-		indirectAddress := mc.LastResult.InstructionData
-
-		// add index to LSB of address
-		mc.acc16.Load(mc.X.Address())
-		mc.acc16.Add(indirectAddress & 0x00ff)
-		address = mc.acc16.Address()
-
-		// check for page fault
-		mc.LastResult.PageFault = defn.PageSensitive && (address&0xff00 == 0x0100)
-		if mc.LastResult.PageFault || defn.Effect == instructions.Write || defn.Effect == instructions.RMW {
-			// phantom read (always happens for Write and RMW)
-			// +1 cycle
-			_, err := mc.read8Bit((indirectAddress & 0xff00) | (address & 0x00ff))
-			if err != nil {
-				return err
-			}
-		}
-
-		// fix MSB of address
-		mc.acc16.Add(indirectAddress & 0xff00)
-		address = mc.acc16.Address()
-
-	case instructions.AbsoluteIndexedY:
-		// +2 cycles
-		err = mc.read16BitPC()
-		if err != nil {
-			return err
-		}
-		indirectAddress := mc.LastResult.InstructionData
-
-		// add index to LSB of address
-		mc.acc16.Load(mc.Y.Address())
-		mc.acc16.Add(indirectAddress & 0x00ff)
-		address = mc.acc16.Address()
-
-		// check for page fault
-		mc.LastResult.PageFault = defn.PageSensitive && (address&0xff00 == 0x0100)
-		if mc.LastResult.PageFault || defn.Effect == instructions.Write || defn.Effect == instructions.RMW {
-			// phantom read (always happens for Write and RMW)
-			// +1 cycle
-			_, err := mc.read8Bit((indirectAddress & 0xff00) | (address & 0x00ff))
-			if err != nil {
-				return err
-			}
-		}
-
-		// fix MSB of address
-		mc.acc16.Add(indirectAddress & 0xff00)
-		address = mc.acc16.Address()
-
-	case instructions.ZeroPageIndexedX:
-		zeroPage = true
-
-		// +1 cycles
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		indirectAddress := uint8(mc.LastResult.InstructionData)
-
-		mc.acc8.Load(indirectAddress)
-		mc.acc8.Add(mc.X.Value(), false)
-		address = mc.acc8.Address()
-
-		// make a note of zero page index bug
-		if uint16(indirectAddress+mc.X.Value())&0xff00 != uint16(indirectAddress)&0xff00 {
-			mc.LastResult.CPUBug = "zero page index bug"
-		}
-
-		// +1 cycle
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case instructions.ZeroPageIndexedY:
-		zeroPage = true
-
-		// used exclusively for LDX ZeroPage,y
-
-		// +1 cycles
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-		indirectAddress := uint8(mc.LastResult.InstructionData)
-
-		mc.acc8.Load(indirectAddress)
-		mc.acc8.Add(mc.Y.Value(), false)
-		address = mc.acc8.Address()
-
-		// make a note of zero page index bug
-		if uint16(indirectAddress+mc.Y.Value())&0xff00 != uint16(indirectAddress)&0xff00 {
-			mc.LastResult.CPUBug = "zero page index bug"
-		}
-
-		// +1 cycle
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
+	return err
+}
 
-	default:
-		return curated.Errorf("cpu: unknown addressing mode for %s", defn.Mnemonic)
+// executeInstruction is the body of ExecuteInstruction().
+func (mc *CPU) executeInstruction() error {
+	// read next instruction. the opcode is read here rather than in a
+	// separate function because it is the hottest read in the emulation
+	// +1 cycle
+	var opcode uint8
+	var err error
+	if mc.vcsMem != nil {
+		opcode, err = mc.vcsMem.Read(mc.PC.Address())
+	} else {
+		opcode, err = mc.mem.Read(mc.PC.Address())
 	}
-
-	// read value from memory using address found in AddressingMode switch above only when:
-	// a) addressing mode is not 'implied' or 'immediate'
-	//	- for immediate modes, we already have the value in lieu of an address
-	//  - for implied modes, we don't need a value
-	// b) instruction is 'Read' OR 'ReadWrite'
-	//  - for write modes, we only use the address to write a value we already have
-	//  - for flow modes, the use of the address is very specific
-	if !(defn.AddressingMode == instructions.Implied || defn.AddressingMode == instructions.Immediate) {
-		if defn.Effect == instructions.Read {
-			// +1 cycle
-
-			if zeroPage {
-				value, err = mc.read8BitZeroPage(uint8(address))
-			} else {
-				value, err = mc.read8Bit(address)
-			}
-			if err != nil {
-				return err
-			}
-		} else if defn.Effect == instructions.RMW {
-			// +1 cycle
-
-			if zeroPage {
-				value, err = mc.read8BitZeroPage(uint8(address))
-			} else {
-				value, err = mc.read8Bit(address)
-			}
-			if err != nil {
-				return err
-			}
-
-			// phantom write
-			// +1 cycle
-			err = mc.write8Bit(address, value)
-			if err != nil {
-				return err
-			}
-
-			err = mc.endCycle()
-			if err != nil {
-				return err
-			}
+	if err != nil {
+		if !curated.Has(err, bus.AddressError) {
+			return mc.decodeError(0, err)
 		}
+		mc.LastResult.Error = err.Error()
 	}
 
-	// actually perform instruction based on mnemonic group
-	switch defn.Mnemonic {
-	case "NOP":
-		// does nothing
-
-	case "CLI":
-		mc.Status.InterruptDisable = false
-
-	case "SEI":
-		mc.Status.InterruptDisable = true
-
-	case "CLC":
-		mc.Status.Carry = false
-
-	case "SEC":
-		mc.Status.Carry = true
-
-	case "CLD":
-		mc.Status.DecimalMode = false
-
-	case "SED":
-		mc.Status.DecimalMode = true
-
-	case "CLV":
-		mc.Status.Overflow = false
-
-	case "PHA":
-		// +1 cycle
-		err = mc.write8Bit(mc.SP.Address(), mc.A.Value())
-		if err != nil {
-			return err
-		}
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "PLA":
-		// +1 cycle
-		mc.SP.Add(1, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// +1 cycle
-		value, err = mc.read8Bit(mc.SP.Address())
-		if err != nil {
-			return err
-		}
-		mc.A.Load(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "PHP":
-		// +1 cycle
-		err = mc.write8Bit(mc.SP.Address(), mc.Status.Value())
-		if err != nil {
-			return err
-		}
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "PLP":
-		// +1 cycle
-		mc.SP.Add(1, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-		// +1 cycle
-		value, err = mc.read8Bit(mc.SP.Address())
-		if err != nil {
-			return err
-		}
-		mc.Status.FromValue(value)
-
-	case "TXA":
-		mc.A.Load(mc.X.Value())
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "TAX":
-		mc.X.Load(mc.A.Value())
-		mc.Status.Zero = mc.X.IsZero()
-		mc.Status.Sign = mc.X.IsNegative()
-
-	case "TAY":
-		mc.Y.Load(mc.A.Value())
-		mc.Status.Zero = mc.Y.IsZero()
-		mc.Status.Sign = mc.Y.IsNegative()
-
-	case "TYA":
-		mc.A.Load(mc.Y.Value())
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "TSX":
-		mc.X.Load(mc.SP.Value())
-		mc.Status.Zero = mc.X.IsZero()
-		mc.Status.Sign = mc.X.IsNegative()
-
-	case "TXS":
-		mc.SP.Load(mc.X.Value())
-		// does not affect status register
-
-	case "EOR":
-		mc.A.EOR(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "ORA":
-		mc.A.ORA(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "AND":
-		mc.A.AND(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "LDA":
-		mc.A.Load(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "LDX":
-		mc.X.Load(value)
-		mc.Status.Zero = mc.X.IsZero()
-		mc.Status.Sign = mc.X.IsNegative()
-
-	case "LDY":
-		mc.Y.Load(value)
-		mc.Status.Zero = mc.Y.IsZero()
-		mc.Status.Sign = mc.Y.IsNegative()
-
-	case "STA":
-		// +1 cycle
-		err = mc.write8Bit(address, mc.A.Value())
-		if err != nil {
-			return err
-		}
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "STX":
-		// +1 cycle
-		err = mc.write8Bit(address, mc.X.Value())
-		if err != nil {
-			return err
-		}
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "STY":
-		// +1 cycle
-		err = mc.write8Bit(address, mc.Y.Value())
-		if err != nil {
-			return err
-		}
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "INX":
-		mc.X.Add(1, false)
-		mc.Status.Zero = mc.X.IsZero()
-		mc.Status.Sign = mc.X.IsNegative()
-
-	case "INY":
-		mc.Y.Add(1, false)
-		mc.Status.Zero = mc.Y.IsZero()
-		mc.Status.Sign = mc.Y.IsNegative()
-
-	case "DEX":
-		mc.X.Add(255, false)
-		mc.Status.Zero = mc.X.IsZero()
-		mc.Status.Sign = mc.X.IsNegative()
-
-	case "DEY":
-		mc.Y.Add(255, false)
-		mc.Status.Zero = mc.Y.IsZero()
-		mc.Status.Sign = mc.Y.IsNegative()
-
-	case "ASL":
-		var r *registers.Register
-		if defn.Effect == instructions.RMW {
-			r = &mc.acc8
-			r.Load(value)
-		} else {
-			r = &mc.A
-		}
-		mc.Status.Carry = r.ASL()
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-
-	case "LSR":
-		var r *registers.Register
-		if defn.Effect == instructions.RMW {
-			r = &mc.acc8
-			r.Load(value)
-		} else {
-			r = &mc.A
-		}
-		mc.Status.Carry = r.LSR()
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-
-	case "ADC":
-		if mc.Status.DecimalMode {
-			mc.Status.Carry,
-				mc.Status.Zero,
-				mc.Status.Overflow,
-				mc.Status.Sign = mc.A.AddDecimal(value, mc.Status.Carry)
-		} else {
-			mc.Status.Carry, mc.Status.Overflow = mc.A.Add(value, mc.Status.Carry)
-			mc.Status.Zero = mc.A.IsZero()
-			mc.Status.Sign = mc.A.IsNegative()
-		}
-
-	case "SBC":
-		if mc.Status.DecimalMode {
-			mc.Status.Carry,
-				mc.Status.Zero,
-				mc.Status.Overflow,
-				mc.Status.Sign = mc.A.SubtractDecimal(value, mc.Status.Carry)
-		} else {
-			mc.Status.Carry, mc.Status.Overflow = mc.A.Subtract(value, mc.Status.Carry)
-			mc.Status.Zero = mc.A.IsZero()
-			mc.Status.Sign = mc.A.IsNegative()
-		}
-
-	case "ROR":
-		var r *registers.Register
-		if defn.Effect == instructions.RMW {
-			r = &mc.acc8
-			r.Load(value)
-		} else {
-			r = &mc.A
-		}
-		mc.Status.Carry = r.ROR(mc.Status.Carry)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-
-	case "ROL":
-		var r *registers.Register
-		if defn.Effect == instructions.RMW {
-			r = &mc.acc8
-			r.Load(value)
-		} else {
-			r = &mc.A
-		}
-		mc.Status.Carry = r.ROL(mc.Status.Carry)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-
-	case "INC":
-		r := mc.acc8
-		r.Load(value)
-		r.Add(1, false)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-
-	case "DEC":
-		r := mc.acc8
-		r.Load(value)
-		r.Add(255, false)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-
-	case "CMP":
-		r := mc.acc8
-		r.Load(mc.A.Value())
-
-		// maybe surprisingly, CMP can be implemented with binary subtract even
-		// if decimal mode is active (the meaning is the same)
-		mc.Status.Carry, _ = r.Subtract(value, true)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-
-	case "CPX":
-		r := mc.acc8
-		r.Load(mc.X.Value())
-		mc.Status.Carry, _ = r.Subtract(value, true)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-
-	case "CPY":
-		r := mc.acc8
-		r.Load(mc.Y.Value())
-		mc.Status.Carry, _ = r.Subtract(value, true)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-
-	case "BIT":
-		r := mc.acc8
-		r.Load(value)
-		mc.Status.Sign = r.IsNegative()
-		mc.Status.Overflow = r.IsBitV()
-		r.AND(mc.A.Value())
-		mc.Status.Zero = r.IsZero()
-
-	case "JMP":
-		if !mc.NoFlowControl {
-			mc.PC.Load(address)
-		}
-
-	case "BCC":
-		err = mc.branch(!mc.Status.Carry, address)
-		if err != nil {
-			return err
-		}
-
-	case "BCS":
-		err = mc.branch(mc.Status.Carry, address)
-		if err != nil {
-			return err
-		}
-
-	case "BEQ":
-		err = mc.branch(mc.Status.Zero, address)
-		if err != nil {
-			return err
-		}
-
-	case "BMI":
-		err = mc.branch(mc.Status.Sign, address)
-		if err != nil {
-			return err
-		}
-
-	case "BNE":
-		err = mc.branch(!mc.Status.Zero, address)
-		if err != nil {
-			return err
-		}
-
-	case "BPL":
-		err = mc.branch(!mc.Status.Sign, address)
-		if err != nil {
-			return err
-		}
-
-	case "BVC":
-		err = mc.branch(!mc.Status.Overflow, address)
-		if err != nil {
-			return err
-		}
-
-	case "BVS":
-		err = mc.branch(mc.Status.Overflow, address)
-		if err != nil {
-			return err
-		}
-
-	case "JSR":
-		// +1 cycle
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = uint16(val)
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-
-		// the current value of the PC is now correct, even though we've only read
-		// one byte of the address so far. remember, RTS increments the PC when
-		// read from the stack, meaning that the PC will be correct at that point
-
-		// with that in mind, we're not sure what this extra cycle is for
-		// +1 cycle
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// push MSB of PC onto stack, and decrement SP
-		// +1 cycle
-		err = mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()>>8))
-		if err != nil {
-			return err
-		}
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// push LSB of PC onto stack, and decrement SP
-		// +1 cycle
-		err = mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()))
-		if err != nil {
-			return err
-		}
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// perform jump
-		err = mc.read8BitPC(func(val uint8) error {
-			mc.LastResult.InstructionData = (uint16(val) << 8) | mc.LastResult.InstructionData
-			return nil
-		})
-		if err != nil {
-			return err
-		}
-
-		// address has been built in the read8BitPC callback functions.
-		//
-		// we would normally do this in the addressing mode switch above. however,
-		// JSR uses absolute addressing and we deliberately do nothing in that
-		// switch for 'sub-routine' commands
-		address = mc.LastResult.InstructionData
-		if !mc.NoFlowControl {
-			mc.PC.Load(address)
-		}
-
-	case "RTS":
-		// +1 cycle
-		if !mc.NoFlowControl {
-			mc.SP.Add(1, false)
-		}
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// +2 cycles
-		var rtsAddress uint16
-		rtsAddress, err = mc.read16Bit(mc.SP.Address())
-		if err != nil {
-			return err
-		}
-
-		if !mc.NoFlowControl {
-			mc.SP.Add(1, false)
-
-			// load and correct PC
-			mc.PC.Load(rtsAddress)
-			mc.PC.Add(1)
-		}
-
-		// +1 cycle
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "BRK":
-		// push PC onto register (same effect as JSR)
-		err := mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()>>8))
-		if err != nil {
-			return err
-		}
-
-		// +1 cycle
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		err = mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()))
-		if err != nil {
-			return err
-		}
-
-		// +1 cycle
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// push status register (same effect as PHP)
-		err = mc.write8Bit(mc.SP.Address(), mc.Status.Value())
-		if err != nil {
-			return err
-		}
-
-		// +1 cycle
-		mc.SP.Add(255, false)
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// set the break flag
-		mc.Status.Break = true
-
-		// perform jump
-		var brkAddress uint16
-		brkAddress, err = mc.read16Bit(addresses.IRQ)
-		if err != nil {
-			return err
-		}
-		if !mc.NoFlowControl {
-			mc.PC.Load(brkAddress)
-		}
-
-	case "RTI":
-		// pull status register (same effect as PLP)
-		if !mc.NoFlowControl {
-			mc.SP.Add(1, false)
-		}
-
-		// not sure when this cycle should occur
-		// +1 cycle
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-		// +1 cycles
-		value, err = mc.read8Bit(mc.SP.Address())
-		if err != nil {
-			return err
-		}
-		mc.Status.FromValue(value)
-
-		// pull program counter (same effect as RTS)
-		if !mc.NoFlowControl {
-			mc.SP.Add(1, false)
-		}
-
-		// +2 cycles
-		var rtiAddress uint16
-		rtiAddress, err = mc.read16Bit(mc.SP.Address())
-		if err != nil {
-			return err
-		}
-
-		if !mc.NoFlowControl {
-			mc.SP.Add(1, false)
-			mc.PC.Load(rtiAddress)
-			// unlike RTS there is no need to add one to return address
-		}
-
-	// undocumented instructions
-
-	case "nop":
-		// does nothing (2 byte nop)
-
-	case "lax":
-		mc.A.Load(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-		mc.X.Load(value)
-
-	case "skw":
-		// does nothing (2 byte skip)
-		// differs to dop because the second byte is actually read
-
-	case "dcp":
-		// AND the contents of the A register with value...
-		// decrease value...
-		r := mc.acc8
-		r.Load(value)
-		r.Add(255, false)
-		value = r.Value()
-
-		// ... and compare with the A register
-		r.Load(mc.A.Value())
-		mc.Status.Carry, _ = r.Subtract(value, true)
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-
-	case "asr":
-		mc.A.AND(value)
-
-		// ... then LSR the result
-		mc.Status.Carry = mc.A.LSR()
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "xaa":
-		mc.A.Load(mc.X.Value())
-		mc.A.AND(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
+	// ignoring if program counter cycling
+	mc.PC.Add(1)
 
-	case "axs":
-		mc.X.AND(mc.A.Value())
+	// bump the number of bytes read during instruction decode
+	mc.LastResult.ByteCount++
 
-		// axs subtract behaves like CMP as far as carry and overflow flags are
-		// concerned
-		mc.Status.Carry, _ = mc.X.Subtract(value, true)
+	d := &dispatch[opcode]
 
-		mc.Status.Zero = mc.X.IsZero()
-		mc.Status.Sign = mc.X.IsNegative()
+	// !!TODO: remove this once all opcodes are defined/implemented
+	if d.defn == nil {
+		return mc.decodeError(opcode, curated.Errorf(UnimplementedInstruction, opcode, mc.PC.Address()-1))
+	}
 
-	case "sax":
-		r := mc.acc8
-		r.Load(mc.A.Value())
-		r.AND(mc.X.Value())
+	mc.LastResult.Defn = d.defn
 
-		// +1 cycle
-		err = mc.write8Bit(address, r.Value())
-		if err != nil {
-			return err
-		}
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
-
-	case "arr":
-		mc.A.AND(value)
-		mc.Status.Carry = mc.A.ROR(mc.Status.Carry)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "slo":
-		r := mc.acc8
-		r.Load(value)
-		mc.Status.Carry = r.ASL()
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-		value = r.Value()
-		mc.A.ORA(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "rla":
-		r := mc.acc8
-		r.Load(value)
-		mc.Status.Carry = r.ROL(mc.Status.Carry)
-		value = r.Value()
-		mc.A.AND(r.Value())
-		mc.Status.Zero = r.IsZero()
-		mc.Status.Sign = r.IsNegative()
-
-	case "isc":
-		r := mc.acc8
-		r.Load(value)
-		r.Add(1, false)
-		value = r.Value()
-		mc.Status.Carry, mc.Status.Overflow = mc.A.Subtract(value, mc.Status.Carry)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-
-	case "anc":
-		// immediate AND. puts bit 7 into the carry flag (in microcode terms
-		// this is as though ASL had been enacted)
-		mc.A.AND(value)
-		mc.Status.Zero = mc.A.IsZero()
-		mc.Status.Sign = mc.A.IsNegative()
-		mc.Status.Carry = value&0x80 == 0x80
-
-	default:
-		return curated.Errorf("cpu: unknown mnemonic (%s)", defn.Mnemonic)
+	// +1 cycle
+	err = mc.endCycle()
+	if err != nil {
+		return mc.decodeError(opcode, err)
 	}
 
-	// for RMW instructions: write altered value back to memory
-	if defn.Effect == instructions.RMW {
-		err = mc.write8Bit(address, value)
-		if err != nil {
-			return err
-		}
-
-		// +1 cycle
-		err = mc.endCycle()
-		if err != nil {
-			return err
-		}
+	// perform instruction using the dispatch table. the step function
+	// performs the addressing for the instruction and then the operator
+	err = d.step(mc, d.defn, d.op)
+	if err != nil {
+		return err
 	}
 
 	// finalise result
-	if mc.LastResult.Defn != nil {
-		mc.LastResult.Final = true
-	}
+	mc.LastResult.Final = true
 
 	// validity check. there's no need to enable unless you've just added a new
 	// opcode and wanting to check the validity of the definition.
@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/cpu"
 	rtest "github.com/jetsetilly/gopher2600/hardware/cpu/registers/test"
+	"github.com/jetsetilly/gopher2600/hardware/memory"
 	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
 	"github.com/jetsetilly/gopher2600/test"
 )
@@ -631,3 +634,114 @@ func TestCPU(t *testing.T) {
 	testDecimalMode(t, mc, mem)
 	testBRK(t, mc, mem)
 }
+
+func BenchmarkExecuteInstruction(b *testing.B) {
+	mem := newMockMem()
+	mc := cpu.NewCPU(nil, mem)
+	mc.Reset()
+
+	// a loop with a representative mix of addressing modes
+	_ = mem.putInstructions(0x1000,
+		0xa5, 0x80, // LDA $80
+		0x69, 0x01, // ADC #$01
+		0x85, 0x80, // STA $80
+		0xbd, 0x00, 0x02, // LDA $0200,X
+		0xe8,       // INX
+		0xe6, 0x81, // INC $81
+		0x20, 0x00, 0x20, // JSR $2000
+		0x4c, 0x00, 0x10, // JMP $1000
+	)
+	_ = mem.putInstructions(0x2000, 0xea, 0x60) // NOP; RTS
+	_ = mc.LoadPC(0x1000)
+
+	cycle := func() error { return nil }
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := mc.ExecuteInstruction(cycle)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// assemble the source into a 4k cartridge.
+func cartridge(tb testing.TB, source string) []byte {
+	tb.Helper()
+	data, err := assembler.Cartridge(source)
+	if err != nil {
+		tb.Fatalf("unexpected error assembling cartridge: %v", err)
+	}
+	return data
+}
+
+// vcsMemory creates a CPU attached to the VCS memory implementation, with the
+// cartridge data attached as a 4k cartridge.
+func vcsMemory(tb testing.TB, data []byte) (*memory.Memory, *cpu.CPU) {
+	tb.Helper()
+
+	mem := memory.NewMemory(nil)
+	mc := cpu.NewCPU(nil, mem)
+	mc.Reset()
+
+	err := mem.Cart.Attach(cartridgeloader.Loader{Filename: "cpu", Mapping: "4k", Data: data, Hash: "cpu"})
+	if err != nil {
+		tb.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	_ = mc.LoadPC(assembler.CartridgeOrigin)
+
+	return mem, mc
+}
+
+func TestVCSMemory(t *testing.T) {
+	mem, mc := vcsMemory(t, cartridge(t, "lda #$42 : sta $80 : inc $80 : ldx $80 : jmp $f000"))
+
+	step(t, mc) // LDA #$42
+	step(t, mc) // STA $80
+	step(t, mc) // INC $80
+	step(t, mc) // LDX $80
+	rtest.EquateRegisters(t, mc.X, 0x43)
+
+	// memory is accessed directly when the CPU is attached to the VCS memory
+	// implementation. the side-effects of the memory access should be the
+	// same as when accessed through the CPUBus interface
+	if mem.LastAccessAddress != 0x80 || mem.LastAccessWrite {
+		t.Errorf("last memory access was not a read of $80 (%#04x write=%v)", mem.LastAccessAddress, mem.LastAccessWrite)
+	}
+
+	v, err := mem.Read(0x80)
+	if err != nil {
+		t.Fatalf("unexpected error reading memory: %v", err)
+	}
+	if v != 0x43 {
+		t.Errorf("RAM value at $80 is %#02x (expected 0x43)", v)
+	}
+
+	step(t, mc) // JMP $f000
+	if mc.PC.Address() != assembler.CartridgeOrigin {
+		t.Errorf("PC is %#04x (expected %#04x)", mc.PC.Address(), assembler.CartridgeOrigin)
+	}
+}
+
+func BenchmarkExecuteInstructionVCS(b *testing.B) {
+	// the same loop as BenchmarkExecuteInstruction but with the VCS memory
+	// implementation. the absolute indexed read is from cartridge space
+	data := cartridge(b, `lda $80 : adc #$01 : sta $80 : lda $f100,x : inx : inc $81
+jsr $f040 : jmp $f000`)
+
+	// NOP; RTS
+	copy(data[0x40:], []byte{0xea, 0x60})
+
+	_, mc := vcsMemory(b, data)
+
+	cycle := func() error { return nil }
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := mc.ExecuteInstruction(cycle)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -20,10 +20,18 @@
 // table. The instruction definition for that opcode is then used to move
 // execution of the program forward.
 //
+// The dispatch table is indexed by opcode and is built once, when the program
+// starts, from the instruction definitions. Each entry has a step function,
+// which reads the operands according to the addressing mode, and an operator
+// function, which performs the instruction itself.
+//
 // The instance of the CPU type require an instance of a bus.CPUBus
 // implementation as the sole argument. The CPUBus interface defines the memory
 // operations required by the CPU. See the bus package for details.
 //
+// If the CPUBus is the VCS memory implementation (from the memory package) then
+// the CPU calls its functions directly rather than through the interface.
+//
 // The bread-and-butter of the CPU type is the ExecuteInstruction() function.
 // Its sole argument is a callback function to be called at every cycle boundary
 // of the instruction.
@@ -40,6 +40,36 @@ const (
 	ZeroPageIndexedY // zpg,Y
 )
 
+func (m AddressingMode) String() string {
+	switch m {
+	case Implied:
+		return "implied"
+	case Immediate:
+		return "immediate"
+	case Relative:
+		return "relative"
+	case Absolute:
+		return "abs"
+	case ZeroPage:
+		return "zpg"
+	case Indirect:
+		return "ind"
+	case IndexedIndirect:
+		return "(ind,X)"
+	case IndirectIndexed:
+		return "(ind),Y"
+	case AbsoluteIndexedX:
+		return "abs,X"
+	case AbsoluteIndexedY:
+		return "abs,Y"
+	case ZeroPageIndexedX:
+		return "zpg,X"
+	case ZeroPageIndexedY:
+		return "zpg,Y"
+	}
+	return "unknown"
+}
+
 // EffectCategory categorises an instruction by the effect it has.
 type EffectCategory int
 
@@ -83,3 +113,9 @@ func (defn Definition) String() string {
 func (defn Definition) IsBranch() bool {
 	return defn.AddressingMode == Relative && defn.Effect == Flow
 }
+
+// IsUndocumented returns true if instruction is not part of the documented
+// 6502 instruction set. Undocumented instructions have lower-case mnemonics.
+func (defn Definition) IsUndocumented() bool {
+	return defn.Mnemonic != "" && defn.Mnemonic[0] >= 'a' && defn.Mnemonic[0] <= 'z'
+}
@@ -0,0 +1,993 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cpu
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/registers"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+)
+
+// operator performs the work of an instruction once the addressing mode has
+// been resolved. the value argument is the value read from memory (or the
+// operand for immediate mode instructions) and the returned value is written
+// back to memory by RMW instructions.
+type operator func(mc *CPU, defn *instructions.Definition, address uint16, value uint8) (uint8, error)
+
+// operators maps every mnemonic to the function that performs it. it is only
+// used to build the dispatch table.
+var operators = map[string]operator{
+	"NOP": (*CPU).opNOP,
+	"CLI": (*CPU).opCLI,
+	"SEI": (*CPU).opSEI,
+	"CLC": (*CPU).opCLC,
+	"SEC": (*CPU).opSEC,
+	"CLD": (*CPU).opCLD,
+	"SED": (*CPU).opSED,
+	"CLV": (*CPU).opCLV,
+	"PHA": (*CPU).opPHA,
+	"PLA": (*CPU).opPLA,
+	"PHP": (*CPU).opPHP,
+	"PLP": (*CPU).opPLP,
+	"TXA": (*CPU).opTXA,
+	"TAX": (*CPU).opTAX,
+	"TAY": (*CPU).opTAY,
+	"TYA": (*CPU).opTYA,
+	"TSX": (*CPU).opTSX,
+	"TXS": (*CPU).opTXS,
+	"EOR": (*CPU).opEOR,
+	"ORA": (*CPU).opORA,
+	"AND": (*CPU).opAND,
+	"LDA": (*CPU).opLDA,
+	"LDX": (*CPU).opLDX,
+	"LDY": (*CPU).opLDY,
+	"STA": (*CPU).opSTA,
+	"STX": (*CPU).opSTX,
+	"STY": (*CPU).opSTY,
+	"INX": (*CPU).opINX,
+	"INY": (*CPU).opINY,
+	"DEX": (*CPU).opDEX,
+	"DEY": (*CPU).opDEY,
+	"ASL": (*CPU).opASL,
+	"LSR": (*CPU).opLSR,
+	"ADC": (*CPU).opADC,
+	"SBC": (*CPU).opSBC,
+	"ROR": (*CPU).opROR,
+	"ROL": (*CPU).opROL,
+	"INC": (*CPU).opINC,
+	"DEC": (*CPU).opDEC,
+	"CMP": (*CPU).opCMP,
+	"CPX": (*CPU).opCPX,
+	"CPY": (*CPU).opCPY,
+	"BIT": (*CPU).opBIT,
+	"JMP": (*CPU).opJMP,
+	"BCC": (*CPU).opBCC,
+	"BCS": (*CPU).opBCS,
+	"BEQ": (*CPU).opBEQ,
+	"BMI": (*CPU).opBMI,
+	"BNE": (*CPU).opBNE,
+	"BPL": (*CPU).opBPL,
+	"BVC": (*CPU).opBVC,
+	"BVS": (*CPU).opBVS,
+	"JSR": (*CPU).opJSR,
+	"RTS": (*CPU).opRTS,
+	"BRK": (*CPU).opBRK,
+	"RTI": (*CPU).opRTI,
+	"nop": (*CPU).undocNOP,
+	"lax": (*CPU).undocLAX,
+	"skw": (*CPU).undocSKW,
+	"dcp": (*CPU).undocDCP,
+	"asr": (*CPU).undocASR,
+	"xaa": (*CPU).undocXAA,
+	"axs": (*CPU).undocAXS,
+	"sax": (*CPU).undocSAX,
+	"arr": (*CPU).undocARR,
+	"slo": (*CPU).undocSLO,
+	"rla": (*CPU).undocRLA,
+	"isc": (*CPU).undocISC,
+	"anc": (*CPU).undocANC,
+}
+
+// dispatch is indexed by opcode. opcodes with no definition will have a nil
+// entry.
+var dispatch = buildDispatch()
+
+// dispatchEntry is an entry in the dispatch table. the step function resolves the
+// address for the instruction and then calls the operator.
+type dispatchEntry struct {
+	defn *instructions.Definition
+	step step
+	op   operator
+}
+
+func buildDispatch() [256]dispatchEntry {
+	var d [256]dispatchEntry
+	for _, defn := range instructions.GetDefinitions() {
+		if defn != nil {
+			op, ok := operators[defn.Mnemonic]
+			if !ok {
+				panic(fmt.Sprintf("cpu: unknown mnemonic (%s)", defn.Mnemonic))
+			}
+			d[defn.OpCode] = dispatchEntry{
+				defn: defn,
+				step: stepFor(defn),
+				op:   op,
+			}
+		}
+	}
+	return d
+}
+
+func (mc *CPU) opNOP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// does nothing
+	return value, nil
+}
+
+func (mc *CPU) opCLI(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.InterruptDisable = false
+	return value, nil
+}
+
+func (mc *CPU) opSEI(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.InterruptDisable = true
+	return value, nil
+}
+
+func (mc *CPU) opCLC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.Carry = false
+	return value, nil
+}
+
+func (mc *CPU) opSEC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.Carry = true
+	return value, nil
+}
+
+func (mc *CPU) opCLD(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.DecimalMode = false
+	return value, nil
+}
+
+func (mc *CPU) opSED(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.DecimalMode = true
+	return value, nil
+}
+
+func (mc *CPU) opCLV(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Status.Overflow = false
+	return value, nil
+}
+
+func (mc *CPU) opPHA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	err := mc.write8Bit(mc.SP.Address(), mc.A.Value())
+	if err != nil {
+		return 0, err
+	}
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opPLA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	mc.SP.Add(1, false)
+	err := mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// +1 cycle
+	value, err = mc.read8Bit(mc.SP.Address())
+	if err != nil {
+		return 0, err
+	}
+	mc.A.Load(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opPHP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	err := mc.write8Bit(mc.SP.Address(), mc.Status.Value())
+	if err != nil {
+		return 0, err
+	}
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opPLP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	mc.SP.Add(1, false)
+	err := mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+	// +1 cycle
+	value, err = mc.read8Bit(mc.SP.Address())
+	if err != nil {
+		return 0, err
+	}
+	mc.Status.FromValue(value)
+
+	return value, nil
+}
+
+func (mc *CPU) opTXA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.Load(mc.X.Value())
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opTAX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.X.Load(mc.A.Value())
+	mc.Status.Zero = mc.X.IsZero()
+	mc.Status.Sign = mc.X.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opTAY(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Y.Load(mc.A.Value())
+	mc.Status.Zero = mc.Y.IsZero()
+	mc.Status.Sign = mc.Y.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opTYA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.Load(mc.Y.Value())
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opTSX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.X.Load(mc.SP.Value())
+	mc.Status.Zero = mc.X.IsZero()
+	mc.Status.Sign = mc.X.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opTXS(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.SP.Load(mc.X.Value())
+	// does not affect status register
+
+	return value, nil
+}
+
+func (mc *CPU) opEOR(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.EOR(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opORA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.ORA(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opAND(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.AND(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opLDA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.Load(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opLDX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.X.Load(value)
+	mc.Status.Zero = mc.X.IsZero()
+	mc.Status.Sign = mc.X.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opLDY(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Y.Load(value)
+	mc.Status.Zero = mc.Y.IsZero()
+	mc.Status.Sign = mc.Y.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opSTA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	err := mc.write8Bit(address, mc.A.Value())
+	if err != nil {
+		return 0, err
+	}
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opSTX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	err := mc.write8Bit(address, mc.X.Value())
+	if err != nil {
+		return 0, err
+	}
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opSTY(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	err := mc.write8Bit(address, mc.Y.Value())
+	if err != nil {
+		return 0, err
+	}
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opINX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.X.Add(1, false)
+	mc.Status.Zero = mc.X.IsZero()
+	mc.Status.Sign = mc.X.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opINY(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Y.Add(1, false)
+	mc.Status.Zero = mc.Y.IsZero()
+	mc.Status.Sign = mc.Y.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opDEX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.X.Add(255, false)
+	mc.Status.Zero = mc.X.IsZero()
+	mc.Status.Sign = mc.X.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opDEY(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.Y.Add(255, false)
+	mc.Status.Zero = mc.Y.IsZero()
+	mc.Status.Sign = mc.Y.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opASL(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	var r *registers.Register
+	if defn.Effect == instructions.RMW {
+		r = &mc.acc8
+		r.Load(value)
+	} else {
+		r = &mc.A
+	}
+	mc.Status.Carry = r.ASL()
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+
+	return value, nil
+}
+
+func (mc *CPU) opLSR(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	var r *registers.Register
+	if defn.Effect == instructions.RMW {
+		r = &mc.acc8
+		r.Load(value)
+	} else {
+		r = &mc.A
+	}
+	mc.Status.Carry = r.LSR()
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+
+	return value, nil
+}
+
+func (mc *CPU) opADC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	if mc.Status.DecimalMode {
+		mc.Status.Carry,
+			mc.Status.Zero,
+			mc.Status.Overflow,
+			mc.Status.Sign = mc.A.AddDecimal(value, mc.Status.Carry)
+	} else {
+		mc.Status.Carry, mc.Status.Overflow = mc.A.Add(value, mc.Status.Carry)
+		mc.Status.Zero = mc.A.IsZero()
+		mc.Status.Sign = mc.A.IsNegative()
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opSBC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	if mc.Status.DecimalMode {
+		mc.Status.Carry,
+			mc.Status.Zero,
+			mc.Status.Overflow,
+			mc.Status.Sign = mc.A.SubtractDecimal(value, mc.Status.Carry)
+	} else {
+		mc.Status.Carry, mc.Status.Overflow = mc.A.Subtract(value, mc.Status.Carry)
+		mc.Status.Zero = mc.A.IsZero()
+		mc.Status.Sign = mc.A.IsNegative()
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opROR(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	var r *registers.Register
+	if defn.Effect == instructions.RMW {
+		r = &mc.acc8
+		r.Load(value)
+	} else {
+		r = &mc.A
+	}
+	mc.Status.Carry = r.ROR(mc.Status.Carry)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+
+	return value, nil
+}
+
+func (mc *CPU) opROL(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	var r *registers.Register
+	if defn.Effect == instructions.RMW {
+		r = &mc.acc8
+		r.Load(value)
+	} else {
+		r = &mc.A
+	}
+	mc.Status.Carry = r.ROL(mc.Status.Carry)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+
+	return value, nil
+}
+
+func (mc *CPU) opINC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(value)
+	r.Add(1, false)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+
+	return value, nil
+}
+
+func (mc *CPU) opDEC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(value)
+	r.Add(255, false)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+
+	return value, nil
+}
+
+func (mc *CPU) opCMP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(mc.A.Value())
+
+	// maybe surprisingly, CMP can be implemented with binary subtract even
+	// if decimal mode is active (the meaning is the same)
+	mc.Status.Carry, _ = r.Subtract(value, true)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opCPX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(mc.X.Value())
+	mc.Status.Carry, _ = r.Subtract(value, true)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opCPY(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(mc.Y.Value())
+	mc.Status.Carry, _ = r.Subtract(value, true)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) opBIT(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(value)
+	mc.Status.Sign = r.IsNegative()
+	mc.Status.Overflow = r.IsBitV()
+	r.AND(mc.A.Value())
+	mc.Status.Zero = r.IsZero()
+
+	return value, nil
+}
+
+func (mc *CPU) opJMP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	if !mc.NoFlowControl {
+		mc.PC.Load(address)
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBCC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(!mc.Status.Carry, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBCS(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(mc.Status.Carry, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBEQ(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(mc.Status.Zero, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBMI(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(mc.Status.Sign, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBNE(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(!mc.Status.Zero, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBPL(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(!mc.Status.Sign, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBVC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(!mc.Status.Overflow, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBVS(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	err := mc.branch(mc.Status.Overflow, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opJSR(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return 0, err
+	}
+
+	// the current value of the PC is now correct, even though we've only read
+	// one byte of the address so far. remember, RTS increments the PC when
+	// read from the stack, meaning that the PC will be correct at that point
+
+	// with that in mind, we're not sure what this extra cycle is for
+	// +1 cycle
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// push MSB of PC onto stack, and decrement SP
+	// +1 cycle
+	err = mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()>>8))
+	if err != nil {
+		return 0, err
+	}
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// push LSB of PC onto stack, and decrement SP
+	// +1 cycle
+	err = mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()))
+	if err != nil {
+		return 0, err
+	}
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// perform jump
+	err = mc.read8BitPC(func(val uint8) error {
+		mc.LastResult.InstructionData = (uint16(val) << 8) | mc.LastResult.InstructionData
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// address has been built in the read8BitPC callback functions.
+	//
+	// we would normally do this in the addressing mode switch above. however,
+	// JSR uses absolute addressing and we deliberately do nothing in that
+	// switch for 'sub-routine' commands
+	address = mc.LastResult.InstructionData
+	if !mc.NoFlowControl {
+		mc.PC.Load(address)
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opRTS(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// +1 cycle
+	if !mc.NoFlowControl {
+		mc.SP.Add(1, false)
+	}
+	err := mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// +2 cycles
+	var rtsAddress uint16
+	rtsAddress, err = mc.read16Bit(mc.SP.Address())
+	if err != nil {
+		return 0, err
+	}
+
+	if !mc.NoFlowControl {
+		mc.SP.Add(1, false)
+
+		// load and correct PC
+		mc.PC.Load(rtsAddress)
+		mc.PC.Add(1)
+	}
+
+	// +1 cycle
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opBRK(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// push PC onto register (same effect as JSR)
+	err := mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()>>8))
+	if err != nil {
+		return 0, err
+	}
+
+	// +1 cycle
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	err = mc.write8Bit(mc.SP.Address(), uint8(mc.PC.Address()))
+	if err != nil {
+		return 0, err
+	}
+
+	// +1 cycle
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// push status register (same effect as PHP)
+	err = mc.write8Bit(mc.SP.Address(), mc.Status.Value())
+	if err != nil {
+		return 0, err
+	}
+
+	// +1 cycle
+	mc.SP.Add(255, false)
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// set the break flag
+	mc.Status.Break = true
+
+	// perform jump
+	var brkAddress uint16
+	brkAddress, err = mc.read16Bit(addresses.IRQ)
+	if err != nil {
+		return 0, err
+	}
+	if !mc.NoFlowControl {
+		mc.PC.Load(brkAddress)
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) opRTI(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// pull status register (same effect as PLP)
+	if !mc.NoFlowControl {
+		mc.SP.Add(1, false)
+	}
+
+	// not sure when this cycle should occur
+	// +1 cycle
+	err := mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	// +1 cycles
+	value, err = mc.read8Bit(mc.SP.Address())
+	if err != nil {
+		return 0, err
+	}
+	mc.Status.FromValue(value)
+
+	// pull program counter (same effect as RTS)
+	if !mc.NoFlowControl {
+		mc.SP.Add(1, false)
+	}
+
+	// +2 cycles
+	var rtiAddress uint16
+	rtiAddress, err = mc.read16Bit(mc.SP.Address())
+	if err != nil {
+		return 0, err
+	}
+
+	if !mc.NoFlowControl {
+		mc.SP.Add(1, false)
+		mc.PC.Load(rtiAddress)
+		// unlike RTS there is no need to add one to return address
+	}
+
+	// undocumented instructions
+
+	return value, nil
+}
+
+func (mc *CPU) undocNOP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// does nothing (2 byte nop)
+	return value, nil
+}
+
+func (mc *CPU) undocLAX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.Load(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+	mc.X.Load(value)
+
+	return value, nil
+}
+
+func (mc *CPU) undocSKW(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// does nothing (2 byte skip)
+	// differs to dop because the second byte is actually read
+
+	return value, nil
+}
+
+func (mc *CPU) undocDCP(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// AND the contents of the A register with value...
+	// decrease value...
+	r := mc.acc8
+	r.Load(value)
+	r.Add(255, false)
+	value = r.Value()
+
+	// ... and compare with the A register
+	r.Load(mc.A.Value())
+	mc.Status.Carry, _ = r.Subtract(value, true)
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocASR(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.AND(value)
+
+	// ... then LSR the result
+	mc.Status.Carry = mc.A.LSR()
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocXAA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.Load(mc.X.Value())
+	mc.A.AND(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocAXS(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.X.AND(mc.A.Value())
+
+	// axs subtract behaves like CMP as far as carry and overflow flags are
+	// concerned
+	mc.Status.Carry, _ = mc.X.Subtract(value, true)
+
+	mc.Status.Zero = mc.X.IsZero()
+	mc.Status.Sign = mc.X.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocSAX(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(mc.A.Value())
+	r.AND(mc.X.Value())
+
+	// +1 cycle
+	err := mc.write8Bit(address, r.Value())
+	if err != nil {
+		return 0, err
+	}
+	err = mc.endCycle()
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func (mc *CPU) undocARR(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	mc.A.AND(value)
+	mc.Status.Carry = mc.A.ROR(mc.Status.Carry)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocSLO(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(value)
+	mc.Status.Carry = r.ASL()
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+	value = r.Value()
+	mc.A.ORA(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocRLA(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(value)
+	mc.Status.Carry = r.ROL(mc.Status.Carry)
+	value = r.Value()
+	mc.A.AND(r.Value())
+	mc.Status.Zero = r.IsZero()
+	mc.Status.Sign = r.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocISC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	r := mc.acc8
+	r.Load(value)
+	r.Add(1, false)
+	value = r.Value()
+	mc.Status.Carry, mc.Status.Overflow = mc.A.Subtract(value, mc.Status.Carry)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+
+	return value, nil
+}
+
+func (mc *CPU) undocANC(defn *instructions.Definition, address uint16, value uint8) (uint8, error) {
+	// immediate AND. puts bit 7 into the carry flag (in microcode terms
+	// this is as though ASL had been enacted)
+	mc.A.AND(value)
+	mc.Status.Zero = mc.A.IsZero()
+	mc.Status.Sign = mc.A.IsNegative()
+	mc.Status.Carry = value&0x80 == 0x80
+
+	return value, nil
+}
@@ -0,0 +1,440 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cpu
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+)
+
+// step performs the addressing cycles of an instruction and then the
+// instruction itself, by way of the operator. there is one step function for
+// each addressing mode (with a couple of special cases) and the correct step
+// function for an opcode is chosen when the dispatch table is built.
+type step func(mc *CPU, defn *instructions.Definition, op operator) error
+
+// stepFor returns the step function for the instruction definition.
+func stepFor(defn *instructions.Definition) step {
+	switch defn.AddressingMode {
+	case instructions.Implied:
+		if defn.Mnemonic == "BRK" {
+			return (*CPU).stepBRK
+		}
+		return (*CPU).stepImplied
+	case instructions.Immediate:
+		return (*CPU).stepImmediate
+	case instructions.Relative:
+		return (*CPU).stepRelative
+	case instructions.Absolute:
+		if defn.Effect == instructions.Subroutine {
+			return (*CPU).stepSubroutine
+		}
+		return (*CPU).stepAbsolute
+	case instructions.ZeroPage:
+		return (*CPU).stepZeroPage
+	case instructions.Indirect:
+		return (*CPU).stepIndirect
+	case instructions.IndexedIndirect:
+		return (*CPU).stepIndexedIndirect
+	case instructions.IndirectIndexed:
+		return (*CPU).stepIndirectIndexed
+	case instructions.AbsoluteIndexedX:
+		return (*CPU).stepAbsoluteIndexedX
+	case instructions.AbsoluteIndexedY:
+		return (*CPU).stepAbsoluteIndexedY
+	case instructions.ZeroPageIndexedX:
+		return (*CPU).stepZeroPageIndexedX
+	case instructions.ZeroPageIndexedY:
+		return (*CPU).stepZeroPageIndexedY
+	}
+	panic(fmt.Sprintf("cpu: unknown addressing mode for %s", defn.Mnemonic))
+}
+
+// operate completes the instruction once the address has been resolved by
+// the step function. the value is read from memory for Read and RMW
+// instructions and the result of RMW instructions is written back.
+//
+// the address argument is used to access memory and zeroPage is true if the
+// data-read should be a zero page read.
+func (mc *CPU) operate(defn *instructions.Definition, op operator, address uint16, zeroPage bool) error {
+	// value is read from non-program memory for Read and RMW instructions.
+	// note that for instructions which are read-modify-write, the value will
+	// change during execution and be used to write back to memory
+	var value uint8
+	var err error
+
+	switch defn.Effect {
+	case instructions.Read:
+		// +1 cycle
+		if zeroPage {
+			value, err = mc.read8BitZeroPage(uint8(address))
+		} else {
+			value, err = mc.read8Bit(address)
+		}
+		if err != nil {
+			return err
+		}
+
+	case instructions.RMW:
+		// +1 cycle
+		if zeroPage {
+			value, err = mc.read8BitZeroPage(uint8(address))
+		} else {
+			value, err = mc.read8Bit(address)
+		}
+		if err != nil {
+			return err
+		}
+
+		// phantom write
+		// +1 cycle
+		err = mc.write8Bit(address, value)
+		if err != nil {
+			return err
+		}
+
+		err = mc.endCycle()
+		if err != nil {
+			return err
+		}
+
+		value, err = op(mc, defn, address, value)
+		if err != nil {
+			return err
+		}
+
+		// write altered value back to memory
+		err = mc.write8Bit(address, value)
+		if err != nil {
+			return err
+		}
+
+		// +1 cycle
+		return mc.endCycle()
+	}
+
+	_, err = op(mc, defn, address, value)
+	return err
+}
+
+// implied mode does not use any additional bytes. however, the next
+// instruction is read but the PC is not incremented.
+func (mc *CPU) stepImplied(defn *instructions.Definition, op operator) error {
+	// phantom read
+	// +1 cycle
+	_, err := mc.read8Bit(mc.PC.Address())
+	if err != nil {
+		return err
+	}
+
+	_, err = op(mc, defn, 0, 0)
+	return err
+}
+
+// BRK is unusual in that it increases the PC by two bytes despite being an
+// implied addressing mode.
+func (mc *CPU) stepBRK(defn *instructions.Definition, op operator) error {
+	// +1 cycle
+	err := mc.read8BitPC(nil)
+	if err != nil {
+		return err
+	}
+
+	// but we don't LastResult to show this
+	mc.LastResult.ByteCount--
+
+	_, err = op(mc, defn, 0, 0)
+	return err
+}
+
+// for immediate mode, the value is the next byte in the program therefore, we
+// don't set the address and we read the value through the PC.
+func (mc *CPU) stepImmediate(defn *instructions.Definition, op operator) error {
+	// +1 cycle
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return err
+	}
+
+	_, err = op(mc, defn, 0, uint8(mc.LastResult.InstructionData))
+	return err
+}
+
+// relative addressing is only used for branch instructions, the address is an
+// offset value from the current PC position.
+func (mc *CPU) stepRelative(defn *instructions.Definition, op operator) error {
+	// most of the addressing cycles for this addressing mode are consumed in
+	// the branch() function
+
+	// +1 cycle
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return err
+	}
+
+	_, err = op(mc, defn, mc.LastResult.InstructionData, 0)
+	return err
+}
+
+func (mc *CPU) stepAbsolute(defn *instructions.Definition, op operator) error {
+	// +2 cycles
+	err := mc.read16BitPC()
+	if err != nil {
+		return err
+	}
+
+	return mc.operate(defn, op, mc.LastResult.InstructionData, false)
+}
+
+// for JSR, addresses are read slightly differently so we defer the reading of
+// the address to the operator.
+func (mc *CPU) stepSubroutine(defn *instructions.Definition, op operator) error {
+	_, err := op(mc, defn, 0, 0)
+	return err
+}
+
+func (mc *CPU) stepZeroPage(defn *instructions.Definition, op operator) error {
+	// +1 cycle
+	// while we must trest the value as an address (ie. as uint16) we actually
+	// only read an 8 bit value so we store the value as uint8
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return err
+	}
+
+	return mc.operate(defn, op, mc.LastResult.InstructionData, true)
+}
+
+// indirect addressing (without indexing) is only used for the JMP command.
+func (mc *CPU) stepIndirect(defn *instructions.Definition, op operator) error {
+	// +2 cycles
+	err := mc.read16BitPC()
+	if err != nil {
+		return err
+	}
+	indirectAddress := mc.LastResult.InstructionData
+
+	var address uint16
+
+	// handle indirect addressing JMP bug
+	if indirectAddress&0x00ff == 0x00ff {
+		mc.LastResult.CPUBug = "indirect addressing bug (JMP bug)"
+
+		var lo, hi uint8
+
+		lo, err = mc.read(indirectAddress)
+		if err != nil {
+			if !curated.Has(err, bus.AddressError) {
+				return err
+			}
+			mc.LastResult.Error = err.Error()
+		}
+
+		// +1 cycle
+		err = mc.endCycle()
+		if err != nil {
+			if !curated.Has(err, bus.AddressError) {
+				return err
+			}
+			mc.LastResult.Error = err.Error()
+			return err
+		}
+
+		// in this bug path, the lower byte of the indirect address is on a
+		// page boundary. because of the bug we must read high byte of JMP
+		// address from the zero byte of the same page (rather than the zero
+		// byte of the next page)
+		hi, err = mc.read(indirectAddress & 0xff00)
+		if err != nil {
+			return err
+		}
+		address = uint16(hi) << 8
+		address |= uint16(lo)
+
+		// +1 cycle
+		err = mc.endCycle()
+		if err != nil {
+			return err
+		}
+	} else {
+		// normal, non-buggy behaviour
+
+		// +2 cycles
+		address, err = mc.read16Bit(indirectAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	return mc.operate(defn, op, address, false)
+}
+
+// x indexing.
+func (mc *CPU) stepIndexedIndirect(defn *instructions.Definition, op operator) error {
+	// +1 cycle
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return err
+	}
+	indirectAddress := uint8(mc.LastResult.InstructionData)
+
+	// phantom read before adjusting the index
+	// +1 cycle
+	_, err = mc.read8Bit(uint16(indirectAddress))
+	if err != nil {
+		return err
+	}
+
+	// using 8bit addition because of the 6507's indirect addressing bug - we
+	// don't want indexed address t8 extend past the first page
+	mc.acc8.Load(mc.X.Value())
+	mc.acc8.Add(indirectAddress, false)
+
+	// make a note of indirect addressig bug
+	if uint16(indirectAddress+mc.X.Value())&0xff00 != uint16(indirectAddress)&0xff00 {
+		mc.LastResult.CPUBug = "indirect addressing bug"
+	}
+
+	// +2 cycles
+	address, err := mc.read16Bit(mc.acc8.Address())
+	if err != nil {
+		return err
+	}
+
+	// never a page fault wth pre-index indirect addressing
+
+	return mc.operate(defn, op, address, false)
+}
+
+// y indexing.
+func (mc *CPU) stepIndirectIndexed(defn *instructions.Definition, op operator) error {
+	// +1 cycle
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return err
+	}
+	indirectAddress := mc.LastResult.InstructionData
+
+	// +2 cycles
+	indexedAddress, err := mc.read16Bit(indirectAddress)
+	if err != nil {
+		return err
+	}
+
+	mc.acc16.Load(mc.Y.Address())
+	mc.acc16.Add(indexedAddress & 0x00ff)
+	address := mc.acc16.Address()
+
+	// check for page fault
+	if defn.PageSensitive && (address&0xff00 == 0x0100) {
+		mc.LastResult.CPUBug = "indirect addressing bug"
+		mc.LastResult.PageFault = true
+	}
+
+	if mc.LastResult.PageFault || defn.Effect == instructions.Write || defn.Effect == instructions.RMW {
+		// phantom read (always happens for Write and RMW)
+		// +1 cycle
+		_, err = mc.read8Bit((indexedAddress & 0xff00) | (address & 0x00ff))
+		if err != nil {
+			return err
+		}
+	}
+
+	// fix MSB of address
+	mc.acc16.Add(indexedAddress & 0xff00)
+
+	return mc.operate(defn, op, mc.acc16.Address(), false)
+}
+
+func (mc *CPU) stepAbsoluteIndexedX(defn *instructions.Definition, op operator) error {
+	return mc.stepAbsoluteIndexed(defn, op, mc.X.Address())
+}
+
+func (mc *CPU) stepAbsoluteIndexedY(defn *instructions.Definition, op operator) error {
+	return mc.stepAbsoluteIndexed(defn, op, mc.Y.Address())
+}
+
+// the X and Y indexed variations of absolute addressing differ only in the
+// index register used.
+func (mc *CPU) stepAbsoluteIndexed(defn *instructions.Definition, op operator, index uint16) error {
+	// +2 cycles
+	err := mc.read16BitPC()
+	if err != nil {
+		return err
+	}
+	indirectAddress := mc.LastResult.InstructionData
+
+	// add index to LSB of address
+	mc.acc16.Load(index)
+	mc.acc16.Add(indirectAddress & 0x00ff)
+	address := mc.acc16.Address()
+
+	// check for page fault
+	mc.LastResult.PageFault = defn.PageSensitive && (address&0xff00 == 0x0100)
+	if mc.LastResult.PageFault || defn.Effect == instructions.Write || defn.Effect == instructions.RMW {
+		// phantom read (always happens for Write and RMW)
+		// +1 cycle
+		_, err := mc.read8Bit((indirectAddress & 0xff00) | (address & 0x00ff))
+		if err != nil {
+			return err
+		}
+	}
+
+	// fix MSB of address
+	mc.acc16.Add(indirectAddress & 0xff00)
+
+	return mc.operate(defn, op, mc.acc16.Address(), false)
+}
+
+func (mc *CPU) stepZeroPageIndexedX(defn *instructions.Definition, op operator) error {
+	return mc.stepZeroPageIndexed(defn, op, mc.X.Value())
+}
+
+// used exclusively for LDX ZeroPage,y.
+func (mc *CPU) stepZeroPageIndexedY(defn *instructions.Definition, op operator) error {
+	return mc.stepZeroPageIndexed(defn, op, mc.Y.Value())
+}
+
+// the X and Y indexed variations of zero page addressing differ only in the
+// index register used.
+func (mc *CPU) stepZeroPageIndexed(defn *instructions.Definition, op operator, index uint8) error {
+	// +1 cycles
+	err := mc.read8BitPCOperand()
+	if err != nil {
+		return err
+	}
+	indirectAddress := uint8(mc.LastResult.InstructionData)
+
+	mc.acc8.Load(indirectAddress)
+	mc.acc8.Add(index, false)
+	address := mc.acc8.Address()
+
+	// make a note of zero page index bug
+	if uint16(indirectAddress+index)&0xff00 != uint16(indirectAddress)&0xff00 {
+		mc.LastResult.CPUBug = "zero page index bug"
+	}
+
+	// +1 cycle
+	err = mc.endCycle()
+	if err != nil {
+		return err
+	}
+
+	return mc.operate(defn, op, address, true)
+}
@@ -0,0 +1,118 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package hardware_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/digest"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// a kernel that sets the background color of every scanline to a value
+// derived from the unused pins of a TIA register and from uninitialised RAM.
+const kernel = `sei : cld : ldx #$ff : txs
+lda #$02 : sta $00 : sta $02 : sta $02 : sta $02 : lda #$00 : sta $00
+ldx #$ff
+lda $00 : eor $80 : sta $09 : sta $02 : dex : bne $f015
+jmp $f005`
+
+// run the test ROM with random hardware state and return the video digest.
+func run(t *testing.T, seed int64) string {
+	t.Helper()
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+	tv.SetFPSCap(false)
+
+	dig, err := digest.NewVideo(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating digest: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	_ = vcs.Prefs.RandomState.Set(true)
+	_ = vcs.Prefs.RandomPins.Set(true)
+	vcs.Prefs.Reseed(seed)
+
+	data, err := assembler.Cartridge(kernel)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{
+		Filename: "determinism_test",
+		Mapping:  "4k",
+		Data:     data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	err = vcs.Run(func() (bool, error) {
+		return vcs.TV.GetState(signal.ReqFramenum) < 5, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error running emulation: %v", err)
+	}
+
+	return dig.Hash()
+}
+
+func TestDeterminism(t *testing.T) {
+	a := run(t, 100)
+	b := run(t, 100)
+	if a != b {
+		t.Errorf("emulations with the same seed produced different output")
+	}
+
+	c := run(t, 200)
+	if a == c {
+		t.Errorf("emulations with different seeds produced the same output")
+	}
+}
+
+func TestFixedClock(t *testing.T) {
+	prefs, err := preferences.NewPreferences()
+	if err != nil {
+		t.Fatalf("unexpected error creating preferences: %v", err)
+	}
+
+	prefs.Clock = preferences.FixedClock(time.Unix(0, 12345))
+	prefs.Reseed(0)
+	a := prefs.RandSrc.Int63()
+	if prefs.RandSeed != 12345 {
+		t.Errorf("expected seed of 12345, got %d", prefs.RandSeed)
+	}
+
+	prefs.Reseed(0)
+	b := prefs.RandSrc.Int63()
+	if a != b {
+		t.Errorf("reseeding with a fixed clock produced different random numbers")
+	}
+}
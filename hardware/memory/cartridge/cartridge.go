@@ -244,6 +244,13 @@ func (cart *Cartridge) Attach(cartload cartridgeloader.Loader) error {
 		cart.mapper, err = newDPC(cartload.Data)
 	case "DPC+":
 		cart.mapper, err = harmony.NewDPCplus(cartload.Data)
+	default:
+		// mappers that have been added with mapper.Register()
+		reg, ok := mapper.Registered(cartload.Mapping)
+		if !ok {
+			return curated.Errorf("cartridge: unrecognised mapping (%s)", cartload.Mapping)
+		}
+		cart.mapper, err = reg.Create(cartload.Data)
 	}
 
 	if err != nil {
@@ -200,6 +200,16 @@ func (cart *Cartridge) fingerprint(cartload cartridgeloader.Loader) error {
 		return err
 	}
 
+	// mappers that have been added with mapper.Register(). these are checked
+	// before the size based fingerprinting below because a registered mapper
+	// will typically share its size with one of the built-in mappers
+	for _, reg := range mapper.Registrations() {
+		if reg.Fingerprint != nil && reg.Fingerprint(cartload.Data) {
+			cart.mapper, err = reg.Create(cartload.Data)
+			return err
+		}
+	}
+
 	switch len(cartload.Data) {
 	case 2048:
 		cart.mapper, err = newAtari2k(cartload.Data)
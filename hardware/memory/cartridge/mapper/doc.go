@@ -24,4 +24,41 @@
 // In addition to the interfaces, any additional types are defined. For
 // instance, the CartHotspotInfo type the symbol name and action type for a
 // every hotspot in the cartridge.
+//
+// New bank-switching schemes can be added without changing the cartridge
+// package. The mapper should implement the CartMapper interface and register
+// itself with the Register() function, normally from the init() function of
+// the package in which it is defined:
+//
+//	func init() {
+//		err := mapper.Register(mapper.Registration{
+//			ID:          "EX",
+//			Description: "example mapper",
+//			Create:      newExample,
+//			Fingerprint: fingerprintExample,
+//		})
+//		if err != nil {
+//			panic(err)
+//		}
+//	}
+//
+// The package containing the mapper must then be imported, with a blank
+// import if necessary, by the program. Once registered, the mapper can be
+// selected by specifying the ID as the cartridge mapping. If a Fingerprint
+// function is provided then the mapper will also be considered when the
+// mapping is being detected automatically.
+//
+// The CartMapper interface covers memory access (Read(), Write() and
+// Listen()), the current banking state (NumBanks() and GetBank()) and the
+// rewind system (Snapshot() and Plumb()). The state of the mapper that can
+// change during emulation should be kept in a separate type that implements
+// the CartSnapshot interface, so that Snapshot() and Plumb() are simple to
+// implement. Of the optional interfaces, CartHotspotsBus is the most useful.
+// The hotspots are added to the symbols table and so appear in the
+// disassembly. CartRegistersBus, CartRAMbus and CartStaticBus make the
+// internal state of the cartridge available to the debugger.
+//
+// The mappertest package contains a test scaffold for new mappers. The
+// Check() function tests the behaviour that the rest of the emulation expects
+// of all mappers.
 package mapper
@@ -43,8 +43,14 @@ type CartMapper interface {
 	// explicit reset (possibly with randomisation)
 	Reset(randSrc *rand.Rand)
 
-	Read(addr uint16, active bool) (data uint8, err error)
-	Write(addr uint16, data uint8, active bool, poke bool) error
+	// read and write the cartridge. a passive access should not cause any
+	// bank-switching or other side-effects. a poke is a write from the
+	// debugger and should alter the underlying data where possible
+	Read(addr uint16, passive bool) (data uint8, err error)
+	Write(addr uint16, data uint8, passive bool, poke bool) error
+
+	// the number of banks in the cartridge and the bank currently mapped to
+	// the address
 	NumBanks() int
 	GetBank(addr uint16) BankInfo
 
@@ -0,0 +1,157 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package mappertest_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper/mappertest"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// example is a minimal mapper implemented outside of the cartridge package. It
+// is the same as the Atari F8 scheme: two 4k banks switched by accessing
+// 0x1ff8 and 0x1ff9.
+type example struct {
+	banks [2][]uint8
+
+	// rewindable state
+	state *exampleState
+}
+
+type exampleState struct {
+	bank int
+}
+
+// Snapshot implements the mapper.CartSnapshot interface.
+func (s *exampleState) Snapshot() mapper.CartSnapshot {
+	n := *s
+	return &n
+}
+
+func newExample(data []byte) (mapper.CartMapper, error) {
+	if len(data) != 8192 {
+		return nil, curated.Errorf("EX: %v", "wrong number bytes in the cartridge data")
+	}
+
+	cart := &example{state: &exampleState{}}
+	for b := range cart.banks {
+		cart.banks[b] = make([]uint8, 4096)
+		copy(cart.banks[b], data[b*4096:])
+	}
+
+	return cart, nil
+}
+
+func (cart *example) String() string {
+	return fmt.Sprintf("EX Bank: %d", cart.state.bank)
+}
+
+func (cart *example) ID() string {
+	return "EX"
+}
+
+func (cart *example) Snapshot() mapper.CartSnapshot {
+	return cart.state.Snapshot()
+}
+
+func (cart *example) Plumb(s mapper.CartSnapshot) {
+	cart.state = s.(*exampleState)
+}
+
+func (cart *example) Reset(_ *rand.Rand) {
+	cart.state.bank = len(cart.banks) - 1
+}
+
+func (cart *example) bankswitch(addr uint16, passive bool) {
+	if passive {
+		return
+	}
+	switch addr {
+	case 0x0ff8:
+		cart.state.bank = 0
+	case 0x0ff9:
+		cart.state.bank = 1
+	}
+}
+
+func (cart *example) Read(addr uint16, passive bool) (uint8, error) {
+	cart.bankswitch(addr, passive)
+	return cart.banks[cart.state.bank][addr], nil
+}
+
+func (cart *example) Write(addr uint16, _ uint8, passive bool, poke bool) error {
+	cart.bankswitch(addr, passive)
+	if poke {
+		return curated.Errorf("EX: %v", "cannot poke ROM")
+	}
+	return nil
+}
+
+func (cart *example) NumBanks() int {
+	return len(cart.banks)
+}
+
+func (cart *example) GetBank(_ uint16) mapper.BankInfo {
+	return mapper.BankInfo{Number: cart.state.bank}
+}
+
+func (cart *example) Listen(_ uint16, _ uint8) {
+}
+
+func (cart *example) Step() {
+}
+
+func (cart *example) Patch(offset int, data uint8) error {
+	if offset >= 8192 {
+		return curated.Errorf("EX: %v", fmt.Errorf("patch offset too high (%v)", offset))
+	}
+	cart.banks[offset/4096][offset%4096] = data
+	return nil
+}
+
+func (cart *example) CopyBanks() []mapper.BankContent {
+	c := make([]mapper.BankContent, len(cart.banks))
+	for b := range cart.banks {
+		c[b] = mapper.BankContent{
+			Number:  b,
+			Data:    append([]uint8{}, cart.banks[b]...),
+			Origins: []uint16{memorymap.OriginCart},
+		}
+	}
+	return c
+}
+
+// ReadHotspots implements the mapper.CartHotspotsBus interface.
+func (cart *example) ReadHotspots() map[uint16]mapper.CartHotspotInfo {
+	return map[uint16]mapper.CartHotspotInfo{
+		0x1ff8: {Symbol: "BANK0", Action: mapper.HotspotBankSwitch},
+		0x1ff9: {Symbol: "BANK1", Action: mapper.HotspotBankSwitch},
+	}
+}
+
+// WriteHotspots implements the mapper.CartHotspotsBus interface.
+func (cart *example) WriteHotspots() map[uint16]mapper.CartHotspotInfo {
+	return cart.ReadHotspots()
+}
+
+func TestExample(t *testing.T) {
+	mappertest.Check(t, newExample, make([]byte, 8192))
+}
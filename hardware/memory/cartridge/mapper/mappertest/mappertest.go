@@ -0,0 +1,193 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package mappertest contains a test scaffold for implementations of the
+// mapper.CartMapper interface. It checks the behaviour that the rest of the
+// emulation expects of every mapper, regardless of the bank-switching scheme.
+//
+// A new mapper should be tested with the Check() function in addition to any
+// tests specific to the bank-switching scheme. For example:
+//
+//	func TestMyMapper(t *testing.T) {
+//		mappertest.Check(t, newMyMapper, make([]byte, 8192))
+//	}
+package mappertest
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// cartridge addresses are normalised to this range before being passed to the
+// mapper.
+const numAddresses = 0x1000
+
+// the distance between the addresses used to sample the banking state of the
+// mapper. small enough for the smallest segment of any known mapper.
+const bankSample = 0x100
+
+// banking returns the bank information for a sample of cartridge addresses.
+func banking(m mapper.CartMapper) []mapper.BankInfo {
+	b := make([]mapper.BankInfo, 0, numAddresses/bankSample)
+	for a := uint16(0); a < numAddresses; a += bankSample {
+		b = append(b, m.GetBank(a))
+	}
+	return b
+}
+
+// peek reads every cartridge address passively.
+func peek(t *testing.T, m mapper.CartMapper) []uint8 {
+	t.Helper()
+	d := make([]uint8, numAddresses)
+	for a := range d {
+		v, err := m.Read(uint16(a), true)
+		if err != nil {
+			t.Fatalf("%s: unexpected error reading address %#04x: %v", m.ID(), a, err)
+		}
+		d[a] = v
+	}
+	return d
+}
+
+func equalBanking(a, b []mapper.BankInfo) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalData(a, b []uint8) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Check creates a mapper from the data and runs it through a series of tests.
+// The data should be valid for the mapper.
+func Check(t *testing.T, create mapper.Creator, data []byte) {
+	t.Helper()
+
+	m, err := create(data)
+	if err != nil {
+		t.Fatalf("unexpected error creating mapper: %v", err)
+	}
+
+	if m.ID() == "" {
+		t.Errorf("mapper ID is empty")
+	}
+	id := m.ID()
+
+	if m.NumBanks() <= 0 {
+		t.Errorf("%s: NumBanks() should be greater than zero", id)
+	}
+
+	// reset without a random source should always put the mapper in the same
+	// state
+	m.Reset(nil)
+	initBanking := banking(m)
+	initData := peek(t, m)
+	m.Reset(nil)
+	if !equalBanking(initBanking, banking(m)) || !equalData(initData, peek(t, m)) {
+		t.Errorf("%s: Reset(nil) is not deterministic", id)
+	}
+
+	// bank numbers should be in range
+	for a := uint16(0); a < numAddresses; a++ {
+		b := m.GetBank(a)
+		if !b.IsRAM && !b.NonCart && (b.Number < 0 || b.Number >= m.NumBanks()) {
+			t.Errorf("%s: GetBank(%#04x) returns bank out of range (%d)", id, a, b.Number)
+			break // for loop
+		}
+	}
+
+	// passive reads should not change the state of the mapper
+	peek(t, m)
+	if !equalBanking(initBanking, banking(m)) {
+		t.Errorf("%s: passive reads change the banking state", id)
+	}
+
+	// snapshot should be unaffected by changes to the mapper. note that some
+	// mappers change state on every read (the DPC random number generator for
+	// example) so the data read from the mapper is only compared after
+	// restoring the snapshot each time
+	s := m.Snapshot()
+	snapBanking := banking(m)
+	snapData := peek(t, m)
+	m.Plumb(s.Snapshot())
+	if !equalData(snapData, peek(t, m)) {
+		t.Errorf("%s: state not restored by Plumb()", id)
+	}
+
+	// active reads of every address will trigger any bank-switching hotspots
+	for a := uint16(0); a < numAddresses; a++ {
+		_, _ = m.Read(a, false)
+	}
+	m.Plumb(s.Snapshot())
+	if !equalBanking(snapBanking, banking(m)) || !equalData(snapData, peek(t, m)) {
+		t.Errorf("%s: state not restored by Plumb() after bank-switching", id)
+	}
+
+	// copies of banks should have valid numbers and origins
+	for _, b := range m.CopyBanks() {
+		if b.Number < 0 || b.Number >= m.NumBanks() {
+			t.Errorf("%s: CopyBanks() returns bank out of range (%d)", id, b.Number)
+		}
+		if len(b.Origins) == 0 {
+			t.Errorf("%s: CopyBanks() returns bank %d with no origins", id, b.Number)
+		}
+		for _, o := range b.Origins {
+			if o < memorymap.OriginCart || o > memorymap.MemtopCart {
+				t.Errorf("%s: CopyBanks() returns bank %d with origin outside cartridge space (%#04x)", id, b.Number, o)
+			}
+		}
+	}
+
+	// patching beyond the end of the data should fail
+	if err := m.Patch(len(data), 0x00); err == nil {
+		t.Errorf("%s: Patch() beyond end of data should return an error", id)
+	}
+
+	// hotspot addresses must be in the primary cartridge mirror
+	if hs, ok := m.(mapper.CartHotspotsBus); ok {
+		check := func(kind string, spots map[uint16]mapper.CartHotspotInfo) {
+			for a := range spots {
+				if a < memorymap.OriginCart || a > memorymap.MemtopCart {
+					t.Errorf("%s: %s hotspot outside cartridge space (%#04x)", id, kind, a)
+				}
+			}
+		}
+		check("read", hs.ReadHotspots())
+		check("write", hs.WriteHotspots())
+	}
+
+	// RAM returned by GetRAM() should be a copy
+	if rb, ok := m.(mapper.CartRAMbus); ok {
+		ram := rb.GetRAM()
+		if len(ram) > 0 && len(ram[0].Data) > 0 {
+			v := ram[0].Data[0]
+			ram[0].Data[0] = ^v
+			if rb.GetRAM()[0].Data[0] != v {
+				t.Errorf("%s: GetRAM() does not return a copy of cartridge RAM", id)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package mapper
+
+import (
+	"sort"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Creator functions create a new instance of a CartMapper from the cartridge
+// data. An error should be returned if the data is not suitable for the
+// mapper.
+type Creator func(data []byte) (CartMapper, error)
+
+// Fingerprinter functions return true if the cartridge data is very likely to
+// be for the mapper. Fingerprinting should be conservative because a false
+// positive will prevent the cartridge from being loaded correctly.
+type Fingerprinter func(data []byte) bool
+
+// Registration describes a mapper that has been added to the emulation with
+// the Register() function.
+type Registration struct {
+	// the mapping ID. this is the value used to select the mapper with the
+	// -mapping flag. it should be the same as the value returned by the ID()
+	// function of the CartMapper
+	ID string
+
+	// short description of the mapper
+	Description string
+
+	// required function to create a new instance of the mapper
+	Create Creator
+
+	// optional function used when the mapping is being detected
+	// automatically. if the function is nil then the mapper can only be
+	// selected by specifying the mapping ID
+	Fingerprint Fingerprinter
+}
+
+// the registered mappers indexed by mapping ID.
+var registry = make(map[string]Registration)
+
+// Register a new cartridge mapper. Mappers should normally be registered from
+// the init() function of the package in which they are defined.
+//
+// The ID must not be the same as any previously registered mapper. IDs of the
+// mappers built into the cartridge package take precedence over registered
+// mappers, so registering a mapper with one of those IDs will have no effect.
+func Register(reg Registration) error {
+	if reg.ID == "" {
+		return curated.Errorf("mapper: registration requires an ID")
+	}
+
+	if reg.Create == nil {
+		return curated.Errorf("mapper: registration for %s requires a Create function", reg.ID)
+	}
+
+	if _, ok := registry[reg.ID]; ok {
+		return curated.Errorf("mapper: %s already registered", reg.ID)
+	}
+
+	registry[reg.ID] = reg
+
+	return nil
+}
+
+// Registered returns the Registration for the mapping ID. Returns false if no
+// mapper with that ID has been registered.
+func Registered(id string) (Registration, bool) {
+	reg, ok := registry[id]
+	return reg, ok
+}
+
+// Registrations returns a list of all registered mappers, sorted by ID.
+func Registrations() []Registration {
+	regs := make([]Registration, 0, len(registry))
+	for _, r := range registry {
+		regs = append(regs, r)
+	}
+
+	sort.Slice(regs, func(i, j int) bool {
+		return regs[i].ID < regs[j].ID
+	})
+
+	return regs
+}
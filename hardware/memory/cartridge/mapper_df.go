@@ -266,38 +266,38 @@ func (cart df) CopyBanks() []mapper.BankContent {
 // ReadHotspots implements the mapper.CartHotspotsBus interface.
 func (cart df) ReadHotspots() map[uint16]mapper.CartHotspotInfo {
 	return map[uint16]mapper.CartHotspotInfo{
-		0x0fc0: {Symbol: "BANK0", Action: mapper.HotspotBankSwitch},
-		0x0fc1: {Symbol: "BANK1", Action: mapper.HotspotBankSwitch},
-		0x0fc2: {Symbol: "BANK2", Action: mapper.HotspotBankSwitch},
-		0x0fc3: {Symbol: "BANK3", Action: mapper.HotspotBankSwitch},
-		0x0fc4: {Symbol: "BANK4", Action: mapper.HotspotBankSwitch},
-		0x0fc5: {Symbol: "BANK5", Action: mapper.HotspotBankSwitch},
-		0x0fc6: {Symbol: "BANK6", Action: mapper.HotspotBankSwitch},
-		0x0fc7: {Symbol: "BANK7", Action: mapper.HotspotBankSwitch},
-		0x0fc8: {Symbol: "BANK8", Action: mapper.HotspotBankSwitch},
-		0x0fc9: {Symbol: "BANK9", Action: mapper.HotspotBankSwitch},
-		0x0fca: {Symbol: "BANK10", Action: mapper.HotspotBankSwitch},
-		0x0fcb: {Symbol: "BANK11", Action: mapper.HotspotBankSwitch},
-		0x0fcc: {Symbol: "BANK12", Action: mapper.HotspotBankSwitch},
-		0x0fcd: {Symbol: "BANK13", Action: mapper.HotspotBankSwitch},
-		0x0fce: {Symbol: "BANK14", Action: mapper.HotspotBankSwitch},
-		0x0fcf: {Symbol: "BANK15", Action: mapper.HotspotBankSwitch},
-		0x0fd0: {Symbol: "BANK16", Action: mapper.HotspotBankSwitch},
-		0x0fd1: {Symbol: "BANK17", Action: mapper.HotspotBankSwitch},
-		0x0fd2: {Symbol: "BANK18", Action: mapper.HotspotBankSwitch},
-		0x0fd3: {Symbol: "BANK19", Action: mapper.HotspotBankSwitch},
-		0x0fd4: {Symbol: "BANK20", Action: mapper.HotspotBankSwitch},
-		0x0fd5: {Symbol: "BANK21", Action: mapper.HotspotBankSwitch},
-		0x0fd6: {Symbol: "BANK22", Action: mapper.HotspotBankSwitch},
-		0x0fd7: {Symbol: "BANK23", Action: mapper.HotspotBankSwitch},
-		0x0fd8: {Symbol: "BANK24", Action: mapper.HotspotBankSwitch},
-		0x0fd9: {Symbol: "BANK25", Action: mapper.HotspotBankSwitch},
-		0x0fda: {Symbol: "BANK26", Action: mapper.HotspotBankSwitch},
-		0x0fdb: {Symbol: "BANK27", Action: mapper.HotspotBankSwitch},
-		0x0fdc: {Symbol: "BANK28", Action: mapper.HotspotBankSwitch},
-		0x0fdd: {Symbol: "BANK29", Action: mapper.HotspotBankSwitch},
-		0x0fde: {Symbol: "BANK30", Action: mapper.HotspotBankSwitch},
-		0x0fdf: {Symbol: "BANK31", Action: mapper.HotspotBankSwitch},
+		0x1fc0: {Symbol: "BANK0", Action: mapper.HotspotBankSwitch},
+		0x1fc1: {Symbol: "BANK1", Action: mapper.HotspotBankSwitch},
+		0x1fc2: {Symbol: "BANK2", Action: mapper.HotspotBankSwitch},
+		0x1fc3: {Symbol: "BANK3", Action: mapper.HotspotBankSwitch},
+		0x1fc4: {Symbol: "BANK4", Action: mapper.HotspotBankSwitch},
+		0x1fc5: {Symbol: "BANK5", Action: mapper.HotspotBankSwitch},
+		0x1fc6: {Symbol: "BANK6", Action: mapper.HotspotBankSwitch},
+		0x1fc7: {Symbol: "BANK7", Action: mapper.HotspotBankSwitch},
+		0x1fc8: {Symbol: "BANK8", Action: mapper.HotspotBankSwitch},
+		0x1fc9: {Symbol: "BANK9", Action: mapper.HotspotBankSwitch},
+		0x1fca: {Symbol: "BANK10", Action: mapper.HotspotBankSwitch},
+		0x1fcb: {Symbol: "BANK11", Action: mapper.HotspotBankSwitch},
+		0x1fcc: {Symbol: "BANK12", Action: mapper.HotspotBankSwitch},
+		0x1fcd: {Symbol: "BANK13", Action: mapper.HotspotBankSwitch},
+		0x1fce: {Symbol: "BANK14", Action: mapper.HotspotBankSwitch},
+		0x1fcf: {Symbol: "BANK15", Action: mapper.HotspotBankSwitch},
+		0x1fd0: {Symbol: "BANK16", Action: mapper.HotspotBankSwitch},
+		0x1fd1: {Symbol: "BANK17", Action: mapper.HotspotBankSwitch},
+		0x1fd2: {Symbol: "BANK18", Action: mapper.HotspotBankSwitch},
+		0x1fd3: {Symbol: "BANK19", Action: mapper.HotspotBankSwitch},
+		0x1fd4: {Symbol: "BANK20", Action: mapper.HotspotBankSwitch},
+		0x1fd5: {Symbol: "BANK21", Action: mapper.HotspotBankSwitch},
+		0x1fd6: {Symbol: "BANK22", Action: mapper.HotspotBankSwitch},
+		0x1fd7: {Symbol: "BANK23", Action: mapper.HotspotBankSwitch},
+		0x1fd8: {Symbol: "BANK24", Action: mapper.HotspotBankSwitch},
+		0x1fd9: {Symbol: "BANK25", Action: mapper.HotspotBankSwitch},
+		0x1fda: {Symbol: "BANK26", Action: mapper.HotspotBankSwitch},
+		0x1fdb: {Symbol: "BANK27", Action: mapper.HotspotBankSwitch},
+		0x1fdc: {Symbol: "BANK28", Action: mapper.HotspotBankSwitch},
+		0x1fdd: {Symbol: "BANK29", Action: mapper.HotspotBankSwitch},
+		0x1fde: {Symbol: "BANK30", Action: mapper.HotspotBankSwitch},
+		0x1fdf: {Symbol: "BANK31", Action: mapper.HotspotBankSwitch},
 	}
 }
 
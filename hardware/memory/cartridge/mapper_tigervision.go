@@ -191,28 +191,25 @@ func (cart *tigervision) Step() {
 func (cart tigervision) CopyBanks() []mapper.BankContent {
 	c := make([]mapper.BankContent, len(cart.banks))
 
-	// banks 0 to len-1 can occupy any of the first three segments
+	// banks 0 to len-1 can only occupy the first segment
 	for b := 0; b < len(cart.banks)-1; b++ {
 		c[b] = mapper.BankContent{Number: b,
-			Data: cart.banks[b],
-			Origins: []uint16{
-				memorymap.OriginCart,
-				memorymap.OriginCart + uint16(cart.bankSize),
-				memorymap.OriginCart + uint16(cart.bankSize)*2,
-			},
+			Data:    cart.banks[b],
+			Origins: []uint16{memorymap.OriginCart},
 		}
 	}
 
-	// last bank cannot point to the first segment
+	// last bank is always in the second segment but can also be switched
+	// into the first segment
 	b := len(cart.banks) - 1
 	c[b] = mapper.BankContent{Number: b,
 		Data: cart.banks[b],
 		Origins: []uint16{
+			memorymap.OriginCart,
 			memorymap.OriginCart + uint16(cart.bankSize),
-			memorymap.OriginCart + uint16(cart.bankSize)*2,
-			memorymap.OriginCart + uint16(cart.bankSize)*3,
 		},
 	}
+
 	return c
 }
 
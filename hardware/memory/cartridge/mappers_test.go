@@ -0,0 +1,136 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package cartridge
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper/mappertest"
+)
+
+// cartridge data with a recognisable pattern.
+func testData(size int) []byte {
+	d := make([]byte, size)
+	for i := range d {
+		d[i] = uint8(i ^ (i >> 8))
+	}
+	return d
+}
+
+func TestBuiltinMappers(t *testing.T) {
+	tests := []struct {
+		name   string
+		create mapper.Creator
+		size   int
+	}{
+		{"2k", newAtari2k, 2048},
+		{"4k", newAtari4k, 4096},
+		{"F8", newAtari8k, 8192},
+		{"F6", newAtari16k, 16384},
+		{"F4", newAtari32k, 32768},
+		{"FA", newCBS, 12288},
+		{"E0", newParkerBros, 8192},
+		{"E7", newMnetwork, 16384},
+		{"3F", newTigervision, 8192},
+		{"DF", newDF, 131072},
+		{"3E", new3e, 32768},
+		{"DPC", newDPC, 10240},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mappertest.Check(t, tt.create, testData(tt.size))
+		})
+	}
+}
+
+// testMapper wraps a built-in mapper so that it can be identified.
+type testMapper struct {
+	mapper.CartMapper
+}
+
+func (m testMapper) ID() string {
+	return "TEST"
+}
+
+func TestRegisteredMapper(t *testing.T) {
+	err := mapper.Register(mapper.Registration{
+		ID:          "TEST",
+		Description: "test mapper",
+		Create: func(data []byte) (mapper.CartMapper, error) {
+			m, err := newAtari4k(data)
+			return testMapper{CartMapper: m}, err
+		},
+		Fingerprint: func(data []byte) bool {
+			return len(data) == 4096 && data[0] == 0xa5
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error registering mapper: %v", err)
+	}
+
+	err = mapper.Register(mapper.Registration{ID: "TEST", Create: newAtari4k})
+	if err == nil {
+		t.Errorf("expected error registering mapper with duplicate ID")
+	}
+
+	attach := func(mapping string, data []byte) (string, error) {
+		cart := NewCartridge(nil)
+		err := cart.Attach(cartridgeloader.Loader{
+			Filename: "test",
+			Mapping:  mapping,
+			Data:     data,
+		})
+		return cart.ID(), err
+	}
+
+	// selected explicitly
+	id, err := attach("TEST", testData(4096))
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+	if id != "TEST" {
+		t.Errorf("expected TEST mapper, got %s", id)
+	}
+
+	// selected by fingerprint
+	d := testData(4096)
+	d[0] = 0xa5
+	id, err = attach("AUTO", d)
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+	if id != "TEST" {
+		t.Errorf("expected TEST mapper, got %s", id)
+	}
+
+	// fingerprint does not match
+	id, err = attach("AUTO", testData(4096))
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+	if id != "4k" {
+		t.Errorf("expected 4k mapper, got %s", id)
+	}
+
+	// unknown mapping
+	_, err = attach("UNKNOWN", testData(4096))
+	if err == nil {
+		t.Errorf("expected error for unrecognised mapping")
+	}
+}
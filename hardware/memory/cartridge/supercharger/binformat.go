@@ -0,0 +1,131 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package supercharger
+
+import (
+	"fmt"
+)
+
+// the number of bytes in a single load of the binary tape format. binary files
+// are made up of one or more loads.
+const binLoadSize = 8448
+
+// the maximum number of pages in a single load.
+const binMaxPages = 24
+
+// binLoad is a single load of the binary tape format. The format is the same
+// as the one used by Stella, with the 8k of page data first followed by a
+// 256 byte header.
+type binLoad struct {
+	// page data. pages are stored in the order in which they are loaded
+	pages []byte
+
+	// the eight bytes of the header proper
+	header []byte
+
+	// the page table indicates the bank and page number of each page.
+	// pageChecksums contains the checksum byte for each page
+	pageTable     []byte
+	pageChecksums []byte
+
+	// header values
+	startAddress  uint16
+	configByte    uint8
+	numPages      int
+	checksum      uint8
+	multiload     uint8
+	progressSpeed uint16
+}
+
+// parseBinLoad interprets the data as a single load of the binary tape format.
+func parseBinLoad(data []byte) (binLoad, error) {
+	if len(data) != binLoadSize {
+		return binLoad{}, fmt.Errorf("wrong number of bytes in load")
+	}
+
+	bl := binLoad{
+		pages:         data[0x0000:0x2000],
+		header:        data[0x2000:0x2008],
+		pageTable:     data[0x2010:0x2028],
+		pageChecksums: data[0x2040:0x2058],
+	}
+
+	// PC address to jump to once loading has finished
+	bl.startAddress = (uint16(bl.header[1]) << 8) | uint16(bl.header[0])
+
+	// RAM config to be set after tape load
+	bl.configByte = bl.header[2]
+
+	// number of pages to load
+	bl.numPages = int(bl.header[3])
+	if bl.numPages > binMaxPages {
+		return binLoad{}, fmt.Errorf("too many pages in load (%d)", bl.numPages)
+	}
+
+	bl.checksum = bl.header[4]
+	bl.multiload = bl.header[5]
+	bl.progressSpeed = (uint16(bl.header[7]) << 8) | uint16(bl.header[6])
+
+	return bl, nil
+}
+
+// page returns the data for the page loaded in the order specified.
+func (bl binLoad) page(i int) []byte {
+	return bl.pages[i*0x100 : (i+1)*0x100]
+}
+
+// the sum of a correctly formed header or page (including the page table
+// entry and checksum byte) is always this value.
+const binChecksumTarget = 0x55
+
+// headerChecksum returns the value the checksum byte in the header should be.
+func (bl binLoad) headerChecksum() uint8 {
+	sum := uint8(0)
+	for i, b := range bl.header {
+		if i != 4 {
+			sum += b
+		}
+	}
+	return binChecksumTarget - sum
+}
+
+// pageChecksum returns the value the checksum byte for the page should be.
+func (bl binLoad) pageChecksum(i int) uint8 {
+	sum := bl.pageTable[i]
+	for _, b := range bl.page(i) {
+		sum += b
+	}
+	return binChecksumTarget - sum
+}
+
+// splitBinLoads divides data into loads. The data must contain at least one
+// load and no partial loads.
+func splitBinLoads(data []byte) ([]binLoad, error) {
+	if len(data) == 0 || len(data)%binLoadSize != 0 {
+		return nil, fmt.Errorf("wrong number of bytes in cartridge data")
+	}
+
+	var loads []binLoad
+	for i := 0; i < len(data); i += binLoadSize {
+		bl, err := parseBinLoad(data[i : i+binLoadSize])
+		if err != nil {
+			return nil, err
+		}
+		loads = append(loads, bl)
+	}
+
+	return loads, nil
+}
@@ -0,0 +1,186 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package supercharger
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-audio/audio"
+	"github.com/go-audio/wav"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// tag string used in called to Log().
+const exportLogTag = "supercharger: export"
+
+// the length of the audio cycle, in microseconds, for zero and one bits.
+// values taken from "Atari 2600 Mappers" document by Kevin Horton and are the
+// optimal values quoted in that document.
+const (
+	zeroBitCycle = 227.0
+	oneBitCycle  = 340.0
+)
+
+// the number of tone bytes before each load and between the header and the
+// page data. a tone is made of alternating zero and one bits and is terminated
+// by the sync byte.
+const (
+	toneByte        = 0x55
+	syncByte        = 0x54
+	leaderToneBytes = 512
+	gapToneBytes    = 16
+)
+
+// the length of silence, in seconds, after each load. for multiload tapes
+// this gives the BIOS time to start the next load.
+const loadGap = 1.0
+
+// ExportSampleRate is the sample rate of WAV files created by ExportWAV().
+const ExportSampleRate = 44100
+
+// tapeEncoder converts bytes into square wave PCM data.
+type tapeEncoder struct {
+	samples []int
+
+	// the number of samples that should have been written so far. the value
+	// is fractional so that rounding errors do not accumulate
+	target float64
+}
+
+const (
+	sampleHigh = 0x7fff
+	sampleLow  = -0x7fff
+)
+
+func (enc *tapeEncoder) level(level int, duration float64) {
+	enc.target += duration * ExportSampleRate / 1000000.0
+	for float64(len(enc.samples)) < enc.target {
+		enc.samples = append(enc.samples, level)
+	}
+}
+
+func (enc *tapeEncoder) bit(b bool) {
+	cycle := zeroBitCycle
+	if b {
+		cycle = oneBitCycle
+	}
+	enc.level(sampleHigh, cycle/2)
+	enc.level(sampleLow, cycle/2)
+}
+
+// bytes are written most significant bit first.
+func (enc *tapeEncoder) byte(b uint8) {
+	for i := 7; i >= 0; i-- {
+		enc.bit(b&(1<<i) != 0)
+	}
+}
+
+func (enc *tapeEncoder) tone(n int) {
+	for i := 0; i < n; i++ {
+		enc.byte(toneByte)
+	}
+	enc.byte(syncByte)
+}
+
+func (enc *tapeEncoder) silence(duration float64) {
+	enc.level(0, duration*1000000.0)
+}
+
+// encodeLoad writes a single load. the load is made up of a leader tone, the
+// eight header bytes, a short tone and then each page preceded by its page
+// table entry and checksum.
+//
+// checksums in the binary data are often incorrect because emulators do not
+// check them. they are recalculated so that the BIOS accepts the load.
+func (enc *tapeEncoder) encodeLoad(bl binLoad) {
+	enc.tone(leaderToneBytes)
+
+	for i, b := range bl.header {
+		if i == 4 {
+			b = bl.headerChecksum()
+			if b != bl.checksum {
+				logger.Log(exportLogTag, fmt.Sprintf("correcting header checksum for load %#02x", bl.multiload))
+			}
+		}
+		enc.byte(b)
+	}
+
+	enc.tone(gapToneBytes)
+
+	for i := 0; i < bl.numPages; i++ {
+		checksum := bl.pageChecksum(i)
+		if checksum != bl.pageChecksums[i] {
+			logger.Log(exportLogTag, fmt.Sprintf("correcting checksum for page %d of load %#02x", i, bl.multiload))
+		}
+
+		enc.byte(bl.pageTable[i])
+		enc.byte(checksum)
+		for _, b := range bl.page(i) {
+			enc.byte(b)
+		}
+	}
+
+	// finish with a zero bit so that the final bit of data is followed by a
+	// cycle boundary
+	enc.bit(false)
+
+	enc.silence(loadGap)
+}
+
+// ExportWAV converts Supercharger binary data into a WAV file suitable for
+// loading on real Supercharger hardware.
+//
+// The data must be in the binary format used by Stella and by the FastLoad
+// mechanism. Data containing more than one load will be exported as a
+// multiload tape.
+func ExportWAV(w io.WriteSeeker, data []byte) error {
+	loads, err := splitBinLoads(data)
+	if err != nil {
+		return fmt.Errorf("export: %v", err)
+	}
+
+	enc := &tapeEncoder{}
+	for _, bl := range loads {
+		logger.Log(exportLogTag, fmt.Sprintf("load %#02x: %d pages, start address %#04x", bl.multiload, bl.numPages, bl.startAddress))
+		enc.encodeLoad(bl)
+	}
+
+	wavEnc := wav.NewEncoder(w, ExportSampleRate, 16, 1, 1)
+
+	buf := &audio.IntBuffer{
+		Format: &audio.Format{
+			NumChannels: 1,
+			SampleRate:  ExportSampleRate,
+		},
+		Data:           enc.samples,
+		SourceBitDepth: 16,
+	}
+
+	err = wavEnc.Write(buf)
+	if err != nil {
+		return fmt.Errorf("export: %v", err)
+	}
+
+	err = wavEnc.Close()
+	if err != nil {
+		return fmt.Errorf("export: %v", err)
+	}
+
+	logger.Log(exportLogTag, fmt.Sprintf("total time: %.02fs", float64(len(enc.samples))/ExportSampleRate))
+
+	return nil
+}
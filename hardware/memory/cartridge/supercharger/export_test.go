@@ -0,0 +1,195 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package supercharger_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/go-audio/wav"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/supercharger"
+)
+
+// makeLoad creates a single load of binary tape data with the specified number
+// of pages. checksums are left as zero.
+func makeLoad(multiload uint8, numPages int) []byte {
+	data := make([]byte, 8448)
+	for i := 0; i < numPages*0x100; i++ {
+		data[i] = uint8(i*7 + int(multiload))
+	}
+
+	// start address, config byte, number of pages, checksum, multiload
+	copy(data[0x2000:], []byte{0x00, 0xf0, 0x1d, uint8(numPages), 0x00, multiload, 0x00, 0x00})
+
+	// page table
+	for i := 0; i < numPages; i++ {
+		data[0x2010+i] = uint8(i<<2) | 0x01
+	}
+
+	return data
+}
+
+// demodulate converts samples into bits by measuring the length of each
+// cycle. cycles longer than the threshold are one bits. cycles that are too
+// long to be bits (ie. silence) are ignored.
+func demodulate(samples []int, sampleRate int) []bool {
+	// threshold between zero and one bits and the maximum length of a one bit,
+	// in samples
+	threshold := 283.0 * float64(sampleRate) / 1000000.0
+	maximum := 2450.0 * float64(sampleRate) / 1000000.0
+
+	var bits []bool
+	start := -1
+	for i := range samples {
+		// rising edge. the start of the samples counts as a rising edge
+		if samples[i] > 0 && (i == 0 || samples[i-1] <= 0) {
+			if start >= 0 && float64(i-start) <= maximum {
+				bits = append(bits, float64(i-start) > threshold)
+			}
+			start = i
+		}
+	}
+
+	return bits
+}
+
+// readTone skips the tone and sync byte at the start of the bits and returns
+// the remaining bits.
+func readTone(t *testing.T, bits []bool) []bool {
+	t.Helper()
+	n := 0
+	for len(bits) >= 8 {
+		b := readByte(bits)
+		bits = bits[8:]
+		switch b {
+		case 0x55:
+			n++
+		case 0x54:
+			if n == 0 {
+				t.Fatalf("sync byte with no tone")
+			}
+			return bits
+		default:
+			t.Fatalf("unexpected byte in tone: %#02x", b)
+		}
+	}
+	t.Fatalf("tone not terminated")
+	return nil
+}
+
+func readByte(bits []bool) uint8 {
+	var b uint8
+	for i := 0; i < 8; i++ {
+		b <<= 1
+		if bits[i] {
+			b |= 0x01
+		}
+	}
+	return b
+}
+
+func readBytes(bits []bool, n int) ([]byte, []bool) {
+	d := make([]byte, n)
+	for i := range d {
+		d[i] = readByte(bits)
+		bits = bits[8:]
+	}
+	return d, bits
+}
+
+func sum(d []byte) uint8 {
+	s := uint8(0)
+	for _, b := range d {
+		s += b
+	}
+	return s
+}
+
+func TestExportWAV(t *testing.T) {
+	data := append(makeLoad(0x00, 3), makeLoad(0x01, 2)...)
+
+	f, err := ioutil.TempFile("", "supercharger_export_test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	err = supercharger.ExportWAV(f, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = f.Seek(0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dec := wav.NewDecoder(f)
+	if !dec.IsValidFile() {
+		t.Fatalf("exported file is not a valid WAV file")
+	}
+	buf, err := dec.FullPCMBuffer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.Format.SampleRate != supercharger.ExportSampleRate {
+		t.Errorf("unexpected sample rate: %d", buf.Format.SampleRate)
+	}
+
+	bits := demodulate(buf.Data, buf.Format.SampleRate)
+
+	for l, numPages := range []int{3, 2} {
+		load := data[l*8448 : (l+1)*8448]
+
+		bits = readTone(t, bits)
+
+		var header []byte
+		header, bits = readBytes(bits, 8)
+		if sum(header) != 0x55 {
+			t.Errorf("load %d: incorrect header checksum", l)
+		}
+		if header[3] != uint8(numPages) || header[5] != uint8(l) {
+			t.Errorf("load %d: unexpected header: %v", l, header)
+		}
+
+		bits = readTone(t, bits)
+
+		for p := 0; p < numPages; p++ {
+			var page []byte
+			page, bits = readBytes(bits, 258)
+			if page[0] != load[0x2010+p] {
+				t.Errorf("load %d: page %d: unexpected page table entry: %#02x", l, p, page[0])
+			}
+			if sum(page) != 0x55 {
+				t.Errorf("load %d: page %d: incorrect checksum", l, p)
+			}
+			for i, b := range page[2:] {
+				if b != load[p*0x100+i] {
+					t.Fatalf("load %d: page %d: unexpected data at %#02x", l, p, i)
+				}
+			}
+		}
+
+		// the final zero bit of each load is followed by silence and so is
+		// not seen by the demodulator
+	}
+
+	if len(bits) > 0 {
+		t.Errorf("unexpected bits after end of tape: %d", len(bits))
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
-	"github.com/jetsetilly/gopher2600/hardware/cpu"
 	"github.com/jetsetilly/gopher2600/hardware/memory/vcs"
 	"github.com/jetsetilly/gopher2600/hardware/riot/timer"
 	"github.com/jetsetilly/gopher2600/logger"
@@ -57,7 +56,14 @@ type FastLoad struct {
 //
 // !!TODO: is there a good way of handling FastLoading completion through the
 // cartridgeloader.OnLoader() mechanism?
-type FastLoaded func(*cpu.CPU, *vcs.RAM, *timer.Timer) error
+type FastLoaded func(CPU, *vcs.RAM, *timer.Timer) error
+
+// CPU defines the CPU functions required by the FastLoaded function. Defining
+// it here rather than referring to the cpu package means that the cpu package
+// can refer to the memory package.
+type CPU interface {
+	LoadPC(directAddress uint16) error
+}
 
 func (er FastLoaded) Error() string {
 	return "supercharger tape loaded, preparing VCS"
@@ -87,45 +93,33 @@ func (tap *FastLoad) snapshot() tape {
 
 // load implements the tape interface.
 func (tap *FastLoad) load() (uint8, error) {
-	gameData := tap.data[0:0x1eff]
-
 	// only 8448 .bin format is supported currently
-	gameHeader := tap.data[0x2000:0x2008]
-
-	// PC address to jump to once loading has finished
-	startAddress := (uint16(gameHeader[1]) << 8) | uint16(gameHeader[0])
-
-	// RAM config to be set adter tape load
-	configByte := gameHeader[2]
-
-	// number of pages to load
-	numPages := int(gameHeader[3])
+	bl, err := parseBinLoad(tap.data[:binLoadSize])
+	if err != nil {
+		return 0, fmt.Errorf("fastload: %v", err)
+	}
 
-	// not using the following in any meaningful way
-	checksum := gameHeader[4]
-	multiload := gameHeader[5]
-	progressSpeed := (uint16(gameHeader[7]) << 8) | uint16(gameHeader[6])
+	startAddress := bl.startAddress
+	configByte := bl.configByte
 
-	logger.Log("supercharger: fastload", fmt.Sprintf("start address: %#04x", startAddress))
-	logger.Log("supercharger: fastload", fmt.Sprintf("config byte: %#08b", configByte))
-	logger.Log("supercharger: fastload", fmt.Sprintf("num pages: %d", numPages))
-	logger.Log("supercharger: fastload", fmt.Sprintf("checksum: %#02x", checksum))
-	logger.Log("supercharger: fastload", fmt.Sprintf("multiload: %#02x", multiload))
-	logger.Log("supercharger: fastload", fmt.Sprintf("progress speed: %#02x", progressSpeed))
+	logger.Log("supercharger: fastload", fmt.Sprintf("start address: %#04x", bl.startAddress))
+	logger.Log("supercharger: fastload", fmt.Sprintf("config byte: %#08b", bl.configByte))
+	logger.Log("supercharger: fastload", fmt.Sprintf("num pages: %d", bl.numPages))
+	logger.Log("supercharger: fastload", fmt.Sprintf("checksum: %#02x", bl.checksum))
+	logger.Log("supercharger: fastload", fmt.Sprintf("multiload: %#02x", bl.multiload))
+	logger.Log("supercharger: fastload", fmt.Sprintf("progress speed: %#02x", bl.progressSpeed))
 
 	// data is loaded according to page table
-	pageTable := tap.data[0x2010:0x2028]
-	logger.Log("supercharger: fastload", fmt.Sprintf("page-table: %v", pageTable))
+	logger.Log("supercharger: fastload", fmt.Sprintf("page-table: %v", bl.pageTable))
 
 	// copy data to RAM banks
-	for i := 0; i < numPages; i++ {
-		bank := pageTable[i] & 0x3
-		page := pageTable[i] >> 2
+	for i := 0; i < bl.numPages; i++ {
+		bank := bl.pageTable[i] & 0x3
+		page := bl.pageTable[i] >> 2
 		bankOffset := int(page) * 0x100
 		binOffset := i * 0x100
 
-		data := gameData[binOffset : binOffset+0x100]
-		copy(tap.cart.state.ram[bank][bankOffset:bankOffset+0x100], data)
+		copy(tap.cart.state.ram[bank][bankOffset:bankOffset+0x100], bl.page(i))
 
 		logger.Log("supercharger: fastload", fmt.Sprintf("copying %#04x:%#04x to bank %d page %d, offset %#04x", binOffset, binOffset+0x100, bank, page, bankOffset))
 	}
@@ -133,7 +127,7 @@ func (tap *FastLoad) load() (uint8, error) {
 	// setup cartridge according to tape instructions. we do this by returning
 	// a function disguised as an error type. The VCS knows how to interpret
 	// this error and will call the function
-	return 0, FastLoaded(func(mc *cpu.CPU, ram *vcs.RAM, tmr *timer.Timer) error {
+	return 0, FastLoaded(func(mc CPU, ram *vcs.RAM, tmr *timer.Timer) error {
 		// initialise VCS RAM with zeros
 		for a := uint16(0x80); a <= 0xff; a++ {
 			_ = ram.Poke(a, 0x00)
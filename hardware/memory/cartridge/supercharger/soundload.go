@@ -90,9 +90,9 @@ func newSoundLoad(cart *Supercharger, loader cartridgeloader.Loader) (tape, erro
 	// number of samples in a cycle for it to be interpreted as a zero or a one
 	// values taken from "Atari 2600 Mappers" document by Kevin Horton
 	logger.Log(soundloadLogTag, fmt.Sprintf("min/opt/max samples for zero-bit: %d/%d/%d",
-		int(158.0/timePerSample), int(227.0/timePerSample), int(317.0/timePerSample)))
+		int(158.0/timePerSample), int(zeroBitCycle/timePerSample), int(317.0/timePerSample)))
 	logger.Log(soundloadLogTag, fmt.Sprintf("min/opt/max samples for one-bit: %d/%d/%d",
-		int(317.0/timePerSample), int(340.0/timePerSample), int(2450.0/timePerSample)))
+		int(317.0/timePerSample), int(oneBitCycle/timePerSample), int(2450.0/timePerSample)))
 
 	// calculate tape regulator speed. 1190000 is the frequency at which step() is called (1.19MHz)
 	tap.regulator = int(math.Round(1190000.0 / tap.sampleRate))
@@ -16,8 +16,6 @@
 package memory
 
 import (
-	"math/rand"
-
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
 	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
@@ -146,14 +144,14 @@ func (mem *Memory) read(address uint16, zeroPage bool) (uint8, error) {
 		if !zeroPage {
 			data &= addresses.DataMasks[ma]
 			if mem.prefs != nil && mem.prefs.RandomPins.Get().(bool) {
-				data |= uint8(rand.Int()) & (addresses.DataMasks[ma] ^ 0xff)
+				data |= uint8(mem.prefs.RandSrc.Intn(0xff)) & (addresses.DataMasks[ma] ^ 0xff)
 			} else {
 				data |= uint8((address>>8)&0xff) & (addresses.DataMasks[ma] ^ 0xff)
 			}
 		} else {
 			data &= addresses.DataMasks[ma]
 			if mem.prefs != nil && mem.prefs.RandomPins.Get().(bool) {
-				data |= uint8(rand.Int()) & (addresses.DataMasks[ma] ^ 0xff)
+				data |= uint8(mem.prefs.RandSrc.Intn(0xff)) & (addresses.DataMasks[ma] ^ 0xff)
 			} else {
 				data |= uint8(address&0x00ff) & (addresses.DataMasks[ma] ^ 0xff)
 			}
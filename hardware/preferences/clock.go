@@ -0,0 +1,43 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package preferences
+
+import "time"
+
+// Clock is the source of the current time for the emulated hardware. The
+// hardware only consults the clock when it is asked to seed the random number
+// generator without a seed value.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the default implementation of the Clock interface.
+type SystemClock struct{}
+
+// Now implements the Clock interface.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is an implementation of the Clock interface that always returns
+// the same time. Useful for tests and for any application that requires the
+// emulation to be reproducible.
+type FixedClock time.Time
+
+// Now implements the Clock interface.
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
@@ -17,7 +17,6 @@ package preferences
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/jetsetilly/gopher2600/paths"
 	"github.com/jetsetilly/gopher2600/prefs"
@@ -36,12 +35,37 @@ type Preferences struct {
 	// unused pins randomly on a read/peek"
 	RandomPins prefs.Bool
 
+	// the TIMINT flag of the RIOT timer is emulated strictly by default.
+	// reading or writing the timer in the cycle immediately after the timer
+	// underflows does not clear the flag. if LenientTimer is true then reading
+	// or writing the timer always clears the flag, as it does in some other
+	// emulators
+	LenientTimer prefs.Bool
+
+	// the stereo position of each TIA audio channel. a value of -1.0 is hard
+	// left, a value of 1.0 is hard right and 0.0 is centred. the default of
+	// both channels being centred is the same as the mono output of an
+	// unmodified console. the popular "stereo mod" is equivalent to channel 0
+	// being panned hard left and channel 1 panned hard right
+	AudioPan0 prefs.Float
+	AudioPan1 prefs.Float
+
+	// user input intended for the left player is sent to the right player
+	// port and vice versa. some games expect the controller to be in the
+	// "wrong" port. this is not saved to disk because it is usually specific
+	// to a ROM
+	SwapPorts prefs.Bool
+
 	// random values generated in the hardware package should use the following
 	// number source
 	RandSrc *rand.Rand
 
 	// the number used to seed RandSrc
 	RandSeed int64
+
+	// the source of the current time. used by Reseed() when no seed value is
+	// given
+	Clock Clock
 }
 
 func (p *Preferences) String() string {
@@ -50,7 +74,9 @@ func (p *Preferences) String() string {
 
 // NewPreferences is the preferred method of initialisation for the Preferences type.
 func NewPreferences() (*Preferences, error) {
-	p := &Preferences{}
+	p := &Preferences{
+		Clock: SystemClock{},
+	}
 
 	// initialise random number generator
 	p.Reseed(0)
@@ -72,6 +98,18 @@ func NewPreferences() (*Preferences, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = p.dsk.Add("hardware.lenientTimer", &p.LenientTimer)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.audio.panChannelZero", &p.AudioPan0)
+	if err != nil {
+		return nil, err
+	}
+	err = p.dsk.Add("hardware.audio.panChannelOne", &p.AudioPan1)
+	if err != nil {
+		return nil, err
+	}
 	err = p.dsk.Load(true)
 	if err != nil {
 		return nil, err
@@ -81,10 +119,13 @@ func NewPreferences() (*Preferences, error) {
 }
 
 // Reseed initialises the random number generator. Use a seed value of 0 to
-// initialise with the current time.
+// initialise with the current time, as reported by the Clock.
+//
+// Two emulations that are reseeded with the same value, and which then run
+// the same cartridge with the same input, will produce identical output.
 func (p *Preferences) Reseed(seed int64) {
 	if seed == 0 {
-		p.RandSeed = int64(time.Now().Nanosecond())
+		p.RandSeed = int64(p.Clock.Now().Nanosecond())
 	} else {
 		p.RandSeed = seed
 	}
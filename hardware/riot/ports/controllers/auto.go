@@ -50,6 +50,13 @@ func (aut *Auto) Plumb(bus ports.PeripheralBus) {
 	aut.controller.Plumb(bus)
 }
 
+// Snapshot implements the ports.Peripheral interface.
+func (aut *Auto) Snapshot() ports.Peripheral {
+	n := *aut
+	n.controller = aut.controller.Snapshot()
+	return &n
+}
+
 // String implements the ports.Peripheral interface.
 func (aut *Auto) String() string {
 	return aut.controller.String()
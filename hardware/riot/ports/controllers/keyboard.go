@@ -60,6 +60,12 @@ func (key *Keyboard) Plumb(bus ports.PeripheralBus) {
 	key.bus = bus
 }
 
+// Snapshot implements the ports.Peripheral interface.
+func (key *Keyboard) Snapshot() ports.Peripheral {
+	n := *key
+	return &n
+}
+
 // String implements the ports.Peripheral interface.
 func (key *Keyboard) String() string {
 	return fmt.Sprintf("keyboard: key=%v", key.key)
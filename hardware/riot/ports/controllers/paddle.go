@@ -85,6 +85,12 @@ func (pdl *Paddle) Plumb(bus ports.PeripheralBus) {
 	pdl.bus = bus
 }
 
+// Snapshot implements the ports.Peripheral interface.
+func (pdl *Paddle) Snapshot() ports.Peripheral {
+	n := *pdl
+	return &n
+}
+
 // String implements the ports.Peripheral interface.
 func (pdl *Paddle) String() string {
 	return fmt.Sprintf("paddle: button=%02x charge=%v resistance=%.02f", pdl.fire, pdl.charge, pdl.resistance)
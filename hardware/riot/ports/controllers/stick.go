@@ -73,6 +73,12 @@ func (stk *Stick) Plumb(bus ports.PeripheralBus) {
 	stk.bus = bus
 }
 
+// Snapshot implements the ports.Peripheral interface.
+func (stk *Stick) Snapshot() ports.Peripheral {
+	n := *stk
+	return &n
+}
+
 // String implements the ports.Peripheral interface.
 func (stk *Stick) String() string {
 	return fmt.Sprintf("stick: axis=%02x fire=%02x", stk.axis, stk.button)
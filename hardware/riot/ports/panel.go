@@ -49,6 +49,12 @@ func (pan *Panel) Plumb(bus PeripheralBus) {
 	pan.bus = bus
 }
 
+// Snapshot implements the Peripheral interface.
+func (pan *Panel) Snapshot() Peripheral {
+	n := *pan
+	return &n
+}
+
 // String implements the Peripheral interface.
 func (pan *Panel) String() string {
 	s := strings.Builder{}
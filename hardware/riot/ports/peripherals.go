@@ -53,6 +53,11 @@ type Peripheral interface {
 	// Plumb a new PeripheralBus into the Peripheral
 	Plumb(PeripheralBus)
 
+	// Snapshot returns a copy of the Peripheral in its current state. The
+	// copy must not share any mutable state with the original. The new
+	// PeripheralBus must be plumbed in with Plumb() before the copy is used.
+	Snapshot() Peripheral
+
 	// Name should return the canonical name for the peripheral (eg. "Paddle"
 	// for the paddle peripheral). It shouldn't include information about which
 	// port the peripheral is attached to.
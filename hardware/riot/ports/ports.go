@@ -92,8 +92,19 @@ func NewPorts(riotMem bus.ChipBus, tiaMem bus.ChipBus) *Ports {
 }
 
 // Snapshot returns a copy of the RIOT Ports sub-system in its current state.
+// The attached peripherals are also copied so the state of the peripherals
+// is preserved in the snapshot.
 func (p *Ports) Snapshot() *Ports {
 	n := *p
+	if p.Panel != nil {
+		n.Panel = p.Panel.Snapshot()
+	}
+	if p.Player0 != nil {
+		n.Player0 = p.Player0.Snapshot()
+	}
+	if p.Player1 != nil {
+		n.Player1 = p.Player1.Snapshot()
+	}
 	return &n
 }
 
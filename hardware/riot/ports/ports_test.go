@@ -0,0 +1,69 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports/controllers"
+)
+
+// chip memory that ignores all writes.
+type mockChipBus struct{}
+
+func (mockChipBus) ChipRead() (bool, bus.ChipData)                   { return false, bus.ChipData{} }
+func (mockChipBus) ChipWrite(reg addresses.ChipRegister, data uint8) {}
+func (mockChipBus) LastReadRegister() string                         { return "" }
+
+func TestSnapshot(t *testing.T) {
+	p := ports.NewPorts(mockChipBus{}, mockChipBus{})
+
+	err := p.AttachPlayer(ports.Player0ID, controllers.NewStick)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = p.AttachPlayer(ports.Player1ID, controllers.NewPaddle)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := p.Snapshot()
+	before := s.Player0.String()
+
+	// changing the state of the peripheral should not affect the snapshot
+	err = p.HandleEvent(ports.Player0ID, ports.Left, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Player0.String() == before {
+		t.Errorf("expected peripheral state to change")
+	}
+	if s.Player0.String() != before {
+		t.Errorf("snapshot of peripheral has changed (%s)", s.Player0.String())
+	}
+
+	// changing the peripheral should not affect the snapshot
+	err = p.AttachPlayer(ports.Player1ID, controllers.NewKeyboard)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Player1.Name() != "Paddle" {
+		t.Errorf("expected Paddle in snapshot (got %s)", s.Player1.Name())
+	}
+}
@@ -58,6 +58,13 @@ func newEeprom() *EEPROM {
 	return ee
 }
 
+func (ee *EEPROM) snapshot() *EEPROM {
+	n := *ee
+	n.data = make([]uint8, len(ee.data))
+	copy(n.data, ee.data)
+	return &n
+}
+
 // Read EEPROM data from disk.
 func (ee *EEPROM) Read() {
 	fn, err := paths.ResourcePath("", saveKeyPath)
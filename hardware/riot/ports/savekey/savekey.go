@@ -102,6 +102,15 @@ func (sk *SaveKey) Plumb(bus ports.PeripheralBus) {
 	sk.bus = bus
 }
 
+// Snapshot implements the ports.Peripheral interface.
+func (sk *SaveKey) Snapshot() ports.Peripheral {
+	n := *sk
+	n.SDA = sk.SDA.snapshot()
+	n.SCL = sk.SCL.snapshot()
+	n.EEPROM = sk.EEPROM.snapshot()
+	return &n
+}
+
 func (sk *SaveKey) String() string {
 	s := strings.Builder{}
 	s.WriteString("savekey: ")
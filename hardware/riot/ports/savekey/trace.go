@@ -49,6 +49,14 @@ func newTrace() trace {
 	return tr
 }
 
+func (tr *trace) snapshot() trace {
+	n := trace{
+		activity: make([]float32, len(tr.activity)),
+	}
+	copy(n.activity, tr.activity)
+	return n
+}
+
 func (tr *trace) recent() (from bool, to bool) {
 	return tr.activity[len(tr.activity)-2] > 0, tr.activity[len(tr.activity)-1] > 0
 }
@@ -139,6 +139,18 @@ func (tmr Timer) timintValue() uint8 {
 	return v
 }
 
+// justExpired returns true if the timer underflowed in the previous cycle.
+// once the timer has expired, TicksRemaining is always zero and INTIMvalue is
+// only 0xff in the cycle immediately after the underflow.
+func (tmr *Timer) justExpired() bool {
+	return tmr.TicksRemaining == 0 && tmr.INTIMvalue == 0xff
+}
+
+// lenient returns true if the LenientTimer preference is set.
+func (tmr *Timer) lenient() bool {
+	return tmr.prefs.LenientTimer.Get().(bool)
+}
+
 // Update checks to see if ChipData applies to the Timer type and updates the
 // internal timer state accordingly.
 //
@@ -150,7 +162,7 @@ func (tmr *Timer) Update(data bus.ChipData) bool {
 
 	// writing to INTIM register has a similar effect on the expired bit of the
 	// TIMINT register as reading. See commentary in the Step() function
-	if tmr.TicksRemaining == 0 && tmr.INTIMvalue == 0xff {
+	if tmr.justExpired() && !tmr.lenient() {
 		tmr.expired = true
 		tmr.mem.ChipWrite(addresses.TIMINT, tmr.timintValue())
 	} else {
@@ -181,7 +193,11 @@ func (tmr *Timer) Step() {
 		// https://atariage.com/forums/topic/303277-to-roll-or-not-to-roll/
 		//
 		// https://atariage.com/forums/topic/133686-please-explain-riot-timmers/?do=findComment&comment=1617207
-		if tmr.TicksRemaining != 0 || tmr.INTIMvalue != 0xff {
+		//
+		// reading INTIM again in a later cycle clears the expired flag as
+		// normal. the reversion is always made if the LenientTimer preference
+		// is set
+		if !tmr.justExpired() || tmr.lenient() {
 			tmr.expired = false
 			tmr.mem.ChipWrite(addresses.TIMINT, tmr.timintValue())
 		}
@@ -195,7 +211,10 @@ func (tmr *Timer) Step() {
 		//
 		// "To clear PA7 interrupt flag, simply read the Interrupt Flag
 		// Register"
+		//
+		// the expired flag is not affected by reading TIMINT
 		tmr.pa7 = false
+		tmr.mem.ChipWrite(addresses.TIMINT, tmr.timintValue())
 	}
 
 	tmr.TicksRemaining--
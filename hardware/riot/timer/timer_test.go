@@ -0,0 +1,184 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package timer_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
+	"github.com/jetsetilly/gopher2600/hardware/riot/timer"
+)
+
+// chip memory that records the registers written by the timer. the register
+// read by the CPU is set by the test with the read field.
+type mockChipBus struct {
+	regs map[addresses.ChipRegister]uint8
+	read string
+}
+
+func (mem *mockChipBus) ChipRead() (bool, bus.ChipData) { return false, bus.ChipData{} }
+
+func (mem *mockChipBus) ChipWrite(reg addresses.ChipRegister, data uint8) {
+	mem.regs[reg] = data
+}
+
+func (mem *mockChipBus) LastReadRegister() string {
+	r := mem.read
+	mem.read = ""
+	return r
+}
+
+// newTimer returns a TIM8T timer that is one step away from underflow.
+func newTimer(t *testing.T, lenient bool) (*timer.Timer, *mockChipBus) {
+	t.Helper()
+
+	prefs, err := preferences.NewPreferences()
+	if err != nil {
+		t.Fatalf("unexpected error creating preferences: %v", err)
+	}
+	err = prefs.RandomState.Set(false)
+	if err != nil {
+		t.Fatalf("unexpected error setting preference: %v", err)
+	}
+	err = prefs.LenientTimer.Set(lenient)
+	if err != nil {
+		t.Fatalf("unexpected error setting preference: %v", err)
+	}
+
+	mem := &mockChipBus{regs: make(map[addresses.ChipRegister]uint8)}
+	tmr := timer.NewTimer(prefs, mem)
+
+	tmr.Update(bus.ChipData{Name: "TIM8T", Value: 1})
+
+	// the value decreases on the first step and then every eight steps
+	for i := 0; i < 8; i++ {
+		tmr.Step()
+	}
+	if tmr.INTIMvalue != 0x00 {
+		t.Fatalf("unexpected INTIM value: %#02x (expected 0x00)", tmr.INTIMvalue)
+	}
+
+	return tmr, mem
+}
+
+// step the timer once with the CPU reading the named register. an empty
+// string means the CPU did not read a timer register.
+func step(tmr *timer.Timer, mem *mockChipBus, read string) {
+	mem.read = read
+	tmr.Step()
+}
+
+func expired(mem *mockChipBus) bool {
+	return mem.regs[addresses.TIMINT]&0x80 == 0x80
+}
+
+func TestUnderflow(t *testing.T) {
+	tmr, mem := newTimer(t, false)
+
+	step(tmr, mem, "")
+	if tmr.INTIMvalue != 0xff || !expired(mem) {
+		t.Fatalf("timer should have expired")
+	}
+
+	// the value decreases every cycle once the timer has expired
+	step(tmr, mem, "")
+	step(tmr, mem, "")
+	if tmr.INTIMvalue != 0xfd || mem.regs[addresses.INTIM] != 0xfd {
+		t.Errorf("unexpected INTIM value: %#02x (expected 0xfd)", tmr.INTIMvalue)
+	}
+
+	// reading INTIM clears the flag and reverts to the TIM8T interval
+	step(tmr, mem, "INTIM")
+	if expired(mem) {
+		t.Errorf("reading INTIM should clear the expired flag")
+	}
+	value := tmr.INTIMvalue
+	for i := 0; i < 7; i++ {
+		step(tmr, mem, "")
+	}
+	if tmr.INTIMvalue != value {
+		t.Errorf("unexpected INTIM value: %#02x (expected %#02x)", tmr.INTIMvalue, value)
+	}
+	step(tmr, mem, "")
+	if tmr.INTIMvalue != value-1 {
+		t.Errorf("unexpected INTIM value: %#02x (expected %#02x)", tmr.INTIMvalue, value-1)
+	}
+}
+
+func TestReadOnUnderflowStrict(t *testing.T) {
+	tmr, mem := newTimer(t, false)
+	step(tmr, mem, "")
+
+	// reading INTIM in the cycle after the underflow does not clear the flag
+	// and the value continues to decrease every cycle
+	step(tmr, mem, "INTIM")
+	if !expired(mem) {
+		t.Errorf("reading INTIM immediately after underflow should not clear the expired flag")
+	}
+	step(tmr, mem, "")
+	if tmr.INTIMvalue != 0xfd {
+		t.Errorf("unexpected INTIM value: %#02x (expected 0xfd)", tmr.INTIMvalue)
+	}
+
+	// reading INTIM again clears the flag
+	step(tmr, mem, "INTIM")
+	if expired(mem) {
+		t.Errorf("reading INTIM again should clear the expired flag")
+	}
+}
+
+func TestReadOnUnderflowLenient(t *testing.T) {
+	tmr, mem := newTimer(t, true)
+	step(tmr, mem, "")
+
+	step(tmr, mem, "INTIM")
+	if expired(mem) {
+		t.Errorf("reading INTIM should always clear the expired flag in lenient mode")
+	}
+}
+
+func TestWriteOnUnderflow(t *testing.T) {
+	tmr, mem := newTimer(t, false)
+	step(tmr, mem, "")
+	tmr.Update(bus.ChipData{Name: "TIM64T", Value: 10})
+	if !expired(mem) {
+		t.Errorf("writing the timer immediately after underflow should not clear the expired flag")
+	}
+
+	tmr, mem = newTimer(t, true)
+	step(tmr, mem, "")
+	tmr.Update(bus.ChipData{Name: "TIM64T", Value: 10})
+	if expired(mem) {
+		t.Errorf("writing the timer should always clear the expired flag in lenient mode")
+	}
+}
+
+func TestTIMINTRead(t *testing.T) {
+	tmr, mem := newTimer(t, false)
+	step(tmr, mem, "")
+	if mem.regs[addresses.TIMINT] != 0xc0 {
+		t.Fatalf("unexpected TIMINT value: %#02x (expected 0xc0)", mem.regs[addresses.TIMINT])
+	}
+
+	// reading TIMINT clears the PA7 flag but not the expired flag
+	step(tmr, mem, "TIMINT")
+	step(tmr, mem, "")
+	if mem.regs[addresses.TIMINT] != 0x80 {
+		t.Errorf("unexpected TIMINT value: %#02x (expected 0x80)", mem.regs[addresses.TIMINT])
+	}
+}
@@ -58,6 +58,15 @@ func (vcs *VCS) Run(continueCheck func() (bool, error)) error {
 		return nil
 	}
 
+	// signals can be batched because nothing is inspecting the emulation
+	// between video cycles. the television asks for the batch to be flushed
+	// if continueCheck() inspects its state (see PendingSignals() in the
+	// signal package)
+	err = vcs.TIA.SetSignalBatching(true)
+	if err != nil {
+		return err
+	}
+
 	cont := true
 	for cont {
 		err = vcs.CPU.ExecuteInstruction(videoCycle)
@@ -68,16 +77,24 @@ func (vcs *VCS) Run(continueCheck func() (bool, error)) error {
 				vcs.CPU.LastResult.Final = true
 				err = onTapeLoaded(vcs.CPU, vcs.Mem.RAM, vcs.RIOT.Timer)
 				if err != nil {
+					_ = vcs.TIA.SetSignalBatching(false)
 					return err
 				}
 			} else {
+				_ = vcs.TIA.SetSignalBatching(false)
 				return err
 			}
 		}
+
 		cont, err = continueCheck()
 	}
 
-	return err
+	if err != nil {
+		_ = vcs.TIA.SetSignalBatching(false)
+		return err
+	}
+
+	return vcs.TIA.SetSignalBatching(false)
 }
 
 // RunForFrameCount sets emulator running for the specified number of frames.
@@ -0,0 +1,115 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// BadSync specifies how the television reacts to a ROM that has lost sync.
+// That is, when a frame ends without a valid VSYNC signal.
+//
+// The mode has no effect until the television is stable. Before that time
+// the television always flies back silently because the ROM will commonly be
+// in the process of establishing the frame and because automatic detection of
+// the specification relies on it.
+type BadSync int
+
+// List of valid BadSync values.
+const (
+	// the television silently flies back to the top of the screen when the
+	// bottom of the screen has been reached and immediately synchronises with
+	// the next VSYNC signal, whenever it occurs. this is the default
+	BadSyncFlyback BadSync = iota
+
+	// the television behaves more like a real CRT. the vertical oscillator
+	// runs freely and a VSYNC signal is only acted upon if it occurs close to
+	// the end of the oscillator's period. a ROM that produces VSYNC at the
+	// wrong time will cause the image to roll vertically
+	BadSyncRoll
+
+	// the television flies back as for BadSyncFlyback but Signal() will
+	// return a BadSyncError. the debugger will halt the emulation when this
+	// happens
+	BadSyncHalt
+)
+
+// BadSyncError is the error pattern returned by Signal() in the BadSyncHalt
+// mode. The argument is the Coordinates of the television immediately before
+// the fly back.
+const BadSyncError = "television: frame ended without VSYNC (%s)"
+
+// the number of scanlines before the end of the specification's frame in
+// which a VSYNC will be acted upon in the BadSyncRoll mode. real televisions
+// have a similar "pull-in" range, usually adjustable with the vertical hold
+// control.
+const rollCaptureRange = 24
+
+func (b BadSync) String() string {
+	switch b {
+	case BadSyncFlyback:
+		return "FLYBACK"
+	case BadSyncRoll:
+		return "ROLL"
+	case BadSyncHalt:
+		return "HALT"
+	}
+	return "unknown bad sync mode"
+}
+
+// ParseBadSync converts a string to a BadSync value. The string is case
+// insensitive and should be one of the values returned by BadSync.String().
+func ParseBadSync(s string) (BadSync, error) {
+	switch strings.ToUpper(s) {
+	case "FLYBACK":
+		return BadSyncFlyback, nil
+	case "ROLL":
+		return BadSyncRoll, nil
+	case "HALT":
+		return BadSyncHalt, nil
+	}
+	return BadSyncFlyback, curated.Errorf("television: unrecognised bad sync mode (%s)", s)
+}
+
+// SetBadSync sets how the television reacts to a ROM that has lost sync.
+func (tv *Television) SetBadSync(mode BadSync) {
+	tv.badSync = mode
+}
+
+// GetBadSync returns the current bad sync mode.
+func (tv *Television) GetBadSync() BadSync {
+	return tv.badSync
+}
+
+// acceptVSync returns true if the VSYNC signal should cause a new frame. only
+// in the BadSyncRoll mode is it possible for a VSYNC signal to be ignored.
+func (tv *Television) acceptVSync() bool {
+	if tv.badSync != BadSyncRoll || tv.state.syncedFrameNum < stabilityThreshold {
+		return true
+	}
+	return tv.state.scanline >= tv.state.spec.ScanlinesTotal-rollCaptureRange
+}
+
+// badSyncError returns an error if the television is in the BadSyncHalt mode.
+// it should be called when a frame is about to end without synchronisation.
+func (tv *Television) badSyncError() error {
+	if tv.badSync != BadSyncHalt || tv.state.syncedFrameNum < stabilityThreshold {
+		return nil
+	}
+	return curated.Errorf(BadSyncError, tv.state.GetCoords())
+}
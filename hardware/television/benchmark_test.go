@@ -0,0 +1,137 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// lockingRenderer takes a lock for the duration of an update in the same way
+// as the sdlimgui renderer.
+type lockingRenderer struct {
+	crit   sync.Mutex
+	pixels int
+}
+
+func (r *lockingRenderer) Resize(_ specification.Spec, _, _ int) error { return nil }
+func (r *lockingRenderer) NewFrame(_ television.FrameInfo) error       { return nil }
+func (r *lockingRenderer) NewScanline(_ int) error                     { return nil }
+func (r *lockingRenderer) Reset()                                      {}
+func (r *lockingRenderer) EndRendering() error                         { return nil }
+
+func (r *lockingRenderer) UpdatingPixels(updating bool) {
+	if updating {
+		r.crit.Lock()
+	} else {
+		r.crit.Unlock()
+	}
+}
+
+func (r *lockingRenderer) SetPixel(_ signal.SignalAttributes, _ bool) error {
+	r.pixels++
+	return nil
+}
+
+// the benchmarks below measure the cost of sending a frame of signals to the
+// television. run with:
+//
+//	go test -run none -bench Signal -benchmem ./hardware/television
+
+func benchmarkTV(b *testing.B) *television.Television {
+	b.Helper()
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		b.Fatalf("unexpected error creating television: %v", err)
+	}
+	tv.SetFPSCap(false)
+	tv.AddPixelRenderer(&lockingRenderer{})
+	return tv
+}
+
+func BenchmarkSignal(b *testing.B) {
+	tv := benchmarkTV(b)
+	frame := vblankFrame(50, 210)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, sig := range frame {
+			_ = tv.Signal(sig)
+		}
+	}
+}
+
+func BenchmarkSignalBatch(b *testing.B) {
+	tv := benchmarkTV(b)
+	frame := vblankFrame(50, 210)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for sl := 0; sl < len(frame); sl += specification.HorizClksScanline {
+			_ = tv.SignalBatch(frame[sl : sl+specification.HorizClksScanline])
+		}
+	}
+}
+
+func TestUpdatingPixels(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	r := &countingRenderer{}
+	tv.AddPixelRenderer(r)
+
+	frame := vblankFrame(50, 210)
+	_ = tv.SignalBatch(frame)
+	_ = tv.SignalBatch(frame)
+
+	// the pixels for the first frame are sent when the second frame begins.
+	// all the pixels are sent in a single update event
+	if r.updates != 1 {
+		t.Errorf("unexpected number of update events: %d (expected 1)", r.updates)
+	}
+	if r.pixels != len(frame) {
+		t.Errorf("unexpected number of pixels: %d (expected %d)", r.pixels, len(frame))
+	}
+	if r.updating {
+		t.Errorf("update event has not been ended")
+	}
+}
+
+// countingRenderer counts the number of update events and checks that pixels
+// are only sent during an update event.
+type countingRenderer struct {
+	lockingRenderer
+	updating bool
+	updates  int
+}
+
+func (r *countingRenderer) UpdatingPixels(updating bool) {
+	r.updating = updating
+	if updating {
+		r.updates++
+	}
+}
+
+func (r *countingRenderer) SetPixel(_ signal.SignalAttributes, _ bool) error {
+	if !r.updating {
+		return curated.Errorf("pixel sent outside of update event")
+	}
+	r.pixels++
+	return nil
+}
@@ -0,0 +1,69 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// Coordinates represents a position of the television beam.
+type Coordinates struct {
+	Frame    int
+	Scanline int
+
+	// the horizontal position of the beam, as returned by
+	// GetState(signal.ReqHorizPos). the first clock of HBLANK is
+	// -specification.HorizClksHBlank and the first visible clock is zero
+	Clock int
+}
+
+func (c Coordinates) String() string {
+	return fmt.Sprintf("frame %d, scanline %d, clock %d", c.Frame, c.Scanline, c.Clock)
+}
+
+// Equal returns true if both coordinates are the same.
+func (c Coordinates) Equal(o Coordinates) bool {
+	return c == o
+}
+
+// Before returns true if c is earlier than o.
+func (c Coordinates) Before(o Coordinates) bool {
+	if c.Frame != o.Frame {
+		return c.Frame < o.Frame
+	}
+	if c.Scanline != o.Scanline {
+		return c.Scanline < o.Scanline
+	}
+	return c.Clock < o.Clock
+}
+
+// After returns true if c is later than o.
+func (c Coordinates) After(o Coordinates) bool {
+	return o.Before(c)
+}
+
+// Absolute converts the coordinates to the number of color clocks since the
+// start of frame zero.
+//
+// Frames can be of any length so the conversion assumes that every frame has
+// the number of scanlines given in the specification. The result is only
+// accurate for ROMs that generate a consistent number of scanlines.
+func (c Coordinates) Absolute(spec specification.Spec) int {
+	sl := c.Frame*spec.ScanlinesTotal + c.Scanline
+	return sl*specification.HorizClksScanline + c.Clock + specification.HorizClksHBlank
+}
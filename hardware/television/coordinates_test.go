@@ -0,0 +1,69 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestCoordinates(t *testing.T) {
+	a := television.Coordinates{Frame: 1, Scanline: 10, Clock: 5}
+	b := television.Coordinates{Frame: 1, Scanline: 10, Clock: 6}
+	c := television.Coordinates{Frame: 1, Scanline: 11, Clock: -68}
+	d := television.Coordinates{Frame: 2, Scanline: 0, Clock: -68}
+
+	test.ExpectedSuccess(t, a.Before(b))
+	test.ExpectedSuccess(t, b.Before(c))
+	test.ExpectedSuccess(t, c.Before(d))
+	test.ExpectedFailure(t, b.Before(a))
+	test.ExpectedFailure(t, a.Before(a))
+
+	test.ExpectedSuccess(t, d.After(a))
+	test.ExpectedFailure(t, a.After(a))
+
+	test.ExpectedSuccess(t, a.Equal(television.Coordinates{Frame: 1, Scanline: 10, Clock: 5}))
+	test.ExpectedFailure(t, a.Equal(b))
+
+	test.Equate(t, a.String(), "frame 1, scanline 10, clock 5")
+
+	// the first clock of the first frame is zero
+	z := television.Coordinates{Clock: -specification.HorizClksHBlank}
+	test.Equate(t, z.Absolute(specification.SpecNTSC), 0)
+
+	// consecutive clocks across the scanline and frame boundaries
+	test.Equate(t, b.Absolute(specification.SpecNTSC)-a.Absolute(specification.SpecNTSC), 1)
+	e := television.Coordinates{Frame: 1, Scanline: 261, Clock: 159}
+	test.Equate(t, d.Absolute(specification.SpecNTSC)-e.Absolute(specification.SpecNTSC), 1)
+}
+
+func TestGetCoords(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+
+	frame := vblankFrame(50, 210)
+	for i := 0; i < 3; i++ {
+		_ = tv.SignalBatch(frame)
+	}
+
+	c := tv.GetCoords()
+	test.Equate(t, c.Frame, tv.GetState(signal.ReqFramenum))
+	test.Equate(t, c.Scanline, tv.GetState(signal.ReqScanline))
+	test.Equate(t, c.Clock, tv.GetState(signal.ReqHorizPos))
+}
@@ -27,7 +27,8 @@
 // It is important to note that the reference television implementation does
 // not render pixels or mix sound itself. Instead, the television interface
 // exposes two functions, AddPixelRenderer() and AddAudioMixer(). These can be
-// used to add as many renderers and mixers as required.
+// used to add as many renderers and mixers as required. Renderers and mixers
+// can be removed again with RemovePixelRenderer() and RemoveAudioMixer().
 //
 // The main means of communication is the Signal() function. This function
 // accepts an instance of SignalAttributes which gives details of how the
@@ -43,6 +44,34 @@
 // the current TV specification (ie. PAL or NTSC) or an aribitrary value, using
 // the SetFPSCap() function.
 //
-// Framesize adaptation is also handled by the reference implementation and is
-// currently functional but rudimentary.
+// For headless applications, where only the state of the television and the
+// audio is of interest, the television can be put into no-render mode with
+// the SetNoRender() function. In this mode no signal history is kept and no
+// pixel renderers or reflector are consulted.
+//
+// The signals for the most recently completed frame can be recorded with the
+// SetFrameRecording() function and retrieved as a Fixture. A Fixture can be
+// written to a file and later replayed into a new television instance, making
+// it possible to reproduce the behaviour of the television in unit tests
+// without the need for a ROM.
+//
+// Tools that need the television image but do not want to add a PixelRenderer
+// can turn on the frame history with SetFrameHistory() and use CopyFrame()
+// and CopyCurrentFrame(). These return the signals for the most recently
+// completed frame and for the current frame respectively, along with the
+// coordinates of each signal.
+//
+// Interlaced images are detected from the cadence of the VSYNC signal. When the
+// time between successive VSYNC signals is an odd number of half-scanlines, the
+// frames are treated as alternating odd and even fields. PixelRenderers that
+// implement the FieldRenderer interface are told which field each new frame
+// belongs to. The field of the current frame is also available with
+// GetField().
+//
+// Framesize adaptation is also handled by the reference implementation. The
+// visible area is measured over a window of recent frames according to when
+// VBLANK is off or, for ROMs that do not use VBLANK, according to which
+// scanlines contain non-black pixels. A new visible area must be measured for
+// several frames before the PixelRenderers are resized. The automatic
+// detection can be overridden with SetVisibleArea().
 package television
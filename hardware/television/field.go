@@ -0,0 +1,123 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// Field indicates which field of an interlaced image a frame belongs to.
+type Field int
+
+// List of valid Field values. FieldNone indicates that the image is not
+// interlaced.
+const (
+	FieldNone Field = iota
+	FieldOdd
+	FieldEven
+)
+
+func (f Field) String() string {
+	switch f {
+	case FieldOdd:
+		return "odd"
+	case FieldEven:
+		return "even"
+	}
+	return "none"
+}
+
+// the number of color clocks in half a scanline.
+const halfScanline = specification.HorizClksScanline / 2
+
+// the number of consecutive fields of odd half-scanline length required before
+// the image is considered to be interlaced.
+const interlaceThreshold = 2
+
+// interlace detects odd/even fields from the cadence of the VSYNC signal.
+//
+// a television draws an interlaced image when the time between the start of
+// successive VSYNC signals is an odd number of half-scanlines (eg. 262.5
+// scanlines for NTSC). the vertical position of every other field is then
+// offset by half a scanline. the field with the VSYNC that starts in the middle
+// of a scanline is the even field, which is drawn lower than the odd field.
+type interlace struct {
+	// the number of color clocks since the start of the most recent VSYNC
+	clocks int
+
+	// the number of consecutive fields with a length of an odd number of
+	// half-scanlines
+	oddLengthCt int
+
+	// the field that will begin with the next synced frame
+	next Field
+
+	// the field of the current frame
+	current Field
+}
+
+func (i *interlace) reset() {
+	*i = interlace{}
+}
+
+// tick should be called for every color clock.
+func (i *interlace) tick() {
+	i.clocks++
+}
+
+// vsync should be called at the start of the VSYNC signal. the horizPos
+// argument is the horizontal position of the television at that moment.
+func (i *interlace) vsync(horizPos int) {
+	halfLines := (i.clocks + halfScanline/2) / halfScanline
+	i.clocks = 0
+
+	if halfLines%2 == 1 {
+		i.oddLengthCt++
+	} else {
+		i.oddLengthCt = 0
+	}
+
+	if i.oddLengthCt < interlaceThreshold {
+		i.next = FieldNone
+		return
+	}
+
+	if horizPos >= halfScanline/2 && horizPos < halfScanline*3/2 {
+		i.next = FieldEven
+	} else {
+		i.next = FieldOdd
+	}
+}
+
+// extraScanlines returns the number of scanlines beyond the specification's
+// total that should be allowed before the television flies back naturally. a
+// field that is an odd number of half-scanlines long can contain an
+// additional, partial scanline depending on where the VSYNC signal ends.
+func (i *interlace) extraScanlines() int {
+	if i.oddLengthCt > 0 {
+		return 1
+	}
+	return 0
+}
+
+// newFrame should be called at the start of every frame.
+func (i *interlace) newFrame(synced bool) {
+	if !synced {
+		i.oddLengthCt = 0
+		i.next = FieldNone
+	}
+	i.current = i.next
+}
@@ -0,0 +1,97 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// fieldRenderer records the fields sent by the television.
+type fieldRenderer struct {
+	fields []television.Field
+}
+
+func (r *fieldRenderer) Resize(_ specification.Spec, _, _ int) error      { return nil }
+func (r *fieldRenderer) NewFrame(_ television.FrameInfo) error            { return nil }
+func (r *fieldRenderer) NewScanline(_ int) error                          { return nil }
+func (r *fieldRenderer) UpdatingPixels(_ bool)                            {}
+func (r *fieldRenderer) SetPixel(_ signal.SignalAttributes, _ bool) error { return nil }
+func (r *fieldRenderer) Reset()                                           {}
+func (r *fieldRenderer) EndRendering() error                              { return nil }
+
+func (r *fieldRenderer) NewField(f television.Field) error {
+	r.fields = append(r.fields, f)
+	return nil
+}
+
+// runFields sends the signals for the specified number of fields to the
+// television. every field is fieldLength color clocks long and begins with
+// three scanlines of VSYNC.
+func runFields(t *testing.T, tv *television.Television, fieldLength int, fields int) {
+	t.Helper()
+
+	for c := 0; c < fieldLength*fields; c++ {
+		var sig signal.SignalAttributes
+		h := c % specification.HorizClksScanline
+		sig.SetHSync(h >= 16 && h < 32)
+		sig.SetVSync(c%fieldLength < specification.HorizClksScanline*3)
+		err := tv.Signal(sig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestProgressive(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	r := &fieldRenderer{}
+	tv.AddPixelRenderer(r)
+
+	runFields(t, tv, specification.HorizClksScanline*262, 10)
+
+	test.Equate(t, len(r.fields) > 0, true)
+	for _, f := range r.fields {
+		test.Equate(t, f.String(), television.FieldNone.String())
+	}
+	test.Equate(t, tv.GetField().String(), television.FieldNone.String())
+}
+
+func TestInterlaced(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	r := &fieldRenderer{}
+	tv.AddPixelRenderer(r)
+
+	// 262.5 scanlines per field
+	runFields(t, tv, specification.HorizClksScanline*525/2, 10)
+
+	// the last few fields should alternate between odd and even
+	n := len(r.fields)
+	test.Equate(t, n > 4, true)
+	for i := n - 4; i < n; i++ {
+		test.ExpectedFailure(t, r.fields[i] == television.FieldNone)
+		test.ExpectedFailure(t, r.fields[i] == r.fields[i-1])
+	}
+	test.Equate(t, tv.GetField().String(), r.fields[n-1].String())
+
+	// a progressive image after an interlaced image
+	runFields(t, tv, specification.HorizClksScanline*262, 4)
+	test.Equate(t, tv.GetField().String(), television.FieldNone.String())
+}
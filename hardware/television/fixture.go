@@ -0,0 +1,157 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// the first line of every fixture file.
+const fixtureHeader = "gopher2600 signal fixture"
+
+// SetFrameRecording turns recording of the signals sent to the television on
+// or off. When recording is on, the signals for the most recently completed
+// frame can be retrieved with the Fixture() function.
+func (tv *Television) SetFrameRecording(record bool) {
+	tv.recording = record
+	tv.record = tv.record[:0]
+	tv.recorded = tv.recorded[:0]
+}
+
+// Fixture is the complete list of signals sent to the television for a single
+// frame. It can be written to a file and replayed into a new television
+// instance. This is useful for recreating the behaviour of the television
+// without the need for a ROM.
+type Fixture struct {
+	// the specification that was requested when the television was created.
+	// this may be AUTO
+	Spec string
+
+	Signals []signal.SignalAttributes
+}
+
+// Fixture returns the signals of the most recently completed frame. Frame
+// recording must have been turned on with SetFrameRecording().
+func (tv *Television) Fixture() (Fixture, error) {
+	if !tv.recording {
+		return Fixture{}, curated.Errorf("television: frame recording is not enabled")
+	}
+	if len(tv.recorded) == 0 {
+		return Fixture{}, curated.Errorf("television: no complete frame has been recorded")
+	}
+
+	f := Fixture{
+		Spec:    tv.reqSpecID,
+		Signals: make([]signal.SignalAttributes, len(tv.recorded)),
+	}
+	copy(f.Signals, tv.recorded)
+
+	return f, nil
+}
+
+// Write the fixture as text. Consecutive signals that are identical are
+// written only once, along with a repeat count.
+func (f Fixture) Write(w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	if _, err := fmt.Fprintf(b, "%s\nspec %s\n", fixtureHeader, f.Spec); err != nil {
+		return curated.Errorf("fixture: %v", err)
+	}
+
+	for i := 0; i < len(f.Signals); {
+		j := i + 1
+		for j < len(f.Signals) && f.Signals[j] == f.Signals[i] {
+			j++
+		}
+		if _, err := fmt.Fprintf(b, "%016x %d\n", uint64(f.Signals[i]), j-i); err != nil {
+			return curated.Errorf("fixture: %v", err)
+		}
+		i = j
+	}
+
+	if err := b.Flush(); err != nil {
+		return curated.Errorf("fixture: %v", err)
+	}
+
+	return nil
+}
+
+// ReadFixture reads a fixture previously written with Fixture.Write().
+func ReadFixture(r io.Reader) (Fixture, error) {
+	var f Fixture
+
+	s := bufio.NewScanner(r)
+
+	if !s.Scan() || s.Text() != fixtureHeader {
+		return f, curated.Errorf("fixture: not a signal fixture")
+	}
+
+	if !s.Scan() || !strings.HasPrefix(s.Text(), "spec ") {
+		return f, curated.Errorf("fixture: missing specification")
+	}
+	f.Spec = strings.TrimPrefix(s.Text(), "spec ")
+
+	line := 2
+	for s.Scan() {
+		line++
+
+		p := strings.Fields(s.Text())
+		if len(p) != 2 {
+			return f, curated.Errorf("fixture: malformed signal on line %d", line)
+		}
+
+		v, err := strconv.ParseUint(p[0], 16, 64)
+		if err != nil {
+			return f, curated.Errorf("fixture: malformed signal on line %d", line)
+		}
+
+		n, err := strconv.Atoi(p[1])
+		if err != nil || n < 1 {
+			return f, curated.Errorf("fixture: malformed repeat count on line %d", line)
+		}
+
+		for i := 0; i < n; i++ {
+			f.Signals = append(f.Signals, signal.SignalAttributes(v))
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return f, curated.Errorf("fixture: %v", err)
+	}
+
+	return f, nil
+}
+
+// Replay sends the signals in the fixture to the television. The signals are
+// sent repeatedly, as many times as specified by the repeat argument. A
+// television created with the Spec field of the fixture will behave in the
+// same way as the television the fixture was taken from.
+func (f Fixture) Replay(tv *Television, repeat int) error {
+	for i := 0; i < repeat; i++ {
+		err := tv.SignalBatch(f.Signals)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// signals for a single NTSC frame with a VSYNC on the first three scanlines
+// and some pixels on every scanline.
+func fixtureFrame() []signal.SignalAttributes {
+	var sigs []signal.SignalAttributes
+	for sl := 0; sl < 262; sl++ {
+		for cl := 0; cl < 228; cl++ {
+			var sig signal.SignalAttributes
+			sig.SetVSync(sl < 3)
+			sig.SetHSync(cl >= 16 && cl < 32)
+			if cl >= 68 {
+				sig.SetPixel(signal.ColorSignal(cl & 0x0e))
+			} else {
+				sig.SetPixel(signal.VideoBlack)
+			}
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+func TestFixture(t *testing.T) {
+	tv, _ := television.NewTelevision("AUTO")
+
+	// fixture is not available until frame recording is turned on
+	_, err := tv.Fixture()
+	if err == nil {
+		t.Fatalf("expected error when frame recording is not enabled")
+	}
+
+	tv.SetFrameRecording(true)
+
+	frame := fixtureFrame()
+	for i := 0; i < 3; i++ {
+		if err := tv.SignalBatch(frame); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	f, err := tv.Fixture()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Spec != "AUTO" {
+		t.Errorf("expected AUTO spec in fixture (got %s)", f.Spec)
+	}
+	if len(f.Signals) != len(frame) {
+		t.Errorf("expected %d signals in fixture (got %d)", len(frame), len(f.Signals))
+	}
+
+	var b bytes.Buffer
+	if err := f.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	g, err := television.ReadFixture(&b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Spec != f.Spec || len(g.Signals) != len(f.Signals) {
+		t.Fatalf("fixture does not survive write and read")
+	}
+	for i := range f.Signals {
+		if g.Signals[i] != f.Signals[i] {
+			t.Fatalf("signal %d does not survive write and read", i)
+		}
+	}
+
+	// replaying the fixture into a new television and recording the result
+	// should produce the same fixture
+	replay, _ := television.NewTelevision(g.Spec)
+	replay.SetFrameRecording(true)
+	if err := g.Replay(replay, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	h, err := replay.Fixture()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h.Signals) != len(f.Signals) {
+		t.Fatalf("replayed fixture has %d signals (expected %d)", len(h.Signals), len(f.Signals))
+	}
+	for i := range f.Signals {
+		if h.Signals[i] != f.Signals[i] {
+			t.Fatalf("signal %d differs in replayed fixture", i)
+		}
+	}
+	if tv.GetSpec().ID != replay.GetSpec().ID {
+		t.Errorf("replayed television has different specification")
+	}
+
+	// not a fixture
+	_, err = television.ReadFixture(bytes.NewBufferString("foo\n"))
+	if err == nil {
+		t.Errorf("expected error reading invalid fixture")
+	}
+}
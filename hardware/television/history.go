@@ -0,0 +1,140 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// FrameSignal is a single entry in the signal history returned by CopyFrame()
+// and CopyCurrentFrame().
+type FrameSignal struct {
+	Coords Coordinates
+	Sig    signal.SignalAttributes
+}
+
+// frameHistory is a copy of the signals array for the current frame and for
+// the most recently completed frame. signals are copied into the history as
+// they are forwarded to the pixel renderers and so the cost of the history
+// is not in the Signal() function. the buffers are allocated once when the
+// history is turned on.
+type frameHistory struct {
+	frames [2][MaxSignalHistory]signal.SignalAttributes
+	len    [2]int
+
+	// index of the current frame in the frames array. the completed frame is
+	// the other entry
+	curr int
+
+	// the frame number of the completed frame
+	completedFrame int
+}
+
+// append signals to the current frame. signals beyond the capacity of the
+// history are dropped.
+func (h *frameHistory) append(sigs []signal.SignalAttributes) {
+	h.len[h.curr] += copy(h.frames[h.curr][h.len[h.curr]:], sigs)
+}
+
+// complete the current frame.
+func (h *frameHistory) complete(frameNum int) {
+	h.completedFrame = frameNum
+	h.curr = 1 - h.curr
+	h.len[h.curr] = 0
+}
+
+func (h *frameHistory) reset() {
+	h.len[0] = 0
+	h.len[1] = 0
+}
+
+// SetFrameHistory turns the frame history on or off. When the frame history
+// is on, the signals for the current frame and for the most recently
+// completed frame can be retrieved with CopyCurrentFrame() and CopyFrame().
+//
+// The frame history is made up of the same signals that are sent to the pixel
+// renderers, so the history can be used in place of a PixelRenderer by tools
+// that only occasionally need the television image. No history is kept in
+// no-render mode (see SetNoRender()).
+func (tv *Television) SetFrameHistory(history bool) {
+	if !history {
+		tv.history = nil
+		return
+	}
+	if tv.history == nil {
+		tv.history = &frameHistory{}
+	}
+}
+
+// CopyFrame returns a copy of every signal in the most recently completed
+// frame, in the order they were received by the television. The history is
+// cleared when the television is reset or when a state is plumbed in.
+//
+// Frame history must have been turned on with SetFrameHistory().
+func (tv *Television) CopyFrame() ([]FrameSignal, error) {
+	tv.flushPending()
+	if tv.history == nil {
+		return nil, curated.Errorf("television: frame history is not enabled")
+	}
+	h := tv.history
+	c := 1 - h.curr
+	if h.len[c] == 0 {
+		return nil, curated.Errorf("television: no complete frame in the frame history")
+	}
+	return copyHistory(h.frames[c][:h.len[c]], nil, h.completedFrame), nil
+}
+
+// CopyCurrentFrame is the same as CopyFrame() except that it returns the
+// signals of the current frame, up to and including the most recent signal.
+// The returned slice is empty if no signals have been received since the
+// start of the frame.
+func (tv *Television) CopyCurrentFrame() ([]FrameSignal, error) {
+	tv.flushPending()
+	if tv.history == nil {
+		return nil, curated.Errorf("television: frame history is not enabled")
+	}
+
+	// the current frame is made up of the signals that have been forwarded to
+	// the pixel renderers and those that are still waiting to be forwarded
+	h := tv.history
+	return copyHistory(h.frames[h.curr][:h.len[h.curr]], tv.signals[:tv.signalIdx], tv.state.frameNum), nil
+}
+
+func copyHistory(history []signal.SignalAttributes, pending []signal.SignalAttributes, frameNum int) []FrameSignal {
+	c := make([]FrameSignal, 0, len(history)+len(pending))
+	for _, l := range [][]signal.SignalAttributes{history, pending} {
+		for _, sig := range l {
+			c = append(c, FrameSignal{
+				Coords: Coordinates{
+					Frame:    frameNum,
+					Scanline: sig.Scanline(),
+					Clock:    sig.HorizPos() - specification.HorizClksHBlank,
+				},
+				Sig: sig,
+			})
+		}
+	}
+	return c
+}
+
+// resetHistory clears the frame history.
+func (tv *Television) resetHistory() {
+	if tv.history != nil {
+		tv.history.reset()
+	}
+}
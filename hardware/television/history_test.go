@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestCopyFrame(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+
+	if _, err := tv.CopyFrame(); err == nil {
+		t.Errorf("expected error when frame history is not enabled")
+	}
+
+	tv.SetFrameHistory(true)
+
+	if _, err := tv.CopyFrame(); err == nil {
+		t.Errorf("expected error before the first frame")
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := tv.SignalBatch(test.Frame(262, true, signal.VideoBlack)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// part of the next frame
+	if err := tv.SignalBatch(test.Frame(262, true, signal.VideoBlack)[:228*10]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	frame := tv.GetState(signal.ReqFramenum)
+
+	// the completed frame has every signal. the history is not affected by the
+	// signals being forwarded to the pixel renderers
+	h, err := tv.CopyFrame()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h) != 262*228 {
+		t.Fatalf("unexpected length of completed frame history: %d", len(h))
+	}
+	for i, s := range h {
+		if s.Coords.Frame != frame-1 {
+			t.Fatalf("unexpected frame number in completed frame history: %d", s.Coords.Frame)
+		}
+		if s.Coords.Scanline != s.Sig.Scanline() || s.Coords.Clock != s.Sig.HorizPos()-specification.HorizClksHBlank {
+			t.Fatalf("coordinates do not match signal at entry %d", i)
+		}
+	}
+
+	// the current frame has only the signals received so far
+	c, err := tv.CopyCurrentFrame()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c) != 228*10 {
+		t.Fatalf("unexpected length of current frame history: %d", len(c))
+	}
+	last := c[len(c)-1].Coords
+	if last.Frame != frame || last.Scanline != tv.GetState(signal.ReqScanline) || last.Clock != tv.GetState(signal.ReqHorizPos) {
+		t.Errorf("last entry of current frame history is not the current position (%s)", last)
+	}
+
+	// the history is a copy
+	h[0].Sig = 0xff
+	if h2, _ := tv.CopyFrame(); h2[0].Sig == 0xff {
+		t.Errorf("signal history was not copied")
+	}
+
+	// no history is kept in no-render mode
+	tv2, _ := television.NewTelevision("NTSC")
+	tv2.SetFrameHistory(true)
+	if err := tv2.SetNoRender(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := tv2.SignalBatch(test.Frame(262, true, signal.VideoBlack)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := tv2.CopyFrame(); err == nil {
+		t.Errorf("unexpected signal history in no-render mode")
+	}
+	if c, _ := tv2.CopyCurrentFrame(); len(c) != 0 {
+		t.Errorf("unexpected signal history in no-render mode")
+	}
+}
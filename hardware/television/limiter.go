@@ -120,6 +120,28 @@ func (lmtr *limiter) checkPixel() {
 	lmtr.measureActual()
 }
 
+// pause is called when the paused state of the television changes. the
+// measurement of the actual frame rate is restarted on resume so that the time
+// spent paused is not included. the value of actual is unchanged while
+// paused.
+func (lmtr *limiter) pause(pause bool) {
+	if pause {
+		return
+	}
+
+	lmtr.actualCt = 0
+	lmtr.actualTime = time.Now()
+
+	// drain the tick that will have been waiting during the pause. without
+	// this the first frame after the pause will not be limited
+	if lmtr.pulse != nil {
+		select {
+		case <-lmtr.pulse.C:
+		default:
+		}
+	}
+}
+
 // called every scanline (although internally limited) to calculate the actual
 // frame rate being achieved.
 func (lmtr *limiter) measureActual() {
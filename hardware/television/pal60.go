@@ -0,0 +1,71 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// the minimum proportion of visible pixels that must be colored (ie. not grey
+// in the PAL palette) before a ROM is considered to be PAL60.
+const pal60MinColored = 0.05
+
+// pal60 detects ROMs that have NTSC timing but which have been written for the
+// PAL palette.
+//
+// the detection relies on the layout of the PAL palette. in PAL, the hues 0x1,
+// 0xe and 0xf are grey and are the same as hue 0x0. programmers writing for
+// PAL have no reason to use them. in the NTSC palette however, the same hues
+// are the yellows, oranges and browns, and most NTSC ROMs use them.
+//
+// a ROM that draws a reasonable amount of color but never uses one of these
+// hues is therefore likely to be a PAL ROM. this is a heuristic and will be
+// fooled by NTSC ROMs that happen not to use yellow, orange or brown.
+type pal60 struct {
+	// the number of pixels seen of each hue
+	hues [16]int
+
+	// the total number of pixels seen
+	total int
+}
+
+func (p *pal60) reset() {
+	*p = pal60{}
+}
+
+// tick should be called for every signal while detection is taking place.
+func (p *pal60) tick(sig signal.SignalAttributes) {
+	if sig.VBlank() || sig.Pixel() == signal.VideoBlack {
+		return
+	}
+	p.hues[sig.Pixel().Hue()]++
+	p.total++
+}
+
+// isPAL60 returns true if the pixels seen so far suggest that the ROM has been
+// written for the PAL palette.
+func (p *pal60) isPAL60() bool {
+	if p.total == 0 {
+		return false
+	}
+
+	if p.hues[0x1] > 0 || p.hues[0xe] > 0 || p.hues[0xf] > 0 {
+		return false
+	}
+
+	colored := p.total - p.hues[0x0]
+	return float32(colored)/float32(p.total) >= pal60MinColored
+}
@@ -0,0 +1,85 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// runColorFrames sends the signals for the specified number of 262 scanline
+// frames to the television. the visible part of every scanline is drawn with
+// the color signal.
+func runColorFrames(t *testing.T, tv *television.Television, col signal.ColorSignal, frames int) {
+	t.Helper()
+
+	tv.SetFPSCap(false)
+
+	frameLength := specification.HorizClksScanline * 262
+	for c := 0; c < frameLength*frames; c++ {
+		var sig signal.SignalAttributes
+		h := c % specification.HorizClksScanline
+		sig.SetHSync(h >= 16 && h < 32)
+		sig.SetVSync(c%frameLength < specification.HorizClksScanline*3)
+		if h >= specification.HorizClksHBlank {
+			sig.SetPixel(col)
+		} else {
+			sig.SetPixel(signal.VideoBlack)
+		}
+		err := tv.Signal(sig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestPAL60Detection(t *testing.T) {
+	// a color that is only grey in the PAL palette. the ROM is NTSC
+	tv, _ := television.NewTelevision("AUTO")
+	runColorFrames(t, tv, signal.NewColorSignal(0x1, 0x7), 30)
+	test.Equate(t, tv.GetSpec().ID, specification.SpecNTSC.ID)
+
+	// a grey image gives no indication of the palette
+	tv, _ = television.NewTelevision("AUTO")
+	runColorFrames(t, tv, signal.NewColorSignal(0x0, 0x4), 30)
+	test.Equate(t, tv.GetSpec().ID, specification.SpecNTSC.ID)
+
+	// a colorful image that never uses the hues that are grey in PAL
+	tv, _ = television.NewTelevision("AUTO")
+	runColorFrames(t, tv, signal.NewColorSignal(0x4, 0x3), 30)
+	test.Equate(t, tv.GetSpec().ID, specification.SpecPAL60.ID)
+
+	// the same image when the specification has been requested explicitly
+	tv, _ = television.NewTelevision("NTSC")
+	runColorFrames(t, tv, signal.NewColorSignal(0x4, 0x3), 30)
+	test.Equate(t, tv.GetSpec().ID, specification.SpecNTSC.ID)
+}
+
+func TestPAL60Spec(t *testing.T) {
+	tv, _ := television.NewTelevision("PAL60")
+	spec := tv.GetSpec()
+	test.Equate(t, spec.ID, "PAL60")
+
+	// NTSC timing with the PAL palette
+	test.Equate(t, spec.ScanlinesTotal, specification.SpecNTSC.ScanlinesTotal)
+	test.Equate(t, spec.FramesPerSecond == specification.SpecNTSC.FramesPerSecond, true)
+	test.Equate(t, spec.GetColor(0x46) == specification.SpecPAL.GetColor(0x46), true)
+	test.Equate(t, spec.GetColor(0x46) == specification.SpecNTSC.GetColor(0x46), false)
+}
@@ -0,0 +1,111 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// pausingRenderer records the calls to Pause() and the number of pixels
+// received.
+type pausingRenderer struct {
+	pauses []bool
+	pixels int
+}
+
+func (r *pausingRenderer) Resize(_ specification.Spec, _, _ int) error { return nil }
+func (r *pausingRenderer) NewFrame(_ television.FrameInfo) error       { return nil }
+func (r *pausingRenderer) NewScanline(_ int) error                     { return nil }
+func (r *pausingRenderer) UpdatingPixels(_ bool)                       {}
+func (r *pausingRenderer) Reset()                                      {}
+func (r *pausingRenderer) EndRendering() error                         { return nil }
+
+func (r *pausingRenderer) SetPixel(_ signal.SignalAttributes, _ bool) error {
+	r.pixels++
+	return nil
+}
+
+func (r *pausingRenderer) Pause(pause bool) error {
+	r.pauses = append(r.pauses, pause)
+	return nil
+}
+
+// pausingMixer records the calls to Pause().
+type pausingMixer struct {
+	pauses []bool
+}
+
+func (m *pausingMixer) SetAudio(_ uint8) error { return nil }
+func (m *pausingMixer) EndMixing() error       { return nil }
+
+func (m *pausingMixer) Pause(pause bool) error {
+	m.pauses = append(m.pauses, pause)
+	return nil
+}
+
+func TestPause(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	rnd := &pausingRenderer{}
+	mix := &pausingMixer{}
+	tv.AddPixelRenderer(rnd)
+	tv.AddAudioMixer(mix)
+
+	if tv.IsPaused() {
+		t.Errorf("television should not be paused")
+	}
+
+	// a few pixels that will not have been sent to the renderer yet
+	for i := 0; i < 10; i++ {
+		_ = tv.Signal(signal.SignalAttributes(0))
+	}
+	if rnd.pixels != 0 {
+		t.Fatalf("pixels unexpectedly sent to renderer before pause: %d", rnd.pixels)
+	}
+
+	// pausing pushes the pending pixels
+	if err := tv.Pause(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rnd.pixels != 10 {
+		t.Errorf("unexpected number of pixels after pause: %d (expected 10)", rnd.pixels)
+	}
+	if !tv.IsPaused() {
+		t.Errorf("television should be paused")
+	}
+
+	// renderers and mixers are notified of every call
+	_ = tv.Pause(true)
+	_ = tv.Pause(false)
+	if tv.IsPaused() {
+		t.Errorf("television should not be paused")
+	}
+
+	expected := []bool{true, true, false}
+	for _, p := range [][]bool{rnd.pauses, mix.pauses} {
+		if len(p) != len(expected) {
+			t.Fatalf("unexpected number of Pause() calls: %d (expected %d)", len(p), len(expected))
+		}
+		for i := range expected {
+			if p[i] != expected[i] {
+				t.Errorf("unexpected Pause() argument for call %d: %v", i, p[i])
+			}
+		}
+	}
+}
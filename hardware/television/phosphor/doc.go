@@ -0,0 +1,41 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package phosphor simulates the persistence of the phosphor coating of a CRT
+// television. Without it, games that flicker sprites at 30Hz (or slower) to
+// work around the limitations of the TIA look wrong when displayed on a modern
+// monitor.
+//
+// The Phosphor type keeps a history of the most recent frames. Every pixel
+// given to SetPixel() is mixed with the same pixel in the previous frames.
+// The contribution of each previous frame is reduced by the decay value for
+// every frame of age. A decay value of 1.0 means that every frame contributes
+// equally; a value of 0.0 means that previous frames do not contribute at
+// all.
+//
+// Phosphor works with RGB values and so is used by PixelRenderers after the
+// signal has been converted to a color. For example:
+//
+//	func (r *Renderer) SetPixel(sig signal.SignalAttributes, current bool) error {
+//		col := r.spec.GetColor(sig.Pixel())
+//		col = r.phosphor.SetPixel(sig.HorizPos(), sig.Scanline(), col)
+//		...
+//	}
+//
+//	func (r *Renderer) NewFrame(info television.FrameInfo) error {
+//		r.phosphor.NewFrame()
+//		...
+//	}
+package phosphor
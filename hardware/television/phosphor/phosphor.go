@@ -0,0 +1,136 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package phosphor
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// The range of values for the number of frames mixed by Phosphor. The number
+// of frames includes the current frame.
+const (
+	MinFrames = 2
+	MaxFrames = 8
+)
+
+// the dimensions of each frame in the history. large enough for any
+// specification.
+const (
+	width  = specification.HorizClksScanline
+	height = television.MaxScanlinesAbsolute
+)
+
+// weights are fixed point values with weightShift fractional bits.
+const weightShift = 8
+
+// Phosphor mixes pixels with the same pixel in previous frames. See package
+// documentation for details.
+type Phosphor struct {
+	// circular buffer of frames. the current frame is at index curr
+	frames [][]color.RGBA
+	curr   int
+
+	// the weight given to each frame according to its age. weights[0] is the
+	// weight of the current frame
+	weights []int32
+	total   int32
+}
+
+// NewPhosphor is the preferred method of initialisation for the Phosphor type.
+func NewPhosphor(frames int, decay float64) (*Phosphor, error) {
+	if frames < MinFrames || frames > MaxFrames {
+		return nil, curated.Errorf("phosphor: number of frames must be between %d and %d", MinFrames, MaxFrames)
+	}
+	if decay < 0.0 || decay > 1.0 {
+		return nil, curated.Errorf("phosphor: decay must be between 0.0 and 1.0")
+	}
+
+	p := &Phosphor{
+		frames:  make([][]color.RGBA, frames),
+		weights: make([]int32, frames),
+	}
+
+	for i := range p.frames {
+		p.frames[i] = make([]color.RGBA, width*height)
+	}
+
+	for i := range p.weights {
+		p.weights[i] = int32(math.Round(math.Pow(decay, float64(i)) * (1 << weightShift)))
+		p.total += p.weights[i]
+	}
+
+	return p, nil
+}
+
+// SetPixel records the color of the pixel in the current frame and returns
+// the color mixed with the previous frames. Pixels outside of the television's
+// maximum dimensions are returned unchanged.
+func (p *Phosphor) SetPixel(x int, y int, col color.RGBA) color.RGBA {
+	if x < 0 || x >= width || y < 0 || y >= height {
+		return col
+	}
+
+	i := y*width + x
+	p.frames[p.curr][i] = col
+
+	var r, g, b int32
+	for age, w := range p.weights {
+		f := p.curr - age
+		if f < 0 {
+			f += len(p.frames)
+		}
+		c := p.frames[f][i]
+		r += int32(c.R) * w
+		g += int32(c.G) * w
+		b += int32(c.B) * w
+	}
+
+	return color.RGBA{
+		R: uint8((r + p.total/2) / p.total),
+		G: uint8((g + p.total/2) / p.total),
+		B: uint8((b + p.total/2) / p.total),
+		A: col.A,
+	}
+}
+
+// NewFrame should be called at the start of every frame. The oldest frame in
+// the history is discarded.
+func (p *Phosphor) NewFrame() {
+	p.curr++
+	if p.curr >= len(p.frames) {
+		p.curr = 0
+	}
+
+	f := p.frames[p.curr]
+	for i := range f {
+		f[i] = color.RGBA{}
+	}
+}
+
+// Reset clears the frame history.
+func (p *Phosphor) Reset() {
+	for _, f := range p.frames {
+		for i := range f {
+			f[i] = color.RGBA{}
+		}
+	}
+	p.curr = 0
+}
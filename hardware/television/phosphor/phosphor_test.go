@@ -0,0 +1,96 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package phosphor_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/phosphor"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestPhosphorRange(t *testing.T) {
+	_, err := phosphor.NewPhosphor(phosphor.MinFrames-1, 0.5)
+	test.ExpectedFailure(t, err)
+	_, err = phosphor.NewPhosphor(phosphor.MaxFrames+1, 0.5)
+	test.ExpectedFailure(t, err)
+	_, err = phosphor.NewPhosphor(phosphor.MinFrames, 1.5)
+	test.ExpectedFailure(t, err)
+	_, err = phosphor.NewPhosphor(phosphor.MinFrames, 0.5)
+	test.ExpectedSuccess(t, err)
+}
+
+func TestPhosphorFlicker(t *testing.T) {
+	white := color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	black := color.RGBA{A: 255}
+
+	// two frames with no decay. a pixel that is on every other frame should
+	// appear at half brightness
+	p, _ := phosphor.NewPhosphor(2, 1.0)
+
+	c := p.SetPixel(10, 10, white)
+	test.Equate(t, int(c.R), 100)
+	p.NewFrame()
+	c = p.SetPixel(10, 10, black)
+	test.Equate(t, int(c.R), 100)
+	test.Equate(t, int(c.A), 255)
+	p.NewFrame()
+	c = p.SetPixel(10, 10, white)
+	test.Equate(t, int(c.R), 100)
+
+	// a steady pixel is unchanged
+	p.NewFrame()
+	c = p.SetPixel(10, 10, white)
+	test.Equate(t, int(c.R), 200)
+
+	// pixels outside of the frame are unchanged
+	c = p.SetPixel(-1, 10, white)
+	test.Equate(t, int(c.R), 200)
+
+	p.Reset()
+	c = p.SetPixel(10, 10, white)
+	test.Equate(t, int(c.R), 100)
+}
+
+func TestPhosphorDecay(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	black := color.RGBA{A: 255}
+
+	// no decay means the previous frames do not contribute
+	p, _ := phosphor.NewPhosphor(4, 0.0)
+	p.SetPixel(0, 0, white)
+	p.NewFrame()
+	c := p.SetPixel(0, 0, black)
+	test.Equate(t, int(c.R), 0)
+
+	// older frames contribute less than newer frames
+	p, _ = phosphor.NewPhosphor(3, 0.5)
+	p.SetPixel(0, 0, white)
+	p.NewFrame()
+	p.SetPixel(0, 0, black)
+	p.NewFrame()
+	older := p.SetPixel(0, 0, black)
+
+	p.Reset()
+	p.SetPixel(0, 0, black)
+	p.NewFrame()
+	p.SetPixel(0, 0, white)
+	p.NewFrame()
+	newer := p.SetPixel(0, 0, black)
+
+	test.ExpectedSuccess(t, older.R < newer.R)
+}
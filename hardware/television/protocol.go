@@ -53,12 +53,17 @@ type PixelRenderer interface {
 	Resize(spec specification.Spec, topScanline, visibleScanlines int) error
 
 	// NewFrame and NewScanline are called at the start of the frame/scanline
-	NewFrame(isStable bool) error
+	NewFrame(info FrameInfo) error
 	NewScanline(scanline int) error
 
 	// Mark the start and end of an update event from the television.
 	// SetPixel() should only be called between calls of UpdatingPixels(true)
 	// and UpdatingPixels(false)
+	//
+	// An update event covers all the pixels that are pending at the time of
+	// the update, which may be as many as a full frame. Renderers can
+	// therefore take any lock they require in UpdatingPixels() rather than in
+	// SetPixel()
 	UpdatingPixels(updating bool)
 
 	// SetPixel() is called every cycle regardless of the state of VBLANK and
@@ -100,10 +105,57 @@ type PixelRenderer interface {
 	EndRendering() error
 }
 
+// FieldRenderer is an optional interface for PixelRenderer implementations.
+// Renderers that implement this interface are told which field of an
+// interlaced image the new frame belongs to. NewField() is called immediately
+// after every call to NewFrame().
+//
+// The field will be FieldNone unless the television has detected that the VCS
+// is producing an interlaced image. Renderers can use the field information to
+// weave the two fields together or to offset the even field by half a
+// scanline (bob).
+type FieldRenderer interface {
+	NewField(field Field) error
+}
+
+// PausingRenderer is an optional interface for PixelRenderer implementations.
+// The renderer will be notified whenever the television is paused or
+// unpaused. As with the PausingMixer interface, Pause(true) may be called
+// many times in succession as the emulation is stepped by the debugger.
+//
+// Pending pixels will have been sent to the renderer before Pause(true) is
+// called.
+type PausingRenderer interface {
+	Pause(pause bool) error
+}
+
+// FrameInfo is sent with every NewFrame event.
+type FrameInfo struct {
+	// the number of the frame that is about to begin. the frame that has just
+	// completed is FrameNum-1
+	FrameNum int
+
+	// whether the television is stable. the same value as returned by
+	// IsStable()
+	Stable bool
+
+	// whether the new frame was started by a valid VSYNC signal
+	Synced bool
+
+	// the current specification. note that the specification may have
+	// changed since the previous frame
+	Spec specification.Spec
+
+	// whether the specification or the visible area changed at the end of
+	// the frame that has just completed. in the case of PixelRenderers, the
+	// Resize() function will have been called before NewFrame()
+	Resized bool
+}
+
 // FrameTrigger implementations listen for NewFrame events. FrameTrigger is a
 // subset of PixelRenderer.
 type FrameTrigger interface {
-	NewFrame(isStable bool) error
+	NewFrame(info FrameInfo) error
 }
 
 // AudioMixer implementations work with sound; most probably playing it. An
@@ -118,6 +170,21 @@ type AudioMixer interface {
 	EndMixing() error
 }
 
+// StereoMixer is an optional interface for AudioMixer implementations. Mixers
+// that implement this interface will receive stereo audio data with
+// SetStereo() instead of mono audio data with SetAudio().
+type StereoMixer interface {
+	SetStereo(left uint8, right uint8) error
+}
+
+// PausingMixer is an optional interface for AudioMixer implementations. The
+// mixer will be notified whenever the television is paused or unpaused. Note
+// that Pause(true) may be called many times in succession as the emulation is
+// stepped by the debugger.
+type PausingMixer interface {
+	Pause(pause bool) error
+}
+
 type ReflectionSynchronising interface {
 	SyncReflectionPixel(idx int) error
 	SyncFrame()
@@ -0,0 +1,119 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+func TestRemovePixelRenderer(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	r1 := &pausingRenderer{}
+	r2 := &pausingRenderer{}
+	tv.AddPixelRenderer(r1)
+	tv.AddPixelRenderer(r2)
+
+	frame := vblankFrame(50, 210)
+	_ = tv.SignalBatch(frame)
+	_ = tv.SignalBatch(frame)
+	if r1.pixels != len(frame) || r2.pixels != len(frame) {
+		t.Fatalf("unexpected number of pixels: %d and %d (expected %d)", r1.pixels, r2.pixels, len(frame))
+	}
+
+	tv.RemovePixelRenderer(r1)
+	_ = tv.SignalBatch(frame)
+	if r1.pixels != len(frame) {
+		t.Errorf("removed renderer received pixels")
+	}
+	if r2.pixels != len(frame)*2 {
+		t.Errorf("unexpected number of pixels: %d (expected %d)", r2.pixels, len(frame)*2)
+	}
+
+	// removing a renderer that is not registered is not an error
+	tv.RemovePixelRenderer(r1)
+
+	// adding the renderer again
+	tv.AddPixelRenderer(r1)
+	_ = tv.SignalBatch(frame)
+	if r1.pixels != len(frame)*2 {
+		t.Errorf("unexpected number of pixels: %d (expected %d)", r1.pixels, len(frame)*2)
+	}
+}
+
+// removingTrigger removes itself from the television on the first NewFrame().
+type removingTrigger struct {
+	tv     *television.Television
+	frames int
+}
+
+func (trg *removingTrigger) NewFrame(_ television.FrameInfo) error {
+	trg.frames++
+	trg.tv.RemoveFrameTrigger(trg)
+	return nil
+}
+
+func TestRemoveFrameTrigger(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	rem := &removingTrigger{tv: tv}
+	trg1 := &frameInfoTrigger{}
+	trg2 := &frameInfoTrigger{}
+	tv.AddFrameTrigger(rem)
+	tv.AddFrameTrigger(trg1)
+	tv.AddFrameTrigger(trg2)
+
+	frame := vblankFrame(50, 210)
+	for i := 0; i < 5; i++ {
+		_ = tv.SignalBatch(frame)
+	}
+
+	if rem.frames != 1 {
+		t.Errorf("unexpected number of NewFrame() events for removed trigger: %d (expected 1)", rem.frames)
+	}
+
+	// the triggers after the removed trigger should receive the NewFrame()
+	// event in which the removal happened exactly once
+	if len(trg1.info) != 4 || len(trg2.info) != 4 {
+		t.Errorf("unexpected number of NewFrame() events: %d and %d (expected 4)", len(trg1.info), len(trg2.info))
+	}
+}
+
+type countingMixer struct {
+	samples int
+}
+
+func (m *countingMixer) SetAudio(_ uint8) error { m.samples++; return nil }
+func (m *countingMixer) EndMixing() error       { return nil }
+
+func TestRemoveAudioMixer(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	m := &countingMixer{}
+	tv.AddAudioMixer(m)
+
+	var sig signal.SignalAttributes
+	sig.SetAudioUpdate(true)
+
+	_ = tv.Signal(sig)
+	_ = tv.Signal(sig)
+	tv.RemoveAudioMixer(m)
+	_ = tv.Signal(sig)
+
+	if m.samples != 2 {
+		t.Errorf("unexpected number of samples: %d (expected 2)", m.samples)
+	}
+}
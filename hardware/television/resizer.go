@@ -15,51 +15,172 @@
 
 package television
 
-import "github.com/jetsetilly/gopher2600/hardware/television/signal"
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
 
-// resize is the simplest resizing method I can come up with.
-type resizer struct {
+// the number of frames over which the visible area is measured. the visible
+// area is the union of the areas measured in each frame of the window. this
+// means that ROMs that draw different parts of the screen on alternate
+// frames will not be cropped.
+const resizeWindow = 30
+
+// the number of consecutive frames a new visible area must be measured before
+// the screen is resized. this prevents the screen from oscillating between
+// sizes.
+const resizeHold = 10
+
+// the minimum number of scanlines in a visible area. measurements smaller than
+// this are ignored.
+const resizeMinScanlines = 64
+
+// if VBLANK is off for all but this number of scanlines in a frame then the
+// ROM is considered to not be using VBLANK to define the visible area. the
+// visible area is defined by non-black pixels instead.
+const resizeVBlankMargin = 10
+
+// extent is the visible area of a single frame.
+type extent struct {
+	valid  bool
+	top    int
 	bottom int
 }
 
+// resizer detects the visible area of the screen. the measurement for each
+// frame is made according to the VBLANK signal unless the ROM does not use
+// VBLANK, in which case the measurement is made according to the scanlines
+// that contain non-black pixels.
+//
+// note that the resizer is part of the television State and so must be
+// copyable. it should not contain any slices or maps.
+type resizer struct {
+	// measurements for the current frame. the top values are -1 if nothing
+	// has been measured
+	vblankTop    int
+	vblankBottom int
+	pixelTop     int
+	pixelBottom  int
+
+	// sliding window of measurements for previous frames
+	window    [resizeWindow]extent
+	windowIdx int
+
+	// the proposed visible area and the number of consecutive frames it has
+	// been proposed for
+	proposed      extent
+	proposedCount int
+
+	// override the detected visible area. see Television.SetVisibleArea()
+	override extent
+}
+
 func (sr *resizer) examine(tv *Television, sig signal.SignalAttributes) {
-	// if vblank is off at any point of then extend the bottom of the screen.
-	// we'll commit the resize procedure in the newFrame() function
-	//
-	// comparing against current bottom scanline, rather than ideal bottom
-	// scanline of the specification. this means that a screen will never
-	// "shrink" until the specification is changed either manually or
-	// automatically.
-	//
-	// we mitigate this by not initiating a resize event until after the setup
-	// phase (as quantified by the leadingFrames value). any problems with ROMs
-	// that erroneously trigger a resize through rogue frames will have to be
-	// dealt with by some sort of count (ie. the new size has to be "held" for
-	// N number of frames before we resize). Earlier versions of this file did
-	// do that but we removed it due to no evidence that it was required.
-	if !sig.VBlank {
-		if tv.state.scanline > sr.bottom {
-			sr.bottom = tv.state.scanline
+	// the state of VBLANK during HBLANK has no effect on the visible area
+	if sig.VBlank() || tv.state.horizPos < specification.HorizClksHBlank {
+		return
+	}
+
+	sl := tv.state.scanline
+
+	if sr.vblankTop == -1 {
+		sr.vblankTop = sl
+	}
+	sr.vblankBottom = sl
+
+	// non-black pixel. the lower bit of the color signal is not used so
+	// values of 0 and 1 are both black
+	if p := sig.Pixel(); p != signal.VideoBlack && p>>1 != 0 {
+		if sr.pixelTop == -1 {
+			sr.pixelTop = sl
 		}
+		sr.pixelBottom = sl
 	}
 }
 
+// measure the visible area of the frame that has just ended.
+func (sr *resizer) measure(tv *Television) extent {
+	var e extent
+
+	if sr.vblankTop != -1 && sr.vblankBottom-sr.vblankTop < tv.state.spec.ScanlinesTotal-resizeVBlankMargin {
+		e = extent{valid: true, top: sr.vblankTop, bottom: sr.vblankBottom + 1}
+	} else if sr.pixelTop != -1 {
+		e = extent{valid: true, top: sr.pixelTop, bottom: sr.pixelBottom + 1}
+	}
+
+	if e.bottom-e.top < resizeMinScanlines {
+		e.valid = false
+	}
+
+	return e
+}
+
 func (sr *resizer) commit(tv *Television) error {
-	// always perform resize operation
-	if tv.state.syncedFrameNum <= leadingFrames || sr.bottom == tv.state.bottom {
+	// record measurement of the frame that has just ended in the window
+	sr.window[sr.windowIdx] = sr.measure(tv)
+	sr.windowIdx++
+	if sr.windowIdx >= len(sr.window) {
+		sr.windowIdx = 0
+	}
+
+	// no resizing during the setup phase of the ROM
+	if tv.state.syncedFrameNum <= leadingFrames {
 		return nil
 	}
 
-	diff := sr.bottom - tv.state.bottom
+	var next extent
+
+	if sr.override.valid {
+		next = sr.override
+	} else {
+		// union of all measurements in the window
+		for _, e := range sr.window {
+			if !e.valid {
+				continue
+			}
+			if !next.valid {
+				next = e
+				continue
+			}
+			if e.top < next.top {
+				next.top = e.top
+			}
+			if e.bottom > next.bottom {
+				next.bottom = e.bottom
+			}
+		}
+
+		if !next.valid {
+			return nil
+		}
+
+		// new visible area must be held for a number of frames before the
+		// screen is resized
+		if next != sr.proposed {
+			sr.proposed = next
+			sr.proposedCount = 0
+		}
+		sr.proposedCount++
+		if sr.proposedCount < resizeHold {
+			return nil
+		}
+	}
+
+	// clamp visible area to the specification
+	if next.top < 0 {
+		next.top = 0
+	}
+	if next.bottom > tv.state.spec.ScanlinesTotal {
+		next.bottom = tv.state.spec.ScanlinesTotal
+	}
 
-	// reduce top by same amount as bottom
-	tv.state.top -= diff
-	if tv.state.top < 0 {
-		tv.state.top = 0
+	if next.top == tv.state.top && next.bottom == tv.state.bottom {
+		return nil
 	}
 
-	// new bottom value is what we detected
-	tv.state.bottom = sr.bottom
+	tv.state.top = next.top
+	tv.state.bottom = next.bottom
 
 	// call Resize() for all attached pixel rendered
 	if tv.state.top < tv.state.bottom {
@@ -74,6 +195,49 @@ func (sr *resizer) commit(tv *Television) error {
 	return nil
 }
 
+// prepare for the measurement of a new frame.
 func (sr *resizer) prepare(tv *Television) {
-	sr.bottom = tv.state.bottom
+	sr.vblankTop = -1
+	sr.vblankBottom = -1
+	sr.pixelTop = -1
+	sr.pixelBottom = -1
+}
+
+// reset all measurements. used when the specification changes.
+func (sr *resizer) reset(tv *Television) {
+	sr.prepare(tv)
+	for i := range sr.window {
+		sr.window[i] = extent{}
+	}
+	sr.windowIdx = 0
+	sr.proposed = extent{}
+	sr.proposedCount = 0
+}
+
+// SetVisibleArea overrides the automatic detection of the visible area of the
+// screen. The top and bottom values are scanline numbers. The bottom scanline
+// is not included in the visible area. The override takes effect at the next
+// frame.
+//
+// Useful for ROMs where the automatic detection gives poor results.
+func (tv *Television) SetVisibleArea(top int, bottom int) error {
+	if top < 0 || bottom <= top || bottom-top < resizeMinScanlines {
+		return curated.Errorf("television: invalid visible area (%d to %d)", top, bottom)
+	}
+	tv.state.resizer.override = extent{valid: true, top: top, bottom: bottom}
+	return nil
+}
+
+// AutoVisibleArea removes any override set by SetVisibleArea(). The visible
+// area will be detected automatically.
+func (tv *Television) AutoVisibleArea() {
+	tv.state.resizer.override = extent{}
+	tv.state.resizer.proposed = extent{}
+	tv.state.resizer.proposedCount = 0
+}
+
+// GetVisibleArea returns the top scanline and the bottom scanline of the
+// visible area. The bottom scanline is not included in the visible area.
+func (tv *Television) GetVisibleArea() (int, int) {
+	return tv.state.top, tv.state.bottom
 }
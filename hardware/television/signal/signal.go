@@ -17,45 +17,221 @@
 // implementation.
 package signal
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // ColorSignal represents the signal that is sent from the VCS to the television.
+//
+// With the exception of VideoBlack, the value is the same as the value written
+// to one of the TIA color registers: the hue in the upper nibble and the
+// luminance in bits one to three. Bit zero is ignored by the TIA.
 type ColorSignal int
 
 // VideoBlack is the ColorSignal value that indicates no pixel is being output.
 const VideoBlack ColorSignal = -1
 
+// NumColors is the number of distinct ColorSignal values, not counting
+// VideoBlack. Because bit zero of a color register is ignored, pairs of
+// adjacent values produce the same color.
+const NumColors = 256
+
+// NewColorSignal returns the ColorSignal for the hue and luminance values.
+// Values out of range are masked.
+func NewColorSignal(hue uint8, lum uint8) ColorSignal {
+	return ColorSignal((hue&0x0f)<<4 | (lum&0x07)<<1)
+}
+
+// Hue returns the hue component of the color signal. The hue of VideoBlack is
+// zero.
+func (col ColorSignal) Hue() uint8 {
+	if col == VideoBlack {
+		return 0
+	}
+	return uint8(col>>4) & 0x0f
+}
+
+// Luminance returns the luminance component of the color signal. The
+// luminance of VideoBlack is zero.
+func (col ColorSignal) Luminance() uint8 {
+	if col == VideoBlack {
+		return 0
+	}
+	return uint8(col>>1) & 0x07
+}
+
+func (col ColorSignal) String() string {
+	if col == VideoBlack {
+		return "video black"
+	}
+	return fmt.Sprintf("%#02x (hue %d, lum %d)", int(col), col.Hue(), col.Luminance())
+}
+
 // SignalAttributes represents the data sent to the television.
-type SignalAttributes struct {
-	VSync     bool
-	VBlank    bool
-	CBurst    bool
-	HSync     bool
-	Pixel     ColorSignal
-	AudioData uint8
+//
+// Signals are sent to the television for every color clock so the type is
+// packed into a single integer to keep it cheap to copy and store. The
+// attributes should be accessed with the accessor functions.
+type SignalAttributes uint64
+
+// the layout of the SignalAttributes bits. the pixel is stored with an offset
+// of one so that VideoBlack can be stored in an unsigned field.
+const (
+	vsyncBit       = 0
+	vblankBit      = 1
+	cburstBit      = 2
+	hsyncBit       = 3
+	audioUpdateBit = 4
+
+	pixelShift      = 8
+	pixelMask       = 0x1ff
+	audioDataShift  = 17
+	audioLeftShift  = 25
+	audioRightShift = 33
+	audioMask       = 0xff
+	horizPosShift   = 41
+	horizPosMask    = 0x1ff
+	scanlineShift   = 50
+	scanlineMask    = 0xfff
+)
+
+func (a SignalAttributes) bit(b uint) bool {
+	return a&(1<<b) != 0
+}
+
+func (a *SignalAttributes) setBit(b uint, v bool) {
+	if v {
+		*a |= 1 << b
+	} else {
+		*a &^= 1 << b
+	}
+}
+
+func (a SignalAttributes) field(shift uint, mask uint64) uint64 {
+	return (uint64(a) >> shift) & mask
+}
+
+func (a *SignalAttributes) setField(shift uint, mask uint64, v uint64) {
+	*a = SignalAttributes((uint64(*a) &^ (mask << shift)) | ((v & mask) << shift))
+}
+
+// VSync returns the state of the VSYNC signal.
+func (a SignalAttributes) VSync() bool {
+	return a.bit(vsyncBit)
+}
+
+// SetVSync sets the state of the VSYNC signal.
+func (a *SignalAttributes) SetVSync(v bool) {
+	a.setBit(vsyncBit, v)
+}
+
+// VBlank returns the state of the VBLANK signal.
+func (a SignalAttributes) VBlank() bool {
+	return a.bit(vblankBit)
+}
+
+// SetVBlank sets the state of the VBLANK signal.
+func (a *SignalAttributes) SetVBlank(v bool) {
+	a.setBit(vblankBit, v)
+}
 
-	// whether the AudioData is valid. should be true only every 114th clock,
-	// which equates to 30Khz
-	AudioUpdate bool
+// CBurst returns the state of the color burst signal.
+func (a SignalAttributes) CBurst() bool {
+	return a.bit(cburstBit)
+}
+
+// SetCBurst sets the state of the color burst signal.
+func (a *SignalAttributes) SetCBurst(v bool) {
+	a.setBit(cburstBit, v)
+}
+
+// HSync returns the state of the HSYNC signal.
+func (a SignalAttributes) HSync() bool {
+	return a.bit(hsyncBit)
+}
+
+// SetHSync sets the state of the HSYNC signal.
+func (a *SignalAttributes) SetHSync(v bool) {
+	a.setBit(hsyncBit, v)
+}
+
+// Pixel returns the color signal.
+func (a SignalAttributes) Pixel() ColorSignal {
+	return ColorSignal(a.field(pixelShift, pixelMask)) - 1
+}
+
+// SetPixel sets the color signal.
+func (a *SignalAttributes) SetPixel(col ColorSignal) {
+	a.setField(pixelShift, pixelMask, uint64(col+1))
+}
+
+// AudioUpdate returns whether the audio data is valid. It should be true only
+// every 114th clock, which equates to 30Khz.
+func (a SignalAttributes) AudioUpdate() bool {
+	return a.bit(audioUpdateBit)
+}
+
+// SetAudioUpdate sets whether the audio data is valid.
+func (a *SignalAttributes) SetAudioUpdate(v bool) {
+	a.setBit(audioUpdateBit, v)
+}
+
+// AudioData returns the mono audio data.
+func (a SignalAttributes) AudioData() uint8 {
+	return uint8(a.field(audioDataShift, audioMask))
+}
+
+// SetAudioData sets the mono audio data.
+func (a *SignalAttributes) SetAudioData(v uint8) {
+	a.setField(audioDataShift, audioMask, uint64(v))
+}
+
+// AudioStereo returns the stereo equivalent of AudioData().
+func (a SignalAttributes) AudioStereo() (uint8, uint8) {
+	return uint8(a.field(audioLeftShift, audioMask)), uint8(a.field(audioRightShift, audioMask))
+}
+
+// SetAudioStereo sets the stereo equivalent of AudioData.
+func (a *SignalAttributes) SetAudioStereo(left uint8, right uint8) {
+	a.setField(audioLeftShift, audioMask, uint64(left))
+	a.setField(audioRightShift, audioMask, uint64(right))
+}
+
+// HorizPos returns the horizontal position on the screen this signal was
+// applied to. Added by the television implementation.
+func (a SignalAttributes) HorizPos() int {
+	return int(a.field(horizPosShift, horizPosMask))
+}
+
+// SetHorizPos sets the horizontal position of the signal.
+func (a *SignalAttributes) SetHorizPos(v int) {
+	a.setField(horizPosShift, horizPosMask, uint64(v))
+}
 
-	// the position on the screen this signal was applied to. added by the
-	// television implementation
-	HorizPos int
-	Scanline int
+// Scanline returns the scanline on the screen this signal was applied to.
+// Added by the television implementation.
+func (a SignalAttributes) Scanline() int {
+	return int(a.field(scanlineShift, scanlineMask))
+}
+
+// SetScanline sets the scanline of the signal.
+func (a *SignalAttributes) SetScanline(v int) {
+	a.setField(scanlineShift, scanlineMask, uint64(v))
 }
 
 func (a SignalAttributes) String() string {
 	s := strings.Builder{}
-	if a.VSync {
+	if a.VSync() {
 		s.WriteString("VSYNC ")
 	}
-	if a.VBlank {
+	if a.VBlank() {
 		s.WriteString("VBLANK ")
 	}
-	if a.CBurst {
+	if a.CBurst() {
 		s.WriteString("CBURST ")
 	}
-	if a.HSync {
+	if a.HSync() {
 		s.WriteString("HSYNC ")
 	}
 	return s.String()
@@ -70,12 +246,43 @@ const (
 	ReqFramenum StateReq = iota
 	ReqScanline
 	ReqHorizPos
+
+	// the number of scanlines in the most recently completed frame.
+	ReqLastFrameScanlines
+
+	// the top and bottom scanlines of the visible area of the screen, as
+	// detected by the television. the bottom scanline is not part of the
+	// visible area.
+	ReqVisibleTop
+	ReqVisibleBottom
+
+	// the number of frames since the television was reset that were generated
+	// by a valid VSYNC sequence.
+	ReqSyncedFramenum
 )
 
 // TelevisionTIA exposes only the functions required by the TIA.
 type TelevisionTIA interface {
 	Signal(SignalAttributes) error
 	GetState(StateReq) int
+
+	// SignalBatch is equivalent to calling Signal() for every entry in the
+	// slice, in order.
+	SignalBatch([]SignalAttributes) error
+
+	// PendingSignals tells the television that the SignalBatcher is holding
+	// signals that have not yet been sent. The television will ask for them
+	// with FlushPendingSignals() before its state is inspected.
+	PendingSignals(SignalBatcher)
+}
+
+// SignalBatcher implementations hold signals that have been generated but not
+// yet sent to the television.
+type SignalBatcher interface {
+	// FlushPendingSignals sends all pending signals to the television. Errors
+	// can not be returned to the caller and should instead be reported by the
+	// SignalBatcher at the next opportunity.
+	FlushPendingSignals()
 }
 
 // TelevisionSprite exposes only the functions required by the video sprites.
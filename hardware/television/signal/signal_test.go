@@ -0,0 +1,84 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package signal_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestSignalAttributes(t *testing.T) {
+	var sig signal.SignalAttributes
+
+	// zero value is a signal with no attributes set and a pixel of VideoBlack
+	test.Equate(t, int(sig.Pixel()), int(signal.VideoBlack))
+	test.Equate(t, sig.VSync(), false)
+
+	sig.SetVSync(true)
+	sig.SetHSync(true)
+	sig.SetPixel(255)
+	sig.SetAudioUpdate(true)
+	sig.SetAudioData(30)
+	sig.SetAudioStereo(12, 255)
+	sig.SetHorizPos(227)
+	sig.SetScanline(312)
+
+	test.Equate(t, sig.VSync(), true)
+	test.Equate(t, sig.VBlank(), false)
+	test.Equate(t, sig.CBurst(), false)
+	test.Equate(t, sig.HSync(), true)
+	test.Equate(t, int(sig.Pixel()), 255)
+	test.Equate(t, sig.AudioUpdate(), true)
+	test.Equate(t, int(sig.AudioData()), 30)
+	l, r := sig.AudioStereo()
+	test.Equate(t, int(l), 12)
+	test.Equate(t, int(r), 255)
+	test.Equate(t, sig.HorizPos(), 227)
+	test.Equate(t, sig.Scanline(), 312)
+
+	// changing one attribute does not affect the others
+	sig.SetHSync(false)
+	sig.SetPixel(signal.VideoBlack)
+	sig.SetScanline(0)
+
+	test.Equate(t, sig.VSync(), true)
+	test.Equate(t, sig.HSync(), false)
+	test.Equate(t, int(sig.Pixel()), int(signal.VideoBlack))
+	test.Equate(t, int(sig.AudioData()), 30)
+	test.Equate(t, sig.HorizPos(), 227)
+	test.Equate(t, sig.Scanline(), 0)
+}
+
+func TestColorSignal(t *testing.T) {
+	col := signal.ColorSignal(0x4e)
+	test.Equate(t, int(col.Hue()), 0x04)
+	test.Equate(t, int(col.Luminance()), 0x07)
+	test.Equate(t, int(signal.NewColorSignal(col.Hue(), col.Luminance())), 0x4e)
+
+	// bit zero is ignored
+	col = signal.ColorSignal(0x4f)
+	test.Equate(t, int(col.Hue()), 0x04)
+	test.Equate(t, int(col.Luminance()), 0x07)
+	test.Equate(t, int(signal.NewColorSignal(col.Hue(), col.Luminance())), 0x4e)
+
+	// out of range values are masked
+	test.Equate(t, int(signal.NewColorSignal(0x1f, 0x0f)), 0xfe)
+
+	test.Equate(t, int(signal.VideoBlack.Hue()), 0)
+	test.Equate(t, int(signal.VideoBlack.Luminance()), 0)
+}
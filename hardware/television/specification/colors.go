@@ -15,13 +15,21 @@
 
 package specification
 
-import "image/color"
+import (
+	"image/color"
 
-// PaletteNTSC is the collection of NTSC colours.
-var PaletteNTSC = []color.RGBA{}
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
 
-// PalettePAL is the collection of PAL colours.
-var PalettePAL = []color.RGBA{}
+// Palette is the collection of colors for a television specification, indexed
+// by signal.ColorSignal. Palette is an array rather than a slice so that a
+// copy of a Palette can not be used to alter the colors used by the emulation.
+type Palette [signal.NumColors]color.RGBA
+
+// the palettes used by the two specifications. use the Palette() function of
+// the Spec type to get a copy.
+var paletteNTSC Palette
+var palettePAL Palette
 
 // VideoBlack is the color produced by a television in the absence of a color
 // signal.
@@ -71,19 +79,19 @@ var pal32bit = []uint32{
 
 // convert the "raw" color values to the RGB components.
 func init() {
-	for _, col := range ntsc32bit {
+	for i, col := range ntsc32bit {
 		red, green, blue := byte((col&0xff0000)>>16), byte((col&0xff00)>>8), byte(col&0xff)
 
 		// repeat color twice in palette
-		PaletteNTSC = append(PaletteNTSC, color.RGBA{red, green, blue, 255})
-		PaletteNTSC = append(PaletteNTSC, color.RGBA{red, green, blue, 255})
+		paletteNTSC[i*2] = color.RGBA{red, green, blue, 255}
+		paletteNTSC[i*2+1] = color.RGBA{red, green, blue, 255}
 	}
 
-	for _, col := range pal32bit {
+	for i, col := range pal32bit {
 		red, green, blue := byte((col&0xff0000)>>16), byte((col&0xff00)>>8), byte(col&0xff)
 
 		// repeat color twice in palette
-		PalettePAL = append(PalettePAL, color.RGBA{red, green, blue, 255})
-		PalettePAL = append(PalettePAL, color.RGBA{red, green, blue, 255})
+		palettePAL[i*2] = color.RGBA{red, green, blue, 255}
+		palettePAL[i*2+1] = color.RGBA{red, green, blue, 255}
 	}
 }
@@ -0,0 +1,189 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package specification
+
+import (
+	"image/color"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// the composite signal is sampled four times per color clock. the NTSC colour
+// subcarrier (3.579545MHz) is the same frequency as the color clock so each
+// sample is a quarter turn of the subcarrier.
+const compositeSamples = 4
+
+// the lowpass filter applied to the demodulated chroma signal. the filter is
+// the result of convolving three box filters of compositeSamples width. each
+// box filter has a zero at the subcarrier frequency and at twice the
+// subcarrier frequency, meaning that areas of flat color are decoded without
+// error. the filter is wider than a single color clock, meaning that sharp
+// changes in luminance leak into the chroma signal, producing the color
+// fringing seen on a real television.
+var compositeChromaFilter = [...]float32{1, 3, 6, 10, 12, 12, 10, 6, 3, 1}
+
+const compositeChromaFilterSum = 64
+
+// Composite decodes a scanline of color signals as though they had been
+// passed through an NTSC composite video connection. Rather than looking up
+// each color in the palette, the palette color is encoded as a composite
+// signal and then decoded by a (simple) model of a television. The result is
+// that high frequency patterns in luminance produce colors that are not in
+// the palette. Some ROMs rely on this artifacting to produce extra colors.
+//
+// Chroma crawl on NTSC televisions is caused by the subcarrier phase changing
+// from one scanline to the next. The 2600 produces an integral number of
+// subcarrier cycles on each scanline (HorizClksScanline) so the artifact
+// pattern is stationary, as it is on real hardware.
+//
+// Composite is not safe to use from more than one goroutine.
+type Composite struct {
+	// the palette in the YIQ color space. the final entry is for VideoBlack
+	yiq [signal.NumColors + 1][3]float32
+
+	// the composite signal for the scanline being decoded and the signal
+	// demodulated by the in-phase and quadrature reference signals
+	samples []float32
+	demodI  []float32
+	demodQ  []float32
+}
+
+// the in-phase and quadrature reference signals for each sample in a color
+// clock.
+var compositeCos = [compositeSamples]float32{1, 0, -1, 0}
+var compositeSin = [compositeSamples]float32{0, 1, 0, -1}
+
+// NewComposite creates a composite decoder for the specification. Only the
+// NTSC specification is supported. PAL televisions average the chroma of
+// adjacent scanlines, which all but removes artifacting.
+func (spec *Spec) NewComposite() (*Composite, error) {
+	if spec.ID != "NTSC" || spec.palette == nil {
+		return nil, curated.Errorf("composite: not available for %s", spec.ID)
+	}
+
+	cmp := &Composite{
+		samples: make([]float32, HorizClksScanline*compositeSamples),
+		demodI:  make([]float32, HorizClksScanline*compositeSamples),
+		demodQ:  make([]float32, HorizClksScanline*compositeSamples),
+	}
+
+	for i := 0; i < signal.NumColors; i++ {
+		cmp.yiq[i] = rgbToYIQ(spec.palette[i])
+	}
+	cmp.yiq[signal.NumColors] = rgbToYIQ(videoBlack)
+
+	return cmp, nil
+}
+
+// Scanline decodes the color signals in the in slice and places the resulting
+// colors in the out slice. Color signals outside of the valid range are
+// treated as VideoBlack. The out slice should be at least as long as the in
+// slice and no more than HorizClksScanline in length.
+func (cmp *Composite) Scanline(in []signal.ColorSignal, out []color.RGBA) {
+	n := len(in) * compositeSamples
+	if n > len(cmp.samples) {
+		n = len(cmp.samples)
+	}
+	samples := cmp.samples[:n]
+	demodI := cmp.demodI[:n]
+	demodQ := cmp.demodQ[:n]
+
+	// encode
+	for i, col := range in[:n/compositeSamples] {
+		var c [3]float32
+		if col < 0 || col >= signal.NumColors {
+			c = cmp.yiq[signal.NumColors]
+		} else {
+			c = cmp.yiq[col]
+		}
+		for s := 0; s < compositeSamples; s++ {
+			j := i*compositeSamples + s
+			samples[j] = c[0] + c[1]*compositeCos[s] + c[2]*compositeSin[s]
+			demodI[j] = samples[j] * compositeCos[s]
+			demodQ[j] = samples[j] * compositeSin[s]
+		}
+	}
+
+	// clamp sample index to the extent of the scanline. the color at the
+	// edges of the scanline is treated as continuing indefinitely, so the
+	// index is moved by whole subcarrier cycles to keep the phase correct
+	clamp := func(j int) int {
+		for j < 0 {
+			j += compositeSamples
+		}
+		for j >= n {
+			j -= compositeSamples
+		}
+		return j
+	}
+
+	// decode. each color clock is decoded at the midpoint of its samples
+	for i := range in[:n/compositeSamples] {
+		m := i*compositeSamples + compositeSamples/2
+
+		// luminance is the average of one complete subcarrier cycle, which
+		// cancels the chroma signal completely
+		var y float32
+		for j := m - compositeSamples/2; j < m+compositeSamples/2; j++ {
+			y += samples[clamp(j)]
+		}
+		y /= compositeSamples
+
+		// chroma is the lowpass filtered demodulated signal. the factor of
+		// two restores the amplitude lost during demodulation
+		var ci, cq float32
+		o := m - len(compositeChromaFilter)/2
+		for k, w := range compositeChromaFilter {
+			j := clamp(o + k)
+			ci += w * demodI[j]
+			cq += w * demodQ[j]
+		}
+		ci = ci * 2 / compositeChromaFilterSum
+		cq = cq * 2 / compositeChromaFilterSum
+
+		out[i] = yiqToRGB(y, ci, cq)
+	}
+}
+
+func rgbToYIQ(col color.RGBA) [3]float32 {
+	r := float32(col.R)
+	g := float32(col.G)
+	b := float32(col.B)
+	return [3]float32{
+		0.299*r + 0.587*g + 0.114*b,
+		0.596*r - 0.274*g - 0.322*b,
+		0.211*r - 0.523*g + 0.312*b,
+	}
+}
+
+func yiqToRGB(y, i, q float32) color.RGBA {
+	clamp := func(v float32) uint8 {
+		if v < 0 {
+			return 0
+		}
+		if v > 255 {
+			return 255
+		}
+		return uint8(v + 0.5)
+	}
+	return color.RGBA{
+		R: clamp(y + 0.956*i + 0.621*q),
+		G: clamp(y - 0.272*i - 0.647*q),
+		B: clamp(y - 1.106*i + 1.703*q),
+		A: 255,
+	}
+}
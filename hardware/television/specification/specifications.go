@@ -24,12 +24,16 @@ import (
 )
 
 // SpecList is the list of specifications that the television may adopt.
-var SpecList = []string{"NTSC", "PAL"}
+var SpecList = []string{"NTSC", "PAL", "PAL60"}
 
 // Spec is used to define the two television specifications.
 type Spec struct {
-	ID     string
-	Colors []color.RGBA
+	ID string
+
+	// the colors used by the specification. the palette is not exported so
+	// that it can not be altered. use the Palette() and GetColor() functions
+	// instead
+	palette *Palette
 
 	// the number of scanlines the 2600 Programmer's guide recommends for the
 	// top/bottom parts of the screen:
@@ -87,10 +91,19 @@ func (spec *Spec) GetColor(col signal.ColorSignal) color.RGBA {
 	// we're usng the ColorSignal to index an array so we need to be extra
 	// careful to make sure the value is valid. if it's not a valid index then
 	// assume the intention was video black
-	if col == signal.VideoBlack {
+	if spec.palette == nil || col < 0 || col >= signal.NumColors {
 		return videoBlack
 	}
-	return spec.Colors[col]
+	return spec.palette[col]
+}
+
+// Palette returns a copy of the colors used by the specification. The palette
+// is indexed by signal.ColorSignal.
+func (spec *Spec) Palette() Palette {
+	if spec.palette == nil {
+		return Palette{}
+	}
+	return *spec.palette
 }
 
 // From the Stella Programmer's Guide:
@@ -115,10 +128,15 @@ var SpecNTSC Spec
 // SpecPAL is the specification for PAL television types.
 var SpecPAL Spec
 
+// SpecPAL60 is the specification for ROMs that use the PAL palette with NTSC
+// timing. Many homebrew ROMs are produced in this form because PAL televisions
+// will usually display a 60Hz signal.
+var SpecPAL60 Spec
+
 func init() {
 	SpecNTSC = Spec{
 		ID:                "NTSC",
-		Colors:            PaletteNTSC,
+		palette:           &paletteNTSC,
 		ScanlinesVSync:    3,
 		scanlinesVBlank:   37,
 		ScanlinesVisible:  192,
@@ -134,7 +152,7 @@ func init() {
 
 	SpecPAL = Spec{
 		ID:                "PAL",
-		Colors:            PalettePAL,
+		palette:           &palettePAL,
 		ScanlinesVSync:    3,
 		scanlinesVBlank:   45,
 		ScanlinesVisible:  228,
@@ -146,5 +164,12 @@ func init() {
 
 	SpecPAL.ScanlineTop = SpecPAL.scanlinesVBlank + SpecPAL.ScanlinesVSync
 	SpecPAL.ScanlineBottom = SpecPAL.ScanlinesTotal - SpecPAL.ScanlinesOverscan
-	SpecNTSC.IdealPixelsPerFrame = SpecPAL.ScanlinesTotal * HorizClksScanline
+	SpecPAL.IdealPixelsPerFrame = SpecPAL.ScanlinesTotal * HorizClksScanline
+
+	// PAL60 is the same as NTSC except for the palette. the aspect bias is the
+	// same as PAL because the image is being shown on a PAL television
+	SpecPAL60 = SpecNTSC
+	SpecPAL60.ID = "PAL60"
+	SpecPAL60.palette = &palettePAL
+	SpecPAL60.AspectBias = SpecPAL.AspectBias
 }
@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package specification_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestPalette(t *testing.T) {
+	spec := specification.SpecNTSC
+
+	// altering a copy of the palette does not alter the colors used by the
+	// specification
+	pal := spec.Palette()
+	c := pal[0x4e]
+	pal[0x4e] = color.RGBA{1, 2, 3, 4}
+	test.Equate(t, spec.GetColor(0x4e) == c, true)
+	test.Equate(t, spec.Palette()[0x4e] == c, true)
+
+	// bit zero of the color signal is ignored
+	test.Equate(t, spec.GetColor(0x4f) == c, true)
+
+	// invalid color signals are treated as video black
+	black := color.RGBA{0, 0, 0, 255}
+	test.Equate(t, spec.GetColor(signal.VideoBlack) == black, true)
+	test.Equate(t, spec.GetColor(signal.NumColors) == black, true)
+}
+
+func TestComposite(t *testing.T) {
+	_, err := specification.SpecPAL.NewComposite()
+	test.ExpectedFailure(t, err)
+
+	spec := specification.SpecNTSC
+	cmp, err := spec.NewComposite()
+	test.ExpectedSuccess(t, err)
+
+	in := make([]signal.ColorSignal, specification.HorizClksScanline)
+	out := make([]color.RGBA, specification.HorizClksScanline)
+
+	near := func(a, b color.RGBA) bool {
+		d := func(x, y uint8) bool {
+			if x > y {
+				return x-y <= 2
+			}
+			return y-x <= 2
+		}
+		return d(a.R, b.R) && d(a.G, b.G) && d(a.B, b.B)
+	}
+
+	// areas of flat color are decoded to (almost) exactly the palette color
+	for _, col := range []signal.ColorSignal{0x00, 0x0e, 0x46, 0x84, 0xc8, signal.VideoBlack} {
+		for i := range in {
+			in[i] = col
+		}
+		cmp.Scanline(in, out)
+		for i := range out {
+			if !near(out[i], spec.GetColor(col)) {
+				t.Fatalf("flat color %d decoded as %v at %d (expected %v)", int(col), out[i], i, spec.GetColor(col))
+			}
+		}
+	}
+
+	// alternating black and white pixels produce colors that are not grey
+	for i := range in {
+		if i%2 == 0 {
+			in[i] = 0x0e
+		} else {
+			in[i] = 0x00
+		}
+	}
+	cmp.Scanline(in, out)
+	c := out[specification.HorizClksScanline/2]
+	test.Equate(t, c.R == c.G && c.G == c.B, false)
+
+	// color fringing at the edge of a block of white. pixels away from the
+	// edge are unaffected
+	for i := range in {
+		if i < specification.HorizClksScanline/2 {
+			in[i] = 0x00
+		} else {
+			in[i] = 0x0e
+		}
+	}
+	cmp.Scanline(in, out)
+	c = out[specification.HorizClksScanline/2]
+	test.Equate(t, c.R == c.G && c.G == c.B, false)
+	test.Equate(t, near(out[10], spec.GetColor(0x00)), true)
+	test.Equate(t, near(out[specification.HorizClksScanline-10], spec.GetColor(0x0e)), true)
+}
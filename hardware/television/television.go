@@ -33,6 +33,7 @@ import (
 	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/television/signal"
 	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/logger"
 )
 
 // the number of additional lines over the NTSC spec that is allowed before the
@@ -46,16 +47,21 @@ const leadingFrames = 5
 const stabilityThreshold = 20
 
 type State struct {
-	// television specification (NTSC or PAL)
+	// television specification (NTSC, PAL or PAL60)
 	spec specification.Spec
 
 	// auto flag indicates that the tv type/specification should switch if it
 	// appears to be outside of the current spec.
 	//
 	// in practice this means that if auto is true then we start with the NTSC
-	// spec and move to PAL if the number of scanlines exceeds the NTSC maximum
+	// spec and move to PAL if the number of scanlines exceeds the NTSC maximum.
+	// if the number of scanlines does not exceed the NTSC maximum then we may
+	// move to PAL60 if the colors being used suggest that it is appropriate
 	auto bool
 
+	// detection of PAL60 ROMs. only used when auto is true
+	pal60 pal60
+
 	// state of the television
 	//	- the current horizontal position. the position where the next pixel will be
 	//  drawn. also used to check we're receiving the correct signals at the
@@ -65,6 +71,8 @@ type State struct {
 	frameNum int
 	//	- the current scanline number
 	scanline int
+	//  - the number of scanlines in the most recently completed frame
+	lastFrameScanlines int
 	//  - the current synced frame number. a synced frame is one which was
 	//  generated from a valid VSYNC/VBLANK sequence. we use this to detect:
 	//   * whether the image is "stable"
@@ -89,6 +97,13 @@ type State struct {
 
 	// frame resizer
 	resizer resizer
+
+	// odd/even field detection
+	interlace interlace
+
+	// the number of scanlines since the last VSYNC. only counted when a frame
+	// timeout has been set. see SetFrameTimeout()
+	unsyncedScanlines int
 }
 
 // Snapshot makes a copy of the television state.
@@ -107,10 +122,27 @@ func (s *State) GetState(request signal.StateReq) int {
 		return s.scanline
 	case signal.ReqHorizPos:
 		return s.horizPos - specification.HorizClksHBlank
+	case signal.ReqLastFrameScanlines:
+		return s.lastFrameScanlines
+	case signal.ReqVisibleTop:
+		return s.top
+	case signal.ReqVisibleBottom:
+		return s.bottom
+	case signal.ReqSyncedFramenum:
+		return s.syncedFrameNum
 	}
 	panic(fmt.Sprintf("television: unhandled tv state request (%v)", request))
 }
 
+// GetCoords returns the position of the television beam.
+func (s *State) GetCoords() Coordinates {
+	return Coordinates{
+		Frame:    s.frameNum,
+		Scanline: s.scanline,
+		Clock:    s.horizPos - specification.HorizClksHBlank,
+	}
+}
+
 // Television is a Television implementation of the Television interface. In all
 // honesty, it's most likely the only implementation required.
 type Television struct {
@@ -135,13 +167,53 @@ type Television struct {
 	// a single registered reflector
 	reflector ReflectionSynchronising
 
+	// how the television reacts to a frame without a valid VSYNC. see
+	// SetBadSync()
+	badSync BadSync
+
+	// the number of scanlines without VSYNC before Signal() times out and the
+	// function to call when it does. see SetFrameTimeout()
+	timeoutScanlines int
+	timeoutCallback  func() error
+
+	// the television has been paused. see Pause()
+	paused bool
+
 	state *State
 
 	// list of signals sent to pixel renderers since the beginning of the
 	// current frame
-	signals []signal.SignalAttributes
+	signals [MaxSignalHistory]signal.SignalAttributes
 	// the index to write the next signal
 	signalIdx int
+
+	// renderers and mixers can be muted. signals are still processed by the
+	// television but the information is not forwarded. see MuteRenderers()
+	// and MuteMixers()
+	mutedRenderers bool
+	mutedMixers    bool
+
+	// in no-render mode the television does not keep a signal history and
+	// does not consult any pixel renderers or the reflector. see
+	// SetNoRender()
+	noRender bool
+
+	// when recording is true the signals for the current frame are appended
+	// to record. at the start of a new frame record is moved to recorded. see
+	// SetFrameRecording()
+	recording bool
+	record    []signal.SignalAttributes
+	recorded  []signal.SignalAttributes
+
+	// copies of the signals array for the current and most recently
+	// completed frames. nil unless frame history has been turned on with
+	// SetFrameHistory()
+	history *frameHistory
+
+	// signals that have been generated but not yet sent to the television.
+	// the state of the television is out of date until they have been sent.
+	// see PendingSignals()
+	pending signal.SignalBatcher
 }
 
 // NewReference creates a new instance of the reference television type,
@@ -150,7 +222,6 @@ func NewTelevision(spec string) (*Television, error) {
 	tv := &Television{
 		reqSpecID: strings.ToUpper(spec),
 		state:     &State{},
-		signals:   make([]signal.SignalAttributes, MaxSignalHistory),
 	}
 
 	// set specification
@@ -175,6 +246,7 @@ func (tv *Television) String() string {
 
 // Snapshot makes a copy of the television state.
 func (tv *Television) Snapshot() *State {
+	tv.flushPending()
 	return tv.state.Snapshot()
 }
 
@@ -184,6 +256,10 @@ func (tv *Television) Plumb(s *State) {
 		return
 	}
 	tv.state = s
+	tv.pending = nil
+
+	// the signal history belongs to the state being replaced
+	tv.resetHistory()
 
 	// resize renderers to match current state
 	for _, r := range tv.renderers {
@@ -193,9 +269,14 @@ func (tv *Television) Plumb(s *State) {
 
 // AddPixelRenderer registers an implementation of PixelRenderer. Multiple
 // implemntations can be added.
+//
+// Pixel renderers are not available in no-render mode and will not be added.
 func (tv *Television) AddPixelRenderer(r PixelRenderer) {
+	if tv.noRender {
+		logger.Log("television", "pixel renderers are not available in no-render mode")
+		return
+	}
 	tv.renderers = append(tv.renderers, r)
-	tv.frameTriggers = append(tv.frameTriggers, r)
 }
 
 // AddFrameTrigger registers an implementation of FrameTrigger. Multiple
@@ -210,9 +291,57 @@ func (tv *Television) AddAudioMixer(m AudioMixer) {
 	tv.mixers = append(tv.mixers, m)
 }
 
+// RemovePixelRenderer removes a previously registered implementation of
+// PixelRenderer. The renderer's EndRendering() function is not called.
+//
+// A renderer that is later added again will not have been told of any changes
+// to the specification or to the visible area in the meantime. Use GetSpec()
+// and GetVisibleArea() to bring it up to date.
+func (tv *Television) RemovePixelRenderer(r PixelRenderer) {
+	for i := range tv.renderers {
+		if tv.renderers[i] == r {
+			// limiting the capacity of the first slice forces append() to
+			// allocate a new array. a range loop over the renderers that is
+			// in progress is therefore unaffected
+			tv.renderers = append(tv.renderers[:i:i], tv.renderers[i+1:]...)
+			return
+		}
+	}
+}
+
+// RemoveFrameTrigger removes a previously registered implementation of
+// FrameTrigger. It is safe to call from the FrameTrigger's NewFrame()
+// function.
+func (tv *Television) RemoveFrameTrigger(f FrameTrigger) {
+	for i := range tv.frameTriggers {
+		if tv.frameTriggers[i] == f {
+			tv.frameTriggers = append(tv.frameTriggers[:i:i], tv.frameTriggers[i+1:]...)
+			return
+		}
+	}
+}
+
+// RemoveAudioMixer removes a previously registered implementation of
+// AudioMixer. The mixer's EndMixing() function is not called.
+func (tv *Television) RemoveAudioMixer(m AudioMixer) {
+	for i := range tv.mixers {
+		if tv.mixers[i] == m {
+			tv.mixers = append(tv.mixers[:i:i], tv.mixers[i+1:]...)
+			return
+		}
+	}
+}
+
 // AddReflector registers an implementation of ReflectionSynchronising. Only
 // one can be added. Subsequence calls replaces existing implementations.
+//
+// Reflection is not available in no-render mode and the reflector will not be
+// added.
 func (tv *Television) AddReflector(r ReflectionSynchronising) {
+	if tv.noRender {
+		logger.Log("television", "reflection is not available in no-render mode")
+		return
+	}
 	tv.reflector = r
 }
 
@@ -229,9 +358,16 @@ func (tv *Television) Reset() error {
 	tv.state.horizPos = 0
 	tv.state.frameNum = 0
 	tv.state.scanline = 0
+	tv.state.lastFrameScanlines = 0
 	tv.state.syncedFrameNum = 0
 	tv.state.vsyncCount = 0
-	tv.state.lastSignal = signal.SignalAttributes{}
+	tv.state.lastSignal = 0
+	tv.state.interlace.reset()
+	tv.state.pal60.reset()
+
+	tv.record = tv.record[:0]
+	tv.recorded = tv.recorded[:0]
+	tv.resetHistory()
 
 	for _, r := range tv.renderers {
 		_ = r.Resize(tv.state.spec, tv.state.top, tv.state.bottom-tv.state.top)
@@ -263,23 +399,137 @@ func (tv *Television) End() error {
 	return err
 }
 
-// Signal updates the current state of the television.
-func (tv *Television) Signal(sig signal.SignalAttributes) error {
-	// mix audio before we do anything else
-	if sig.AudioUpdate {
-		for _, m := range tv.mixers {
-			err := m.SetAudio(sig.AudioData)
+// SignalBatch updates the current state of the television with every signal in
+// the slice, in order.
+func (tv *Television) SignalBatch(sigs []signal.SignalAttributes) error {
+	// the signals being sent are the pending signals so there's no need to
+	// ask for them again
+	tv.pending = nil
+
+	// a BadSyncError or FrameTimeoutError does not prevent the signal from
+	// being processed so the rest of the batch is sent before the error is
+	// returned
+	var syncErr error
+
+	mixing := !tv.mutedMixers && len(tv.mixers) > 0
+
+	for i := range sigs {
+		if mixing && sigs[i].AudioUpdate() {
+			err := tv.mixAudio(sigs[i])
 			if err != nil {
 				return err
 			}
 		}
+
+		err := tv.signal(sigs[i])
+		if err != nil {
+			if !curated.Is(err, BadSyncError) && !curated.Is(err, FrameTimeoutError) {
+				return err
+			}
+			syncErr = err
+		}
+
+		tv.lmtr.checkPixel()
+	}
+
+	// when the limiter is working at the pixel scale the pixels of the batch
+	// are sent to the renderers together at the end of the batch, rather
+	// than one at a time
+	if tv.lmtr.scale == scalePixel && !tv.noRender {
+		err := tv.setPendingPixels()
+		if err != nil {
+			return err
+		}
+	}
+
+	return syncErr
+}
+
+// PendingSignals implements the signal.TelevisionTIA interface.
+func (tv *Television) PendingSignals(b signal.SignalBatcher) {
+	tv.pending = b
+}
+
+// flushPending asks for any pending signals. should be called before any
+// state is returned to the caller.
+func (tv *Television) flushPending() {
+	if tv.pending == nil {
+		return
+	}
+	p := tv.pending
+	tv.pending = nil
+	p.FlushPendingSignals()
+}
+
+// Signal updates the current state of the television.
+func (tv *Television) Signal(sig signal.SignalAttributes) error {
+	// mix audio before we do anything else
+	if sig.AudioUpdate() && !tv.mutedMixers {
+		err := tv.mixAudio(sig)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := tv.signal(sig)
+	if err != nil && !curated.Is(err, BadSyncError) && !curated.Is(err, FrameTimeoutError) {
+		return err
+	}
+
+	if tv.lmtr.scale == scalePixel && !tv.noRender {
+		perr := tv.setPendingPixels()
+		if perr != nil {
+			return perr
+		}
+	}
+
+	tv.lmtr.checkPixel()
+
+	return err
+}
+
+// send audio data in the signal to all mixers.
+func (tv *Television) mixAudio(sig signal.SignalAttributes) error {
+	for _, m := range tv.mixers {
+		var err error
+		if s, ok := m.(StereoMixer); ok {
+			err = s.SetStereo(sig.AudioStereo())
+		} else {
+			err = m.SetAudio(sig.AudioData())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signal is the part of Signal() and SignalBatch() that is the same for every
+// signal. it does not mix audio, does not send pixels to the renderers when
+// the limiter is working at the pixel scale and does not check the limiter.
+// a BadSyncError or FrameTimeoutError is returned after the signal has been
+// processed.
+func (tv *Television) signal(sig signal.SignalAttributes) error {
+	// record signal as it was received
+	if tv.recording {
+		tv.record = append(tv.record, sig)
 	}
 
 	// examine signal for resizing possibility
 	tv.state.resizer.examine(tv, sig)
 
+	// examine signal for PAL60 possibility. only while the specification can
+	// still change
+	if tv.state.auto && tv.state.syncedFrameNum > leadingFrames && tv.state.syncedFrameNum < stabilityThreshold {
+		tv.state.pal60.tick(sig)
+	}
+
+	// an unsynchronised frame in the BadSyncHalt mode or a frame timeout
+	var syncErr error
+
 	// a Signal() is by definition a new color clock. increase the horizontal count
 	tv.state.horizPos++
+	tv.state.interlace.tick()
 
 	// once we reach the scanline's back-porch we'll reset the horizPos counter
 	// and wait for the HSYNC signal. we do this so that the front-porch and
@@ -292,8 +542,16 @@ func (tv *Television) Signal(sig signal.SignalAttributes) error {
 		// bump scanline counter
 		tv.state.scanline++
 
+		// the error is returned once the signal has been processed
+		syncErr = tv.frameTimeout()
+
 		// reached end of screen without synchronisation. fly-back naturally.
-		if tv.state.scanline > tv.state.spec.ScanlinesTotal {
+		if tv.state.scanline > tv.state.spec.ScanlinesTotal+tv.state.interlace.extraScanlines() {
+			// the error is returned once the signal has been processed
+			if syncErr == nil {
+				syncErr = tv.badSyncError()
+			}
+
 			err := tv.newFrame(false)
 			if err != nil {
 				return err
@@ -310,10 +568,12 @@ func (tv *Television) Signal(sig signal.SignalAttributes) error {
 	// check vsync signal at the time of the flyback
 	//
 	// !!TODO: replace VSYNC signal with extended HSYNC signal
-	if sig.VSync && !tv.state.lastSignal.VSync {
+	if sig.VSync() && !tv.state.lastSignal.VSync() {
 		tv.state.vsyncCount = 0
-	} else if !sig.VSync && tv.state.lastSignal.VSync {
-		if tv.state.vsyncCount > 0 {
+		tv.state.interlace.vsync(tv.state.horizPos)
+	} else if !sig.VSync() && tv.state.lastSignal.VSync() {
+		if tv.state.vsyncCount > 0 && tv.acceptVSync() {
+			tv.state.unsyncedScanlines = 0
 			err := tv.newFrame(true)
 			if err != nil {
 				return err
@@ -326,28 +586,33 @@ func (tv *Television) Signal(sig signal.SignalAttributes) error {
 	// making sure we're at the correct horizPos value.  if horizPos doesn't
 	// equal 16 at the front of the HSYNC or 36 at then back of the HSYNC, then
 	// it indicates that the RSYNC register was used last scanline.
-	if sig.HSync && !tv.state.lastSignal.HSync {
+	if sig.HSync() && !tv.state.lastSignal.HSync() {
 		tv.state.horizPos = 16
 
 		// count vsync lines at start of hsync
-		if sig.VSync || tv.state.lastSignal.VSync {
+		if sig.VSync() || tv.state.lastSignal.VSync() {
 			tv.state.vsyncCount++
 		}
 	}
-	if !sig.HSync && tv.state.lastSignal.HSync {
+	if !sig.HSync() && tv.state.lastSignal.HSync() {
 		tv.state.horizPos = 36
 	}
 
 	// doing nothing with CBURST signal
 
 	// augment television signal before sending to pixel renderer
-	sig.HorizPos = tv.state.horizPos
-	sig.Scanline = tv.state.scanline
+	sig.SetHorizPos(tv.state.horizPos)
+	sig.SetScanline(tv.state.scanline)
 
 	// record the current signal settings so they can be used for reference
 	// during the next call to Signal()
 	tv.state.lastSignal = sig
 
+	// nothing more to do in no-render mode
+	if tv.noRender {
+		return syncErr
+	}
+
 	// record signal history
 	if tv.signalIdx >= MaxSignalHistory {
 		err := tv.setPendingPixels()
@@ -358,24 +623,22 @@ func (tv *Television) Signal(sig signal.SignalAttributes) error {
 	tv.signals[tv.signalIdx] = sig
 	tv.signalIdx++
 
-	if tv.lmtr.scale == scalePixel {
-		err := tv.setPendingPixels()
-		if err != nil {
-			return err
-		}
-	}
-
-	tv.lmtr.checkPixel()
-
-	return nil
+	return syncErr
 }
 
 func (tv *Television) newScanline() error {
+	if tv.noRender {
+		tv.lmtr.checkScanline()
+		return nil
+	}
+
 	// notify renderers of new scanline
-	for _, r := range tv.renderers {
-		err := r.NewScanline(tv.state.scanline)
-		if err != nil {
-			return err
+	if !tv.mutedRenderers {
+		for _, r := range tv.renderers {
+			err := r.NewScanline(tv.state.scanline)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -392,6 +655,12 @@ func (tv *Television) newScanline() error {
 }
 
 func (tv *Television) newFrame(synced bool) error {
+	// note the specification and visible area so we can tell renderers if
+	// there has been a change
+	specID := tv.state.spec.ID
+	top := tv.state.top
+	bottom := tv.state.bottom
+
 	// a synced frame is one which was generated from a valid VSYNC/VBLANK sequence
 	if tv.state.syncedFrame {
 		tv.state.syncedFrameNum++
@@ -407,6 +676,15 @@ func (tv *Television) newFrame(synced bool) error {
 		}
 	}
 
+	// the last opportunity for a specification change. if we're still using
+	// the NTSC specification then check whether the ROM is PAL60
+	if tv.state.auto && tv.state.syncedFrameNum == stabilityThreshold-1 {
+		if tv.state.spec.ID == specification.SpecNTSC.ID && tv.state.pal60.isPAL60() {
+			logger.Log("television", "switching to PAL60")
+			_ = tv.SetSpec("PAL60")
+		}
+	}
+
 	// commit any resizing that maybe pending
 	err := tv.state.resizer.commit(tv)
 	if err != nil {
@@ -414,30 +692,68 @@ func (tv *Television) newFrame(synced bool) error {
 	}
 
 	// prepare for next frame
+	tv.state.lastFrameScanlines = tv.state.scanline
 	tv.state.frameNum++
 	tv.state.scanline = 0
 	tv.state.resizer.prepare(tv)
 	tv.state.syncedFrame = synced
+	tv.state.interlace.newFrame(synced)
+
+	info := FrameInfo{
+		FrameNum: tv.state.frameNum,
+		Stable:   tv.IsStable(),
+		Synced:   synced,
+		Spec:     tv.state.spec,
+		Resized:  specID != tv.state.spec.ID || top != tv.state.top || bottom != tv.state.bottom,
+	}
 
 	// set pixels for all renderers
-	if tv.lmtr.scale == scaleFrame {
+	if tv.lmtr.scale == scaleFrame && !tv.noRender {
 		err = tv.setPendingPixels()
 		if err != nil {
 			return err
 		}
 	}
 
+	// notify renderers of new frame
+	if !tv.mutedRenderers && !tv.noRender {
+		for _, r := range tv.renderers {
+			err = r.NewFrame(info)
+			if err != nil {
+				return err
+			}
+			if f, ok := r.(FieldRenderer); ok {
+				err = f.NewField(tv.state.interlace.current)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	// process all FrameTriggers
 	for _, r := range tv.frameTriggers {
-		err = r.NewFrame(tv.IsStable())
+		err = r.NewFrame(info)
 		if err != nil {
 			return err
 		}
 	}
 
+	// the frame history is complete. including any signals that have not
+	// been sent to the pixel renderers
+	if tv.history != nil {
+		tv.history.append(tv.signals[:tv.signalIdx])
+		tv.history.complete(tv.state.frameNum - 1)
+	}
+
 	// reset signal history for next frame
 	tv.signalIdx = 0
 
+	// the recording of the frame is complete
+	if tv.recording {
+		tv.recorded, tv.record = tv.record, tv.recorded[:0]
+	}
+
 	// reset reflector for new frame
 	if tv.reflector != nil {
 		tv.reflector.SyncFrame()
@@ -451,18 +767,48 @@ func (tv *Television) newFrame(synced bool) error {
 // setPendindPixels forwards all pixels in the signalHistory buffer (between
 // the *from and *to values) to all pixel renderers.
 func (tv *Television) setPendingPixels() error {
+	if tv.history != nil {
+		tv.history.append(tv.signals[:tv.signalIdx])
+	}
+
+	if tv.mutedRenderers {
+		tv.signalIdx = 0
+		return nil
+	}
+
+	// a single update event for all pending pixels. renderers will often
+	// take a lock in UpdatingPixels() so it is important not to do this for
+	// every pixel
+	//
+	// the list of renderers is noted so that every renderer that receives
+	// pixels also receives the start and end of the update event, even if the
+	// list changes
+	renderers := tv.renderers
+	for _, r := range renderers {
+		r.UpdatingPixels(true)
+	}
+	defer func() {
+		for _, r := range renderers {
+			r.UpdatingPixels(false)
+		}
+	}()
+
 	for i := 0; i < tv.signalIdx; i++ {
 		sig := tv.signals[i]
-		for _, r := range tv.renderers {
-			r.UpdatingPixels(true)
+		for _, r := range renderers {
 			err := r.SetPixel(sig, true)
 			if err != nil {
 				return curated.Errorf("television", err)
 			}
-			if tv.reflector != nil {
-				tv.reflector.SyncReflectionPixel(i)
+		}
+
+		// the reflection for the pixel is synchronised once all renderers
+		// have received the pixel
+		if tv.reflector != nil {
+			err := tv.reflector.SyncReflectionPixel(i)
+			if err != nil {
+				return curated.Errorf("television", err)
 			}
-			r.UpdatingPixels(false)
 		}
 	}
 
@@ -475,16 +821,26 @@ func (tv *Television) setPendingPixels() error {
 // IsStable returns true if the television thinks the image being sent by
 // the VCS is stable.
 func (tv *Television) IsStable() bool {
+	tv.flushPending()
 	return tv.state.syncedFrameNum >= stabilityThreshold
 }
 
 // Returns a copy of SignalAttributes for reference.
 func (tv *Television) GetLastSignal() signal.SignalAttributes {
+	tv.flushPending()
 	return tv.state.lastSignal
 }
 
+// GetField returns the field of the current frame. The value will be FieldNone
+// unless the VCS is producing an interlaced image.
+func (tv *Television) GetField() Field {
+	tv.flushPending()
+	return tv.state.interlace.current
+}
+
 // Returns state information.
 func (tv *Television) GetState(request signal.StateReq) int {
+	tv.flushPending()
 	return tv.state.GetState(request)
 }
 
@@ -497,16 +853,20 @@ func (tv *Television) SetSpec(spec string) error {
 	case "PAL":
 		tv.state.spec = specification.SpecPAL
 		tv.state.auto = false
+	case "PAL60":
+		tv.state.spec = specification.SpecPAL60
+		tv.state.auto = false
 	case "AUTO":
 		tv.state.spec = specification.SpecNTSC
 		tv.state.auto = true
+		tv.state.pal60.reset()
 	default:
 		return curated.Errorf("television: unsupported spec (%s)", spec)
 	}
 
 	tv.state.top = tv.state.spec.ScanlineTop
 	tv.state.bottom = tv.state.spec.ScanlineBottom
-	tv.state.resizer.prepare(tv)
+	tv.state.resizer.reset(tv)
 
 	for _, r := range tv.renderers {
 		err := r.Resize(tv.state.spec, tv.state.top, tv.state.bottom-tv.state.top)
@@ -518,6 +878,12 @@ func (tv *Television) SetSpec(spec string) error {
 	return nil
 }
 
+// GetCoords returns the current position of the television beam.
+func (tv *Television) GetCoords() Coordinates {
+	tv.flushPending()
+	return tv.state.GetCoords()
+}
+
 // GetReqSpecID returns the specification that was requested on creation.
 func (tv *Television) GetReqSpecID() string {
 	return tv.reqSpecID
@@ -526,20 +892,66 @@ func (tv *Television) GetReqSpecID() string {
 // Returns the television's current specification. Renderers should use
 // GetSpec() rather than keeping a private pointer to the specification.
 func (tv *Television) GetSpec() specification.Spec {
+	tv.flushPending()
 	return tv.state.spec
 }
 
-// Pause indicates that emulation has been paused. All renderers will pause
-// rendering and pending pixels pushed.
+// Pause indicates that emulation has been paused or resumed. Pending pixels
+// are pushed to the renderers when the emulation is paused and the frame
+// rate limiter is frozen so that the time spent paused does not affect the
+// measurement of the actual frame rate.
+//
+// Pixel renderers that implement the PausingRenderer interface and audio
+// mixers that implement the PausingMixer interface are notified on every
+// call, even if the paused state has not changed.
+//
+// Emulation front-ends should call Pause() rather than handle pausing
+// themselves.
 func (tv *Television) Pause(pause bool) error {
-	if pause {
-		return tv.setPendingPixels()
+	tv.flushPending()
+
+	if pause != tv.paused {
+		tv.paused = pause
+		tv.lmtr.pause(pause)
+	}
+
+	if pause && !tv.noRender {
+		err := tv.setPendingPixels()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, m := range tv.mixers {
+		if p, ok := m.(PausingMixer); ok {
+			err := p.Pause(pause)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, r := range tv.renderers {
+		if p, ok := r.(PausingRenderer); ok {
+			err := p.Pause(pause)
+			if err != nil {
+				return err
+			}
+		}
 	}
+
 	return nil
 }
 
+// IsPaused returns true if the television has been paused with Pause(true).
+func (tv *Television) IsPaused() bool {
+	return tv.paused
+}
+
 // ForceDraw pushes all pending pixels to the pixel renderers.
 func (tv *Television) ForceDraw() error {
+	tv.flushPending()
+
 	err := tv.setPendingPixels()
 	if err != nil {
 		return err
@@ -555,6 +967,54 @@ func (tv *Television) SetFPSCap(limit bool) bool {
 	return cap
 }
 
+// MuteRenderers prevents pixels and frame information from being forwarded to
+// the pixel renderers. The state of the television continues to be updated
+// as normal. Returns the setting as it was previously.
+//
+// Pending pixels are discarded when the renderers are muted.
+func (tv *Television) MuteRenderers(mute bool) bool {
+	muted := tv.mutedRenderers
+	tv.mutedRenderers = mute
+	return muted
+}
+
+// SetNoRender puts the television into no-render mode. This is intended for
+// throughput measurement and for headless applications that only require
+// the state of the television and the audio. No signal history is kept and
+// no pixel renderers or reflector are consulted. Frame triggers and audio
+// mixers are unaffected.
+//
+// No-render mode can not be entered if any pixel renderers or a reflector
+// has already been added. Leaving no-render mode is always possible.
+func (tv *Television) SetNoRender(noRender bool) error {
+	if noRender {
+		if len(tv.renderers) > 0 {
+			return curated.Errorf("television: no-render mode is not available with attached pixel renderers")
+		}
+		if tv.reflector != nil {
+			return curated.Errorf("television: no-render mode is not available with an attached reflector")
+		}
+	}
+	tv.noRender = noRender
+	tv.signalIdx = 0
+	tv.resetHistory()
+	return nil
+}
+
+// IsNoRender returns true if the television is in no-render mode. See
+// SetNoRender().
+func (tv *Television) IsNoRender() bool {
+	return tv.noRender
+}
+
+// MuteMixers prevents audio data from being forwarded to the audio mixers.
+// Returns the setting as it was previously.
+func (tv *Television) MuteMixers(mute bool) bool {
+	muted := tv.mutedMixers
+	tv.mutedMixers = mute
+	return muted
+}
+
 // Request the number frames per second. This overrides the frame rate of
 // the specification. A negative  value restores the spec's frame rate.
 func (tv *Television) SetFPS(fps float32) {
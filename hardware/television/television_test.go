@@ -18,7 +18,10 @@ package television_test
 import (
 	"testing"
 
+	"github.com/jetsetilly/gopher2600/curated"
 	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/test"
 )
 
 func TestNewTelevision(t *testing.T) {
@@ -42,3 +45,359 @@ func TestNewTelevision(t *testing.T) {
 		t.Errorf("'FOO' spec creation unexpectedly succeeded")
 	}
 }
+
+type batcher struct {
+	tv      *television.Television
+	pending []signal.SignalAttributes
+	flushed int
+}
+
+func (b *batcher) FlushPendingSignals() {
+	_ = b.tv.SignalBatch(b.pending)
+	b.pending = b.pending[:0]
+	b.flushed++
+}
+
+func TestSignalBatch(t *testing.T) {
+	single, _ := television.NewTelevision("NTSC")
+	batched, _ := television.NewTelevision("NTSC")
+
+	b := &batcher{tv: batched}
+
+	// enough signals for a couple of scanlines, with an HSYNC on each
+	var sigs []signal.SignalAttributes
+	for i := 0; i < 500; i++ {
+		var sig signal.SignalAttributes
+		sig.SetHSync(i%228 >= 16 && i%228 < 32)
+		sigs = append(sigs, sig)
+	}
+
+	for _, sig := range sigs {
+		err := single.Signal(sig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(b.pending) == 0 {
+			batched.PendingSignals(b)
+		}
+		b.pending = append(b.pending, sig)
+	}
+
+	// inspecting the state of the batched television should cause the
+	// pending signals to be flushed
+	for _, req := range []signal.StateReq{signal.ReqFramenum, signal.ReqScanline, signal.ReqHorizPos,
+		signal.ReqLastFrameScanlines, signal.ReqVisibleTop, signal.ReqVisibleBottom, signal.ReqSyncedFramenum} {
+		if single.GetState(req) != batched.GetState(req) {
+			t.Errorf("state mismatch for request %v: %d != %d", req, single.GetState(req), batched.GetState(req))
+		}
+	}
+
+	if b.flushed != 1 {
+		t.Errorf("expected pending signals to be flushed once, flushed %d times", b.flushed)
+	}
+}
+
+func TestCoordsDuringBatch(t *testing.T) {
+	single, _ := television.NewTelevision("NTSC")
+	batched, _ := television.NewTelevision("NTSC")
+
+	b := &batcher{tv: batched}
+
+	for i := 0; i < 300; i++ {
+		var sig signal.SignalAttributes
+		sig.SetHSync(i%228 >= 16 && i%228 < 32)
+		if err := single.Signal(sig); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(b.pending) == 0 {
+			batched.PendingSignals(b)
+		}
+		b.pending = append(b.pending, sig)
+	}
+
+	// the coordinates of the batched television should include the pending
+	// signals
+	if single.GetCoords() != batched.GetCoords() {
+		t.Errorf("coordinates mismatch: %v != %v", single.GetCoords(), batched.GetCoords())
+	}
+	if b.flushed != 1 {
+		t.Errorf("expected pending signals to be flushed once, flushed %d times", b.flushed)
+	}
+
+	// the specification can change as a result of the pending signals so
+	// GetSpec() also flushes
+	batched.PendingSignals(b)
+	b.pending = append(b.pending, signal.SignalAttributes(0))
+	_ = batched.GetSpec()
+	if b.flushed != 2 {
+		t.Errorf("expected pending signals to be flushed by GetSpec()")
+	}
+}
+
+type reflector struct{}
+
+func (r *reflector) SyncReflectionPixel(idx int) error {
+	return nil
+}
+
+func (r *reflector) SyncFrame() {}
+
+func TestNoRender(t *testing.T) {
+	normal, _ := television.NewTelevision("NTSC")
+	noRender, _ := television.NewTelevision("NTSC")
+
+	err := noRender.SetNoRender(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !noRender.IsNoRender() {
+		t.Errorf("television is not in no-render mode")
+	}
+
+	// reflection is unavailable in no-render mode
+	noRender.AddReflector(&reflector{})
+
+	// state of the television should be the same with or without rendering
+	for i := 0; i < 100000; i++ {
+		var sig signal.SignalAttributes
+		sig.SetHSync(i%228 >= 16 && i%228 < 32)
+		sig.SetVSync(i%(228*262) < 228*3)
+		if err := normal.Signal(sig); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := noRender.Signal(sig); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, req := range []signal.StateReq{signal.ReqFramenum, signal.ReqScanline, signal.ReqHorizPos,
+		signal.ReqLastFrameScanlines, signal.ReqVisibleTop, signal.ReqVisibleBottom, signal.ReqSyncedFramenum} {
+		if normal.GetState(req) != noRender.GetState(req) {
+			t.Errorf("state mismatch for request %v: %d != %d", req, normal.GetState(req), noRender.GetState(req))
+		}
+	}
+
+	// no-render mode can not be entered once a reflector has been added
+	normal.AddReflector(&reflector{})
+	err = normal.SetNoRender(true)
+	if err == nil {
+		t.Errorf("no-render mode unexpectedly entered with a reflector attached")
+	}
+}
+
+// signals for a single NTSC frame with VBLANK turned off between the top and
+// bottom scanlines. VSYNC is on for the last three scanlines so that the
+// television's scanline numbering matches the scanlines in the frame.
+func vblankFrame(top int, bottom int) []signal.SignalAttributes {
+	var sigs []signal.SignalAttributes
+	for sl := 0; sl < 262; sl++ {
+		for cl := 0; cl < 228; cl++ {
+			var sig signal.SignalAttributes
+			sig.SetVSync(sl >= 259)
+			sig.SetVBlank(sl < top || sl >= bottom)
+			sig.SetHSync(cl >= 16 && cl < 32)
+			sig.SetPixel(signal.VideoBlack)
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+func TestResizer(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+
+	expectArea := func(top int, bottom int) {
+		t.Helper()
+		if tp, bt := tv.GetVisibleArea(); tp != top || bt != bottom {
+			t.Errorf("expected visible area of %d to %d (got %d to %d)", top, bottom, tp, bt)
+		}
+	}
+
+	// visible area is detected from VBLANK
+	frame := vblankFrame(50, 210)
+	for i := 0; i < 20; i++ {
+		_ = tv.SignalBatch(frame)
+	}
+	expectArea(50, 210)
+
+	// alternating frames do not cause the screen to oscillate
+	other := vblankFrame(60, 230)
+	for i := 0; i < 40; i++ {
+		_ = tv.SignalBatch(other)
+		_ = tv.SignalBatch(frame)
+	}
+	expectArea(50, 230)
+
+	// override is used in preference to detected area
+	if err := tv.SetVisibleArea(30, 240); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = tv.SignalBatch(frame)
+	_ = tv.SignalBatch(frame)
+	expectArea(30, 240)
+
+	if err := tv.SetVisibleArea(100, 110); err == nil {
+		t.Errorf("expected error for small visible area")
+	}
+
+	// automatic detection shrinks the screen once the other frames have left
+	// the window
+	tv.AutoVisibleArea()
+	for i := 0; i < 60; i++ {
+		_ = tv.SignalBatch(frame)
+	}
+	expectArea(50, 210)
+}
+
+func TestFrameGeometry(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+
+	expect := func(req signal.StateReq, v int) {
+		t.Helper()
+		if got := tv.GetState(req); got != v {
+			t.Errorf("unexpected value for request %v: %d (expected %d)", req, got, v)
+		}
+	}
+
+	// no frame has been completed
+	expect(signal.ReqLastFrameScanlines, 0)
+	expect(signal.ReqSyncedFramenum, 0)
+
+	frame := vblankFrame(50, 210)
+	for i := 0; i < 20; i++ {
+		_ = tv.SignalBatch(frame)
+	}
+
+	expect(signal.ReqLastFrameScanlines, 262)
+	expect(signal.ReqVisibleTop, 50)
+	expect(signal.ReqVisibleBottom, 210)
+
+	// the frame is only completed by the signal that follows the VSYNC so
+	// the twentieth frame has not yet been completed. the first frame is not
+	// synced because the television had not seen a VSYNC before it
+	expect(signal.ReqFramenum, 19)
+	expect(signal.ReqSyncedFramenum, 18)
+
+	// geometry is reset along with the television
+	if err := tv.Reset(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expect(signal.ReqLastFrameScanlines, 0)
+	expect(signal.ReqSyncedFramenum, 0)
+}
+
+type frameInfoTrigger struct {
+	info []television.FrameInfo
+}
+
+func (trg *frameInfoTrigger) NewFrame(info television.FrameInfo) error {
+	trg.info = append(trg.info, info)
+	return nil
+}
+
+func TestFrameInfo(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	trg := &frameInfoTrigger{}
+	tv.AddFrameTrigger(trg)
+
+	frame := vblankFrame(50, 210)
+	for i := 0; i < 20; i++ {
+		_ = tv.SignalBatch(frame)
+	}
+
+	if len(trg.info) != 19 {
+		t.Fatalf("unexpected number of NewFrame() events: %d (expected 19)", len(trg.info))
+	}
+
+	resized := 0
+	for i, info := range trg.info {
+		if info.FrameNum != i+1 {
+			t.Errorf("unexpected frame number: %d (expected %d)", info.FrameNum, i+1)
+		}
+		if !info.Synced {
+			t.Errorf("frame %d should be synced", info.FrameNum)
+		}
+		if info.Spec.ID != "NTSC" {
+			t.Errorf("unexpected specification: %s", info.Spec.ID)
+		}
+		if info.Resized {
+			resized++
+		}
+	}
+
+	// the visible area of the frame is different to the default visible area
+	// of the specification so there should be exactly one resize event
+	if resized != 1 {
+		t.Errorf("unexpected number of resize events: %d (expected 1)", resized)
+	}
+}
+
+// stableTV returns an NTSC television that has become stable.
+func stableTV(t *testing.T, mode television.BadSync) *television.Television {
+	t.Helper()
+
+	tv, _ := television.NewTelevision("NTSC")
+	tv.SetBadSync(mode)
+
+	for i := 0; i < 25; i++ {
+		if err := tv.SignalBatch(test.Frame(262, true, signal.VideoBlack)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !tv.IsStable() {
+		t.Fatalf("television is not stable")
+	}
+
+	return tv
+}
+
+func TestBadSync(t *testing.T) {
+	mode, err := television.ParseBadSync("roll")
+	if err != nil || mode != television.BadSyncRoll || mode.String() != "ROLL" {
+		t.Errorf("unexpected result from ParseBadSync(): %v %v", mode, err)
+	}
+	if _, err := television.ParseBadSync("foo"); err == nil {
+		t.Errorf("expected error from ParseBadSync()")
+	}
+
+	// the default mode synchronises with every VSYNC
+	tv := stableTV(t, television.BadSyncFlyback)
+	_ = tv.SignalBatch(test.Frame(200, true, signal.VideoBlack))
+	_ = tv.SignalBatch(test.Frame(200, true, signal.VideoBlack))
+	if n := tv.GetState(signal.ReqLastFrameScanlines); n != 200 {
+		t.Errorf("unexpected frame length in FLYBACK mode: %d (expected 200)", n)
+	}
+
+	// in roll mode a VSYNC near the end of the frame is acted upon...
+	tv = stableTV(t, television.BadSyncRoll)
+	_ = tv.SignalBatch(test.Frame(250, true, signal.VideoBlack))
+	_ = tv.SignalBatch(test.Frame(200, true, signal.VideoBlack))
+	if n := tv.GetState(signal.ReqLastFrameScanlines); n != 250 {
+		t.Errorf("unexpected frame length in ROLL mode: %d (expected 250)", n)
+	}
+
+	// ...but an early VSYNC is ignored and the television flies back
+	// naturally
+	_ = tv.SignalBatch(test.Frame(200, true, signal.VideoBlack))
+	if n := tv.GetState(signal.ReqLastFrameScanlines); n != 263 {
+		t.Errorf("unexpected frame length in ROLL mode: %d (expected 263)", n)
+	}
+
+	// halt mode returns an error for a frame without VSYNC. the error does
+	// not prevent the rest of the batch from being processed
+	tv = stableTV(t, television.BadSyncHalt)
+	err = tv.SignalBatch(test.Frame(300, false, signal.VideoBlack))
+	if !curated.Is(err, television.BadSyncError) {
+		t.Errorf("expected BadSyncError in HALT mode (got %v)", err)
+	}
+	if n := tv.GetState(signal.ReqScanline); n != 300-263 {
+		t.Errorf("unexpected scanline after BadSyncError: %d (expected %d)", n, 300-263)
+	}
+
+	// no error before the television is stable
+	tv, _ = television.NewTelevision("NTSC")
+	tv.SetBadSync(television.BadSyncHalt)
+	if err := tv.SignalBatch(test.Frame(600, false, signal.VideoBlack)); err != nil {
+		t.Errorf("unexpected error before television is stable: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// FrameTimeoutError is returned by Signal() when the number of scanlines since
+// the last VSYNC exceeds the limit given to SetFrameTimeout() and no callback
+// function has been registered. The argument is the number of scanlines.
+const FrameTimeoutError = "television: no VSYNC for %d scanlines"
+
+// SetFrameTimeout sets the number of scanlines the television will accept
+// without a VSYNC signal before timing out. A value of zero, the default,
+// disables the timeout. Unlike the BadSync modes, the timeout applies whether
+// or not the television is stable.
+//
+// When the timeout occurs the callback function is called and any error it
+// returns is returned by Signal(). If the callback is nil then Signal() returns
+// a FrameTimeoutError. In both cases the signal will have been processed
+// normally.
+//
+// The count of scanlines restarts after a timeout so if the callback returns
+// nil it will be called again if the ROM continues without VSYNC.
+//
+// Intended for batch tools that need to abort a ROM that never synchronises.
+func (tv *Television) SetFrameTimeout(scanlines int, callback func() error) {
+	tv.timeoutScanlines = scanlines
+	tv.timeoutCallback = callback
+	tv.state.unsyncedScanlines = 0
+}
+
+// frameTimeout should be called at the start of every scanline. returns an
+// error if the frame has timed out.
+func (tv *Television) frameTimeout() error {
+	if tv.timeoutScanlines <= 0 {
+		return nil
+	}
+
+	tv.state.unsyncedScanlines++
+	if tv.state.unsyncedScanlines <= tv.timeoutScanlines {
+		return nil
+	}
+	tv.state.unsyncedScanlines = 0
+
+	if tv.timeoutCallback == nil {
+		return curated.Errorf(FrameTimeoutError, tv.timeoutScanlines)
+	}
+
+	err := tv.timeoutCallback()
+	if err != nil {
+		return curated.Errorf("television: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package television_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestFrameTimeout(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+	tv.SetFrameTimeout(300, nil)
+
+	// frames with VSYNC never time out
+	for i := 0; i < 5; i++ {
+		if err := tv.SignalBatch(test.Frame(262, true, signal.VideoBlack)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// the timeout happens on the 301st scanline without VSYNC
+	sigs := test.Frame(400, false, signal.VideoBlack)
+	timeout := -1
+	for i := range sigs {
+		err := tv.Signal(sigs[i])
+		if err != nil {
+			if !curated.Is(err, television.FrameTimeoutError) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if timeout != -1 {
+				t.Fatalf("more than one timeout")
+			}
+			timeout = i
+		}
+	}
+	if timeout/228 != 300 {
+		t.Errorf("unexpected timeout scanline: %d (expected 300)", timeout/228)
+	}
+
+	// a timeout of zero disables the timeout
+	tv.SetFrameTimeout(0, nil)
+	if err := tv.SignalBatch(test.Frame(1000, false, signal.VideoBlack)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFrameTimeoutCallback(t *testing.T) {
+	tv, _ := television.NewTelevision("NTSC")
+
+	calls := 0
+	tv.SetFrameTimeout(100, func() error {
+		calls++
+		return nil
+	})
+
+	// the callback is called every 101 scanlines without VSYNC
+	if err := tv.SignalBatch(test.Frame(500, false, signal.VideoBlack)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("unexpected number of timeouts: %d (expected 4)", calls)
+	}
+
+	// an error from the callback is returned by Signal()
+	tv.SetFrameTimeout(100, func() error {
+		return errors.New("abort")
+	})
+	err := tv.SignalBatch(test.Frame(500, false, signal.VideoBlack))
+	if err == nil || err.Error() != "television: abort" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
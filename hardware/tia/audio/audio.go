@@ -16,7 +16,10 @@
 package audio
 
 import (
+	"math"
 	"strings"
+
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
 )
 
 // SampleFreq represents the number of samples generated per second. This is
@@ -28,6 +31,8 @@ const SampleFreq = 31403
 //
 // https://raw.githubusercontent.com/alekmaul/stella/master/emucore/TIASound.c
 type Audio struct {
+	prefs *preferences.Preferences
+
 	// clock114 is so called because of the observation that the 30Khz
 	// reference frequency described in the Stella Programmer's Guide is
 	// generated from the 3.58Mhz clock divided by 114, giving a sample
@@ -44,11 +49,18 @@ type Audio struct {
 	// completely independent and can be operated simultaneously [...]"
 	channel0 channel
 	channel1 channel
+
+	// the name of the audio register written to during the most recent video
+	// cycle. empty if no audio register was written to. the TIA resets the
+	// field at the start of every video cycle
+	LastWrite string
 }
 
 // NewAudio is the preferred method of initialisation for the Audio sub-system.
-func NewAudio() *Audio {
-	return &Audio{}
+func NewAudio(prefs *preferences.Preferences) *Audio {
+	return &Audio{
+		prefs: prefs,
+	}
 }
 
 // Snapshot creates a copy of the TIA Audio sub-system in its current state.
@@ -102,3 +114,74 @@ func (au *Audio) Mix() (bool, uint8) {
 	// !!TODO: simulate analogue sound generation
 	return true, (au.channel0.actualVol + au.channel1.actualVol) << 2
 }
+
+// Stereo mixes the two VCS audio channels into a left and right value,
+// according to the pan preferences. Should only be called after Mix() has
+// indicated that the sound has been updated.
+//
+// When both channels are centred the left and right values are the same as the
+// single value returned by Mix().
+func (au *Audio) Stereo() (uint8, uint8) {
+	pan0 := panFixed(au.prefs.AudioPan0.Get().(float64))
+	pan1 := panFixed(au.prefs.AudioPan1.Get().(float64))
+	return stereo(au.channel0.actualVol, au.channel1.actualVol, pan0, pan1)
+}
+
+// stereo mixing is performed with fixed point arithmetic so that the output
+// is identical on every platform. a gain of panOne is full volume.
+const (
+	panShift = 8
+	panOne   = 1 << panShift
+)
+
+// panFixed converts a pan preference to fixed point. the preference is a
+// floating point value but the conversion is exact for every value that is a
+// multiple of 1/panOne, and is otherwise rounded in the same way on every
+// platform.
+func panFixed(pan float64) int32 {
+	if pan <= -1.0 {
+		return -panOne
+	}
+	if pan >= 1.0 {
+		return panOne
+	}
+	return int32(math.Round(pan * panOne))
+}
+
+// stereo mixes two volume values into a left and right value using pan values
+// that have been converted with panFixed().
+func stereo(vol0 uint8, vol1 uint8, pan0 int32, pan1 int32) (uint8, uint8) {
+	left := int32(vol0)*leftGain(pan0) + int32(vol1)*leftGain(pan1)
+	right := int32(vol0)*rightGain(pan0) + int32(vol1)*rightGain(pan1)
+
+	// shift of 2 (or multiplication by 4) for the same reason as in Mix().
+	// half of panOne is added before the fixed point shift so that the result
+	// is rounded to the nearest value
+	left = (left<<2 + panOne/2) >> panShift
+	right = (right<<2 + panOne/2) >> panShift
+
+	return uint8(left), uint8(right)
+}
+
+// panning is linear. a centred channel is output at full volume on both sides.
+// as the channel is panned to one side the volume on the other side is
+// reduced until it is silent.
+func leftGain(pan int32) int32 {
+	if pan <= 0 {
+		return panOne
+	}
+	if pan >= panOne {
+		return 0
+	}
+	return panOne - pan
+}
+
+func rightGain(pan int32) int32 {
+	if pan >= 0 {
+		return panOne
+	}
+	if pan <= -panOne {
+		return 0
+	}
+	return panOne + pan
+}
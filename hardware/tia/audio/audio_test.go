@@ -0,0 +1,97 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package audio
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+)
+
+// the digest of the audio produced by generateAudio(). the audio path uses
+// only integer arithmetic so this value must be the same on every platform. if
+// the value needs to change because the audio emulation has been improved
+// then the audio digests in the regression database will need to be
+// regenerated.
+const expectedAudioDigest = "b9ac17e7dbe0ac4655cc762b50b330392fbe0437"
+
+// generateAudio produces audio for every AUDC value, with a selection of AUDF
+// and AUDV values and pan positions.
+func generateAudio() []byte {
+	au := NewAudio(nil)
+
+	pans := []int32{panFixed(-1.0), panFixed(-0.3), panFixed(0.0), panFixed(0.6), panFixed(1.0)}
+
+	data := make([]byte, 0, 1<<20)
+	write := func(name string, value uint8) {
+		au.UpdateRegisters(bus.ChipData{Name: name, Value: value})
+	}
+
+	for c := uint8(0); c < 16; c++ {
+		for _, f := range []uint8{0, 7, 31} {
+			write("AUDC0", c)
+			write("AUDC1", 15-c)
+			write("AUDF0", f)
+			write("AUDF1", 31-f)
+			write("AUDV0", 15-c)
+			write("AUDV1", c)
+
+			for i := 0; i < 20000; i++ {
+				ok, m := au.Mix()
+				if !ok {
+					continue
+				}
+				data = append(data, m)
+				p := pans[i%len(pans)]
+				l, r := stereo(au.channel0.actualVol, au.channel1.actualVol, p, -p)
+				data = append(data, l, r)
+			}
+		}
+	}
+
+	return data
+}
+
+func TestAudioDigest(t *testing.T) {
+	d := fmt.Sprintf("%x", sha1.Sum(generateAudio()))
+	if d != expectedAudioDigest {
+		t.Errorf("audio digest: got %s, wanted %s", d, expectedAudioDigest)
+	}
+}
+
+func TestLastWrite(t *testing.T) {
+	au := NewAudio(nil)
+	if au.LastWrite != "" {
+		t.Errorf("last write: got %s, wanted nothing", au.LastWrite)
+	}
+
+	if au.UpdateRegisters(bus.ChipData{Name: "AUDV0", Value: 0x0f}) {
+		t.Errorf("AUDV0 not serviced by audio")
+	}
+	if au.LastWrite != "AUDV0" {
+		t.Errorf("last write: got %s, wanted AUDV0", au.LastWrite)
+	}
+
+	// non-audio registers do not change the last write field
+	if !au.UpdateRegisters(bus.ChipData{Name: "COLUP0", Value: 0x0f}) {
+		t.Errorf("COLUP0 serviced by audio")
+	}
+	if au.LastWrite != "AUDV0" {
+		t.Errorf("last write: got %s, wanted AUDV0", au.LastWrite)
+	}
+}
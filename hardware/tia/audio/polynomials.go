@@ -42,8 +42,15 @@ var div31 = [31]uint8{0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 // generator".
 var poly9bit [511]uint16
 
+// the table is generated with a fixed seed so that the audio output is the
+// same every time the program is run, regardless of how the global source of
+// the math/rand package has been seeded. the value is the same as the
+// default seed of the global source.
+const poly9bitSeed = 1
+
 func init() {
+	r := rand.New(rand.NewSource(poly9bitSeed))
 	for i := 0; i < len(poly9bit); i++ {
-		poly9bit[i] = uint16(rand.Int() & 0x01)
+		poly9bit[i] = uint16(r.Int() & 0x01)
 	}
 }
@@ -39,6 +39,8 @@ func (au *Audio) UpdateRegisters(data bus.ChipData) bool {
 		return true
 	}
 
+	au.LastWrite = data.Name
+
 	au.channel0.reactAUDCx()
 	au.channel1.reactAUDCx()
 
@@ -0,0 +1,96 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package audio
+
+import (
+	"testing"
+)
+
+func TestPanGain(t *testing.T) {
+	tests := []struct {
+		pan   float64
+		left  int32
+		right int32
+	}{
+		{-1.0, panOne, 0},
+		{-0.5, panOne, panOne / 2},
+		{0.0, panOne, panOne},
+		{0.5, panOne / 2, panOne},
+		{1.0, 0, panOne},
+
+		// out of range values are clamped
+		{-2.0, panOne, 0},
+		{2.0, 0, panOne},
+	}
+
+	for _, tt := range tests {
+		if g := leftGain(panFixed(tt.pan)); g != tt.left {
+			t.Errorf("left gain for pan %.2f: got %d, wanted %d", tt.pan, g, tt.left)
+		}
+		if g := rightGain(panFixed(tt.pan)); g != tt.right {
+			t.Errorf("right gain for pan %.2f: got %d, wanted %d", tt.pan, g, tt.right)
+		}
+	}
+}
+
+func TestStereoCentred(t *testing.T) {
+	// when both channels are centred the stereo output is the same as the
+	// mono output of Mix()
+	for v0 := uint8(0); v0 < 16; v0++ {
+		for v1 := uint8(0); v1 < 16; v1++ {
+			l, r := stereo(v0, v1, 0, 0)
+			m := (v0 + v1) << 2
+			if l != m || r != m {
+				t.Errorf("centred stereo for volumes %d, %d: got %d, %d, wanted %d", v0, v1, l, r, m)
+			}
+		}
+	}
+}
+
+func TestStereoRounding(t *testing.T) {
+	tests := []struct {
+		vol0  uint8
+		vol1  uint8
+		pan0  float64
+		pan1  float64
+		left  uint8
+		right uint8
+	}{
+		// stereo mod
+		{15, 15, -1.0, 1.0, 60, 60},
+		{15, 0, -1.0, 1.0, 60, 0},
+
+		// 15 * 0.5 * 4 = 30
+		{15, 0, 0.5, 0.0, 30, 60},
+
+		// 15 * 0.75 * 4 = 45
+		{15, 0, -0.25, 0.0, 60, 45},
+
+		// 1 * 0.3 * 4 = 1.2 (rounded down)
+		{1, 0, 0.7, 0.0, 1, 4},
+
+		// 1 * 0.4 * 4 = 1.6 (rounded up)
+		{1, 0, 0.6, 0.0, 2, 4},
+	}
+
+	for _, tt := range tests {
+		l, r := stereo(tt.vol0, tt.vol1, panFixed(tt.pan0), panFixed(tt.pan1))
+		if l != tt.left || r != tt.right {
+			t.Errorf("stereo for volumes %d, %d and pans %.2f, %.2f: got %d, %d, wanted %d, %d",
+				tt.vol0, tt.vol1, tt.pan0, tt.pan1, l, r, tt.left, tt.right)
+		}
+	}
+}
@@ -24,9 +24,10 @@
 //
 // The delay package is a lot simpler and consequently a lot more efficient.
 //
-// The only element in the package is the Event type. An Event type instance
+// The main element in the package is the Event type. An Event type instance
 // represents a single future change to the TIA system, which will take place
-// after the stated number of cycles.
+// after the stated number of cycles. The Future type describes an active Event
+// and is intended for use by debuggers.
 //
 // To effectively emulate the electronics of the TIA these Events can be
 // dropped, rescheduled or premepted almost at will.
@@ -15,6 +15,36 @@
 
 package delay
 
+import "fmt"
+
+// Future describes an active Event. Intended to help debuggers show the
+// pending changes to the TIA system.
+type Future struct {
+	// the TIA component the event belongs to (eg. "Player 0")
+	Label string
+
+	// a short description of what will happen when the event concludes
+	Description string
+
+	// the number of cycles remaining and the total number of cycles the
+	// event was scheduled for
+	Remaining int
+	Initial   int
+
+	// the value that will be applied when the event concludes
+	Value uint8
+
+	Paused bool
+}
+
+func (f Future) String() string {
+	s := fmt.Sprintf("%s: %s in %d (value %02x)", f.Label, f.Description, f.Remaining, f.Value)
+	if f.Paused {
+		s = fmt.Sprintf("%s [paused]", s)
+	}
+	return s
+}
+
 // Event represents something that will occur in the future.
 type Event struct {
 	initial   int
@@ -96,3 +126,29 @@ func (e *Event) AboutToEnd() bool {
 func (e *Event) IsActive() bool {
 	return e.remaining > 0
 }
+
+// Future returns a description of the event for the given component label and
+// event description. The boolean return value is false if the event is not
+// active.
+func (e *Event) Future(label string, description string) (Future, bool) {
+	if !e.IsActive() {
+		return Future{}, false
+	}
+	return Future{
+		Label:       label,
+		Description: description,
+		Remaining:   e.Remaining(),
+		Initial:     e.initial - 1,
+		Value:       e.value,
+		Paused:      e.paused,
+	}, true
+}
+
+// AppendFuture appends the description of the event to the list of futures
+// if the event is active. See Future() function.
+func AppendFuture(futures []Future, e *Event, label string, description string) []Future {
+	if f, ok := e.Future(label, description); ok {
+		return append(futures, f)
+	}
+	return futures
+}
@@ -20,7 +20,9 @@ import (
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+	"github.com/jetsetilly/gopher2600/hardware/preferences"
 	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
 	"github.com/jetsetilly/gopher2600/hardware/tia/audio"
 	"github.com/jetsetilly/gopher2600/hardware/tia/delay"
 	"github.com/jetsetilly/gopher2600/hardware/tia/phaseclock"
@@ -93,6 +95,15 @@ type TIA struct {
 	// futureHsyncEvent field is used to differentiate.
 	futureHsync      delay.Event
 	futureHsyncEvent string
+
+	// signals are sent to the television in batches when batching is
+	// enabled. see SetSignalBatching()
+	batching bool
+	batch    []signal.SignalAttributes
+
+	// error from a flush of the batch that could not be returned at the time.
+	// it will be returned by the next call to Step()
+	batchErr error
 }
 
 // Label returns an identifying label for the TIA.
@@ -115,7 +126,7 @@ func (tia TIA) String() string {
 }
 
 // NewTIA creates a TIA, to be used in a VCS emulation.
-func NewTIA(tv signal.TelevisionTIA, mem bus.ChipBus, input bus.UpdateBus) *TIA {
+func NewTIA(prefs *preferences.Preferences, tv signal.TelevisionTIA, mem bus.ChipBus, input bus.UpdateBus) *TIA {
 	tia := &TIA{
 		tv:      tv,
 		mem:     mem,
@@ -124,21 +135,94 @@ func NewTIA(tv signal.TelevisionTIA, mem bus.ChipBus, input bus.UpdateBus) *TIA
 		HmoveCt: 0xff,
 	}
 
-	tia.Audio = audio.NewAudio()
+	tia.Audio = audio.NewAudio(prefs)
 	tia.Video = video.NewVideo(mem, tv, &tia.pclk, &tia.hsync, &tia.Hblank, &tia.HmoveLatch)
 	tia.pclk.Reset()
 
 	return tia
 }
 
+// Futures returns a description of all active delay events in the TIA,
+// including those in the video sub-system.
+func (tia *TIA) Futures() []delay.Future {
+	var f []delay.Future
+	f = delay.AppendFuture(f, &tia.futureVblank, tia.Label(), "VBLANK")
+	f = delay.AppendFuture(f, &tia.futureRsyncAlign, tia.Label(), "RSYNC align")
+	f = delay.AppendFuture(f, &tia.futureRsyncReset, tia.Label(), "RSYNC reset")
+	f = delay.AppendFuture(f, &tia.futureHmoveLatch, tia.Label(), "HMOVE latch")
+	f = delay.AppendFuture(f, &tia.FutureHmove, tia.Label(), "HMOVE")
+	f = delay.AppendFuture(f, &tia.futureHsync, tia.Label(), fmt.Sprintf("HSYNC %s", tia.futureHsyncEvent))
+	f = append(f, tia.Video.Futures()...)
+	return f
+}
+
 // Snapshot creates a copy of the TIA in its current state.
+//
+// Signal batching is turned off in the copy. Batching is a property of the
+// loop that is running the emulation and not of the emulation state, so it is
+// up to the code plumbing the snapshot into the emulation to turn batching
+// back on if required. See IsSignalBatching().
 func (tia *TIA) Snapshot() *TIA {
+	// pending signals belong to the television state. make sure they have
+	// been sent before taking the snapshot
+	tia.FlushPendingSignals()
+
 	n := *tia
 	n.Audio = tia.Audio.Snapshot()
 	n.Video = tia.Video.Snapshot()
+	n.batching = false
+	n.batch = nil
+	n.batchErr = nil
 	return &n
 }
 
+// SetSignalBatching turns signal batching on or off. When batching is on,
+// signals are collected and sent to the television in one go, either at the
+// start of every scanline, when FlushSignals() is called, or when the state of
+// the television is next inspected.
+//
+// Note that FrameTriggers and the other television listeners will be called
+// later than they otherwise would be, by up to one scanline.
+//
+// Any pending signals are sent when batching is turned off.
+func (tia *TIA) SetSignalBatching(batching bool) error {
+	tia.batching = batching
+	if batching {
+		if tia.batch == nil {
+			tia.batch = make([]signal.SignalAttributes, 0, specification.HorizClksScanline)
+		}
+		return nil
+	}
+	return tia.FlushSignals()
+}
+
+// IsSignalBatching returns true if signal batching is turned on.
+func (tia *TIA) IsSignalBatching() bool {
+	return tia.batching
+}
+
+// FlushPendingSignals implements the signal.SignalBatcher interface.
+func (tia *TIA) FlushPendingSignals() {
+	err := tia.FlushSignals()
+	if err != nil && tia.batchErr == nil {
+		tia.batchErr = err
+	}
+}
+
+// FlushSignals sends any pending signals to the television.
+func (tia *TIA) FlushSignals() error {
+	if len(tia.batch) == 0 {
+		return nil
+	}
+
+	// the batch is emptied before the signals are sent in case the television
+	// asks for pending signals while it is working through the batch
+	b := tia.batch
+	tia.batch = tia.batch[:0]
+
+	return tia.tv.SignalBatch(b)
+}
+
 // Plumb the a new ChipBus into the TIA.
 func (tia *TIA) Plumb(mem bus.ChipBus, input bus.UpdateBus) {
 	tia.mem = mem
@@ -152,7 +236,7 @@ func (tia *TIA) Plumb(mem bus.ChipBus, input bus.UpdateBus) {
 func (tia *TIA) UpdateTIA(data bus.ChipData) bool {
 	switch data.Name {
 	case "VSYNC":
-		tia.sig.VSync = data.Value&0x02 == 0x02
+		tia.sig.SetVSync(data.Value&0x02 == 0x02)
 		return false
 
 	case "VBLANK":
@@ -269,6 +353,12 @@ func (tia *TIA) newScanline() {
 	// reset debugging information
 	tia.videoCycles = 0
 
+	// send the signals for the previous scanline to the television. any error
+	// will be returned by the next call to Step()
+	if tia.batching {
+		tia.FlushPendingSignals()
+	}
+
 	// rather than include the reset signal in the delay, we will
 	// manually reset hsync counter when it reaches a count of 57
 }
@@ -276,7 +366,7 @@ func (tia *TIA) newScanline() {
 func (tia *TIA) resolveDelayedEvents() {
 	if v, ok := tia.futureVblank.Tick(); ok {
 		// actual vblank signal
-		tia.sig.VBlank = v&0x02 == 0x02
+		tia.sig.SetVBlank(v&0x02 == 0x02)
 	}
 
 	if _, ok := tia.futureRsyncAlign.Tick(); ok {
@@ -310,10 +400,10 @@ func (tia *TIA) resolveDelayedEvents() {
 		case "SHB":
 			tia.newScanline()
 		case "RHS":
-			tia.sig.HSync = false
-			tia.sig.CBurst = true
+			tia.sig.SetHSync(false)
+			tia.sig.SetCBurst(true)
 		case "RCB":
-			tia.sig.CBurst = false
+			tia.sig.SetCBurst(false)
 		case "RHB":
 			tia.Hblank = false
 		case "LRHB":
@@ -325,8 +415,17 @@ func (tia *TIA) resolveDelayedEvents() {
 // Step moves the state of the tia forward one video cycle returns the state of
 // the CPU's RDY flag.
 func (tia *TIA) Step(readMemory bool) (bool, error) {
+	// return any error from a flush of the signal batch that happened outside
+	// of Step()
+	if tia.batchErr != nil {
+		err := tia.batchErr
+		tia.batchErr = nil
+		return !tia.wsync, err
+	}
+
 	// update debugging information
 	tia.videoCycles++
+	tia.Audio.LastWrite = ""
 
 	var memoryData bus.ChipData
 
@@ -404,7 +503,7 @@ func (tia *TIA) Step(readMemory bool) (bool, error) {
 			// this. not clear if this is the case.
 			//
 			// !!TODO: check accuracy of HSync timing
-			tia.sig.HSync = true
+			tia.sig.SetHSync(true)
 
 		case 8: // [RHS]
 			// reset HSYNC
@@ -493,9 +592,9 @@ func (tia *TIA) Step(readMemory bool) (bool, error) {
 		// historic reasons (to do with how we handle debug colours) we leave
 		// it up to PixelRenderer implementations to switch to VideoBlack on
 		// VBLANK.
-		tia.sig.Pixel = signal.VideoBlack
+		tia.sig.SetPixel(signal.VideoBlack)
 	} else {
-		tia.sig.Pixel = signal.ColorSignal(pixelColor)
+		tia.sig.SetPixel(signal.ColorSignal(pixelColor))
 	}
 
 	if readMemory {
@@ -512,10 +611,25 @@ func (tia *TIA) Step(readMemory bool) (bool, error) {
 	}
 
 	// copy audio to television signal
-	tia.sig.AudioUpdate, tia.sig.AudioData = tia.Audio.Mix()
+	update, data := tia.Audio.Mix()
+	tia.sig.SetAudioUpdate(update)
+	tia.sig.SetAudioData(data)
+	if update {
+		tia.sig.SetAudioStereo(tia.Audio.Stereo())
+	}
 
 	// send signal to television
-	if err := tia.tv.Signal(tia.sig); err != nil {
+	if tia.batching {
+		if len(tia.batch) == 0 {
+			tia.tv.PendingSignals(tia)
+		}
+		tia.batch = append(tia.batch, tia.sig)
+		if len(tia.batch) == cap(tia.batch) {
+			if err := tia.FlushSignals(); err != nil {
+				return !tia.wsync, err
+			}
+		}
+	} else if err := tia.tv.Signal(tia.sig); err != nil {
 		return !tia.wsync, err
 	}
 
@@ -123,6 +123,14 @@ func (bs BallSprite) Label() string {
 	return bs.label
 }
 
+// Futures returns a description of the sprite's active delay events.
+func (bs *BallSprite) Futures() []delay.Future {
+	var f []delay.Future
+	f = delay.AppendFuture(f, &bs.futureReset, bs.label, "reset position")
+	f = delay.AppendFuture(f, &bs.futureStart, bs.label, "start drawing")
+	return f
+}
+
 func (bs BallSprite) String() string {
 	// the hmove value as maintained by the sprite type is normalised for
 	// for purposes of presentation
@@ -362,8 +370,22 @@ func (bs *BallSprite) _futureResetPosition() {
 	bs.Enclockifier.start()
 }
 
+// IsEnabled returns the state of the enable bit that is currently being used
+// to draw the ball. when the vertical delay bit is set this is the "old"
+// copy of the ENABL register (EnabledDelay); otherwise it is the "new" copy
+// (Enabled).
+func (bs *BallSprite) IsEnabled() bool {
+	if bs.VerticalDelay {
+		return bs.EnabledDelay
+	}
+	return bs.Enabled
+}
+
 func (bs *BallSprite) pixel() (active bool, color uint8, collision bool) {
-	if !bs.Enabled || (bs.VerticalDelay && !bs.EnabledDelay) {
+	// the ball enable bit is double buffered in the same way as the player
+	// graphics registers. only one of the copies is ever used to decide
+	// whether the ball is output. the other copy has no effect.
+	if !bs.IsEnabled() {
 		return false, bs.Color, false
 	}
 
@@ -390,11 +412,7 @@ func (bs *BallSprite) pixel() (active bool, color uint8, collision bool) {
 
 	px := !earlyEnd && (bs.Enclockifier.Active || earlyStart)
 
-	if bs.VerticalDelay {
-		return px, bs.Color, px || (*bs.hblank && bs.EnabledDelay && bs.futureStart.AboutToEnd())
-	}
-
-	return px, bs.Color, px || (*bs.hblank && bs.Enabled && bs.futureStart.AboutToEnd())
+	return px, bs.Color, px || (*bs.hblank && bs.futureStart.AboutToEnd())
 }
 
 // the delayed enable bit is set when the gfx register for player 1 is updated.
@@ -123,6 +123,14 @@ func (ms MissileSprite) Label() string {
 	return ms.label
 }
 
+// Futures returns a description of the sprite's active delay events.
+func (ms *MissileSprite) Futures() []delay.Future {
+	var f []delay.Future
+	f = delay.AppendFuture(f, &ms.futureReset, ms.label, "reset position")
+	f = delay.AppendFuture(f, &ms.futureStart, ms.label, "start drawing")
+	return f
+}
+
 func (ms MissileSprite) String() string {
 	// the hmove value as maintained by the sprite type is normalised for
 	// for purposes of presentation
@@ -0,0 +1,77 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package video_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/tia/video"
+)
+
+// position the player and missile and then, optionally, lock the missile to
+// the player with RESMP0 and release it after a few scanlines.
+const resmpKernel = `sei : cld
+lda #$44 : sta $06
+lda #$%02x : sta $04
+lda #$%02x : sta $1b
+lda #$%02x : sta $1d
+sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop
+sta $10 : nop : nop : nop : nop : sta $12
+lda #$%02x : sta $28
+sta $02 : sta $02 : sta $02
+lda #$00 : sta $28`
+
+// returns the position of the first pixel of either player 0 or missile 0 on
+// a scanline well into the frame.
+func resmpPosition(t *testing.T, nusiz uint8, player bool, resmp bool) int {
+	t.Helper()
+
+	grp := uint8(0x00)
+	enam := uint8(0x02)
+	if player {
+		grp = 0xff
+		enam = 0x00
+	}
+
+	var lock uint8
+	if resmp {
+		lock = 0x02
+	}
+
+	return runKernel(t, fmt.Sprintf(resmpKernel, nusiz, grp, enam, lock)).firstPixel(100, 0x44)
+}
+
+func TestResetToPlayer(t *testing.T) {
+	for _, nusiz := range []uint8{0x00, 0x01, 0x05, 0x07} {
+		p := resmpPosition(t, nusiz, true, false)
+		m := resmpPosition(t, nusiz, false, true)
+		if p == -1 || m == -1 {
+			t.Fatalf("NUSIZ %02x: player or missile not drawn (%d, %d)", nusiz, p, m)
+		}
+
+		offset := video.ResetToPlayerOffset(nusiz)
+		if m-p != offset {
+			t.Errorf("NUSIZ %02x: missile reset to player at offset %d (expected %d)", nusiz, m-p, offset)
+		}
+
+		// the missile's own position should be unaffected if RESMP is never
+		// used
+		if n := resmpPosition(t, nusiz, false, false); n == m {
+			t.Errorf("NUSIZ %02x: missile unaffected by RESMP", nusiz)
+		}
+	}
+}
@@ -180,6 +180,15 @@ func (ps PlayerSprite) Label() string {
 	return ps.label
 }
 
+// Futures returns a description of the sprite's active delay events.
+func (ps *PlayerSprite) Futures() []delay.Future {
+	var f []delay.Future
+	f = delay.AppendFuture(f, &ps.futureReset, ps.label, "reset position")
+	f = delay.AppendFuture(f, &ps.futureStart, ps.label, "start drawing")
+	f = delay.AppendFuture(f, &ps.futureSetNUSIZ, ps.label, "set NUSIZ")
+	return f
+}
+
 func (ps PlayerSprite) String() string {
 	// the hmove value as maintained by the sprite type is normalised for
 	// for purposes of presentation
@@ -768,3 +777,23 @@ func (ps *PlayerSprite) triggerMissileReset() bool {
 	}
 	return ps.ScanCounter.Pixel == 2
 }
+
+// ResetToPlayerOffset returns the distance, in pixels, between the first
+// pixel of the player and the position a missile will take when it is reset
+// to that player. The offset depends on the player size given in the
+// sizeAndCopies value (lower three bits of NUSIZ).
+//
+// The values are informational and are what triggerMissileReset() produces:
+// the missile position counter is reset in the middle of the player's
+// graphics and the missile starts drawing one clock earlier than the player
+// relative to its position counter. Players of double and quadruple width
+// start drawing one clock later than single width players.
+func ResetToPlayerOffset(sizeAndCopies uint8) int {
+	switch sizeAndCopies & 0x07 {
+	case 0x05:
+		return 7
+	case 0x07:
+		return 10
+	}
+	return 4
+}
@@ -54,9 +54,8 @@ type Playfield struct {
 	//
 	// RegionLeft always uses RegularData and RegionRight uses either
 	// RegularDat or ReflectedData depending on the state of the reflected bit
-	// at either:
-	//	- the start of the region
-	//	- when PF bits are changed
+	// at the start of the region. changing the reflected bit during the right
+	// hand region has no effect until the next scanline (see reflectedLatch)
 	RegularData   []bool
 	ReflectedData []bool
 	Data          *[]bool
@@ -85,6 +84,11 @@ type Playfield struct {
 	Priority  bool
 	Scoremode bool
 
+	// the value of the Reflected field at the start of the right hand region.
+	// unlike the priority and score bits, the reflected bit only takes effect
+	// at the centre of the screen
+	reflectedLatch bool
+
 	// Region keeps track of which part of the screen we're currently in
 	Region ScreenRegion
 
@@ -196,6 +200,7 @@ func (pf *Playfield) pixel() (bool, uint8) {
 		case 37:
 			// just past the centre of the visible screen
 			pf.Region = RegionRight
+			pf.reflectedLatch = pf.Reflected
 			pf.latchRegionData()
 		}
 
@@ -238,7 +243,7 @@ func (pf *Playfield) latchRegionData() {
 		pf.RightData = &pf.ReflectedData
 	}
 
-	if pf.Region != RegionRight || !pf.Reflected {
+	if pf.Region != RegionRight || !pf.reflectedLatch {
 		pf.Data = &pf.RegularData
 	} else {
 		pf.Data = &pf.ReflectedData
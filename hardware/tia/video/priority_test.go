@@ -0,0 +1,186 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package video_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// the player colors and the playfield (and ball) color used by the priority
+// tests. the background is left black.
+const (
+	colP0 = 0x44
+	colP1 = 0x86
+	colPF = 0x1e
+)
+
+// sets the colors, fills the playfield and writes CTRLPF. the remaining
+// register writes enable and position a sprite.
+const priorityKernel = `sei : cld
+lda #$44 : sta $06 : lda #$86 : sta $07 : lda #$1e : sta $08
+lda #$ff : sta $0d : sta $0e : sta $0f
+lda #$%02x : sta $0a
+%s`
+
+// sprites positioned in the left or right half of the screen.
+const (
+	p0Left  = "lda #$ff : sta $1b : sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : sta $10"
+	p1Left  = "lda #$ff : sta $1c : sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : sta $11"
+	p0Right = "lda #$ff : sta $1b : sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : sta $10"
+	p0Ball  = "lda #$00 : sta $0d : sta $0e : sta $0f : lda #$07 : sta $04 : lda #$ff : sta $1b : lda #$02 : sta $1f : sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : sta $10 : sta $14"
+	blLeft  = "lda #$02 : sta $1f : sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop : sta $14"
+)
+
+// pixel returns the color of the pixel at the visible position on the
+// scanline.
+func (r *lineRenderer) pixel(scanline int, x int) signal.ColorSignal {
+	return r.frame[scanline*specification.HorizClksScanline+specification.HorizClksHBlank+x]
+}
+
+// contains returns true if there is a pixel of the specified color between
+// the two visible positions on the scanline.
+func (r *lineRenderer) contains(scanline int, col signal.ColorSignal, from int, to int) bool {
+	for x := from; x < to; x++ {
+		if r.pixel(scanline, x) == col {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctrlpf   uint8
+		sprite   string
+		col      signal.ColorSignal
+		from, to int
+		visible  bool
+	}{
+		{"normal P0", 0x00, p0Left, colP0, 0, 80, true},
+		{"priority P0", 0x04, p0Left, colP0, 0, 160, false},
+
+		// in score mode the playfield takes the color of player 0 in the left
+		// half of the screen and of player 1 in the right half
+		{"score left half", 0x02, "", colP0, 0, 80, true},
+		{"score right half", 0x02, "", colP1, 80, 160, true},
+		{"score no P1 in left half", 0x02, "", colP1, 0, 80, false},
+		{"score no P0 in right half", 0x02, "", colP0, 80, 160, false},
+		{"score no playfield color", 0x02, "", colPF, 0, 160, false},
+
+		// the priority bit suppresses the score bit completely
+		{"score and priority", 0x06, "", colPF, 0, 160, true},
+		{"score and priority no P0", 0x06, p0Left, colP0, 0, 160, false},
+		{"score and priority no P1", 0x06, "", colP1, 0, 160, false},
+
+		// in score mode the playfield is in the player 0 group in the left
+		// half of the screen and so has priority over player 1
+		{"normal P1", 0x00, p1Left, colP1, 0, 80, true},
+		{"score P1", 0x02, p1Left, colP1, 0, 80, false},
+
+		// player 0 keeps priority over the playfield in the right half
+		{"score P0 right half", 0x02, p0Right, colP0, 80, 160, true},
+
+		// the playfield always has priority over the ball in score mode. the
+		// ball uses the playfield color so it would be the only source of it
+		{"score ball", 0x02, blLeft, colPF, 0, 160, false},
+
+		// a quad width player 0 overlapping an eight pixel ball, with an empty
+		// playfield. the ball is in the playfield group and so is below the
+		// player unless the priority bit is set. this is true in score mode
+		// too
+		{"normal P0 over ball", 0x30, p0Ball, colPF, 0, 80, false},
+		{"priority ball over P0", 0x34, p0Ball, colPF, 0, 80, true},
+		{"score P0 over ball", 0x32, p0Ball, colPF, 0, 80, false},
+	}
+
+	for _, tt := range tests {
+		r := runKernel(t, fmt.Sprintf(priorityKernel, tt.ctrlpf, tt.sprite))
+		visible := r.contains(100, tt.col, tt.from, tt.to)
+		if visible != tt.visible {
+			t.Errorf("%s: color %v visible is %v (expected %v)", tt.name, tt.col, visible, tt.visible)
+		}
+	}
+}
+
+// fills the playfield and then writes CTRLPF twice on every scanline. the
+// first write is during the horizontal blank and the second is after the
+// specified number of NOP instructions. the second write is followed by any
+// additional register writes. the loop must complete within the 76 cycles of
+// the scanline.
+const ctrlpfKernel = `sta $02
+lda #$%02x : sta $0a
+lda #$44 : sta $06 : lda #$86 : sta $07 : lda #$1e : sta $08
+lda #$%02x : sta $0d : sta $0e : lda #$%02x : sta $0f
+lda #$%02x
+%s
+sta $0a
+%s
+jmp $f000`
+
+func TestCTRLPFMidScanline(t *testing.T) {
+	nops := func(n int) string {
+		s := "nop"
+		for i := 1; i < n; i++ {
+			s += " : nop"
+		}
+		return s
+	}
+
+	// score mode is set part way through the left half of the screen and
+	// takes effect immediately
+	r := runKernel(t, fmt.Sprintf(ctrlpfKernel, 0x00, 0xff, 0xff, 0x02, nops(1), ""))
+	for _, tt := range []struct {
+		x   int
+		col signal.ColorSignal
+	}{{10, colPF}, {70, colP0}, {120, colP1}} {
+		if c := r.pixel(100, tt.x); c != tt.col {
+			t.Errorf("score mid-scanline: pixel %d is %v (expected %v)", tt.x, c, tt.col)
+		}
+	}
+
+	// only the last bit of PF2 is set (PF2 is written again after the CTRLPF
+	// write). it is drawn at the end of each half
+	// of the screen or, when reflected, either side of the centre
+	for _, tt := range []struct {
+		name      string
+		nops      int
+		reflected bool
+	}{
+		// REF is set before the centre of the screen
+		{"REF set in left half", 1, true},
+
+		// REF is set in the right half of the screen. it has no effect until
+		// the next scanline and is cleared before then. PF2 is written after
+		// REF to make sure the playfield data is not reflected by the write
+		{"REF set in right half", 10, false},
+	} {
+		r := runKernel(t, fmt.Sprintf(ctrlpfKernel, 0x00, 0x00, 0x80, 0x01, nops(tt.nops), "lda #$80 : sta $0f"))
+		if r.pixel(100, 78) != colPF {
+			t.Errorf("%s: playfield missing from left half", tt.name)
+		}
+		if (r.pixel(100, 82) == colPF) != tt.reflected {
+			t.Errorf("%s: pixel 82 drawn is %v (expected %v)", tt.name, !tt.reflected, tt.reflected)
+		}
+		if (r.pixel(100, 158) == colPF) == tt.reflected {
+			t.Errorf("%s: pixel 158 drawn is %v (expected %v)", tt.name, tt.reflected, !tt.reflected)
+		}
+	}
+}
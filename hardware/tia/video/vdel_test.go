@@ -0,0 +1,59 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package video_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+// sets the player 0 and playfield (ball) colour, runs the supplied register
+// writes and then positions player 0 and the ball.
+const vdelKernel = `sei : cld
+lda #$44 : sta $06 : sta $08
+%s
+sta $02 : nop : nop : nop : nop : nop : nop : nop : nop : nop : nop
+sta $10 : sta $14`
+
+func TestVerticalDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		writes  string
+		visible bool
+	}{
+		// GRP0 is written to the "new" register. the "old" register is
+		// updated by a write to GRP1
+		{"GRP0 new", "lda #$ff : sta $1b", true},
+		{"GRP0 new with VDEL", "lda #$01 : sta $25 : lda #$ff : sta $1b", false},
+		{"GRP0 old with VDEL", "lda #$01 : sta $25 : lda #$ff : sta $1b : lda #$00 : sta $1c", true},
+		{"GRP0 old without VDEL", "lda #$ff : sta $1b : lda #$00 : sta $1c : sta $1b", false},
+
+		// ENABL is written to the "new" bit. the "old" bit is updated by a
+		// write to GRP1
+		{"ENABL new", "lda #$02 : sta $1f", true},
+		{"ENABL new with VDEL", "lda #$01 : sta $27 : lda #$02 : sta $1f", false},
+		{"ENABL old with VDEL", "lda #$01 : sta $27 : lda #$02 : sta $1f : sta $1c : lda #$00 : sta $1f", true},
+		{"ENABL old without VDEL", "lda #$02 : sta $1f : sta $1c : lda #$00 : sta $1f", false},
+	}
+
+	for _, tt := range tests {
+		r := runKernel(t, fmt.Sprintf(vdelKernel, tt.writes))
+		visible := r.firstPixel(100, 0x44) != -1
+		if visible != tt.visible {
+			t.Errorf("%s: visible is %v (expected %v)", tt.name, visible, tt.visible)
+		}
+	}
+}
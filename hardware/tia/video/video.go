@@ -58,6 +58,40 @@ func (e Element) String() string {
 	panic("unknown video element")
 }
 
+// Priority is used to record how the priority of the video elements was
+// decided for the most recent pixel.
+type Priority int
+
+// List of valid Priority values.
+const (
+	// players and missiles have priority over the ball and playfield
+	PriorityNormal Priority = iota
+
+	// the CTRLPF priority bit is set. ball and playfield have priority over
+	// players and missiles
+	PriorityPlayfield
+
+	// the CTRLPF score bit is set (and the priority bit is not set). the
+	// playfield takes the color and priority of player 0 in the left half of
+	// the screen and of player 1 in the right half
+	PriorityScoreLeft
+	PriorityScoreRight
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityNormal:
+		return "Normal"
+	case PriorityPlayfield:
+		return "Playfield"
+	case PriorityScoreLeft:
+		return "Score (Left)"
+	case PriorityScoreRight:
+		return "Score (Right)"
+	}
+	panic("unknown video priority")
+}
+
 // Video contains all the components of the video sub-system of the VCS TIA chip.
 type Video struct {
 	// collision matrix
@@ -78,6 +112,9 @@ type Video struct {
 	// for details
 	LastElement Element
 
+	// LastPriority records how priority was decided for the most recent pixel
+	LastPriority Priority
+
 	// keeping track of whether any sprite element has changed since last call
 	// to Pixel(). we use this for some small optimisations
 	spriteHasChanged    bool
@@ -91,6 +128,19 @@ type Video struct {
 	writingRegister string
 }
 
+// Futures returns a description of the active delay events in the video
+// sub-system.
+func (vd *Video) Futures() []delay.Future {
+	var f []delay.Future
+	f = delay.AppendFuture(f, &vd.writing, "Video", "write "+vd.writingRegister)
+	f = append(f, vd.Player0.Futures()...)
+	f = append(f, vd.Player1.Futures()...)
+	f = append(f, vd.Missile0.Futures()...)
+	f = append(f, vd.Missile1.Futures()...)
+	f = append(f, vd.Ball.Futures()...)
+	return f
+}
+
 // NewVideo is the preferred method of initialisation for the Video sub-system.
 //
 // The playfield type requires access access to the TIA's phaseclock and
@@ -233,48 +283,54 @@ func (vd *Video) Pixel() uint8 {
 	var col uint8
 	var element Element
 
-	// the interaction of the priority and scoremode bits are a little more
-	// complex than at first glance:
+	// the priority and score bits of CTRLPF work on three groups of video
+	// elements: player 0 and missile 0; player 1 and missile 1; and the
+	// playfield and ball. normally the groups have priority in that order.
+	// when the priority bit is set, the playfield and ball group has the
+	// highest priority.
 	//
-	//  o if the priority bit is set then priority ordering applies
-	//  o if it is not set but scoremode is set and we're in the left half of
-	//		the screen, then priority ordering also applies
-	//	o if priority bit is not set but scoremode is set and we're in the
-	//		right hand side of the screen then regular ordering applies, except
-	//		that playfield has priority over the ball
-	//	o phew
+	// when the score bit is set the playfield (but not the ball) is moved into
+	// the player 0 group in the left half of the screen and into the player 1
+	// group in the right half. the playfield takes the color of the group as
+	// well as the priority. this means that in the left half of the screen the
+	// playfield will hide player 1 and in both halves the playfield will hide
+	// the ball.
 	//
-	//	that scoremode reorders priority regardless of the priority bit is not
-	//	at all obvious but observation proves it to be true. see test.bin ROM
+	// setting the priority bit suppresses the effect of the score bit
+	// completely. the playfield is drawn with the playfield color and with
+	// playfield priority.
 	//
-	//	the comment by "supercat" in the discussion "Playfield Score Mode -
-	//	effect on ball" on AtariAge proved useful here.
+	// the CTRLPF register is not latched so changes to the priority and score
+	// bits take effect immediately, including in the middle of the scanline.
 	//
-	//	!!TODO: I'm still not 100% sure this is correct. check playfield priorties
-	if vd.Playfield.Priority || (vd.Playfield.Scoremode && vd.Playfield.Region == RegionLeft) {
-		if pfa { // priority 1
-			if vd.Playfield.Scoremode && !vd.Playfield.Priority {
-				switch vd.Playfield.Region {
-				case RegionLeft:
-					col = p0c
-				case RegionRight:
-					col = p1c
-				}
-			} else {
-				col = pfc
-			}
+	// see the discussion "Playfield Score Mode - effect on ball" on AtariAge
+	// and the comment by "supercat" in particular.
+	var priority Priority
+	if vd.Playfield.Priority {
+		priority = PriorityPlayfield
+	} else if vd.Playfield.Scoremode && vd.Playfield.Region == RegionRight {
+		priority = PriorityScoreRight
+	} else if vd.Playfield.Scoremode {
+		priority = PriorityScoreLeft
+	} else {
+		priority = PriorityNormal
+	}
 
+	switch priority {
+	case PriorityPlayfield:
+		if pfa {
+			col = pfc
 			element = ElementPlayfield
 		} else if bla {
 			col = blc
 			element = ElementBall
-		} else if p0a { // priority 2
+		} else if p0a {
 			col = p0c
 			element = ElementPlayer0
 		} else if m0a {
 			col = m0c
 			element = ElementMissile0
-		} else if p1a { // priority 3
+		} else if p1a {
 			col = p1c
 			element = ElementPlayer1
 		} else if m1a {
@@ -284,53 +340,84 @@ func (vd *Video) Pixel() uint8 {
 			col = bgc
 			element = ElementBackground
 		}
-	} else {
-		if p0a { // priority 1
+
+	case PriorityScoreLeft:
+		if p0a {
 			col = p0c
 			element = ElementPlayer0
 		} else if m0a {
 			col = m0c
 			element = ElementMissile0
-		} else if p1a { // priority 2
+		} else if pfa {
+			col = p0c
+			element = ElementPlayfield
+		} else if p1a {
 			col = p1c
 			element = ElementPlayer1
 		} else if m1a {
 			col = m1c
 			element = ElementMissile1
-		} else if vd.Playfield.Scoremode && (bla || pfa) {
-			// priority 3 (scoremode without priority bit)
-			if pfa {
-				col = pfc
-				switch vd.Playfield.Region {
-				case RegionLeft:
-					col = p0c
-				case RegionRight:
-					col = p1c
-				}
-				element = ElementPlayfield
-			} else if bla { // priority 3
-				col = blc
-				element = ElementBall
-			}
+		} else if bla {
+			col = blc
+			element = ElementBall
 		} else {
-			// priority 3 (no scoremode or priority bit)
-			if bla { // priority 3
-				col = blc
-				element = ElementBall
-			} else if pfa {
-				col = pfc
-				element = ElementPlayfield
-			} else {
-				col = bgc
-				element = ElementBackground
-			}
+			col = bgc
+			element = ElementBackground
+		}
+
+	case PriorityScoreRight:
+		if p0a {
+			col = p0c
+			element = ElementPlayer0
+		} else if m0a {
+			col = m0c
+			element = ElementMissile0
+		} else if p1a {
+			col = p1c
+			element = ElementPlayer1
+		} else if m1a {
+			col = m1c
+			element = ElementMissile1
+		} else if pfa {
+			col = p1c
+			element = ElementPlayfield
+		} else if bla {
+			col = blc
+			element = ElementBall
+		} else {
+			col = bgc
+			element = ElementBackground
+		}
+
+	default:
+		if p0a {
+			col = p0c
+			element = ElementPlayer0
+		} else if m0a {
+			col = m0c
+			element = ElementMissile0
+		} else if p1a {
+			col = p1c
+			element = ElementPlayer1
+		} else if m1a {
+			col = m1c
+			element = ElementMissile1
+		} else if bla {
+			col = blc
+			element = ElementBall
+		} else if pfa {
+			col = pfc
+			element = ElementPlayfield
+		} else {
+			col = bgc
+			element = ElementBackground
 		}
 	}
 
 	vd.LastElement = element
+	vd.LastPriority = priority
 	vd.lastPixelColor = col
 
-	// priority 4
 	return col
 }
 
@@ -0,0 +1,113 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package video_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// lineRenderer records the pixels of the most recently completed frame.
+type lineRenderer struct {
+	current []signal.ColorSignal
+	frame   []signal.ColorSignal
+}
+
+func (r *lineRenderer) Resize(_ specification.Spec, _ int, _ int) error { return nil }
+func (r *lineRenderer) NewScanline(_ int) error                         { return nil }
+func (r *lineRenderer) UpdatingPixels(_ bool)                           {}
+func (r *lineRenderer) Reset()                                          {}
+func (r *lineRenderer) EndRendering() error                             { return nil }
+
+func (r *lineRenderer) NewFrame(_ television.FrameInfo) error {
+	r.frame = r.current
+	r.current = nil
+	return nil
+}
+
+func (r *lineRenderer) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	r.current = append(r.current, sig.Pixel())
+	return nil
+}
+
+// firstPixel returns the visible position of the first pixel on the scanline
+// that is of the specified color. returns -1 if there is no such pixel.
+func (r *lineRenderer) firstPixel(scanline int, col signal.ColorSignal) int {
+	l := r.frame[scanline*specification.HorizClksScanline : (scanline+1)*specification.HorizClksScanline]
+	for x, c := range l[specification.HorizClksHBlank:] {
+		if c == col {
+			return x
+		}
+	}
+	return -1
+}
+
+// runKernel assembles the kernel into a 4k cartridge, followed by an endless
+// loop of WSYNC writes, and runs it for two frames. the returned renderer
+// contains the pixels of the last complete frame.
+func runKernel(t *testing.T, kernel string) *lineRenderer {
+	t.Helper()
+
+	lines, err := assembler.Assemble(assembler.CartridgeOrigin, kernel, nil)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	end := uint16(assembler.CartridgeOrigin)
+	if len(lines) > 0 {
+		l := lines[len(lines)-1]
+		end = l.Address + uint16(len(l.Bytes))
+	}
+
+	// loop forever on WSYNC
+	data, err := assembler.Cartridge(fmt.Sprintf("%s\nsta $02 : jmp $%04x", kernel, end))
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+	tv.SetFPSCap(false)
+
+	r := &lineRenderer{}
+	tv.AddPixelRenderer(r)
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{Filename: "kernel", Mapping: "4k", Data: data, Hash: "kernel"})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	err = vcs.RunForFrameCount(2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error running VCS: %v", err)
+	}
+
+	return r
+}
@@ -33,6 +33,10 @@ import (
 type Session struct {
 	id    string
 	Prefs *Preferences
+
+	// the score to be submitted with EndSession(). see SetScore()
+	score    int
+	hasScore bool
 }
 
 // NewSession is the preferred method of initialisation of the Session type.
@@ -76,10 +80,20 @@ func (sess *Session) StartSession(name string, hash string) error {
 	return nil
 }
 
+// SetScore sets the score that will be submitted when the session ends. If
+// SetScore() is never called then no score is submitted.
+func (sess *Session) SetScore(score int) {
+	sess.score = score
+	sess.hasScore = true
+}
+
 // EndSession notifies the the HiScore server that a game has finished, with
 // details of the game session (time spent, score, etc.)
 func (sess *Session) EndSession(playTime time.Duration) error {
 	values := map[string]interface{}{"session": sess.id, "duration": fmt.Sprintf("%.0f", playTime.Seconds())}
+	if sess.hasScore {
+		values["score"] = fmt.Sprintf("%d", sess.score)
+	}
 	jsonValue, _ := json.Marshal(values)
 	statusCode, response, err := sess.post("/HiScore/rest/play/", jsonValue)
 	if err != nil {
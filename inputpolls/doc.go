@@ -0,0 +1,28 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package inputpolls records when the ROM reads the input registers of the
+// VCS. The input registers are INPT0 to INPT5 in the TIA and SWCHA and SWCHB
+// in the RIOT. Every read is recorded along with the television coordinates
+// at the time of the read.
+//
+// The information shows exactly when during the frame a ROM polls the
+// joystick or the paddles. This is useful for input latency analysis and for
+// heuristics that try to detect which controller a ROM expects.
+//
+// The Monitor type implements the television.FrameTrigger interface and should
+// be added to the television with AddFrameTrigger(). The Check() function
+// should be called after every CPU cycle or after every CPU instruction.
+package inputpolls
@@ -0,0 +1,164 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package inputpolls
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+)
+
+// Poll is a single read of an input register.
+type Poll struct {
+	// the name of the register and its (mapped) address
+	Register string
+	Address  uint16
+
+	// the value that was read
+	Value uint8
+
+	// the address of the instruction that read the register
+	PC uint16
+
+	// television coordinates at the time of the read
+	Frame    int
+	Scanline int
+	Clock    int
+}
+
+func (p Poll) String() string {
+	return fmt.Sprintf("%s (%#02x) by %#04x [frame %d, scanline %d, clock %d]",
+		p.Register, p.Value, p.PC, p.Frame, p.Scanline, p.Clock)
+}
+
+// register returns the name of the register if the mapped address is one of
+// the input registers.
+func register(mapped uint16) (string, bool) {
+	_, area := memorymap.MapAddress(mapped, true)
+
+	switch area {
+	case memorymap.TIA:
+		// INPT0 to INPT5
+		if mapped >= 0x08 && mapped <= 0x0d {
+			return addresses.TIAReadSymbols[mapped], true
+		}
+	case memorymap.RIOT:
+		if mapped == 0x0280 || mapped == 0x0282 {
+			return addresses.RIOTReadSymbols[mapped], true
+		}
+	}
+
+	return "", false
+}
+
+// Monitor records the reads of the input registers for the current and for
+// the previous frame.
+type Monitor struct {
+	vcs *hardware.VCS
+
+	enabled bool
+
+	// reads in the current frame and in the most recently completed frame
+	current []Poll
+	last    []Poll
+
+	// the access ID of the most recent read. prevents the same read being
+	// recorded more than once
+	lastAccessID int
+}
+
+// NewMonitor is the preferred method of initialisation for the Monitor type.
+// The monitor is enabled by default.
+func NewMonitor(vcs *hardware.VCS) *Monitor {
+	return &Monitor{
+		vcs:          vcs,
+		enabled:      true,
+		lastAccessID: -1,
+	}
+}
+
+// Enable or disable the monitor. Disabling the monitor clears all recorded
+// reads.
+func (m *Monitor) Enable(enable bool) {
+	m.enabled = enable
+	if !enable {
+		m.Clear()
+	}
+}
+
+// IsEnabled returns true if the monitor is recording reads.
+func (m *Monitor) IsEnabled() bool {
+	return m.enabled
+}
+
+// Clear all recorded reads.
+func (m *Monitor) Clear() {
+	m.current = m.current[:0]
+	m.last = nil
+}
+
+// Check the most recent memory access and record it if it was a read of an
+// input register.
+func (m *Monitor) Check() {
+	if !m.enabled {
+		return
+	}
+
+	mem := m.vcs.Mem
+	if mem.LastAccessWrite || mem.LastAccessID == m.lastAccessID {
+		return
+	}
+	m.lastAccessID = mem.LastAccessID
+
+	reg, ok := register(mem.LastAccessAddressMapped)
+	if !ok {
+		return
+	}
+
+	m.current = append(m.current, Poll{
+		Register: reg,
+		Address:  mem.LastAccessAddressMapped,
+		Value:    mem.LastAccessValue,
+		PC:       m.vcs.CPU.LastResult.Address,
+		Frame:    m.vcs.TV.GetState(signal.ReqFramenum),
+		Scanline: m.vcs.TV.GetState(signal.ReqScanline),
+		Clock:    m.vcs.TV.GetState(signal.ReqHorizPos),
+	})
+}
+
+// NewFrame implements the television.FrameTrigger interface.
+func (m *Monitor) NewFrame(_ television.FrameInfo) error {
+	if !m.enabled {
+		return nil
+	}
+
+	m.last = m.current
+	m.current = make([]Poll, 0, len(m.last))
+
+	return nil
+}
+
+// LastFrame returns a copy of the reads recorded during the most recently
+// completed frame.
+func (m *Monitor) LastFrame() []Poll {
+	p := make([]Poll, len(m.last))
+	copy(p, m.last)
+	return p
+}
@@ -0,0 +1,103 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package inputpolls_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/inputpolls"
+)
+
+func TestMonitor(t *testing.T) {
+	// the RAM read and the write to SWCHA must not be recorded
+	const kernel = `sta $02
+lda $0c
+lda $0280
+lda $80
+sta $0280
+bit $0282`
+
+	data, err := assembler.Cartridge(kernel)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{Filename: "kernel", Mapping: "4k", Data: data, Hash: "kernel"})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	m := inputpolls.NewMonitor(vcs)
+
+	// run each instruction in the kernel
+	for i := 0; i < 6; i++ {
+		err = vcs.Step(func() error {
+			m.Check()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error running VCS: %v", err)
+		}
+	}
+
+	// nothing is available until the frame has completed
+	if len(m.LastFrame()) != 0 {
+		t.Fatalf("polls available before the end of the frame")
+	}
+	_ = m.NewFrame(television.FrameInfo{})
+
+	p := m.LastFrame()
+	expected := []string{"INPT4", "SWCHA", "SWCHB"}
+	if len(p) != len(expected) {
+		t.Fatalf("expected %d polls, got %d", len(expected), len(p))
+	}
+	for i, r := range expected {
+		if p[i].Register != r {
+			t.Errorf("poll %d: expected %s, got %s", i, r, p[i].Register)
+		}
+	}
+
+	// polls are on the scanline after the WSYNC and in the order they were
+	// executed
+	for i := 1; i < len(p); i++ {
+		if p[i].Scanline != p[0].Scanline {
+			t.Errorf("poll %d: expected scanline %d, got %d", i, p[0].Scanline, p[i].Scanline)
+		}
+		if p[i].Clock <= p[i-1].Clock {
+			t.Errorf("poll %d: clock %d is not after clock %d", i, p[i].Clock, p[i-1].Clock)
+		}
+	}
+
+	// disabling the monitor clears the polls
+	m.Enable(false)
+	if len(m.LastFrame()) != 0 {
+		t.Errorf("polls available after monitor was disabled")
+	}
+}
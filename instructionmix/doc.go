@@ -0,0 +1,28 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package instructionmix counts the CPU instructions executed by the emulation,
+// grouped by opcode and by addressing mode. Counts are kept for a sampling
+// window, measured in frames, so the mix reflects what the ROM has been doing
+// recently rather than since it was started.
+//
+// The information is useful for identifying the instructions that dominate
+// execution, either in the ROM (for the homebrew developer) or in the emulator
+// (for anyone looking to optimise the CPU emulation).
+//
+// The Mix type implements the television.FrameTrigger interface and should be
+// added to the television with AddFrameTrigger(). Executed instructions should
+// be passed to the Record() function.
+package instructionmix
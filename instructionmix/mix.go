@@ -0,0 +1,267 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package instructionmix
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// DefaultWindow is the default length of the sampling window in frames.
+const DefaultWindow = 60
+
+// MaxWindow is the maximum length of the sampling window in frames.
+const MaxWindow = 3600
+
+type count struct {
+	instructions int
+	cycles       int
+}
+
+// Mix records the number of instructions executed for every opcode.
+type Mix struct {
+	defns []*instructions.Definition
+
+	enabled bool
+
+	// per-frame counts for every frame in the window. the frame at frameIdx
+	// is the current frame
+	frames   [][256]count
+	frameIdx int
+
+	// running total of all frames in the window
+	total [256]count
+}
+
+// NewMix is the preferred method of initialisation for the Mix type.
+func NewMix() *Mix {
+	mx := &Mix{
+		defns: instructions.GetDefinitions(),
+	}
+	mx.frames = make([][256]count, DefaultWindow)
+	return mx
+}
+
+// SetEnabled turns instruction counting on or off. Counts are not reset.
+func (mx *Mix) SetEnabled(enabled bool) {
+	mx.enabled = enabled
+}
+
+// IsEnabled returns true if instruction counting is on.
+func (mx *Mix) IsEnabled() bool {
+	return mx.enabled
+}
+
+// Window returns the length of the sampling window in frames.
+func (mx *Mix) Window() int {
+	return len(mx.frames)
+}
+
+// SetWindow changes the length of the sampling window. All counts are reset.
+func (mx *Mix) SetWindow(frames int) error {
+	if frames < 1 || frames > MaxWindow {
+		return curated.Errorf("instruction mix: %v", fmt.Sprintf("window must be between 1 and %d frames", MaxWindow))
+	}
+	mx.frames = make([][256]count, frames)
+	mx.Clear()
+	return nil
+}
+
+// Clear all counts.
+func (mx *Mix) Clear() {
+	for i := range mx.frames {
+		mx.frames[i] = [256]count{}
+	}
+	mx.frameIdx = 0
+	mx.total = [256]count{}
+}
+
+// NewFrame implements the television.FrameTrigger interface.
+func (mx *Mix) NewFrame(_ television.FrameInfo) error {
+	if !mx.enabled {
+		return nil
+	}
+
+	mx.frameIdx++
+	if mx.frameIdx >= len(mx.frames) {
+		mx.frameIdx = 0
+	}
+
+	// the oldest frame in the window is about to be reused. remove its counts
+	// from the running total
+	f := &mx.frames[mx.frameIdx]
+	for i := range f {
+		mx.total[i].instructions -= f[i].instructions
+		mx.total[i].cycles -= f[i].cycles
+	}
+	*f = [256]count{}
+
+	return nil
+}
+
+// Record an executed instruction. Results that are not final are ignored.
+func (mx *Mix) Record(result execution.Result) {
+	if !mx.enabled || !result.Final || result.Defn == nil {
+		return
+	}
+
+	op := result.Defn.OpCode
+	f := &mx.frames[mx.frameIdx]
+	f[op].instructions++
+	f[op].cycles += result.Cycles
+	mx.total[op].instructions++
+	mx.total[op].cycles += result.Cycles
+}
+
+// Entry is a single line in the instruction mix.
+type Entry struct {
+	// the instruction definition. will be nil for entries returned by
+	// ByAddressingMode()
+	Defn *instructions.Definition
+
+	AddressingMode instructions.AddressingMode
+
+	Count  int
+	Cycles int
+
+	// count and cycles as a percentage of all instructions in the window
+	CountPercent  float64
+	CyclesPercent float64
+}
+
+func (mx *Mix) totals() (int, int) {
+	var n, c int
+	for i := range mx.total {
+		n += mx.total[i].instructions
+		c += mx.total[i].cycles
+	}
+	return n, c
+}
+
+func percent(v int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(v) / float64(total) * 100
+}
+
+// sort entries by count with ties broken by opcode or addressing mode, so that
+// the order is stable
+func sortEntries(e []Entry) {
+	sort.Slice(e, func(i, j int) bool {
+		if e[i].Count != e[j].Count {
+			return e[i].Count > e[j].Count
+		}
+		if e[i].Defn != nil && e[j].Defn != nil {
+			return e[i].Defn.OpCode < e[j].Defn.OpCode
+		}
+		return e[i].AddressingMode < e[j].AddressingMode
+	})
+}
+
+// ByOpcode returns an entry for every opcode that has been executed in the
+// window. Entries are sorted by count, most frequent first.
+func (mx *Mix) ByOpcode() []Entry {
+	n, c := mx.totals()
+
+	var e []Entry
+	for _, d := range mx.defns {
+		if d == nil || mx.total[d.OpCode].instructions == 0 {
+			continue
+		}
+		t := mx.total[d.OpCode]
+		e = append(e, Entry{
+			Defn:           d,
+			AddressingMode: d.AddressingMode,
+			Count:          t.instructions,
+			Cycles:         t.cycles,
+			CountPercent:   percent(t.instructions, n),
+			CyclesPercent:  percent(t.cycles, c),
+		})
+	}
+
+	sortEntries(e)
+	return e
+}
+
+// ByAddressingMode returns an entry for every addressing mode that has been
+// used in the window. Entries are sorted by count, most frequent first.
+func (mx *Mix) ByAddressingMode() []Entry {
+	n, c := mx.totals()
+
+	modes := make(map[instructions.AddressingMode]*Entry)
+	for _, d := range mx.defns {
+		if d == nil || mx.total[d.OpCode].instructions == 0 {
+			continue
+		}
+		m, ok := modes[d.AddressingMode]
+		if !ok {
+			m = &Entry{AddressingMode: d.AddressingMode}
+			modes[d.AddressingMode] = m
+		}
+		m.Count += mx.total[d.OpCode].instructions
+		m.Cycles += mx.total[d.OpCode].cycles
+	}
+
+	var e []Entry
+	for _, m := range modes {
+		m.CountPercent = percent(m.Count, n)
+		m.CyclesPercent = percent(m.Cycles, c)
+		e = append(e, *m)
+	}
+
+	sortEntries(e)
+	return e
+}
+
+// WriteCSV writes the instruction mix, by opcode, to w in CSV format.
+func (mx *Mix) WriteCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+
+	err := c.Write([]string{"opcode", "mnemonic", "addressing mode", "count", "count %", "cycles", "cycles %"})
+	if err != nil {
+		return curated.Errorf("instruction mix: %v", err)
+	}
+
+	for _, e := range mx.ByOpcode() {
+		err = c.Write([]string{
+			fmt.Sprintf("$%02x", e.Defn.OpCode),
+			e.Defn.Mnemonic,
+			e.AddressingMode.String(),
+			fmt.Sprintf("%d", e.Count),
+			fmt.Sprintf("%.2f", e.CountPercent),
+			fmt.Sprintf("%d", e.Cycles),
+			fmt.Sprintf("%.2f", e.CyclesPercent),
+		})
+		if err != nil {
+			return curated.Errorf("instruction mix: %v", err)
+		}
+	}
+
+	c.Flush()
+	if err := c.Error(); err != nil {
+		return curated.Errorf("instruction mix: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package instructionmix_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/instructionmix"
+)
+
+func result(defns []*instructions.Definition, opcode uint8) execution.Result {
+	return execution.Result{
+		Defn:   defns[opcode],
+		Cycles: defns[opcode].Cycles,
+		Final:  true,
+	}
+}
+
+func TestWindow(t *testing.T) {
+	defns := instructions.GetDefinitions()
+
+	mx := instructionmix.NewMix()
+	mx.SetEnabled(true)
+	err := mx.SetWindow(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// frame 1: two LDA immediate and one NOP
+	mx.Record(result(defns, 0xa9))
+	mx.Record(result(defns, 0xa9))
+	mx.Record(result(defns, 0xea))
+
+	// frame 2: one NOP
+	_ = mx.NewFrame(television.FrameInfo{Stable: true})
+	mx.Record(result(defns, 0xea))
+
+	e := mx.ByOpcode()
+	if len(e) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(e))
+	}
+	if e[0].Count != 2 || e[1].Count != 2 {
+		t.Errorf("unexpected counts: %d and %d", e[0].Count, e[1].Count)
+	}
+
+	// ties are broken by opcode value
+	if e[0].Defn.OpCode != 0xa9 {
+		t.Errorf("unexpected first entry: %02x", e[0].Defn.OpCode)
+	}
+
+	// frame 3: frame 1 falls out of the window
+	_ = mx.NewFrame(television.FrameInfo{Stable: true})
+	e = mx.ByOpcode()
+	if len(e) != 1 || e[0].Defn.OpCode != 0xea || e[0].Count != 1 {
+		t.Fatalf("unexpected entries after window moved: %v", e)
+	}
+	if e[0].CountPercent != 100 {
+		t.Errorf("unexpected percentage: %.2f", e[0].CountPercent)
+	}
+
+	m := mx.ByAddressingMode()
+	if len(m) != 1 || m[0].AddressingMode != instructions.Implied {
+		t.Errorf("unexpected addressing mode entries: %v", m)
+	}
+
+	var b bytes.Buffer
+	err = mx.WriteCSV(&b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(b.String(), "$ea,NOP,implied,1,100.00") {
+		t.Errorf("unexpected CSV output: %s", b.String())
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	defns := instructions.GetDefinitions()
+
+	mx := instructionmix.NewMix()
+	mx.Record(result(defns, 0xea))
+	if len(mx.ByOpcode()) != 0 {
+		t.Errorf("instructions recorded while disabled")
+	}
+}
@@ -15,4 +15,8 @@
 
 // Package linter analyses disassembled code (from the disassembly package)
 // producing a lint report. As it is, it is a proof-of-concept and incomplete.
+//
+// The Run() function is more thorough. It runs the ROM headlessly and reports
+// problems with how the ROM uses the hardware, as well as the problems found
+// by Lint(). It is used by the LINT mode of the main program.
 package linter
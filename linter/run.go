@@ -0,0 +1,279 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/disassembly"
+	"github.com/jetsetilly/gopher2600/framehealth"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/memory/bus"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/setup"
+)
+
+// the number of frames after power-on during which writes to RAM are not
+// considered to be establishing a variable. ROMs will typically clear all of
+// RAM, including the stack area, during initialisation.
+const startupFrames = 5
+
+// problem categories. the order of the list is the order in which the
+// categories are output by Run().
+const (
+	categorySync = iota
+	categoryOpcode
+	categoryROMWrite
+	categoryStack
+	categoryCPUBug
+	categoryStatic
+)
+
+var categoryLabels = []string{
+	categorySync:     "sync",
+	categoryOpcode:   "opcode",
+	categoryROMWrite: "rom write",
+	categoryStack:    "stack",
+	categoryCPUBug:   "cpu bug",
+	categoryStatic:   "static",
+}
+
+// a single problem. identical problems are counted rather than reported
+// individually, with the coordinates of the first occurence kept for
+// reference.
+type problem struct {
+	category int
+	message  string
+	count    int
+
+	// order in which the problem was first found
+	seq int
+
+	frame    int
+	scanline int
+	horizpos int
+}
+
+func (p problem) String() string {
+	s := fmt.Sprintf("%s: %s (frame %d scanline %d horizpos %d)", categoryLabels[p.category], p.message, p.frame, p.scanline, p.horizpos)
+	if p.count > 1 {
+		s = fmt.Sprintf("%s x%d", s, p.count)
+	}
+	return s
+}
+
+type write struct {
+	address uint16
+	err     error
+}
+
+// snoop sits between the CPU and the VCS memory and records every write made
+// by the current instruction.
+type snoop struct {
+	*memory.Memory
+	writes []write
+}
+
+// Write implements the bus.CPUBus interface.
+func (s *snoop) Write(address uint16, data uint8) error {
+	err := s.Memory.Write(address, data)
+	s.writes = append(s.writes, write{address: address, err: err})
+	return err
+}
+
+// lineCounter prefixes and counts the lines written by Lint(). each call to
+// Write() is assumed to be a single line.
+type lineCounter struct {
+	w      io.Writer
+	prefix string
+	lines  int
+}
+
+func (l *lineCounter) Write(p []byte) (int, error) {
+	l.lines++
+	if _, err := l.w.Write([]byte(l.prefix)); err != nil {
+		return 0, err
+	}
+	return l.w.Write(p)
+}
+
+type runner struct {
+	vcs   *hardware.VCS
+	snoop *snoop
+
+	problems map[string]*problem
+
+	// RAM addresses written to as variables (ie. not by a stack operation
+	// and not within the stack)
+	variables [memorymap.MemtopRAM - memorymap.OriginRAM + 1]bool
+}
+
+func (r *runner) add(category int, message string) {
+	key := fmt.Sprintf("%d %s", category, message)
+	if p, ok := r.problems[key]; ok {
+		p.count++
+		return
+	}
+	r.problems[key] = &problem{
+		category: category,
+		message:  message,
+		count:    1,
+		seq:      len(r.problems),
+		frame:    r.vcs.TV.GetState(signal.ReqFramenum),
+		scanline: r.vcs.TV.GetState(signal.ReqScanline),
+		horizpos: r.vcs.TV.GetState(signal.ReqHorizPos),
+	}
+}
+
+// check the most recently executed instruction.
+func (r *runner) check(frame int) (bool, error) {
+	res := r.vcs.CPU.LastResult
+	if res.Defn == nil {
+		r.snoop.writes = r.snoop.writes[:0]
+		return true, nil
+	}
+
+	if res.Defn.IsUndocumented() {
+		r.add(categoryOpcode, fmt.Sprintf("undocumented opcode %s at %#04x", res.Defn.Mnemonic, res.Address))
+	}
+
+	if res.CPUBug != "" {
+		r.add(categoryCPUBug, fmt.Sprintf("%s at %#04x", res.CPUBug, res.Address))
+	}
+
+	var push bool
+	switch res.Defn.Mnemonic {
+	case "PHA", "PHP", "JSR", "BRK":
+		push = true
+	}
+
+	// top of the stack after the instruction has executed. for push
+	// instructions this will be below the addresses just written to
+	sp := uint16(r.vcs.CPU.SP.Value())
+
+	for _, w := range r.snoop.writes {
+		ma, area := memorymap.MapAddress(w.address, false)
+
+		switch area {
+		case memorymap.Cartridge:
+			if w.err != nil && curated.Has(w.err, bus.AddressError) {
+				r.add(categoryROMWrite, fmt.Sprintf("write to ROM address %#04x by instruction at %#04x", w.address, res.Address))
+			}
+
+		case memorymap.RAM:
+			v := ma - memorymap.OriginRAM
+			if push {
+				if r.variables[v] {
+					r.add(categoryStack, fmt.Sprintf("push by instruction at %#04x overwrote variable at %#02x", res.Address, ma))
+				}
+			} else if frame >= startupFrames && ma&0xff <= sp {
+				r.variables[v] = true
+			}
+		}
+	}
+
+	r.snoop.writes = r.snoop.writes[:0]
+
+	return true, nil
+}
+
+// Run the cartridge headlessly for the specified number of frames and output
+// an aggregate report of the hardware misuse problems found. Problems
+// include:
+//
+//	non-standard frame sync (see framehealth package)
+//	use of undocumented opcodes
+//	writes to cartridge ROM
+//	stack pushes overwriting RAM that has been used as a variable
+//	instructions that trigger known 6502 bugs
+//
+// The problems found by Lint() are also included in the report.
+//
+// Returns the number of distinct problems found.
+func Run(cartload cartridgeloader.Loader, spec string, frames int, output io.Writer) (int, error) {
+	tv, err := television.NewTelevision(spec)
+	if err != nil {
+		return 0, curated.Errorf("linter: %v", err)
+	}
+	defer tv.End()
+	tv.SetFPSCap(false)
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		return 0, curated.Errorf("linter: %v", err)
+	}
+
+	r := &runner{
+		vcs:      vcs,
+		problems: make(map[string]*problem),
+	}
+
+	_ = framehealth.NewChecker(tv, func(w framehealth.Warning) {
+		r.add(categorySync, w.Message)
+	})
+
+	err = setup.AttachCartridge(vcs, cartload)
+	if err != nil {
+		return 0, curated.Errorf("linter: %v", err)
+	}
+
+	r.snoop = &snoop{Memory: vcs.Mem}
+	vcs.CPU.Plumb(r.snoop)
+
+	err = vcs.RunForFrameCount(frames, r.check)
+	if err != nil {
+		return 0, curated.Errorf("linter: %v", err)
+	}
+
+	problems := make([]*problem, 0, len(r.problems))
+	for _, p := range r.problems {
+		problems = append(problems, p)
+	}
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].category == problems[j].category {
+			return problems[i].seq < problems[j].seq
+		}
+		return problems[i].category < problems[j].category
+	})
+
+	for _, p := range problems {
+		output.Write([]byte(p.String()))
+		output.Write([]byte("\n"))
+	}
+
+	num := len(problems)
+
+	// static analysis of the disassembly
+	dsm, err := disassembly.FromCartridge(cartload)
+	if err != nil {
+		return num, curated.Errorf("linter: %v", err)
+	}
+
+	static := &lineCounter{w: output, prefix: categoryLabels[categoryStatic] + ": "}
+	err = Lint(dsm, static)
+	if err != nil {
+		return num, err
+	}
+
+	return num + static.lines, nil
+}
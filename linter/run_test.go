@@ -0,0 +1,102 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package linter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/linter"
+)
+
+// a 4k ROM with a correctly formed 262 scanline frame. after the overscan of
+// every frame it: executes an undocumented NOP; writes to ROM; writes to a
+// variable at the very top of RAM; and then calls a subroutine, overwriting
+// the variable with the return address.
+const program = `
+sei
+cld
+ldx #$ff
+txs
+lda #2
+sta $00
+sta $02
+sta $02
+sta $02
+lda #0
+sta $00
+lda #2
+sta $01
+ldx #37
+sta $02
+dex
+bne $f019
+lda #0
+sta $01
+ldx #192
+sta $02
+dex
+bne $f024
+lda #2
+sta $01
+ldx #30
+sta $02
+dex
+bne $f02f
+nop $80
+sta $f000
+sta $fe
+jsr $f041
+jmp $f005
+rts`
+
+func TestRun(t *testing.T) {
+	data, err := assembler.Cartridge(program)
+	if err != nil {
+		t.Fatalf("unexpected error assembling program: %v", err)
+	}
+
+	cartload := cartridgeloader.Loader{
+		Filename: "lint_test.bin",
+		Mapping:  "4k",
+		Data:     data,
+	}
+
+	output := &strings.Builder{}
+	num, err := linter.Run(cartload, "NTSC", 20, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := strings.Split(strings.TrimSpace(output.String()), "\n")
+	expected := []string{
+		"opcode: undocumented opcode nop at 0xf034",
+		"rom write: write to ROM address 0xf000 by instruction at 0xf036",
+		"stack: push by instruction at 0xf03b overwrote variable at 0xfe",
+	}
+
+	if num != len(expected) || len(report) != len(expected) {
+		t.Fatalf("expected %d problems, got %d: %v", len(expected), num, report)
+	}
+
+	for i := range expected {
+		if !strings.HasPrefix(report[i], expected[i]) {
+			t.Errorf("unexpected problem (%s) should be (%s)", report[i], expected[i])
+		}
+	}
+}
@@ -102,11 +102,11 @@ func newLogger(maxEntries int) *logger {
 				if n < 0 || n > len(entries) {
 					n = len(entries)
 				}
-				l.entries <- entries[len(entries)-n:]
+				l.entries <- copyEntries(entries[len(entries)-n:])
 
 			case v := <-l.recent:
 				if v {
-					l.entries <- entries[l.lastRecent:]
+					l.entries <- copyEntries(entries[l.lastRecent:])
 					l.lastRecent = len(entries)
 				}
 
@@ -123,6 +123,15 @@ func newLogger(maxEntries int) *logger {
 	return l
 }
 
+// entries are sent over the entries channel as a copy because the service
+// goroutine may continue to modify the underlying array. this is possible
+// when the log is being used by more than one emulation in the same process.
+func copyEntries(entries []Entry) []Entry {
+	c := make([]Entry, len(entries))
+	copy(c, entries)
+	return c
+}
+
 func (l *logger) log(tag, detail string) {
 	// remove first part of the details string if it's the same as the tag
 	p := strings.SplitN(detail, ": ", 3)
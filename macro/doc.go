@@ -0,0 +1,27 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package macro records and plays back short sequences of input events. A
+// macro might be a perfectly timed jump in Pitfall or a cheat code entered on
+// the keypad. The timing of each event is recorded as the number of frames
+// since the start of the macro.
+//
+// Macros are stored in a file named after the hash of the cartridge for which
+// they were recorded. There are NumSlots slots available for each cartridge.
+//
+// The Macros type implements the ports.EventRecorder interface. It should be
+// attached to the VCS ports with AttachEventRecorder() and will record events
+// only when a recording has been started with the Record() function.
+package macro
@@ -0,0 +1,163 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package macro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+)
+
+// Step is a single event in a macro.
+type Step struct {
+	// number of frames since the start of the macro
+	Frame int
+
+	ID    ports.PortID
+	Event ports.Event
+	Data  ports.EventData
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("%d,%d,%s,%s", s.Frame, s.ID, s.Event, encodeData(s.Data))
+}
+
+// Macro is a sequence of input events.
+type Macro []Step
+
+// String returns the macro in a form suitable for storing in the preferences
+// file. The string can be converted back to a Macro with the Parse()
+// function.
+func (m Macro) String() string {
+	s := strings.Builder{}
+	for i, st := range m {
+		if i > 0 {
+			s.WriteString(stepSep)
+		}
+		s.WriteString(st.String())
+	}
+	return s.String()
+}
+
+// the separator between steps in the string representation of a macro.
+const stepSep = ";"
+
+// Parse a string as produced by Macro.String(). An empty string results in an
+// empty macro.
+func Parse(s string) (Macro, error) {
+	m := Macro{}
+
+	if s == "" {
+		return m, nil
+	}
+
+	for _, st := range strings.Split(s, stepSep) {
+		f := strings.Split(st, ",")
+		if len(f) != 4 {
+			return nil, curated.Errorf("macro: %v", fmt.Sprintf("malformed step (%s)", st))
+		}
+
+		frame, err := strconv.Atoi(f[0])
+		if err != nil {
+			return nil, curated.Errorf("macro: %v", fmt.Sprintf("malformed frame (%s)", f[0]))
+		}
+
+		id, err := strconv.Atoi(f[1])
+		if err != nil {
+			return nil, curated.Errorf("macro: %v", fmt.Sprintf("malformed port (%s)", f[1]))
+		}
+
+		d, err := decodeData(f[3])
+		if err != nil {
+			return nil, curated.Errorf("macro: %v", err)
+		}
+
+		m = append(m, Step{
+			Frame: frame,
+			ID:    ports.PortID(id),
+			Event: ports.Event(f[2]),
+			Data:  d,
+		})
+	}
+
+	return m, nil
+}
+
+// event data is encoded with a single character prefix indicating the type.
+// this is important because the data for some events is a rune and others a
+// float32 and we can't tell them apart from the string representation alone.
+func encodeData(d ports.EventData) string {
+	switch d := d.(type) {
+	case bool:
+		return fmt.Sprintf("b%v", d)
+	case float32:
+		return fmt.Sprintf("f%v", d)
+	case float64:
+		return fmt.Sprintf("f%v", d)
+	case rune:
+		return fmt.Sprintf("r%d", d)
+	case int:
+		return fmt.Sprintf("i%d", d)
+	case nil:
+		return "-"
+	}
+	return fmt.Sprintf("s%v", d)
+}
+
+func decodeData(s string) (ports.EventData, error) {
+	if s == "-" {
+		return nil, nil
+	}
+	if len(s) < 2 {
+		return nil, fmt.Errorf("malformed event data (%s)", s)
+	}
+
+	v := s[1:]
+
+	switch s[0] {
+	case 'b':
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("malformed event data (%s)", s)
+		}
+		return b, nil
+	case 'f':
+		f, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed event data (%s)", s)
+		}
+		return float32(f), nil
+	case 'r':
+		r, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("malformed event data (%s)", s)
+		}
+		return rune(r), nil
+	case 'i':
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("malformed event data (%s)", s)
+		}
+		return i, nil
+	case 's':
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("malformed event data (%s)", s)
+}
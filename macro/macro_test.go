@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package macro_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/macro"
+	"github.com/jetsetilly/gopher2600/paths"
+)
+
+func TestNewMacros(t *testing.T) {
+	// keep macro files out of the real resource directory
+	paths.SetPortableRoot(t.TempDir())
+	paths.SetPortable(true)
+	defer func() {
+		paths.SetPortable(false)
+		paths.SetPortableRoot("")
+	}()
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{
+		Filename: "macro_test",
+		Mapping:  "4k",
+		Data:     make([]byte, 4096),
+		Hash:     "4ea2ed2b1ec0e7e2a16e0ea47d3a2e52a0ff3c65",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	_, err = macro.NewMacros(vcs)
+	if err != nil {
+		t.Fatalf("unexpected error creating macros: %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	m := macro.Macro{
+		{Frame: 0, ID: ports.Player0ID, Event: ports.Right, Data: true},
+		{Frame: 3, ID: ports.Player0ID, Event: ports.Fire, Data: true},
+		{Frame: 5, ID: ports.Player0ID, Event: ports.PaddleSet, Data: float32(0.25)},
+		{Frame: 9, ID: ports.Player1ID, Event: ports.KeyboardDown, Data: '#'},
+		{Frame: 10, ID: ports.Player1ID, Event: ports.KeyboardUp, Data: nil},
+		{Frame: 12, ID: ports.PanelID, Event: ports.PanelReset, Data: false},
+	}
+
+	s := m.String()
+
+	n, err := macro.Parse(s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(n) != len(m) {
+		t.Fatalf("expected %d steps, got %d", len(m), len(n))
+	}
+
+	for i := range m {
+		if m[i] != n[i] {
+			t.Errorf("step %d: expected %v, got %v", i, m[i], n[i])
+		}
+	}
+
+	// empty string is an empty macro
+	n, err = macro.Parse("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(n) != 0 {
+		t.Errorf("expected empty macro, got %d steps", len(n))
+	}
+
+	// malformed strings
+	for _, s := range []string{"0,1", "x,0,Fire,btrue", "0,0,Fire,q", "0,0,Fire,btrue;1"} {
+		_, err = macro.Parse(s)
+		if err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
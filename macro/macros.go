@@ -0,0 +1,224 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package macro
+
+import (
+	"fmt"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// NumSlots is the number of macros that can be stored for each cartridge.
+const NumSlots = 4
+
+// the resource path in which macro files are stored.
+const macroPath = "macros"
+
+// Macros records and plays back macros for the cartridge currently attached
+// to the VCS.
+type Macros struct {
+	vcs *hardware.VCS
+	dsk *prefs.Disk
+
+	slots [NumSlots]Macro
+
+	// the slot being recorded. -1 if no recording is taking place
+	recording int
+
+	// the frame number at which the recording started
+	recordingFrame int
+
+	// the macro being played back. nil if no macro is being played
+	playing Macro
+
+	// the frame number at which playback started
+	playingFrame int
+}
+
+// NewMacros is the preferred method of initialisation for the Macros type.
+// The cartridge should be attached to the VCS before calling this function.
+func NewMacros(vcs *hardware.VCS) (*Macros, error) {
+	mcr := &Macros{
+		vcs:       vcs,
+		recording: -1,
+	}
+
+	// macros can still be recorded and played without a cartridge hash but
+	// they will not be saved to disk
+	if vcs.Mem.Cart.Hash == "" {
+		return mcr, nil
+	}
+
+	pth, err := paths.ResourcePath(macroPath, vcs.Mem.Cart.Hash)
+	if err != nil {
+		return nil, curated.Errorf("macro: %v", err)
+	}
+
+	mcr.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, curated.Errorf("macro: %v", err)
+	}
+
+	for i := range mcr.slots {
+		slot := i
+		// preference keys can only contain letters so the slot is identified
+		// with a letter rather than a number
+		err = mcr.dsk.Add(fmt.Sprintf("macro.slot%c", 'A'+slot), prefs.NewGeneric(
+			func(s string) error {
+				m, err := Parse(s)
+				if err != nil {
+					return err
+				}
+				mcr.slots[slot] = m
+				return nil
+			},
+			func() string {
+				return mcr.slots[slot].String()
+			},
+		))
+		if err != nil {
+			return nil, curated.Errorf("macro: %v", err)
+		}
+	}
+
+	err = mcr.dsk.Load(false)
+	if err != nil {
+		return nil, curated.Errorf("macro: %v", err)
+	}
+
+	return mcr, nil
+}
+
+func (mcr *Macros) frameNum() int {
+	return mcr.vcs.TV.GetState(signal.ReqFramenum)
+}
+
+// IsRecording returns true if a macro is being recorded.
+func (mcr *Macros) IsRecording() bool {
+	return mcr.recording != -1
+}
+
+// Record starts recording the specified slot. Any macro currently stored in
+// the slot will be replaced when the recording is stopped.
+func (mcr *Macros) Record(slot int) error {
+	if slot < 0 || slot >= NumSlots {
+		return curated.Errorf("macro: %v", fmt.Sprintf("no such slot (%d)", slot))
+	}
+
+	mcr.playing = nil
+	mcr.recording = slot
+	mcr.recordingFrame = mcr.frameNum()
+	mcr.slots[slot] = Macro{}
+
+	logger.Log("macro", fmt.Sprintf("recording slot %d", slot))
+
+	return nil
+}
+
+// Stop recording and save the recorded macro to disk.
+func (mcr *Macros) Stop() error {
+	if mcr.recording == -1 {
+		return nil
+	}
+
+	logger.Log("macro", fmt.Sprintf("recorded slot %d (%d steps)", mcr.recording, len(mcr.slots[mcr.recording])))
+	mcr.recording = -1
+
+	if mcr.dsk == nil {
+		return nil
+	}
+
+	err := mcr.dsk.Save()
+	if err != nil {
+		return curated.Errorf("macro: %v", err)
+	}
+
+	return nil
+}
+
+// Play the macro in the specified slot. Events in the macro will be sent to
+// the VCS ports by the Check() function. Playing a slot with no macro does
+// nothing.
+func (mcr *Macros) Play(slot int) error {
+	if slot < 0 || slot >= NumSlots {
+		return curated.Errorf("macro: %v", fmt.Sprintf("no such slot (%d)", slot))
+	}
+
+	if mcr.recording != -1 || len(mcr.slots[slot]) == 0 {
+		return nil
+	}
+
+	mcr.playing = mcr.slots[slot]
+	mcr.playingFrame = mcr.frameNum()
+
+	return nil
+}
+
+// Check should be called regularly, ideally after every CPU instruction.
+// Events in the macro being played back that are now due are sent to the VCS
+// ports. Returns true if any events have been sent.
+func (mcr *Macros) Check() (bool, error) {
+	if len(mcr.playing) == 0 {
+		return false, nil
+	}
+
+	fn := mcr.frameNum() - mcr.playingFrame
+
+	var sent bool
+
+	for len(mcr.playing) > 0 && mcr.playing[0].Frame <= fn {
+		st := mcr.playing[0]
+		mcr.playing = mcr.playing[1:]
+
+		err := mcr.vcs.RIOT.Ports.HandleEvent(st.ID, st.Event, st.Data)
+		if err != nil {
+			return sent, err
+		}
+		sent = true
+	}
+
+	return sent, nil
+}
+
+// RecordEvent implements the ports.EventRecorder interface.
+func (mcr *Macros) RecordEvent(id ports.PortID, ev ports.Event, d ports.EventData) error {
+	// do not record events if we're not recording or if the event has been
+	// caused by playback of another macro
+	if mcr.recording == -1 || len(mcr.playing) > 0 {
+		return nil
+	}
+
+	// the first event in the macro always happens on frame zero
+	m := mcr.slots[mcr.recording]
+	if len(m) == 0 {
+		mcr.recordingFrame = mcr.frameNum()
+	}
+
+	mcr.slots[mcr.recording] = append(m, Step{
+		Frame: mcr.frameNum() - mcr.recordingFrame,
+		ID:    id,
+		Event: ev,
+		Data:  d,
+	})
+
+	return nil
+}
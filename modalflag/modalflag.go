@@ -173,6 +173,10 @@ func (md *Modes) Parse() (ParseResult, error) {
 			return ParseError, err
 		}
 	} else if len(md.subModes) > 0 {
+		// skip over the flags that have been consumed by this mode so that
+		// they are not seen again by the sub-mode
+		md.argsIdx = len(md.args) - md.flags.NArg()
+
 		arg := strings.ToUpper(md.flags.Arg(0))
 
 		// check to see if the single argument is in the list of modes,
@@ -156,3 +156,36 @@ func TestHelpFlagsAndModes(t *testing.T) {
 		t.Error("unexpected help message")
 	}
 }
+
+func TestFlagsAndModes(t *testing.T) {
+	md := modalflag.Modes{Output: os.Stdout}
+	md.NewArgs([]string{"-test", "B", "-sub", "1"})
+	testFlag := md.AddBool("test", false, "test flag")
+	md.AddSubModes("A", "B", "C")
+
+	p, err := md.Parse()
+	if p != modalflag.ParseContinue || err != nil {
+		t.Fatalf("unexpected parse result: %v", err)
+	}
+	if !*testFlag {
+		t.Error("expected *testFlag to be true after Parse()")
+	}
+	if md.Mode() != "B" {
+		t.Errorf("expected mode B, got %s", md.Mode())
+	}
+
+	// flags of the previous mode should not be seen by the new mode
+	md.NewMode()
+	subFlag := md.AddBool("sub", false, "sub flag")
+
+	p, err = md.Parse()
+	if p != modalflag.ParseContinue || err != nil {
+		t.Fatalf("unexpected parse result: %v", err)
+	}
+	if !*subFlag {
+		t.Error("expected *subFlag to be true after Parse()")
+	}
+	if len(md.RemainingArgs()) != 1 || md.GetArg(0) != "1" {
+		t.Errorf("unexpected remaining arguments: %v", md.RemainingArgs())
+	}
+}
@@ -0,0 +1,159 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build linux
+
+// Package alsa is a television.AudioMixer that plays sound through ALSA
+// without the need for SDL. Samples are piped to the aplay command, which is
+// part of the standard alsa-utils package on most Linux distributions.
+//
+// The package registers itself with the outputs package as "ALSA". The option
+// string is the ALSA device to use. If no option is given then the default
+// device is used. For example:
+//
+//	gopher2600 -output FBDEV,ALSA:plughw:1 rom.bin
+//
+// Samples are sent in small chunks to keep latency low. If aplay cannot keep
+// up with the emulation then chunks are dropped rather than slowing the
+// emulation down.
+package alsa
+
+import (
+	"io"
+	"os/exec"
+	"strconv"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	tiaAudio "github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/outputs"
+)
+
+// number of samples sent to aplay at once. at the TIA sample frequency this
+// is about 8ms of sound.
+const chunkSize = 256
+
+// size of the aplay buffer in microseconds.
+const bufferTime = 40000
+
+// number of chunks that can be queued before chunks are dropped.
+const queueLength = 8
+
+func init() {
+	err := outputs.Register("ALSA", "play sound through ALSA. option is the device (optional)", func(tv *television.Television, options string) error {
+		m, err := NewMixer(options)
+		if err != nil {
+			return err
+		}
+		tv.AddAudioMixer(m)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Mixer implements the television.AudioMixer interface.
+type Mixer struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	chunk []uint8
+	queue chan []uint8
+	done  chan bool
+}
+
+// NewMixer is the preferred method of initialisation for the Mixer type. An
+// empty device string indicates that the default ALSA device should be used.
+func NewMixer(device string) (*Mixer, error) {
+	args := []string{
+		"-q", "-t", "raw", "-f", "U8", "-c", "1",
+		"-r", strconv.Itoa(tiaAudio.SampleFreq),
+		"--buffer-time", strconv.Itoa(bufferTime),
+	}
+	if device != "" {
+		args = append(args, "-D", device)
+	}
+
+	m := &Mixer{
+		cmd:   exec.Command("aplay", args...),
+		chunk: make([]uint8, 0, chunkSize),
+		queue: make(chan []uint8, queueLength),
+		done:  make(chan bool),
+	}
+
+	var err error
+
+	m.stdin, err = m.cmd.StdinPipe()
+	if err != nil {
+		return nil, curated.Errorf("alsa: %v", err)
+	}
+
+	err = m.cmd.Start()
+	if err != nil {
+		return nil, curated.Errorf("alsa: %v", err)
+	}
+
+	go func() {
+		for c := range m.queue {
+			_, err := m.stdin.Write(c)
+			if err != nil {
+				logger.Log("alsa", err.Error())
+				break // for loop
+			}
+		}
+
+		// drain queue in case writing failed
+		for range m.queue {
+		}
+
+		m.done <- true
+	}()
+
+	return m, nil
+}
+
+// SetAudio implements the television.AudioMixer interface.
+func (m *Mixer) SetAudio(audioData uint8) error {
+	m.chunk = append(m.chunk, audioData)
+	if len(m.chunk) >= chunkSize {
+		select {
+		case m.queue <- m.chunk:
+		default:
+			// drop chunk if queue is full
+		}
+		m.chunk = make([]uint8, 0, chunkSize)
+	}
+	return nil
+}
+
+// EndMixing implements the television.AudioMixer interface.
+func (m *Mixer) EndMixing() error {
+	close(m.queue)
+	<-m.done
+
+	err := m.stdin.Close()
+	if err != nil {
+		return curated.Errorf("alsa: %v", err)
+	}
+
+	err = m.cmd.Wait()
+	if err != nil {
+		return curated.Errorf("alsa: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package outputs is a registry of output backends for the television. An
+// output backend is anything that implements one or more of the
+// television.PixelRenderer, television.AudioMixer or television.FrameTrigger
+// interfaces. For example, a driver for an LED matrix or an audio recorder.
+//
+// Backends register themselves with the Register() function, usually from
+// the init() function of the backend's package. Once registered, a backend
+// can be attached to a television by name with the Attach() function. The
+// main gopher2600 program does this with the -output flag. For example:
+//
+//	gopher2600 -output WAV:recording.wav rom.bin
+//
+// Backends do not need to be part of the main gopher2600 source tree. A
+// backend package can be included in a build in one of two ways:
+//
+// 1. By a blank import, which will cause the package's init() function to be
+// called. The import can be placed in its own file and guarded by a build tag
+// so that it is only included when required. For example, a file containing:
+//
+//	// +build ledmatrix
+//
+//	package main
+//
+//	import _ "example.com/ledmatrix"
+//
+// 2. As a Go plugin (see the plugin package in the standard library) placed
+// in the plugins directory of the resource path (see paths package). Plugins
+// are loaded with the LoadPlugins() function. Plugin support depends on the
+// platform and is not available on all systems.
+package outputs
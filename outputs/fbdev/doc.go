@@ -0,0 +1,33 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package fbdev is a television.PixelRenderer that draws directly to a Linux
+// framebuffer device. It does not require X or Wayland and is intended for
+// dedicated machines like a Raspberry Pi running without a desktop
+// environment.
+//
+// The package registers itself with the outputs package as "FBDEV". The
+// option string is the framebuffer device to use. If no option is given then
+// /dev/fb0 is used. For example:
+//
+//	gopher2600 -output FBDEV:/dev/fb1 rom.bin
+//
+// The visible portion of the television image is scaled by the largest whole
+// number that fits the framebuffer and centred. Framebuffers with 16 (RGB565)
+// or 32 bits per pixel are supported.
+//
+// Note that the package only renders the image. It does not handle user
+// input. See the alsa package for sound output.
+package fbdev
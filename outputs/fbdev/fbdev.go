@@ -0,0 +1,329 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build linux
+
+package fbdev
+
+import (
+	"image/color"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/outputs"
+)
+
+// the default framebuffer device.
+const defaultDevice = "/dev/fb0"
+
+// ioctl requests from linux/fb.h.
+const (
+	ioctlGetVarScreenInfo = 0x4600
+	ioctlGetFixScreenInfo = 0x4602
+)
+
+// fbBitfield is the fb_bitfield structure from linux/fb.h.
+type fbBitfield struct {
+	Offset   uint32
+	Length   uint32
+	MsbRight uint32
+}
+
+// fbVarScreenInfo is the fb_var_screeninfo structure from linux/fb.h.
+type fbVarScreenInfo struct {
+	XRes         uint32
+	YRes         uint32
+	XResVirtual  uint32
+	YResVirtual  uint32
+	XOffset      uint32
+	YOffset      uint32
+	BitsPerPixel uint32
+	Grayscale    uint32
+	Red          fbBitfield
+	Green        fbBitfield
+	Blue         fbBitfield
+	Transp       fbBitfield
+	NonStd       uint32
+	Activate     uint32
+	Height       uint32
+	Width        uint32
+	AccelFlags   uint32
+	PixClock     uint32
+	LeftMargin   uint32
+	RightMargin  uint32
+	UpperMargin  uint32
+	LowerMargin  uint32
+	HSyncLen     uint32
+	VSyncLen     uint32
+	Sync         uint32
+	VMode        uint32
+	Rotate       uint32
+	Colorspace   uint32
+	Reserved     [4]uint32
+}
+
+// fbFixScreenInfo is the fb_fix_screeninfo structure from linux/fb.h.
+type fbFixScreenInfo struct {
+	ID           [16]byte
+	SmemStart    uintptr
+	SmemLen      uint32
+	Type         uint32
+	TypeAux      uint32
+	Visual       uint32
+	XPanStep     uint16
+	YPanStep     uint16
+	YWrapStep    uint16
+	LineLength   uint32
+	MmioStart    uintptr
+	MmioLen      uint32
+	Accel        uint32
+	Capabilities uint16
+	Reserved     [2]uint16
+}
+
+func init() {
+	err := outputs.Register("FBDEV", "draw to linux framebuffer. option is the device (default /dev/fb0)", func(tv *television.Television, options string) error {
+		if options == "" {
+			options = defaultDevice
+		}
+		fb, err := NewFramebuffer(options)
+		if err != nil {
+			return err
+		}
+		tv.AddPixelRenderer(fb)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Framebuffer implements the television.PixelRenderer interface.
+type Framebuffer struct {
+	f   *os.File
+	mem []byte
+
+	vinfo fbVarScreenInfo
+	finfo fbFixScreenInfo
+
+	// bytes per pixel in the framebuffer
+	bpp int
+
+	// the image is drawn to back before being copied to mem in one go. this
+	// reduces tearing
+	back []byte
+
+	spec        specification.Spec
+	topScanline int
+	scanlines   int
+
+	// colors of the visible portion of the television image
+	pixels [specification.HorizClksVisible * television.MaxScanlinesAbsolute]color.RGBA
+}
+
+// NewFramebuffer is the preferred method of initialisation for the
+// Framebuffer type.
+func NewFramebuffer(device string) (*Framebuffer, error) {
+	fb := &Framebuffer{
+		spec:      specification.SpecNTSC,
+		scanlines: specification.SpecNTSC.ScanlinesVisible,
+	}
+
+	var err error
+
+	fb.f, err = os.OpenFile(device, os.O_RDWR, 0)
+	if err != nil {
+		return nil, curated.Errorf("fbdev: %v", err)
+	}
+
+	err = fb.ioctl(ioctlGetVarScreenInfo, unsafe.Pointer(&fb.vinfo))
+	if err != nil {
+		fb.f.Close()
+		return nil, curated.Errorf("fbdev: %v", err)
+	}
+
+	err = fb.ioctl(ioctlGetFixScreenInfo, unsafe.Pointer(&fb.finfo))
+	if err != nil {
+		fb.f.Close()
+		return nil, curated.Errorf("fbdev: %v", err)
+	}
+
+	switch fb.vinfo.BitsPerPixel {
+	case 16:
+		fb.bpp = 2
+	case 32:
+		fb.bpp = 4
+	default:
+		fb.f.Close()
+		return nil, curated.Errorf("fbdev: unsupported bits per pixel (%d)", fb.vinfo.BitsPerPixel)
+	}
+
+	sz := int(fb.finfo.LineLength) * int(fb.vinfo.YRes)
+	fb.mem, err = syscall.Mmap(int(fb.f.Fd()), 0, sz, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		fb.f.Close()
+		return nil, curated.Errorf("fbdev: %v", err)
+	}
+
+	fb.back = make([]byte, len(fb.mem))
+
+	return fb, nil
+}
+
+func (fb *Framebuffer) ioctl(req uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fb.f.Fd(), req, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Resize implements the television.PixelRenderer interface.
+func (fb *Framebuffer) Resize(spec specification.Spec, topScanline int, visibleScanlines int) error {
+	fb.spec = spec
+	fb.topScanline = topScanline
+	fb.scanlines = visibleScanlines
+
+	// clear the screen because the image may now be smaller than it was
+	for i := range fb.back {
+		fb.back[i] = 0
+	}
+
+	return nil
+}
+
+// NewFrame implements the television.PixelRenderer interface.
+func (fb *Framebuffer) NewFrame(_ television.FrameInfo) error {
+	fb.draw()
+	return nil
+}
+
+// NewScanline implements the television.PixelRenderer interface.
+func (fb *Framebuffer) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements the television.PixelRenderer interface.
+func (fb *Framebuffer) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements the television.PixelRenderer interface.
+func (fb *Framebuffer) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	x := sig.HorizPos() - specification.HorizClksHBlank
+	y := sig.Scanline() - fb.topScanline
+	if x < 0 || y < 0 || y >= fb.scanlines || x >= specification.HorizClksVisible {
+		return nil
+	}
+
+	col := color.RGBA{A: 255}
+	if !sig.VBlank() {
+		col = fb.spec.GetColor(sig.Pixel())
+	}
+	fb.pixels[y*specification.HorizClksVisible+x] = col
+
+	return nil
+}
+
+// Reset implements the television.PixelRenderer interface.
+func (fb *Framebuffer) Reset() {
+	for i := range fb.pixels {
+		fb.pixels[i] = color.RGBA{A: 255}
+	}
+}
+
+// EndRendering implements the television.PixelRenderer interface.
+func (fb *Framebuffer) EndRendering() error {
+	err := syscall.Munmap(fb.mem)
+	if err != nil {
+		return curated.Errorf("fbdev: %v", err)
+	}
+	err = fb.f.Close()
+	if err != nil {
+		return curated.Errorf("fbdev: %v", err)
+	}
+	return nil
+}
+
+// pack color into the pixel format of the framebuffer.
+func (fb *Framebuffer) pack(col color.RGBA) uint32 {
+	v := &fb.vinfo
+	return uint32(col.R)>>(8-v.Red.Length)<<v.Red.Offset |
+		uint32(col.G)>>(8-v.Green.Length)<<v.Green.Offset |
+		uint32(col.B)>>(8-v.Blue.Length)<<v.Blue.Offset
+}
+
+// draw the visible image to the framebuffer.
+func (fb *Framebuffer) draw() {
+	// a color clock is roughly twice as wide as it is tall on a television
+	// screen
+	const pixelWidth = 2
+
+	w := int(fb.vinfo.XRes)
+	h := int(fb.vinfo.YRes)
+	scale := w / (specification.HorizClksVisible * pixelWidth)
+	if s := h / fb.scanlines; s < scale {
+		scale = s
+	}
+	if scale < 1 {
+		scale = 1
+	}
+
+	pw := scale * pixelWidth
+	ox := (w - specification.HorizClksVisible*pw) / 2
+	oy := (h - fb.scanlines*scale) / 2
+	if ox < 0 {
+		ox = 0
+	}
+	if oy < 0 {
+		oy = 0
+	}
+
+	stride := int(fb.finfo.LineLength)
+
+	for y := 0; y < fb.scanlines; y++ {
+		for x := 0; x < specification.HorizClksVisible; x++ {
+			p := fb.pack(fb.pixels[y*specification.HorizClksVisible+x])
+			for sy := 0; sy < scale; sy++ {
+				row := (oy + y*scale + sy) * stride
+				for sx := 0; sx < pw; sx++ {
+					col := ox + x*pw + sx
+					if col >= w {
+						break // for loop
+					}
+					i := row + col*fb.bpp
+					if i+fb.bpp > len(fb.back) {
+						break // for loop
+					}
+					if fb.bpp == 2 {
+						fb.back[i] = uint8(p)
+						fb.back[i+1] = uint8(p >> 8)
+					} else {
+						fb.back[i] = uint8(p)
+						fb.back[i+1] = uint8(p >> 8)
+						fb.back[i+2] = uint8(p >> 16)
+						fb.back[i+3] = 0xff
+					}
+				}
+			}
+		}
+	}
+
+	copy(fb.mem, fb.back)
+}
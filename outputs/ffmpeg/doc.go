@@ -0,0 +1,49 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package ffmpeg encodes the television output as a video file. Frames and
+// audio are piped to the ffmpeg command, which must be installed separately
+// and be available on the PATH (or be named by the Executable variable).
+//
+// The Encoder type is both a television.PixelRenderer and a
+// television.AudioMixer. Frames are sent to ffmpeg as raw RGBA images and
+// audio as unsigned 8bit mono samples. The container and codecs are chosen
+// by ffmpeg according to the extension of the output filename.
+//
+// The package registers itself with the outputs package as "FFMPEG". The
+// option string is the output filename followed by any number of options,
+// separated by semi-colons. For example:
+//
+//	gopher2600 -output "FFMPEG:game.mp4;scale=4;phosphor" rom.bin
+//
+// The available options are:
+//
+//	scale=N     integer scaling of the television image
+//	phosphor    simulate the persistence of the television phosphor
+//	composite   simulate the artifacts of an NTSC composite connection
+//
+// Each television pixel is twice as wide as it is high, so the width of the
+// video is HorizClksVisible * 2 * scale pixels.
+//
+// Encoding starts with the first frame after the television has stabilised
+// and the size and frame rate of the video are fixed at that point. Audio
+// and video produced before then is discarded.
+//
+// When encoding with the -output flag the emulation runs at its normal
+// speed. For offline encoding of a recorded playback, with no limit on the
+// speed of the emulation, see the RENDER mode of the gopher2600 command.
+// The RENDER mode can also burn the joystick and panel input into the video
+// with the AttachInput() function.
+package ffmpeg
@@ -0,0 +1,528 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package ffmpeg
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/phosphor"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	tiaAudio "github.com/jetsetilly/gopher2600/hardware/tia/audio"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/outputs"
+)
+
+// Executable is the name of the ffmpeg command. If the name does not contain
+// a path separator then the command is looked for in the directories named by
+// the PATH environment variable.
+var Executable = "ffmpeg"
+
+// the width of a television pixel in relation to its height.
+const pixelWidth = 2
+
+// number of frames of audio that can be queued before the emulation waits for
+// ffmpeg to catch up. audio is always slightly ahead of the video so ffmpeg
+// will never wait for the queue to fill.
+const queueLength = 256
+
+func init() {
+	err := outputs.Register("FFMPEG", "encode video with ffmpeg. option is the filename followed by options (see ffmpeg package)", func(tv *television.Television, options string) error {
+		filename, opts, err := ParseOptions(options)
+		if err != nil {
+			return err
+		}
+		enc, err := NewEncoder(filename, opts)
+		if err != nil {
+			return err
+		}
+		tv.AddPixelRenderer(enc)
+		tv.AddAudioMixer(enc)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Options specify how the television image is rendered by the Encoder.
+type Options struct {
+	// integer scaling of the television image. a value of zero is treated the
+	// same as one
+	Scale int
+
+	// simulate the persistence of the television phosphor
+	Phosphor bool
+
+	// simulate the artifacts of an NTSC composite connection. the option has
+	// no effect for other specifications
+	Composite bool
+}
+
+// DefaultOptions produce a video with a height of roughly 720 pixels.
+var DefaultOptions = Options{Scale: 3}
+
+// ParseOptions parses an option string of the form described in the package
+// documentation. Returns the output filename and the options.
+func ParseOptions(s string) (string, Options, error) {
+	opts := DefaultOptions
+
+	p := strings.Split(s, ";")
+	filename := strings.TrimSpace(p[0])
+	if filename == "" {
+		return "", opts, curated.Errorf("ffmpeg: no output filename")
+	}
+
+	for _, o := range p[1:] {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			continue // for loop
+		}
+
+		switch strings.ToLower(o) {
+		case "phosphor":
+			opts.Phosphor = true
+			continue // for loop
+		case "composite":
+			opts.Composite = true
+			continue // for loop
+		}
+
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 || strings.ToLower(kv[0]) != "scale" {
+			return "", opts, curated.Errorf("ffmpeg: unknown option (%s)", o)
+		}
+
+		n, err := strconv.Atoi(kv[1])
+		if err != nil || n < 1 {
+			return "", opts, curated.Errorf("ffmpeg: scale option requires a positive number (%s)", kv[1])
+		}
+		opts.Scale = n
+	}
+
+	return filename, opts, nil
+}
+
+// Encoder implements the television.PixelRenderer and television.AudioMixer
+// interfaces.
+type Encoder struct {
+	filename string
+	opts     Options
+
+	spec        specification.Spec
+	topScanline int
+	visible     int
+
+	// every pixel of the television, including the blanking areas
+	pixels []color.RGBA
+
+	phosphor *phosphor.Phosphor
+
+	// color signals for the scanline being decoded by the composite decoder.
+	// composite is nil if the option is disabled or if the specification is
+	// not NTSC
+	composite         *specification.Composite
+	compositeLine     []signal.ColorSignal
+	compositeOut      []color.RGBA
+	compositeScanline int
+
+	// the memory of the VCS being encoded. nil if the input display is not
+	// being drawn
+	mem   *memory.Memory
+	input inputState
+
+	// the ffmpeg process is started on the first stable frame. the dimensions
+	// of the video are fixed at that point
+	started bool
+	ended   bool
+	cmd     *exec.Cmd
+	stderr  bytes.Buffer
+	video   io.WriteCloser
+	audio   *os.File
+	width   int
+	height  int
+	frame   []uint8
+
+	// audio for the current frame is sent to the queue at the end of the
+	// frame. the queue is serviced by a separate goroutine so that ffmpeg can
+	// read from the video and audio pipes in whatever order it wants
+	chunk []uint8
+	queue chan []uint8
+	done  chan bool
+}
+
+// NewEncoder is the preferred method of initialisation for the Encoder type.
+// The output file must not already exist.
+func NewEncoder(filename string, opts Options) (*Encoder, error) {
+	if _, err := os.Stat(filename); err == nil {
+		return nil, curated.Errorf("ffmpeg: file already exists (%s)", filename)
+	}
+
+	if _, err := exec.LookPath(Executable); err != nil {
+		return nil, curated.Errorf("ffmpeg: %v", err)
+	}
+
+	if opts.Scale < 1 {
+		opts.Scale = 1
+	}
+
+	enc := &Encoder{
+		filename:      filename,
+		opts:          opts,
+		spec:          specification.SpecNTSC,
+		pixels:        make([]color.RGBA, specification.HorizClksScanline*television.MaxScanlinesAbsolute),
+		compositeLine: make([]signal.ColorSignal, specification.HorizClksScanline),
+		compositeOut:  make([]color.RGBA, specification.HorizClksScanline),
+	}
+
+	if opts.Phosphor {
+		var err error
+		enc.phosphor, err = phosphor.NewPhosphor(phosphor.MinFrames, 1.0)
+		if err != nil {
+			return nil, curated.Errorf("ffmpeg: %v", err)
+		}
+	}
+
+	enc.topScanline = enc.spec.ScanlineTop
+	enc.visible = enc.spec.ScanlinesVisible
+	enc.updateComposite()
+	enc.Reset()
+
+	return enc, nil
+}
+
+// AttachInput draws the state of the left player joystick and the panel
+// switches over the television image. The state is read from the memory at
+// the end of every frame.
+func (enc *Encoder) AttachInput(mem *memory.Memory) {
+	enc.mem = mem
+}
+
+// updateComposite creates or removes the composite decoder according to the
+// options and the current specification.
+func (enc *Encoder) updateComposite() {
+	enc.composite = nil
+	if !enc.opts.Composite {
+		return
+	}
+
+	// NewComposite() fails for specifications other than NTSC, in which case
+	// the palette colors are used
+	enc.composite, _ = enc.spec.NewComposite()
+	for x := range enc.compositeLine {
+		enc.compositeLine[x] = signal.VideoBlack
+	}
+}
+
+// start the ffmpeg process. the video dimensions and frame rate are taken from
+// the current state of the television.
+func (enc *Encoder) start() error {
+	enc.width = specification.HorizClksVisible * pixelWidth * enc.opts.Scale
+	enc.height = enc.visible * enc.opts.Scale
+
+	// most codecs require the dimensions to be even
+	if enc.height%2 == 1 {
+		enc.height++
+	}
+
+	enc.frame = make([]uint8, enc.width*enc.height*4)
+
+	args := []string{
+		"-hide_banner", "-loglevel", "error", "-nostdin", "-n",
+		"-f", "rawvideo", "-pix_fmt", "rgba",
+		"-s", strconv.Itoa(enc.width) + "x" + strconv.Itoa(enc.height),
+		"-framerate", strconv.FormatFloat(float64(enc.spec.FramesPerSecond), 'f', -1, 32),
+		"-i", "pipe:0",
+		"-f", "u8", "-ar", strconv.Itoa(tiaAudio.SampleFreq), "-ac", "1",
+		"-i", "pipe:3",
+		"-pix_fmt", "yuv420p",
+		enc.filename,
+	}
+
+	enc.cmd = exec.Command(Executable, args...)
+	enc.cmd.Stderr = &enc.stderr
+
+	var err error
+
+	enc.video, err = enc.cmd.StdinPipe()
+	if err != nil {
+		return curated.Errorf("ffmpeg: %v", err)
+	}
+
+	// the audio pipe is the first of the extra files and so is file
+	// descriptor 3 in the ffmpeg process
+	r, w, err := os.Pipe()
+	if err != nil {
+		return curated.Errorf("ffmpeg: %v", err)
+	}
+	enc.cmd.ExtraFiles = []*os.File{r}
+	enc.audio = w
+
+	err = enc.cmd.Start()
+	_ = r.Close()
+	if err != nil {
+		_ = w.Close()
+		return curated.Errorf("ffmpeg: %v", err)
+	}
+
+	enc.queue = make(chan []uint8, queueLength)
+	enc.done = make(chan bool)
+
+	go func() {
+		for c := range enc.queue {
+			_, err := enc.audio.Write(c)
+			if err != nil {
+				logger.Log("ffmpeg", err.Error())
+				break // for loop
+			}
+		}
+
+		// drain queue in case writing failed
+		for range enc.queue {
+		}
+
+		enc.done <- true
+	}()
+
+	enc.started = true
+
+	return nil
+}
+
+// encode the completed frame and send it to ffmpeg, along with the audio for
+// the frame.
+func (enc *Encoder) encode() error {
+	if enc.composite != nil {
+		enc.decodeComposite()
+	}
+
+	// scale the visible area of the television. rows beyond the visible area
+	// (either because the television has been resized or because the height
+	// has been rounded up) are black
+	for y := 0; y < enc.height; y++ {
+		sl := enc.topScanline + y/enc.opts.Scale
+		row := enc.frame[y*enc.width*4 : (y+1)*enc.width*4]
+		for x := 0; x < enc.width; x++ {
+			col := color.RGBA{A: 255}
+			if y/enc.opts.Scale < enc.visible && sl < television.MaxScanlinesAbsolute {
+				hp := specification.HorizClksHBlank + x/(pixelWidth*enc.opts.Scale)
+				col = enc.pixels[sl*specification.HorizClksScanline+hp]
+			}
+			row[x*4] = col.R
+			row[x*4+1] = col.G
+			row[x*4+2] = col.B
+			row[x*4+3] = 255
+		}
+	}
+
+	if enc.mem != nil {
+		enc.input.sample(enc.mem)
+		enc.drawInput()
+	}
+
+	if len(enc.chunk) > 0 {
+		enc.queue <- enc.chunk
+		enc.chunk = nil
+	}
+
+	_, err := enc.video.Write(enc.frame)
+	if err != nil {
+		return curated.Errorf("ffmpeg: %v", err)
+	}
+
+	return nil
+}
+
+// Resize implements the television.PixelRenderer interface.
+func (enc *Encoder) Resize(spec specification.Spec, topScanline int, visibleScanlines int) error {
+	if enc.started && spec.ID != enc.spec.ID {
+		logger.Log("ffmpeg", fmt.Sprintf("specification changed to %s after encoding started", spec.ID))
+	}
+
+	enc.topScanline = topScanline
+
+	// the height of the video can not change once encoding has started
+	if !enc.started {
+		enc.visible = visibleScanlines
+	}
+
+	if spec.ID != enc.spec.ID {
+		enc.spec = spec
+		enc.updateComposite()
+	}
+
+	return nil
+}
+
+// NewFrame implements the television.PixelRenderer interface.
+func (enc *Encoder) NewFrame(info television.FrameInfo) error {
+	if enc.ended {
+		return nil
+	}
+
+	if enc.started {
+		err := enc.encode()
+		if err != nil {
+			return err
+		}
+	} else if info.Stable {
+		err := enc.start()
+		if err != nil {
+			return err
+		}
+	}
+
+	if enc.phosphor != nil {
+		enc.phosphor.NewFrame()
+	}
+
+	return nil
+}
+
+// NewScanline implements the television.PixelRenderer interface.
+func (enc *Encoder) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements the television.PixelRenderer interface.
+func (enc *Encoder) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements the television.PixelRenderer interface.
+func (enc *Encoder) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	x := sig.HorizPos()
+	y := sig.Scanline()
+	if x < 0 || x >= specification.HorizClksScanline || y < 0 || y >= television.MaxScanlinesAbsolute {
+		return nil
+	}
+
+	col := color.RGBA{A: 255}
+	if !sig.VBlank() {
+		col = enc.spec.GetColor(sig.Pixel())
+	}
+
+	// the scanline is drawn by the composite decoder once it has been
+	// completed
+	if enc.composite != nil {
+		if y != enc.compositeScanline {
+			enc.decodeComposite()
+			enc.compositeScanline = y
+		}
+		if sig.VBlank() {
+			enc.compositeLine[x] = signal.VideoBlack
+		} else {
+			enc.compositeLine[x] = sig.Pixel()
+		}
+		return nil
+	}
+
+	if enc.phosphor != nil {
+		col = enc.phosphor.SetPixel(x, y, col)
+	}
+
+	enc.pixels[y*specification.HorizClksScanline+x] = col
+
+	return nil
+}
+
+// decode the scanline of color signals collected by SetPixel().
+func (enc *Encoder) decodeComposite() {
+	y := enc.compositeScanline
+	enc.composite.Scanline(enc.compositeLine, enc.compositeOut)
+
+	for x, col := range enc.compositeOut {
+		if enc.phosphor != nil {
+			col = enc.phosphor.SetPixel(x, y, col)
+		}
+		enc.pixels[y*specification.HorizClksScanline+x] = col
+		enc.compositeLine[x] = signal.VideoBlack
+	}
+}
+
+// Reset implements the television.PixelRenderer interface.
+func (enc *Encoder) Reset() {
+	for i := range enc.pixels {
+		enc.pixels[i] = color.RGBA{A: 255}
+	}
+	if enc.phosphor != nil {
+		enc.phosphor.Reset()
+	}
+}
+
+// EndRendering implements the television.PixelRenderer interface. The video
+// file is complete once EndRendering() has returned.
+func (enc *Encoder) EndRendering() error {
+	if enc.ended {
+		return nil
+	}
+	enc.ended = true
+
+	if !enc.started {
+		return curated.Errorf("ffmpeg: television did not stabilise. nothing encoded")
+	}
+
+	// closing the video pipe first means that ffmpeg will not be waiting for
+	// video when the audio queue is being flushed
+	verr := enc.video.Close()
+
+	if len(enc.chunk) > 0 {
+		enc.queue <- enc.chunk
+		enc.chunk = nil
+	}
+	close(enc.queue)
+	<-enc.done
+	aerr := enc.audio.Close()
+
+	err := enc.cmd.Wait()
+	if err != nil {
+		if s := strings.TrimSpace(enc.stderr.String()); s != "" {
+			return curated.Errorf("ffmpeg: %v", s)
+		}
+		return curated.Errorf("ffmpeg: %v", err)
+	}
+	if verr != nil {
+		return curated.Errorf("ffmpeg: %v", verr)
+	}
+	if aerr != nil {
+		return curated.Errorf("ffmpeg: %v", aerr)
+	}
+
+	return nil
+}
+
+// SetAudio implements the television.AudioMixer interface.
+func (enc *Encoder) SetAudio(audioData uint8) error {
+	if enc.started && !enc.ended {
+		enc.chunk = append(enc.chunk, audioData)
+	}
+	return nil
+}
+
+// EndMixing implements the television.AudioMixer interface. The audio is
+// flushed by EndRendering() so there is nothing to do.
+func (enc *Encoder) EndMixing() error {
+	return nil
+}
@@ -0,0 +1,136 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package ffmpeg_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/outputs/ffmpeg"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// a stand-in for the ffmpeg command. the video and audio pipes are copied to
+// the output file and to the output file with an additional .audio extension.
+const fakeFFmpeg = `#!/bin/sh
+for a; do out="$a"; done
+cat <&3 > "$out.audio" &
+cat > "$out"
+wait
+`
+
+func TestParseOptions(t *testing.T) {
+	filename, opts, err := ffmpeg.ParseOptions("game.mp4;scale=4;phosphor;composite")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filename != "game.mp4" {
+		t.Errorf("unexpected filename (%s)", filename)
+	}
+	if opts != (ffmpeg.Options{Scale: 4, Phosphor: true, Composite: true}) {
+		t.Errorf("unexpected options (%v)", opts)
+	}
+
+	_, opts, err = ffmpeg.ParseOptions("game.mp4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts != ffmpeg.DefaultOptions {
+		t.Errorf("expected default options (got %v)", opts)
+	}
+
+	for _, s := range []string{"", "game.mp4;scale=0", "game.mp4;scale=x", "game.mp4;foo"} {
+		if _, _, err := ffmpeg.ParseOptions(s); err == nil {
+			t.Errorf("expected error for option string (%s)", s)
+		}
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg command requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+
+	exe := filepath.Join(dir, "ffmpeg")
+	err := ioutil.WriteFile(exe, []byte(fakeFFmpeg), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func(e string) { ffmpeg.Executable = e }(ffmpeg.Executable)
+	ffmpeg.Executable = exe
+
+	out := filepath.Join(dir, "video.mp4")
+
+	tv, _ := television.NewTelevision("NTSC")
+	enc, err := ffmpeg.NewEncoder(out, ffmpeg.Options{Scale: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tv.AddPixelRenderer(enc)
+	tv.AddAudioMixer(enc)
+
+	const numFrames = 30
+	for i := 0; i < numFrames; i++ {
+		_ = tv.SignalBatch(test.Frame(262, true, signal.ColorSignal(0x1e)))
+	}
+
+	err = enc.EndRendering()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	st, err := os.Stat(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the height of the video depends on the visible area of the television
+	// so the output is checked for a whole number of rows. not every frame is
+	// encoded because encoding does not start until the television is stable
+	row := int64(specification.HorizClksVisible * 2 * 2 * 4)
+	if st.Size() == 0 || st.Size()%row != 0 {
+		t.Errorf("unexpected size of video output (%d bytes)", st.Size())
+	}
+
+	// every pixel is the same color
+	data, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	col := specification.SpecNTSC.GetColor(signal.ColorSignal(0x1e))
+	for i := 0; i < len(data); i += 4 {
+		if data[i] != col.R || data[i+1] != col.G || data[i+2] != col.B || data[i+3] != 255 {
+			t.Fatalf("unexpected pixel color at offset %d", i)
+		}
+	}
+
+	if _, err := os.Stat(out + ".audio"); err != nil {
+		t.Errorf("missing audio output: %v", err)
+	}
+
+	// existing files are not overwritten
+	if _, err := ffmpeg.NewEncoder(out, ffmpeg.DefaultOptions); err == nil {
+		t.Errorf("expected error when output file already exists")
+	}
+}
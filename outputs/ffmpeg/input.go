@@ -0,0 +1,116 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package ffmpeg
+
+import (
+	"image/color"
+
+	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+)
+
+// inputState is a copy of the VCS registers that reflect the state of the
+// player 0 joystick and the panel. it is sampled at the end of every frame.
+type inputState struct {
+	swcha uint8
+	swchb uint8
+	inpt4 uint8
+}
+
+func (inp *inputState) sample(mem *memory.Memory) {
+	inp.swcha, _ = mem.Peek(addresses.ReadAddress["SWCHA"])
+	inp.swchb, _ = mem.Peek(addresses.ReadAddress["SWCHB"])
+	inp.inpt4, _ = mem.Peek(addresses.ReadAddress["INPT4"])
+}
+
+// the joystick directions, fire button and the reset and select switches are
+// all active low.
+func (inp inputState) up() bool    { return inp.swcha&0x10 == 0x00 }
+func (inp inputState) down() bool  { return inp.swcha&0x20 == 0x00 }
+func (inp inputState) left() bool  { return inp.swcha&0x40 == 0x00 }
+func (inp inputState) right() bool { return inp.swcha&0x80 == 0x00 }
+func (inp inputState) fire() bool  { return inp.inpt4&0x80 == 0x00 }
+func (inp inputState) reset() bool { return inp.swchb&0x01 == 0x00 }
+func (inp inputState) sel() bool   { return inp.swchb&0x02 == 0x00 }
+
+// colors used by the input display. the alpha value is the opacity of the
+// color when blended with the television image.
+var (
+	inputIdle   = color.RGBA{R: 64, G: 64, B: 64, A: 128}
+	inputActive = color.RGBA{R: 255, G: 255, B: 255, A: 224}
+	inputFire   = color.RGBA{R: 224, G: 32, B: 32, A: 224}
+)
+
+// the size of a single cell of the input display, in television pixels.
+const inputCell = 4
+
+// drawInput blends the input display with the bottom-left corner of the
+// encoded frame. the joystick is drawn as a cross with the fire button in the
+// middle. the select and reset switches are drawn to the right of the
+// joystick, in that order.
+func (enc *Encoder) drawInput() {
+	// cells are square in the video. the size is based on the width of a
+	// television pixel
+	sz := inputCell * pixelWidth * enc.opts.Scale
+
+	// top-left of the input display
+	ox := sz / 2
+	oy := enc.height - sz*3 - sz/2
+
+	cells := []struct {
+		x, y   int
+		active bool
+		col    color.RGBA
+	}{
+		{x: 1, y: 0, active: enc.input.up(), col: inputActive},
+		{x: 0, y: 1, active: enc.input.left(), col: inputActive},
+		{x: 1, y: 1, active: enc.input.fire(), col: inputFire},
+		{x: 2, y: 1, active: enc.input.right(), col: inputActive},
+		{x: 1, y: 2, active: enc.input.down(), col: inputActive},
+		{x: 4, y: 2, active: enc.input.sel(), col: inputActive},
+		{x: 6, y: 2, active: enc.input.reset(), col: inputActive},
+	}
+
+	for _, c := range cells {
+		col := inputIdle
+		if c.active {
+			col = c.col
+		}
+
+		// a one pixel gap is left between adjacent cells
+		enc.blendRect(ox+c.x*sz+1, oy+c.y*sz+1, sz-2, sz-2, col)
+	}
+}
+
+// blendRect blends the color with a rectangle of the encoded frame.
+// rectangles are clipped to the dimensions of the frame.
+func (enc *Encoder) blendRect(x, y, w, h int, col color.RGBA) {
+	a := uint32(col.A)
+	for j := y; j < y+h; j++ {
+		if j < 0 || j >= enc.height {
+			continue // for loop
+		}
+		for i := x; i < x+w; i++ {
+			if i < 0 || i >= enc.width {
+				continue // for loop
+			}
+			p := enc.frame[(j*enc.width+i)*4:]
+			p[0] = uint8((uint32(col.R)*a + uint32(p[0])*(255-a)) / 255)
+			p[1] = uint8((uint32(col.G)*a + uint32(p[1])*(255-a)) / 255)
+			p[2] = uint8((uint32(col.B)*a + uint32(p[2])*(255-a)) / 255)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package imagetv is a television.PixelRenderer that saves television frames
+// as PNG images. The images are written to a target directory along with a
+// manifest file that lists each image, the frame number of the image and a
+// digest of the image's pixels.
+//
+// The package registers itself with the outputs package as "IMAGETV". The
+// option string is the target directory followed by any number of options,
+// separated by semi-colons. For example:
+//
+//	gopher2600 -output "IMAGETV:frames;every=10;from=100;to=200" rom.bin
+//
+// The available options are:
+//
+//	every=N     save every Nth frame
+//	from=N      do not save frames before frame N
+//	to=N        do not save frames after frame N
+//	changed     only save frames that are different to the previous frame
+//
+// The options can be combined, in which case the changed option compares
+// against the previous frame that passed the other options. Frames are
+// numbered from zero. Existing files in the target directory will not be
+// overwritten.
+//
+// The image is the visible portion of the television image, as defined by the
+// most recent Resize() call.
+package imagetv
@@ -0,0 +1,294 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package imagetv
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/outputs"
+)
+
+// ManifestFile is the name of the manifest file in the target directory.
+const ManifestFile = "manifest.txt"
+
+func init() {
+	err := outputs.Register("IMAGETV", "save frames as PNG images. option is the target directory followed by options (see imagetv package)", func(tv *television.Television, options string) error {
+		dir, opts, err := ParseOptions(options)
+		if err != nil {
+			return err
+		}
+		img, err := NewImageTV(dir, opts)
+		if err != nil {
+			return err
+		}
+		tv.AddPixelRenderer(img)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Options specify which frames are saved by ImageTV.
+type Options struct {
+	// save every Nth frame. a value of zero or one means every frame
+	Every int
+
+	// the range of frames to save. if To is less than zero then there is no
+	// upper limit
+	From int
+	To   int
+
+	// only save frames that are different to the previous frame selected by
+	// the Every, From and To fields
+	Changed bool
+}
+
+// DefaultOptions saves every frame.
+var DefaultOptions = Options{Every: 1, From: 0, To: -1}
+
+// ParseOptions parses an option string of the form described in the package
+// documentation. Returns the target directory and the options.
+func ParseOptions(s string) (string, Options, error) {
+	opts := DefaultOptions
+
+	p := strings.Split(s, ";")
+	dir := strings.TrimSpace(p[0])
+	if dir == "" {
+		return "", opts, curated.Errorf("imagetv: no target directory")
+	}
+
+	for _, o := range p[1:] {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			continue // for loop
+		}
+
+		if strings.ToLower(o) == "changed" {
+			opts.Changed = true
+			continue // for loop
+		}
+
+		kv := strings.SplitN(o, "=", 2)
+		if len(kv) != 2 {
+			return "", opts, curated.Errorf("imagetv: unknown option (%s)", o)
+		}
+
+		n, err := strconv.Atoi(kv[1])
+		if err != nil || n < 0 {
+			return "", opts, curated.Errorf("imagetv: %s option requires a positive number (%s)", kv[0], kv[1])
+		}
+
+		switch strings.ToLower(kv[0]) {
+		case "every":
+			opts.Every = n
+		case "from":
+			opts.From = n
+		case "to":
+			opts.To = n
+		default:
+			return "", opts, curated.Errorf("imagetv: unknown option (%s)", o)
+		}
+	}
+
+	if opts.To >= 0 && opts.To < opts.From {
+		return "", opts, curated.Errorf("imagetv: frame range is empty (%d to %d)", opts.From, opts.To)
+	}
+
+	return dir, opts, nil
+}
+
+// ImageTV implements the television.PixelRenderer interface.
+type ImageTV struct {
+	dir  string
+	opts Options
+
+	manifest *os.File
+
+	spec        specification.Spec
+	topScanline int
+	img         *image.RGBA
+
+	// the number of the frame currently being rendered
+	frameNum int
+
+	// digest of the previous frame. used for the Changed option
+	prevDigest [sha1.Size]byte
+	hasPrev    bool
+}
+
+// NewImageTV is the preferred method of initialisation for the ImageTV type.
+// The target directory will be created if it does not exist.
+func NewImageTV(dir string, opts Options) (*ImageTV, error) {
+	imgtv := &ImageTV{
+		dir:  dir,
+		opts: opts,
+		spec: specification.SpecNTSC,
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, curated.Errorf("imagetv: %v", err)
+	}
+
+	imgtv.manifest, err = create(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	imgtv.resize(imgtv.spec.ScanlineTop, imgtv.spec.ScanlinesVisible)
+
+	return imgtv, nil
+}
+
+// create a file in the target directory. existing files are not overwritten.
+func create(filename string) (*os.File, error) {
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, curated.Errorf("imagetv: file already exists (%s)", filename)
+		}
+		return nil, curated.Errorf("imagetv: %v", err)
+	}
+	return f, nil
+}
+
+func (imgtv *ImageTV) resize(topScanline int, visibleScanlines int) {
+	imgtv.topScanline = topScanline
+	imgtv.img = image.NewRGBA(image.Rect(0, 0, specification.HorizClksVisible, visibleScanlines))
+	imgtv.Reset()
+}
+
+// selected returns true if the current frame should be saved according to
+// the options.
+func (imgtv *ImageTV) selected() bool {
+	if imgtv.frameNum < imgtv.opts.From {
+		return false
+	}
+	if imgtv.opts.To >= 0 && imgtv.frameNum > imgtv.opts.To {
+		return false
+	}
+	if imgtv.opts.Every > 1 && (imgtv.frameNum-imgtv.opts.From)%imgtv.opts.Every != 0 {
+		return false
+	}
+	return true
+}
+
+// save the current frame if it is selected.
+func (imgtv *ImageTV) save() error {
+	if !imgtv.selected() {
+		return nil
+	}
+
+	digest := sha1.Sum(imgtv.img.Pix)
+	changed := !imgtv.hasPrev || digest != imgtv.prevDigest
+	imgtv.prevDigest = digest
+	imgtv.hasPrev = true
+
+	if imgtv.opts.Changed && !changed {
+		return nil
+	}
+
+	name := fmt.Sprintf("frame_%06d.png", imgtv.frameNum)
+
+	f, err := create(filepath.Join(imgtv.dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = png.Encode(f, imgtv.img)
+	if err != nil {
+		return curated.Errorf("imagetv: %v", err)
+	}
+
+	_, err = fmt.Fprintf(imgtv.manifest, "%d %s %x\n", imgtv.frameNum, name, digest)
+	if err != nil {
+		return curated.Errorf("imagetv: %v", err)
+	}
+
+	return nil
+}
+
+// Resize implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) Resize(spec specification.Spec, topScanline int, visibleScanlines int) error {
+	imgtv.spec = spec
+	imgtv.resize(topScanline, visibleScanlines)
+	return nil
+}
+
+// NewFrame implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) NewFrame(_ television.FrameInfo) error {
+	err := imgtv.save()
+	imgtv.frameNum++
+	return err
+}
+
+// NewScanline implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) NewScanline(_ int) error {
+	return nil
+}
+
+// UpdatingPixels implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) UpdatingPixels(_ bool) {
+}
+
+// SetPixel implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) SetPixel(sig signal.SignalAttributes, _ bool) error {
+	x := sig.HorizPos() - specification.HorizClksHBlank
+	y := sig.Scanline() - imgtv.topScanline
+	if x < 0 || y < 0 || y >= imgtv.img.Bounds().Dy() || x >= specification.HorizClksVisible {
+		return nil
+	}
+
+	col := color.RGBA{A: 255}
+	if !sig.VBlank() {
+		col = imgtv.spec.GetColor(sig.Pixel())
+	}
+	imgtv.img.SetRGBA(x, y, col)
+
+	return nil
+}
+
+// Reset implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) Reset() {
+	for y := 0; y < imgtv.img.Bounds().Dy(); y++ {
+		for x := 0; x < imgtv.img.Bounds().Dx(); x++ {
+			imgtv.img.SetRGBA(x, y, color.RGBA{A: 255})
+		}
+	}
+}
+
+// EndRendering implements the television.PixelRenderer interface.
+func (imgtv *ImageTV) EndRendering() error {
+	err := imgtv.manifest.Close()
+	if err != nil {
+		return curated.Errorf("imagetv: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package imagetv_test
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/outputs/imagetv"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+// manifest returns the image filenames listed in the manifest.
+func manifest(t *testing.T, dir string) []string {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join(dir, imagetv.ManifestFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	var names []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		flds := strings.Fields(s.Text())
+		if len(flds) != 3 {
+			t.Fatalf("malformed manifest entry (%s)", s.Text())
+		}
+		if _, err := os.Stat(filepath.Join(dir, flds[1])); err != nil {
+			t.Errorf("missing image for manifest entry (%s)", flds[1])
+		}
+		names = append(names, flds[1])
+	}
+
+	return names
+}
+
+func TestParseOptions(t *testing.T) {
+	dir, opts, err := imagetv.ParseOptions("frames;every=10;from=5;to=100;changed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "frames" {
+		t.Errorf("unexpected directory (%s)", dir)
+	}
+	if opts != (imagetv.Options{Every: 10, From: 5, To: 100, Changed: true}) {
+		t.Errorf("unexpected options (%v)", opts)
+	}
+
+	for _, s := range []string{"", "frames;every=x", "frames;foo=1", "frames;from=10;to=5"} {
+		if _, _, err := imagetv.ParseOptions(s); err == nil {
+			t.Errorf("expected error for option string (%s)", s)
+		}
+	}
+}
+
+func TestImageTV(t *testing.T) {
+	dir := t.TempDir()
+
+	tv, _ := television.NewTelevision("NTSC")
+	img, err := imagetv.NewImageTV(dir, imagetv.Options{Every: 2, From: 1, To: 9, Changed: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tv.AddPixelRenderer(img)
+
+	// the color changes every four frames
+	for i := 0; i < 12; i++ {
+		_ = tv.SignalBatch(test.Frame(262, true, signal.ColorSignal((i/4)*2)))
+	}
+
+	if err := img.EndRendering(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// frames 1, 3, 5, 7 and 9 are selected but frames 3 and 7 are the same as
+	// the previous selected frame and are not saved
+	names := manifest(t, dir)
+	expected := []string{"frame_000001.png", "frame_000005.png", "frame_000009.png"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected frames %v to be saved (got %v)", expected, names)
+	}
+
+	// existing files are not overwritten
+	if _, err := imagetv.NewImageTV(dir, imagetv.DefaultOptions); err == nil {
+		t.Errorf("expected error when manifest already exists")
+	}
+}
@@ -0,0 +1,125 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package outputs
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// Creator functions create an output and attach it to the television, using
+// the AddPixelRenderer(), AddAudioMixer() or AddFrameTrigger() functions of
+// the television as appropriate. The meaning of the options string is
+// defined by the output and may be empty.
+type Creator func(tv *television.Television, options string) error
+
+// Output describes a registered output backend.
+type Output struct {
+	Name        string
+	Description string
+	create      Creator
+}
+
+var registry = make(map[string]Output)
+var registryLock sync.Mutex
+
+// separators used in the specification string given to Attach().
+const (
+	outputSeparator  = ","
+	optionsSeparator = ":"
+)
+
+// Register an output backend. Names are case-insensitive and must be unique.
+func Register(name string, description string, create Creator) error {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "" {
+		return curated.Errorf("outputs: output name is empty")
+	}
+	if strings.ContainsAny(name, outputSeparator+optionsSeparator) {
+		return curated.Errorf("outputs: illegal output name (%s)", name)
+	}
+	if _, ok := registry[name]; ok {
+		return curated.Errorf("outputs: output already registered (%s)", name)
+	}
+
+	registry[name] = Output{
+		Name:        name,
+		Description: description,
+		create:      create,
+	}
+
+	return nil
+}
+
+// List returns all registered outputs in alphabetical order.
+func List() []Output {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	l := make([]Output, 0, len(registry))
+	for _, o := range registry {
+		l = append(l, o)
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].Name < l[j].Name })
+
+	return l
+}
+
+// Attach one or more outputs to the television. The specification string is
+// a comma separated list of output names. Each name can be followed by a
+// colon and the options for that output. For example:
+//
+//	WAV:recording.wav,LEDMATRIX
+//
+// Options can not contain a comma.
+func Attach(tv *television.Television, spec string) error {
+	for _, s := range strings.Split(spec, outputSeparator) {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue // for loop
+		}
+
+		name := s
+		options := ""
+		if i := strings.Index(s, optionsSeparator); i >= 0 {
+			name = s[:i]
+			options = s[i+1:]
+		}
+		name = strings.ToUpper(name)
+
+		registryLock.Lock()
+		o, ok := registry[name]
+		registryLock.Unlock()
+
+		if !ok {
+			return curated.Errorf("outputs: unknown output (%s)", name)
+		}
+
+		err := o.create(tv, options)
+		if err != nil {
+			return curated.Errorf("outputs: %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
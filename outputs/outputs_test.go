@@ -0,0 +1,76 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package outputs_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/outputs"
+)
+
+func TestOutputs(t *testing.T) {
+	var opts []string
+
+	err := outputs.Register("test", "test output", func(tv *television.Television, options string) error {
+		opts = append(opts, options)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// names are case insensitive so this is a duplicate
+	err = outputs.Register("TEST", "duplicate output", nil)
+	if err == nil {
+		t.Errorf("expected error when registering duplicate output")
+	}
+
+	err = outputs.Register("bad:name", "illegal name", nil)
+	if err == nil {
+		t.Errorf("expected error when registering output with illegal name")
+	}
+
+	l := outputs.List()
+	if len(l) != 1 || l[0].Name != "TEST" {
+		t.Fatalf("unexpected list of outputs (%v)", l)
+	}
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = outputs.Attach(tv, "test, Test:foo:bar,")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(opts) != 2 || opts[0] != "" || opts[1] != "foo:bar" {
+		t.Errorf("unexpected options (%v)", opts)
+	}
+
+	err = outputs.Attach(tv, "unknown")
+	if err == nil {
+		t.Errorf("expected error when attaching unknown output")
+	}
+
+	// an empty specification is not an error
+	err = outputs.Attach(tv, "")
+	if err != nil {
+		t.Error(err)
+	}
+}
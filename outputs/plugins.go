@@ -0,0 +1,51 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// +build linux,cgo darwin,cgo
+
+package outputs
+
+import (
+	"path/filepath"
+	"plugin"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// LoadPlugins opens every Go plugin (files with the .so extension) in the
+// specified directory. Plugins should register their outputs from their
+// init() function. Plugins that fail to load are logged and otherwise
+// ignored.
+//
+// Note that plugins must have been built with the same version of Go and of
+// every shared package as the main program.
+func LoadPlugins(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return curated.Errorf("outputs: %v", err)
+	}
+
+	for _, f := range files {
+		_, err := plugin.Open(f)
+		if err != nil {
+			logger.Log("outputs", err.Error())
+			continue // for loop
+		}
+		logger.Log("outputs", "loaded plugin "+filepath.Base(f))
+	}
+
+	return nil
+}
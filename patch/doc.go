@@ -47,4 +47,9 @@
 // to how memory is mapped inside the VCS. Imagine that the patches are being
 // applied to the cartridge file image. The cartridge mapper handles the VCS
 // memory side of things.
+//
+// The package can also export changes made to cartridge memory. The Modified()
+// function returns the current state of the cartridge as it would appear in
+// the cartridge file, and WriteIPS() creates an IPS patch file of the
+// differences between that and the original file.
 package patch
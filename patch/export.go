@@ -0,0 +1,119 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package patch
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge"
+)
+
+// the largest offset and the largest record that can be expressed in an IPS
+// file.
+const (
+	ipsMaxOffset = 0xffffff
+	ipsMaxRecord = 0xffff
+)
+
+// the IPS file format uses the string "EOF" to indicate the end of the file.
+// an offset that has the same value as the string can not be used.
+const ipsEOFOffset = 0x454f46
+
+// Modified returns the current contents of cartridge ROM, as it would appear
+// in the cartridge file. The original file data is used to confirm that the
+// banks of the cartridge correspond with the layout of the file. The number
+// of bytes that differ from the original is also returned.
+//
+// Cartridge formats that store data outside of the cartridge banks (eg. DPC)
+// are not supported.
+func Modified(cart *cartridge.Cartridge, original []uint8) ([]uint8, int, error) {
+	banks, err := cart.CopyBanks()
+	if err != nil {
+		return nil, 0, curated.Errorf("patch: %v", err)
+	}
+
+	modified := make([]uint8, 0, len(original))
+	for b := 0; b < len(banks); b++ {
+		for _, bank := range banks {
+			if bank.Number == b {
+				modified = append(modified, bank.Data...)
+			}
+		}
+	}
+
+	if len(modified) != len(original) {
+		return nil, 0, curated.Errorf("patch: cartridge format not supported for export (%s)", cart.ID())
+	}
+
+	n := 0
+	for i := range original {
+		if original[i] != modified[i] {
+			n++
+		}
+	}
+
+	return modified, n, nil
+}
+
+// WriteIPS writes an IPS patch file that will transform the original data
+// into the modified data. Both data slices must be the same length.
+func WriteIPS(w io.Writer, original []uint8, modified []uint8) error {
+	if len(original) != len(modified) {
+		return curated.Errorf("patch: original and modified data are of different lengths")
+	}
+
+	if len(original) > ipsMaxOffset {
+		return curated.Errorf("patch: data too large for IPS format")
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("PATCH")
+
+	for i := 0; i < len(original); i++ {
+		if original[i] == modified[i] {
+			continue // for loop
+		}
+
+		// an offset that is the same as the EOF marker is avoided by starting
+		// the record one byte earlier
+		start := i
+		if start == ipsEOFOffset {
+			start--
+		}
+
+		end := i
+		for end < len(original) && original[end] != modified[end] && end-start < ipsMaxRecord {
+			end++
+		}
+
+		b.Write([]byte{uint8(start >> 16), uint8(start >> 8), uint8(start)})
+		b.Write([]byte{uint8((end - start) >> 8), uint8(end - start)})
+		b.Write(modified[start:end])
+
+		i = end - 1
+	}
+
+	b.WriteString("EOF")
+
+	_, err := w.Write(b.Bytes())
+	if err != nil {
+		return curated.Errorf("patch: %v", err)
+	}
+
+	return nil
+}
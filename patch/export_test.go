@@ -0,0 +1,58 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package patch_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/patch"
+)
+
+func TestWriteIPS(t *testing.T) {
+	original := make([]uint8, 0x100)
+	modified := make([]uint8, 0x100)
+	copy(modified, original)
+
+	modified[0x10] = 0x01
+	modified[0x11] = 0x02
+	modified[0xff] = 0x03
+
+	b := &bytes.Buffer{}
+	err := patch.WriteIPS(b, original, modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte("PATCH")
+	expected = append(expected, 0x00, 0x00, 0x10, 0x00, 0x02, 0x01, 0x02)
+	expected = append(expected, 0x00, 0x00, 0xff, 0x00, 0x01, 0x03)
+	expected = append(expected, []byte("EOF")...)
+
+	if !bytes.Equal(b.Bytes(), expected) {
+		t.Errorf("unexpected IPS data % 02x", b.Bytes())
+	}
+
+	// no differences produces an empty patch
+	b.Reset()
+	err = patch.WriteIPS(b, original, original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "PATCHEOF" {
+		t.Errorf("unexpected IPS data % 02x", b.Bytes())
+	}
+}
@@ -32,5 +32,19 @@
 //
 // The reason for this is simple. During development, it is more convenient to
 // have the config directory close to hand. For release binaries meanwhile, the
-// config directory should be somewhere the user expects.
+// config directory should be somewhere the user expects. The user's
+// configuration directory is found with os.UserConfigDir(), which honours the
+// XDG_CONFIG_HOME environment variable on Linux and chooses the platform
+// specific location on macOS and Windows.
+//
+// All resources, whether they are preferences, saved data or state, are
+// stored in the one config directory. There is no separation of config, data
+// and state files as described by the XDG Base Directory Specification.
+//
+// Both types of build also support a portable mode, enabled with the
+// SetPortable() function. In portable mode the config directory is placed next
+// to the executable. If SetPortable() is not called then the existence of the
+// portable config directory is enough to enable portable mode automatically.
+//
+// The base path is decided the first time it is required and is then cached.
 package paths
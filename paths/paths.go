@@ -16,9 +16,151 @@
 package paths
 
 import (
+	"os"
 	"path"
+	"path/filepath"
+	"sync"
 )
 
+// the base path is decided the first time it is required and then cached.
+// portable mode is a program wide setting and should be set once, as early as
+// possible, with the SetPortable() function.
+var resources struct {
+	crit sync.Mutex
+
+	// portable mode as set by SetPortable(). if portableSet is false then
+	// portable mode is enabled automatically if the portable directory
+	// exists
+	portable    bool
+	portableSet bool
+
+	// the directory in which the portable directory is placed. if empty then
+	// the directory containing the executable is used
+	portableRoot string
+
+	// the cached base path and whether it is the portable directory. base is
+	// empty if the base path has not yet been decided
+	base       string
+	isPortable bool
+}
+
+// SetPortable enables or disables portable mode. In portable mode, resources
+// are stored in a directory next to the executable rather than in the
+// directory chosen according to the build tag.
+//
+// If SetPortable() is not called then portable mode is enabled automatically
+// if the portable directory already exists.
+func SetPortable(enabled bool) {
+	resources.crit.Lock()
+	defer resources.crit.Unlock()
+	resources.portable = enabled
+	resources.portableSet = true
+	resources.base = ""
+}
+
+// SetPortableRoot changes the directory in which the portable directory is
+// placed. By default, the portable directory is placed next to the
+// executable. An empty string restores the default.
+//
+// Intended for testing purposes.
+func SetPortableRoot(root string) {
+	resources.crit.Lock()
+	defer resources.crit.Unlock()
+	resources.portableRoot = root
+	resources.base = ""
+}
+
+// IsPortable returns true if portable mode is enabled, either explicitly with
+// SetPortable() or automatically because the portable directory exists.
+func IsPortable() bool {
+	resources.crit.Lock()
+	defer resources.crit.Unlock()
+
+	if err := resolveBase(); err != nil {
+		return false
+	}
+
+	return resources.isPortable
+}
+
+// portableDir returns the path of the portable directory, which is next to
+// the executable unless SetPortableRoot() has been used.
+//
+// should be called with the resources lock held.
+func portableDir() (string, error) {
+	if resources.portableRoot != "" {
+		return path.Join(resources.portableRoot, gopherConfigDir), nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(filepath.Dir(exe), gopherConfigDir), nil
+}
+
+// resolveBase decides the base path if it has not already been decided.
+//
+// should be called with the resources lock held.
+func resolveBase() error {
+	if resources.base != "" {
+		return nil
+	}
+
+	portable := resources.portable
+	if !resources.portableSet {
+		pth, err := portableDir()
+		if err == nil {
+			info, err := os.Stat(pth)
+			portable = err == nil && info.IsDir()
+		}
+	}
+
+	var base string
+	var err error
+
+	if portable {
+		base, err = portableDir()
+	} else {
+		base, err = configDir()
+	}
+	if err != nil {
+		return err
+	}
+
+	resources.base = base
+	resources.isPortable = portable
+
+	return nil
+}
+
+// getBasePath looks for and if necessary creates the base directory (and child
+// directories).
+func getBasePath(subPth string) (string, error) {
+	resources.crit.Lock()
+	err := resolveBase()
+	base := resources.base
+	resources.crit.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+
+	pth := path.Join(base, subPth)
+
+	if err := os.MkdirAll(pth, 0700); err != nil {
+		return "", err
+	}
+
+	return pth, nil
+}
+
 // ResourcePath returns the resource string (representing the resource to be
 // loaded) prepended with OS/build specific paths.
 //
@@ -16,6 +16,8 @@
 package paths_test
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/jetsetilly/gopher2600/paths"
@@ -39,3 +41,57 @@ func TestPaths(t *testing.T) {
 	test.Equate(t, err, nil)
 	test.Equate(t, pth, ".gopher2600")
 }
+
+func TestPortable(t *testing.T) {
+	root := t.TempDir()
+	paths.SetPortableRoot(root)
+	defer paths.SetPortableRoot("")
+
+	if paths.IsPortable() {
+		t.Errorf("portable mode should not be enabled")
+	}
+
+	// portable mode is enabled automatically if the portable directory
+	// exists. the decision is cached so the root is set again to clear the
+	// cache
+	err := os.Mkdir(path.Join(root, ".gopher2600"), 0700)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if paths.IsPortable() {
+		t.Errorf("portable mode should not be enabled until the base path is decided again")
+	}
+	paths.SetPortableRoot(root)
+	if !paths.IsPortable() {
+		t.Errorf("portable mode should be enabled while the portable directory exists")
+	}
+
+	err = os.RemoveAll(path.Join(root, ".gopher2600"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paths.SetPortableRoot(root)
+	if paths.IsPortable() {
+		t.Errorf("portable mode should not be enabled")
+	}
+
+	paths.SetPortable(true)
+	if !paths.IsPortable() {
+		t.Errorf("portable mode should be enabled")
+	}
+
+	pth, err := paths.ResourcePath("foo", "baz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pth != path.Join(root, ".gopher2600/foo/baz") {
+		t.Errorf("unexpected portable path (%s)", pth)
+	}
+
+	// portable mode can be disabled explicitly even though the portable
+	// directory exists
+	paths.SetPortable(false)
+	if paths.IsPortable() {
+		t.Errorf("portable mode should not be enabled")
+	}
+}
@@ -19,12 +19,36 @@ import (
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware"
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/macro"
 )
 
+// playerPort returns the port that input for the player should be sent to.
+// the SwapPorts preference reverses the player ports.
+func playerPort(vcs *hardware.VCS, id ports.PortID) ports.PortID {
+	if !vcs.Prefs.SwapPorts.Get().(bool) {
+		return id
+	}
+
+	switch id {
+	case ports.Player0ID:
+		return ports.Player1ID
+	case ports.Player1ID:
+		return ports.Player0ID
+	}
+
+	return id
+}
+
+// SwapPorts toggles the SwapPorts preference. Returns the new value.
+func SwapPorts(vcs *hardware.VCS) (bool, error) {
+	swap := !vcs.Prefs.SwapPorts.Get().(bool)
+	return swap, vcs.Prefs.SwapPorts.Set(swap)
+}
+
 // MouseMotionEventHandler handles mouse events sent from a GUI. Returns true if key
 // has been handled, false otherwise.
 func MouseMotionEventHandler(ev gui.EventMouseMotion, vcs *hardware.VCS) (bool, error) {
-	return true, vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.PaddleSet, ev.X)
+	return true, vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.PaddleSet, ev.X)
 }
 
 // MouseButtonEventHandler handles mouse events sent from a GUI. Returns true if key
@@ -36,9 +60,9 @@ func MouseButtonEventHandler(ev gui.EventMouseButton, vcs *hardware.VCS, scr gui
 	switch ev.Button {
 	case gui.MouseButtonLeft:
 		if ev.Down {
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.PaddleFire, true)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.PaddleFire, true)
 		} else {
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.PaddleFire, false)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.PaddleFire, false)
 		}
 
 		handled = true
@@ -74,91 +98,96 @@ func KeyboardEventHandler(ev gui.EventKeyboard, vcs *hardware.VCS) (bool, error)
 			err = vcs.RIOT.Ports.HandleEvent(ports.PanelID, ports.PanelTogglePlayer1Pro, nil)
 			handled = true
 
+		// swap player ports
+		case "F7":
+			_, err = SwapPorts(vcs)
+			handled = true
+
 		// joystick
 		case "Left":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Left, true)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Left, true)
 			handled = true
 		case "Right":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Right, true)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Right, true)
 			handled = true
 		case "Up":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Up, true)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Up, true)
 			handled = true
 		case "Down":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Down, true)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Down, true)
 			handled = true
 		case "Space":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Fire, true)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Fire, true)
 			handled = true
 
 		// keypad (left player)
 		case "1", "2", "3":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, rune(ev.Key[0]))
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, rune(ev.Key[0]))
 			handled = true
 		case "Q":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '4')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '4')
 			handled = true
 		case "W":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '5')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '5')
 			handled = true
 		case "E":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '6')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '6')
 			handled = true
 		case "A":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '7')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '7')
 			handled = true
 		case "S":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '8')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '8')
 			handled = true
 		case "D":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '9')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '9')
 			handled = true
 		case "Z":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '*')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '*')
 			handled = true
 		case "X":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '0')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '0')
 			handled = true
 		case "C":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardDown, '#')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardDown, '#')
 			handled = true
 
 		// keypad (right player)
 		case "4":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '1')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '1')
 			handled = true
 		case "5":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '2')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '2')
 			handled = true
 		case "6":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '3')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '3')
 			handled = true
 		case "R":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '4')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '4')
 			handled = true
 		case "T":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '5')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '5')
 			handled = true
 		case "Y":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '6')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '6')
 			handled = true
 		case "F":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '7')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '7')
 			handled = true
 		case "G":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '8')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '8')
 			handled = true
 		case "H":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '9')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '9')
 			handled = true
 		case "V":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '*')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '*')
 			handled = true
 		case "B":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '0')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '0')
 			handled = true
 		case "N":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardDown, '#')
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardDown, '#')
 			handled = true
 		}
 	} else {
@@ -173,29 +202,29 @@ func KeyboardEventHandler(ev gui.EventKeyboard, vcs *hardware.VCS) (bool, error)
 
 		// josytick
 		case "Left":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Left, false)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Left, false)
 			handled = true
 		case "Right":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Right, false)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Right, false)
 			handled = true
 		case "Up":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Up, false)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Up, false)
 			handled = true
 		case "Down":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Down, false)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Down, false)
 			handled = true
 		case "Space":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.Fire, false)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.Fire, false)
 			handled = true
 
 		// keyboard (left player)
 		case "1", "2", "3", "Q", "W", "E", "A", "S", "D", "Z", "X", "C":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player0ID, ports.KeyboardUp, nil)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player0ID), ports.KeyboardUp, nil)
 			handled = true
 
 		// keyboard (right player)
 		case "4", "5", "6", "R", "T", "Y", "F", "G", "H", "V", "B", "N":
-			err = vcs.RIOT.Ports.HandleEvent(ports.Player1ID, ports.KeyboardUp, nil)
+			err = vcs.RIOT.Ports.HandleEvent(playerPort(vcs, ports.Player1ID), ports.KeyboardUp, nil)
 			handled = true
 		}
 	}
@@ -208,27 +237,113 @@ func (pl *playmode) guiEventHandler(ev gui.Event) (bool, error) {
 	case gui.EventQuit:
 		return false, nil
 	case gui.EventKeyboard:
-		_, err := KeyboardEventHandler(ev, pl.vcs)
+		if handled, err := pl.macroEventHandler(ev); handled || err != nil {
+			return err == nil, err
+		}
+		if ev.Key == "F6" {
+			if ev.Down && ev.Mod == gui.KeyModNone {
+				err := pl.scr.SetFeature(gui.ReqToggleInputDisplay)
+				return err == nil, err
+			}
+			return true, nil
+		}
+		handled, err := KeyboardEventHandler(ev, pl.vcs)
+		pl.inputChanged(handled)
+		if handled && err == nil && ev.Key == "F7" && ev.Down && ev.Mod == gui.KeyModNone {
+			pl.notifySwapPorts()
+		}
 		return err == nil, err
 	case gui.EventMouseButton:
-		_, err := MouseButtonEventHandler(ev, pl.vcs, pl.scr)
+		handled, err := MouseButtonEventHandler(ev, pl.vcs, pl.scr)
+		pl.inputChanged(handled)
 		return err == nil, err
 	case gui.EventMouseMotion:
-		_, err := MouseMotionEventHandler(ev, pl.vcs)
+		handled, err := MouseMotionEventHandler(ev, pl.vcs)
+		pl.inputChanged(handled)
 		return err == nil, err
+	case gui.EventPause:
+		pl.setPause(ev.Pause)
+		return true, nil
+	case gui.EventPlaymodeAction:
+		return pl.playmodeAction(ev.Action)
 	}
 
 	return true, nil
 }
 
+// the keys used to record and play macros. the index of the key in the array
+// is the macro slot.
+var macroKeys = [macro.NumSlots]string{"F9", "F10", "F11", "F12"}
+
+// macroEventHandler handles the keys used to control macros. pressing a macro
+// key plays the macro in the corresponding slot. pressing a macro key with
+// the control key starts recording into the slot; pressing any macro key
+// with the control key stops the recording.
+func (pl *playmode) macroEventHandler(ev gui.EventKeyboard) (bool, error) {
+	if pl.macros == nil {
+		return false, nil
+	}
+
+	for slot, k := range macroKeys {
+		if ev.Key != k {
+			continue // for loop
+		}
+
+		if !ev.Down {
+			return true, nil
+		}
+
+		if ev.Mod == gui.KeyModCtrl {
+			if pl.macros.IsRecording() {
+				return true, pl.macros.Stop()
+			}
+			return true, pl.macros.Record(slot)
+		}
+
+		return true, pl.macros.Play(slot)
+	}
+
+	return false, nil
+}
+
+// inputChanged notifies the run-ahead system that the input has changed.
+func (pl *playmode) inputChanged(handled bool) {
+	if handled && pl.runAhead != nil {
+		pl.runAhead.InputChanged()
+	}
+}
+
 func (pl *playmode) eventHandler() (bool, error) {
+	if pl.macros != nil {
+		sent, err := pl.macros.Check()
+		if err != nil {
+			return false, err
+		}
+		pl.inputChanged(sent)
+	}
+
+	if pl.runAhead != nil {
+		err := pl.runAhead.Check()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	pl.eventMonitor.Check()
+
 	select {
 	case <-pl.intChan:
 		return false, nil
 	case ev := <-pl.guiChan:
-		return pl.guiEventHandler(ev)
+		if cont, err := pl.guiEventHandler(ev); !cont || err != nil {
+			return cont, err
+		}
 	default:
 	}
 
+	if pl.paused {
+		return pl.pauseLoop()
+	}
+
 	return true, nil
 }
@@ -0,0 +1,251 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package playmode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/gui"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/cpu"
+	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/riot"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports/controllers"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/tia"
+	"github.com/jetsetilly/gopher2600/logger"
+)
+
+// quickState is a snapshot of the emulation held by the quick save slot. it
+// is very similar to the state type in the runahead package.
+type quickState struct {
+	cpu  *cpu.CPU
+	mem  *memory.Memory
+	riot *riot.RIOT
+	tia  *tia.TIA
+	tv   *television.State
+	cart mapper.CartSnapshot
+}
+
+func snapshotState(vcs *hardware.VCS) *quickState {
+	return &quickState{
+		cpu:  vcs.CPU.Snapshot(),
+		mem:  vcs.Mem.Snapshot(),
+		riot: vcs.RIOT.Snapshot(),
+		tia:  vcs.TIA.Snapshot(),
+		tv:   vcs.TV.Snapshot(),
+		cart: vcs.Mem.Cart.Snapshot(),
+	}
+}
+
+// plumb state into the emulation. the state is consumed and should not be
+// used again.
+func plumbState(vcs *hardware.VCS, s *quickState) {
+	// signal batching is not part of the snapshot. it belongs to the loop
+	// running the emulation and must be restored after plumbing
+	batching := vcs.TIA.IsSignalBatching()
+
+	vcs.CPU = s.cpu
+	vcs.Mem = s.mem
+	vcs.RIOT = s.riot
+	vcs.TIA = s.tia
+
+	vcs.CPU.Plumb(vcs.Mem)
+	vcs.RIOT.Plumb(vcs.Mem.RIOT, vcs.Mem.TIA)
+	vcs.TIA.Plumb(vcs.Mem.TIA, vcs.RIOT.Ports)
+	vcs.Mem.Cart.Plumb(s.cart)
+	vcs.TV.Plumb(s.tv)
+
+	// turning batching on cannot fail and turning it off only sends signals
+	// that are pending, of which there are none in a new snapshot
+	_ = vcs.TIA.SetSignalBatching(batching)
+}
+
+// setPause opens or closes the pause period. the time spent paused is
+// accumulated so that it can be removed from the play time.
+func (pl *playmode) setPause(pause bool) {
+	if pause == pl.paused {
+		return
+	}
+
+	pl.paused = pause
+	if pause {
+		pl.pauseStart = time.Now()
+	} else {
+		pl.pausedTime += time.Since(pl.pauseStart)
+	}
+
+	// the television looks after the audio mixers and the frame rate
+	// limiter. failing to pause the television is not serious enough to stop
+	// the emulation
+	if err := pl.vcs.TV.Pause(pause); err != nil {
+		logger.Log("playmode", err.Error())
+	}
+}
+
+// pauseLoop blocks until the pause has ended or until the emulation should
+// stop. GUI events are serviced in the meantime.
+func (pl *playmode) pauseLoop() (bool, error) {
+	for pl.paused {
+		select {
+		case <-pl.intChan:
+			return false, nil
+		case ev := <-pl.guiChan:
+			if cont, err := pl.guiEventHandler(ev); !cont || err != nil {
+				return cont, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// notify the user of the result of an action. not all GUIs support
+// notifications so any error is ignored.
+func (pl *playmode) notify(format string, a ...interface{}) {
+	_ = pl.scr.SetFeature(gui.ReqPlaymodeNotification, fmt.Sprintf(format, a...))
+}
+
+// notifySwapPorts tells the user which port the left player input is going
+// to.
+func (pl *playmode) notifySwapPorts() {
+	if pl.vcs.Prefs.SwapPorts.Get().(bool) {
+		pl.notify("Ports swapped. Input goes to the right port")
+	} else {
+		pl.notify("Ports not swapped. Input goes to the left port")
+	}
+}
+
+// the order in which controllers are cycled by ActionSwapController.
+var controllerCycle = []struct {
+	name string
+	new  ports.NewPeripheral
+}{
+	{name: "Stick", new: controllers.NewStick},
+	{name: "Paddle", new: controllers.NewPaddle},
+	{name: "Keyboard", new: controllers.NewKeyboard},
+}
+
+// playmodeAction performs the action requested by the GUI.
+func (pl *playmode) playmodeAction(action gui.PlaymodeAction) (bool, error) {
+	switch action {
+	case gui.ActionResetConsole:
+		if pl.fixedTimeline {
+			pl.notify("Reset not available when recording or playing back")
+			return true, nil
+		}
+		if err := pl.vcs.Reset(); err != nil {
+			return false, curated.Errorf("playmode: %v", err)
+		}
+		if pl.runAhead != nil {
+			pl.runAhead.Reset()
+		}
+		pl.notify("Console reset")
+
+	case gui.ActionSwapController:
+		// the controller is changed in the port that is receiving the input
+		// for the left player
+		id := playerPort(pl.vcs, ports.Player0ID)
+		port := "Left"
+		current := pl.vcs.RIOT.Ports.Player0
+		if id == ports.Player1ID {
+			port = "Right"
+			current = pl.vcs.RIOT.Ports.Player1
+		}
+
+		next := controllerCycle[0]
+		for i, c := range controllerCycle {
+			if c.name == current.Name() {
+				next = controllerCycle[(i+1)%len(controllerCycle)]
+				break // for loop
+			}
+		}
+		if err := pl.vcs.RIOT.Ports.AttachPlayer(id, next.new); err != nil {
+			return false, curated.Errorf("playmode: %v", err)
+		}
+		pl.inputChanged(true)
+		pl.notify("%s port using %s", port, next.name)
+
+	case gui.ActionSwapPorts:
+		if _, err := SwapPorts(pl.vcs); err != nil {
+			return false, curated.Errorf("playmode: %v", err)
+		}
+		pl.inputChanged(true)
+		pl.notifySwapPorts()
+
+	case gui.ActionSaveState:
+		if pl.fixedTimeline {
+			pl.notify("Save state not available when recording or playing back")
+			return true, nil
+		}
+		pl.quickSave = snapshotState(pl.vcs)
+		pl.notify("State saved")
+
+	case gui.ActionLoadState:
+		if pl.fixedTimeline {
+			pl.notify("Load state not available when recording or playing back")
+			return true, nil
+		}
+		if pl.quickSave == nil {
+			pl.notify("No saved state")
+			return true, nil
+		}
+
+		// plumbing consumes the state so take a new snapshot immediately
+		// afterwards. this means the saved state can be loaded more than once
+		plumbState(pl.vcs, pl.quickSave)
+		pl.quickSave = snapshotState(pl.vcs)
+		if pl.runAhead != nil {
+			pl.runAhead.Reset()
+		}
+		pl.notify("State loaded")
+
+	case gui.ActionResumeSuspend:
+		if pl.fixedTimeline {
+			pl.notify("Suspend points not available when recording or playing back")
+			return true, nil
+		}
+		if err := loadSuspendPoint(pl.vcs); err != nil {
+			pl.notify("Cannot resume: %v", err)
+			return true, nil
+		}
+		if pl.runAhead != nil {
+			pl.runAhead.Reset()
+		}
+		pl.notify("Resumed from suspend point")
+
+	case gui.ActionScreenshot:
+		n := time.Now()
+		filename := fmt.Sprintf("screenshot_%s_%s.png",
+			pl.shortName, fmt.Sprintf("%04d%02d%02d_%02d%02d%02d",
+				n.Year(), n.Month(), n.Day(), n.Hour(), n.Minute(), n.Second()))
+
+		// a failed screenshot is not fatal to the emulation
+		if err := pl.scr.SetFeature(gui.ReqScreenshot, filename); err != nil {
+			if !curated.Is(err, gui.UnsupportedGuiFeature) {
+				pl.notify("Screenshot failed: %v", err)
+			}
+			return true, nil
+		}
+		pl.notify("Screenshot saved to %s", filename)
+	}
+
+	return true, nil
+}
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/jetsetilly/gopher2600/cartridgeloader"
 	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/eventbus"
 	"github.com/jetsetilly/gopher2600/gui"
 	"github.com/jetsetilly/gopher2600/hardware"
 	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
@@ -32,8 +34,13 @@ import (
 	"github.com/jetsetilly/gopher2600/hardware/riot/ports/savekey"
 	"github.com/jetsetilly/gopher2600/hardware/television"
 	"github.com/jetsetilly/gopher2600/hiscore"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/macro"
 	"github.com/jetsetilly/gopher2600/patch"
+	"github.com/jetsetilly/gopher2600/playstats"
 	"github.com/jetsetilly/gopher2600/recorder"
+	"github.com/jetsetilly/gopher2600/runahead"
+	"github.com/jetsetilly/gopher2600/score"
 	"github.com/jetsetilly/gopher2600/setup"
 )
 
@@ -42,6 +49,40 @@ type playmode struct {
 	scr     gui.GUI
 	intChan chan os.Signal
 	guiChan chan gui.Event
+
+	// run-ahead is not available in all circumstances. will be nil if it is
+	// not being used
+	runAhead *runahead.RunAhead
+
+	// input macros. will be nil if macros are not available
+	macros *macro.Macros
+
+	// emulation events are forwarded to the GUI
+	events       *eventbus.Bus
+	eventMonitor *eventbus.Monitor
+
+	// short name of the cartridge. used when naming screenshots
+	shortName string
+
+	// a recording is being made or played back. actions that would alter the
+	// timeline (reset, save state, load state) are not allowed
+	fixedTimeline bool
+
+	// the pause menu is open and the emulation is not advancing. time spent
+	// paused is not included in the play time sent to the hiscore server
+	paused     bool
+	pauseStart time.Time
+	pausedTime time.Duration
+
+	// the quick save slot. nil if no state has been saved
+	quickSave *quickState
+
+	// the cartridge RAM is saved to disk when the emulation ends
+	persistRAM bool
+
+	// the state of the emulation is saved to a suspend point when the
+	// emulation ends
+	autosave bool
 }
 
 // Play creates a 'playable' instance of the emulator.
@@ -50,9 +91,26 @@ type playmode struct {
 // contents of the file specified in Filename field of the Loader instance will
 // be checked. If it is a playback file then the playback codepath will be
 // used.
-func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload cartridgeloader.Loader, patchFile string, hiscoreServer bool, useSavekey bool) error {
+//
+// The runAhead argument specifies the number of frames to run ahead. A
+// negative value indicates that the value in the preferences file should be
+// used. Run-ahead is not used for recordings, playbacks or PlusROM
+// cartridges.
+//
+// The autosave argument can be "ON" or "OFF" and specifies whether the state
+// of the emulation should be saved to a suspend point when the emulation ends.
+// An empty string indicates that the value in the preferences file should be
+// used. Suspend points are not used for recordings or playbacks.
+func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload cartridgeloader.Loader, patchFile string, hiscoreServer bool, useSavekey bool, runAhead int, autosave string) error {
 	var recording string
 
+	// whether the cartridge RAM should be saved at the end of the session
+	var persistRAM bool
+
+	// short name of the cartridge. for playbacks this is taken from the
+	// cartridge loader in the playback file
+	shortName := cartload.ShortName()
+
 	// if supplied cartridge name is actually a playback file then set
 	// recording variable and dump cartridgeLoader information
 	if recorder.IsPlaybackFile(cartload.Filename) {
@@ -143,6 +201,7 @@ func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload ca
 		if err != nil {
 			return err
 		}
+		shortName = plb.CartLoad.ShortName()
 
 		// not using setup.AttachCartridge. if the playback was recorded with setup
 		// changes the events will have been copied into the playback script and
@@ -174,15 +233,85 @@ func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload ca
 				return curated.Errorf("playmode: %v", err)
 			}
 		}
+
+		// restore cartridge RAM from the previous session if the setupDB says
+		// that it should persist. this is not done for recordings and
+		// playbacks because the restored RAM is not part of the recording
+		persistRAM, err = setup.PersistCartRAM(vcs.Mem.Cart.Hash)
+		if err != nil {
+			return curated.Errorf("playmode: %v", err)
+		}
+		if persistRAM {
+			err = setup.LoadCartRAM(vcs)
+			if err != nil {
+				return curated.Errorf("playmode: %v", err)
+			}
+		}
 	}
 
 	pl := &playmode{
-		vcs:     vcs,
-		scr:     scr,
-		intChan: make(chan os.Signal, 1),
-		guiChan: make(chan gui.Event, 10),
+		vcs:           vcs,
+		scr:           scr,
+		intChan:       make(chan os.Signal, 1),
+		guiChan:       make(chan gui.Event, 10),
+		shortName:     shortName,
+		fixedTimeline: newRecording || recording != "",
+		persistRAM:    persistRAM,
+	}
+
+	// prepare run-ahead and input macros. speculative frames would interfere
+	// with recordings and playbacks and would result in spurious network
+	// activity for PlusROM cartridges. macros use the event recorder of the
+	// VCS ports, which is used by the recorder package
+	if !newRecording && recording == "" {
+		pl.macros, err = macro.NewMacros(vcs)
+		if err != nil {
+			return curated.Errorf("playmode: %v", err)
+		}
+		vcs.RIOT.Ports.AttachEventRecorder(pl.macros)
+
+		if _, ok := vcs.Mem.Cart.GetContainer().(*plusrom.PlusROM); !ok {
+			pl.runAhead, err = runahead.NewRunAhead(vcs)
+			if err != nil {
+				return curated.Errorf("playmode: %v", err)
+			}
+			if runAhead >= 0 {
+				err = pl.runAhead.Prefs.Frames.Set(runAhead)
+				if err != nil {
+					return curated.Errorf("playmode: %v", err)
+				}
+			}
+		}
 	}
 
+	// suspend points would interfere with recordings and playbacks in the
+	// same way as run-ahead
+	if !pl.fixedTimeline {
+		prefs, err := newPreferences()
+		if err != nil {
+			return curated.Errorf("playmode: %v", err)
+		}
+
+		switch strings.ToUpper(autosave) {
+		case "":
+			pl.autosave = prefs.Autosave.Get().(bool)
+		case "ON":
+			pl.autosave = true
+		case "OFF":
+			pl.autosave = false
+		default:
+			return curated.Errorf("playmode: %v", fmt.Sprintf("unrecognised autosave value (%s)", autosave))
+		}
+	}
+
+	// forward emulation events to the GUI. not all GUIs support emulation
+	// events so any error is ignored
+	pl.events = eventbus.NewBus()
+	pl.eventMonitor = eventbus.NewMonitor(vcs, pl.events)
+	pl.events.Subscribe(func(n eventbus.Notification) {
+		_ = scr.SetFeature(gui.ReqEmulationEvent, n)
+	})
+
 	// connect gui
 	err = scr.SetFeature(gui.ReqSetEventChan, pl.guiChan)
 	if err != nil {
@@ -212,8 +341,15 @@ func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload ca
 	// ctrl-c is pressed. redirect interrupt signal to an os.Signal channel
 	signal.Notify(pl.intChan, os.Interrupt)
 
+	// offer to resume from a previous suspend point. the suspend point is
+	// only loaded if the user asks for it from the pause menu
+	if !pl.fixedTimeline && hasSuspendPoint(vcs) {
+		pl.notify("Suspend point found. Resume from the pause menu")
+	}
+
 	// register game and begin game session
 	var sess *hiscore.Session
+	var scoreTracker *score.Tracker
 	if hiscoreServer {
 		sess, err = hiscore.NewSession()
 		if err != nil {
@@ -224,6 +360,21 @@ func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload ca
 		if err != nil {
 			return curated.Errorf("playmode: %v", err)
 		}
+
+		// track the score if the setupDB says where it is to be found
+		fields, err := setup.ScoreFields(vcs.Mem.Cart.Hash)
+		if err != nil {
+			return curated.Errorf("playmode: %v", err)
+		}
+		if len(fields) > 0 {
+			scoreTracker = score.NewTracker(vcs.Mem)
+			for _, f := range fields {
+				if err := scoreTracker.Add(f); err != nil {
+					return curated.Errorf("playmode: %v", err)
+				}
+			}
+			tv.AddFrameTrigger(scoreTracker)
+		}
 	}
 
 	// note startime
@@ -232,11 +383,54 @@ func Play(tv *television.Television, scr gui.GUI, newRecording bool, cartload ca
 	// run and handle events
 	err = vcs.Run(pl.eventHandler)
 
-	// figure out amount of time played
-	playTime := time.Since(startTime)
+	// make sure any macro being recorded is saved
+	if pl.macros != nil {
+		if err := pl.macros.Stop(); err != nil {
+			return curated.Errorf("playmode: %v", err)
+		}
+	}
+
+	// save cartridge RAM for the next session
+	if pl.persistRAM {
+		if err := setup.SaveCartRAM(vcs); err != nil {
+			return curated.Errorf("playmode: %v", err)
+		}
+	}
+
+	// save suspend point for the next session. an error here is not serious
+	// enough to return an error, it is most likely because the cartridge
+	// mapper is not supported
+	if pl.autosave && (err == nil || curated.Has(err, ports.PowerOff)) {
+		if err := saveSuspendPoint(vcs); err != nil {
+			logger.Log("playmode", err.Error())
+		}
+	}
+
+	// figure out amount of time played. time spent in the pause menu does not
+	// count
+	pl.setPause(false)
+	playTime := time.Since(startTime) - pl.pausedTime
+
+	// add session to the play statistics. playbacks are not sessions of play.
+	// failure to record the statistics is not serious enough to return an
+	// error
+	if (newRecording || recording == "") && vcs.Mem.Cart.Hash != "" {
+		st, err := playstats.Load(vcs.Mem.Cart.Hash)
+		if err == nil {
+			err = st.AddSession(shortName, playTime)
+		}
+		if err != nil {
+			logger.Log("playmode", err.Error())
+		}
+	}
 
 	// send to high score server
 	if hiscoreServer {
+		if scoreTracker != nil {
+			if best, ok := scoreTracker.Best(); ok {
+				sess.SetScore(best)
+			}
+		}
 		if err := sess.EndSession(playTime); err != nil {
 			return curated.Errorf("playmode: %v", err)
 		}
@@ -0,0 +1,118 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package playmode
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/stellastate"
+)
+
+// suspend points are stored in this sub-directory of the resource path. the
+// name of each file is the hash of the cartridge.
+//
+// there is no savestate format for the emulation and so the state is stored
+// in the Stella savestate format. this means that suspend points are only
+// available for the cartridge mappers supported by the stellastate package
+// and that TIA state is not preserved.
+const suspendPath = "suspend"
+
+func suspendFilename(vcs *hardware.VCS) (string, error) {
+	if vcs.Mem.Cart.Hash == "" {
+		return "", curated.Errorf("suspend: %v", "cartridge has no hash")
+	}
+	return paths.ResourcePath(suspendPath, vcs.Mem.Cart.Hash)
+}
+
+// hasSuspendPoint returns true if there is a suspend point for the attached
+// cartridge.
+func hasSuspendPoint(vcs *hardware.VCS) bool {
+	pth, err := suspendFilename(vcs)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(pth)
+	return err == nil
+}
+
+// saveSuspendPoint writes the current state of the emulation to the suspend
+// point for the attached cartridge. any previous suspend point is replaced.
+func saveSuspendPoint(vcs *hardware.VCS) error {
+	pth, err := suspendFilename(vcs)
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	hash, err := stellastate.CartridgeMD5(vcs)
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	// export to a buffer first so that an unsupported cartridge does not
+	// destroy an existing suspend point
+	var buf bytes.Buffer
+	err = stellastate.Export(vcs, hash, &buf)
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	err = ioutil.WriteFile(pth, buf.Bytes(), 0600)
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	logger.Log("playmode", fmt.Sprintf("suspend point saved to %s", pth))
+
+	return nil
+}
+
+// loadSuspendPoint sets the state of the emulation from the suspend point for
+// the attached cartridge.
+func loadSuspendPoint(vcs *hardware.VCS) error {
+	pth, err := suspendFilename(vcs)
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	hash, err := stellastate.CartridgeMD5(vcs)
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(pth)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return curated.Errorf("suspend: %v", "no suspend point")
+		}
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	err = stellastate.Import(vcs, hash, bytes.NewReader(data))
+	if err != nil {
+		return curated.Errorf("suspend: %v", err)
+	}
+
+	logger.Log("playmode", fmt.Sprintf("suspend point loaded from %s", pth))
+
+	return nil
+}
@@ -0,0 +1,25 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package playstats records the amount of time spent playing each ROM and the
+// number of play sessions. The statistics complement the session duration
+// reported to the hiscore server but are kept locally and do not require a
+// server.
+//
+// Statistics are stored in a file named after the hash of the cartridge, in
+// the same way as the macro package stores macros. A session is recorded with
+// the AddSession() function. The statistics for every ROM can be retrieved
+// with List() and exported as CSV with Export().
+package playstats
@@ -0,0 +1,251 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package playstats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// the resource path in which statistics files are stored.
+const statsPath = "playstats"
+
+// the format used to store the LastPlayed field.
+const timeFormat = time.RFC3339
+
+// Stats are the play statistics for a single ROM.
+type Stats struct {
+	// hash of the cartridge data
+	Hash string
+
+	// short name of the cartridge when it was last played
+	Name string
+
+	// cumulative time spent playing the ROM. time spent paused is not
+	// included
+	PlayTime time.Duration
+
+	// the number of play sessions
+	Sessions int
+
+	// the time the most recent session ended. the zero value if the ROM has
+	// never been played
+	LastPlayed time.Time
+
+	dsk *prefs.Disk
+}
+
+// Load the statistics for the cartridge with the specified hash. The zero
+// value is returned for cartridges that have not been played before.
+func Load(hash string) (*Stats, error) {
+	if hash == "" {
+		return nil, curated.Errorf("playstats: %v", "cartridge has no hash")
+	}
+
+	pth, err := paths.ResourcePath(statsPath, hash)
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	return load(pth, hash)
+}
+
+func load(pth string, hash string) (*Stats, error) {
+	st := &Stats{Hash: hash}
+
+	var err error
+
+	st.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	err = st.dsk.Add("playstats.name", prefs.NewGeneric(
+		func(s string) error {
+			st.Name = s
+			return nil
+		},
+		func() string {
+			return st.Name
+		},
+	))
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	err = st.dsk.Add("playstats.playtime", prefs.NewGeneric(
+		func(s string) error {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			st.PlayTime = d
+			return nil
+		},
+		func() string {
+			return st.PlayTime.String()
+		},
+	))
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	err = st.dsk.Add("playstats.sessions", prefs.NewGeneric(
+		func(s string) error {
+			_, err := fmt.Sscanf(s, "%d", &st.Sessions)
+			return err
+		},
+		func() string {
+			return fmt.Sprintf("%d", st.Sessions)
+		},
+	))
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	err = st.dsk.Add("playstats.lastplayed", prefs.NewGeneric(
+		func(s string) error {
+			t, err := time.Parse(timeFormat, s)
+			if err != nil {
+				return err
+			}
+			st.LastPlayed = t
+			return nil
+		},
+		func() string {
+			return st.LastPlayed.Format(timeFormat)
+		},
+	))
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	err = st.dsk.Load(false)
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	return st, nil
+}
+
+// AddSession adds a session of the specified duration to the statistics and
+// saves them to disk.
+func (st *Stats) AddSession(name string, playTime time.Duration) error {
+	st.Name = name
+	st.PlayTime += playTime
+	st.Sessions++
+	st.LastPlayed = time.Now()
+
+	err := st.dsk.Save()
+	if err != nil {
+		return curated.Errorf("playstats: %v", err)
+	}
+
+	return nil
+}
+
+func (st Stats) String() string {
+	if st.Sessions == 0 {
+		return "never played"
+	}
+	s := "sessions"
+	if st.Sessions == 1 {
+		s = "session"
+	}
+	return fmt.Sprintf("%d %s, %s total", st.Sessions, s, st.PlayTime.Round(time.Second))
+}
+
+// List returns the statistics for every ROM that has been played, sorted by
+// name.
+func List() ([]*Stats, error) {
+	pth, err := paths.ResourcePath(statsPath, "")
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+	return list(pth)
+}
+
+func list(dir string) ([]*Stats, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, curated.Errorf("playstats: %v", err)
+	}
+
+	var l []*Stats
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		st, err := load(filepath.Join(dir, f.Name()), f.Name())
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, st)
+	}
+
+	sort.Slice(l, func(i, j int) bool {
+		return l[i].Name < l[j].Name
+	})
+
+	return l, nil
+}
+
+// Export the statistics for every ROM that has been played as CSV.
+func Export(w io.Writer) error {
+	l, err := List()
+	if err != nil {
+		return err
+	}
+	return export(w, l)
+}
+
+func export(w io.Writer, l []*Stats) error {
+	c := csv.NewWriter(w)
+
+	err := c.Write([]string{"name", "hash", "sessions", "playtime_seconds", "last_played"})
+	if err != nil {
+		return curated.Errorf("playstats: %v", err)
+	}
+
+	for _, st := range l {
+		err = c.Write([]string{
+			st.Name,
+			st.Hash,
+			fmt.Sprintf("%d", st.Sessions),
+			fmt.Sprintf("%.0f", st.PlayTime.Seconds()),
+			st.LastPlayed.Format(timeFormat),
+		})
+		if err != nil {
+			return curated.Errorf("playstats: %v", err)
+		}
+	}
+
+	c.Flush()
+	if err := c.Error(); err != nil {
+		return curated.Errorf("playstats: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package playstats
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestSessions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "playstats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pth := filepath.Join(dir, "abcdef")
+
+	st, err := load(pth, "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Equate(t, st.Sessions, 0)
+	test.Equate(t, st.String(), "never played")
+
+	err = st.AddSession("Pitfall", 90*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = st.AddSession("Pitfall", 30*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reload from disk
+	st, err = load(pth, "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Equate(t, st.Name, "Pitfall")
+	test.Equate(t, st.Sessions, 2)
+	test.Equate(t, st.PlayTime.String(), "2m0s")
+	test.ExpectedFailure(t, st.LastPlayed.IsZero())
+	test.Equate(t, st.String(), "2 sessions, 2m0s total")
+}
+
+func TestExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "playstats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, n := range []string{"Zaxxon", "Adventure"} {
+		st, err := load(filepath.Join(dir, strings.ToLower(n)), strings.ToLower(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = st.AddSession(n, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l, err := list(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.Equate(t, len(l), 2)
+	test.Equate(t, l[0].Name, "Adventure")
+
+	b := &bytes.Buffer{}
+	err = export(b, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	test.Equate(t, len(lines), 3)
+	test.ExpectedSuccess(t, strings.HasPrefix(lines[1], "Adventure,adventure,1,60,"))
+}
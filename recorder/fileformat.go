@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/version"
 )
 
 const (
@@ -56,14 +57,13 @@ const (
 )
 
 const magicString = "gopher2600playback"
-const versionString = "1.0"
 
 func (rec *Recorder) writeHeader() error {
 	lines := make([]string, numHeaderLines)
 
 	// add header information
 	lines[lineMagicString] = magicString
-	lines[lineVersion] = versionString
+	lines[lineVersion] = version.Playback.String()
 	lines[lineCartName] = rec.vcs.Mem.Cart.Filename
 	lines[lineCartHash] = rec.vcs.Mem.Cart.Hash
 	lines[lineTVSpec] = fmt.Sprintf("%v\n", rec.vcs.TV.GetReqSpecID())
@@ -90,6 +90,12 @@ func (plb *Playback) readHeader(lines []string) error {
 		return curated.Errorf("playback: not a valid transcript (%s)", plb.transcript)
 	}
 
+	// files from newer versions of the emulator can not be played back
+	err := version.Playback.Check(lines[lineVersion])
+	if err != nil {
+		return curated.Errorf("playback: %v", err)
+	}
+
 	// read header
 	plb.CartLoad.Filename = lines[lineCartName]
 	plb.CartLoad.Hash = lines[lineCartHash]
@@ -101,7 +107,8 @@ func (plb *Playback) readHeader(lines []string) error {
 // IsPlaybackFile returns true if the specified file appears to be a playback
 // file. It does not care about the nature of any errors that may be generated
 // or if the file appears to be a playback file but is of an unsupported
-// version.
+// version. An unsupported version will be reported when the file is opened
+// with NewPlayback().
 func IsPlaybackFile(filename string) bool {
 	// !!TODO: more nuanced results from IsPlaybackFile()
 
@@ -121,15 +128,5 @@ func IsPlaybackFile(filename string) bool {
 		return false
 	}
 
-	// version number verification
-	b = make([]byte, len(versionString)+1)
-	n, err = f.Read(b)
-	if n != len(versionString)+1 || err != nil {
-		return false
-	}
-	if string(b) != versionString+"\n" {
-		return false
-	}
-
 	return true
 }
@@ -232,6 +232,7 @@ func (plb *Playback) AttachToVCS(vcs *hardware.VCS) error {
 	if err != nil {
 		return curated.Errorf("playback: %v", err)
 	}
+	vcs.Prefs.Reseed(recordingSeed)
 
 	// validate header. keep it simple and disallow any difference in tv
 	// specification. some combinations may work but there's no compelling
@@ -40,6 +40,12 @@ type Recorder struct {
 	headerWritten bool
 }
 
+// the random number generator of the VCS is seeded with this value when
+// recording and when playing back. this means that the recording will
+// playback correctly even if the hardware preferences are changed to
+// randomise the hardware state.
+const recordingSeed = 1
+
 // NewRecorder is the preferred method of implementation for the FileRecorder
 // type. Note that attaching of the Recorder to all the ports of the VCS
 // (including the panel) is implicit in this function call.
@@ -63,6 +69,7 @@ func NewRecorder(transcript string, vcs *hardware.VCS) (*Recorder, error) {
 	if err != nil {
 		return nil, curated.Errorf("recorder: %v", err)
 	}
+	vcs.Prefs.Reseed(recordingSeed)
 
 	err = rec.vcs.Reset()
 	if err != nil {
@@ -0,0 +1,98 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package reflection
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// Activity classifies how the CPU cycle in which a pixel was drawn was being
+// spent. It is used by the "Cycles" overlay to show the timing slack in a
+// kernel.
+type Activity int
+
+// List of valid Activity values.
+const (
+	// the CPU is executing an instruction that is not obviously waiting
+	ActivityWork Activity = iota
+
+	// the CPU is executing an instruction that is part of a loop that
+	// is probably waiting for something, for example a loop checking the
+	// RIOT timer
+	ActivityBusy
+
+	// the CPU is halted by WSYNC
+	ActivityWSYNC
+)
+
+func (a Activity) String() string {
+	switch a {
+	case ActivityWork:
+		return "work"
+	case ActivityBusy:
+		return "busy"
+	case ActivityWSYNC:
+		return "WSYNC"
+	}
+	return "unknown activity"
+}
+
+// a branch backwards by no more than this number of bytes is considered to
+// be a busy loop. large enough for a timer loop (LDA INTIM / BNE) and for a
+// simple delay loop (DEX / BNE)
+const busyLoopSize = 8
+
+// Activity returns the classification of the CPU cycle in which the pixel was
+// drawn.
+//
+// The classification is a heuristic. An instruction is busy if it is a short
+// backwards branch that has been taken, or if it reads the RIOT timer. The
+// loop counter of a delay loop (the DEX in DEX / BNE, for example) is
+// therefore counted as work.
+func (ref Reflection) Activity() Activity {
+	if ref.WSYNC {
+		return ActivityWSYNC
+	}
+
+	defn := ref.CPU.Defn
+	if defn == nil {
+		return ActivityWork
+	}
+
+	if defn.IsBranch() {
+		if ref.CPU.BranchSuccess {
+			offset := int8(ref.CPU.InstructionData)
+			if offset < 0 && offset >= -busyLoopSize {
+				return ActivityBusy
+			}
+		}
+		return ActivityWork
+	}
+
+	if defn.Effect == instructions.Read && (defn.AddressingMode == instructions.Absolute || defn.AddressingMode == instructions.ZeroPage) {
+		ma, area := memorymap.MapAddress(ref.CPU.InstructionData, true)
+		if area == memorymap.RIOT {
+			switch addresses.RIOTReadSymbols[ma] {
+			case "INTIM", "TIMINT":
+				return ActivityBusy
+			}
+		}
+	}
+
+	return ActivityWork
+}
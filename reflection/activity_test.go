@@ -0,0 +1,83 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package reflection_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/reflection"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestActivity(t *testing.T) {
+	defns := instructions.GetDefinitions()
+
+	// opcodes of the instructions used in the test
+	const (
+		bne    = 0xd0
+		dex    = 0xca
+		ldaAbs = 0xad
+		ldaZp  = 0xa5
+		ldaImm = 0xa9
+		bitAbs = 0x2c
+		staZp  = 0x85
+	)
+
+	activity := func(opcode int, data uint16, branched bool) reflection.Activity {
+		ref := reflection.Reflection{
+			CPU: execution.Result{
+				Defn:            defns[opcode],
+				InstructionData: data,
+				BranchSuccess:   branched,
+			},
+		}
+		return ref.Activity()
+	}
+
+	// WSYNC takes priority over the instruction being executed
+	ref := reflection.Reflection{WSYNC: true}
+	ref.CPU.Defn = defns[staZp]
+	test.Equate(t, int(ref.Activity()), int(reflection.ActivityWSYNC))
+
+	// no instruction is not busy
+	ref = reflection.Reflection{}
+	test.Equate(t, int(ref.Activity()), int(reflection.ActivityWork))
+
+	// reading the timer is busy
+	test.Equate(t, int(activity(ldaAbs, 0x0284, false)), int(reflection.ActivityBusy))
+	test.Equate(t, int(activity(bitAbs, 0x0285, false)), int(reflection.ActivityBusy))
+
+	// reading other locations is not busy. nor is loading an immediate value
+	// that happens to look like the timer address
+	test.Equate(t, int(activity(ldaAbs, 0x0280, false)), int(reflection.ActivityWork))
+	test.Equate(t, int(activity(ldaZp, 0x0080, false)), int(reflection.ActivityWork))
+	test.Equate(t, int(activity(ldaImm, 0x0084, false)), int(reflection.ActivityWork))
+
+	// a short backwards branch is a busy loop but only if the branch is taken
+	test.Equate(t, int(activity(bne, 0x00fb, true)), int(reflection.ActivityBusy))
+	test.Equate(t, int(activity(bne, 0x00fb, false)), int(reflection.ActivityWork))
+
+	// long and forward branches are not busy loops
+	test.Equate(t, int(activity(bne, 0x00e0, true)), int(reflection.ActivityWork))
+	test.Equate(t, int(activity(bne, 0x0010, true)), int(reflection.ActivityWork))
+
+	// other instructions are work, including the counter of a delay loop
+	test.Equate(t, int(activity(dex, 0, false)), int(reflection.ActivityWork))
+
+	test.Equate(t, reflection.ActivityBusy.String(), "busy")
+}
@@ -0,0 +1,66 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package reflection
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+)
+
+// Buffer is a fixed-capacity store of Reflection values, indexed by the
+// horizontal position and scanline of the television signal the reflection
+// was made for.
+//
+// The storage is a single flat array large enough for any television
+// specification. It is never reallocated, not even when the television
+// changes size, so a Buffer can be kept for the lifetime of the program
+// without adding to memory pressure. Use Reset() to clear it.
+//
+// The zero value is ready to use.
+type Buffer struct {
+	refs [television.MaxSignalHistory]Reflection
+}
+
+func bufferIdx(x int, y int) (int, bool) {
+	if x < 0 || x >= specification.HorizClksScanline || y < 0 || y >= television.MaxScanlinesAbsolute {
+		return 0, false
+	}
+	return y*specification.HorizClksScanline + x, true
+}
+
+// Set the Reflection for the coordinates. Out of range coordinates are
+// ignored.
+func (buf *Buffer) Set(x int, y int, ref Reflection) {
+	if idx, ok := bufferIdx(x, y); ok {
+		buf.refs[idx] = ref
+	}
+}
+
+// Get the Reflection for the coordinates. The zero value is returned for out
+// of range coordinates.
+func (buf *Buffer) Get(x int, y int) Reflection {
+	if idx, ok := bufferIdx(x, y); ok {
+		return buf.refs[idx]
+	}
+	return Reflection{}
+}
+
+// Reset all entries to the zero value.
+func (buf *Buffer) Reset() {
+	for i := range buf.refs {
+		buf.refs[i] = Reflection{}
+	}
+}
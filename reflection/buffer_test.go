@@ -0,0 +1,75 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package reflection_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/reflection"
+)
+
+func TestBuffer(t *testing.T) {
+	var buf reflection.Buffer
+
+	buf.Set(10, 20, reflection.Reflection{WSYNC: true})
+	if !buf.Get(10, 20).WSYNC {
+		t.Errorf("expected reflection to be set")
+	}
+	if buf.Get(20, 10).WSYNC {
+		t.Errorf("unexpected reflection at transposed coordinates")
+	}
+
+	// out of range coordinates are ignored
+	buf.Set(-1, 0, reflection.Reflection{WSYNC: true})
+	buf.Set(specification.HorizClksScanline, 0, reflection.Reflection{WSYNC: true})
+	buf.Set(0, television.MaxScanlinesAbsolute, reflection.Reflection{WSYNC: true})
+	if buf.Get(-1, 0).WSYNC || buf.Get(0, television.MaxScanlinesAbsolute).WSYNC {
+		t.Errorf("expected zero value for out of range coordinates")
+	}
+
+	buf.Reset()
+	if buf.Get(10, 20).WSYNC {
+		t.Errorf("expected reflection to be cleared by Reset()")
+	}
+}
+
+// the two benchmarks below compare the cost of preparing storage for a frame
+// of reflections. run with:
+//
+//	go test -run none -bench Buffer -benchmem ./reflection
+
+// BenchmarkBuffer_alloc2D allocates a two dimensional slice in the way
+// reflection storage was allocated before the introduction of the Buffer type.
+func BenchmarkBuffer_alloc2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		refs := make([][]reflection.Reflection, specification.HorizClksScanline)
+		for x := 0; x < specification.HorizClksScanline; x++ {
+			refs[x] = make([]reflection.Reflection, television.MaxScanlinesAbsolute)
+		}
+		refs[0][0].WSYNC = true
+	}
+}
+
+func BenchmarkBuffer_reset(b *testing.B) {
+	buf := &reflection.Buffer{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		buf.Set(0, 0, reflection.Reflection{WSYNC: true})
+	}
+}
@@ -35,10 +35,47 @@ var PaletteElements = []color.RGBA{
 // PaletteEvents lists the colors to be used for reflected events. For example,
 // when WSYNC is active the PaletteEvent["WSYNC"] entry should be used.
 var PaletteEvents = map[string]color.RGBA{
-	"WSYNC":         {R: 50, G: 50, B: 255, A: 100},
-	"Collisions":    {R: 255, G: 25, B: 25, A: 200},
-	"HMOVE delay":   {R: 150, G: 50, B: 50, A: 150},
-	"HMOVE":         {R: 50, G: 150, B: 50, A: 150},
-	"HMOVE latched": {R: 50, G: 50, B: 150, A: 150},
-	"Unchanged":     {R: 255, G: 100, B: 25, A: 150},
+	"WSYNC":          {R: 50, G: 50, B: 255, A: 100},
+	"Collisions":     {R: 255, G: 25, B: 25, A: 200},
+	"HMOVE delay":    {R: 150, G: 50, B: 50, A: 150},
+	"HMOVE":          {R: 50, G: 150, B: 50, A: 150},
+	"HMOVE latched":  {R: 50, G: 50, B: 150, A: 150},
+	"Unchanged":      {R: 255, G: 100, B: 25, A: 150},
+	"Frame Diff":     {R: 255, G: 0, B: 255, A: 200},
+	"Cycles work":    {R: 255, G: 50, B: 25, A: 150},
+	"Cycles busy":    {R: 255, G: 200, B: 25, A: 150},
+	"Cycles WSYNC":   {R: 25, G: 100, B: 255, A: 150},
+	"Audio":          {R: 255, G: 255, B: 50, A: 230},
+	"Audio scanline": {R: 50, G: 255, B: 150, A: 200},
+}
+
+// PaletteElementsHighContrast is an alternative to PaletteElements. The colors
+// are chosen to be distinguishable from one another even by those with
+// common color vision deficiencies.
+var PaletteElementsHighContrast = []color.RGBA{
+	{R: 0, G: 0, B: 0, A: 255},
+	{R: 0, G: 114, B: 178, A: 255},
+	{R: 230, G: 159, B: 0, A: 255},
+	{R: 213, G: 94, B: 0, A: 255},
+	{R: 240, G: 228, B: 66, A: 255},
+	{R: 204, G: 121, B: 167, A: 255},
+	{R: 86, G: 180, B: 233, A: 255},
+}
+
+// PaletteEventsHighContrast is an alternative to PaletteEvents. As with
+// PaletteElementsHighContrast the colors have been chosen to be
+// distinguishable by those with common color vision deficiencies.
+var PaletteEventsHighContrast = map[string]color.RGBA{
+	"WSYNC":          {R: 0, G: 114, B: 178, A: 200},
+	"Collisions":     {R: 255, G: 255, B: 255, A: 230},
+	"HMOVE delay":    {R: 213, G: 94, B: 0, A: 200},
+	"HMOVE":          {R: 240, G: 228, B: 66, A: 200},
+	"HMOVE latched":  {R: 86, G: 180, B: 233, A: 200},
+	"Unchanged":      {R: 230, G: 159, B: 0, A: 200},
+	"Frame Diff":     {R: 204, G: 121, B: 167, A: 230},
+	"Cycles work":    {R: 213, G: 94, B: 0, A: 200},
+	"Cycles busy":    {R: 240, G: 228, B: 66, A: 200},
+	"Cycles WSYNC":   {R: 0, G: 114, B: 178, A: 200},
+	"Audio":          {R: 255, G: 255, B: 255, A: 230},
+	"Audio scanline": {R: 0, G: 158, B: 115, A: 230},
 }
@@ -0,0 +1,145 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package reflection
+
+import (
+	"image"
+	"image/color"
+)
+
+// OverlayEvents returns the keys in PaletteEvents (or
+// PaletteEventsHighContrast) that are used by the named overlay. The order of
+// the returned keys is the order in which they should be presented in a
+// legend.
+func OverlayEvents(overlay string) []string {
+	switch overlay {
+	case "WSYNC":
+		return []string{"WSYNC"}
+	case "Collisions":
+		return []string{"Collisions"}
+	case "HMOVE":
+		return []string{"HMOVE delay", "HMOVE", "HMOVE latched"}
+	case "Unchanged":
+		return []string{"Unchanged"}
+	case "Frame Diff":
+		return []string{"Frame Diff"}
+	case "Cycles":
+		return []string{"Cycles work", "Cycles busy", "Cycles WSYNC"}
+	case "Audio":
+		return []string{"Audio", "Audio scanline"}
+	}
+	return []string{}
+}
+
+// Composite blends the overlay image onto a copy of the pixels image. The
+// overlay colors are treated as non-premultiplied, which is how they are
+// specified in PaletteEvents, and the returned image is fully opaque.
+//
+// The overlay is aligned with the top-left corner of pixels. Any part of the
+// overlay outside of the bounds of pixels is ignored.
+func Composite(pixels *image.RGBA, overlay *image.RGBA) *image.RGBA {
+	sz := pixels.Bounds().Size()
+	img := image.NewRGBA(image.Rect(0, 0, sz.X, sz.Y))
+
+	po := pixels.Bounds().Min
+	oo := overlay.Bounds().Min
+	osz := overlay.Bounds().Size()
+
+	for y := 0; y < sz.Y; y++ {
+		for x := 0; x < sz.X; x++ {
+			p := pixels.RGBAAt(po.X+x, po.Y+y)
+			p.A = 255
+			if x < osz.X && y < osz.Y {
+				p = blend(p, overlay.RGBAAt(oo.X+x, oo.Y+y))
+			}
+			img.SetRGBA(x, y, p)
+		}
+	}
+
+	return img
+}
+
+// blend the non-premultiplied color c over the opaque color dst.
+func blend(dst color.RGBA, c color.RGBA) color.RGBA {
+	a := uint32(c.A)
+	mix := func(d, s uint8) uint8 {
+		return uint8((uint32(s)*a + uint32(d)*(255-a) + 127) / 255)
+	}
+	return color.RGBA{R: mix(dst.R, c.R), G: mix(dst.G, c.G), B: mix(dst.B, c.B), A: 255}
+}
+
+// dimensions of a single legend entry.
+const (
+	legendMargin    = 2
+	legendRowHeight = glyphHeight + legendMargin
+	legendSwatch    = glyphHeight
+)
+
+// LegendHeight returns the height in pixels of the legend that would be
+// created by AddLegend() for the named overlay.
+func LegendHeight(overlay string) int {
+	n := len(OverlayEvents(overlay))
+	if n == 0 {
+		return 0
+	}
+	return n*legendRowHeight + legendMargin
+}
+
+// AddLegend returns a copy of img with a legend for the named overlay appended
+// to the bottom. Each entry of the legend is a swatch of the event color
+// followed by the name of the event. Event names that do not fit within the
+// width of the image are truncated.
+//
+// If the overlay has no events then the returned image is an unchanged copy of
+// img.
+func AddLegend(img *image.RGBA, overlay string, palette map[string]color.RGBA) *image.RGBA {
+	sz := img.Bounds().Size()
+	leg := image.NewRGBA(image.Rect(0, 0, sz.X, sz.Y+LegendHeight(overlay)))
+
+	for y := 0; y < leg.Bounds().Size().Y; y++ {
+		for x := 0; x < sz.X; x++ {
+			if y < sz.Y {
+				leg.SetRGBA(x, y, img.RGBAAt(img.Bounds().Min.X+x, img.Bounds().Min.Y+y))
+			} else {
+				leg.SetRGBA(x, y, legendBackground)
+			}
+		}
+	}
+
+	top := sz.Y + legendMargin
+	for _, e := range OverlayEvents(overlay) {
+		// the swatch is shown as it would appear over a black pixel in the
+		// composited image
+		sw := blend(color.RGBA{A: 255}, palette[e])
+		for y := 0; y < legendSwatch; y++ {
+			for x := 0; x < legendSwatch; x++ {
+				if legendMargin+x < sz.X {
+					leg.SetRGBA(legendMargin+x, top+y, sw)
+				}
+			}
+		}
+		drawText(leg, legendMargin*2+legendSwatch, top, e, legendText)
+		top += legendRowHeight
+	}
+
+	return leg
+}
+
+// colors used in the legend.
+var (
+	legendBackground = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+	legendText       = color.RGBA{R: 220, G: 220, B: 220, A: 255}
+)
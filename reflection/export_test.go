@@ -0,0 +1,83 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package reflection_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/reflection"
+	"github.com/jetsetilly/gopher2600/test"
+)
+
+func TestComposite(t *testing.T) {
+	pixels := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	overlay := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		pixels.SetRGBA(x, 0, color.RGBA{R: 100, G: 100, B: 100, A: 255})
+	}
+
+	// fully opaque, fully transparent and half transparent overlay pixels
+	overlay.SetRGBA(0, 0, color.RGBA{R: 255, A: 255})
+	overlay.SetRGBA(1, 0, color.RGBA{R: 255, A: 0})
+	overlay.SetRGBA(2, 0, color.RGBA{R: 200, A: 128})
+
+	img := reflection.Composite(pixels, overlay)
+	test.Equate(t, img.Bounds().Size().X, 4)
+	test.Equate(t, img.Bounds().Size().Y, 2)
+
+	test.Equate(t, img.RGBAAt(0, 0) == color.RGBA{R: 255, A: 255}, true)
+	test.Equate(t, img.RGBAAt(1, 0) == color.RGBA{R: 100, G: 100, B: 100, A: 255}, true)
+	test.Equate(t, img.RGBAAt(2, 0) == color.RGBA{R: 150, G: 50, B: 50, A: 255}, true)
+
+	// pixels with no overlay are opaque in the composited image
+	test.Equate(t, img.RGBAAt(3, 1) == color.RGBA{A: 255}, true)
+
+	// pixels that are a sub-image are composited from their own origin
+	sub := pixels.SubImage(image.Rect(1, 0, 4, 2)).(*image.RGBA)
+	img = reflection.Composite(sub, overlay)
+	test.Equate(t, img.Bounds().Size().X, 3)
+	test.Equate(t, img.RGBAAt(0, 0) == color.RGBA{R: 255, G: 0, B: 0, A: 255}, true)
+}
+
+func TestLegend(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 160, 100))
+
+	// every overlay has at least one legend entry
+	for _, o := range reflection.OverlayList {
+		test.Equate(t, len(reflection.OverlayEvents(o)) > 0, true)
+		for _, e := range reflection.OverlayEvents(o) {
+			_, ok := reflection.PaletteEvents[e]
+			test.Equate(t, ok, true)
+			_, ok = reflection.PaletteEventsHighContrast[e]
+			test.Equate(t, ok, true)
+		}
+
+		leg := reflection.AddLegend(img, o, reflection.PaletteEvents)
+		test.Equate(t, leg.Bounds().Size().X, 160)
+		test.Equate(t, leg.Bounds().Size().Y, 100+reflection.LegendHeight(o))
+	}
+
+	// HMOVE overlay uses more than one color
+	test.Equate(t, len(reflection.OverlayEvents("HMOVE")), 3)
+	test.Equate(t, reflection.LegendHeight("HMOVE") > reflection.LegendHeight("WSYNC"), true)
+
+	// unknown overlays have no legend
+	test.Equate(t, reflection.LegendHeight("unknown"), 0)
+	leg := reflection.AddLegend(img, "unknown", reflection.PaletteEvents)
+	test.Equate(t, leg.Bounds().Size().Y, 100)
+}
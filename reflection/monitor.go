@@ -47,10 +47,12 @@ func (mon *Monitor) Check(bank mapper.BankInfo) error {
 		WSYNC:        !mon.vcs.CPU.RdyFlg,
 		Bank:         bank,
 		VideoElement: mon.vcs.TIA.Video.LastElement,
+		Priority:     mon.vcs.TIA.Video.LastPriority,
 		TV:           mon.vcs.TV.GetLastSignal(),
 		Hblank:       mon.vcs.TIA.Hblank,
 		Collision:    mon.vcs.TIA.Video.Collisions.Activity.String(),
 		Unchanged:    mon.vcs.TIA.Video.Unchanged,
+		AudioWrite:   mon.vcs.TIA.Audio.LastWrite,
 	}
 
 	// reflect HMOVE state
@@ -44,6 +44,7 @@ type Reflection struct {
 	CPU          execution.Result
 	Bank         mapper.BankInfo
 	VideoElement video.Element
+	Priority     video.Priority
 	TV           signal.SignalAttributes
 	Hmove        Hmove
 	WSYNC        bool
@@ -51,6 +52,7 @@ type Reflection struct {
 	Hblank       bool
 	Unchanged    bool
 	Collision    string
+	AudioWrite   string
 }
 
 // Hmove groups the HMOVE reflection information. It's too complex a property
@@ -66,4 +68,13 @@ type Hmove struct {
 
 // OverlayList is the list of overlays that should be supported by a
 // reflection.Renderer.
-var OverlayList = []string{"WSYNC", "Collisions", "HMOVE", "Unchanged"}
+//
+// The "Frame Diff" overlay is not based on reflection information but on the
+// difference between the current and previous frame.
+//
+// The "Cycles" overlay shows how the CPU was spending its time while each
+// pixel was being drawn. See the Reflection.Activity() function.
+//
+// The "Audio" overlay marks the pixels and scanlines on which a TIA audio
+// register was written to.
+var OverlayList = []string{"WSYNC", "Collisions", "HMOVE", "Unchanged", "Frame Diff", "Cycles", "Audio"}
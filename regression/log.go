@@ -145,6 +145,7 @@ func (reg *LogRegression) regress(newRegression bool, output io.Writer, msg stri
 	if err != nil {
 		return false, "", curated.Errorf("log: %v", err)
 	}
+	vcs.Prefs.Reseed(regressionSeed)
 
 	err = setup.AttachCartridge(vcs, reg.CartLoad)
 	if err != nil {
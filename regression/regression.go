@@ -18,7 +18,6 @@ package regression
 import (
 	"fmt"
 	"io"
-	"math/rand"
 	"os"
 	"os/signal"
 	"sort"
@@ -30,6 +29,10 @@ import (
 	"github.com/jetsetilly/gopher2600/paths"
 )
 
+// tests must be determinate so the random number generator of the VCS is
+// seeded with a value we know.
+const regressionSeed = 1
+
 // ansi code for clear line.
 const ansiClearLine = "\033[2K"
 
@@ -74,6 +77,10 @@ func initDBSession(db *database.Session) error {
 		return err
 	}
 
+	if err := db.RegisterEntryType(audioEntryID, deserialiseAudioEntry); err != nil {
+		return err
+	}
+
 	// make sure regression script directory exists
 	// if err := os.MkdirAll(paths.ResourcePath(regressionScripts), 0755); err != nil {
 	// 	msg := fmt.Sprintf("regression script directory: %s", err)
@@ -105,11 +112,6 @@ func RegressList(output io.Writer) error {
 
 // RegressAdd adds a new regression handler to the database.
 func RegressAdd(output io.Writer, reg Regressor) error {
-	// tests must be determinate so we set math.rand seed to something we know.
-	// reseed with clock on completion
-	rand.Seed(1)
-	defer rand.Seed(int64(time.Now().Second()))
-
 	if output == nil {
 		return fmt.Errorf("regression: add: io.Writer should not be nil (use a nopWriter)")
 	}
@@ -188,11 +190,6 @@ func RegressDelete(output io.Writer, confirmation io.Reader, key string) error {
 // list specified which entries to test. an empty keys list means that every
 // entry should be tested.
 func RegressRun(output io.Writer, verbose bool, filterKeys []string) error {
-	// tests must be determinate so we set math.rand seed to something we know.
-	// reseed with clock on completion
-	rand.Seed(1)
-	defer rand.Seed(int64(time.Now().Second()))
-
 	if output == nil {
 		return fmt.Errorf("regression: run: io.Writer should not be nil (use a nopWriter)")
 	}
@@ -203,6 +203,7 @@ func (reg *VideoRegression) regress(newRegression bool, output io.Writer, msg st
 	if err != nil {
 		return false, "", curated.Errorf("video: %v", err)
 	}
+	vcs.Prefs.Reseed(regressionSeed)
 
 	err = setup.AttachCartridge(vcs, reg.CartLoad)
 	if err != nil {
@@ -338,7 +338,7 @@ func (r *Rewind) append(s *State) {
 
 // plumb in state found at index. splice point will be updated. remaining
 // arguments as in plumbState().
-func (r *Rewind) plumb(idx, frame, scanline, horizpos int) error {
+func (r *Rewind) plumb(idx int, coords television.Coordinates) error {
 	// current index is the index we're plumbing in. this has nothing to do
 	// with the frame number (especially important to remember if frequency is
 	// greater than 1)
@@ -348,7 +348,7 @@ func (r *Rewind) plumb(idx, frame, scanline, horizpos int) error {
 	startingFrame := s.TV.GetState(signal.ReqFramenum)
 
 	// plumb in selected entry
-	err := r.plumbState(s, frame, scanline, horizpos)
+	err := r.plumbState(s, coords)
 	if err != nil {
 		return err
 	}
@@ -360,12 +360,16 @@ func (r *Rewind) plumb(idx, frame, scanline, horizpos int) error {
 }
 
 // plumb in state supplied as the argument. catch-up loop will halt as soon as
-// possible after frame/scanline/horizpos is reached or surpassed
+// possible after the coordinates are reached or surpassed
 //
 // note that this will not update the splice point up update the
 // framesSinceSnapshot value. use plumb() with an index into the history for
 // that.
-func (r *Rewind) plumbState(s *State, frame, scanline, horizpos int) error {
+func (r *Rewind) plumbState(s *State, coords television.Coordinates) error {
+	// signal batching is not part of the snapshot. it belongs to whatever
+	// loop is running the emulation and must be restored after plumbing
+	batching := r.vcs.TIA.IsSignalBatching()
+
 	// take another snapshot of the state before plumbing. we don't want the
 	// machine to change what we have stored in our state array (we learned
 	// that lesson the hard way :-)
@@ -397,17 +401,14 @@ func (r *Rewind) plumbState(s *State, frame, scanline, horizpos int) error {
 	adhocSnapshotted := r.Prefs.Freq.Get().(int) == 1
 
 	continueCheck := func() bool {
-		nf := r.vcs.TV.GetState(signal.ReqFramenum)
-		ny := r.vcs.TV.GetState(signal.ReqScanline)
-		nx := r.vcs.TV.GetState(signal.ReqHorizPos)
+		c := r.vcs.TV.GetCoords()
 
-		if !adhocSnapshotted && nf == frame-1 {
+		if !adhocSnapshotted && c.Frame == coords.Frame-1 {
 			r.adhoc = r.snapshot(levelAdhoc)
 			adhocSnapshotted = true
 		}
 
-		tooFar := nf > frame || (nf == frame && ny > scanline) || (nf == frame && ny == scanline && nx >= horizpos)
-		return !tooFar
+		return c.Before(coords)
 	}
 
 	// run emulation until continueCheck returns false
@@ -420,6 +421,11 @@ func (r *Rewind) plumbState(s *State, frame, scanline, horizpos int) error {
 		return curated.Errorf("rewind", err)
 	}
 
+	err = r.vcs.TIA.SetSignalBatching(batching)
+	if err != nil {
+		return curated.Errorf("rewind", err)
+	}
+
 	return nil
 }
 
@@ -430,14 +436,12 @@ func (r *Rewind) GotoLast() error {
 		idx += len(r.entries)
 	}
 
-	frame := r.entries[idx].TV.GetState(signal.ReqFramenum)
-	horizpos := -specification.HorizClksHBlank
-	scanline := 0
-
-	// use more specific scanline/horizpos values if entry is an "execution" entry
-	if r.entries[idx].level == levelExecution {
-		scanline = r.entries[idx].TV.GetState(signal.ReqScanline)
-		horizpos = r.entries[idx].TV.GetState(signal.ReqHorizPos)
+	// use the exact coordinates if entry is an "execution" entry. otherwise
+	// the start of the frame
+	coords := r.entries[idx].TV.GetCoords()
+	if r.entries[idx].level != levelExecution {
+		coords.Scanline = 0
+		coords.Clock = -specification.HorizClksHBlank
 	}
 
 	// make adjustments to the index so we plumbing from a suitable place
@@ -452,7 +456,7 @@ func (r *Rewind) GotoLast() error {
 		idx = r.start
 	}
 
-	return r.plumb(idx, frame, scanline, horizpos)
+	return r.plumb(idx, coords)
 }
 
 // GotoFrame searches the timeline for the frame number. If the precise frame
@@ -469,7 +473,7 @@ func (r *Rewind) GotoFrame(frame int) error {
 
 	// plumb in index. the frame argument to the plumb() function is
 	// the frame that has been requested, not the search frame
-	return r.plumb(idx, frame, 0, -specification.HorizClksHBlank)
+	return r.plumb(idx, television.Coordinates{Frame: frame, Clock: -specification.HorizClksHBlank})
 }
 
 // find index nearest to the requested frame. returns the index and the frame
@@ -551,26 +555,32 @@ func (r *Rewind) findFrameIndex(frame int) (idx int, fr int, last bool) {
 	return e, frame, false
 }
 
-// GotoFrameCoords of current frame.
-func (r *Rewind) GotoFrameCoords(scanline int, horizpos int) error {
-	// frame to which to run the catch-up loop
-	frame := r.vcs.TV.GetState(signal.ReqFramenum)
-
-	// get nearest index of entry from which we can (re)generate the current frame
-	idx, _, _ := r.findFrameIndex(frame)
+// GotoCoords searches the timeline for the frame in the coordinates and runs
+// the emulation until the coordinates are reached.
+func (r *Rewind) GotoCoords(coords television.Coordinates) error {
+	// get nearest index of entry from which we can (re)generate the frame
+	idx, _, _ := r.findFrameIndex(coords.Frame)
 
 	// if found index does not point to an immediately suitable state then try
 	// the adhoc state if available
-	if frame != r.entries[idx].TV.GetState(signal.ReqFramenum)+1 {
-		if r.adhoc != nil && r.adhoc.TV.GetState(signal.ReqFramenum) == frame-1 {
-			return r.plumbState(r.adhoc, frame, scanline, horizpos)
+	if coords.Frame != r.entries[idx].TV.GetState(signal.ReqFramenum)+1 {
+		if r.adhoc != nil && r.adhoc.TV.GetState(signal.ReqFramenum) == coords.Frame-1 {
+			return r.plumbState(r.adhoc, coords)
 		}
 	}
 
 	// we've not used adhoc this time so nillify it
 	r.adhoc = nil
 
-	return r.plumb(idx, frame, scanline, horizpos)
+	return r.plumb(idx, coords)
+}
+
+// GotoFrameCoords of current frame.
+func (r *Rewind) GotoFrameCoords(scanline int, horizpos int) error {
+	coords := r.vcs.TV.GetCoords()
+	coords.Scanline = scanline
+	coords.Clock = horizpos
+	return r.GotoCoords(coords)
 }
 
 // SetComparison points comparison to the most recent rewound entry.
@@ -584,7 +594,7 @@ func (r *Rewind) GetComparison() *State {
 }
 
 // NewFrame is in an implementation of television.FrameTrigger.
-func (r *Rewind) NewFrame(_ bool) error {
+func (r *Rewind) NewFrame(_ television.FrameInfo) error {
 	r.newFrame = true
 	return nil
 }
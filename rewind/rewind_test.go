@@ -0,0 +1,166 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package rewind_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/rewind"
+)
+
+// a simple kernel that increases a RAM value every frame.
+const kernel = `sei : cld : ldx #$ff : txs
+lda #$02 : sta $00 : sta $02 : sta $02 : sta $02 : lda #$00 : sta $00
+inc $80 : lda $80 : sta $09
+ldx #$ff : sta $02 : dex : bne $f01b
+jmp $f005`
+
+// runner implements the rewind.Runner interface.
+type runner struct {
+	vcs *hardware.VCS
+}
+
+func (r *runner) CatchUpLoop(continueCheck func() bool) error {
+	return r.vcs.Run(func() (bool, error) {
+		return continueCheck(), nil
+	})
+}
+
+// the parts of the emulation state that are compared after rewinding.
+type state struct {
+	coords television.Coordinates
+	pc     uint16
+	a      uint8
+	ram    uint8
+}
+
+func getState(t *testing.T, vcs *hardware.VCS) state {
+	t.Helper()
+
+	ram, err := vcs.Mem.Peek(0x80)
+	if err != nil {
+		t.Fatalf("unexpected error reading RAM: %v", err)
+	}
+
+	return state{
+		coords: vcs.TV.GetCoords(),
+		pc:     vcs.CPU.PC.Address(),
+		a:      vcs.CPU.A.Value(),
+		ram:    ram,
+	}
+}
+
+func TestRewindWithBatching(t *testing.T) {
+	// keep preferences out of the real resource directory
+	paths.SetPortableRoot(t.TempDir())
+	paths.SetPortable(true)
+	defer func() {
+		paths.SetPortable(false)
+		paths.SetPortableRoot("")
+	}()
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+	tv.SetFPSCap(false)
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	data, err := assembler.Cartridge(kernel)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{
+		Filename: "rewind_test",
+		Mapping:  "4k",
+		Data:     data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	rew, err := rewind.NewRewind(vcs, &runner{vcs: vcs})
+	if err != nil {
+		t.Fatalf("unexpected error creating rewind: %v", err)
+	}
+
+	// run to frame 20, rewind to frame 10 and then run until the coordinates
+	// at frame 20 are reached again. the rewind happens inside the Run()
+	// loop, which has signal batching turned on
+	var first *state
+	var second *state
+
+	err = vcs.Run(func() (bool, error) {
+		rew.Check()
+
+		if vcs.TV.GetState(signal.ReqFramenum) < 20 {
+			return true, nil
+		}
+
+		if first == nil {
+			s := getState(t, vcs)
+			first = &s
+
+			err := rew.GotoFrame(10)
+			if err != nil {
+				return false, err
+			}
+
+			if !vcs.TIA.IsSignalBatching() {
+				t.Errorf("signal batching should be on after rewinding")
+			}
+
+			return true, nil
+		}
+
+		if vcs.TV.GetCoords().Before(first.coords) {
+			return true, nil
+		}
+
+		s := getState(t, vcs)
+		second = &s
+
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error running emulation: %v", err)
+	}
+
+	if first == nil || second == nil {
+		t.Fatalf("emulation did not reach frame 20 twice")
+	}
+
+	if *first != *second {
+		t.Errorf("state after rewinding (%v) does not match original state (%v)", *second, *first)
+	}
+
+	// the RAM value is increased at the start of every frame after the
+	// first, so check that the emulation was really running
+	if first.ram != 19 {
+		t.Errorf("unexpected RAM value (%d)", first.ram)
+	}
+}
@@ -0,0 +1,40 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package runahead hides the input lag inherent in many VCS ROMs. Many games
+// only react to input a frame or two after the input has occurred. By
+// emulating the next few frames ahead of time and displaying the last of
+// those frames, the input lag as seen by the player is reduced.
+//
+// The RunAhead type works by maintaining two timelines. The real timeline is
+// the emulation as it would be without run-ahead. Audio is produced only by
+// the real timeline. The speculative timeline is kept a fixed number of
+// frames ahead of the real timeline and it is only the speculative timeline
+// that is displayed.
+//
+// In the normal course of events the speculative timeline is advanced by a
+// single frame for every real frame. When an input event occurs the
+// speculative timeline is rolled back; that is, it is discarded and
+// recreated from the real timeline, with the new input applied.
+//
+// The number of frames to run ahead is set by the Frames preference. A value
+// of zero disables the run-ahead feature. Values greater than two are unlikely
+// to be useful and will adversely affect performance.
+//
+// Note that because the speculative timeline is discarded, run-ahead is not
+// suitable for cartridges that communicate with the outside world (PlusROM
+// for example) and should not be used during recording or playback of input
+// scripts.
+package runahead
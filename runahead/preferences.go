@@ -0,0 +1,79 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package runahead
+
+import (
+	"github.com/jetsetilly/gopher2600/paths"
+	"github.com/jetsetilly/gopher2600/prefs"
+)
+
+// Preferences for the run-ahead system.
+type Preferences struct {
+	dsk *prefs.Disk
+
+	// the number of frames to run ahead. zero disables run-ahead.
+	Frames prefs.Int
+}
+
+func (p *Preferences) String() string {
+	return p.dsk.String()
+}
+
+// the default number of frames to run ahead.
+const defaultFrames = 0
+
+// the maximum number of frames to run ahead.
+const maxFrames = 4
+
+// newPreferences is the preferred method of initialisation for the Preferences type.
+func newPreferences() (*Preferences, error) {
+	p := &Preferences{}
+
+	p.Frames.Set(defaultFrames)
+
+	// save server using the prefs package
+	pth, err := paths.ResourcePath("", prefs.DefaultPrefsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dsk, err = prefs.NewDisk(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Add("playmode.runAhead", &p.Frames)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.dsk.Load(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Load run-ahead preferences.
+func (p *Preferences) Load() error {
+	return p.dsk.Load(false)
+}
+
+// Save current run-ahead preferences to disk.
+func (p *Preferences) Save() error {
+	return p.dsk.Save()
+}
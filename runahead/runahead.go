@@ -0,0 +1,240 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package runahead
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/cpu"
+	"github.com/jetsetilly/gopher2600/hardware/memory"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/hardware/riot"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/tia"
+)
+
+// state is a snapshot of the emulation. it is very similar to the State type
+// in the rewind package.
+type state struct {
+	cpu  *cpu.CPU
+	mem  *memory.Memory
+	riot *riot.RIOT
+	tia  *tia.TIA
+	tv   *television.State
+	cart mapper.CartSnapshot
+}
+
+// RunAhead keeps a speculative timeline of the emulation a fixed number of
+// frames ahead of the real timeline.
+type RunAhead struct {
+	vcs *hardware.VCS
+
+	Prefs *Preferences
+
+	// the speculative timeline. nil if the timeline needs to be recreated
+	ahead *state
+
+	// the number of frames the speculative timeline is ahead of the real
+	// timeline
+	aheadFrames int
+
+	// a new frame has been triggered. resolve on next call to Check()
+	newFrame bool
+
+	// speculative frames are being run. the NewFrame() function ignores
+	// frame triggers during this time
+	speculating bool
+}
+
+// NewRunAhead is the preferred method of initialisation for the RunAhead
+// type. The RunAhead instance is added to the VCS television as a
+// FrameTrigger.
+func NewRunAhead(vcs *hardware.VCS) (*RunAhead, error) {
+	ra := &RunAhead{
+		vcs: vcs,
+	}
+
+	var err error
+
+	ra.Prefs, err = newPreferences()
+	if err != nil {
+		return nil, curated.Errorf("runahead: %v", err)
+	}
+
+	vcs.TV.AddFrameTrigger(ra)
+
+	return ra, nil
+}
+
+// NewFrame implements the television.FrameTrigger interface.
+func (ra *RunAhead) NewFrame(_ television.FrameInfo) error {
+	if !ra.speculating {
+		ra.newFrame = true
+	}
+	return nil
+}
+
+// InputChanged should be called whenever an input event has been applied to
+// the emulation. The speculative timeline will be rolled back on the next
+// call to Check().
+func (ra *RunAhead) InputChanged() {
+	ra.ahead = nil
+}
+
+// Reset discards the speculative timeline. It should be called whenever the
+// emulation state has been changed by something other than the emulation
+// itself. For example, when a new cartridge has been attached.
+func (ra *RunAhead) Reset() {
+	ra.ahead = nil
+	ra.newFrame = false
+	ra.vcs.TV.MuteRenderers(false)
+}
+
+// frames returns the number of frames to run ahead as specified by the
+// preferences, clamped to a sensible range.
+func (ra *RunAhead) frames() int {
+	n := ra.Prefs.Frames.Get().(int)
+	if n < 0 {
+		return 0
+	}
+	if n > maxFrames {
+		return maxFrames
+	}
+	return n
+}
+
+// Check should be called after every CPU instruction. If a new frame has been
+// triggered since the last call then the speculative timeline is advanced
+// and the last speculative frame sent to the television's pixel renderers.
+//
+// If the TIA is batching signals then the new frame may not be noticed until
+// the batch has been sent to the television, which will be no more than one
+// scanline later.
+func (ra *RunAhead) Check() error {
+	if !ra.newFrame {
+		return nil
+	}
+	ra.newFrame = false
+
+	frames := ra.frames()
+
+	// run-ahead has been disabled. make sure the real timeline is visible
+	if frames == 0 {
+		if ra.ahead != nil {
+			ra.ahead = nil
+			ra.vcs.TV.MuteRenderers(false)
+		}
+		return nil
+	}
+
+	// number of frames in the preferences has changed so the speculative
+	// timeline must be recreated
+	if frames != ra.aheadFrames {
+		ra.ahead = nil
+		ra.aheadFrames = frames
+	}
+
+	// signal batching is not part of a snapshot. it belongs to the loop that
+	// is running the real timeline and must be restored once the real
+	// timeline has been plumbed back in
+	batching := ra.vcs.TIA.IsSignalBatching()
+
+	real := ra.snapshot()
+
+	// the speculative timeline only needs to be advanced by one frame if it
+	// is still valid. otherwise run all the frames from the current position
+	// of the real timeline
+	run := 1
+	if ra.ahead == nil {
+		run = frames
+	} else {
+		ra.plumb(ra.ahead)
+	}
+
+	err := ra.speculate(run)
+	if err != nil {
+		return curated.Errorf("runahead: %v", err)
+	}
+
+	ra.ahead = ra.snapshot()
+	ra.plumb(real)
+
+	err = ra.vcs.TIA.SetSignalBatching(batching)
+	if err != nil {
+		return curated.Errorf("runahead: %v", err)
+	}
+
+	return nil
+}
+
+// run the speculative timeline for the specified number of frames. only the
+// last frame is sent to the pixel renderers and no frame is sent to the audio
+// mixers. the real timeline is never sent to the pixel renderers.
+func (ra *RunAhead) speculate(frames int) error {
+	ra.speculating = true
+	defer func() {
+		ra.speculating = false
+	}()
+
+	cap := ra.vcs.TV.SetFPSCap(false)
+	defer ra.vcs.TV.SetFPSCap(cap)
+
+	ra.vcs.TV.MuteMixers(true)
+	defer ra.vcs.TV.MuteMixers(false)
+
+	defer ra.vcs.TV.MuteRenderers(true)
+
+	for i := 0; i < frames; i++ {
+		ra.vcs.TV.MuteRenderers(i < frames-1)
+
+		target := ra.vcs.TV.GetState(signal.ReqFramenum) + 1
+		err := ra.vcs.Run(func() (bool, error) {
+			return ra.vcs.TV.GetState(signal.ReqFramenum) < target, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ra *RunAhead) snapshot() *state {
+	return &state{
+		cpu:  ra.vcs.CPU.Snapshot(),
+		mem:  ra.vcs.Mem.Snapshot(),
+		riot: ra.vcs.RIOT.Snapshot(),
+		tia:  ra.vcs.TIA.Snapshot(),
+		tv:   ra.vcs.TV.Snapshot(),
+		cart: ra.vcs.Mem.Cart.Snapshot(),
+	}
+}
+
+// plumb state into the emulation. the state is consumed and should not be
+// used again.
+func (ra *RunAhead) plumb(s *state) {
+	ra.vcs.CPU = s.cpu
+	ra.vcs.Mem = s.mem
+	ra.vcs.RIOT = s.riot
+	ra.vcs.TIA = s.tia
+
+	ra.vcs.CPU.Plumb(ra.vcs.Mem)
+	ra.vcs.RIOT.Plumb(ra.vcs.Mem.RIOT, ra.vcs.Mem.TIA)
+	ra.vcs.TIA.Plumb(ra.vcs.Mem.TIA, ra.vcs.RIOT.Ports)
+	ra.vcs.Mem.Cart.Plumb(s.cart)
+	ra.vcs.TV.Plumb(s.tv)
+}
@@ -0,0 +1,178 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package runahead_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/assembler"
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/hardware/television/signal"
+	"github.com/jetsetilly/gopher2600/hardware/television/specification"
+	"github.com/jetsetilly/gopher2600/runahead"
+)
+
+// a simple kernel that changes the audio frequency every frame.
+const kernel = `sei : cld : ldx #$ff : txs
+lda #$02 : sta $00 : sta $02 : sta $02 : sta $02 : lda #$00 : sta $00
+inc $80 : lda $80 : sta $17 : sta $09 : lda #$0f : sta $19 : lda #$04 : sta $15
+ldx #$ff : sta $02 : dex : bne $f025
+jmp $f005`
+
+type mixer struct {
+	data []uint8
+}
+
+func (m *mixer) SetAudio(audioData uint8) error {
+	m.data = append(m.data, audioData)
+	return nil
+}
+
+func (m *mixer) EndMixing() error {
+	return nil
+}
+
+type renderer struct {
+	tv     *television.Television
+	frames []int
+}
+
+func (r *renderer) Resize(_ specification.Spec, _, _ int) error { return nil }
+func (r *renderer) NewScanline(_ int) error                     { return nil }
+func (r *renderer) UpdatingPixels(_ bool)                       {}
+func (r *renderer) SetPixel(_ signal.SignalAttributes, _ bool) error {
+	return nil
+}
+func (r *renderer) Reset()              {}
+func (r *renderer) EndRendering() error { return nil }
+
+func (r *renderer) NewFrame(info television.FrameInfo) error {
+	r.frames = append(r.frames, info.FrameNum-1)
+	return nil
+}
+
+// run the test ROM for the number of frames. the frame count is of frames in
+// the real timeline.
+func run(t *testing.T, frames int, ahead int, inputChanges bool) (*mixer, *renderer) {
+	t.Helper()
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+	tv.SetFPSCap(false)
+
+	mx := &mixer{}
+	tv.AddAudioMixer(mx)
+	rn := &renderer{tv: tv}
+	tv.AddPixelRenderer(rn)
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	data, err := assembler.Cartridge(kernel)
+	if err != nil {
+		t.Fatalf("unexpected error assembling kernel: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{
+		Filename: "runahead_test",
+		Mapping:  "4k",
+		Data:     data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	ra, err := runahead.NewRunAhead(vcs)
+	if err != nil {
+		t.Fatalf("unexpected error creating run-ahead: %v", err)
+	}
+	err = ra.Prefs.Frames.Set(ahead)
+	if err != nil {
+		t.Fatalf("unexpected error setting preference: %v", err)
+	}
+
+	err = vcs.Run(func() (bool, error) {
+		// inspecting the television sends any pending signals so the frame
+		// number is read before calling Check(). this makes sure the final
+		// frame is noticed before the loop ends
+		fn := vcs.TV.GetState(signal.ReqFramenum)
+		err := ra.Check()
+		if err != nil {
+			return false, err
+		}
+
+		// speculation runs the emulation inside this loop. signal batching
+		// should be the same afterwards
+		if !vcs.TIA.IsSignalBatching() {
+			return false, fmt.Errorf("signal batching has been turned off")
+		}
+		if inputChanges && fn%3 == 0 {
+			ra.InputChanged()
+		}
+		return fn < frames, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error running emulation: %v", err)
+	}
+
+	return mx, rn
+}
+
+func TestRunAhead(t *testing.T) {
+	const frames = 30
+
+	refMx, refRn := run(t, frames, 0, false)
+	if len(refRn.frames) != frames {
+		t.Fatalf("expected %d frames from reference run, got %d", frames, len(refRn.frames))
+	}
+
+	for _, inputChanges := range []bool{false, true} {
+		for ahead := 1; ahead <= 2; ahead++ {
+			mx, rn := run(t, frames, ahead, inputChanges)
+
+			// audio should be the same as the reference. the run-ahead
+			// timeline never produces any audio
+			if len(mx.data) != len(refMx.data) {
+				t.Fatalf("ahead %d: expected %d audio samples, got %d", ahead, len(refMx.data), len(mx.data))
+			}
+			for i := range mx.data {
+				if mx.data[i] != refMx.data[i] {
+					t.Fatalf("ahead %d: audio differs from reference at sample %d", ahead, i)
+				}
+			}
+
+			// the first frame is rendered before run-ahead has started.
+			// thereafter, a frame is rendered at the end of every real frame
+			// and should be the specified number of frames ahead of the real
+			// frame that has just ended
+			if len(rn.frames) != frames+1 {
+				t.Fatalf("ahead %d: expected %d frames to be rendered, got %d", ahead, frames+1, len(rn.frames))
+			}
+			for i, fn := range rn.frames[1:] {
+				if fn != i+ahead {
+					t.Fatalf("ahead %d: expected rendered frame %d to be frame %d, got %d", ahead, i+1, i+ahead, fn)
+				}
+			}
+		}
+	}
+}
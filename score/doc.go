@@ -0,0 +1,30 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package score interprets areas of VCS memory as the score (or timer) of the
+// running game.
+//
+// Most games store their score as packed binary-coded decimal (BCD), two
+// digits to a byte, with the most significant digits at the lowest address.
+// A Field describes where the score is to be found and how it is formatted.
+// The Tracker type reads the current value of every Field it has been given.
+//
+// Fields can be specified by the user or by the setup database (see the setup
+// package).
+//
+// The Tracker also records the best score seen since the last call to
+// Clear(). The best score is the highest value of the first field with the
+// BCD format. It is used when submitting scores to the hiscore server.
+package score
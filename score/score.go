@@ -0,0 +1,291 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package score
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// Format specifies how the bytes of a Field are interpreted.
+type Format int
+
+// List of valid Format values.
+const (
+	// each byte is two BCD digits.
+	FormatBCD Format = iota
+
+	// each byte is two BCD digits. the bytes are formatted as minutes and
+	// seconds (two bytes) or as hours, minutes and seconds (three bytes).
+	FormatTimer
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatBCD:
+		return "BCD"
+	case FormatTimer:
+		return "TIMER"
+	}
+	panic("unknown score format")
+}
+
+// ParseFormat returns the Format with the specified name. The name is not case
+// sensitive.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToUpper(s) {
+	case "BCD":
+		return FormatBCD, nil
+	case "TIMER":
+		return FormatTimer, nil
+	}
+	return FormatBCD, curated.Errorf("score: unknown format (%s)", s)
+}
+
+// the maximum number of bytes in a field. eight BCD digits is larger than any
+// score a VCS game is likely to display.
+const maxFieldLen = 4
+
+// Field describes the location and format of a score or timer.
+type Field struct {
+	Label  string
+	Format Format
+
+	// the addresses of the bytes that make up the score, most significant
+	// byte first
+	Addresses []uint16
+}
+
+func (f Field) String() string {
+	s := strings.Builder{}
+	s.WriteString(fmt.Sprintf("%s: %s", f.Label, f.Format))
+	for _, a := range f.Addresses {
+		s.WriteString(fmt.Sprintf(" %#04x", a))
+	}
+	return s.String()
+}
+
+func (f Field) validate() error {
+	if f.Label == "" {
+		return curated.Errorf("score: field has no label")
+	}
+
+	switch f.Format {
+	case FormatBCD:
+		if len(f.Addresses) == 0 || len(f.Addresses) > maxFieldLen {
+			return curated.Errorf("score: BCD field must have between 1 and %d addresses", maxFieldLen)
+		}
+	case FormatTimer:
+		if len(f.Addresses) < 2 || len(f.Addresses) > 3 {
+			return curated.Errorf("score: TIMER field must have 2 or 3 addresses")
+		}
+	default:
+		return curated.Errorf("score: unknown format for field")
+	}
+
+	return nil
+}
+
+// Reading is the current value of a Field.
+type Reading struct {
+	Label  string
+	Format Format
+
+	// the decoded value. for FormatTimer this is the number of seconds
+	Value int
+
+	// the value formatted for display. invalid digits are shown as '?'
+	Text string
+
+	// false if any of the bytes are not valid BCD. the Value field should be
+	// ignored if Valid is false
+	Valid bool
+}
+
+// Decode the sequence of bytes according to the Format.
+func Decode(format Format, data []uint8) Reading {
+	r := Reading{Format: format, Valid: true}
+
+	digits := strings.Builder{}
+	value := 0
+
+	for i, d := range data {
+		hi := d >> 4
+		lo := d & 0x0f
+
+		for _, n := range []uint8{hi, lo} {
+			if n > 9 {
+				r.Valid = false
+				digits.WriteRune('?')
+			} else {
+				digits.WriteRune(rune('0' + n))
+			}
+		}
+
+		v := int(hi)*10 + int(lo)
+		if format == FormatTimer {
+			// hours are unbounded but minutes and seconds are base 60
+			if i > 0 {
+				if v > 59 {
+					r.Valid = false
+				}
+				value = value*60 + v
+			} else {
+				value = v
+			}
+			if i < len(data)-1 {
+				digits.WriteRune(':')
+			}
+		} else {
+			value = value*100 + v
+		}
+	}
+
+	r.Text = digits.String()
+
+	if format == FormatBCD {
+		// suppress leading zeroes but always leave at least one digit
+		r.Text = strings.TrimLeft(r.Text, "0")
+		if r.Text == "" {
+			r.Text = "0"
+		}
+	}
+
+	if r.Valid {
+		r.Value = value
+	}
+
+	return r
+}
+
+// Peeker is the interface to memory required by the Tracker. The
+// memory.Memory type from the hardware package satisfies this interface.
+type Peeker interface {
+	Peek(address uint16) (uint8, error)
+}
+
+// Tracker reads the score fields from memory. It implements the
+// television.FrameTrigger interface so that the best score can be recorded
+// every frame.
+type Tracker struct {
+	mem    Peeker
+	fields []Field
+
+	best      int
+	bestValid bool
+}
+
+// NewTracker is the preferred method of initialisation for the Tracker type.
+func NewTracker(mem Peeker) *Tracker {
+	return &Tracker{mem: mem}
+}
+
+// Add a field to the tracker. Fields with the same label as an existing
+// field will replace the existing field.
+func (trk *Tracker) Add(f Field) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	// copy addresses so the field cannot be altered after it has been added
+	f.Addresses = append([]uint16{}, f.Addresses...)
+
+	for i := range trk.fields {
+		if trk.fields[i].Label == f.Label {
+			trk.fields[i] = f
+			trk.bestValid = false
+			return nil
+		}
+	}
+
+	trk.fields = append(trk.fields, f)
+
+	return nil
+}
+
+// Clear all fields and forget the best score.
+func (trk *Tracker) Clear() {
+	trk.fields = trk.fields[:0]
+	trk.bestValid = false
+}
+
+// Fields returns a copy of the fields in the tracker.
+func (trk *Tracker) Fields() []Field {
+	return append([]Field{}, trk.fields...)
+}
+
+func (trk *Tracker) read(f Field) (Reading, error) {
+	data := make([]uint8, len(f.Addresses))
+	for i, a := range f.Addresses {
+		var err error
+		data[i], err = trk.mem.Peek(a)
+		if err != nil {
+			return Reading{}, curated.Errorf("score: %v", err)
+		}
+	}
+
+	r := Decode(f.Format, data)
+	r.Label = f.Label
+
+	return r, nil
+}
+
+// Read returns the current value of every field, in the order in which the
+// fields were added.
+func (trk *Tracker) Read() ([]Reading, error) {
+	rs := make([]Reading, 0, len(trk.fields))
+	for _, f := range trk.fields {
+		r, err := trk.read(f)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+// NewFrame implements the television.FrameTrigger interface.
+func (trk *Tracker) NewFrame(_ television.FrameInfo) error {
+	for _, f := range trk.fields {
+		if f.Format != FormatBCD {
+			continue
+		}
+
+		r, err := trk.read(f)
+		if err != nil {
+			return err
+		}
+
+		if r.Valid && (!trk.bestValid || r.Value > trk.best) {
+			trk.best = r.Value
+			trk.bestValid = true
+		}
+
+		// only the first BCD field is considered
+		break
+	}
+
+	return nil
+}
+
+// Best returns the highest score seen since the tracker was last cleared. The
+// boolean return value is false if no score has been seen.
+func (trk *Tracker) Best() (int, bool) {
+	return trk.best, trk.bestValid
+}
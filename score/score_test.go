@@ -0,0 +1,111 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package score_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/score"
+)
+
+type mockMem map[uint16]uint8
+
+func (mem mockMem) Peek(address uint16) (uint8, error) {
+	return mem[address], nil
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		format score.Format
+		data   []uint8
+		text   string
+		value  int
+		valid  bool
+	}{
+		{score.FormatBCD, []uint8{0x00, 0x12, 0x34}, "1234", 1234, true},
+		{score.FormatBCD, []uint8{0x00, 0x00}, "0", 0, true},
+		{score.FormatBCD, []uint8{0x98, 0x76, 0x54, 0x32}, "98765432", 98765432, true},
+		{score.FormatBCD, []uint8{0x01, 0x2a}, "12?", 0, false},
+		{score.FormatTimer, []uint8{0x02, 0x35}, "02:35", 155, true},
+		{score.FormatTimer, []uint8{0x01, 0x00, 0x05}, "01:00:05", 3605, true},
+		{score.FormatTimer, []uint8{0x01, 0x75}, "01:75", 0, false},
+	}
+
+	for _, tt := range tests {
+		r := score.Decode(tt.format, tt.data)
+		if r.Text != tt.text || r.Value != tt.value || r.Valid != tt.valid {
+			t.Errorf("%s %v: got %q %d %v (expected %q %d %v)", tt.format, tt.data,
+				r.Text, r.Value, r.Valid, tt.text, tt.value, tt.valid)
+		}
+	}
+}
+
+func TestTracker(t *testing.T) {
+	mem := mockMem{0x80: 0x00, 0x81: 0x10, 0x82: 0x01, 0x83: 0x30}
+	trk := score.NewTracker(mem)
+
+	if err := trk.Add(score.Field{Label: "score", Format: score.FormatBCD}); err == nil {
+		t.Errorf("expected error for field with no addresses")
+	}
+	if err := trk.Add(score.Field{Label: "time", Format: score.FormatTimer, Addresses: []uint16{0x82}}); err == nil {
+		t.Errorf("expected error for timer field with one address")
+	}
+
+	if err := trk.Add(score.Field{Label: "score", Format: score.FormatBCD, Addresses: []uint16{0x80, 0x81}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := trk.Add(score.Field{Label: "time", Format: score.FormatTimer, Addresses: []uint16{0x82, 0x83}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rs, err := trk.Read()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rs) != 2 || rs[0].Text != "10" || rs[1].Text != "01:30" {
+		t.Errorf("unexpected readings: %v", rs)
+	}
+
+	if _, ok := trk.Best(); ok {
+		t.Errorf("best score should not be valid before first frame")
+	}
+
+	// best score only ever increases
+	for _, v := range []uint8{0x20, 0x50, 0x00} {
+		mem[0x81] = v
+		_ = trk.NewFrame(television.FrameInfo{Stable: true})
+	}
+	if best, ok := trk.Best(); !ok || best != 50 {
+		t.Errorf("unexpected best score: %d %v", best, ok)
+	}
+
+	// fields with the same label replace the existing field
+	if err := trk.Add(score.Field{Label: "score", Format: score.FormatBCD, Addresses: []uint16{0x81}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trk.Fields()) != 2 {
+		t.Errorf("unexpected number of fields: %d", len(trk.Fields()))
+	}
+
+	trk.Clear()
+	if len(trk.Fields()) != 0 {
+		t.Errorf("fields not cleared")
+	}
+	if _, ok := trk.Best(); ok {
+		t.Errorf("best score not cleared")
+	}
+}
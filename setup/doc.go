@@ -20,6 +20,8 @@
 //	Toggling of panel switches
 //	Apply patches to cartridge
 //	Television specification
+//	Location of score in memory
+//	Persistence of cartridge RAM
 //
 // Menu driven selection of patches would be a nice feature to have in the
 // future. But at the moment, the package doesn't even facilitate editing of
@@ -44,4 +46,21 @@
 //	<DB Key>, television, <SHA-1 Hash>, <tv spec>, notes
 //
 // TV spec should be one of PAL or NTSC (or AUTO)
+//
+//	Score
+//
+//	<DB Key>, score, <SHA-1 Hash>, <label>, <format>, <addresses>, <notes>
+//
+// Format should be one of BCD or TIMER. Addresses are separated by spaces and
+// the most significant byte is first. For example, "0x80 0x81 0x82". Score
+// entries do not change the emulation, they are returned by ScoreFields().
+//
+//	Persist
+//
+//	<DB Key>, persist, <SHA-1 Hash>, <notes>
+//
+// Indicates that the cartridge RAM should survive between sessions, for
+// homebrew cartridges that save progress in cartridge RAM. The entry does not
+// change the emulation. Use PersistCartRAM() to check for the entry and
+// LoadCartRAM() and SaveCartRAM() to restore and save the RAM.
 package setup
@@ -0,0 +1,201 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package setup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/database"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/cartridge/mapper"
+	"github.com/jetsetilly/gopher2600/logger"
+	"github.com/jetsetilly/gopher2600/paths"
+)
+
+const persistID = "persist"
+
+const (
+	persistFieldCartHash int = iota
+	persistFieldNotes
+	numPersistFields
+)
+
+// the resource path in which cartridge RAM is stored.
+const cartRAMPath = "cartram"
+
+// persistEntry indicates that the cartridge RAM is intended to survive
+// between sessions. Like the score entry it makes no changes to the VCS. The
+// entry is used by the PersistCartRAM() function.
+type persistEntry struct {
+	cartHash string
+	notes    string
+}
+
+func deserialisePersistEntry(fields database.SerialisedEntry) (database.Entry, error) {
+	set := &persistEntry{}
+
+	// basic sanity check
+	if len(fields) > numPersistFields {
+		return nil, curated.Errorf("persist: too many fields in persist entry")
+	}
+	if len(fields) < numPersistFields {
+		return nil, curated.Errorf("persist: too few fields in persist entry")
+	}
+
+	set.cartHash = fields[persistFieldCartHash]
+	set.notes = fields[persistFieldNotes]
+
+	return set, nil
+}
+
+// ID implements the database.Entry interface.
+func (set persistEntry) ID() string {
+	return persistID
+}
+
+// String implements the database.Entry interface.
+func (set persistEntry) String() string {
+	return set.cartHash
+}
+
+// Serialise implements the database.Entry interface.
+func (set *persistEntry) Serialise() (database.SerialisedEntry, error) {
+	return database.SerialisedEntry{
+			set.cartHash,
+			set.notes,
+		},
+		nil
+}
+
+// CleanUp implements the database.Entry interface.
+func (set persistEntry) CleanUp() error {
+	// no cleanup necessary
+	return nil
+}
+
+// matchCartHash implements setupEntry interface.
+func (set persistEntry) matchCartHash(hash string) bool {
+	return set.cartHash == hash
+}
+
+// apply implements setupEntry interface.
+func (set persistEntry) apply(vcs *hardware.VCS) error {
+	// persist entries do not change the VCS. loading of cartridge RAM is
+	// performed explicitly with LoadCartRAM() because it is not wanted in
+	// all circumstances. for example, it would break recordings
+	return nil
+}
+
+// PersistCartRAM returns true if the setupDB indicates that the RAM of the
+// cartridge with the specified hash should be saved between sessions.
+func PersistCartRAM(cartHash string) (bool, error) {
+	var persist bool
+
+	err := selectEntries(cartHash, func(set setupEntry) error {
+		if _, ok := set.(*persistEntry); ok {
+			persist = true
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return persist, nil
+}
+
+// cartRAM returns the RAM segments of the attached cartridge. returns nil if
+// the cartridge has no RAM.
+func cartRAM(vcs *hardware.VCS) (mapper.CartRAMbus, []mapper.CartRAM) {
+	bus := vcs.Mem.Cart.GetRAMbus()
+	if bus == nil {
+		return nil, nil
+	}
+	return bus, bus.GetRAM()
+}
+
+// LoadCartRAM restores the cartridge RAM from the copy saved by SaveCartRAM().
+// It is not an error for there to be no saved copy.
+func LoadCartRAM(vcs *hardware.VCS) error {
+	bus, ram := cartRAM(vcs)
+	if ram == nil {
+		return nil
+	}
+
+	pth, err := paths.ResourcePath(cartRAMPath, vcs.Mem.Cart.Hash)
+	if err != nil {
+		return curated.Errorf("setup: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(pth)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return curated.Errorf("setup: %v", err)
+	}
+
+	// the saved data is all RAM segments, one after the other
+	var size int
+	for _, r := range ram {
+		size += len(r.Data)
+	}
+	if len(data) != size {
+		return curated.Errorf("setup: %v", fmt.Sprintf("saved cartridge RAM is the wrong size (%d bytes, expected %d)", len(data), size))
+	}
+
+	for bank, r := range ram {
+		for idx := range r.Data {
+			bus.PutRAM(bank, idx, data[0])
+			data = data[1:]
+		}
+	}
+
+	logger.Log("setup", fmt.Sprintf("cartridge RAM loaded from %s", pth))
+
+	return nil
+}
+
+// SaveCartRAM saves the cartridge RAM so that it can be restored by
+// LoadCartRAM() in a later session.
+func SaveCartRAM(vcs *hardware.VCS) error {
+	_, ram := cartRAM(vcs)
+	if ram == nil {
+		return nil
+	}
+
+	pth, err := paths.ResourcePath(cartRAMPath, vcs.Mem.Cart.Hash)
+	if err != nil {
+		return curated.Errorf("setup: %v", err)
+	}
+
+	var data []byte
+	for _, r := range ram {
+		data = append(data, r.Data...)
+	}
+
+	err = ioutil.WriteFile(pth, data, 0600)
+	if err != nil {
+		return curated.Errorf("setup: %v", err)
+	}
+
+	logger.Log("setup", fmt.Sprintf("cartridge RAM saved to %s", pth))
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package setup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/database"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/score"
+)
+
+const scoreID = "score"
+
+const (
+	scoreFieldCartHash int = iota
+	scoreFieldLabel
+	scoreFieldFormat
+	scoreFieldAddresses
+	scoreFieldNotes
+	numScoreFields
+)
+
+// scoreEntry describes the location of a score or timer in memory. Unlike
+// other entry types it makes no changes to the VCS, the entry is used by the
+// ScoreFields() function.
+type scoreEntry struct {
+	cartHash string
+	field    score.Field
+	notes    string
+}
+
+func deserialiseScoreEntry(fields database.SerialisedEntry) (database.Entry, error) {
+	set := &scoreEntry{}
+
+	// basic sanity check
+	if len(fields) > numScoreFields {
+		return nil, curated.Errorf("score: too many fields in score entry")
+	}
+	if len(fields) < numScoreFields {
+		return nil, curated.Errorf("score: too few fields in score entry")
+	}
+
+	set.cartHash = fields[scoreFieldCartHash]
+	set.field.Label = fields[scoreFieldLabel]
+
+	var err error
+
+	set.field.Format, err = score.ParseFormat(fields[scoreFieldFormat])
+	if err != nil {
+		return nil, curated.Errorf("score: %v", err)
+	}
+
+	// addresses are separated by spaces
+	for _, a := range strings.Fields(fields[scoreFieldAddresses]) {
+		v, err := strconv.ParseUint(a, 0, 16)
+		if err != nil {
+			return nil, curated.Errorf("score: invalid address (%s)", a)
+		}
+		set.field.Addresses = append(set.field.Addresses, uint16(v))
+	}
+
+	set.notes = fields[scoreFieldNotes]
+
+	return set, nil
+}
+
+// ID implements the database.Entry interface.
+func (set scoreEntry) ID() string {
+	return scoreID
+}
+
+// String implements the database.Entry interface.
+func (set scoreEntry) String() string {
+	return fmt.Sprintf("%s, %s", set.cartHash, set.field)
+}
+
+// Serialise implements the database.Entry interface.
+func (set *scoreEntry) Serialise() (database.SerialisedEntry, error) {
+	addresses := make([]string, len(set.field.Addresses))
+	for i, a := range set.field.Addresses {
+		addresses[i] = fmt.Sprintf("%#04x", a)
+	}
+
+	return database.SerialisedEntry{
+			set.cartHash,
+			set.field.Label,
+			set.field.Format.String(),
+			strings.Join(addresses, " "),
+			set.notes,
+		},
+		nil
+}
+
+// CleanUp implements the database.Entry interface.
+func (set scoreEntry) CleanUp() error {
+	// no cleanup necessary
+	return nil
+}
+
+// matchCartHash implements setupEntry interface.
+func (set scoreEntry) matchCartHash(hash string) bool {
+	return set.cartHash == hash
+}
+
+// apply implements setupEntry interface.
+func (set scoreEntry) apply(vcs *hardware.VCS) error {
+	// score entries do not change the VCS
+	return nil
+}
+
+// ScoreFields returns the score fields in the setupDB for the cartridge with
+// the specified hash. An empty list is returned if there is no setupDB.
+func ScoreFields(cartHash string) ([]score.Field, error) {
+	var fields []score.Field
+
+	err := selectEntries(cartHash, func(set setupEntry) error {
+		if s, ok := set.(*scoreEntry); ok {
+			fields = append(fields, s.field)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
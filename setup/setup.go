@@ -53,6 +53,14 @@ func initDBSession(db *database.Session) error {
 		return err
 	}
 
+	if err := db.RegisterEntryType(scoreID, deserialiseScoreEntry); err != nil {
+		return err
+	}
+
+	if err := db.RegisterEntryType(persistID, deserialisePersistEntry); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -65,6 +73,19 @@ func AttachCartridge(vcs *hardware.VCS, cartload cartridgeloader.Loader) error {
 		return curated.Errorf("setup: %v", err)
 	}
 
+	err = selectEntries(vcs.Mem.Cart.Hash, func(set setupEntry) error {
+		return set.apply(vcs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// selectEntries calls onMatch for every entry in the setupDB that matches the
+// cartridge hash. The absence of the setupDB is not an error.
+func selectEntries(cartHash string, onMatch func(setupEntry) error) error {
 	dbPth, err := paths.ResourcePath("", setupDBFile)
 	if err != nil {
 		return curated.Errorf("setup: %v", err)
@@ -84,11 +105,11 @@ func AttachCartridge(vcs *hardware.VCS, cartload cartridgeloader.Loader) error {
 		// database entry should also satisfy setupEntry interface
 		set, ok := ent.(setupEntry)
 		if !ok {
-			return curated.Errorf("setup: attach cartridge: database entry does not satisfy setupEntry interface")
+			return curated.Errorf("setup: database entry does not satisfy setupEntry interface")
 		}
 
-		if set.matchCartHash(vcs.Mem.Cart.Hash) {
-			err := set.apply(vcs)
+		if set.matchCartHash(cartHash) {
+			err := onMatch(set)
 			if err != nil {
 				return err
 			}
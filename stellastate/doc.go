@@ -0,0 +1,43 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package stellastate imports and exports emulation state in the style of
+// savestate files created by the Stella emulator. The intention is to allow
+// users to move an in-progress game between emulators and to cross-check
+// machine state when investigating emulation accuracy.
+//
+// Stella's savestate format is not formally documented. It is a direct
+// serialisation of Stella's internal emulation state and the details change
+// between versions of Stella. This package is therefore a best-effort
+// implementation. It uses the same primitive encodings as Stella's Serializer
+// class and the same header and cartridge check, but only the following
+// sections are supported:
+//
+//	M6502      the CPU registers and status flags
+//	M6532      the VCS RAM and the RIOT timer
+//	Cartridge  the current bank and any Superchip RAM
+//
+// Furthermore, only the plain Atari cartridge mappers (2k, 4k, F8, F6 and F4)
+// are supported.
+//
+// Stella's TIA state is specific to how Stella emulates the TIA and there is
+// no meaningful mapping to Gopher2600's TIA. TIA state is neither exported nor
+// imported. In practice, most ROMs will have rewritten all relevant TIA
+// registers within a frame of the state being imported.
+//
+// The cartridge is identified by the MD5 hash of the cartridge data, as is the
+// case with Stella. Import() will fail if the hash in the savestate does not
+// match the hash of the cartridge currently attached.
+package stellastate
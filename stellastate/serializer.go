@@ -0,0 +1,119 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package stellastate
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Stella's Serializer class uses specific byte patterns for boolean values.
+const (
+	truePattern  = 0xfe
+	falsePattern = 0x01
+)
+
+// serializer writes values using the same encoding as Stella's Serializer
+// class. the first error encountered is retained and subsequent writes do
+// nothing.
+type serializer struct {
+	w   io.Writer
+	err error
+}
+
+func (s *serializer) putBytes(b []uint8) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = s.w.Write(b)
+}
+
+func (s *serializer) putByte(v uint8) {
+	s.putBytes([]uint8{v})
+}
+
+func (s *serializer) putShort(v uint16) {
+	b := make([]uint8, 2)
+	binary.LittleEndian.PutUint16(b, v)
+	s.putBytes(b)
+}
+
+func (s *serializer) putInt(v uint32) {
+	b := make([]uint8, 4)
+	binary.LittleEndian.PutUint32(b, v)
+	s.putBytes(b)
+}
+
+func (s *serializer) putBool(v bool) {
+	if v {
+		s.putByte(truePattern)
+	} else {
+		s.putByte(falsePattern)
+	}
+}
+
+// strings are prefixed with their length.
+func (s *serializer) putString(v string) {
+	s.putInt(uint32(len(v)))
+	s.putBytes([]uint8(v))
+}
+
+// deserializer is the counterpart to the serializer type.
+type deserializer struct {
+	r   io.Reader
+	err error
+}
+
+func (d *deserializer) getBytes(n int) []uint8 {
+	b := make([]uint8, n)
+	if d.err != nil {
+		return b
+	}
+	_, d.err = io.ReadFull(d.r, b)
+	return b
+}
+
+func (d *deserializer) getByte() uint8 {
+	return d.getBytes(1)[0]
+}
+
+func (d *deserializer) getShort() uint16 {
+	return binary.LittleEndian.Uint16(d.getBytes(2))
+}
+
+func (d *deserializer) getInt() uint32 {
+	return binary.LittleEndian.Uint32(d.getBytes(4))
+}
+
+// any value other than truePattern is false.
+func (d *deserializer) getBool() bool {
+	return d.getByte() == truePattern
+}
+
+// the length prefix of a string is limited to a sensible value to prevent
+// a corrupt file from causing a very large allocation.
+const maxStringLength = 1024
+
+func (d *deserializer) getString() string {
+	n := d.getInt()
+	if n > maxStringLength {
+		if d.err == nil {
+			d.err = io.ErrUnexpectedEOF
+		}
+		return ""
+	}
+	return string(d.getBytes(int(n)))
+}
@@ -0,0 +1,239 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package stellastate
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/riot/timer"
+)
+
+// the header string used by Stella to identify a savestate file. the numeric
+// part is the version of Stella that created the file.
+const stateHeader = "06000000state"
+
+// section names as used by Stella.
+const (
+	sectionCPU  = "M6502"
+	sectionRIOT = "M6532"
+	sectionCart = "Cartridge"
+)
+
+// the address of the first hotspot for each of the supported mappers. the
+// hotspot for a bank is the first hotspot plus the bank number. a value of
+// zero indicates a single bank cartridge.
+var supportedMappers = map[string]uint16{
+	"2k": 0x0000,
+	"4k": 0x0000,
+	"F8": 0x1ff8,
+	"F6": 0x1ff6,
+	"F4": 0x1ff4,
+}
+
+func checkMapper(vcs *hardware.VCS) (uint16, error) {
+	id := vcs.Mem.Cart.ID()
+	hotspot, ok := supportedMappers[id]
+	if !ok {
+		return 0, curated.Errorf("stellastate: %v", fmt.Sprintf("unsupported cartridge mapper (%s)", id))
+	}
+	return hotspot, nil
+}
+
+// CartridgeMD5 returns the MD5 hash of the cartridge data, as used by Stella
+// to identify cartridges. The emulation doesn't keep that hash so the original
+// cartridge data is reloaded and the hash calculated as required.
+func CartridgeMD5(vcs *hardware.VCS) (string, error) {
+	cart := vcs.Mem.Cart
+	if cart.IsEjected() {
+		return "", curated.Errorf("stellastate: %v", "no cartridge attached")
+	}
+
+	cartload := cartridgeloader.NewLoader(cart.Filename, "AUTO")
+	err := cartload.Load()
+	if err != nil {
+		return "", curated.Errorf("stellastate: %v", err)
+	}
+
+	return fmt.Sprintf("%x", md5.Sum(cartload.Data)), nil
+}
+
+// Export the state of the emulation to w. The md5 argument is the MD5 hash of
+// the cartridge data.
+func Export(vcs *hardware.VCS, md5 string, w io.Writer) error {
+	if _, err := checkMapper(vcs); err != nil {
+		return err
+	}
+
+	s := &serializer{w: w}
+
+	s.putString(stateHeader)
+	s.putString(md5)
+
+	// cpu
+	s.putString(sectionCPU)
+	s.putByte(vcs.CPU.A.Value())
+	s.putByte(vcs.CPU.X.Value())
+	s.putByte(vcs.CPU.Y.Value())
+	s.putByte(vcs.CPU.SP.Value())
+	s.putShort(vcs.CPU.PC.Value())
+	s.putBool(vcs.CPU.Status.Sign)
+	s.putBool(vcs.CPU.Status.Overflow)
+	s.putBool(vcs.CPU.Status.Break)
+	s.putBool(vcs.CPU.Status.DecimalMode)
+	s.putBool(vcs.CPU.Status.InterruptDisable)
+	s.putBool(!vcs.CPU.Status.Zero)
+	s.putBool(vcs.CPU.Status.Carry)
+
+	// riot
+	s.putString(sectionRIOT)
+	s.putBytes(vcs.Mem.RAM.RAM)
+	s.putByte(vcs.RIOT.Timer.INTIMvalue)
+	s.putInt(uint32(vcs.RIOT.Timer.Divider))
+	s.putInt(uint32(vcs.RIOT.Timer.TicksRemaining))
+
+	// cartridge
+	s.putString(sectionCart)
+	s.putShort(uint16(vcs.Mem.Cart.GetBank(memorymap.OriginCart).Number))
+
+	var ram []uint8
+	if bus := vcs.Mem.Cart.GetRAMbus(); bus != nil {
+		if r := bus.GetRAM(); len(r) > 0 {
+			ram = r[0].Data
+		}
+	}
+	s.putInt(uint32(len(ram)))
+	s.putBytes(ram)
+
+	if s.err != nil {
+		return curated.Errorf("stellastate: %v", s.err)
+	}
+
+	return nil
+}
+
+// Import emulation state from r. The md5 argument is the MD5 hash of the
+// cartridge data and must match the hash recorded in the savestate.
+//
+// The VCS is only changed once the savestate has been read successfully.
+func Import(vcs *hardware.VCS, md5 string, r io.Reader) error {
+	hotspot, err := checkMapper(vcs)
+	if err != nil {
+		return err
+	}
+
+	d := &deserializer{r: r}
+
+	if h := d.getString(); d.err == nil && h != stateHeader {
+		return curated.Errorf("stellastate: %v", fmt.Sprintf("unsupported savestate version (%s)", h))
+	}
+	if h := d.getString(); d.err == nil && h != md5 {
+		return curated.Errorf("stellastate: %v", "savestate is for a different cartridge")
+	}
+
+	section := func(name string) {
+		if s := d.getString(); d.err == nil && s != name {
+			d.err = fmt.Errorf("expected %s section but found %s", name, s)
+		}
+	}
+
+	// cpu
+	section(sectionCPU)
+	a := d.getByte()
+	x := d.getByte()
+	y := d.getByte()
+	sp := d.getByte()
+	pc := d.getShort()
+	sign := d.getBool()
+	overflow := d.getBool()
+	brk := d.getBool()
+	decimal := d.getBool()
+	interrupt := d.getBool()
+	notZero := d.getBool()
+	carry := d.getBool()
+
+	// riot
+	section(sectionRIOT)
+	ram := d.getBytes(len(vcs.Mem.RAM.RAM))
+	intim := d.getByte()
+	divider := timer.Interval(d.getInt())
+	ticks := int(d.getInt())
+
+	switch divider {
+	case timer.TIM1T, timer.TIM8T, timer.TIM64T, timer.T1024T:
+	default:
+		if d.err == nil {
+			d.err = fmt.Errorf("unrecognised timer interval (%d)", divider)
+		}
+	}
+
+	// cartridge
+	section(sectionCart)
+	bank := int(d.getShort())
+	cartRAM := d.getBytes(int(d.getInt()))
+
+	if bank >= vcs.Mem.Cart.NumBanks() {
+		if d.err == nil {
+			d.err = fmt.Errorf("bank out of range (%d)", bank)
+		}
+	}
+
+	if d.err != nil {
+		return curated.Errorf("stellastate: %v", d.err)
+	}
+
+	// state has been read successfully so we can now update the VCS
+	vcs.CPU.A.Load(a)
+	vcs.CPU.X.Load(x)
+	vcs.CPU.Y.Load(y)
+	vcs.CPU.SP.Load(sp)
+	vcs.CPU.PC.Load(pc)
+	vcs.CPU.Status.Sign = sign
+	vcs.CPU.Status.Overflow = overflow
+	vcs.CPU.Status.Break = brk
+	vcs.CPU.Status.DecimalMode = decimal
+	vcs.CPU.Status.InterruptDisable = interrupt
+	vcs.CPU.Status.Zero = !notZero
+	vcs.CPU.Status.Carry = carry
+
+	copy(vcs.Mem.RAM.RAM, ram)
+	vcs.RIOT.Timer.SetValue(intim)
+	vcs.RIOT.Timer.Divider = divider
+	vcs.RIOT.Timer.SetTicks(ticks)
+
+	// switch bank by reading the hotspot for that bank
+	if hotspot != 0x0000 {
+		_, err := vcs.Mem.Cart.Read(hotspot + uint16(bank))
+		if err != nil {
+			return curated.Errorf("stellastate: %v", err)
+		}
+	}
+
+	if bus := vcs.Mem.Cart.GetRAMbus(); bus != nil {
+		if r := bus.GetRAM(); len(r) > 0 {
+			for i := 0; i < len(r[0].Data) && i < len(cartRAM); i++ {
+				bus.PutRAM(0, i, cartRAM[i])
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package stellastate_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/cartridgeloader"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+	"github.com/jetsetilly/gopher2600/hardware/riot/timer"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/stellastate"
+)
+
+const testMD5 = "0123456789abcdef0123456789abcdef"
+
+func newVCS(t *testing.T) *hardware.VCS {
+	t.Helper()
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	err = vcs.AttachCartridge(cartridgeloader.Loader{
+		Filename: "stellastate_test",
+		Mapping:  "F8",
+		Data:     make([]byte, 8192),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	return vcs
+}
+
+func TestRoundTrip(t *testing.T) {
+	vcs := newVCS(t)
+
+	vcs.CPU.A.Load(0x12)
+	vcs.CPU.X.Load(0x34)
+	vcs.CPU.Y.Load(0x56)
+	vcs.CPU.SP.Load(0xf0)
+	vcs.CPU.PC.Load(0xf123)
+	vcs.CPU.Status.Carry = true
+	vcs.CPU.Status.Zero = true
+	vcs.Mem.RAM.RAM[0] = 0xaa
+	vcs.Mem.RAM.RAM[127] = 0x55
+	vcs.RIOT.Timer.SetValue(0x40)
+	vcs.RIOT.Timer.Divider = timer.TIM64T
+	vcs.RIOT.Timer.SetTicks(10)
+	_, _ = vcs.Mem.Cart.Read(0x1ff8)
+
+	var b bytes.Buffer
+	err := stellastate.Export(vcs, testMD5, &b)
+	if err != nil {
+		t.Fatalf("unexpected error exporting state: %v", err)
+	}
+
+	n := newVCS(t)
+
+	// state for a different cartridge should not be accepted
+	err = stellastate.Import(n, "fedcba9876543210fedcba9876543210", bytes.NewReader(b.Bytes()))
+	if err == nil {
+		t.Errorf("expected error importing state with different MD5")
+	}
+
+	err = stellastate.Import(n, testMD5, bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error importing state: %v", err)
+	}
+
+	if n.CPU.A.Value() != 0x12 || n.CPU.X.Value() != 0x34 || n.CPU.Y.Value() != 0x56 || n.CPU.SP.Value() != 0xf0 {
+		t.Errorf("registers not imported correctly")
+	}
+	if n.CPU.PC.Value() != 0xf123 {
+		t.Errorf("PC not imported correctly: got %04x", n.CPU.PC.Value())
+	}
+	if n.CPU.Status != vcs.CPU.Status {
+		t.Errorf("status register not imported correctly: got %s wanted %s", n.CPU.Status, vcs.CPU.Status)
+	}
+	if !bytes.Equal(n.Mem.RAM.RAM, vcs.Mem.RAM.RAM) {
+		t.Errorf("RAM not imported correctly")
+	}
+	if n.RIOT.Timer.INTIMvalue != 0x40 || n.RIOT.Timer.Divider != timer.TIM64T || n.RIOT.Timer.TicksRemaining != 10 {
+		t.Errorf("timer not imported correctly: got %s", n.RIOT.Timer)
+	}
+	if n.Mem.Cart.GetBank(memorymap.OriginCart).Number != 0 {
+		t.Errorf("bank not imported correctly: got %d", n.Mem.Cart.GetBank(memorymap.OriginCart).Number)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	vcs := newVCS(t)
+
+	var b bytes.Buffer
+	err := stellastate.Export(vcs, testMD5, &b)
+	if err != nil {
+		t.Fatalf("unexpected error exporting state: %v", err)
+	}
+
+	err = stellastate.Import(vcs, testMD5, bytes.NewReader(b.Bytes()[:b.Len()-10]))
+	if err == nil {
+		t.Errorf("expected error importing truncated state")
+	}
+}
+
+func TestCartridgeMD5(t *testing.T) {
+	data := make([]byte, 4096)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	f, err := ioutil.TempFile("", "stellastate_*.bin")
+	if err != nil {
+		t.Fatalf("unexpected error creating cartridge file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	f.Close()
+	if err != nil {
+		t.Fatalf("unexpected error writing cartridge file: %v", err)
+	}
+
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	cartload := cartridgeloader.NewLoader(f.Name(), "AUTO")
+	err = vcs.AttachCartridge(cartload)
+	if err != nil {
+		t.Fatalf("unexpected error attaching cartridge: %v", err)
+	}
+
+	hash, err := stellastate.CartridgeMD5(vcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hash != fmt.Sprintf("%x", md5.Sum(data)) {
+		t.Errorf("unexpected MD5 hash (%s)", hash)
+	}
+
+	// the cartridge data is reloaded so a missing file is an error
+	os.Remove(f.Name())
+	_, err = stellastate.CartridgeMD5(vcs)
+	if err == nil {
+		t.Errorf("expected error for missing cartridge file")
+	}
+}
@@ -37,6 +37,10 @@
 // types (eg. uint16) can be compared against int for convenience. See Equate()
 // documentation for discussion why.
 //
+// The Frame() function creates the television signals for a single frame. It
+// is useful for testing television and output packages without the need to
+// create an emulated VCS.
+//
 // The two "assert thread" functions, AssertMainThread() and
 // AssertNonMainThread() will panic if they are not called from, respectively,
 // the main thread or from a non-main thread. These functions do nothing unless
@@ -0,0 +1,36 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package test
+
+import "github.com/jetsetilly/gopher2600/hardware/television/signal"
+
+// Frame returns the signals for a single frame of the specified number of
+// scanlines. Every scanline has a horizontal sync pulse at the conventional
+// position and every pixel is set to the specified color. If vsync is true
+// then the VSYNC bit is set for the last three scanlines of the frame.
+func Frame(scanlines int, vsync bool, col signal.ColorSignal) []signal.SignalAttributes {
+	sigs := make([]signal.SignalAttributes, 0, scanlines*228)
+	for sl := 0; sl < scanlines; sl++ {
+		for cl := 0; cl < 228; cl++ {
+			var sig signal.SignalAttributes
+			sig.SetVSync(vsync && sl >= scanlines-3)
+			sig.SetHSync(cl >= 16 && cl < 32)
+			sig.SetPixel(col)
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
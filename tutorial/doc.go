@@ -0,0 +1,29 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package tutorial contains explanatory text for people learning how to
+// program the VCS. The text is intended to be shown alongside the emulation
+// in the debugger, annotating what is happening in terms that a newcomer can
+// understand.
+//
+// The Bands() function divides a frame into the parts that every VCS program
+// must generate: VSYNC, VBLANK, the visible picture and overscan. Each part has
+// a Note explaining its purpose.
+//
+// The Annotate() function explains the effect of a CPU instruction on the TIA
+// or the RIOT. Only instructions that write to a register using absolute or
+// zero page addressing are annotated. Indexed writes are not annotated
+// because the target register cannot be known from the instruction alone.
+package tutorial
@@ -0,0 +1,157 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tutorial
+
+// Note is a piece of explanatory text.
+type Note struct {
+	// short title. for example, the name of the register
+	Title string
+
+	// a single sentence summary
+	Summary string
+
+	// a longer explanation. may contain more than one paragraph, separated
+	// by a blank line
+	Detail string
+}
+
+// FramePart identifies the different parts of a television frame.
+type FramePart int
+
+// List of valid FramePart values.
+const (
+	PartVSYNC FramePart = iota
+	PartVBLANK
+	PartVisible
+	PartOverscan
+	PartHBLANK
+)
+
+func (p FramePart) String() string {
+	switch p {
+	case PartVSYNC:
+		return "VSYNC"
+	case PartVBLANK:
+		return "VBLANK"
+	case PartVisible:
+		return "Visible"
+	case PartOverscan:
+		return "Overscan"
+	case PartHBLANK:
+		return "HBLANK"
+	}
+	return ""
+}
+
+// Note returns the explanation for the frame part.
+func (p FramePart) Note() Note {
+	return frameNotes[p]
+}
+
+var frameNotes = map[FramePart]Note{
+	PartVSYNC: {
+		Title:   "VSYNC",
+		Summary: "Vertical sync. Tells the television to start a new frame.",
+		Detail: `The program turns on VSYNC by writing to the VSYNC register and holds it on for
+(usually) three scanlines. The television sees this as the signal to move the beam
+back to the top of the screen.
+
+The VCS does not generate VSYNC automatically. If the program does not generate it
+regularly the picture will roll.`,
+	},
+	PartVBLANK: {
+		Title:   "VBLANK",
+		Summary: "Vertical blank. The beam is off while the television prepares for the picture.",
+		Detail: `After VSYNC the program keeps VBLANK on for a number of scanlines (usually 37 for
+NTSC). Nothing is drawn during this time so the program uses it for game logic.
+
+A common technique is to set the RIOT timer at the start of VBLANK and to wait for
+it to expire before turning VBLANK off.`,
+	},
+	PartVisible: {
+		Title:   "Visible",
+		Summary: "The visible picture. The TIA draws pixels as the beam moves across the screen.",
+		Detail: `The TIA has no frame buffer. The program must update the TIA registers as the beam
+moves down the screen, often several times on every scanline. This is known as
+"racing the beam".
+
+The part of the program that draws the visible picture is called the kernel. Every
+scanline is 76 CPU cycles long and the kernel must fit its work into that time.`,
+	},
+	PartOverscan: {
+		Title:   "Overscan",
+		Summary: "Overscan. VBLANK is on again at the bottom of the screen.",
+		Detail: `After the visible picture the program turns VBLANK back on for a number of
+scanlines (usually 30 for NTSC). As with the VBLANK period at the top of the screen,
+this time is used for game logic, for example reading the joysticks.`,
+	},
+	PartHBLANK: {
+		Title:   "HBLANK",
+		Summary: "Horizontal blank. The beam is returning to the left edge of the screen.",
+		Detail: `The first 68 color clocks (22.6 CPU cycles) of every scanline are HBLANK. The TIA
+generates HBLANK automatically and nothing is drawn during this time.
+
+Writing to WSYNC halts the CPU until the start of the next HBLANK, which is how a
+program keeps its kernel in step with the television.`,
+	},
+}
+
+// Line describes the state of the television signal for one scanline.
+type Line struct {
+	VSync  bool
+	VBlank bool
+}
+
+// Band is a range of scanlines that all belong to the same part of the frame.
+// The Bottom scanline is included in the range.
+type Band struct {
+	Part   FramePart
+	Top    int
+	Bottom int
+}
+
+// Bands divides a frame into parts. The lines argument describes the signal
+// for every scanline in the frame, starting with scanline zero.
+//
+// Scanlines with VBLANK on are VBLANK if they come before the first visible
+// scanline and Overscan otherwise. VSYNC takes precedence over VBLANK.
+func Bands(lines []Line) []Band {
+	var bands []Band
+
+	visible := false
+	for sl, l := range lines {
+		var p FramePart
+		switch {
+		case l.VSync:
+			p = PartVSYNC
+		case !l.VBlank:
+			p = PartVisible
+			visible = true
+		case visible:
+			p = PartOverscan
+		default:
+			p = PartVBLANK
+		}
+
+		if len(bands) > 0 && bands[len(bands)-1].Part == p {
+			bands[len(bands)-1].Bottom = sl
+		} else {
+			bands = append(bands, Band{Part: p, Top: sl, Bottom: sl})
+		}
+	}
+
+	return bands
+}
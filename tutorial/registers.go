@@ -0,0 +1,297 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tutorial
+
+import (
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/hardware/memory/memorymap"
+)
+
+// Register returns the explanation for the named write register. The name
+// should be the canonical name of a TIA or RIOT write register.
+func Register(name string) (Note, bool) {
+	n, ok := registerNotes[name]
+	if !ok {
+		return Note{}, false
+	}
+	n.Title = name
+	return n, true
+}
+
+// Annotate returns the explanation for the effect the CPU instruction has on
+// the TIA or the RIOT. Returns false if the instruction does not write to a
+// TIA or RIOT register or if the target register cannot be determined.
+func Annotate(res execution.Result) (Note, bool) {
+	if res.Defn == nil || !res.Final {
+		return Note{}, false
+	}
+
+	if res.Defn.Effect != instructions.Write && res.Defn.Effect != instructions.RMW {
+		return Note{}, false
+	}
+
+	if res.Defn.AddressingMode != instructions.Absolute && res.Defn.AddressingMode != instructions.ZeroPage {
+		return Note{}, false
+	}
+
+	ma, area := memorymap.MapAddress(res.InstructionData, false)
+
+	var name string
+	switch area {
+	case memorymap.TIA:
+		name = addresses.TIAWriteSymbols[ma]
+	case memorymap.RIOT:
+		name = addresses.RIOTWriteSymbols[ma]
+	default:
+		return Note{}, false
+	}
+
+	return Register(name)
+}
+
+// the Title field is filled in by the Register() function.
+var registerNotes = map[string]Note{
+	// TIA
+	"VSYNC": {
+		Summary: "Turns vertical sync on or off (bit 1).",
+		Detail: `VSYNC must be turned on for (usually) three scanlines at the start of every frame.
+It is normal to write to WSYNC three times while VSYNC is on.`,
+	},
+	"VBLANK": {
+		Summary: "Turns vertical blanking on or off (bit 1).",
+		Detail: `While VBLANK is on the TIA outputs black. VBLANK is on at the top of the screen
+after VSYNC and at the bottom of the screen (overscan). Bits 6 and 7 also control
+the input latches and the paddle capacitors.`,
+	},
+	"WSYNC": {
+		Summary: "Halts the CPU until the start of the next scanline.",
+		Detail: `The value written to WSYNC does not matter. The TIA holds the RDY line of the CPU
+low until HBLANK begins. This is the simplest way of keeping the program in step
+with the television.`,
+	},
+	"RSYNC": {
+		Summary: "Resets the horizontal sync counter.",
+		Detail:  `RSYNC is intended for testing the TIA. It is rarely used by programs.`,
+	},
+	"NUSIZ0": {
+		Summary: "Sets the number and size of player 0 and missile 0.",
+		Detail: `Bits 0 to 2 select the number of copies of the player and the distance between
+them, or a double or quadruple width player. Bits 4 and 5 set the width of the
+missile.`,
+	},
+	"NUSIZ1": {
+		Summary: "Sets the number and size of player 1 and missile 1.",
+		Detail: `Bits 0 to 2 select the number of copies of the player and the distance between
+them, or a double or quadruple width player. Bits 4 and 5 set the width of the
+missile.`,
+	},
+	"COLUP0": {
+		Summary: "Sets the color of player 0 and missile 0.",
+		Detail:  `The upper four bits select the hue and bits 1 to 3 select the luminance.`,
+	},
+	"COLUP1": {
+		Summary: "Sets the color of player 1 and missile 1.",
+		Detail:  `The upper four bits select the hue and bits 1 to 3 select the luminance.`,
+	},
+	"COLUPF": {
+		Summary: "Sets the color of the playfield and the ball.",
+		Detail:  `The upper four bits select the hue and bits 1 to 3 select the luminance.`,
+	},
+	"COLUBK": {
+		Summary: "Sets the color of the background.",
+		Detail: `The upper four bits select the hue and bits 1 to 3 select the luminance. Changing
+the background color on every scanline is a simple way of drawing a rainbow.`,
+	},
+	"CTRLPF": {
+		Summary: "Controls the playfield and the ball.",
+		Detail: `Bit 0 reflects the right half of the playfield. Bit 1 colors the playfield with
+the player colors (score mode). Bit 2 draws the playfield in front of the players.
+Bits 4 and 5 set the width of the ball.`,
+	},
+	"REFP0": {
+		Summary: "Reflects player 0 horizontally (bit 3).",
+		Detail:  `Reflecting the player graphics is commonly used when a character changes direction.`,
+	},
+	"REFP1": {
+		Summary: "Reflects player 1 horizontally (bit 3).",
+		Detail:  `Reflecting the player graphics is commonly used when a character changes direction.`,
+	},
+	"PF0": {
+		Summary: "Sets the first four bits of the playfield (upper nibble).",
+		Detail: `The playfield is 20 bits wide and is repeated or reflected for the right half of
+the screen. PF0 is the leftmost four bits, drawn in reverse order.`,
+	},
+	"PF1": {
+		Summary: "Sets the middle eight bits of the playfield.",
+		Detail: `The playfield is 20 bits wide and is repeated or reflected for the right half of
+the screen. PF1 is drawn with the most significant bit first.`,
+	},
+	"PF2": {
+		Summary: "Sets the last eight bits of the playfield.",
+		Detail: `The playfield is 20 bits wide and is repeated or reflected for the right half of
+the screen. PF2 is drawn with the least significant bit first.`,
+	},
+	"RESP0": {
+		Summary: "Moves player 0 to the current horizontal position of the beam.",
+		Detail: `The value written does not matter, only the time of the write. Programs usually
+use RESP0 for coarse positioning and HMOVE for fine positioning.`,
+	},
+	"RESP1": {
+		Summary: "Moves player 1 to the current horizontal position of the beam.",
+		Detail: `The value written does not matter, only the time of the write. Programs usually
+use RESP1 for coarse positioning and HMOVE for fine positioning.`,
+	},
+	"RESM0": {
+		Summary: "Moves missile 0 to the current horizontal position of the beam.",
+		Detail:  `The value written does not matter, only the time of the write.`,
+	},
+	"RESM1": {
+		Summary: "Moves missile 1 to the current horizontal position of the beam.",
+		Detail:  `The value written does not matter, only the time of the write.`,
+	},
+	"RESBL": {
+		Summary: "Moves the ball to the current horizontal position of the beam.",
+		Detail:  `The value written does not matter, only the time of the write.`,
+	},
+	"AUDC0": {
+		Summary: "Sets the waveform of audio channel 0.",
+		Detail:  `The lower four bits select one of the sixteen tone generators.`,
+	},
+	"AUDC1": {
+		Summary: "Sets the waveform of audio channel 1.",
+		Detail:  `The lower four bits select one of the sixteen tone generators.`,
+	},
+	"AUDF0": {
+		Summary: "Sets the frequency divider of audio channel 0.",
+		Detail:  `The lower five bits divide the base frequency. Higher values give lower pitches.`,
+	},
+	"AUDF1": {
+		Summary: "Sets the frequency divider of audio channel 1.",
+		Detail:  `The lower five bits divide the base frequency. Higher values give lower pitches.`,
+	},
+	"AUDV0": {
+		Summary: "Sets the volume of audio channel 0.",
+		Detail:  `The lower four bits set the volume. A value of zero silences the channel.`,
+	},
+	"AUDV1": {
+		Summary: "Sets the volume of audio channel 1.",
+		Detail:  `The lower four bits set the volume. A value of zero silences the channel.`,
+	},
+	"GRP0": {
+		Summary: "Sets the eight pixel graphics of player 0.",
+		Detail: `The graphics are drawn on every scanline until GRP0 is changed. A kernel usually
+writes a new value to GRP0 on every scanline to draw a sprite.`,
+	},
+	"GRP1": {
+		Summary: "Sets the eight pixel graphics of player 1.",
+		Detail: `The graphics are drawn on every scanline until GRP1 is changed. A kernel usually
+writes a new value to GRP1 on every scanline to draw a sprite.`,
+	},
+	"ENAM0": {
+		Summary: "Turns missile 0 on or off (bit 1).",
+	},
+	"ENAM1": {
+		Summary: "Turns missile 1 on or off (bit 1).",
+	},
+	"ENABL": {
+		Summary: "Turns the ball on or off (bit 1).",
+	},
+	"HMP0": {
+		Summary: "Sets the fine horizontal movement of player 0.",
+		Detail: `The upper four bits are a signed value between -8 and 7. The movement happens when
+HMOVE is written to.`,
+	},
+	"HMP1": {
+		Summary: "Sets the fine horizontal movement of player 1.",
+		Detail: `The upper four bits are a signed value between -8 and 7. The movement happens when
+HMOVE is written to.`,
+	},
+	"HMM0": {
+		Summary: "Sets the fine horizontal movement of missile 0.",
+		Detail: `The upper four bits are a signed value between -8 and 7. The movement happens when
+HMOVE is written to.`,
+	},
+	"HMM1": {
+		Summary: "Sets the fine horizontal movement of missile 1.",
+		Detail: `The upper four bits are a signed value between -8 and 7. The movement happens when
+HMOVE is written to.`,
+	},
+	"HMBL": {
+		Summary: "Sets the fine horizontal movement of the ball.",
+		Detail: `The upper four bits are a signed value between -8 and 7. The movement happens when
+HMOVE is written to.`,
+	},
+	"VDELP0": {
+		Summary: "Delays the graphics of player 0 until GRP1 is written to (bit 0).",
+		Detail:  `Vertical delay is used to move a sprite by a single scanline in a two-line kernel.`,
+	},
+	"VDELP1": {
+		Summary: "Delays the graphics of player 1 until GRP0 is written to (bit 0).",
+		Detail:  `Vertical delay is used to move a sprite by a single scanline in a two-line kernel.`,
+	},
+	"VDELBL": {
+		Summary: "Delays the ball until GRP1 is written to (bit 0).",
+		Detail:  `Vertical delay is used to move the ball by a single scanline in a two-line kernel.`,
+	},
+	"RESMP0": {
+		Summary: "Locks missile 0 to the center of player 0 (bit 1).",
+		Detail:  `While locked the missile is hidden. The missile is released when the bit is cleared.`,
+	},
+	"RESMP1": {
+		Summary: "Locks missile 1 to the center of player 1 (bit 1).",
+		Detail:  `While locked the missile is hidden. The missile is released when the bit is cleared.`,
+	},
+	"HMOVE": {
+		Summary: "Applies the fine horizontal movement values to all sprites.",
+		Detail: `HMOVE should be written to immediately after a WSYNC. Doing so extends the HBLANK
+period by eight pixels, which is why many games have black lines ("HMOVE bars")
+on the left of the screen.`,
+	},
+	"HMCLR": {
+		Summary: "Clears all fine horizontal movement values.",
+	},
+	"CXCLR": {
+		Summary: "Clears all collision latches.",
+		Detail: `The collision latches stay set until they are cleared. Programs usually clear them
+once per frame after they have been read.`,
+	},
+
+	// RIOT
+	"SWCHA": {
+		Summary: "Sets the output value of the controller port pins.",
+		Detail: `Only the pins configured as outputs by SWACNT are affected. Keypad controllers are
+scanned by writing to SWCHA.`,
+	},
+	"SWACNT": {
+		Summary: "Sets the direction (input or output) of the controller port pins.",
+	},
+	"TIM1T": {
+		Summary: "Starts the timer, decrementing once every CPU cycle.",
+	},
+	"TIM8T": {
+		Summary: "Starts the timer, decrementing once every 8 CPU cycles.",
+	},
+	"TIM64T": {
+		Summary: "Starts the timer, decrementing once every 64 CPU cycles.",
+		Detail: `TIM64T is the usual way of timing the VBLANK and overscan periods. The program
+sets the timer, does its game logic and then waits for INTIM to reach zero.`,
+	},
+	"T1024T": {
+		Summary: "Starts the timer, decrementing once every 1024 CPU cycles.",
+	},
+}
@@ -0,0 +1,97 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package tutorial_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware/cpu/execution"
+	"github.com/jetsetilly/gopher2600/hardware/cpu/instructions"
+	"github.com/jetsetilly/gopher2600/hardware/memory/addresses"
+	"github.com/jetsetilly/gopher2600/test"
+	"github.com/jetsetilly/gopher2600/tutorial"
+)
+
+func TestRegisters(t *testing.T) {
+	for _, n := range addresses.TIAWriteSymbols {
+		if _, ok := tutorial.Register(n); !ok {
+			t.Errorf("no explanation for TIA register %s", n)
+		}
+	}
+	for _, n := range addresses.RIOTWriteSymbols {
+		if _, ok := tutorial.Register(n); !ok {
+			t.Errorf("no explanation for RIOT register %s", n)
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	defns := instructions.GetDefinitions()
+
+	// STA zero page
+	res := execution.Result{Defn: defns[0x85], InstructionData: 0x02, Final: true}
+	n, ok := tutorial.Annotate(res)
+	test.ExpectedSuccess(t, ok)
+	test.Equate(t, n.Title, "WSYNC")
+
+	// STA absolute to a mirror of the RIOT
+	res = execution.Result{Defn: defns[0x8d], InstructionData: 0x0296, Final: true}
+	n, ok = tutorial.Annotate(res)
+	test.ExpectedSuccess(t, ok)
+	test.Equate(t, n.Title, "TIM64T")
+
+	// STA to RAM
+	res = execution.Result{Defn: defns[0x85], InstructionData: 0x80, Final: true}
+	_, ok = tutorial.Annotate(res)
+	test.ExpectedFailure(t, ok)
+
+	// LDA is not a write
+	res = execution.Result{Defn: defns[0xa5], InstructionData: 0x02, Final: true}
+	_, ok = tutorial.Annotate(res)
+	test.ExpectedFailure(t, ok)
+
+	// STA zero page, X cannot be annotated
+	res = execution.Result{Defn: defns[0x95], InstructionData: 0x02, Final: true}
+	_, ok = tutorial.Annotate(res)
+	test.ExpectedFailure(t, ok)
+}
+
+func TestBands(t *testing.T) {
+	var lines []tutorial.Line
+	add := func(n int, l tutorial.Line) {
+		for i := 0; i < n; i++ {
+			lines = append(lines, l)
+		}
+	}
+
+	add(3, tutorial.Line{VSync: true, VBlank: true})
+	add(37, tutorial.Line{VBlank: true})
+	add(192, tutorial.Line{})
+	add(30, tutorial.Line{VBlank: true})
+
+	b := tutorial.Bands(lines)
+	test.Equate(t, len(b), 4)
+	test.Equate(t, b[0].Part.String(), "VSYNC")
+	test.Equate(t, b[0].Bottom, 2)
+	test.Equate(t, b[1].Part.String(), "VBLANK")
+	test.Equate(t, b[1].Top, 3)
+	test.Equate(t, b[1].Bottom, 39)
+	test.Equate(t, b[2].Part.String(), "Visible")
+	test.Equate(t, b[2].Top, 40)
+	test.Equate(t, b[2].Bottom, 231)
+	test.Equate(t, b[3].Part.String(), "Overscan")
+	test.Equate(t, b[3].Bottom, 261)
+}
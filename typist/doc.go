@@ -0,0 +1,45 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package typist converts text into key presses on the keypad (keyboard)
+// controllers. This makes entering text into ROMs that use keypads, for
+// example BASIC Programming, practical.
+//
+// Each character is mapped to one or more steps. A step is one or more keys
+// that are pressed at the same time; at most one key for each keypad. Each
+// step is held for a number of frames and then released for a number of
+// frames so that the ROM has time to register the key press.
+//
+// The default KeyMap maps the characters on the keypads to the left keypad.
+// Other KeyMaps can be read from a file with ReadKeyMap(). The format of the
+// file is one character per line. The first field is the character and the
+// remaining fields are the steps required to input the character. A step is a
+// key on the left (L) or right (R) keypad, and simultaneous key presses are
+// joined with a plus sign. For example:
+//
+//	A       L1
+//	B       L1 L2
+//	C       L1+R3
+//	SPACE   R0
+//
+// In the example, the character A is input by pressing the 1 key on the left
+// keypad; B by pressing 1 and then 2 on the left keypad; and C by pressing 1
+// on the left keypad and 3 on the right keypad at the same time. The
+// characters SPACE and NEWLINE must be spelled out. Lines beginning with a
+// semi-colon are comments.
+//
+// The Typist type implements the television.FrameTrigger interface and should
+// be added to the television with AddFrameTrigger().
+package typist
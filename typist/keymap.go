@@ -0,0 +1,146 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package typist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+)
+
+// Key is a single key on one of the keypads.
+type Key struct {
+	ID  ports.PortID
+	Key rune
+}
+
+func (k Key) String() string {
+	switch k.ID {
+	case ports.Player0ID:
+		return fmt.Sprintf("L%c", k.Key)
+	case ports.Player1ID:
+		return fmt.Sprintf("R%c", k.Key)
+	}
+	return fmt.Sprintf("?%c", k.Key)
+}
+
+// Step is one or more keys that are pressed at the same time.
+type Step []Key
+
+// KeyMap maps characters to the steps required to input them.
+type KeyMap map[rune][]Step
+
+// DefaultKeyMap maps the characters printed on the keypad to the keys on the
+// left keypad.
+var DefaultKeyMap = KeyMap{}
+
+func init() {
+	for _, r := range "0123456789*#" {
+		DefaultKeyMap[r] = []Step{{{ID: ports.Player0ID, Key: r}}}
+	}
+}
+
+// the names of characters that can not be written in a key map file.
+var keyNames = map[string]rune{
+	"SPACE":   ' ',
+	"NEWLINE": '\n',
+}
+
+// parseKey parses a key in the L1 or R# form.
+func parseKey(s string) (Key, error) {
+	var k Key
+
+	if len(s) != 2 {
+		return k, curated.Errorf("typist: illegal key (%s)", s)
+	}
+
+	switch s[0] {
+	case 'L', 'l':
+		k.ID = ports.Player0ID
+	case 'R', 'r':
+		k.ID = ports.Player1ID
+	default:
+		return k, curated.Errorf("typist: illegal keypad (%s)", s)
+	}
+
+	k.Key = rune(s[1])
+	if !strings.ContainsRune("0123456789*#", k.Key) {
+		return k, curated.Errorf("typist: illegal key (%s)", s)
+	}
+
+	return k, nil
+}
+
+// ReadKeyMap reads a KeyMap in the format described in the package
+// documentation.
+func ReadKeyMap(r io.Reader) (KeyMap, error) {
+	km := KeyMap{}
+
+	scanner := bufio.NewScanner(r)
+	ln := 0
+	for scanner.Scan() {
+		ln++
+
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" || strings.HasPrefix(s, ";") {
+			continue // for loop
+		}
+
+		flds := strings.Fields(s)
+		if len(flds) < 2 {
+			return nil, curated.Errorf("typist: line %d: no steps for character", ln)
+		}
+
+		c, ok := keyNames[strings.ToUpper(flds[0])]
+		if !ok {
+			if utf8.RuneCountInString(flds[0]) != 1 {
+				return nil, curated.Errorf("typist: line %d: illegal character (%s)", ln, flds[0])
+			}
+			c, _ = utf8.DecodeRuneInString(flds[0])
+		}
+
+		var steps []Step
+		for _, f := range flds[1:] {
+			var step Step
+			for _, k := range strings.Split(f, "+") {
+				key, err := parseKey(k)
+				if err != nil {
+					return nil, curated.Errorf("line %d: %v", ln, err)
+				}
+				for _, o := range step {
+					if o.ID == key.ID {
+						return nil, curated.Errorf("typist: line %d: more than one key on the same keypad (%s)", ln, f)
+					}
+				}
+				step = append(step, key)
+			}
+			steps = append(steps, step)
+		}
+
+		km[c] = steps
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, curated.Errorf("typist: %v", err)
+	}
+
+	return km, nil
+}
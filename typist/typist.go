@@ -0,0 +1,137 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package typist
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+)
+
+// default number of frames that a key is held down and released for.
+const (
+	DefaultHoldFrames    = 4
+	DefaultReleaseFrames = 4
+)
+
+// Typist sends key presses to the keypads according to the text given to
+// Type().
+type Typist struct {
+	vcs    *hardware.VCS
+	keyMap KeyMap
+
+	// the number of frames that each step is held down and released for
+	HoldFrames    int
+	ReleaseFrames int
+
+	// steps waiting to be input
+	queue []Step
+
+	// the step currently being held down. nil if no step is being held
+	current Step
+
+	// frames remaining until the next change of state
+	count int
+}
+
+// NewTypist is the preferred method of initialisation for the Typist type.
+func NewTypist(vcs *hardware.VCS) *Typist {
+	return &Typist{
+		vcs:           vcs,
+		keyMap:        DefaultKeyMap,
+		HoldFrames:    DefaultHoldFrames,
+		ReleaseFrames: DefaultReleaseFrames,
+	}
+}
+
+// SetKeyMap changes the KeyMap used to translate characters to key presses.
+func (t *Typist) SetKeyMap(km KeyMap) {
+	t.keyMap = km
+}
+
+// Type adds the text to the queue of characters to be input. Returns an error
+// if any of the characters is not in the current KeyMap, in which case none of
+// the text will be input.
+func (t *Typist) Type(text string) error {
+	var steps []Step
+	for _, c := range text {
+		s, ok := t.keyMap[c]
+		if !ok {
+			return curated.Errorf("typist: no keys for character (%q)", c)
+		}
+		steps = append(steps, s...)
+	}
+	t.queue = append(t.queue, steps...)
+	return nil
+}
+
+// Pending returns the number of steps waiting to be input.
+func (t *Typist) Pending() int {
+	return len(t.queue)
+}
+
+// Clear the queue. Any key currently being held is released.
+func (t *Typist) Clear() error {
+	t.queue = t.queue[:0]
+	t.count = 0
+	return t.release()
+}
+
+func (t *Typist) press(step Step) error {
+	for _, k := range step {
+		err := t.vcs.RIOT.Ports.HandleEvent(k.ID, ports.KeyboardDown, k.Key)
+		if err != nil {
+			return curated.Errorf("typist: %v", err)
+		}
+	}
+	t.current = step
+	return nil
+}
+
+func (t *Typist) release() error {
+	for _, k := range t.current {
+		err := t.vcs.RIOT.Ports.HandleEvent(k.ID, ports.KeyboardUp, nil)
+		if err != nil {
+			return curated.Errorf("typist: %v", err)
+		}
+	}
+	t.current = nil
+	return nil
+}
+
+// NewFrame implements the television.FrameTrigger interface.
+func (t *Typist) NewFrame(_ television.FrameInfo) error {
+	if t.count > 0 {
+		t.count--
+		return nil
+	}
+
+	if t.current != nil {
+		t.count = t.ReleaseFrames
+		return t.release()
+	}
+
+	if len(t.queue) == 0 {
+		return nil
+	}
+
+	step := t.queue[0]
+	t.queue = t.queue[1:]
+	t.count = t.HoldFrames
+
+	return t.press(step)
+}
@@ -0,0 +1,132 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package typist_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/hardware"
+	"github.com/jetsetilly/gopher2600/hardware/riot/ports"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/typist"
+)
+
+// recorder implements the ports.EventRecorder interface. events are recorded
+// with the frame on which they happened.
+type recorder struct {
+	frame  int
+	events []string
+}
+
+func (r *recorder) RecordEvent(id ports.PortID, ev ports.Event, data ports.EventData) error {
+	switch ev {
+	case ports.KeyboardDown:
+		r.events = append(r.events, fmt.Sprintf("%d:%d down %c", r.frame, id, data))
+	case ports.KeyboardUp:
+		r.events = append(r.events, fmt.Sprintf("%d:%d up", r.frame, id))
+	}
+	return nil
+}
+
+func TestTypist(t *testing.T) {
+	tv, err := television.NewTelevision("NTSC")
+	if err != nil {
+		t.Fatalf("unexpected error creating television: %v", err)
+	}
+
+	vcs, err := hardware.NewVCS(tv)
+	if err != nil {
+		t.Fatalf("unexpected error creating VCS: %v", err)
+	}
+
+	rec := &recorder{}
+	vcs.RIOT.Ports.AttachEventRecorder(rec)
+
+	tp := typist.NewTypist(vcs)
+	tp.HoldFrames = 2
+	tp.ReleaseFrames = 1
+
+	err = tp.Type("A")
+	if err == nil {
+		t.Errorf("expected error for character not in keymap")
+	}
+	if tp.Pending() != 0 {
+		t.Errorf("unexpected pending key presses after error: %d", tp.Pending())
+	}
+
+	err = tp.Type("1#")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tp.Pending() != 2 {
+		t.Errorf("expected 2 pending key presses, got %d", tp.Pending())
+	}
+
+	for rec.frame = 0; rec.frame < 12; rec.frame++ {
+		err = tp.NewFrame(television.FrameInfo{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := strings.Join(rec.events, ", ")
+	expected := fmt.Sprintf("0:%[1]d down 1, 3:%[1]d up, 5:%[1]d down #, 8:%[1]d up", ports.Player0ID)
+	if got != expected {
+		t.Errorf("unexpected events\n got: %s\nwant: %s", got, expected)
+	}
+
+	if tp.Pending() != 0 {
+		t.Errorf("unexpected pending key presses: %d", tp.Pending())
+	}
+}
+
+func TestReadKeyMap(t *testing.T) {
+	km, err := typist.ReadKeyMap(strings.NewReader(`
+; comment
+A       L1
+B       L1 L2
+C       L1+R3
+SPACE   R0
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(km) != 4 {
+		t.Fatalf("expected 4 characters in keymap, got %d", len(km))
+	}
+
+	if len(km['B']) != 2 {
+		t.Errorf("expected 2 steps for B, got %d", len(km['B']))
+	}
+
+	if len(km['C']) != 1 || len(km['C'][0]) != 2 {
+		t.Errorf("expected 1 step of 2 keys for C, got %v", km['C'])
+	}
+
+	if km[' '][0][0] != (typist.Key{ID: ports.Player1ID, Key: '0'}) {
+		t.Errorf("unexpected key for SPACE: %v", km[' '])
+	}
+
+	for _, bad := range []string{"A", "A X1", "A L1+L2", "AB L1", "A LA"} {
+		_, err = typist.ReadKeyMap(strings.NewReader(bad))
+		if err == nil {
+			t.Errorf("expected error for keymap line %q", bad)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package version records the version of the emulator and the versions of
+// the file formats that it writes.
+//
+// Packages that write files to disk, which may later be read by a different
+// version of the emulator, should write the version of the format to the
+// file. When reading the file, the version should be passed to the Check()
+// function of the format. Files created by a newer version of the emulator
+// will then fail with a clear message rather than being mis-loaded.
+//
+// The version of the emulator itself is for information only. It can be set
+// at build time with the linker:
+//
+//	go build -ldflags "-X github.com/jetsetilly/gopher2600/version.Version=v0.10"
+package version
@@ -0,0 +1,103 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jetsetilly/gopher2600/curated"
+)
+
+// Version is the version of the emulator. It is set at build time. See
+// package documentation.
+var Version = "unreleased"
+
+// Format describes a file format written by the emulator. The version of the
+// format is in two parts. The major version should be increased when a change
+// means that older versions of the emulator can no longer read the file. The
+// minor version should be increased for other changes.
+type Format struct {
+	Name  string
+	Major int
+	Minor int
+}
+
+func (f Format) String() string {
+	return fmt.Sprintf("%d.%d", f.Major, f.Minor)
+}
+
+// The file formats written by the emulator.
+var (
+	// playback files written by the recorder package
+	Playback = Format{Name: "playback", Major: 1, Minor: 0}
+
+	// debugger session files
+	Session = Format{Name: "session", Major: 1, Minor: 0}
+
+	// database files, including the regression database
+	Database = Format{Name: "database", Major: 1, Minor: 0}
+
+	// execution traces written by the cputrace package
+	CPUTrace = Format{Name: "cputrace", Major: 1, Minor: 0}
+)
+
+// Capabilities returns the list of file formats, and the versions, that can
+// be read by this version of the emulator.
+func Capabilities() []Format {
+	return []Format{Playback, Session, Database, CPUTrace}
+}
+
+// Check returns an error if a file written with the specified version of the
+// format cannot be read. This will be because the file was created by a
+// version of the emulator with a newer major version of the format or because
+// the version string is not valid. A newer minor version is accepted.
+func (f Format) Check(version string) error {
+	major, _, err := parse(version)
+	if err != nil {
+		return curated.Errorf("version: invalid %s version (%s)", f.Name, version)
+	}
+
+	if major > f.Major {
+		return curated.Errorf("version: %s file is version %s and was created by a newer version of the emulator (this version supports %s)",
+			f.Name, version, f)
+	}
+
+	return nil
+}
+
+// parse a version string of the form "major.minor". the minor part is
+// optional.
+func parse(version string) (int, int, error) {
+	p := strings.SplitN(strings.TrimSpace(version), ".", 2)
+
+	major, err := strconv.Atoi(p[0])
+	if err != nil || major < 0 {
+		return 0, 0, fmt.Errorf("invalid major version")
+	}
+
+	if len(p) == 1 {
+		return major, 0, nil
+	}
+
+	minor, err := strconv.Atoi(p[1])
+	if err != nil || minor < 0 {
+		return 0, 0, fmt.Errorf("invalid minor version")
+	}
+
+	return major, minor, nil
+}
@@ -0,0 +1,54 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package version_test
+
+import (
+	"testing"
+
+	"github.com/jetsetilly/gopher2600/test"
+	"github.com/jetsetilly/gopher2600/version"
+)
+
+func TestCheck(t *testing.T) {
+	f := version.Format{Name: "test", Major: 2, Minor: 1}
+	test.Equate(t, f.String(), "2.1")
+
+	// same and older versions
+	test.ExpectedSuccess(t, f.Check("2.1"))
+	test.ExpectedSuccess(t, f.Check("2.0"))
+	test.ExpectedSuccess(t, f.Check("2"))
+	test.ExpectedSuccess(t, f.Check("1.9"))
+
+	// newer minor versions can still be read
+	test.ExpectedSuccess(t, f.Check("2.2"))
+	test.ExpectedSuccess(t, f.Check("2.10"))
+
+	// newer major versions
+	test.ExpectedFailure(t, f.Check("3.0"))
+	test.ExpectedFailure(t, f.Check("3"))
+
+	// invalid versions
+	test.ExpectedFailure(t, f.Check(""))
+	test.ExpectedFailure(t, f.Check("two"))
+	test.ExpectedFailure(t, f.Check("2.x"))
+	test.ExpectedFailure(t, f.Check("-1.0"))
+}
+
+func TestCapabilities(t *testing.T) {
+	for _, f := range version.Capabilities() {
+		test.ExpectedSuccess(t, f.Check(f.String()))
+	}
+}
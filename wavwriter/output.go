@@ -0,0 +1,39 @@
+// This file is part of Gopher2600.
+//
+// Gopher2600 is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Gopher2600 is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Gopher2600.  If not, see <https://www.gnu.org/licenses/>.
+
+package wavwriter
+
+import (
+	"github.com/jetsetilly/gopher2600/curated"
+	"github.com/jetsetilly/gopher2600/hardware/television"
+	"github.com/jetsetilly/gopher2600/outputs"
+)
+
+func init() {
+	err := outputs.Register("WAV", "record audio to WAV file. option is the filename", func(tv *television.Television, options string) error {
+		if options == "" {
+			return curated.Errorf("wavwriter: filename required")
+		}
+		aw, err := New(options)
+		if err != nil {
+			return err
+		}
+		tv.AddAudioMixer(aw)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}